@@ -1,13 +1,26 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"net/http/pprof"
 	"os"
+	"os/signal"
+	"political-network-api/internal/config"
 	"political-network-api/internal/database"
+	"political-network-api/internal/demo"
+	"political-network-api/internal/grpcapi"
 	"political-network-api/internal/handlers"
+	"political-network-api/internal/jobs"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"political-network-api/internal/search"
+	"political-network-api/internal/server"
 	"political-network-api/internal/utils"
+	"political-network-api/internal/web"
+	"syscall"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
@@ -18,84 +31,444 @@ func main() {
 		log.Println("⚠️ No .env file found, using environment variables")
 	}
 
-	// Initialize database
-	if err := database.Initialize(); err != nil {
-		log.Fatalf("❌ Failed to initialize database: %v", err)
+	// Load configuration (config file + environment overrides)
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("❌ Invalid configuration: %v", err)
+	}
+
+	// Initialize database: Postgres, a seeded SQLite file for local development and demos, or
+	// none in demo mode, which serves the bundled dataset from memory. The last two serve the
+	// core read endpoints only.
+	postgres := cfg.Database.Driver != "sqlite" && !cfg.Server.Demo
+	var repos handlers.Repositories
+	switch {
+	case cfg.Server.Demo:
+		data, err := demo.Load()
+		if err != nil {
+			log.Fatalf("❌ Failed to load demo dataset: %v", err)
+		}
+		database.OpenNone()
+		repos = handlers.DemoRepositories(data)
+		log.Println("🎭 Demo mode: serving the bundled synthetic dataset without a database")
+	case postgres:
+		if err := database.Initialize(cfg.Database); err != nil {
+			log.Fatalf("❌ Failed to initialize database: %v", err)
+		}
+		repos = handlers.PostgresRepositories()
+	default:
+		if err := database.OpenSQLite(cfg.Database); err != nil {
+			log.Fatalf("❌ Failed to open SQLite database: %v", err)
+		}
+		repos = handlers.SQLiteRepositories()
 	}
 	defer database.Close()
 
+	// `political-network-api verify` runs the data integrity checks and exits, with status 1 when
+	// one fails
+	if len(os.Args) > 1 && os.Args[1] == "verify" {
+		if cfg.Server.Demo {
+			log.Fatal("❌ verify checks a database; demo mode has none")
+		}
+		passed := handlers.VerifyData().Passed
+		database.Close()
+		if !passed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Handlers read through repositories
+	handlers.UseRepositories(repos)
+
+	// Integrity checks in the background, reported at /api/admin/data-quality
+	if cfg.Jobs.VerifyOnStartup && !cfg.Server.Demo {
+		go handlers.VerifyData()
+	}
+
 	// Initialize cache
 	utils.InitializeCache()
+	handlers.RestoreCacheSnapshot()
+	if cfg.Cache.Warmup {
+		handlers.WarmCache()
+	}
 
-	// Setup Gin
-	if os.Getenv("GIN_MODE") == "release" {
-		gin.SetMode(gin.ReleaseMode)
+	// Background jobs, search and user sessions work on the Postgres tables
+	if postgres {
+		// Replicas drop cache entries together: flushes and purges are announced over NOTIFY
+		jobs.StartCacheInvalidation(cfg.Cache)
+
+		// Expire sanctions whose end date has passed
+		jobs.StartSanctionExpiry(cfg.Jobs.SanctionExpiryInterval)
+
+		// Full-text index for /api/search, kept in sync with the rows the ETL pipeline changes
+		search.Open(cfg.Search)
+		defer search.Close()
+		jobs.StartSearchSync(cfg.Search.SyncInterval)
+
+		// Detect change events, deliver them to registered webhooks and alert watchlists
+		jobs.StartWebhooks(cfg.Jobs.WebhookInterval)
+
+		// Weekly watchlist digests by email
+		jobs.StartDigests(cfg.Jobs.DigestInterval)
+
+		// Workers for ETL, score recomputation, export and layout jobs queued through /api/admin/jobs
+		handlers.RegisterJobs()
+		jobs.StartQueue(cfg.Jobs)
+
+		// User sessions are looked up in the database
+		middleware.SetSessionResolver(database.GetSessionUser)
+
+		// Overrides outlast ETL runs made from a shell while the API was down
+		if changed, err := database.ApplyOverrides(); err != nil {
+			log.Printf("⚠️ Overrides not applied: %v", err)
+		} else if changed > 0 {
+			log.Printf("✏️ Applied overrides to %d rows", changed)
+			utils.FlushCache()
+		}
+
+		// Politicians loaded while the API was down get their public IDs and slugs
+		if assigned, err := database.AssignPublicIDs(); err != nil {
+			log.Printf("⚠️ Public IDs not assigned: %v", err)
+		} else if assigned > 0 {
+			log.Printf("🔗 Assigned public IDs to %d politicians", assigned)
+		}
 	}
 
+	// Load API keys for authenticated roles
+	middleware.LoadAPIKeys(cfg.Auth.APIKeys)
+
+	// Reload non-structural configuration (TTLs, CORS, API keys, network limits) on SIGHUP
+	config.OnReload(handlers.ApplyConfigReload)
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			if _, err := config.Reload(); err != nil {
+				log.Printf("❌ Configuration reload failed, keeping current settings: %v", err)
+			}
+		}
+	}()
+
+	// SIGINT and SIGTERM stop the server gracefully; main then returns, closing the database and
+	// search index. A second signal kills the process as usual.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+
+	// Setup Gin
+	gin.SetMode(cfg.Server.GinMode)
+
 	router := gin.New()
 
+	// Client IPs, for rate limits and logs, trust X-Forwarded-For from these peers only
+	if err := router.SetTrustedProxies(cfg.RateLimit.TrustedProxies); err != nil {
+		log.Fatalf("❌ Invalid trusted proxies: %v", err)
+	}
+
 	// Middleware
 	router.Use(gin.Logger())
-	router.Use(gin.Recovery())
+	// Panics answer 500 with an error_id, reported to the error tracker (reporting.dsn)
+	router.Use(middleware.Recovery())
 
-	// CORS configuration for frontend
-	config := cors.DefaultConfig()
-	config.AllowOrigins = []string{
-		"http://localhost:3000",
-		"http://127.0.0.1:3000",
-		"https://open-data-gov.vercel.app", // Add your production domain
-	}
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
-	config.ExposeHeaders = []string{"Content-Length"}
-	config.AllowCredentials = true
+	// CORS for the frontend origins in configuration
+	router.Use(middleware.CORS())
+
+	// Per-IP token buckets, before any work is done for a request
+	router.Use(middleware.IPLimit())
 
-	router.Use(cors.New(config))
+	// gzip/brotli for JSON, feeds, exports and streams above compression.min_size
+	router.Use(middleware.Compress())
+	router.Use(middleware.Usage())
+	router.Use(middleware.Authenticate())
+	router.Use(middleware.BodyLimit())
+
+	// On SQLite and in demo mode, the routes they cannot serve answer 501
+	switch {
+	case cfg.Server.Demo:
+		router.Use(handlers.DemoRoutesOnly())
+	case !postgres:
+		router.Use(handlers.SQLiteRoutesOnly())
+	}
 
 	// Health check endpoint
 	router.GET("/health", handlers.HealthCheck)
 
 	// API routes
-	api := router.Group("/api")
+	api := router.Group("/api", middleware.RateLimit())
 	{
 		// Core data endpoints
 		api.GET("/politicians", handlers.GetPoliticians)
+		api.GET("/politicians/:id", handlers.GetPolitician)
+		api.GET("/politicians/:id/topics", handlers.GetPoliticianTopics)
+		api.GET("/politicians/:id/assets", handlers.GetPoliticianAssets)
+		api.GET("/politicians/:id/top-vendors", handlers.GetPoliticianTopVendors)
 		api.GET("/parties", handlers.GetParties)
+		api.GET("/parties/:id", handlers.GetParty)
 		api.GET("/companies", handlers.GetCompanies)
+		api.GET("/companies/groups", handlers.GetCompanyGroups)
+		api.GET("/companies/groups/:root", handlers.GetCompanyGroup)
+		api.GET("/companies/:cnpj", handlers.GetCompany)
+		api.GET("/companies/:cnpj/top-politicians", handlers.GetCompanyTopPoliticians)
+		api.GET("/fronts", handlers.GetFronts)
+		api.GET("/fronts/:id", handlers.GetFront)
 		api.GET("/sanctions", handlers.GetSanctions)
+		api.GET("/sanctions/:id", handlers.GetSanction)
+		api.GET("/tcu/rulings", handlers.GetTCURulings)
+		api.GET("/tcu/rulings/:id", handlers.GetTCURuling)
+		api.GET("/expenses", handlers.GetExpenses)
 		api.GET("/connections", handlers.GetConnections)
 
+		// Source system, source record ID and fetch time behind a resource, for citations
+		api.GET("/provenance", handlers.GetProvenance)
+		api.GET("/dataset-versions", handlers.GetDatasetVersions)
+		api.GET("/changes", handlers.GetChanges)
+
+		// Downloadable dossiers for journalists, as JSON, HTML or PDF
+		api.GET("/reports/politician/:id", handlers.GetPoliticianReport)
+
+		// English and Portuguese labels for the field names, following Accept-Language
+		api.GET("/meta/labels", handlers.GetLabels)
+		// Filter values with counts, for dropdowns
+		api.GET("/meta/enums", handlers.GetEnums)
+
+		// Fuzzy, accent-insensitive name search over the full-text index
+		api.GET("/search", handlers.GetSearch)
+		api.GET("/search/suggest", handlers.GetSearchSuggestions)
+
 		// Complete network data for 3D visualization
 		api.GET("/network", handlers.GetNetworkData)
+		api.GET("/network/export", handlers.ExportNetwork)
+		api.POST("/network/rebuild", middleware.RequireRole(models.RoleResearcher, models.RoleAdmin), handlers.RebuildNetwork)
+
+		// Analyses over the historical data
+		api.GET("/analysis/party-switches", handlers.GetPartySwitches)
+		api.GET("/analysis/nepotism", handlers.GetNepotism)
+		api.GET("/analysis/seasonality", handlers.GetSeasonality)
+		api.GET("/analysis/vendor-clusters", handlers.GetVendorClusters)
+		api.GET("/analysis/flows", handlers.GetFlows)
+		api.GET("/topics", handlers.GetTopics)
+		api.GET("/anomalies", middleware.RequireFlag("anomalies"), handlers.GetAnomalies)
+
+		// Spending per state/municipality as GeoJSON for choropleth maps
+		api.GET("/geo/spending", handlers.GetGeoSpending)
+
+		// Resized, cached politician photos and party logos
+		api.GET("/images/:entity/:id", handlers.GetImage)
 
 		// Statistics and monitoring
 		api.GET("/stats", handlers.GetStats)
+		api.GET("/stats/by-sector", handlers.GetSectorStats)
+		api.GET("/stats/sanctions/by-source", handlers.GetSanctionSourceStats)
+
+		// Bulk dataset archives
+		api.GET("/export/full", handlers.ExportFull)
+		api.GET("/export/files/:name", handlers.DownloadExport)
+		api.GET("/export/parquet/:dataset", handlers.ExportParquet)
 
-		// Cache management
-		api.POST("/cache/clear", handlers.ClearCache)
+		// Progress of background builds, as JSON or a Server-Sent Events stream
+		api.GET("/progress/:id", handlers.GetProgress)
+		api.GET("/progress/:id/events", handlers.GetProgressEvents)
+
+		// Newline-delimited JSON streams of full tables
+		api.GET("/stream/:entity", handlers.StreamEntities)
+
+		// Feature flags on for the caller; experimental endpoints check theirs with RequireFlag
+		api.GET("/flags", handlers.GetFlags)
+
+		// Several GET requests in one round trip, run concurrently
+		api.POST("/batch", handlers.Batch(router))
 	}
 
-	// Static file serving for frontend (optional)
-	router.Static("/static", "./static")
+	// Atom feeds for journalists, refreshed as the ETL pipeline loads new data
+	router.GET("/feeds/sanctions.atom", handlers.GetSanctionFeed)
+	router.GET("/feeds/alerts.atom", handlers.GetAlertFeed)
 
-	// Start server
-	port := os.Getenv("SERVER_PORT")
-	if port == "" {
-		port = "8080"
+	// User sign-in by emailed magic link
+	auth := api.Group("/auth")
+	{
+		auth.POST("/magic-link", handlers.RequestMagicLink)
+		auth.GET("/verify", handlers.VerifyMagicLink)
+		auth.GET("/me", middleware.RequireUser(), handlers.GetCurrentUser)
+		auth.POST("/logout", middleware.RequireUser(), handlers.Logout)
 	}
 
-	host := os.Getenv("SERVER_HOST")
-	if host == "" {
-		host = "0.0.0.0"
+	// Saved network views, shareable by ID
+	api.GET("/views/:id", handlers.GetSavedView)
+	views := api.Group("/views", middleware.RequireUser())
+	{
+		views.POST("", handlers.CreateSavedView)
+		views.GET("", handlers.GetSavedViews)
+		views.PUT("/:id", handlers.UpdateSavedView)
+		views.DELETE("/:id", handlers.DeleteSavedView)
 	}
 
-	serverAddr := host + ":" + port
+	// Short-lived snapshots of a filtered subgraph, with OpenGraph pages so links unfurl
+	api.POST("/share", handlers.CreateShare)
+	api.GET("/share/:id", handlers.GetShare)
+	router.GET("/share/:id", handlers.SharePage)
+	router.GET("/share/:id/image.png", handlers.ShareImage)
+
+	// Reports of wrong data from anyone, queued for moderation under /api/admin/corrections
+	api.POST("/corrections", handlers.CreateCorrection)
+
+	// Researchers' tags and notes on politicians and companies, private, shared with the team or
+	// public; ?tag= filters /api/politicians, /api/companies and /api/network by them
+	api.GET("/annotations", handlers.GetAnnotations)
+	api.GET("/tags", handlers.GetTags)
+	annotations := api.Group("/annotations", middleware.RequireRole(models.RoleResearcher, models.RoleAdmin))
+	{
+		annotations.POST("", handlers.CreateAnnotation)
+		annotations.PATCH("/:id", handlers.UpdateAnnotation)
+		annotations.DELETE("/:id", handlers.DeleteAnnotation)
+	}
+
+	// Webhook subscriptions for authenticated consumers, scoped to the API key that created them
+	webhooks := api.Group("/webhooks", middleware.RequireRole(models.RoleResearcher, models.RoleAdmin))
+	{
+		webhooks.POST("", handlers.CreateWebhook)
+		webhooks.GET("", handlers.GetWebhooks)
+		webhooks.DELETE("/:id", handlers.DeleteWebhook)
+		webhooks.GET("/:id/deliveries", handlers.GetWebhookDeliveries)
+	}
+
+	// Watchlists of followed politicians and companies, alerting through webhooks or email
+	watchlists := api.Group("/watchlists", middleware.RequireRole(models.RoleResearcher, models.RoleAdmin))
+	{
+		watchlists.POST("", handlers.CreateWatchlist)
+		watchlists.GET("", handlers.GetWatchlists)
+		watchlists.GET("/:id", handlers.GetWatchlist)
+		watchlists.DELETE("/:id", handlers.DeleteWatchlist)
+		watchlists.POST("/:id/items", handlers.AddWatchlistItems)
+		watchlists.DELETE("/:id/items/:type/:entity", handlers.RemoveWatchlistItem)
+		watchlists.GET("/:id/alerts", handlers.GetWatchlistAlerts)
+		watchlists.POST("/:id/digests", handlers.SubscribeDigest)
+		watchlists.GET("/:id/digests", handlers.GetDigests)
+		watchlists.DELETE("/:id/digests/:digest", handlers.DeleteDigest)
+	}
+
+	// Unsubscribe links of the weekly digests, which work without signing in
+	api.GET("/digests/unsubscribe", handlers.UnsubscribeDigestPage)
+	api.POST("/digests/unsubscribe", handlers.UnsubscribeDigest)
+
+	// Family relations between politicians: heuristic suggestions from populate-family are
+	// confirmed or rejected here, and confirmed ones become family edges in the network
+	relations := api.Group("/relations", middleware.RequireRole(models.RoleResearcher, models.RoleAdmin))
+	{
+		relations.GET("", handlers.GetFamilyRelations)
+		relations.POST("", handlers.CreateFamilyRelation)
+		relations.PATCH("/:id", handlers.ReviewFamilyRelation)
+	}
+
+	// Administrative routes
+	admin := api.Group("/admin", middleware.RequireRole(models.RoleAdmin))
+	{
+		// Full graph dump for Neo4j (pipe into cypher-shell)
+		admin.GET("/export/neo4j", handlers.ExportNeo4j)
+
+		// Audit trail of cache clears, ETL runs, score recomputes and PII access
+		admin.GET("/audit", handlers.GetAuditLog)
+
+		// Cache inspection, single-key purge and a flush of every replica's cache
+		admin.GET("/cache", handlers.GetCacheInfo)
+		admin.DELETE("/cache", handlers.PurgeCacheKey)
+		admin.POST("/cache/clear", handlers.ClearCache)
+
+		// Request counts, latencies and top consumers per route
+		admin.GET("/usage", handlers.GetUsage)
+
+		// Per-query call counts and latencies, to find slow database queries
+		admin.GET("/queries", handlers.GetQueryStats)
+
+		// Effective configuration with secrets redacted
+		admin.GET("/config", handlers.GetConfig)
+
+		// Goroutines, heap and GC statistics of the process
+		admin.GET("/runtime", handlers.GetRuntimeStats)
+
+		// Integrity checks of the core tables (?refresh=true to run them again)
+		admin.GET("/data-quality", handlers.GetDataQuality)
+
+		// Full search index rebuild, dropping entities deleted from the database
+		admin.POST("/search/reindex", handlers.ReindexSearch)
+
+		// Moderation of submitted corrections; accepted ones become overrides
+		admin.GET("/corrections", handlers.GetCorrections)
+		admin.GET("/corrections/:id", handlers.GetCorrection)
+		admin.PATCH("/corrections/:id", handlers.ReviewCorrection)
+
+		// Curated field values set on top of the ingested data, again after every ETL run
+		admin.GET("/overrides", handlers.GetOverrides)
+		admin.POST("/overrides", handlers.CreateOverride)
+		admin.POST("/overrides/apply", handlers.ApplyOverrides)
+		admin.DELETE("/overrides/:id", handlers.DeleteOverride)
+
+		// Cross-source entity resolution: resolve-entities confirms document matches and queues
+		// the rest here for review
+		admin.GET("/entity-links", handlers.GetEntityLinks)
+		admin.PATCH("/entity-links/:id", handlers.ReviewEntityLink)
+
+		// Bulk upserts for external ETL scripts, instead of writing to the database directly
+		admin.POST("/ingest/:entity", handlers.IngestEntity)
+
+		// Job queue: status, progress and durations, with retry and cancel controls
+		admin.GET("/jobs", handlers.GetJobs)
+		admin.POST("/jobs", handlers.CreateJob)
+		admin.GET("/jobs/:id", handlers.GetJob)
+		admin.POST("/jobs/:id/retry", handlers.RetryJob)
+		admin.POST("/jobs/:id/cancel", handlers.CancelJob)
+	}
+
+	// CPU, heap, goroutine and other profiles for go tool pprof, to diagnose memory spikes
+	// during network builds
+	profiles := router.Group("/debug/pprof", middleware.RequireRole(models.RoleAdmin))
+	{
+		profiles.GET("/", gin.WrapF(pprof.Index))
+		profiles.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+		profiles.GET("/profile", gin.WrapF(pprof.Profile))
+		profiles.GET("/symbol", gin.WrapF(pprof.Symbol))
+		profiles.POST("/symbol", gin.WrapF(pprof.Symbol))
+		profiles.GET("/trace", gin.WrapF(pprof.Trace))
+		profiles.GET("/:name", gin.WrapF(pprof.Index))
+	}
+
+	// The frontend, embedded in the binary (or from server.frontend_dir), with index.html for the
+	// paths no route matches so client-side routes resolve
+	frontend, err := web.New(cfg.Server.FrontendDir)
+	if err != nil {
+		log.Fatalf("❌ Failed to load frontend: %v", err)
+	}
+	router.NoRoute(frontend.Handler())
+
+	// Typed read API for internal services and pipelines, sharing the HTTP caches
+	if cfg.Server.GRPCPort != 0 {
+		grpcAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.GRPCPort)
+		go func() {
+			if err := grpcapi.Serve(grpcAddr, grpcapi.NewServer(repos.Live, handlers.CachedConnections, handlers.CachedNetwork)); err != nil {
+				log.Fatalf("❌ Failed to start gRPC service: %v", err)
+			}
+		}()
+	}
+
+	// Start server, terminating HTTPS itself when tls.enabled is set
+	serverAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
+	scheme := "http"
+	if cfg.TLS.Enabled {
+		scheme = "https"
+	}
 
 	log.Printf("🚀 Political Network API starting on %s", serverAddr)
-	log.Printf("📊 API endpoints available at http://%s/api/", serverAddr)
-	log.Printf("❤️ Health check at http://%s/health", serverAddr)
+	log.Printf("📊 API endpoints available at %s://%s/api/", scheme, serverAddr)
+	log.Printf("❤️ Health check at %s://%s/health", scheme, serverAddr)
 
-	if err := router.Run(serverAddr); err != nil {
-		log.Fatalf("❌ Failed to start server: %v", err)
+	if err := server.Run(ctx, router, cfg); err != nil {
+		if ctx.Err() == nil {
+			log.Fatalf("❌ Failed to start server: %v", err)
+		}
+		log.Printf("⚠️ Shutdown did not finish cleanly: %v", err)
 	}
+	log.Println("🛑 Server stopped")
+
+	// Save the slowest caches so the next start doesn't rebuild them cold
+	handlers.SaveCacheSnapshot()
 }
@@ -0,0 +1,1257 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"os"
+	"path"
+	"political-network-api/internal/models"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the API's runtime configuration: built-in defaults, then the config file,
+// then environment variables
+type Config struct {
+	Server      ServerConfig      `yaml:"server"`
+	TLS         TLSConfig         `yaml:"tls"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Cache       CacheConfig       `yaml:"cache"`
+	Auth        AuthConfig        `yaml:"auth"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit"`
+	Requests    RequestsConfig    `yaml:"requests"`
+	Export      ExportConfig      `yaml:"export"`
+	Images      ImagesConfig      `yaml:"images"`
+	Jobs        JobsConfig        `yaml:"jobs"`
+	Search      SearchConfig      `yaml:"search"`
+	Email       EmailConfig       `yaml:"email"`
+	Share       ShareConfig       `yaml:"share"`
+	Compression CompressionConfig `yaml:"compression"`
+	Network     NetworkConfig     `yaml:"network"`
+	CORS        CORSConfig        `yaml:"cors"`
+	Reporting   ReportingConfig   `yaml:"reporting"`
+	Flags       map[string]Flag   `yaml:"flags"`
+}
+
+// ServerConfig controls the HTTP listener and the gRPC service, which is off while GRPCPort is 0.
+// Demo serves the bundled synthetic dataset from memory instead of opening the database.
+// FrontendDir serves the frontend from a directory rather than the build embedded in the binary,
+// for working on it without rebuilding. MaxBodyBytes caps the request bodies of callers without
+// an API key (0 for no cap); endpoints taking uploads cap them further. PublicURL is the
+// address clients reach the API at, the base of the links in sign-in and digest emails; it is
+// required with email, since links built from the request's Host could point anywhere.
+type ServerConfig struct {
+	Host         string `yaml:"host"`
+	Port         int    `yaml:"port"`
+	GRPCPort     int    `yaml:"grpc_port"`
+	GinMode      string `yaml:"gin_mode"`
+	Demo         bool   `yaml:"demo"`
+	FrontendDir  string `yaml:"frontend_dir"`
+	MaxBodyBytes int    `yaml:"max_body_bytes"`
+	PublicURL    string `yaml:"public_url"`
+}
+
+// TLSConfig has the API terminate HTTPS itself, with certificates obtained and renewed from an
+// ACME CA (Let's Encrypt unless ACMEDirectory names another, such as its staging directory), for
+// deployments without a load balancer in front. The API then serves HTTPS on server.port and,
+// on HTTPPort, answers ACME HTTP-01 challenges and redirects everything else to HTTPS; 0 leaves
+// only the TLS-ALPN challenge, which needs server.port 443. Certificates are issued for Domains
+// alone and kept in CacheDir across restarts; Email receives the CA's expiry notices.
+type TLSConfig struct {
+	Enabled       bool     `yaml:"enabled"`
+	Domains       []string `yaml:"domains"`
+	Email         string   `yaml:"email"`
+	CacheDir      string   `yaml:"cache_dir"`
+	HTTPPort      int      `yaml:"http_port"`
+	ACMEDirectory string   `yaml:"acme_directory"`
+}
+
+// DatabaseConfig holds connection settings. URL (a pool URL) takes precedence over the
+// individual fields. A failed connect is tried RetryAttempts times in all, waiting RetryBackoff
+// and doubling; after BreakerThreshold consecutive failures (0 disables the breaker) connects
+// fail fast for BreakerCooldown before one is let through to probe the database. Queries taking
+// SlowQueryThreshold or longer are logged (0 disables the log). A list read whose rows fail to
+// scan drops them and reports dropped_rows, unless more than MaxDroppedRatio of its rows did,
+// which fails the request (0 fails it on any bad row). Driver sqlite serves the core read
+// endpoints from the SQLite file at SQLitePath instead, seeded with a synthetic dataset when new,
+// for local development and demos; the Postgres settings are then unused.
+type DatabaseConfig struct {
+	Driver             string        `yaml:"driver"`
+	SQLitePath         string        `yaml:"sqlite_path"`
+	URL                string        `yaml:"url"`
+	Host               string        `yaml:"host"`
+	Port               int           `yaml:"port"`
+	User               string        `yaml:"user"`
+	Password           string        `yaml:"password"`
+	Name               string        `yaml:"name"`
+	SSLMode            string        `yaml:"sslmode"`
+	MaxOpenConns       int           `yaml:"max_open_conns"`
+	ConnMaxLifetime    time.Duration `yaml:"conn_max_lifetime"`
+	RetryAttempts      int           `yaml:"retry_attempts"`
+	RetryBackoff       time.Duration `yaml:"retry_backoff"`
+	BreakerThreshold   int           `yaml:"breaker_threshold"`
+	BreakerCooldown    time.Duration `yaml:"breaker_cooldown"`
+	SlowQueryThreshold time.Duration `yaml:"slow_query_threshold"`
+	MaxDroppedRatio    float64       `yaml:"max_dropped_ratio"`
+}
+
+// CacheConfig holds cache expirations. TTLs overrides the built-in per-endpoint defaults;
+// endpoints without either use DefaultTTL. StaleTTL is how long a copy of each cached value is
+// kept to answer reads while the database is unavailable (0 disables stale serving), for at
+// most StaleMaxEntries keys, the oldest copies giving way to new ones. Compress
+// keeps the largest values (the network and connections) as gzipped JSON instead of live objects.
+// SnapshotFile, when set, is where those values are saved on shutdown and restored from on boot.
+// Invalidation broadcasts cache flushes and purges to the other replicas (InvalidationPostgres
+// over LISTEN/NOTIFY, through InvalidationURL when the database URL is a transaction pool).
+type CacheConfig struct {
+	DefaultTTL      time.Duration            `yaml:"default_ttl"`
+	TTLs            map[string]time.Duration `yaml:"ttls"`
+	StaleTTL        time.Duration            `yaml:"stale_ttl"`
+	StaleMaxEntries int                      `yaml:"stale_max_entries"`
+	Warmup          bool                     `yaml:"warmup"`
+	Compress        bool                     `yaml:"compress"`
+	SnapshotFile    string                   `yaml:"snapshot_file"`
+	Invalidation    string                   `yaml:"invalidation"`
+	InvalidationURL string                   `yaml:"invalidation_url"`
+}
+
+// Cache invalidation transports
+const (
+	InvalidationPostgres = "postgres"
+	InvalidationNone     = "none"
+)
+
+// AuthConfig lists API keys as label:role:key entries and configures user sign-in by magic
+// link. MagicLinkURL is the page the emailed link opens (the token is appended as ?token=);
+// empty links straight to /api/auth/verify.
+type AuthConfig struct {
+	APIKeys      []string      `yaml:"api_keys"`
+	MagicLinkURL string        `yaml:"magic_link_url"`
+	SessionTTL   time.Duration `yaml:"session_ttl"`
+	// CursorSecret signs pagination cursors; empty uses a random key per process, so cursors
+	// don't outlast a restart or move between replicas
+	CursorSecret string `yaml:"cursor_secret"`
+}
+
+// RateLimitConfig caps requests per client in each window by role; a limit of 0 means unlimited.
+//
+// Independently of API keys, every client IP also draws on a token bucket holding up to IPBurst
+// tokens and refilled at IPRate tokens a second (0 disables it). A request takes one token, or
+// its route's entry in Costs (gin route patterns such as /api/network), so one client can't tie
+// up the database with expensive requests. Addresses and CIDR ranges in Allowlist are exempt.
+// Client IPs come from X-Forwarded-For only when the peer is in TrustedProxies, which is read at
+// startup; the default trusts every peer, as behind a load balancer.
+type RateLimitConfig struct {
+	Window         time.Duration  `yaml:"window"`
+	Public         int            `yaml:"public"`
+	Researcher     int            `yaml:"researcher"`
+	Admin          int            `yaml:"admin"`
+	IPRate         float64        `yaml:"ip_rate"`
+	IPBurst        int            `yaml:"ip_burst"`
+	Costs          map[string]int `yaml:"costs"`
+	Allowlist      []string       `yaml:"allowlist"`
+	TrustedProxies []string       `yaml:"trusted_proxies"`
+}
+
+// Cost returns the tokens a request to route takes from its IP's bucket
+func (r RateLimitConfig) Cost(route string) int {
+	if cost, ok := r.Costs[route]; ok {
+		return cost
+	}
+	return 1
+}
+
+// Limit returns the request limit for role, 0 when unlimited
+func (r RateLimitConfig) Limit(role models.Role) int {
+	switch role {
+	case models.RoleAdmin:
+		return r.Admin
+	case models.RoleResearcher:
+		return r.Researcher
+	default:
+		return r.Public
+	}
+}
+
+// RequestsConfig bounds the time and concurrency of requests, by route pattern: gin patterns such
+// as /api/network or /api/stream/:entity, with a final *name segment matching any rest of the
+// path; the pattern with the most literal segments wins. A request still unanswered after its
+// route's entry in Timeouts, or DefaultTimeout, gets a 504 (0 means no timeout, for streams and
+// exports). MaxInFlight caps the requests to a route running at once; more get a 503 with
+// Retry-After until one finishes.
+type RequestsConfig struct {
+	DefaultTimeout time.Duration            `yaml:"default_timeout"`
+	Timeouts       map[string]time.Duration `yaml:"timeouts"`
+	MaxInFlight    map[string]int           `yaml:"max_in_flight"`
+}
+
+// Timeout returns the timeout for a request path, 0 for none
+func (r RequestsConfig) Timeout(path string) time.Duration {
+	if pattern, ok := MatchRoute(path, r.Timeouts); ok {
+		return r.Timeouts[pattern]
+	}
+	return r.DefaultTimeout
+}
+
+// MatchRoute returns the pattern among routes' keys that best matches path
+func MatchRoute[V any](path string, routes map[string]V) (string, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	best, bestLiterals := "", -1
+	for pattern := range routes {
+		literals, ok := matchPattern(strings.Split(strings.Trim(pattern, "/"), "/"), segments)
+		if ok && (literals > bestLiterals || literals == bestLiterals && pattern < best) {
+			best, bestLiterals = pattern, literals
+		}
+	}
+	return best, bestLiterals >= 0
+}
+
+// matchPattern matches path segments against pattern segments, counting the literal ones
+func matchPattern(pattern, path []string) (literals int, ok bool) {
+	for i, part := range pattern {
+		if strings.HasPrefix(part, "*") && i == len(pattern)-1 {
+			return literals, true
+		}
+		if i >= len(path) {
+			return 0, false
+		}
+		switch {
+		case strings.HasPrefix(part, ":"):
+			if path[i] == "" {
+				return 0, false
+			}
+		case part == path[i]:
+			literals++
+		default:
+			return 0, false
+		}
+	}
+	return literals, len(pattern) == len(path)
+}
+
+// ExportConfig controls generated dataset archives
+type ExportConfig struct {
+	Dir string `yaml:"dir"`
+}
+
+// ImagesConfig controls the photo and logo proxy's disk cache
+type ImagesConfig struct {
+	CacheDir string        `yaml:"cache_dir"`
+	MaxAge   time.Duration `yaml:"max_age"`
+}
+
+// JobsConfig schedules background maintenance; a zero interval disables a job. QueueWorkers
+// run the jobs queued through /api/admin/jobs (0 leaves them queued for another instance);
+// ETL jobs run ETLCommand, the cli4 entry point, from ETLDir. VerifyOnStartup runs the data
+// integrity checks in the background on boot. DigestInterval is how often the weekly watchlist
+// digests that are due are sent.
+type JobsConfig struct {
+	SanctionExpiryInterval time.Duration `yaml:"sanction_expiry_interval"`
+	WebhookInterval        time.Duration `yaml:"webhook_interval"`
+	DigestInterval         time.Duration `yaml:"digest_interval"`
+	QueueWorkers           int           `yaml:"queue_workers"`
+	QueuePollInterval      time.Duration `yaml:"queue_poll_interval"`
+	ETLCommand             string        `yaml:"etl_command"`
+	ETLDir                 string        `yaml:"etl_dir"`
+	VerifyOnStartup        bool          `yaml:"verify_on_startup"`
+}
+
+// SearchConfig selects the full-text index behind /api/search: an embedded Bleve index at
+// Path, an external Elasticsearch index, or none. SyncInterval is how often rows changed
+// by the ETL pipeline are pushed into it; 0 only builds the index at startup.
+type SearchConfig struct {
+	Backend          string        `yaml:"backend"`
+	Path             string        `yaml:"path"`
+	ElasticsearchURL string        `yaml:"elasticsearch_url"`
+	Index            string        `yaml:"index"`
+	SyncInterval     time.Duration `yaml:"sync_interval"`
+}
+
+// Search backends
+const (
+	SearchBleve         = "bleve"
+	SearchElasticsearch = "elasticsearch"
+	SearchNone          = "none"
+)
+
+// EmailConfig selects how notification emails are sent: through an SMTP relay or Amazon SES.
+// Email is disabled while the selected provider is not configured (no SMTP host or SES region).
+type EmailConfig struct {
+	Provider           string `yaml:"provider"`
+	SMTPHost           string `yaml:"smtp_host"`
+	SMTPPort           int    `yaml:"smtp_port"`
+	Username           string `yaml:"username"`
+	Password           string `yaml:"password"`
+	From               string `yaml:"from"`
+	SESRegion          string `yaml:"ses_region"`
+	SESAccessKeyID     string `yaml:"ses_access_key_id"`
+	SESSecretAccessKey string `yaml:"ses_secret_access_key"`
+}
+
+// Email providers
+const (
+	EmailSMTP = "smtp"
+	EmailSES  = "ses"
+)
+
+// ShareConfig controls shared snapshot links. FrontendURL is where /share/:id sends visitors
+// after the link preview has been read.
+type ShareConfig struct {
+	FrontendURL string        `yaml:"frontend_url"`
+	TTL         time.Duration `yaml:"ttl"`
+}
+
+// CompressionConfig controls gzip/brotli response compression. Types are media types, with
+// "text/*" style wildcards; bodies smaller than MinSize bytes are sent uncompressed.
+type CompressionConfig struct {
+	Enabled bool     `yaml:"enabled"`
+	MinSize int      `yaml:"min_size"`
+	Types   []string `yaml:"types"`
+}
+
+// AllowsType reports whether responses of mediaType may be compressed
+func (c CompressionConfig) AllowsType(mediaType string) bool {
+	for _, allowed := range c.Types {
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+		} else if mediaType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// NetworkConfig caps the generated connections served to the interactive network
+type NetworkConfig struct {
+	FinancialConnectionsLimit int `yaml:"financial_connections_limit"`
+	SanctionConnectionsLimit  int `yaml:"sanction_connections_limit"`
+}
+
+// ReportingConfig sends panics to a Sentry-compatible error tracker (Sentry, GlitchTip) at the
+// project's DSN; empty disables reporting. Environment and Release label the events.
+type ReportingConfig struct {
+	DSN         string `yaml:"dsn"`
+	Environment string `yaml:"environment"`
+	Release     string `yaml:"release"`
+}
+
+// Flag gates an experimental feature: it is on for everyone once Enabled, and before that only
+// for callers with one of the API key labels in Keys or the roles in Roles
+type Flag struct {
+	Enabled bool     `yaml:"enabled"`
+	Keys    []string `yaml:"keys"`
+	Roles   []string `yaml:"roles"`
+}
+
+// FlagOn reports whether the flag name is on for a caller with the API key label actor and role.
+// Flags not configured are off.
+func (c *Config) FlagOn(name, actor string, role models.Role) bool {
+	flag, ok := c.Flags[name]
+	if !ok {
+		return false
+	}
+	return flag.Enabled || slices.Contains(flag.Keys, actor) || slices.Contains(flag.Roles, string(role))
+}
+
+// defaultTTLs are the per-endpoint expirations used when nothing is configured
+var defaultTTLs = map[string]time.Duration{
+	"politicians":       15 * time.Minute,
+	"politician_detail": 15 * time.Minute,
+	"parties":           20 * time.Minute,
+	"party_detail":      20 * time.Minute,
+	"party_switches":    30 * time.Minute,
+	"companies":         25 * time.Minute,
+	"company_groups":    25 * time.Minute,
+	"company_detail":    25 * time.Minute,
+	"sanctions":         30 * time.Minute,
+	"topics":            30 * time.Minute,
+	"fronts":            1 * time.Hour,
+	"nepotism":          1 * time.Hour,
+	"tcu_rulings":       1 * time.Hour,
+	"politician_assets": 1 * time.Hour,
+	"politician_report": 30 * time.Minute,
+	"counterparties":    1 * time.Hour,
+	"geo_spending":      1 * time.Hour,
+	"anomalies":         1 * time.Hour,
+	"seasonality":       1 * time.Hour,
+	"vendor_clusters":   1 * time.Hour,
+	"flows":             1 * time.Hour,
+	"search":            5 * time.Minute,
+	"expenses":          15 * time.Minute,
+	"connections":       20 * time.Minute,
+	"network":           10 * time.Minute,
+	"stats":             5 * time.Minute,
+	"ipca":              24 * time.Hour,
+	"images":            1 * time.Hour,
+	"feeds":             30 * time.Minute,
+	"provenance":        1 * time.Hour,
+	"dataset_versions":  5 * time.Minute,
+	"enums":             1 * time.Hour,
+}
+
+var current atomic.Pointer[Config]
+
+// Default returns the built-in configuration
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{Host: "0.0.0.0", Port: 8080, GinMode: "debug", MaxBodyBytes: 1 << 20},
+		TLS:    TLSConfig{CacheDir: "./data/certs", HTTPPort: 80},
+		Database: DatabaseConfig{
+			Driver:             "postgres",
+			SQLitePath:         "./data/demo.db",
+			Host:               "localhost",
+			Port:               5432,
+			User:               "postgres",
+			Name:               "political_transparency",
+			SSLMode:            "disable",
+			ConnMaxLifetime:    5 * time.Minute,
+			RetryAttempts:      3,
+			RetryBackoff:       100 * time.Millisecond,
+			BreakerThreshold:   5,
+			BreakerCooldown:    30 * time.Second,
+			SlowQueryThreshold: 500 * time.Millisecond,
+			MaxDroppedRatio:    0.01,
+		},
+		Cache: CacheConfig{
+			DefaultTTL:      30 * time.Minute,
+			TTLs:            map[string]time.Duration{},
+			StaleTTL:        24 * time.Hour,
+			StaleMaxEntries: 1000,
+			Warmup:          true,
+			Compress:        true,
+			Invalidation:    InvalidationNone,
+		},
+		Auth: AuthConfig{SessionTTL: 30 * 24 * time.Hour},
+		RateLimit: RateLimitConfig{
+			Window:     time.Minute,
+			Public:     120,
+			Researcher: 1200,
+			IPRate:     10,
+			IPBurst:    50,
+			Costs: map[string]int{
+				"/api/network":        10,
+				"/api/network/export": 20,
+				"/api/export/full":    20,
+				"/api/stream/:entity": 20,
+			},
+			Allowlist:      []string{"127.0.0.1", "::1"},
+			TrustedProxies: []string{"0.0.0.0/0", "::/0"},
+		},
+		Requests: RequestsConfig{
+			DefaultTimeout: 15 * time.Second,
+			Timeouts: map[string]time.Duration{
+				"/api/network":             30 * time.Second,
+				"/api/connections":         30 * time.Second,
+				"/api/politicians":         5 * time.Second,
+				"/api/parties":             5 * time.Second,
+				"/api/companies":           5 * time.Second,
+				"/api/expenses":            5 * time.Second,
+				"/api/sanctions":           5 * time.Second,
+				"/api/network/export":      0,
+				"/api/export/*path":        0,
+				"/api/stream/:entity":      0,
+				"/api/progress/:id/events": 0,
+				"/api/admin/*path":         0,
+				"/debug/pprof/*path":       0,
+			},
+			MaxInFlight: map[string]int{
+				"/api/network":        8,
+				"/api/network/export": 2,
+				"/api/connections":    8,
+			},
+		},
+		Export: ExportConfig{Dir: "./exports"},
+		Images: ImagesConfig{CacheDir: "./cache/images", MaxAge: 7 * 24 * time.Hour},
+		Jobs: JobsConfig{
+			SanctionExpiryInterval: time.Hour,
+			WebhookInterval:        time.Minute,
+			DigestInterval:         time.Hour,
+			QueueWorkers:           2,
+			QueuePollInterval:      5 * time.Second,
+			ETLCommand:             "python3 cli4/main.py",
+			ETLDir:                 "..",
+			VerifyOnStartup:        true,
+		},
+		Search: SearchConfig{
+			Backend:          SearchBleve,
+			Path:             "./data/search.bleve",
+			ElasticsearchURL: "http://localhost:9200",
+			Index:            "political-network",
+			SyncInterval:     10 * time.Minute,
+		},
+		Email: EmailConfig{Provider: EmailSMTP, SMTPPort: 587},
+		Share: ShareConfig{FrontendURL: "https://open-data-gov.vercel.app", TTL: 7 * 24 * time.Hour},
+		Compression: CompressionConfig{
+			Enabled: true,
+			MinSize: 1024,
+			Types: []string{
+				"application/json",
+				"application/msgpack",
+				"application/x-ndjson",
+				"application/atom+xml",
+				"application/xml",
+				"application/graphml+xml",
+				"image/svg+xml",
+				"text/*",
+			},
+		},
+		Network: NetworkConfig{
+			FinancialConnectionsLimit: 5000,
+			SanctionConnectionsLimit:  2000,
+		},
+		Reporting: ReportingConfig{Environment: "production"},
+		Flags: map[string]Flag{
+			// Anomaly detection's false positive rate is still being measured
+			"anomalies": {Roles: []string{string(models.RoleResearcher), string(models.RoleAdmin)}},
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{
+				"http://localhost:3000",
+				"http://127.0.0.1:3000",
+				"https://open-data-gov.vercel.app",
+				"https://open-data-gov-*.vercel.app", // preview deployments
+			},
+		},
+	}
+}
+
+// Get returns the active configuration, the defaults if Load hasn't run
+func Get() *Config {
+	if cfg := current.Load(); cfg != nil {
+		return cfg
+	}
+	return Default()
+}
+
+// Load reads and validates the configuration and makes it active
+func Load() (*Config, error) {
+	cfg, err := read()
+	if err != nil {
+		return nil, err
+	}
+
+	current.Store(cfg)
+	return cfg, nil
+}
+
+// read loads CONFIG_FILE (default config.yaml, optional), applies environment overrides
+// and validates the result
+func read() (*Config, error) {
+	cfg := Default()
+
+	path := os.Getenv("CONFIG_FILE")
+	if path == "" {
+		path = "config.yaml"
+	}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		log.Printf("⚙️ Loaded configuration from %s", path)
+	case errors.Is(err, os.ErrNotExist) && os.Getenv("CONFIG_FILE") == "":
+		// No file is fine; defaults and environment apply
+	default:
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	envErr := applyEnv(cfg)
+	if cfg.Cache.TTLs == nil {
+		cfg.Cache.TTLs = map[string]time.Duration{}
+	}
+	if cfg.Database.MaxOpenConns == 0 {
+		// Shared pools (POSTGRES_POOL_URL) get a conservative default
+		cfg.Database.MaxOpenConns = 50
+		if cfg.Database.URL != "" {
+			cfg.Database.MaxOpenConns = 25
+		}
+	}
+	if err := errors.Join(envErr, cfg.Validate()); err != nil {
+		return nil, err
+	}
+	if cfg.Auth.CursorSecret == "" && !cfg.Server.Demo {
+		log.Println("⚠️ auth.cursor_secret (CURSOR_SECRET) is not set; pagination cursors are signed with a random key and break on restart and across replicas")
+	}
+	return cfg, nil
+}
+
+// applyEnv overrides file values with environment variables
+func applyEnv(cfg *Config) error {
+	var errs []error
+	str := func(name string, dst *string) {
+		if v, ok := os.LookupEnv(name); ok {
+			*dst = v
+		}
+	}
+	num := func(name string, dst *int) {
+		if v, ok := os.LookupEnv(name); ok && v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: must be an integer", name))
+				return
+			}
+			*dst = n
+		}
+	}
+	list := func(name string, dst *[]string) {
+		if v, ok := os.LookupEnv(name); ok {
+			*dst = nil
+			for _, entry := range strings.Split(v, ",") {
+				if entry = strings.TrimSpace(entry); entry != "" {
+					*dst = append(*dst, entry)
+				}
+			}
+		}
+	}
+
+	str("SERVER_HOST", &cfg.Server.Host)
+	num("SERVER_PORT", &cfg.Server.Port)
+	num("GRPC_PORT", &cfg.Server.GRPCPort)
+	str("GIN_MODE", &cfg.Server.GinMode)
+	str("FRONTEND_DIR", &cfg.Server.FrontendDir)
+	str("PUBLIC_URL", &cfg.Server.PublicURL)
+	num("CACHE_STALE_MAX_ENTRIES", &cfg.Cache.StaleMaxEntries)
+	str("CACHE_SNAPSHOT_FILE", &cfg.Cache.SnapshotFile)
+	str("CACHE_INVALIDATION", &cfg.Cache.Invalidation)
+	str("CACHE_INVALIDATION_URL", &cfg.Cache.InvalidationURL)
+	num("MAX_BODY_BYTES", &cfg.Server.MaxBodyBytes)
+
+	list("TLS_DOMAINS", &cfg.TLS.Domains)
+	str("TLS_EMAIL", &cfg.TLS.Email)
+	str("TLS_CACHE_DIR", &cfg.TLS.CacheDir)
+	num("TLS_HTTP_PORT", &cfg.TLS.HTTPPort)
+	str("TLS_ACME_DIRECTORY", &cfg.TLS.ACMEDirectory)
+
+	str("DB_DRIVER", &cfg.Database.Driver)
+	str("SQLITE_PATH", &cfg.Database.SQLitePath)
+	str("POSTGRES_POOL_URL", &cfg.Database.URL)
+	str("DB_HOST", &cfg.Database.Host)
+	num("DB_PORT", &cfg.Database.Port)
+	str("DB_USER", &cfg.Database.User)
+	str("DB_PASSWORD", &cfg.Database.Password)
+	str("DB_NAME", &cfg.Database.Name)
+	str("DB_SSLMODE", &cfg.Database.SSLMode)
+	num("MAX_DB_CONNECTIONS", &cfg.Database.MaxOpenConns)
+	num("DB_RETRY_ATTEMPTS", &cfg.Database.RetryAttempts)
+	num("DB_BREAKER_THRESHOLD", &cfg.Database.BreakerThreshold)
+
+	str("EXPORT_DIR", &cfg.Export.Dir)
+	str("IMAGE_CACHE_DIR", &cfg.Images.CacheDir)
+
+	str("SEARCH_BACKEND", &cfg.Search.Backend)
+	str("SEARCH_PATH", &cfg.Search.Path)
+	str("ELASTICSEARCH_URL", &cfg.Search.ElasticsearchURL)
+	str("SEARCH_INDEX", &cfg.Search.Index)
+
+	str("EMAIL_PROVIDER", &cfg.Email.Provider)
+	str("SMTP_HOST", &cfg.Email.SMTPHost)
+	num("SMTP_PORT", &cfg.Email.SMTPPort)
+	str("SMTP_USERNAME", &cfg.Email.Username)
+	str("SMTP_PASSWORD", &cfg.Email.Password)
+	str("EMAIL_FROM", &cfg.Email.From)
+	str("SES_REGION", &cfg.Email.SESRegion)
+	str("AWS_ACCESS_KEY_ID", &cfg.Email.SESAccessKeyID)
+	str("AWS_SECRET_ACCESS_KEY", &cfg.Email.SESSecretAccessKey)
+
+	str("SHARE_FRONTEND_URL", &cfg.Share.FrontendURL)
+
+	num("JOB_QUEUE_WORKERS", &cfg.Jobs.QueueWorkers)
+	str("ETL_COMMAND", &cfg.Jobs.ETLCommand)
+	str("ETL_DIR", &cfg.Jobs.ETLDir)
+
+	num("COMPRESSION_MIN_SIZE", &cfg.Compression.MinSize)
+	list("COMPRESSION_TYPES", &cfg.Compression.Types)
+
+	num("NETWORK_FINANCIAL_CONNECTIONS_LIMIT", &cfg.Network.FinancialConnectionsLimit)
+	num("NETWORK_SANCTION_CONNECTIONS_LIMIT", &cfg.Network.SanctionConnectionsLimit)
+
+	list("API_KEYS", &cfg.Auth.APIKeys)
+	str("MAGIC_LINK_URL", &cfg.Auth.MagicLinkURL)
+	str("CURSOR_SECRET", &cfg.Auth.CursorSecret)
+	num("RATE_LIMIT_PUBLIC", &cfg.RateLimit.Public)
+	num("RATE_LIMIT_RESEARCHER", &cfg.RateLimit.Researcher)
+	num("RATE_LIMIT_ADMIN", &cfg.RateLimit.Admin)
+	num("RATE_LIMIT_IP_BURST", &cfg.RateLimit.IPBurst)
+	list("RATE_LIMIT_ALLOWLIST", &cfg.RateLimit.Allowlist)
+	list("TRUSTED_PROXIES", &cfg.RateLimit.TrustedProxies)
+	list("CORS_ALLOWED_ORIGINS", &cfg.CORS.AllowedOrigins)
+
+	str("SENTRY_DSN", &cfg.Reporting.DSN)
+	str("SENTRY_ENVIRONMENT", &cfg.Reporting.Environment)
+	str("SENTRY_RELEASE", &cfg.Reporting.Release)
+
+	if v, ok := os.LookupEnv("DEMO_MODE"); ok && v != "" {
+		demo, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("DEMO_MODE: must be a boolean"))
+		}
+		cfg.Server.Demo = demo
+	}
+	if v, ok := os.LookupEnv("TLS_ENABLED"); ok && v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("TLS_ENABLED: must be a boolean"))
+		}
+		cfg.TLS.Enabled = enabled
+	}
+	if v, ok := os.LookupEnv("CACHE_WARMUP"); ok && v != "" {
+		warmup, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("CACHE_WARMUP: must be a boolean"))
+		}
+		cfg.Cache.Warmup = warmup
+	}
+	if v, ok := os.LookupEnv("VERIFY_ON_STARTUP"); ok && v != "" {
+		verify, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("VERIFY_ON_STARTUP: must be a boolean"))
+		}
+		cfg.Jobs.VerifyOnStartup = verify
+	}
+	if v, ok := os.LookupEnv("CACHE_COMPRESS"); ok && v != "" {
+		compress, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("CACHE_COMPRESS: must be a boolean"))
+		}
+		cfg.Cache.Compress = compress
+	}
+	if v, ok := os.LookupEnv("COMPRESSION_ENABLED"); ok && v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("COMPRESSION_ENABLED: must be a boolean"))
+		}
+		cfg.Compression.Enabled = enabled
+	}
+	if v, ok := os.LookupEnv("DB_RETRY_BACKOFF"); ok && v != "" {
+		backoff, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("DB_RETRY_BACKOFF: %w", err))
+		}
+		cfg.Database.RetryBackoff = backoff
+	}
+	if v, ok := os.LookupEnv("DB_BREAKER_COOLDOWN"); ok && v != "" {
+		cooldown, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("DB_BREAKER_COOLDOWN: %w", err))
+		}
+		cfg.Database.BreakerCooldown = cooldown
+	}
+	if v, ok := os.LookupEnv("DB_SLOW_QUERY_THRESHOLD"); ok && v != "" {
+		threshold, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("DB_SLOW_QUERY_THRESHOLD: %w", err))
+		}
+		cfg.Database.SlowQueryThreshold = threshold
+	}
+	if v, ok := os.LookupEnv("DB_MAX_DROPPED_RATIO"); ok && v != "" {
+		ratio, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("DB_MAX_DROPPED_RATIO: must be a number"))
+		}
+		cfg.Database.MaxDroppedRatio = ratio
+	}
+	if v, ok := os.LookupEnv("CACHE_STALE_TTL"); ok && v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("CACHE_STALE_TTL: %w", err))
+		}
+		cfg.Cache.StaleTTL = ttl
+	}
+	if v, ok := os.LookupEnv("IMAGE_CACHE_MAX_AGE"); ok && v != "" {
+		maxAge, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("IMAGE_CACHE_MAX_AGE: %w", err))
+		}
+		cfg.Images.MaxAge = maxAge
+	}
+	if v, ok := os.LookupEnv("SESSION_TTL"); ok && v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("SESSION_TTL: %w", err))
+		}
+		cfg.Auth.SessionTTL = ttl
+	}
+	if v, ok := os.LookupEnv("RATE_LIMIT_IP_RATE"); ok && v != "" {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("RATE_LIMIT_IP_RATE: must be a number"))
+		}
+		cfg.RateLimit.IPRate = rate
+	}
+	if v, ok := os.LookupEnv("REQUEST_TIMEOUT"); ok && v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("REQUEST_TIMEOUT: %w", err))
+		}
+		cfg.Requests.DefaultTimeout = timeout
+	}
+	if v, ok := os.LookupEnv("RATE_LIMIT_WINDOW"); ok && v != "" {
+		window, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("RATE_LIMIT_WINDOW: %w", err))
+		}
+		cfg.RateLimit.Window = window
+	}
+	if v, ok := os.LookupEnv("SHARE_TTL"); ok && v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("SHARE_TTL: %w", err))
+		}
+		cfg.Share.TTL = ttl
+	}
+	if v, ok := os.LookupEnv("SANCTION_EXPIRY_INTERVAL"); ok && v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("SANCTION_EXPIRY_INTERVAL: %w", err))
+		}
+		cfg.Jobs.SanctionExpiryInterval = interval
+	}
+	if v, ok := os.LookupEnv("WEBHOOK_INTERVAL"); ok && v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("WEBHOOK_INTERVAL: %w", err))
+		}
+		cfg.Jobs.WebhookInterval = interval
+	}
+	if v, ok := os.LookupEnv("DIGEST_INTERVAL"); ok && v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("DIGEST_INTERVAL: %w", err))
+		}
+		cfg.Jobs.DigestInterval = interval
+	}
+	if v, ok := os.LookupEnv("JOB_QUEUE_POLL_INTERVAL"); ok && v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("JOB_QUEUE_POLL_INTERVAL: %w", err))
+		}
+		cfg.Jobs.QueuePollInterval = interval
+	}
+	if v, ok := os.LookupEnv("SEARCH_SYNC_INTERVAL"); ok && v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("SEARCH_SYNC_INTERVAL: %w", err))
+		}
+		cfg.Search.SyncInterval = interval
+	}
+
+	// CACHE_TTL_MINUTES sets the default TTL and CACHE_TTL_<ENDPOINT> (a duration such
+	// as 90s or 15m) sets one endpoint
+	minutes := 0
+	num("CACHE_TTL_MINUTES", &minutes)
+	if minutes != 0 {
+		cfg.Cache.DefaultTTL = time.Duration(minutes) * time.Minute
+	}
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		endpoint, ok := strings.CutPrefix(name, "CACHE_TTL_")
+		if !ok || endpoint == "MINUTES" {
+			continue
+		}
+
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		if cfg.Cache.TTLs == nil {
+			cfg.Cache.TTLs = map[string]time.Duration{}
+		}
+		cfg.Cache.TTLs[strings.ToLower(endpoint)] = ttl
+	}
+
+	// FLAG_<NAME> turns a flag on (true) or off (false) for everyone, or on for a comma-separated
+	// list of API key labels
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		flag, ok := strings.CutPrefix(name, "FLAG_")
+		if !ok || flag == "" {
+			continue
+		}
+
+		flag = strings.ToLower(flag)
+		if cfg.Flags == nil {
+			cfg.Flags = map[string]Flag{}
+		}
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			cfg.Flags[flag] = Flag{Enabled: enabled}
+			continue
+		}
+		var keys []string
+		for _, key := range strings.Split(value, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+		cfg.Flags[flag] = Flag{Keys: keys}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate reports every invalid setting at once
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		fail("server.port: %d is not a valid port", c.Server.Port)
+	}
+	if c.Server.GRPCPort < 0 || c.Server.GRPCPort > 65535 {
+		fail("server.grpc_port: %d is not a valid port (0 disables gRPC)", c.Server.GRPCPort)
+	} else if c.Server.GRPCPort == c.Server.Port {
+		fail("server.grpc_port: must differ from server.port")
+	}
+	switch c.Server.GinMode {
+	case "debug", "release", "test":
+	default:
+		fail("server.gin_mode: %q must be debug, release or test", c.Server.GinMode)
+	}
+	if c.Server.MaxBodyBytes < 0 {
+		fail("server.max_body_bytes: must not be negative (0 disables the cap)")
+	}
+
+	if c.TLS.Enabled {
+		if len(c.TLS.Domains) == 0 {
+			fail("tls.domains: at least one domain is required with tls.enabled")
+		}
+		for i, domain := range c.TLS.Domains {
+			if strings.ContainsAny(domain, "/:*") || !strings.Contains(domain, ".") {
+				fail("tls.domains[%d]: %q must be a host name such as api.example.org, without wildcards", i, domain)
+			}
+		}
+		if c.TLS.Email != "" {
+			if _, err := mail.ParseAddress(c.TLS.Email); err != nil {
+				fail("tls.email: must be an email address")
+			}
+		}
+		if c.TLS.CacheDir == "" {
+			fail("tls.cache_dir: is required with tls.enabled")
+		}
+		if c.TLS.HTTPPort < 0 || c.TLS.HTTPPort > 65535 {
+			fail("tls.http_port: %d is not a valid port (0 disables the HTTP listener)", c.TLS.HTTPPort)
+		} else if c.TLS.HTTPPort != 0 && (c.TLS.HTTPPort == c.Server.Port || c.TLS.HTTPPort == c.Server.GRPCPort) {
+			fail("tls.http_port: must differ from server.port and server.grpc_port")
+		} else if c.TLS.HTTPPort == 0 && c.Server.Port != 443 {
+			fail("tls.http_port: is required unless server.port is 443, for the ACME challenges")
+		}
+		if c.TLS.ACMEDirectory != "" {
+			if u, err := url.Parse(c.TLS.ACMEDirectory); err != nil || u.Scheme != "https" || u.Host == "" {
+				fail("tls.acme_directory: must be an https URL")
+			}
+		}
+	}
+
+	switch c.Database.Driver {
+	case "postgres":
+		if c.Database.URL != "" {
+			if _, err := url.Parse(c.Database.URL); err != nil {
+				fail("database.url: invalid URL")
+			}
+		} else if c.Database.Host == "" || c.Database.Name == "" {
+			fail("database: url or host and name are required")
+		}
+	case "sqlite":
+		if c.Database.SQLitePath == "" {
+			fail("database.sqlite_path: required with the sqlite driver")
+		}
+	default:
+		fail("database.driver: %q must be postgres or sqlite", c.Database.Driver)
+	}
+	if c.Database.MaxOpenConns < 1 {
+		fail("database.max_open_conns: must be at least 1")
+	}
+	if c.Database.RetryAttempts < 1 {
+		fail("database.retry_attempts: must be at least 1 (1 disables retries)")
+	}
+	if c.Database.RetryBackoff <= 0 {
+		fail("database.retry_backoff: must be positive")
+	}
+	if c.Database.BreakerThreshold < 0 {
+		fail("database.breaker_threshold: must not be negative (0 disables the breaker)")
+	}
+	if c.Database.BreakerCooldown <= 0 {
+		fail("database.breaker_cooldown: must be positive")
+	}
+	if c.Database.SlowQueryThreshold < 0 {
+		fail("database.slow_query_threshold: must not be negative (0 disables the slow query log)")
+	}
+	if c.Database.MaxDroppedRatio < 0 || c.Database.MaxDroppedRatio > 1 {
+		fail("database.max_dropped_ratio: must be between 0 and 1")
+	}
+
+	if c.Cache.DefaultTTL <= 0 {
+		fail("cache.default_ttl: must be positive")
+	}
+	for endpoint, ttl := range c.Cache.TTLs {
+		if ttl <= 0 {
+			fail("cache.ttls.%s: must be positive", endpoint)
+		}
+	}
+	if c.Cache.StaleTTL < 0 {
+		fail("cache.stale_ttl: must not be negative (0 disables stale serving)")
+	}
+	if c.Cache.StaleMaxEntries < 1 {
+		fail("cache.stale_max_entries: must be positive")
+	}
+	switch c.Cache.Invalidation {
+	case InvalidationPostgres:
+		if c.Database.Driver != "postgres" || c.Server.Demo {
+			fail("cache.invalidation: postgres needs the postgres database driver")
+		}
+	case InvalidationNone:
+	default:
+		fail("cache.invalidation: %q must be postgres or none", c.Cache.Invalidation)
+	}
+
+	for i, entry := range c.Auth.APIKeys {
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			fail("auth.api_keys[%d]: expected label:role:key", i)
+			continue
+		}
+		if role := models.Role(parts[1]); role != models.RoleResearcher && role != models.RoleAdmin {
+			fail("auth.api_keys[%d] (%s): role %q must be researcher or admin", i, parts[0], parts[1])
+		}
+		if strings.HasPrefix(parts[2], "sess_") {
+			fail("auth.api_keys[%d] (%s): keys must not start with sess_, which marks user sessions", i, parts[0])
+		}
+	}
+	if c.Server.PublicURL != "" {
+		if u, err := url.Parse(c.Server.PublicURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") ||
+			u.Host == "" || u.RawQuery != "" {
+			fail("server.public_url: must be an absolute http(s) URL without a query")
+		}
+	}
+	if c.Auth.MagicLinkURL != "" {
+		if u, err := url.Parse(c.Auth.MagicLinkURL); err != nil || u.Scheme == "" || u.Host == "" {
+			fail("auth.magic_link_url: must be an absolute URL")
+		}
+	}
+	if c.Auth.SessionTTL <= 0 {
+		fail("auth.session_ttl: must be positive")
+	}
+
+	if c.RateLimit.Window <= 0 {
+		fail("rate_limit.window: must be positive")
+	}
+	if c.RateLimit.Public < 0 || c.RateLimit.Researcher < 0 || c.RateLimit.Admin < 0 {
+		fail("rate_limit: limits must not be negative (0 means unlimited)")
+	}
+	if c.RateLimit.IPRate < 0 {
+		fail("rate_limit.ip_rate: must not be negative (0 disables per-IP limits)")
+	}
+	if c.RateLimit.IPRate > 0 {
+		if c.RateLimit.IPBurst < 1 {
+			fail("rate_limit.ip_burst: must be at least 1")
+		}
+		for route, cost := range c.RateLimit.Costs {
+			if !strings.HasPrefix(route, "/") {
+				fail("rate_limit.costs: %q must be a route pattern such as /api/network", route)
+			}
+			if cost < 1 || cost > c.RateLimit.IPBurst {
+				fail("rate_limit.costs.%s: must be between 1 and rate_limit.ip_burst", route)
+			}
+		}
+	}
+	for i, entry := range c.RateLimit.Allowlist {
+		if _, err := netip.ParsePrefix(entry); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(entry); err != nil {
+			fail("rate_limit.allowlist[%d]: %q must be an IP address or CIDR range", i, entry)
+		}
+	}
+	for i, entry := range c.RateLimit.TrustedProxies {
+		if _, err := netip.ParsePrefix(entry); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(entry); err != nil {
+			fail("rate_limit.trusted_proxies[%d]: %q must be an IP address or CIDR range", i, entry)
+		}
+	}
+
+	if c.Requests.DefaultTimeout < 0 {
+		fail("requests.default_timeout: must not be negative (0 disables it)")
+	}
+	for pattern, timeout := range c.Requests.Timeouts {
+		if !strings.HasPrefix(pattern, "/") {
+			fail("requests.timeouts: %q must be a route pattern such as /api/network", pattern)
+		} else if timeout < 0 {
+			fail("requests.timeouts.%s: must not be negative (0 disables it)", pattern)
+		}
+	}
+	for pattern, max := range c.Requests.MaxInFlight {
+		if !strings.HasPrefix(pattern, "/") {
+			fail("requests.max_in_flight: %q must be a route pattern such as /api/network", pattern)
+		} else if max < 1 {
+			fail("requests.max_in_flight.%s: must be at least 1", pattern)
+		}
+	}
+
+	if c.Export.Dir == "" {
+		fail("export.dir: is required")
+	}
+
+	if c.Images.CacheDir == "" {
+		fail("images.cache_dir: is required")
+	}
+	if c.Images.MaxAge <= 0 {
+		fail("images.max_age: must be positive")
+	}
+
+	if c.Jobs.SanctionExpiryInterval < 0 {
+		fail("jobs.sanction_expiry_interval: must not be negative (0 disables the job)")
+	}
+	if c.Jobs.WebhookInterval < 0 {
+		fail("jobs.webhook_interval: must not be negative (0 disables the job)")
+	}
+	if c.Jobs.DigestInterval < 0 {
+		fail("jobs.digest_interval: must not be negative (0 disables the job)")
+	}
+	if c.Jobs.QueueWorkers < 0 {
+		fail("jobs.queue_workers: must not be negative (0 disables the workers)")
+	}
+	if c.Jobs.QueueWorkers > 0 && c.Jobs.QueuePollInterval <= 0 {
+		fail("jobs.queue_poll_interval: must be positive")
+	}
+	if c.Jobs.QueueWorkers > 0 && strings.TrimSpace(c.Jobs.ETLCommand) == "" {
+		fail("jobs.etl_command: is required while queue workers run")
+	}
+
+	switch c.Search.Backend {
+	case SearchBleve:
+		if c.Search.Path == "" {
+			fail("search.path: is required for the bleve backend")
+		}
+	case SearchElasticsearch:
+		if u, err := url.Parse(c.Search.ElasticsearchURL); err != nil || u.Scheme == "" || u.Host == "" {
+			fail("search.elasticsearch_url: must be an absolute URL")
+		}
+		if c.Search.Index == "" {
+			fail("search.index: is required for the elasticsearch backend")
+		}
+	case SearchNone:
+	default:
+		fail("search.backend: %q must be bleve, elasticsearch or none", c.Search.Backend)
+	}
+	if c.Search.SyncInterval < 0 {
+		fail("search.sync_interval: must not be negative (0 disables incremental sync)")
+	}
+
+	if u, err := url.Parse(c.Share.FrontendURL); err != nil || u.Scheme == "" || u.Host == "" {
+		fail("share.frontend_url: must be an absolute URL")
+	}
+	if c.Share.TTL <= 0 {
+		fail("share.ttl: must be positive")
+	}
+
+	if c.Compression.MinSize < 0 {
+		fail("compression.min_size: must not be negative")
+	}
+	for i, t := range c.Compression.Types {
+		if _, _, err := mime.ParseMediaType(t); err != nil || !strings.Contains(t, "/") {
+			fail("compression.types[%d]: %q is not a media type", i, t)
+		}
+	}
+
+	switch c.Email.Provider {
+	case EmailSMTP:
+		if c.Email.SMTPHost != "" {
+			if c.Email.SMTPPort < 1 || c.Email.SMTPPort > 65535 {
+				fail("email.smtp_port: %d is not a valid port", c.Email.SMTPPort)
+			}
+			if _, err := mail.ParseAddress(c.Email.From); err != nil {
+				fail("email.from: is required when email.smtp_host is set and must be an email address")
+			}
+		}
+	case EmailSES:
+		if c.Email.SESRegion != "" {
+			if _, err := mail.ParseAddress(c.Email.From); err != nil {
+				fail("email.from: is required when email.ses_region is set and must be an email address")
+			}
+			if c.Email.SESAccessKeyID == "" || c.Email.SESSecretAccessKey == "" {
+				fail("email.ses_access_key_id and email.ses_secret_access_key: are required for SES")
+			}
+		}
+	default:
+		fail("email.provider: %q must be smtp or ses", c.Email.Provider)
+	}
+	emailEnabled := c.Email.Provider == EmailSMTP && c.Email.SMTPHost != "" ||
+		c.Email.Provider == EmailSES && c.Email.SESRegion != ""
+	if emailEnabled && c.Server.PublicURL == "" {
+		fail("server.public_url: is required when email is configured, as the base of the links emails carry")
+	}
+
+	for i, pattern := range c.CORS.AllowedOrigins {
+		if err := ValidateOrigins([]string{pattern}); err != nil {
+			fail("cors.allowed_origins[%d]: %v", i, err)
+		}
+	}
+
+	if c.Reporting.DSN != "" {
+		u, err := url.Parse(c.Reporting.DSN)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User.Username() == "" ||
+			path.Base(u.Path) == "/" || path.Base(u.Path) == "." {
+			fail("reporting.dsn: must be a DSN such as https://<key>@sentry.example.org/<project>")
+		}
+	}
+
+	if c.Network.FinancialConnectionsLimit < 1 {
+		fail("network.financial_connections_limit: must be at least 1")
+	}
+	if c.Network.SanctionConnectionsLimit < 1 {
+		fail("network.sanction_connections_limit: must be at least 1")
+	}
+
+	labels := make([]string, 0, len(c.Auth.APIKeys))
+	for _, entry := range c.Auth.APIKeys {
+		label, _, _ := strings.Cut(entry, ":")
+		labels = append(labels, label)
+	}
+	for name, flag := range c.Flags {
+		for _, role := range flag.Roles {
+			if role := models.Role(role); role != models.RoleResearcher && role != models.RoleAdmin {
+				fail("flags.%s.roles: %q must be researcher or admin (enable the flag for everyone)", name, role)
+			}
+		}
+		for _, key := range flag.Keys {
+			if !slices.Contains(labels, key) {
+				fail("flags.%s.keys: %q is not the label of an auth.api_keys entry", name, key)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// TTL returns the cache expiration for an endpoint
+func (c CacheConfig) TTL(endpoint string) time.Duration {
+	if ttl, ok := c.TTLs[endpoint]; ok {
+		return ttl
+	}
+	if ttl, ok := defaultTTLs[endpoint]; ok {
+		return ttl
+	}
+	return c.DefaultTTL
+}
+
+// CacheTTL returns the active cache expiration for an endpoint
+func CacheTTL(endpoint string) time.Duration {
+	return Get().Cache.TTL(endpoint)
+}
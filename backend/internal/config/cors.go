@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// CORSConfig lists the origins allowed to call the API with credentials.
+//
+// Patterns are origins with an optional scheme and port. A leading "*." matches one or more
+// subdomain labels (https://*.example.org); a "*" inside the first label matches within that
+// label only (https://open-data-gov-*.vercel.app matches Vercel preview builds). A pattern
+// without a scheme matches both http and https.
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowed_origins"`
+}
+
+// originPattern is a parsed CORSConfig entry
+type originPattern struct {
+	scheme string // empty matches http and https
+	host   string // lower-case, may contain "*" as described on CORSConfig
+	port   string
+}
+
+// parseOriginPattern validates a pattern and splits it into its parts
+func parseOriginPattern(pattern string) (originPattern, error) {
+	var p originPattern
+
+	rest := pattern
+	if scheme, after, ok := strings.Cut(pattern, "://"); ok {
+		if scheme != "http" && scheme != "https" {
+			return p, fmt.Errorf("%q: scheme must be http or https", pattern)
+		}
+		p.scheme, rest = scheme, after
+	}
+	if strings.ContainsAny(rest, "/?#@") {
+		return p, fmt.Errorf("%q: origins cannot have a path, query or credentials", pattern)
+	}
+
+	host, port, hasPort := strings.Cut(rest, ":")
+	if hasPort && port == "" {
+		return p, fmt.Errorf("%q: empty port", pattern)
+	}
+	p.host, p.port = strings.ToLower(host), port
+
+	if p.host == "" || p.host == "*" {
+		return p, fmt.Errorf("%q: a host is required; allowing every origin is not supported with credentials", pattern)
+	}
+
+	first, base, _ := strings.Cut(p.host, ".")
+	if strings.Contains(base, "*") || strings.Count(first, "*") > 1 {
+		return p, fmt.Errorf("%q: only one \"*\", in the first label, is allowed", pattern)
+	}
+	if first == "*" && !strings.Contains(base, ".") {
+		return p, fmt.Errorf("%q: a wildcard needs at least a registrable domain (*.example.org)", pattern)
+	}
+	if strings.Contains(first, "*") {
+		if _, err := path.Match(first, ""); err != nil {
+			return p, fmt.Errorf("%q: %w", pattern, err)
+		}
+	}
+	return p, nil
+}
+
+// matches reports whether origin (scheme://host[:port]) is allowed by the pattern
+func (p originPattern) matches(u *url.URL) bool {
+	if p.scheme != "" && u.Scheme != p.scheme {
+		return false
+	}
+	if p.scheme == "" && u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	if u.Port() != p.port {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if !strings.Contains(p.host, "*") {
+		return host == p.host
+	}
+
+	first, base, _ := strings.Cut(p.host, ".")
+	if first == "*" {
+		sub, ok := strings.CutSuffix(host, "."+base)
+		return ok && sub != ""
+	}
+
+	label, hostBase, ok := strings.Cut(host, ".")
+	if !ok || hostBase != base {
+		return false
+	}
+	matched, _ := path.Match(first, label)
+	return matched
+}
+
+// ValidateOrigins checks every pattern, reporting the first invalid one
+func ValidateOrigins(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := parseOriginPattern(pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AllowsOrigin reports whether a request Origin header matches any configured pattern
+func (c CORSConfig) AllowsOrigin(origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" || u.Path != "" {
+		return false
+	}
+
+	for _, pattern := range c.AllowedOrigins {
+		p, err := parseOriginPattern(pattern)
+		if err == nil && p.matches(u) {
+			return true
+		}
+	}
+	return false
+}
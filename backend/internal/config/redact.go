@@ -0,0 +1,94 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "***"
+
+// Redacted returns a copy that is safe to display: the database password, credentials in
+// the pool, cache invalidation and Elasticsearch URLs, the error tracker's key, the SMTP
+// password, the SES secret key, the cursor secret and API key secrets are hidden
+func (c Config) Redacted() Config {
+	if c.Database.Password != "" {
+		c.Database.Password = redacted
+	}
+	if c.Database.URL != "" {
+		if u, err := url.Parse(c.Database.URL); err == nil {
+			c.Database.URL = u.Redacted()
+		} else {
+			c.Database.URL = redacted
+		}
+	}
+
+	if c.Cache.InvalidationURL != "" {
+		if u, err := url.Parse(c.Cache.InvalidationURL); err == nil {
+			c.Cache.InvalidationURL = u.Redacted()
+		} else {
+			c.Cache.InvalidationURL = redacted
+		}
+	}
+
+	if c.Search.ElasticsearchURL != "" {
+		if u, err := url.Parse(c.Search.ElasticsearchURL); err == nil {
+			c.Search.ElasticsearchURL = u.Redacted()
+		} else {
+			c.Search.ElasticsearchURL = redacted
+		}
+	}
+
+	if c.Reporting.DSN != "" {
+		if u, err := url.Parse(c.Reporting.DSN); err == nil && u.User != nil {
+			u.User = url.User(redacted)
+			c.Reporting.DSN = u.String()
+		} else {
+			c.Reporting.DSN = redacted
+		}
+	}
+
+	if c.Email.Password != "" {
+		c.Email.Password = redacted
+	}
+	if c.Email.SESSecretAccessKey != "" {
+		c.Email.SESSecretAccessKey = redacted
+	}
+
+	if c.Auth.CursorSecret != "" {
+		c.Auth.CursorSecret = redacted
+	}
+
+	keys := make([]string, len(c.Auth.APIKeys))
+	for i, entry := range c.Auth.APIKeys {
+		label, rest, _ := strings.Cut(entry, ":")
+		role, _, _ := strings.Cut(rest, ":")
+		keys[i] = label + ":" + role + ":" + redacted
+	}
+	c.Auth.APIKeys = keys
+
+	ttls := make(map[string]time.Duration, len(c.Cache.TTLs))
+	for endpoint := range defaultTTLs {
+		ttls[endpoint] = c.Cache.TTL(endpoint)
+	}
+	for endpoint, ttl := range c.Cache.TTLs {
+		ttls[endpoint] = ttl
+	}
+	c.Cache.TTLs = ttls
+
+	return c
+}
+
+// Map renders the configuration with its file keys and readable durations (15m0s)
+func (c Config) Map() (map[string]interface{}, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	err = yaml.Unmarshal(data, &m)
+	return m, err
+}
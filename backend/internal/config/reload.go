@@ -0,0 +1,61 @@
+package config
+
+import (
+	"log"
+	"reflect"
+	"sync"
+)
+
+var (
+	hooksMu     sync.Mutex
+	reloadHooks []func(old, next *Config)
+)
+
+// OnReload registers fn to run after every successful Reload
+func OnReload(fn func(old, next *Config)) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	reloadHooks = append(reloadHooks, fn)
+}
+
+// Reload re-reads the configuration file and environment. Structural settings (server, tls,
+// database, export, images, jobs) need a restart, so their running values are kept and a warning is
+// logged; everything else takes effect immediately. On error nothing changes.
+func Reload() (*Config, error) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	old := Get()
+	next, err := read()
+	if err != nil {
+		return nil, err
+	}
+
+	if next.Server != old.Server {
+		log.Println("⚠️ server settings changed; restart to apply")
+	}
+	if !reflect.DeepEqual(next.TLS, old.TLS) {
+		log.Println("⚠️ tls settings changed; restart to apply")
+	}
+	if next.Database != old.Database {
+		log.Println("⚠️ database settings changed; restart to apply")
+	}
+	if next.Export != old.Export {
+		log.Println("⚠️ export settings changed; restart to apply")
+	}
+	if next.Images != old.Images {
+		log.Println("⚠️ images settings changed; restart to apply")
+	}
+	if next.Jobs != old.Jobs {
+		log.Println("⚠️ jobs settings changed; restart to apply")
+	}
+	next.Server, next.TLS, next.Database, next.Export, next.Images, next.Jobs = old.Server, old.TLS, old.Database, old.Export, old.Images, old.Jobs
+
+	current.Store(next)
+	for _, hook := range reloadHooks {
+		hook(old, next)
+	}
+
+	log.Println("🔄 Configuration reloaded")
+	return next, nil
+}
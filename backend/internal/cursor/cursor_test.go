@@ -0,0 +1,53 @@
+package cursor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type position struct {
+	Sort string `json:"s"`
+	ID   int    `json:"id"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	token, err := Encode("politicians", position{Sort: "-votes", ID: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got position
+	if err := Decode("politicians", token, &got); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got != (position{Sort: "-votes", ID: 42}) {
+		t.Errorf("position = %+v", got)
+	}
+}
+
+func TestRejectsForgedAndForeignTokens(t *testing.T) {
+	token, err := Encode("politicians", position{ID: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged, _ := Encode("politicians", position{ID: 43})
+	payload, _, _ := strings.Cut(forged, ".")
+	_, signature, _ := strings.Cut(token, ".")
+
+	for name, s := range map[string]string{
+		"other list":        token,
+		"swapped payload":   payload + "." + signature,
+		"missing signature": payload,
+		"garbage":           "not-a-cursor",
+	} {
+		list := "politicians"
+		if name == "other list" {
+			list = "expenses"
+		}
+		var got position
+		if err := Decode(list, s, &got); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: err = %v, want ErrInvalid", name, err)
+		}
+	}
+}
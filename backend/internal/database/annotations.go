@@ -0,0 +1,175 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// AnnotationViewer is who reads annotations: everyone sees public ones, Team members (researcher
+// and admin keys) team ones, and the Actor their own; an Admin sees all of them
+type AnnotationViewer struct {
+	Actor string
+	Team  bool
+	Admin bool
+}
+
+// annotationVisible matches the annotations a that the viewer in parameters $n (actor), $n+1
+// (team) and $n+2 (admin) may see
+func annotationVisible(n int) string {
+	return fmt.Sprintf(`(a.visibility = '%s' OR ($%d AND a.visibility = '%s') OR a.author = $%d OR $%d)`,
+		models.VisibilityPublic, n+1, models.VisibilityTeam, n, n+2)
+}
+
+// AnnotationFilter narrows GetAnnotations; zero values match everything
+type AnnotationFilter struct {
+	EntityType string
+	EntityID   string
+	Tag        string
+	Author     string
+}
+
+// annotationSelect is the shared projection for annotation queries
+const annotationSelect = `
+		SELECT a.id, a.entity_type, a.entity_id, COALESCE(a.tag, ''), COALESCE(a.note, ''),
+		       a.visibility, a.author, a.created_at, a.updated_at
+		FROM annotations a
+`
+
+// scanAnnotation scans a row produced by annotationSelect
+func scanAnnotation(row interface{ Scan(...interface{}) error }) (models.Annotation, error) {
+	var a models.Annotation
+	err := row.Scan(&a.ID, &a.EntityType, &a.EntityID, &a.Tag, &a.Note, &a.Visibility, &a.Author,
+		&a.CreatedAt, &a.UpdatedAt)
+	return a, err
+}
+
+// CreateAnnotation stores a and fills in its ID and times
+func CreateAnnotation(a *models.Annotation) error {
+	err := DB.QueryRow(`
+		INSERT INTO annotations (entity_type, entity_id, tag, note, visibility, author)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6)
+		RETURNING id, created_at, updated_at
+	`, a.EntityType, a.EntityID, a.Tag, a.Note, a.Visibility, a.Author).Scan(&a.ID, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create annotation: %w", err)
+	}
+	return nil
+}
+
+// GetAnnotations retrieves the annotations viewer may see, newest first
+func GetAnnotations(filter AnnotationFilter, viewer AnnotationViewer, limit, offset int) ([]models.Annotation, error) {
+	rows, err := DB.Query(annotationSelect+`
+		WHERE ($1 = '' OR a.entity_type = $1)
+		  AND ($2 = '' OR a.entity_id = $2)
+		  AND ($3 = '' OR LOWER(a.tag) = LOWER($3))
+		  AND ($4 = '' OR a.author = $4)
+		  AND `+annotationVisible(5)+`
+		ORDER BY a.created_at DESC, a.id DESC
+		LIMIT $8 OFFSET $9
+	`, filter.EntityType, filter.EntityID, filter.Tag, filter.Author,
+		viewer.Actor, viewer.Team, viewer.Admin, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query annotations: %w", err)
+	}
+	defer rows.Close()
+
+	var annotations []models.Annotation
+	for rows.Next() {
+		a, err := scanAnnotation(rows)
+		if err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, a)
+	}
+	return annotations, rows.Err()
+}
+
+// GetAnnotation retrieves one annotation whatever its visibility; sql.ErrNoRows is returned when
+// it doesn't exist
+func GetAnnotation(id int64) (models.Annotation, error) {
+	return scanAnnotation(DB.QueryRow(annotationSelect+" WHERE a.id = $1", id))
+}
+
+// UpdateAnnotation replaces an annotation's tag, note and visibility
+func UpdateAnnotation(a *models.Annotation) error {
+	err := DB.QueryRow(`
+		UPDATE annotations
+		SET tag = NULLIF($2, ''), note = NULLIF($3, ''), visibility = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at
+	`, a.ID, a.Tag, a.Note, a.Visibility).Scan(&a.UpdatedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to update annotation: %w", err)
+	}
+	return err
+}
+
+// DeleteAnnotation removes an annotation
+func DeleteAnnotation(id int64) error {
+	if _, err := DB.Exec(`DELETE FROM annotations WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete annotation: %w", err)
+	}
+	return nil
+}
+
+// GetTags lists the tags viewer may see, most used first
+func GetTags(viewer AnnotationViewer, limit int) ([]models.TagCount, error) {
+	rows, err := DB.Query(`
+		SELECT MIN(a.tag),
+		       COUNT(DISTINCT CASE WHEN a.entity_type = $4 THEN a.entity_id END),
+		       COUNT(DISTINCT CASE WHEN a.entity_type = $5 THEN a.entity_id END)
+		FROM annotations a
+		WHERE a.tag IS NOT NULL AND `+annotationVisible(1)+`
+		GROUP BY LOWER(a.tag)
+		ORDER BY COUNT(DISTINCT a.entity_type || a.entity_id) DESC, MIN(a.tag)
+		LIMIT $6
+	`, viewer.Actor, viewer.Team, viewer.Admin, models.AnnotatePolitician, models.AnnotateCompany, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []models.TagCount{}
+	for rows.Next() {
+		var t models.TagCount
+		if err := rows.Scan(&t.Tag, &t.Politicians, &t.Companies); err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// TaggedEntities returns the IDs of the politicians and the CNPJs of the companies carrying tag
+// in an annotation viewer may see
+func TaggedEntities(tag string, viewer AnnotationViewer) (politicians []string, companies []string, err error) {
+	// Annotations live in Postgres only; SQLite files and the demo dataset have none
+	if DB == nil || sqlite {
+		return []string{}, []string{}, nil
+	}
+
+	rows, err := DB.Query(`
+		SELECT DISTINCT a.entity_type, a.entity_id
+		FROM annotations a
+		WHERE LOWER(a.tag) = LOWER($4) AND `+annotationVisible(1)+`
+	`, viewer.Actor, viewer.Team, viewer.Admin, tag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query tagged entities: %w", err)
+	}
+	defer rows.Close()
+
+	politicians, companies = []string{}, []string{}
+	for rows.Next() {
+		var entityType, entityID string
+		if err := rows.Scan(&entityType, &entityID); err != nil {
+			return nil, nil, err
+		}
+		if entityType == models.AnnotatePolitician {
+			politicians = append(politicians, entityID)
+		} else {
+			companies = append(companies, entityID)
+		}
+	}
+	return politicians, companies, rows.Err()
+}
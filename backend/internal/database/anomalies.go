@@ -0,0 +1,74 @@
+package database
+
+import (
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// Anomaly types, as written by detect-anomalies
+const (
+	AnomalyGeoMismatch = "geo_mismatch"
+	AnomalyDuplicate   = "duplicate"
+)
+
+// AnomalyTypes lists every anomaly type ?type= accepts
+var AnomalyTypes = []string{AnomalyGeoMismatch, AnomalyDuplicate}
+
+// AnomalyFilter narrows GetAnomalies; zero values match everything
+type AnomalyFilter struct {
+	Type         string
+	PoliticianID int
+}
+
+// GetAnomalies retrieves expense anomalies, largest amount first. It returns none until cli4
+// detect-anomalies has run.
+func GetAnomalies(filter AnomalyFilter, limit, offset int) ([]models.Anomaly, error) {
+	if ingested, err := tableExists("expense_anomalies"); err != nil || !ingested {
+		return nil, err
+	}
+
+	query := `
+		SELECT
+			a.id,
+			a.anomaly_type,
+			a.politician_id,
+			COALESCE(p.nome_eleitoral, p.nome_civil, 'Unknown'),
+			COALESCE(a.cnpj_cpf, ''),
+			COALESCE(a.counterpart_name, ''),
+			a.record_count,
+			a.total_amount,
+			COALESCE(a.first_date::text, ''),
+			COALESCE(a.last_date::text, ''),
+			COALESCE(a.details, '{}'::jsonb)::text
+		FROM expense_anomalies a
+		JOIN unified_politicians p ON p.id = a.politician_id
+		WHERE ($1 = '' OR a.anomaly_type = $1)
+		  AND ($2 = 0 OR a.politician_id = $2)
+		ORDER BY a.total_amount DESC NULLS LAST, a.id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := DB.Query(query, filter.Type, filter.PoliticianID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query anomalies: %w", err)
+	}
+	defer rows.Close()
+
+	var anomalies []models.Anomaly
+	for rows.Next() {
+		var a models.Anomaly
+		var details string
+		err := rows.Scan(
+			&a.ID, &a.Type, &a.PoliticianID, &a.PoliticianName, &a.CNPJCPF, &a.CounterpartName,
+			&a.RecordCount, &a.TotalAmount, &a.FirstDate, &a.LastDate, &details,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan anomaly: %w", err)
+		}
+
+		a.Details = []byte(details)
+		anomalies = append(anomalies, a)
+	}
+
+	return anomalies, nil
+}
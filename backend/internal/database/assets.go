@@ -0,0 +1,97 @@
+package database
+
+import (
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// GetPoliticianAssetDeclarations retrieves a politician's TSE asset declarations (loaded by
+// cli4 populate-wealth), oldest first. Dates are the declaration's reference date, or the
+// candidacy registration deadline of its year when the TSE gave none.
+func GetPoliticianAssetDeclarations(politicianID int) ([]models.AssetDeclaration, []string, error) {
+	query := `
+		SELECT
+			year,
+			COALESCE(election_year, 0),
+			COALESCE(number_of_assets, 0),
+			total_declared_wealth,
+			COALESCE(real_estate_value, 0),
+			COALESCE(vehicles_value, 0),
+			COALESCE(investments_value, 0),
+			COALESCE(business_value, 0),
+			COALESCE(cash_deposits_value, 0),
+			COALESCE(other_assets_value, 0),
+			COALESCE(reference_date, make_date(year, 8, 15))::text
+		FROM unified_wealth_tracking
+		WHERE politician_id = $1
+		ORDER BY year
+	`
+
+	rows, err := DB.Query(query, politicianID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query asset declarations: %w", err)
+	}
+	defer rows.Close()
+
+	declarations := []models.AssetDeclaration{}
+	var dates []string
+	for rows.Next() {
+		var d models.AssetDeclaration
+		var date string
+		err := rows.Scan(
+			&d.Year, &d.ElectionYear, &d.AssetCount, &d.TotalDeclared,
+			&d.RealEstate, &d.Vehicles, &d.Investments, &d.Business, &d.CashDeposits, &d.Other,
+			&date,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan asset declaration: %w", err)
+		}
+
+		declarations = append(declarations, d)
+		dates = append(dates, date)
+	}
+
+	return declarations, dates, nil
+}
+
+// GetPoliticianDeclaredAssets retrieves the items of a politician's declarations keyed by
+// declaration year, most valuable first, up to limit per year
+func GetPoliticianDeclaredAssets(politicianID, limit int) (map[int][]models.DeclaredAsset, error) {
+	query := `
+		SELECT declaration_year, asset_sequence, asset_type_code, asset_type_description,
+		       asset_description, declared_value
+		FROM (
+			SELECT
+				declaration_year,
+				COALESCE(asset_sequence, 0) as asset_sequence,
+				COALESCE(asset_type_code, 0) as asset_type_code,
+				COALESCE(asset_type_description, '') as asset_type_description,
+				COALESCE(asset_description, '') as asset_description,
+				declared_value,
+				ROW_NUMBER() OVER (PARTITION BY declaration_year ORDER BY declared_value DESC, asset_sequence) as rank
+			FROM politician_assets
+			WHERE politician_id = $1
+		) a
+		WHERE rank <= $2
+		ORDER BY declaration_year, declared_value DESC, asset_sequence
+	`
+
+	rows, err := DB.Query(query, politicianID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query declared assets: %w", err)
+	}
+	defer rows.Close()
+
+	items := map[int][]models.DeclaredAsset{}
+	for rows.Next() {
+		var year int
+		var a models.DeclaredAsset
+		if err := rows.Scan(&year, &a.Sequence, &a.TypeCode, &a.Type, &a.Description, &a.Value); err != nil {
+			return nil, fmt.Errorf("failed to scan declared asset: %w", err)
+		}
+
+		items[year] = append(items[year], a)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"political-network-api/internal/models"
+)
+
+// RecordAudit appends an entry to the audit log
+func RecordAudit(entry models.AuditEntry) error {
+	payload, err := json.Marshal(entry.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit payload: %w", err)
+	}
+
+	_, err = DB.Exec(`
+		INSERT INTO audit_log (actor, role, action, resource, payload)
+		VALUES ($1, $2, $3, $4, $5)
+	`, entry.Actor, entry.Role, entry.Action, entry.Resource, payload)
+	if err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// GetAuditLog retrieves audit entries newest first, optionally filtered by action and actor
+func GetAuditLog(action, actor string, limit, offset int) ([]models.AuditEntry, error) {
+	query := `
+		SELECT id, actor, role, action, COALESCE(resource, ''), payload, created_at
+		FROM audit_log
+		WHERE ($1 = '' OR action = $1)
+		  AND ($2 = '' OR actor = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := DB.Query(query, action, actor, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.AuditEntry
+	for rows.Next() {
+		var e models.AuditEntry
+		var payload []byte
+		if err := rows.Scan(&e.ID, &e.Actor, &e.Role, &e.Action, &e.Resource, &payload, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		if len(payload) > 0 {
+			if err := json.Unmarshal(payload, &e.Payload); err != nil {
+				log.Printf("Error decoding audit payload %d: %v", e.ID, err)
+			}
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,122 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ChangeFilter selects a page of the change feed: changes to entity recorded after since (zero
+// = from the start) with an ID above after
+type ChangeFilter struct {
+	Entity string
+	Since  time.Time
+	After  int64
+}
+
+// GetChanges retrieves up to limit changes in feed order, each with the record as it is now
+func GetChanges(filter ChangeFilter, limit int) ([]models.Change, error) {
+	rows, err := DB.Query(`
+		SELECT id, entity, record_id, operation, changed_at
+		FROM record_changes
+		WHERE entity = $1
+		  AND ($2::timestamp IS NULL OR changed_at > $2)
+		  AND id > $3
+		ORDER BY id
+		LIMIT $4
+	`, filter.Entity, nullTime(filter.Since), filter.After, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []models.Change{}
+	var ids []int64
+	for rows.Next() {
+		var ch models.Change
+		if err := rows.Scan(&ch.ID, &ch.Entity, &ch.RecordID, &ch.Operation, &ch.ChangedAt); err != nil {
+			return nil, err
+		}
+		if ch.Operation != models.ChangeDeleted {
+			ids = append(ids, int64(ch.RecordID))
+		}
+		changes = append(changes, ch)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return changes, nil
+	}
+
+	records, err := changedRecords(filter.Entity, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range changes {
+		if changes[i].Operation == models.ChangeDeleted {
+			continue
+		}
+		if record, ok := records[changes[i].RecordID]; ok {
+			changes[i].Record = record
+		}
+	}
+	return changes, nil
+}
+
+// changedRecords loads the current records of changed rows by ID
+func changedRecords(entity string, ids []int64) (map[int]interface{}, error) {
+	records := map[int]interface{}{}
+
+	switch entity {
+	case models.ChangePoliticians:
+		rows, err := DB.Query(politicianSelect+" WHERE p.id = ANY($1)", pq.Array(ids))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query changed politicians: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			p, err := scanPolitician(rows)
+			if err != nil {
+				return nil, err
+			}
+			records[p.ID] = p
+		}
+		return records, rows.Err()
+
+	case models.ChangeSanctions:
+		rows, err := DB.Query(allSanctionsSelect+" AND id = ANY($1)", pq.Array(ids))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query changed sanctions: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			s, err := scanSanction(rows)
+			if err != nil {
+				return nil, err
+			}
+			records[s.ID] = s
+		}
+		return records, rows.Err()
+	}
+
+	return nil, fmt.Errorf("unknown change entity %q", entity)
+}
+
+// DatasetVersionPublishedAt is when a dataset version was published; sql.ErrNoRows is returned
+// when it never was
+func DatasetVersionPublishedAt(version int) (time.Time, error) {
+	var publishedAt sql.NullTime
+	err := DB.QueryRow(`SELECT published_at FROM dataset_versions WHERE version = $1`, version).Scan(&publishedAt)
+	if err == nil && !publishedAt.Valid {
+		err = sql.ErrNoRows
+	}
+	return publishedAt.Time, err
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
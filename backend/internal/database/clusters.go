@@ -0,0 +1,207 @@
+package database
+
+import (
+	"fmt"
+	"math"
+	"political-network-api/internal/models"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Vendor cluster signals, as accepted by ?signal=
+const (
+	ClusterSignalParty = "party"
+	ClusterSignalState = "state"
+	ClusterSignalSplit = "split_invoices"
+)
+
+// A vendor's clients are concentrated when at least clusterMinPoliticians politicians pay it
+// and clusterDominantShare of them share a party or a state. Invoices are split when two or
+// more in a month each fall within splitMargin under one of splitThresholds, the dispensa de
+// licitação limits for purchases (Lei 8.666/93 until 2018, Decreto 9.412/2018, Lei 14.133/2021),
+// and together exceed it.
+const (
+	clusterMinPoliticians = 5
+	clusterDominantShare  = 0.8
+	splitMargin           = 0.9
+)
+
+var splitThresholds = []models.Money{8000_00, 17600_00, 50000_00}
+
+// GetVendorClusters finds the CEAP vendors with concentrated clients or split invoices,
+// largest total first
+func GetVendorClusters() ([]models.VendorCluster, error) {
+	splits, err := getSplitInvoices()
+	if err != nil {
+		return nil, err
+	}
+	splitCNPJs := make([]string, 0, len(splits))
+	for cnpj := range splits {
+		splitCNPJs = append(splitCNPJs, cnpj)
+	}
+
+	query := `
+		WITH served AS (
+			SELECT counterpart_cnpj_cpf as cnpj, politician_id, SUM(amount) as amount
+			FROM unified_financial_records
+			WHERE transaction_type = 'PARLIAMENTARY_EXPENSE'
+			  AND LENGTH(counterpart_cnpj_cpf) = 14
+			GROUP BY 1, 2
+		), candidates AS (
+			SELECT cnpj FROM served GROUP BY cnpj HAVING COUNT(*) >= $1
+			UNION
+			SELECT unnest($2::text[])
+		)
+		SELECT
+			s.cnpj,
+			COALESCE(fc.name, ''),
+			s.politician_id,
+			COALESCE(p.nome_civil, p.nome_eleitoral, 'Unknown'),
+			COALESCE(p.current_party, ''),
+			COALESCE(p.current_state, ''),
+			s.amount
+		FROM served s
+		JOIN candidates c ON c.cnpj = s.cnpj
+		JOIN unified_politicians p ON p.id = s.politician_id
+		LEFT JOIN financial_counterparts fc ON fc.cnpj_cpf = s.cnpj
+		ORDER BY s.cnpj, s.amount DESC
+	`
+
+	rows, err := DB.Query(query, clusterMinPoliticians, pq.Array(splitCNPJs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query vendor clients: %w", err)
+	}
+	defer rows.Close()
+
+	var vendors []models.VendorCluster
+	for rows.Next() {
+		var cnpj, nome string
+		var client models.VendorClient
+		err := rows.Scan(&cnpj, &nome, &client.PoliticianID, &client.Nome, &client.SiglaPartido, &client.UF, &client.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		if n := len(vendors); n == 0 || vendors[n-1].CNPJ != cnpj {
+			vendors = append(vendors, models.VendorCluster{CNPJ: cnpj, Nome: nome})
+		}
+		v := &vendors[len(vendors)-1]
+		v.Clients = append(v.Clients, client)
+		v.Politicians++
+		v.TotalAmount += client.Amount
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	clusters := []models.VendorCluster{}
+	for _, v := range vendors {
+		v.Signals = []string{}
+		if v.Politicians >= clusterMinPoliticians {
+			v.Party = dominantClients(v.Clients, func(c models.VendorClient) string { return c.SiglaPartido })
+			if v.Party != nil {
+				v.Signals = append(v.Signals, ClusterSignalParty)
+			}
+			v.State = dominantClients(v.Clients, func(c models.VendorClient) string { return c.UF })
+			if v.State != nil {
+				v.Signals = append(v.Signals, ClusterSignalState)
+			}
+		}
+		if v.SplitInvoices = splits[v.CNPJ]; len(v.SplitInvoices) > 0 {
+			v.Signals = append(v.Signals, ClusterSignalSplit)
+		}
+
+		if len(v.Signals) > 0 {
+			clusters = append(clusters, v)
+		}
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return clusters[i].TotalAmount > clusters[j].TotalAmount
+	})
+	return clusters, nil
+}
+
+// dominantClients returns the party or state (by key) of at least clusterDominantShare of the
+// clients, or nil when there is none
+func dominantClients(clients []models.VendorClient, key func(models.VendorClient) string) *models.ClientConcentration {
+	groups := map[string]*models.ClientConcentration{}
+	var top *models.ClientConcentration
+	for _, c := range clients {
+		k := key(c)
+		if k == "" {
+			continue
+		}
+		g, ok := groups[k]
+		if !ok {
+			g = &models.ClientConcentration{Key: k}
+			groups[k] = g
+		}
+		g.Politicians++
+		g.Amount += c.Amount
+		if top == nil || g.Politicians > top.Politicians {
+			top = g
+		}
+	}
+
+	if top == nil {
+		return nil
+	}
+	top.Share = math.Round(float64(top.Politicians)/float64(len(clients))*100) / 100
+	if float64(top.Politicians) < clusterDominantShare*float64(len(clients)) {
+		return nil
+	}
+	return top
+}
+
+// getSplitInvoices returns the split invoices of each vendor, by CNPJ
+func getSplitInvoices() (map[string][]models.SplitInvoices, error) {
+	thresholds := make([]string, len(splitThresholds))
+	for i, t := range splitThresholds {
+		thresholds[i] = t.String()
+	}
+
+	query := `
+		SELECT
+			fr.counterpart_cnpj_cpf,
+			fr.politician_id,
+			COALESCE(p.nome_civil, p.nome_eleitoral, 'Unknown'),
+			to_char(fr.transaction_date, 'YYYY-MM') as month,
+			t.threshold,
+			COUNT(*),
+			SUM(fr.amount),
+			COALESCE(STRING_AGG(fr.document_url, ' ' ORDER BY fr.transaction_date), '')
+		FROM unified_financial_records fr
+		JOIN unnest($1::numeric[]) as t(threshold)
+		  ON fr.amount >= t.threshold * $2 AND fr.amount < t.threshold
+		JOIN unified_politicians p ON p.id = fr.politician_id
+		WHERE fr.transaction_type = 'PARLIAMENTARY_EXPENSE'
+		  AND LENGTH(fr.counterpart_cnpj_cpf) = 14
+		GROUP BY 1, 2, 3, 4, 5
+		HAVING COUNT(*) >= 2
+		ORDER BY 1, 4
+	`
+
+	rows, err := DB.Query(query, pq.Array(thresholds), splitMargin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query split invoices: %w", err)
+	}
+	defer rows.Close()
+
+	splits := map[string][]models.SplitInvoices{}
+	for rows.Next() {
+		var cnpj, urls string
+		var s models.SplitInvoices
+		err := rows.Scan(&cnpj, &s.PoliticianID, &s.Nome, &s.Month, &s.Threshold, &s.Invoices, &s.Total, &urls)
+		if err != nil {
+			return nil, err
+		}
+
+		s.DocumentURLs = strings.Fields(urls)
+		splits[cnpj] = append(splits[cnpj], s)
+	}
+
+	return splits, rows.Err()
+}
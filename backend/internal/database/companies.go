@@ -0,0 +1,134 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// GetCompany retrieves a single company; sql.ErrNoRows is returned when it does not exist
+func (rd Reader) GetCompany(cnpj string) (models.Company, error) {
+	return selectOne(rd.q, "company", scanCompany, companySelect+`
+		  AND fc.cnpj_cpf = $1
+	`, cnpj)
+}
+
+// GetCompanyPayers retrieves every politician who paid a company, largest total first
+func (rd Reader) GetCompanyPayers(cnpj string) ([]models.CompanyPayer, error) {
+	rows, err := rd.q.Query(`
+		SELECT
+			p.id,
+			COALESCE(p.nome_civil, p.nome_eleitoral, 'Unknown') as nome,
+			COALESCE(p.current_party, '') as sigla_partido,
+			COALESCE(p.current_state, '') as uf,
+			COUNT(fr.id),
+			COALESCE(SUM(fr.amount), 0),
+			COALESCE(CAST(MIN(fr.transaction_date) AS TEXT), ''),
+			COALESCE(CAST(MAX(fr.transaction_date) AS TEXT), '')
+		FROM unified_financial_records fr
+		JOIN unified_politicians p ON p.id = fr.politician_id
+		WHERE fr.counterpart_cnpj_cpf = $1
+		GROUP BY p.id
+		ORDER BY SUM(fr.amount) DESC NULLS LAST, p.id
+	`, cnpj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query company payers: %w", err)
+	}
+	defer rows.Close()
+
+	payers := []models.CompanyPayer{}
+	scanned := scanErrors{what: "company payers"}
+	for rows.Next() {
+		var p models.CompanyPayer
+		err := rows.Scan(
+			&p.PoliticianID, &p.Nome, &p.SiglaPartido, &p.UF,
+			&p.TransactionCount, &p.TotalValue, &p.FirstPayment, &p.LastPayment,
+		)
+		if !scanned.keep(err) {
+			continue
+		}
+
+		payers = append(payers, p)
+	}
+
+	return rowsResult(payers, rows.Err(), &scanned)
+}
+
+// GetCompanySanctions retrieves every sanction registered against a CNPJ, expired ones included
+func (rd Reader) GetCompanySanctions(cnpj string) ([]models.Sanction, error) {
+	rows, err := rd.q.Query(allSanctionsSelect+`
+		  AND cnpj_cpf = $1
+		ORDER BY sanction_start_date DESC NULLS LAST, id
+	`, cnpj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query company sanctions: %w", err)
+	}
+	defer rows.Close()
+
+	sanctions := []models.Sanction{}
+	scanned := scanErrors{what: "company sanctions"}
+	for rows.Next() {
+		s, err := scanSanction(rows)
+		if !scanned.keep(err) {
+			continue
+		}
+
+		sanctions = append(sanctions, s)
+	}
+
+	return rowsResult(sanctions, rows.Err(), &scanned)
+}
+
+// GetCompanyOwners retrieves the partners of a company's CNPJ root, with the politician a partner
+// was confirmed to be by entity resolution. It returns nothing when company_partners hasn't been
+// created (cli4 populate-qsa is optional).
+func (rd Reader) GetCompanyOwners(cnpj string) ([]models.CompanyOwner, error) {
+	if ingested, err := rd.tableExists("company_partners"); err != nil || !ingested {
+		return nil, err
+	}
+
+	rows, err := rd.q.Query(`
+		SELECT
+			partner_name,
+			COALESCE(partner_type, ''),
+			COALESCE(partner_document, ''),
+			COALESCE(qualification, ''),
+			COALESCE(entry_date::text, ''),
+			COALESCE(age_range, ''),
+			l.politician_id
+		FROM company_partners cp
+		LEFT JOIN LATERAL (
+			SELECT entity_id::int as politician_id
+			FROM entity_links
+			WHERE source = 'RECEITA' AND entity_type = $2 AND status = $3
+			  AND source_key = cp.cnpj_root || ':' || cp.partner_document || ':' || cp.partner_name
+			ORDER BY confidence DESC
+			LIMIT 1
+		) l ON true
+		WHERE cp.cnpj_root = $1
+		ORDER BY entry_date NULLS LAST, partner_name
+	`, CNPJRoot(cnpj), models.LinkPolitician, models.LinkConfirmed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query company owners: %w", err)
+	}
+	defer rows.Close()
+
+	var owners []models.CompanyOwner
+	scanned := scanErrors{what: "company owners"}
+	for rows.Next() {
+		var o models.CompanyOwner
+		var politicianID sql.NullInt64
+		err := rows.Scan(&o.Nome, &o.Tipo, &o.Documento, &o.Qualificacao, &o.DataEntrada, &o.FaixaEtaria, &politicianID)
+		if !scanned.keep(err) {
+			continue
+		}
+		if politicianID.Valid {
+			id := int(politicianID.Int64)
+			o.PoliticianID = &id
+		}
+
+		owners = append(owners, o)
+	}
+
+	return rowsResult(owners, rows.Err(), &scanned)
+}
@@ -0,0 +1,172 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// CNPJRoot returns the 8-digit radical shared by a company's matriz and filiais,
+// or "" for CPFs and malformed identifiers
+func CNPJRoot(cnpj string) string {
+	if len(cnpj) != 14 {
+		return ""
+	}
+	return cnpj[:8]
+}
+
+// companyGroupSelect aggregates companies by CNPJ root. The group is named after its
+// matriz (branch 0001) when present, otherwise its largest branch.
+const companyGroupSelect = `
+		WITH branches AS (
+			SELECT
+				SUBSTR(fc.cnpj_cpf, 1, 8) as cnpj_root,
+				fc.cnpj_cpf,
+				COALESCE(fc.name, 'Unknown Company') as nome_empresa,
+				COALESCE(fc.transaction_count, 0) as transaction_count,
+				COALESCE(fc.total_transaction_amount, 0) as total_value,
+				ROW_NUMBER() OVER (
+					PARTITION BY SUBSTR(fc.cnpj_cpf, 1, 8)
+					ORDER BY SUBSTR(fc.cnpj_cpf, 9, 4) = '0001' DESC, COALESCE(fc.total_transaction_amount, 0) DESC
+				) as name_rank
+			FROM financial_counterparts fc
+			WHERE fc.entity_type = 'COMPANY'
+			  AND LENGTH(fc.cnpj_cpf) = 14
+		)
+		SELECT
+			cnpj_root,
+			MAX(CASE WHEN name_rank = 1 THEN nome_empresa END),
+			COUNT(*) as branch_count,
+			SUM(transaction_count),
+			SUM(total_value) as total_value
+		FROM branches
+`
+
+// scanCompanyGroup scans a row produced by companyGroupSelect
+func scanCompanyGroup(rows *sql.Rows) (models.CompanyGroup, error) {
+	var g models.CompanyGroup
+	err := rows.Scan(&g.CNPJRoot, &g.NomeEmpresa, &g.BranchCount, &g.TransactionCount, &g.TotalValue)
+	g.ID = g.CNPJRoot
+	return g, err
+}
+
+// GetCompanyGroups retrieves corporate groups with more than one branch, largest first
+func GetCompanyGroups(limit, offset int) ([]models.CompanyGroup, error) {
+	query := companyGroupSelect + `
+		GROUP BY cnpj_root
+		HAVING COUNT(*) > 1
+		ORDER BY total_value DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := DB.Query(query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query company groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []models.CompanyGroup
+	for rows.Next() {
+		g, err := scanCompanyGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan company group: %w", err)
+		}
+
+		groups = append(groups, g)
+	}
+
+	return groups, nil
+}
+
+// GetCompanyGroup retrieves one corporate group with its branches; sql.ErrNoRows is
+// returned when no company has the root
+func GetCompanyGroup(root string) (models.CompanyGroup, error) {
+	rows, err := DB.Query(companyGroupSelect+`
+		WHERE cnpj_root = $1
+		GROUP BY cnpj_root
+	`, root)
+	if err != nil {
+		return models.CompanyGroup{}, fmt.Errorf("failed to query company group: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return models.CompanyGroup{}, err
+		}
+		return models.CompanyGroup{}, sql.ErrNoRows
+	}
+	group, err := scanCompanyGroup(rows)
+	if err != nil {
+		return group, err
+	}
+	rows.Close()
+
+	branchRows, err := DB.Query(companySelect+`
+		  AND SUBSTR(fc.cnpj_cpf, 1, 8) = $1
+		  AND LENGTH(fc.cnpj_cpf) = 14
+		ORDER BY fc.cnpj_cpf
+	`, root)
+	if err != nil {
+		return group, fmt.Errorf("failed to query company group branches: %w", err)
+	}
+	defer branchRows.Close()
+
+	for branchRows.Next() {
+		c, err := scanCompany(branchRows)
+		if err != nil {
+			return group, err
+		}
+		group.Branches = append(group.Branches, c)
+	}
+
+	return group, branchRows.Err()
+}
+
+// getGroupFinancialConnections creates politician-company group connections for groups
+// paid through more than one branch, using the same thresholds as company connections
+func getGroupFinancialConnections(limit int) ([]models.Connection, error) {
+	query := `
+		SELECT
+			fr.politician_id,
+			SUBSTR(fr.counterpart_cnpj_cpf, 1, 8) as cnpj_root,
+			COUNT(*) as transaction_count,
+			SUM(fr.amount) as total_value
+		FROM unified_financial_records fr
+		WHERE LENGTH(fr.counterpart_cnpj_cpf) = 14
+		  AND fr.amount > 0
+		GROUP BY fr.politician_id, SUBSTR(fr.counterpart_cnpj_cpf, 1, 8)
+		HAVING COUNT(DISTINCT fr.counterpart_cnpj_cpf) > 1
+		   AND (COUNT(*) >= 2 OR SUM(fr.amount) > 50000)
+		ORDER BY total_value DESC
+		LIMIT $1
+	`
+
+	rows, err := DB.Query(query, sqlLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var connections []models.Connection
+	for rows.Next() {
+		var politicianID int
+		var root string
+		var transactionCount int
+		var totalValue models.Money
+
+		if err := rows.Scan(&politicianID, &root, &transactionCount, &totalValue); err != nil {
+			return nil, err
+		}
+
+		connections = append(connections, models.Connection{
+			SourceID: fmt.Sprintf("politician_%d", politicianID),
+			TargetID: fmt.Sprintf("%s_%s", models.NodeTypeCompanyGroup, root),
+			Type:     "financial_group",
+			Value:    totalValue,
+			Strength: ConnectionStrength(transactionCount),
+		})
+	}
+
+	return connections, rows.Err()
+}
@@ -1,83 +1,52 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
 	"log"
-	"os"
-	"strconv"
-	"time"
-
-	_ "github.com/lib/pq"
+	"political-network-api/internal/config"
 )
 
 var DB *sql.DB
 
-// Config holds database configuration
-type Config struct {
-	Host         string
-	Port         int
-	User         string
-	Password     string
-	DBName       string
-	SSLMode      string
-	MaxOpenConns int
-	MaxIdleConns int
-	MaxLifetime  time.Duration
-}
-
-// LoadConfig loads database configuration from environment
-func LoadConfig() *Config {
-	port, _ := strconv.Atoi(getEnv("DB_PORT", "5432"))
-	maxConns, _ := strconv.Atoi(getEnv("MAX_DB_CONNECTIONS", "50"))
-
-	return &Config{
-		Host:         getEnv("DB_HOST", "localhost"),
-		Port:         port,
-		User:         getEnv("DB_USER", "postgres"),
-		Password:     getEnv("DB_PASSWORD", ""),
-		DBName:       getEnv("DB_NAME", "political_transparency"),
-		SSLMode:      getEnv("DB_SSLMODE", "disable"),
-		MaxOpenConns: maxConns,
-		MaxIdleConns: maxConns / 2,
-		MaxLifetime:  5 * time.Minute,
-	}
-}
-
-// Initialize establishes database connection with optimized settings
-func Initialize() error {
-	// Check for POSTGRES_POOL_URL first (for production)
-	poolURL := os.Getenv("POSTGRES_POOL_URL")
+// connString is the connection string DB was opened with, for connections outside its pool
+var connString string
 
+// Initialize establishes database connection with optimized settings. New connections retry
+// transient failures with backoff behind a circuit breaker (see resilience.go).
+func Initialize(cfg config.DatabaseConfig) error {
 	var connStr string
-	var maxConns int
 
-	if poolURL != "" {
-		// Use pool URL directly
-		connStr = poolURL
-		maxConns = 25 // Conservative for shared pool
+	if cfg.URL != "" {
+		// Use pool URL directly (for production)
+		connStr = cfg.URL
 		log.Println("🔗 Using PostgreSQL Pool URL")
 	} else {
-		// Build from individual environment variables
-		config := LoadConfig()
+		// Build from individual settings
 		connStr = fmt.Sprintf(
 			"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-			config.Host, config.Port, config.User, config.Password, config.DBName, config.SSLMode,
+			cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode,
 		)
-		maxConns = config.MaxOpenConns
 		log.Println("🔗 Using individual DB config")
 	}
+	maxConns := cfg.MaxOpenConns
 
-	var err error
-	DB, err = sql.Open("postgres", connStr)
+	connString = connStr
+	connector, err := newResilientConnector(connStr, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
+	DB = sql.OpenDB(connector)
+	slowQueryThreshold = cfg.SlowQueryThreshold
+	maxDroppedRatio = cfg.MaxDroppedRatio
 
 	// Configure connection pool for high performance
 	DB.SetMaxOpenConns(maxConns)
 	DB.SetMaxIdleConns(maxConns / 2)
-	DB.SetConnMaxLifetime(5 * time.Minute)
+	DB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
 	// Test connection
 	if err := DB.Ping(); err != nil {
@@ -85,9 +54,26 @@ func Initialize() error {
 	}
 
 	log.Printf("✅ Database connected successfully (max_conns: %d)", maxConns)
-	return nil
+	return Migrate()
 }
 
+// ErrNoDatabase is what every query fails with after OpenNone
+var ErrNoDatabase = errors.New("no database: the API is serving the demo dataset")
+
+// OpenNone sets DB to a handle with no database behind it, for demo mode: the handlers read the
+// bundled dataset through repositories, and anything reaching for DB anyway fails with
+// ErrNoDatabase instead of dereferencing a nil DB
+func OpenNone() {
+	DB = sql.OpenDB(noDatabase{})
+}
+
+// noDatabase is a driver.Connector that never connects
+type noDatabase struct{}
+
+func (noDatabase) Connect(context.Context) (driver.Conn, error) { return nil, ErrNoDatabase }
+func (n noDatabase) Driver() driver.Driver                      { return n }
+func (noDatabase) Open(string) (driver.Conn, error)             { return nil, ErrNoDatabase }
+
 // Close closes the database connection
 func Close() error {
 	if DB != nil {
@@ -111,11 +97,3 @@ func GetStats() sql.DBStats {
 	}
 	return DB.Stats()
 }
-
-// getEnv gets environment variable with default value
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
\ No newline at end of file
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"political-network-api/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// CorrectionFilter narrows GetCorrections; zero values match everything
+type CorrectionFilter struct {
+	Status     string
+	EntityType string
+	EntityID   string
+}
+
+// CreateCorrection stores c in the moderation queue and fills in its ID, status and creation time
+func CreateCorrection(c *models.Correction) error {
+	err := DB.QueryRow(`
+		INSERT INTO corrections
+			(entity_type, entity_id, field, current_value, proposed_value, description, evidence, contact, submitted_by)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), $6, $7, NULLIF($8, ''), $9)
+		RETURNING id, status, created_at
+	`, c.EntityType, c.EntityID, c.Field, c.CurrentValue, c.ProposedValue, c.Description,
+		pq.Array(c.Evidence), c.Contact, c.SubmittedBy).Scan(&c.ID, &c.Status, &c.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create correction: %w", err)
+	}
+	return nil
+}
+
+const correctionSelect = `
+		SELECT id, entity_type, entity_id, COALESCE(field, ''), COALESCE(current_value, ''),
+		       COALESCE(proposed_value, ''), description, evidence, COALESCE(contact, ''), submitted_by,
+		       status, COALESCE(value, ''), COALESCE(note, ''), COALESCE(reviewed_by, ''),
+		       reviewed_at, created_at
+		FROM corrections
+`
+
+func scanCorrection(row interface{ Scan(...interface{}) error }) (models.Correction, error) {
+	var c models.Correction
+	var reviewedAt sql.NullTime
+	err := row.Scan(
+		&c.ID, &c.EntityType, &c.EntityID, &c.Field, &c.CurrentValue, &c.ProposedValue,
+		&c.Description, pq.Array(&c.Evidence), &c.Contact, &c.SubmittedBy,
+		&c.Status, &c.Value, &c.Note, &c.ReviewedBy, &reviewedAt, &c.CreatedAt,
+	)
+	if reviewedAt.Valid {
+		c.ReviewedAt = &reviewedAt.Time
+	}
+	if c.Evidence == nil {
+		c.Evidence = []string{}
+	}
+	return c, err
+}
+
+// GetCorrections retrieves corrections, oldest first so the queue is worked in order
+func GetCorrections(filter CorrectionFilter, limit, offset int) ([]models.Correction, error) {
+	rows, err := DB.Query(correctionSelect+`
+		WHERE ($1 = '' OR status = $1)
+		  AND ($2 = '' OR entity_type = $2)
+		  AND ($3 = '' OR entity_id = $3)
+		ORDER BY created_at, id
+		LIMIT $4 OFFSET $5
+	`, filter.Status, filter.EntityType, filter.EntityID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query corrections: %w", err)
+	}
+	defer rows.Close()
+
+	var corrections []models.Correction
+	for rows.Next() {
+		c, err := scanCorrection(rows)
+		if err != nil {
+			return nil, err
+		}
+		corrections = append(corrections, c)
+	}
+	return corrections, rows.Err()
+}
+
+// GetCorrection retrieves one correction; sql.ErrNoRows is returned when it doesn't exist
+func GetCorrection(id int64) (models.Correction, error) {
+	return scanCorrection(DB.QueryRow(correctionSelect+" WHERE id = $1", id))
+}
+
+// ReviewCorrection accepts or rejects a correction; sql.ErrNoRows is returned when it doesn't
+// exist. Accepting one that names a field overrides the field with value in the same
+// transaction; value is empty when rejecting.
+func ReviewCorrection(id int64, status, value, note, reviewer string) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var entityType, entityID, field, description string
+	err = tx.QueryRow(`
+		UPDATE corrections
+		SET status = $2, value = CASE WHEN field IS NOT NULL THEN NULLIF($3, '') END,
+		    note = NULLIF($4, ''), reviewed_by = $5, reviewed_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING entity_type, entity_id, COALESCE(field, ''), description
+	`, id, status, value, note, reviewer).Scan(&entityType, &entityID, &field, &description)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		return fmt.Errorf("failed to review correction: %w", err)
+	}
+
+	// Accepting overrides the field; a rejected correction takes its override with it, unless a
+	// later override of the field replaced it
+	if status == models.CorrectionAccepted && field != "" {
+		o := models.Override{
+			EntityType:   entityType,
+			EntityID:     entityID,
+			Field:        field,
+			Value:        value,
+			Reason:       description,
+			Author:       reviewer,
+			CorrectionID: &id,
+		}
+		if err := setOverride(tx, &o); err != nil {
+			return err
+		}
+	} else if _, err := tx.Exec(`DELETE FROM overrides WHERE correction_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to remove the correction's override: %w", err)
+	}
+	return tx.Commit()
+}
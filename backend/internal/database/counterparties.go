@@ -0,0 +1,139 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+	"time"
+)
+
+// spendingRecords is the condition matching the CEAP and campaign expenses among the
+// unified_financial_records x. A SQLite file has no transaction_type and holds only expenses.
+func spendingRecords() string {
+	if sqlite {
+		return "1 = 1"
+	}
+	return "x.transaction_type IN " + spendingTypes
+}
+
+// trendColumns sum the payments of x after $3 and those after $4 up to $3, the starts of the 12
+// months up to the latest payment and of the 12 before (see trendBounds). CASE rather than
+// FILTER and dates computed here rather than INTERVAL keep them portable to SQLite.
+const trendColumns = `
+	COALESCE(SUM(CASE WHEN x.transaction_date > $3 THEN x.amount END), 0),
+	COALESCE(SUM(CASE WHEN x.transaction_date <= $3 AND x.transaction_date > $4 THEN x.amount END), 0)`
+
+// trendBounds totals the expenses matched by condition (on unified_financial_records x, with
+// arg as $1) and returns the starts of the 12 months up to the latest one and of the 12 before.
+// ok is false when there are none.
+func (rd Reader) trendBounds(condition string, arg interface{}) (total models.Money, recent, previous string, ok bool, err error) {
+	var latest sql.NullString
+	err = rd.q.QueryRow(`
+		SELECT COALESCE(SUM(x.amount), 0), CAST(MAX(x.transaction_date) AS TEXT)
+		FROM unified_financial_records x
+		WHERE `+condition+` AND `+spendingRecords(), arg).Scan(&total, &latest)
+	if err != nil || !latest.Valid || len(latest.String) < 10 {
+		return total, "", "", false, err
+	}
+	day, err := time.Parse("2006-01-02", latest.String[:10])
+	if err != nil {
+		return total, "", "", false, fmt.Errorf("invalid transaction date %q: %w", latest.String, err)
+	}
+	return total, day.AddDate(-1, 0, 0).Format("2006-01-02"), day.AddDate(-2, 0, 0).Format("2006-01-02"), true, nil
+}
+
+// GetTopVendors ranks the limit vendors a politician paid the most, with their share of the
+// politician's spending (CEAP and campaign expenses, as in the dossier) and their trend
+func (rd Reader) GetTopVendors(politicianID, limit int) ([]models.TopVendor, error) {
+	vendors := []models.TopVendor{}
+	total, recent, previous, ok, err := rd.trendBounds("x.politician_id = $1", politicianID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top vendors: %w", err)
+	}
+	if !ok {
+		return vendors, nil
+	}
+
+	rows, err := rd.q.Query(`
+		SELECT
+			x.counterpart_cnpj_cpf,
+			COALESCE(MAX(x.counterpart_name), x.counterpart_cnpj_cpf),
+			SUM(x.amount),
+			COUNT(*),
+			COALESCE(CAST(MIN(x.transaction_date) AS TEXT), ''),
+			COALESCE(CAST(MAX(x.transaction_date) AS TEXT), ''),`+trendColumns+`
+		FROM unified_financial_records x
+		WHERE x.politician_id = $1 AND `+spendingRecords()+`
+		  AND x.counterpart_cnpj_cpf IS NOT NULL AND x.counterpart_cnpj_cpf != ''
+		GROUP BY x.counterpart_cnpj_cpf
+		ORDER BY SUM(x.amount) DESC, x.counterpart_cnpj_cpf
+		LIMIT $2
+	`, politicianID, limit, recent, previous)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top vendors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v models.TopVendor
+		var recent, previous models.Money
+		err := rows.Scan(&v.CNPJCPF, &v.Name, &v.Total, &v.Payments, &v.FirstDate, &v.LastDate,
+			&recent, &previous)
+		if err != nil {
+			return nil, err
+		}
+		v.Share = models.Share(v.Total, total)
+		v.Trend = models.NewCounterpartyTrend(recent, previous)
+		vendors = append(vendors, v)
+	}
+	return vendors, rows.Err()
+}
+
+// GetTopPoliticians ranks the limit politicians who paid a company the most in CEAP and
+// campaign expenses, with their share of what politicians paid it and their trend
+func (rd Reader) GetTopPoliticians(cnpj string, limit int) ([]models.TopPolitician, error) {
+	politicians := []models.TopPolitician{}
+	total, recent, previous, ok, err := rd.trendBounds("x.counterpart_cnpj_cpf = $1", cnpj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top politicians: %w", err)
+	}
+	if !ok {
+		return politicians, nil
+	}
+
+	rows, err := rd.q.Query(`
+		SELECT
+			p.id,
+			COALESCE(p.nome_civil, p.nome_eleitoral, 'Unknown'),
+			COALESCE(p.current_party, ''),
+			COALESCE(p.current_state, ''),
+			SUM(x.amount),
+			COUNT(*),
+			COALESCE(CAST(MIN(x.transaction_date) AS TEXT), ''),
+			COALESCE(CAST(MAX(x.transaction_date) AS TEXT), ''),`+trendColumns+`
+		FROM unified_financial_records x
+		JOIN unified_politicians p ON p.id = x.politician_id
+		WHERE x.counterpart_cnpj_cpf = $1 AND `+spendingRecords()+`
+		GROUP BY p.id, p.nome_civil, p.nome_eleitoral, p.current_party, p.current_state
+		ORDER BY SUM(x.amount) DESC, p.id
+		LIMIT $2
+	`, cnpj, limit, recent, previous)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top politicians: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p models.TopPolitician
+		var recent, previous models.Money
+		err := rows.Scan(&p.PoliticianID, &p.Nome, &p.SiglaPartido, &p.UF, &p.Total, &p.Payments,
+			&p.FirstDate, &p.LastDate, &recent, &previous)
+		if err != nil {
+			return nil, err
+		}
+		p.Share = models.Share(p.Total, total)
+		p.Trend = models.NewCounterpartyTrend(recent, previous)
+		politicians = append(politicians, p)
+	}
+	return politicians, rows.Err()
+}
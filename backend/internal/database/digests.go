@@ -0,0 +1,229 @@
+package database
+
+import (
+	"fmt"
+	"political-network-api/internal/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// digestItems caps the lines of each digest section
+const digestItems = 20
+
+// SubscribeDigest subscribes d.Email to the weekly digest of d.WatchlistID and fills in its ID
+// and creation time. Subscribing an address again resumes its digest if it had unsubscribed.
+// token is the unsubscribe token and baseURL the API address its link points at; the token is
+// stored as is because every digest links it, and all it can do is unsubscribe.
+func SubscribeDigest(d *models.WatchlistDigest, token, baseURL string) error {
+	err := DB.QueryRow(`
+		INSERT INTO watchlist_digests (watchlist_id, email, unsubscribe_token, base_url)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (watchlist_id, email) DO UPDATE SET unsubscribed_at = NULL, base_url = EXCLUDED.base_url
+		RETURNING id, last_sent_at, created_at
+	`, d.WatchlistID, d.Email, token, baseURL).Scan(&d.ID, &d.LastSentAt, &d.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to digest: %w", err)
+	}
+	return nil
+}
+
+// GetDigests lists the active digest subscriptions of a watchlist
+func GetDigests(watchlistID int64) ([]models.WatchlistDigest, error) {
+	rows, err := DB.Query(`
+		SELECT id, watchlist_id, email, last_sent_at, created_at
+		FROM watchlist_digests
+		WHERE watchlist_id = $1 AND unsubscribed_at IS NULL
+		ORDER BY id
+	`, watchlistID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query digests: %w", err)
+	}
+	defer rows.Close()
+
+	digests := []models.WatchlistDigest{}
+	for rows.Next() {
+		var d models.WatchlistDigest
+		if err := rows.Scan(&d.ID, &d.WatchlistID, &d.Email, &d.LastSentAt, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		digests = append(digests, d)
+	}
+	return digests, rows.Err()
+}
+
+// DeleteDigest removes a digest subscription of a watchlist, reporting whether it existed
+func DeleteDigest(watchlistID, id int64) (bool, error) {
+	res, err := DB.Exec(`DELETE FROM watchlist_digests WHERE watchlist_id = $1 AND id = $2`, watchlistID, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete digest: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// GetDigestByToken returns the watchlist name and address of the digest whose unsubscribe token
+// is token, without changing it. It returns sql.ErrNoRows for an unknown token.
+func GetDigestByToken(token string) (watchlist, email string, err error) {
+	err = DB.QueryRow(`
+		SELECT w.name, d.email
+		FROM watchlist_digests d
+		JOIN watchlists w ON w.id = d.watchlist_id
+		WHERE d.unsubscribe_token = $1
+	`, token).Scan(&watchlist, &email)
+	return watchlist, email, err
+}
+
+// UnsubscribeDigest stops the digest whose unsubscribe token is token and returns its watchlist's
+// name and address. Unsubscribing twice is fine. It returns sql.ErrNoRows for an unknown token.
+func UnsubscribeDigest(token string) (watchlist, email string, err error) {
+	err = DB.QueryRow(`
+		UPDATE watchlist_digests d
+		SET unsubscribed_at = COALESCE(d.unsubscribed_at, CURRENT_TIMESTAMP)
+		FROM watchlists w
+		WHERE w.id = d.watchlist_id AND d.unsubscribe_token = $1
+		RETURNING w.name, d.email
+	`, token).Scan(&watchlist, &email)
+	return watchlist, email, err
+}
+
+// GetDueDigests retrieves up to limit active digests not sent in the last period, with what
+// happened since they were last sent. A first digest covers the last period.
+func GetDueDigests(period time.Duration, limit int) ([]models.DueDigest, error) {
+	cutoff := time.Now().Add(-period)
+	rows, err := DB.Query(`
+		SELECT d.id, d.watchlist_id, w.name, d.email, d.unsubscribe_token, d.base_url,
+		       COALESCE(d.last_sent_at, $1), d.reported_anomalies
+		FROM watchlist_digests d
+		JOIN watchlists w ON w.id = d.watchlist_id
+		WHERE d.unsubscribed_at IS NULL
+		  AND (d.last_sent_at IS NULL OR d.last_sent_at <= $1)
+		ORDER BY d.last_sent_at NULLS FIRST, d.id
+		LIMIT $2
+	`, cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due digests: %w", err)
+	}
+	defer rows.Close()
+
+	var due []models.DueDigest
+	reported := map[int64][]string{}
+	for rows.Next() {
+		var d models.DueDigest
+		var keys []string
+		err := rows.Scan(&d.ID, &d.WatchlistID, &d.Watchlist, &d.Email, &d.Token, &d.BaseURL, &d.Since, pq.Array(&keys))
+		if err != nil {
+			return nil, err
+		}
+		reported[d.ID] = keys
+		due = append(due, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range due {
+		if err := loadDigest(&due[i], reported[due[i].ID]); err != nil {
+			return nil, err
+		}
+	}
+	return due, nil
+}
+
+// loadDigest fills in the alerts and anomalies of a due digest; reported are the anomalies
+// earlier digests listed
+func loadDigest(d *models.DueDigest, reported []string) error {
+	rows, err := DB.Query(`
+		SELECT event, summary
+		FROM watchlist_alerts
+		WHERE watchlist_id = $1 AND created_at > $2 AND event = ANY($3)
+		ORDER BY created_at, id
+	`, d.WatchlistID, d.Since, pq.Array([]string{models.EventNewSanction, models.EventScoreChange}))
+	if err != nil {
+		return fmt.Errorf("failed to query digest alerts: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var event, summary string
+		if err := rows.Scan(&event, &summary); err != nil {
+			return err
+		}
+		switch {
+		case event == models.EventNewSanction && len(d.Sanctions) < digestItems:
+			d.Sanctions = append(d.Sanctions, summary)
+		case event == models.EventScoreChange && len(d.ScoreChanges) < digestItems:
+			d.ScoreChanges = append(d.ScoreChanges, summary)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.AnomalyKeys = []string{}
+
+	// Unusual expenses count once detect-anomalies has run. Detection replaces every anomaly,
+	// so new ones are told apart by key rather than by date.
+	if detected, err := tableExists("expense_anomalies"); err != nil || !detected {
+		return err
+	}
+	anomalies, err := DB.Query(`
+		SELECT
+			a.anomaly_type || ':' || a.politician_id || ':' || COALESCE(a.cnpj_cpf, ''),
+			a.anomaly_type,
+			a.politician_id,
+			COALESCE(p.nome_eleitoral, p.nome_civil, 'Unknown'),
+			COALESCE(a.cnpj_cpf, ''),
+			COALESCE(a.counterpart_name, ''),
+			a.record_count,
+			a.total_amount,
+			COALESCE(a.first_date::text, ''),
+			COALESCE(a.last_date::text, '')
+		FROM expense_anomalies a
+		JOIN unified_politicians p ON p.id = a.politician_id
+		WHERE EXISTS (
+			SELECT 1 FROM watchlist_items i
+			WHERE i.watchlist_id = $1
+			  AND ((i.entity_type = 'politician' AND i.entity_id = a.politician_id::text)
+			    OR (i.entity_type = 'company' AND i.entity_id = a.cnpj_cpf))
+		)
+		ORDER BY a.total_amount DESC NULLS LAST, a.id
+	`, d.WatchlistID)
+	if err != nil {
+		return fmt.Errorf("failed to query digest anomalies: %w", err)
+	}
+	defer anomalies.Close()
+
+	seen := make(map[string]bool, len(reported))
+	for _, key := range reported {
+		seen[key] = true
+	}
+	for anomalies.Next() {
+		var key string
+		var a models.Anomaly
+		err := anomalies.Scan(&key, &a.Type, &a.PoliticianID, &a.PoliticianName, &a.CNPJCPF,
+			&a.CounterpartName, &a.RecordCount, &a.TotalAmount, &a.FirstDate, &a.LastDate)
+		if err != nil {
+			return err
+		}
+		switch {
+		case seen[key]:
+			d.AnomalyKeys = append(d.AnomalyKeys, key)
+		case len(d.Anomalies) < digestItems:
+			d.AnomalyKeys = append(d.AnomalyKeys, key)
+			d.Anomalies = append(d.Anomalies, a)
+		}
+	}
+	return anomalies.Err()
+}
+
+// MarkDigestSent records that a digest went out, with the anomalies it has now reported
+func MarkDigestSent(id int64, anomalyKeys []string) error {
+	_, err := DB.Exec(`
+		UPDATE watchlist_digests SET last_sent_at = CURRENT_TIMESTAMP, reported_anomalies = $2
+		WHERE id = $1
+	`, id, pq.Array(anomalyKeys))
+	if err != nil {
+		return fmt.Errorf("failed to mark digest sent: %w", err)
+	}
+	return nil
+}
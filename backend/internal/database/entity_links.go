@@ -0,0 +1,121 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// EntityLinkFilter narrows GetEntityLinks; zero values match everything
+type EntityLinkFilter struct {
+	Status        string
+	Source        string
+	EntityType    string
+	EntityID      string
+	MinConfidence float64
+}
+
+// entityLinkSelect reads entity_links with the name of the linked politician or company
+const entityLinkSelect = `
+		SELECT
+			l.id, l.source, l.source_key,
+			COALESCE(l.source_name, ''), COALESCE(l.source_document, ''),
+			l.entity_type, l.entity_id,
+			COALESCE(p.nome_civil, p.nome_eleitoral, c.name, 'Unknown'),
+			l.method, CAST(l.confidence AS DOUBLE PRECISION),
+			COALESCE(l.evidence, ''), l.status, COALESCE(l.note, ''), COALESCE(l.reviewed_by, ''),
+			l.reviewed_at, l.created_at, l.updated_at
+		FROM entity_links l
+		LEFT JOIN unified_politicians p
+			ON l.entity_type = 'politician' AND p.id::text = l.entity_id
+		LEFT JOIN financial_counterparts c
+			ON l.entity_type = 'company' AND c.cnpj_cpf = l.entity_id
+`
+
+func scanEntityLink(row interface{ Scan(...interface{}) error }) (models.EntityLink, error) {
+	var l models.EntityLink
+	var reviewedAt sql.NullTime
+	err := row.Scan(
+		&l.ID, &l.Source, &l.SourceKey, &l.SourceName, &l.SourceDocument,
+		&l.EntityType, &l.EntityID, &l.EntityName, &l.Method, &l.Confidence,
+		&l.Evidence, &l.Status, &l.Note, &l.ReviewedBy, &reviewedAt, &l.CreatedAt, &l.UpdatedAt,
+	)
+	if reviewedAt.Valid {
+		l.ReviewedAt = &reviewedAt.Time
+	}
+	return l, err
+}
+
+// GetEntityLinks retrieves links, most confident first; the review queue is the suggested ones
+func GetEntityLinks(filter EntityLinkFilter, limit, offset int) ([]models.EntityLink, error) {
+	rows, err := DB.Query(entityLinkSelect+`
+		WHERE ($1 = '' OR l.status = $1)
+		  AND ($2 = '' OR l.source = $2)
+		  AND ($3 = '' OR l.entity_type = $3)
+		  AND ($4 = '' OR l.entity_id = $4)
+		  AND l.confidence >= $5
+		ORDER BY l.confidence DESC, l.id
+		LIMIT $6 OFFSET $7
+	`, filter.Status, filter.Source, filter.EntityType, filter.EntityID, filter.MinConfidence, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query entity links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []models.EntityLink
+	for rows.Next() {
+		l, err := scanEntityLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+	return links, rows.Err()
+}
+
+// GetEntityLink retrieves one link; sql.ErrNoRows is returned when it doesn't exist
+func GetEntityLink(id int64) (models.EntityLink, error) {
+	return scanEntityLink(DB.QueryRow(entityLinkSelect+" WHERE l.id = $1", id))
+}
+
+// ReviewEntityLink sets a link's status and note; sql.ErrNoRows is returned when it doesn't
+// exist. A source record names one entity, so confirming a link also rejects the other
+// unreviewed candidates for the same record. Later resolve-entities runs keep reviewed links.
+func ReviewEntityLink(id int64, status, note, reviewer string) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var source, sourceKey, entityType string
+	err = tx.QueryRow(`
+		UPDATE entity_links
+		SET status = $2, note = NULLIF($3, ''), reviewed_by = $4,
+		    reviewed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING source, source_key, entity_type
+	`, id, status, note, reviewer).Scan(&source, &sourceKey, &entityType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		return fmt.Errorf("failed to review entity link: %w", err)
+	}
+
+	if status == models.LinkConfirmed {
+		_, err = tx.Exec(`
+			UPDATE entity_links
+			SET status = $5, note = 'another candidate was confirmed', reviewed_by = $6,
+			    reviewed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+			WHERE source = $1 AND source_key = $2 AND entity_type = $3 AND id <> $4
+			  AND reviewed_by IS NULL
+		`, source, sourceKey, entityType, id, models.LinkRejected, reviewer)
+		if err != nil {
+			return fmt.Errorf("failed to reject competing entity links: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
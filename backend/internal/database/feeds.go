@@ -0,0 +1,248 @@
+package database
+
+import (
+	"fmt"
+	"political-network-api/internal/models"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// politicianLabel renders "Name (PARTY-UF)" for unified_politicians p
+const politicianLabel = `COALESCE(p.nome_civil, p.nome_eleitoral, 'Unknown')
+	|| ' (' || COALESCE(p.current_party, '?') || '-' || COALESCE(p.current_state, '?') || ')'`
+
+// sittingPoliticianPayments totals each sitting deputy's payments per counterpart
+const sittingPoliticianPayments = `
+		SELECT
+			fr.counterpart_cnpj_cpf as cnpj,
+			p.id as politician_id,
+			` + politicianLabel + ` as label,
+			SUM(fr.amount) as total
+		FROM unified_financial_records fr
+		JOIN unified_politicians p ON p.id = fr.politician_id
+		WHERE p.deputy_active = true
+		GROUP BY fr.counterpart_cnpj_cpf, p.id
+`
+
+// GetSanctionFeed retrieves the most recently imported sanctions against companies paid by
+// sitting politicians, newest first
+func GetSanctionFeed(limit int) ([]models.FeedEntry, error) {
+	rows, err := DB.Query(`
+		WITH sitting AS (`+sittingPoliticianPayments+`)
+		SELECT
+			vs.id,
+			vs.cnpj_cpf,
+			COALESCE(vs.entity_name, ''),
+			COALESCE(vs.sanction_type, ''),
+			COALESCE(vs.sanctioning_agency, ''),
+			COALESCE(vs.data_source, ''),
+			COALESCE(vs.sanction_start_date::text, ''),
+			COALESCE(vs.sanction_end_date::text, ''),
+			vs.created_at,
+			COUNT(s.politician_id),
+			SUM(s.total),
+			(array_agg(s.label ORDER BY s.total DESC))[1:5]
+		FROM vendor_sanctions vs
+		JOIN sitting s ON s.cnpj = vs.cnpj_cpf
+		WHERE LENGTH(vs.cnpj_cpf) = 14
+		GROUP BY vs.id
+		ORDER BY vs.created_at DESC, vs.id DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sanction feed: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.FeedEntry
+	for rows.Next() {
+		var id, payers int
+		var cnpj, name, sanctionType, agency, source, startDate, endDate string
+		var createdAt time.Time
+		var total models.Money
+		var labels []string
+		err := rows.Scan(
+			&id, &cnpj, &name, &sanctionType, &agency, &source, &startDate, &endDate,
+			&createdAt, &payers, &total, pq.Array(&labels),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			name = cnpj
+		}
+
+		list := sanctionList(source)
+		summary := fmt.Sprintf("%s sanction (%s) against %s, CNPJ %s", list, sanctionType, name, cnpj)
+		if agency != "" {
+			summary += ", by " + agency
+		}
+		summary += sanctionPeriod(startDate, endDate) + ". "
+		summary += fmt.Sprintf("Paid R$ %s by %d sitting politician(s): %s.", total, payers, listWithMore(labels, payers))
+
+		entries = append(entries, models.FeedEntry{
+			Kind:     "sanction",
+			Key:      strconv.Itoa(id),
+			Title:    fmt.Sprintf("%s: %s sanctioned (%s)", list, name, sanctionType),
+			Summary:  summary,
+			Path:     fmt.Sprintf("/api/sanctions/%d", id),
+			Category: list,
+			Updated:  createdAt,
+		})
+	}
+
+	return entries, rows.Err()
+}
+
+// GetAlertFeed retrieves high-risk events involving sitting politicians, newest first:
+// payments to a company while it was under sanction, and TCU disqualifications
+func GetAlertFeed(limit int) ([]models.FeedEntry, error) {
+	payments, err := sanctionedPaymentAlerts(limit)
+	if err != nil {
+		return nil, err
+	}
+	disqualifications, err := tcuAlerts(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := append(payments, disqualifications...)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Updated.After(entries[j].Updated) })
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// sanctionedPaymentAlerts groups a sitting politician's payments made during one sanction
+func sanctionedPaymentAlerts(limit int) ([]models.FeedEntry, error) {
+	rows, err := DB.Query(`
+		SELECT
+			p.id,
+			`+politicianLabel+`,
+			vs.id,
+			vs.cnpj_cpf,
+			COALESCE(vs.entity_name, vs.cnpj_cpf),
+			COALESCE(vs.data_source, ''),
+			COUNT(fr.id),
+			SUM(fr.amount),
+			MAX(fr.transaction_date)::text,
+			MAX(fr.created_at)
+		FROM unified_financial_records fr
+		JOIN unified_politicians p ON p.id = fr.politician_id
+		JOIN vendor_sanctions vs ON vs.cnpj_cpf = fr.counterpart_cnpj_cpf
+		WHERE p.deputy_active = true
+		  AND LENGTH(vs.cnpj_cpf) = 14
+		  AND fr.transaction_date >= vs.sanction_start_date
+		  AND (vs.sanction_end_date IS NULL OR fr.transaction_date <= vs.sanction_end_date)
+		GROUP BY p.id, vs.id
+		ORDER BY MAX(fr.created_at) DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sanctioned payment alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.FeedEntry
+	for rows.Next() {
+		var politicianID, sanctionID, count int
+		var politician, cnpj, company, source, lastPayment string
+		var total models.Money
+		var updated time.Time
+		err := rows.Scan(
+			&politicianID, &politician, &sanctionID, &cnpj, &company, &source,
+			&count, &total, &lastPayment, &updated,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, models.FeedEntry{
+			Kind:  "sanctioned_vendor_payment",
+			Key:   fmt.Sprintf("%d-%d", politicianID, sanctionID),
+			Title: fmt.Sprintf("%s paid sanctioned company %s", politician, company),
+			Summary: fmt.Sprintf("%d payment(s) totaling R$ %s to %s (CNPJ %s) while under a %s sanction, the latest on %s.",
+				count, total, company, cnpj, sanctionList(source), lastPayment),
+			Path:     "/api/companies/" + cnpj,
+			Category: "sanctioned_vendor_payment",
+			Updated:  updated,
+		})
+	}
+
+	return entries, rows.Err()
+}
+
+// tcuAlerts lists TCU disqualifications of sitting politicians, matched by CPF
+func tcuAlerts(limit int) ([]models.FeedEntry, error) {
+	rows, err := DB.Query(`
+		SELECT
+			p.id,
+			`+politicianLabel+`,
+			t.id,
+			COALESCE(t.processo, ''),
+			COALESCE(t.deliberacao, ''),
+			COALESCE(t.data_final::text, ''),
+			t.created_at
+		FROM tcu_disqualifications t
+		JOIN unified_politicians p ON p.cpf = t.cpf
+		WHERE p.deputy_active = true
+		ORDER BY t.created_at DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query TCU alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.FeedEntry
+	for rows.Next() {
+		var politicianID, tcuID int
+		var politician, process, ruling, until string
+		var updated time.Time
+		if err := rows.Scan(&politicianID, &politician, &tcuID, &process, &ruling, &until, &updated); err != nil {
+			return nil, err
+		}
+
+		summary := fmt.Sprintf("%s was declared ineligible by the TCU (processo %s, %s)", politician, process, ruling)
+		if until != "" {
+			summary += " until " + until
+		}
+
+		entries = append(entries, models.FeedEntry{
+			Kind:     "tcu_disqualification",
+			Key:      strconv.Itoa(tcuID),
+			Title:    fmt.Sprintf("%s disqualified by the TCU", politician),
+			Summary:  summary + ".",
+			Path:     fmt.Sprintf("/api/politicians/%d", politicianID),
+			Category: "tcu_disqualification",
+			Updated:  updated,
+		})
+	}
+
+	return entries, rows.Err()
+}
+
+// sanctionPeriod renders a sanction's dates for feed summaries
+func sanctionPeriod(start, end string) string {
+	switch {
+	case start != "" && end != "":
+		return " from " + start + " to " + end
+	case start != "":
+		return " since " + start
+	}
+	return ""
+}
+
+// listWithMore joins the first names of a longer list: "A, B and 3 more"
+func listWithMore(names []string, total int) string {
+	list := strings.Join(names, ", ")
+	if more := total - len(names); more > 0 {
+		list += fmt.Sprintf(" and %d more", more)
+	}
+	return list
+}
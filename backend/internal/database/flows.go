@@ -0,0 +1,197 @@
+package database
+
+import (
+	"fmt"
+	"political-network-api/internal/models"
+	"sort"
+	"strings"
+)
+
+// flowTiers maps ?group_by= tiers to their key and label expressions over the expense, its
+// politician (p) and its vendor (fc)
+var flowTiers = map[string]struct{ key, label string }{
+	"party":      {"COALESCE(p.current_party, '')", "COALESCE(p.current_party, '')"},
+	"uf":         {"COALESCE(p.current_state, '')", "COALESCE(p.current_state, '')"},
+	"politician": {"p.id::text", "COALESCE(p.nome_civil, p.nome_eleitoral, 'Unknown')"},
+	"sector":     {"COALESCE(fc.cnae_section, '')", "COALESCE(fc.cnae_section, '')"},
+	"company":    {"fr.counterpart_cnpj_cpf", "COALESCE(fc.name, fr.counterpart_name, fr.counterpart_cnpj_cpf)"},
+}
+
+// otherNodeKey and unknownNodeName label merged and missing tier values
+const (
+	otherNodeKey    = "_other"
+	otherNodeName   = "Outros"
+	unknownNodeName = "Não informado"
+)
+
+// FlowFilter selects the expenses of a flow diagram; zero values match everything
+type FlowFilter struct {
+	Tiers  []string `json:"tiers"`
+	Source string   `json:"source,omitempty"` // source system, e.g. DEPUTADOS or TSE
+	Year   int      `json:"year,omitempty"`
+	Top    int      `json:"top"` // nodes kept per tier, the rest merged into Outros
+}
+
+// ValidFlowTier reports whether tier is a supported ?group_by= value
+func ValidFlowTier(tier string) bool {
+	_, ok := flowTiers[tier]
+	return ok
+}
+
+// GetFlows aggregates CEAP and campaign expenses paid to companies through the filter's tiers
+func GetFlows(filter FlowFilter) (models.FlowDiagram, error) {
+	var columns, groups []string
+	for i, tier := range filter.Tiers {
+		t, ok := flowTiers[tier]
+		if !ok {
+			return models.FlowDiagram{}, fmt.Errorf("unknown flow tier %q", tier)
+		}
+		columns = append(columns, t.key, fmt.Sprintf("MAX(%s)", t.label))
+		groups = append(groups, fmt.Sprint(i*2+1))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s, SUM(fr.amount)
+		FROM unified_financial_records fr
+		JOIN unified_politicians p ON p.id = fr.politician_id
+		LEFT JOIN financial_counterparts fc ON fc.cnpj_cpf = fr.counterpart_cnpj_cpf
+		WHERE fr.transaction_type IN ('PARLIAMENTARY_EXPENSE', 'CAMPAIGN_EXPENSE_PAID')
+		  AND LENGTH(fr.counterpart_cnpj_cpf) = 14
+		  AND ($1 = '' OR fr.source_system = $1)
+		  AND ($2 = 0 OR fr.year = $2)
+		GROUP BY %s
+	`, strings.Join(columns, ", "), strings.Join(groups, ", "))
+
+	rows, err := DB.Query(query, filter.Source, filter.Year)
+	if err != nil {
+		return models.FlowDiagram{}, fmt.Errorf("failed to query flows: %w", err)
+	}
+	defer rows.Close()
+
+	type flowRow struct {
+		keys   []string
+		amount models.Money
+	}
+	var flows []flowRow
+	names := make([]map[string]string, len(filter.Tiers))
+	totals := make([]map[string]models.Money, len(filter.Tiers))
+	for i := range filter.Tiers {
+		names[i] = map[string]string{}
+		totals[i] = map[string]models.Money{}
+	}
+
+	for rows.Next() {
+		values := make([]string, len(filter.Tiers)*2)
+		dest := make([]interface{}, 0, len(values)+1)
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		var amount models.Money
+		dest = append(dest, &amount)
+		if err := rows.Scan(dest...); err != nil {
+			return models.FlowDiagram{}, err
+		}
+
+		r := flowRow{amount: amount}
+		for i := range filter.Tiers {
+			key := values[i*2]
+			r.keys = append(r.keys, key)
+			names[i][key] = values[i*2+1]
+			totals[i][key] += amount
+		}
+		flows = append(flows, r)
+	}
+	if err := rows.Err(); err != nil {
+		return models.FlowDiagram{}, err
+	}
+
+	diagram := models.FlowDiagram{Tiers: filter.Tiers, Nodes: []models.FlowNode{}, Links: []models.FlowLink{}}
+
+	// Keep the largest nodes of each tier, merging the others
+	kept := make([]map[string]bool, len(filter.Tiers))
+	for i, tier := range filter.Tiers {
+		kept[i] = largestKeys(totals[i], filter.Top)
+		var other models.Money
+		for key, total := range totals[i] {
+			if !kept[i][key] {
+				other += total
+				continue
+			}
+			diagram.Nodes = append(diagram.Nodes, models.FlowNode{
+				ID: tier + ":" + key, Name: flowNodeName(tier, key, names[i][key]), Tier: i, Value: total,
+			})
+		}
+		if other > 0 {
+			diagram.Nodes = append(diagram.Nodes, models.FlowNode{
+				ID: tier + ":" + otherNodeKey, Name: otherNodeName, Tier: i, Value: other,
+			})
+		}
+	}
+
+	links := map[[2]string]models.Money{}
+	for _, r := range flows {
+		diagram.Total += r.amount
+		ids := make([]string, len(r.keys))
+		for i, key := range r.keys {
+			if !kept[i][key] {
+				key = otherNodeKey
+			}
+			ids[i] = filter.Tiers[i] + ":" + key
+		}
+		for i := 0; i+1 < len(ids); i++ {
+			links[[2]string{ids[i], ids[i+1]}] += r.amount
+		}
+	}
+	for pair, value := range links {
+		diagram.Links = append(diagram.Links, models.FlowLink{Source: pair[0], Target: pair[1], Value: value})
+	}
+
+	sort.Slice(diagram.Nodes, func(i, j int) bool {
+		a, b := diagram.Nodes[i], diagram.Nodes[j]
+		if a.Tier != b.Tier {
+			return a.Tier < b.Tier
+		}
+		return a.Value > b.Value
+	})
+	sort.Slice(diagram.Links, func(i, j int) bool {
+		return diagram.Links[i].Value > diagram.Links[j].Value
+	})
+
+	return diagram, nil
+}
+
+// largestKeys returns the n keys with the largest totals
+func largestKeys(totals map[string]models.Money, n int) map[string]bool {
+	keys := make([]string, 0, len(totals))
+	for key := range totals {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if totals[keys[i]] != totals[keys[j]] {
+			return totals[keys[i]] > totals[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	kept := map[string]bool{}
+	for i, key := range keys {
+		if i == n {
+			break
+		}
+		kept[key] = true
+	}
+	return kept
+}
+
+// flowNodeName labels a node: CNAE sections by name, missing values as not informed
+func flowNodeName(tier, key, label string) string {
+	if key == "" || label == "" {
+		return unknownNodeName
+	}
+	if tier == "sector" {
+		if name, ok := models.CNAESections[key]; ok {
+			return key + " - " + name
+		}
+	}
+	return label
+}
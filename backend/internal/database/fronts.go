@@ -0,0 +1,236 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// FrontNodeLimit caps the frentes parlamentares in the interactive network, keeping the largest
+const FrontNodeLimit = 100
+
+// frontSelect aggregates the PARLIAMENTARY_FRONT rows of unified_political_networks (loaded by
+// populate-networks) into one row per frente. Callers add conditions, then frontGroupBy.
+const frontSelect = `
+		SELECT
+			n.network_id::int as front_id,
+			MAX(n.network_name),
+			COALESCE(MAX(n.legislature_id), 0),
+			COUNT(DISTINCT n.politician_id) as member_count
+		FROM unified_political_networks n
+		WHERE n.network_type = 'PARLIAMENTARY_FRONT'
+		  AND n.network_id ~ '^[0-9]+$'
+`
+
+const frontGroupBy = `
+		GROUP BY n.network_id
+`
+
+// scanFront scans a row produced by frontSelect
+func scanFront(rows *sql.Rows) (models.Front, error) {
+	var f models.Front
+	err := rows.Scan(&f.ID, &f.Titulo, &f.LegislaturaID, &f.MemberCount)
+	return f, err
+}
+
+// queryFronts runs a frontSelect query and scans every row
+func (rd Reader) queryFronts(query string, args ...interface{}) ([]models.Front, error) {
+	if loaded, err := rd.hasTable("unified_political_networks"); err != nil || !loaded {
+		return nil, err
+	}
+
+	rows, err := rd.q.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fronts: %w", err)
+	}
+	defer rows.Close()
+
+	var fronts []models.Front
+	scanned := scanErrors{what: "fronts"}
+	for rows.Next() {
+		f, err := scanFront(rows)
+		if !scanned.keep(err) {
+			continue
+		}
+
+		fronts = append(fronts, f)
+	}
+
+	return rowsResult(fronts, rows.Err(), &scanned)
+}
+
+// GetFronts retrieves frentes parlamentares, largest first, optionally of one legislature (0 for all)
+func GetFronts(legislaturaID, limit, offset int) ([]models.Front, error) {
+	return Live().queryFronts(frontSelect+`
+		  AND ($1 = 0 OR n.legislature_id = $1)
+	`+frontGroupBy+`
+		ORDER BY member_count DESC, front_id DESC
+		LIMIT $2 OFFSET $3
+	`, legislaturaID, limit, offset)
+}
+
+// GetFront retrieves one frente parlamentar; sql.ErrNoRows is returned when it has no members
+func GetFront(id int) (models.Front, error) {
+	fronts, err := Live().queryFronts(frontSelect+`
+		  AND n.network_id = $1::text
+	`+frontGroupBy, id)
+	if err != nil {
+		return models.Front{}, err
+	}
+	if len(fronts) == 0 {
+		return models.Front{}, sql.ErrNoRows
+	}
+	return fronts[0], nil
+}
+
+// GetFrontMembers retrieves the politicians in a frente parlamentar, by name
+func GetFrontMembers(frontID, limit int) ([]models.FrontMember, error) {
+	query := `
+		SELECT
+			p.id,
+			COALESCE(p.nome_civil, p.nome_eleitoral, 'Unknown') as nome,
+			COALESCE(p.current_party, ''),
+			COALESCE(p.current_state, ''),
+			COALESCE(CAST(p.corruption_risk_score AS INTEGER), 0)
+		FROM unified_political_networks n
+		JOIN unified_politicians p ON p.id = n.politician_id
+		WHERE n.network_type = 'PARLIAMENTARY_FRONT'
+		  AND n.network_id = $1::text
+		ORDER BY nome
+		LIMIT $2
+	`
+
+	rows, err := DB.Query(query, frontID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query front members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []models.FrontMember
+	for rows.Next() {
+		var m models.FrontMember
+		if err := rows.Scan(&m.PoliticianID, &m.Nome, &m.SiglaPartido, &m.UF, &m.CorruptionScore); err != nil {
+			return nil, fmt.Errorf("failed to scan front member: %w", err)
+		}
+
+		members = append(members, m)
+	}
+
+	return members, nil
+}
+
+// GetPoliticianFronts retrieves the frentes parlamentares a politician belongs to, most recent
+// legislature first
+func (rd Reader) GetPoliticianFronts(politicianID, limit int) ([]models.Front, error) {
+	return rd.queryFronts(frontSelect+`
+		  AND n.network_id IN (
+			SELECT network_id FROM unified_political_networks
+			WHERE network_type = 'PARLIAMENTARY_FRONT' AND politician_id = $1
+		  )
+	`+frontGroupBy+`
+		ORDER BY MAX(n.legislature_id) DESC NULLS LAST, MAX(n.network_name)
+		LIMIT $2
+	`, politicianID, limit)
+}
+
+// GetFrontPeers retrieves the politicians sharing the most frentes parlamentares with a
+// politician. Fronts joined by most of the house say little about a tie, but they add the same
+// count to every peer, so the ranking still surfaces the closest caucus allies.
+func (rd Reader) GetFrontPeers(politicianID, limit int) ([]models.FrontPeer, error) {
+	query := `
+		SELECT
+			p.id,
+			COALESCE(p.nome_civil, p.nome_eleitoral, 'Unknown') as nome,
+			COALESCE(p.current_party, ''),
+			COUNT(DISTINCT peer.network_id) as shared_fronts
+		FROM unified_political_networks own
+		JOIN unified_political_networks peer
+		  ON peer.network_type = own.network_type
+		 AND peer.network_id = own.network_id
+		 AND peer.politician_id != own.politician_id
+		JOIN unified_politicians p ON p.id = peer.politician_id
+		WHERE own.network_type = 'PARLIAMENTARY_FRONT'
+		  AND own.politician_id = $1
+		GROUP BY p.id
+		ORDER BY shared_fronts DESC, nome
+		LIMIT $2
+	`
+
+	if loaded, err := rd.hasTable("unified_political_networks"); err != nil || !loaded {
+		return nil, err
+	}
+	rows, err := rd.q.Query(query, politicianID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query front peers: %w", err)
+	}
+	defer rows.Close()
+
+	var peers []models.FrontPeer
+	scanned := scanErrors{what: "front peers"}
+	for rows.Next() {
+		var fp models.FrontPeer
+		if err := rows.Scan(&fp.PoliticianID, &fp.Nome, &fp.SiglaPartido, &fp.SharedFronts); !scanned.keep(err) {
+			continue
+		}
+
+		peers = append(peers, fp)
+	}
+
+	return rowsResult(peers, rows.Err(), &scanned)
+}
+
+// EachFront streams every frente parlamentar to fn
+func EachFront(fn func(models.Front) error) error {
+	return eachRow(frontSelect+frontGroupBy+" ORDER BY front_id", "fronts", func(rows *sql.Rows) error {
+		f, err := scanFront(rows)
+		if err != nil {
+			return err
+		}
+		return fn(f)
+	})
+}
+
+// getFrontConnections creates politician-front membership connections for the limit largest
+// frentes, the same ones GetFronts puts in the network
+func getFrontConnections(limit int) ([]models.Connection, error) {
+	query := `
+		WITH top_fronts AS (
+			SELECT network_id
+			FROM unified_political_networks
+			WHERE network_type = 'PARLIAMENTARY_FRONT'
+			  AND network_id ~ '^[0-9]+$'
+			GROUP BY network_id
+			ORDER BY COUNT(DISTINCT politician_id) DESC, network_id::int DESC
+			LIMIT $1
+		)
+		SELECT DISTINCT n.politician_id, n.network_id
+		FROM unified_political_networks n
+		JOIN top_fronts tf ON tf.network_id = n.network_id
+		WHERE n.network_type = 'PARLIAMENTARY_FRONT'
+	`
+
+	rows, err := DB.Query(query, sqlLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var connections []models.Connection
+	for rows.Next() {
+		var politicianID int
+		var frontID string
+
+		if err := rows.Scan(&politicianID, &frontID); err != nil {
+			return nil, err
+		}
+
+		connections = append(connections, models.Connection{
+			SourceID: fmt.Sprintf("politician_%d", politicianID),
+			TargetID: fmt.Sprintf("%s_%s", models.NodeTypeFront, frontID),
+			Type:     "front_member",
+			Strength: 0.3, // a soft tie: fronts are joined by signature, often by hundreds
+		})
+	}
+
+	return connections, rows.Err()
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// geoLevels maps ?level= to the expression that rolls a stored IBGE code up to that level and
+// the code length of its areas. State codes are the first two digits of their municipalities'
+// codes, so municipality-level data also counts toward its state.
+var geoLevels = map[string]struct {
+	code       string
+	codeLength int
+}{
+	"state":        {"LEFT(%s, 2)", 2},
+	"municipality": {"%s", 7},
+}
+
+// GeoSpendingFilter selects the areas and spending of a map; zero values match everything
+type GeoSpendingFilter struct {
+	Level        string `json:"level"`        // state or municipality
+	UF           string `json:"uf,omitempty"` // only the areas of one state
+	Year         int    `json:"year,omitempty"`
+	PoliticianID int    `json:"politician_id,omitempty"`
+}
+
+// ValidGeoLevel reports whether level is a supported map aggregation level
+func ValidGeoLevel(level string) bool {
+	_, ok := geoLevels[level]
+	return ok
+}
+
+// GetGeoSpending aggregates expenses (by the municipality where the vendor is registered,
+// loaded by cli4 populate-geo) and paid amendments (cli4 populate-amendments) over every
+// IBGE area of a level. Areas without spending are included with zero totals so maps have
+// no holes; nothing is returned before populate-geo has run.
+func GetGeoSpending(filter GeoSpendingFilter) ([]models.Feature, error) {
+	level, ok := geoLevels[filter.Level]
+	if !ok {
+		return nil, fmt.Errorf("unknown geo level %q", filter.Level)
+	}
+
+	if ingested, err := tableExists("ibge_areas"); err != nil || !ingested {
+		return []models.Feature{}, err
+	}
+
+	// State-wide amendments have no municipality to be drawn on, so they only count at the
+	// state level
+	amendments := `SELECT NULL::text as code, 0::numeric as total, 0 as n WHERE false`
+	if ingested, err := tableExists("parliamentary_amendments"); err != nil {
+		return nil, err
+	} else if ingested {
+		amendments = fmt.Sprintf(`
+			SELECT %s as code, SUM(paid_value) as total, COUNT(*) as n
+			FROM parliamentary_amendments
+			WHERE LENGTH(ibge_code) >= %d
+			  AND ($1 = 0 OR year = $1)
+			  AND ($2 = 0 OR politician_id = $2)
+			GROUP BY 1`, fmt.Sprintf(level.code, "ibge_code"), level.codeLength)
+	}
+
+	query := fmt.Sprintf(`
+		WITH expenses AS (
+			SELECT %s as code, SUM(fr.amount) as total, COUNT(*) as n
+			FROM unified_financial_records fr
+			JOIN financial_counterparts fc ON fc.cnpj_cpf = fr.counterpart_cnpj_cpf
+			WHERE fc.municipality_ibge_code IS NOT NULL
+			  AND fr.transaction_type IN ('PARLIAMENTARY_EXPENSE', 'CAMPAIGN_EXPENSE_PAID')
+			  AND ($1 = 0 OR fr.year = $1)
+			  AND ($2 = 0 OR fr.politician_id = $2)
+			GROUP BY 1
+		), amendments AS (%s)
+		SELECT
+			a.ibge_code, a.name, COALESCE(a.uf, ''), COALESCE(a.geometry, 'null'),
+			COALESCE(e.total, 0), COALESCE(e.n, 0),
+			COALESCE(am.total, 0), COALESCE(am.n, 0)
+		FROM ibge_areas a
+		LEFT JOIN expenses e ON e.code = a.ibge_code
+		LEFT JOIN amendments am ON am.code = a.ibge_code
+		WHERE a.level = $3
+		  AND ($4 = '' OR a.uf = $4)
+		ORDER BY a.ibge_code
+	`, fmt.Sprintf(level.code, "fc.municipality_ibge_code"), amendments)
+
+	rows, err := DB.Query(query, filter.Year, filter.PoliticianID, filter.Level, filter.UF)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query geo spending: %w", err)
+	}
+	defer rows.Close()
+
+	features := []models.Feature{}
+	for rows.Next() {
+		var s models.AreaSpending
+		var geometry []byte
+		err := rows.Scan(
+			&s.IBGECode, &s.Name, &s.UF, &geometry,
+			&s.Expenses, &s.ExpenseCount, &s.Amendments, &s.AmendmentCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan geo spending: %w", err)
+		}
+
+		s.Total = s.Expenses + s.Amendments
+		features = append(features, models.Feature{
+			Type:       "Feature",
+			ID:         s.IBGECode,
+			Geometry:   geometry,
+			Properties: s,
+		})
+	}
+
+	return features, rows.Err()
+}
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"fmt"
+	"political-network-api/internal/models"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IngestSource is the source recorded on ingested rows that don't name their own
+const IngestSource = "API_INGEST"
+
+// ingestSpec describes an entity POST /api/admin/ingest/:entity upserts: its table, the unique
+// key rows are matched on, and the columns it accepts with the SQL type each value is cast to.
+// Key columns are required, since NULLs never conflict and would insert duplicates.
+type ingestSpec struct {
+	table    string
+	key      []string
+	required []string
+	source   string // provenance source column, defaulted to IngestSource
+	columns  map[string]string
+}
+
+var ingestSpecs = map[string]ingestSpec{
+	"sanctions": {
+		table:  "vendor_sanctions",
+		key:    []string{"cnpj_cpf", "sanction_type", "sanction_start_date", "sanctioning_agency"},
+		source: "data_source",
+		columns: map[string]string{
+			"cnpj_cpf": "text", "entity_name": "text", "sanction_type": "text", "sanction_description": "text",
+			"legal_basis": "text", "sanction_start_date": "date", "sanction_end_date": "date",
+			"sanctioning_agency": "text", "sanctioning_state": "text", "sanctioning_process": "text",
+			"penalty_amount": "numeric", "is_active": "boolean", "data_source": "text", "api_reference_id": "text",
+		},
+	},
+	"companies": {
+		table:    "financial_counterparts",
+		key:      []string{"cnpj_cpf"},
+		required: []string{"name", "entity_type"},
+		source:   "source_system",
+		columns: map[string]string{
+			"cnpj_cpf": "text", "name": "text", "entity_type": "text", "source_system": "text",
+			"trade_name": "text", "business_sector": "text", "cnae_code": "text", "cnae_description": "text",
+			"cnae_section": "text", "company_size": "text", "registration_status": "text",
+			"state": "text", "municipality": "text", "municipality_ibge_code": "text",
+		},
+	},
+	"expenses": {
+		table:    "unified_financial_records",
+		key:      []string{"source_system", "source_record_id"},
+		required: []string{"politician_id", "transaction_type", "amount", "transaction_date", "year"},
+		source:   "source_system",
+		columns: map[string]string{
+			"politician_id": "integer", "source_system": "text", "source_record_id": "text", "source_url": "text",
+			"transaction_type": "text", "transaction_category": "text", "amount": "numeric", "amount_net": "numeric",
+			"transaction_date": "date", "year": "integer", "month": "integer",
+			"counterpart_name": "text", "counterpart_cnpj_cpf": "text", "counterpart_type": "text",
+			"state": "text", "municipality": "text", "document_number": "text", "document_type": "text",
+			"document_url": "text",
+		},
+	},
+	"tcu_rulings": {
+		table:  "tcu_rulings",
+		key:    []string{"ruling_key"},
+		source: "data_source",
+		columns: map[string]string{
+			"ruling_key": "text", "numero": "integer", "ano": "integer", "colegiado": "text", "relator": "text",
+			"session_date": "date", "titulo": "text", "sumario": "text", "url": "text", "document_url": "text",
+			"data_source": "text",
+		},
+	},
+}
+
+// IngestEntities lists the entities the ingest API accepts
+func IngestEntities() []string {
+	entities := make([]string, 0, len(ingestSpecs))
+	for entity := range ingestSpecs {
+		entities = append(entities, entity)
+	}
+	sort.Strings(entities)
+	return entities
+}
+
+// Ingest upserts rows into entity's table in one transaction, each row behind a savepoint so a
+// failing row is reported and skipped without losing the others. A dry run validates and
+// writes every row, then rolls everything back.
+func Ingest(entity string, rows []models.IngestRow, dryRun bool) (models.IngestResult, error) {
+	result := models.IngestResult{Entity: entity, DryRun: dryRun, Rows: len(rows)}
+	spec, ok := ingestSpecs[entity]
+	if !ok {
+		return result, fmt.Errorf("unknown ingest entity %q", entity)
+	}
+	if exists, err := tableExists(spec.table); err != nil {
+		return result, err
+	} else if !exists {
+		return result, fmt.Errorf("%s has not been created yet (run the setup scripts)", spec.table)
+	}
+	fetchedAt, err := columnExists(spec.table, "fetched_at")
+	if err != nil {
+		return result, err
+	}
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	for _, row := range rows {
+		if row.Error != "" {
+			result.AddError(row.Line, row.Error)
+			continue
+		}
+		query, args, err := spec.upsert(row.Values, fetchedAt)
+		if err != nil {
+			result.AddError(row.Line, err.Error())
+			continue
+		}
+
+		if _, err := tx.Exec("SAVEPOINT ingest_row"); err != nil {
+			return result, err
+		}
+		var inserted bool
+		if err := tx.QueryRow(query, args...).Scan(&inserted); err != nil {
+			if _, rbErr := tx.Exec("ROLLBACK TO SAVEPOINT ingest_row"); rbErr != nil {
+				return result, rbErr
+			}
+			result.AddError(row.Line, err.Error())
+			continue
+		}
+		if _, err := tx.Exec("RELEASE SAVEPOINT ingest_row"); err != nil {
+			return result, err
+		}
+		if inserted {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return result, fmt.Errorf("failed to commit ingest: %w", err)
+	}
+	return result, nil
+}
+
+// upsert builds the INSERT ... ON CONFLICT of one row. Only the row's columns are written, so
+// a partial row updates those and leaves the rest of an existing record alone.
+func (spec ingestSpec) upsert(values map[string]*string, fetchedAt bool) (string, []interface{}, error) {
+	var unknown []string
+	for column := range values {
+		if _, ok := spec.columns[column]; !ok {
+			unknown = append(unknown, column)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return "", nil, fmt.Errorf("unknown columns: %s", strings.Join(unknown, ", "))
+	}
+	for _, columns := range [][]string{spec.key, spec.required} {
+		for _, column := range columns {
+			if v := values[column]; v == nil || *v == "" {
+				return "", nil, fmt.Errorf("%s is required", column)
+			}
+		}
+	}
+	if _, ok := values[spec.source]; !ok {
+		source := IngestSource
+		values[spec.source] = &source
+	}
+
+	columns := make([]string, 0, len(values))
+	for column := range values {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	placeholders := make([]string, len(columns))
+	args := make([]interface{}, len(columns))
+	var updates []string
+	for i, column := range columns {
+		placeholders[i] = "$" + strconv.Itoa(i+1) + "::" + spec.columns[column]
+		if v := values[column]; v != nil {
+			args[i] = *v
+		}
+		if !slices.Contains(spec.key, column) {
+			updates = append(updates, column+" = EXCLUDED."+column)
+		}
+	}
+	updates = append(updates, "updated_at = CURRENT_TIMESTAMP")
+	if fetchedAt {
+		updates = append(updates, "fetched_at = CURRENT_TIMESTAMP")
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (%s) VALUES (%s)
+		ON CONFLICT (%s) DO UPDATE SET %s
+		RETURNING xmax = 0
+	`, spec.table, strings.Join(columns, ", "), strings.Join(placeholders, ", "),
+		strings.Join(spec.key, ", "), strings.Join(updates, ", "))
+	return query, args, nil
+}
@@ -0,0 +1,160 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"time"
+)
+
+// integrityExamples is how many offending keys a check reports
+const integrityExamples = 5
+
+// integrityCheck is an invariant of the core tables, broken by the rows from selects: a FROM
+// clause with its WHERE, in the SQL Postgres and SQLite share. key identifies an offending row.
+type integrityCheck struct {
+	name        string
+	description string
+	severity    string
+	from        string
+	key         string
+}
+
+var integrityChecks = []integrityCheck{
+	{
+		name:        "financial_records_missing_politician",
+		description: "Financial records of politicians that don't exist, which the network links to missing nodes",
+		severity:    models.SeverityError,
+		from: `unified_financial_records fr
+			WHERE NOT EXISTS (SELECT 1 FROM unified_politicians p WHERE p.id = fr.politician_id)`,
+		key: "fr.politician_id",
+	},
+	{
+		name:        "financial_records_missing_counterpart",
+		description: "Financial records paying a CNPJ/CPF missing from financial_counterparts, which the network links to missing company nodes",
+		severity:    models.SeverityError,
+		from: `unified_financial_records fr
+			WHERE fr.counterpart_cnpj_cpf IS NOT NULL AND fr.counterpart_cnpj_cpf != ''
+			  AND NOT EXISTS (SELECT 1 FROM financial_counterparts fc WHERE fc.cnpj_cpf = fr.counterpart_cnpj_cpf)`,
+		key: "fr.counterpart_cnpj_cpf",
+	},
+	{
+		name:        "memberships_missing_party",
+		description: "Party memberships in parties that don't exist, which the network links to missing nodes",
+		severity:    models.SeverityError,
+		from: `party_memberships pm
+			WHERE NOT EXISTS (SELECT 1 FROM political_parties pp WHERE pp.id = pm.party_id)`,
+		key: "pm.party_id",
+	},
+	{
+		name:        "negative_expense_amounts",
+		description: "Financial records with a negative amount: refunds, or sign errors to correct",
+		severity:    models.SeverityWarning,
+		from:        `unified_financial_records fr WHERE fr.amount < 0`,
+		key:         "fr.id",
+	},
+	{
+		name:        "negative_penalties",
+		description: "Sanctions with a negative penalty amount",
+		severity:    models.SeverityError,
+		from:        `vendor_sanctions vs WHERE vs.penalty_amount < 0`,
+		key:         "vs.id",
+	},
+	{
+		name:        "politicians_without_party",
+		description: "Politicians whose current party is empty or not a known party acronym, so they have no party node",
+		severity:    models.SeverityWarning,
+		from: `unified_politicians p
+			WHERE p.current_party IS NULL OR p.current_party = ''
+			   OR NOT EXISTS (SELECT 1 FROM political_parties pp WHERE pp.sigla = p.current_party)`,
+		key: "p.id",
+	},
+}
+
+// CheckDataQuality checks the invariants of the core tables the network and figures are built
+// from, including the check digits of every 14-digit CNPJ. A check that can't run is reported
+// with its error, and counts as failed, rather than stopping the others.
+func CheckDataQuality() models.DataQualityReport {
+	start := time.Now()
+	report := models.DataQualityReport{CheckedAt: start}
+
+	for _, check := range integrityChecks {
+		report.Checks = append(report.Checks, runIntegrityCheck(check))
+	}
+	report.Checks = append(report.Checks, checkCNPJDigits())
+
+	for _, check := range report.Checks {
+		switch {
+		case check.Error != "" || (check.Violations > 0 && check.Severity == models.SeverityError):
+			report.Errors++
+		case check.Violations > 0:
+			report.Warnings++
+		}
+	}
+	report.Passed = report.Errors == 0
+	report.Duration = time.Since(start).Round(time.Millisecond).String()
+	return report
+}
+
+func runIntegrityCheck(check integrityCheck) models.DataQualityCheck {
+	result := models.DataQualityCheck{Name: check.name, Description: check.description, Severity: check.severity}
+	if err := DB.QueryRow(`SELECT COUNT(*) FROM ` + check.from).Scan(&result.Violations); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if result.Violations == 0 {
+		return result
+	}
+
+	examples, err := selectAll(DB, check.name, func(rows *sql.Rows) (string, error) {
+		var key string
+		err := rows.Scan(&key)
+		return key, err
+	}, fmt.Sprintf(`SELECT DISTINCT CAST(%s AS TEXT) FROM %s ORDER BY 1 LIMIT %d`, check.key, check.from, integrityExamples))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Examples = examples
+	return result
+}
+
+// checkCNPJDigits validates the check digits of the companies and sanctioned entities, whose
+// CNPJs would otherwise match nothing in the Receita Federal data
+func checkCNPJDigits() models.DataQualityCheck {
+	result := models.DataQualityCheck{
+		Name:        "invalid_cnpj_check_digits",
+		Description: "14-digit CNPJs in financial_counterparts or vendor_sanctions whose check digits are wrong",
+		Severity:    models.SeverityError,
+	}
+
+	rows, err := DB.Query(`
+		SELECT cnpj_cpf FROM financial_counterparts WHERE LENGTH(cnpj_cpf) = 14
+		UNION
+		SELECT cnpj_cpf FROM vendor_sanctions WHERE LENGTH(cnpj_cpf) = 14
+	`)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cnpj string
+		if err := rows.Scan(&cnpj); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		if !utils.ValidCNPJ(cnpj) {
+			result.Violations++
+			if len(result.Examples) < integrityExamples {
+				result.Examples = append(result.Examples, cnpj)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
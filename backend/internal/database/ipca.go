@@ -0,0 +1,63 @@
+package database
+
+import (
+	"fmt"
+	"political-network-api/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// GetIPCAIndex returns every month of the IPCA index, oldest first
+func GetIPCAIndex() ([]models.IPCAIndex, error) {
+	rows, err := DB.Query(`
+		SELECT to_char(reference_month, 'YYYY-MM'), index_number::text
+		FROM ipca_index
+		ORDER BY reference_month
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ipca_index: %w", err)
+	}
+	defer rows.Close()
+
+	var months []models.IPCAIndex
+	for rows.Next() {
+		var m models.IPCAIndex
+		if err := rows.Scan(&m.Month, &m.IndexNumber); err != nil {
+			return nil, err
+		}
+		months = append(months, m)
+	}
+	return months, rows.Err()
+}
+
+// GetAdjustedCompanyTotals sums each company's records in the prices of the month whose
+// index number is given. Records in months without an index are summed nominally.
+func GetAdjustedCompanyTotals(cnpjs []string, targetIndex string) (map[string]models.Money, error) {
+	rows, err := DB.Query(`
+		SELECT
+			fr.counterpart_cnpj_cpf,
+			SUM(CASE
+				WHEN i.index_number IS NULL THEN fr.amount
+				ELSE ROUND(fr.amount * $2::numeric / i.index_number, 2)
+			END)
+		FROM unified_financial_records fr
+		LEFT JOIN ipca_index i ON i.reference_month = date_trunc('month', fr.transaction_date)::date
+		WHERE fr.counterpart_cnpj_cpf = ANY($1)
+		GROUP BY fr.counterpart_cnpj_cpf
+	`, pq.Array(cnpjs), targetIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query adjusted company totals: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make(map[string]models.Money, len(cnpjs))
+	for rows.Next() {
+		var cnpj string
+		var total models.Money
+		if err := rows.Scan(&cnpj, &total); err != nil {
+			return nil, err
+		}
+		totals[cnpj] = total
+	}
+	return totals, rows.Err()
+}
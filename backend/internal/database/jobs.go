@@ -0,0 +1,228 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"political-network-api/internal/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// JobFilter narrows GetJobs; zero values match everything
+type JobFilter struct {
+	Status string
+	Kind   string
+}
+
+const jobSelect = `
+		SELECT
+			id, kind, payload, status, attempts, max_attempts, COALESCE(requested_by, ''),
+			COALESCE(task_id, ''), COALESCE(stage, ''), done, total, COALESCE(error, ''), result,
+			run_after, created_at, started_at, finished_at,
+			EXTRACT(EPOCH FROM COALESCE(finished_at, CASE WHEN status = 'running' THEN CURRENT_TIMESTAMP END)
+				- started_at)::DOUBLE PRECISION
+		FROM job_queue
+`
+
+func scanJob(row interface{ Scan(...interface{}) error }) (models.Job, error) {
+	var j models.Job
+	var payload, result []byte
+	var startedAt, finishedAt sql.NullTime
+	var duration sql.NullFloat64
+	err := row.Scan(
+		&j.ID, &j.Kind, &payload, &j.Status, &j.Attempts, &j.MaxAttempts, &j.RequestedBy,
+		&j.TaskID, &j.Stage, &j.Done, &j.Total, &j.Error, &result,
+		&j.RunAfter, &j.CreatedAt, &startedAt, &finishedAt, &duration,
+	)
+	if err != nil {
+		return j, err
+	}
+
+	j.Payload, j.Result = payload, result
+	if j.Total > 0 {
+		j.Percent = min(100, float64(j.Done)*100/float64(j.Total))
+	}
+	if startedAt.Valid {
+		j.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		j.FinishedAt = &finishedAt.Time
+	}
+	if duration.Valid {
+		j.DurationSeconds = &duration.Float64
+	}
+	return j, nil
+}
+
+// EnqueueJob adds a job to the queue, runnable at once
+func EnqueueJob(kind string, payload []byte, maxAttempts int, requestedBy string) (models.Job, error) {
+	row := DB.QueryRow(`
+		INSERT INTO job_queue (kind, payload, max_attempts, requested_by)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		RETURNING id
+	`, kind, payload, maxAttempts, requestedBy)
+
+	var id int64
+	if err := row.Scan(&id); err != nil {
+		return models.Job{}, fmt.Errorf("failed to queue %s job: %w", kind, err)
+	}
+	return GetJob(id)
+}
+
+// GetJob retrieves one job; sql.ErrNoRows is returned when it does not exist
+func GetJob(id int64) (models.Job, error) {
+	j, err := scanJob(DB.QueryRow(jobSelect+` WHERE id = $1`, id))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return j, fmt.Errorf("failed to query job %d: %w", id, err)
+	}
+	return j, err
+}
+
+// GetJobs retrieves jobs, newest first
+func GetJobs(filter JobFilter, limit, offset int) ([]models.Job, error) {
+	rows, err := DB.Query(jobSelect+`
+		WHERE ($1 = '' OR status = $1)
+		  AND ($2 = '' OR kind = $2)
+		ORDER BY id DESC
+		LIMIT $3 OFFSET $4
+	`, filter.Status, filter.Kind, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []models.Job{}
+	for rows.Next() {
+		j, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, rows.Err()
+}
+
+// ClaimJob takes the next due job for worker and marks it running, or returns sql.ErrNoRows
+// when there is none. A running job whose heartbeat is older than stale lost its worker: it is
+// claimed again if it has attempts left and marked failed otherwise.
+func ClaimJob(worker string, stale time.Duration) (models.Job, error) {
+	_, err := DB.Exec(`
+		UPDATE job_queue
+		SET status = $1, error = 'worker stopped responding', finished_at = CURRENT_TIMESTAMP
+		WHERE status = $2
+		  AND heartbeat_at < CURRENT_TIMESTAMP - make_interval(secs => $3)
+		  AND attempts >= max_attempts
+	`, models.JobFailed, models.JobRunning, stale.Seconds())
+	if err != nil {
+		return models.Job{}, fmt.Errorf("failed to expire abandoned jobs: %w", err)
+	}
+
+	var id int64
+	err = DB.QueryRow(`
+		UPDATE job_queue
+		SET status = $1, attempts = attempts + 1, worker = $2, task_id = NULL,
+		    stage = NULL, done = 0, total = 0, result = NULL,
+		    started_at = CURRENT_TIMESTAMP, heartbeat_at = CURRENT_TIMESTAMP, finished_at = NULL
+		WHERE id = (
+			SELECT id FROM job_queue
+			WHERE (status = $3 AND run_after <= CURRENT_TIMESTAMP)
+			   OR (status = $1 AND heartbeat_at < CURRENT_TIMESTAMP - make_interval(secs => $4))
+			ORDER BY run_after, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id
+	`, models.JobRunning, worker, models.JobQueued, stale.Seconds()).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Job{}, err
+		}
+		return models.Job{}, fmt.Errorf("failed to claim job: %w", err)
+	}
+	return GetJob(id)
+}
+
+// HeartbeatJob records a running job's progress. running is false once the job is no longer
+// running on worker, because it was cancelled or claimed by another worker after going stale;
+// the worker should then stop it.
+func HeartbeatJob(id int64, worker string, p models.Progress) (running bool, err error) {
+	res, err := DB.Exec(`
+		UPDATE job_queue
+		SET heartbeat_at = CURRENT_TIMESTAMP, task_id = NULLIF($3, ''), stage = NULLIF($4, ''),
+		    done = $5, total = $6
+		WHERE id = $1 AND worker = $2 AND status = $7
+	`, id, worker, p.TaskID, p.Stage, p.Done, p.Total, models.JobRunning)
+	if err != nil {
+		return false, fmt.Errorf("failed to record job %d heartbeat: %w", id, err)
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// FinishJob records the outcome of a run. A failed run with a non-nil retryAt is queued again
+// for then; otherwise the job ends done or failed. Jobs no longer running on worker are left
+// alone.
+func FinishJob(id int64, worker string, p models.Progress, result []byte, runErr string, retryAt *time.Time) error {
+	status := models.JobDone
+	switch {
+	case runErr != "" && retryAt != nil:
+		status = models.JobQueued
+	case runErr != "":
+		status = models.JobFailed
+	}
+
+	_, err := DB.Exec(`
+		UPDATE job_queue
+		SET status = $3, stage = NULLIF($4, ''), done = $5, total = $6, result = $7,
+		    error = NULLIF($8, ''), run_after = COALESCE($9, run_after), finished_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND worker = $2 AND status = $10
+	`, id, worker, status, p.Stage, p.Done, p.Total, result, runErr, retryAt, models.JobRunning)
+	if err != nil {
+		return fmt.Errorf("failed to record job %d outcome: %w", id, err)
+	}
+	return nil
+}
+
+// RetryJob queues a job in one of the from statuses to run now with a fresh set of attempts.
+// sql.ErrNoRows is returned when the job is not in one of them.
+func RetryJob(id int64, from []string) (models.Job, error) {
+	res, err := DB.Exec(`
+		UPDATE job_queue
+		SET status = $2, attempts = 0, run_after = CURRENT_TIMESTAMP, finished_at = NULL
+		WHERE id = $1 AND status = ANY($3)
+	`, id, models.JobQueued, pq.Array(from))
+	if err := affectedOne(res, err); err != nil {
+		return models.Job{}, err
+	}
+	return GetJob(id)
+}
+
+// CancelJob cancels a job in one of the from statuses; a running job's worker notices at its
+// next heartbeat and stops it. sql.ErrNoRows is returned when the job is not in one of them.
+func CancelJob(id int64, from []string) (models.Job, error) {
+	res, err := DB.Exec(`
+		UPDATE job_queue
+		SET status = $2, finished_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = ANY($3)
+	`, id, models.JobCancelled, pq.Array(from))
+	if err := affectedOne(res, err); err != nil {
+		return models.Job{}, err
+	}
+	return GetJob(id)
+}
+
+func affectedOne(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"fmt"
+	"political-network-api/internal/models"
+	"strconv"
+)
+
+// ListCursor is the last row of a page of a list in its keyset order: the row's sort value as a
+// decimal or date string and its key, an id or CNPJ. As with PoliticianCursor, the value is
+// stored so the page continues where it was even when the row's value changes.
+type ListCursor struct {
+	Value string `json:"v"`
+	Key   string `json:"k"`
+}
+
+// keyset is the order of a list: by a never-NULL sort expression descending, ties by a unique key
+type keyset struct {
+	sort    string
+	numeric bool // compare sort values as NUMERIC; dates compare as they are
+	key     string
+	keyDesc bool
+}
+
+// Keyset orders of the cursor-paged lists
+var (
+	partyKeyset    = keyset{sort: "COALESCE(total_membros, 0)", numeric: true, key: "id"}
+	companyKeyset  = keyset{sort: "COALESCE(fc.total_transaction_amount, 0)", numeric: true, key: "fc.cnpj_cpf"}
+	sanctionKeyset = keyset{sort: "COALESCE(penalty_amount, 0)", numeric: true, key: "id"}
+	expenseKeyset  = keyset{sort: "fr.transaction_date", key: "fr.id", keyDesc: true}
+)
+
+// orderBy is k's ORDER BY clause
+func (k keyset) orderBy() string {
+	if k.keyDesc {
+		return k.sort + " DESC, " + k.key + " DESC"
+	}
+	return k.sort + " DESC, " + k.key
+}
+
+// after is the condition matching the rows after cursor in k, with its parameters numbered from n
+func (k keyset) after(cursor ListCursor, n int) (string, []interface{}) {
+	value := fmt.Sprintf("$%d", n)
+	if k.numeric {
+		value = "CAST(" + value + " AS NUMERIC)"
+	}
+	op := ">"
+	if k.keyDesc {
+		op = "<"
+	}
+	return fmt.Sprintf("(%[1]s < %[2]s OR (%[1]s = %[2]s AND %[3]s %[4]s $%[5]d))",
+		k.sort, value, k.key, op, n+1), []interface{}{cursor.Value, cursor.Key}
+}
+
+// PartyCursor is the cursor of the page after p
+func PartyCursor(p models.Party) ListCursor {
+	return ListCursor{Value: strconv.Itoa(p.TotalMembros), Key: strconv.Itoa(p.ID)}
+}
+
+// CompanyCursor is the cursor of the page after c, by its unadjusted total
+func CompanyCursor(c models.Company) ListCursor {
+	return ListCursor{Value: c.TotalValue.String(), Key: c.CNPJ}
+}
+
+// SanctionCursor is the cursor of the page after s
+func SanctionCursor(s models.Sanction) ListCursor {
+	return ListCursor{Value: s.ValorMulta.String(), Key: strconv.Itoa(s.ID)}
+}
+
+// ExpenseCursor is the cursor of the page after f
+func ExpenseCursor(f models.FinancialRecord) ListCursor {
+	return ListCursor{Value: f.DataDoc, Key: strconv.Itoa(f.ID)}
+}
@@ -0,0 +1,176 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+	"strings"
+)
+
+// publicLenderNames names the lenders of public_loans, which stores their acronym
+var publicLenderNames = map[string]string{
+	"BNDES": "Banco Nacional de Desenvolvimento Econômico e Social",
+}
+
+// publicLoanSelect reads public_loans (loaded by cli4 populate-bndes)
+const publicLoanSelect = `
+		SELECT
+			id, lender, cnpj, COALESCE(client_name, ''), COALESCE(uf, ''), COALESCE(municipality, ''),
+			COALESCE(contract_number, ''), COALESCE(contract_date::text, ''),
+			COALESCE(contracted_value, 0), COALESCE(disbursed_value, 0),
+			COALESCE(product, ''), COALESCE(instrument, ''), COALESCE(support_type, ''),
+			COALESCE(financial_agent, ''), COALESCE(status, '')
+		FROM public_loans
+`
+
+// GetCompanyPublicLoans retrieves a company's public loans, most recent first. It returns
+// nothing when public_loans hasn't been created (cli4 populate-bndes is optional).
+func (rd Reader) GetCompanyPublicLoans(cnpj string, limit int) ([]models.PublicLoan, error) {
+	loans := []models.PublicLoan{}
+	if ingested, err := rd.tableExists("public_loans"); err != nil || !ingested {
+		return loans, err
+	}
+
+	rows, err := rd.q.Query(publicLoanSelect+`
+		WHERE cnpj = $1
+		ORDER BY contract_date DESC NULLS LAST, id DESC
+		LIMIT $2
+	`, cnpj, sqlLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query public loans: %w", err)
+	}
+	defer rows.Close()
+
+	scanned := scanErrors{what: "public loans"}
+	for rows.Next() {
+		var l models.PublicLoan
+		err := rows.Scan(
+			&l.ID, &l.Lender, &l.CNPJ, &l.Cliente, &l.UF, &l.Municipio,
+			&l.NumeroContrato, &l.DataContratacao, &l.ValorContratado, &l.ValorDesembolsado,
+			&l.Produto, &l.Instrumento, &l.FormaApoio, &l.AgenteFinanceiro, &l.Situacao,
+		)
+		if !scanned.keep(err) {
+			continue
+		}
+
+		loans = append(loans, l)
+	}
+
+	return rowsResult(loans, rows.Err(), &scanned)
+}
+
+// GetCompanyLoanTotals sums every public loan of a company
+func (rd Reader) GetCompanyLoanTotals(cnpj string) (models.PublicLoanTotals, error) {
+	var totals models.PublicLoanTotals
+	if ingested, err := rd.tableExists("public_loans"); err != nil || !ingested {
+		return totals, err
+	}
+
+	err := rd.q.QueryRow(`
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(contracted_value), 0),
+			COALESCE(SUM(disbursed_value), 0),
+			COALESCE(MIN(contract_date)::text, ''),
+			COALESCE(MAX(contract_date)::text, '')
+		FROM public_loans
+		WHERE cnpj = $1
+	`, cnpj).Scan(&totals.Count, &totals.ValorContratado, &totals.ValorDesembolsado, &totals.FirstDate, &totals.LastDate)
+	if err != nil {
+		return totals, fmt.Errorf("failed to sum public loans: %w", err)
+	}
+	return totals, nil
+}
+
+// publicBankSelect aggregates public_loans by lender
+const publicBankSelect = `
+		SELECT lender, COUNT(*), COUNT(DISTINCT cnpj), COALESCE(SUM(disbursed_value), 0)
+		FROM public_loans
+		GROUP BY lender
+		ORDER BY lender
+`
+
+// scanPublicBank scans a row produced by publicBankSelect
+func scanPublicBank(rows *sql.Rows) (models.PublicBank, error) {
+	var b models.PublicBank
+	var lender string
+	if err := rows.Scan(&lender, &b.LoanCount, &b.CompanyCount, &b.ValorDesembolsado); err != nil {
+		return b, err
+	}
+	b.Slug = strings.ToLower(lender)
+	b.Nome = lender
+	if name, ok := publicLenderNames[lender]; ok {
+		b.Nome = name
+	}
+	return b, nil
+}
+
+// GetPublicBanks retrieves the public lenders with loans to companies in the dataset
+func GetPublicBanks() ([]models.PublicBank, error) {
+	banks := []models.PublicBank{}
+	err := EachPublicBank(func(b models.PublicBank) error {
+		banks = append(banks, b)
+		return nil
+	})
+	return banks, err
+}
+
+// EachPublicBank streams every public lender to fn
+func EachPublicBank(fn func(models.PublicBank) error) error {
+	if ingested, err := tableExists("public_loans"); err != nil || !ingested {
+		return err
+	}
+	return eachRow(publicBankSelect, "public banks", func(rows *sql.Rows) error {
+		b, err := scanPublicBank(rows)
+		if err != nil {
+			return err
+		}
+		return fn(b)
+	})
+}
+
+// getPublicLoanConnections creates company-public bank connections carrying the amount
+// disbursed and the period of the loans
+func getPublicLoanConnections() ([]models.Connection, error) {
+	if ingested, err := tableExists("public_loans"); err != nil || !ingested {
+		return nil, err
+	}
+
+	rows, err := DB.Query(`
+		SELECT
+			cnpj, lender,
+			COALESCE(SUM(disbursed_value), 0),
+			COUNT(*),
+			COALESCE(MIN(contract_date)::text, ''),
+			COALESCE(MAX(contract_date)::text, '')
+		FROM public_loans
+		GROUP BY cnpj, lender
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var connections []models.Connection
+	for rows.Next() {
+		var cnpj, lender, firstDate, lastDate string
+		var disbursed models.Money
+		var loans int
+
+		if err := rows.Scan(&cnpj, &lender, &disbursed, &loans, &firstDate, &lastDate); err != nil {
+			return nil, err
+		}
+
+		connections = append(connections, models.Connection{
+			SourceID:  fmt.Sprintf("company_%s", cnpj),
+			TargetID:  fmt.Sprintf("%s_%s", models.NodeTypePublicBank, strings.ToLower(lender)),
+			Type:      "public_loan",
+			Value:     disbursed,
+			Strength:  ConnectionStrength(loans),
+			StartDate: firstDate,
+			EndDate:   lastDate,
+		})
+	}
+
+	return connections, rows.Err()
+}
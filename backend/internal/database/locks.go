@@ -0,0 +1,67 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+// schedulerLockKey is the advisory lock guarding a scheduled job, hashed from its name so
+// every instance agrees on it
+func schedulerLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("political-network-api:scheduler:" + name))
+	return int64(h.Sum64())
+}
+
+// RunScheduled runs fn for the scheduled job name on one instance at a time: unless another
+// instance holds its lock or started it less than interval ago, as recorded in scheduler_runs.
+// The lock is transaction-scoped, so it is released if this instance dies and works behind a
+// transaction-pooling bouncer; fn uses its own connections. ran reports whether fn ran.
+func RunScheduled(name, instance string, interval time.Duration, fn func() error) (ran bool, err error) {
+	tx, err := DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err := tx.QueryRow(`SELECT pg_try_advisory_xact_lock($1)`, schedulerLockKey(name)).Scan(&locked); err != nil {
+		return false, fmt.Errorf("failed to take %s scheduler lock: %w", name, err)
+	}
+	if !locked {
+		return false, nil
+	}
+
+	// Replicas' tickers drift apart; a tenth of the interval of slack keeps one that ticks just
+	// before the interval is up from skipping a whole round
+	var due bool
+	err = tx.QueryRow(`
+		SELECT last_run_at <= CURRENT_TIMESTAMP - make_interval(secs => $2)
+		FROM scheduler_runs WHERE name = $1
+	`, name, (interval - interval/10).Seconds()).Scan(&due)
+	if err == sql.ErrNoRows {
+		due = true
+	} else if err != nil {
+		return false, fmt.Errorf("failed to read %s last run: %w", name, err)
+	}
+	if !due {
+		return false, nil
+	}
+
+	// Stamped with the transaction's start, before fn runs, so the interval runs start to start
+	_, err = tx.Exec(`
+		INSERT INTO scheduler_runs (name, last_run_at, instance) VALUES ($1, CURRENT_TIMESTAMP, $2)
+		ON CONFLICT (name) DO UPDATE SET last_run_at = EXCLUDED.last_run_at, instance = EXCLUDED.instance
+	`, name, instance)
+	if err != nil {
+		return false, fmt.Errorf("failed to record %s run: %w", name, err)
+	}
+
+	runErr := fn()
+	if err := tx.Commit(); err != nil {
+		return true, fmt.Errorf("failed to release %s scheduler lock: %w", name, err)
+	}
+	return true, runErr
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// nodeTables are the tables whose rows are the nodes of a type, for the node type counts;
+// types without one, built from several tables, are listed without a count
+var nodeTables = map[models.NodeType]string{
+	models.NodeTypePolitician: "unified_politicians",
+	models.NodeTypeParty:      "political_parties",
+	models.NodeTypeCompany:    "financial_counterparts",
+	models.NodeTypeSanction:   "vendor_sanctions",
+	models.NodeTypeTCURuling:  "tcu_rulings",
+}
+
+// GetEnums lists the valid node and connection types, sanction types, CEAP expense categories,
+// UFs and parties, the data-backed ones with counts, most common first. Node counts of large
+// tables are planner estimates (see CountRows).
+func GetEnums() (models.Enums, error) {
+	var enums models.Enums
+	var err error
+
+	enums.NodeTypes = make([]models.EnumValue, len(models.NodeTypes))
+	for i, t := range models.NodeTypes {
+		enums.NodeTypes[i] = models.EnumValue{Value: string(t)}
+		table, ok := nodeTables[t]
+		if !ok {
+			continue
+		}
+		if loaded, err := tableExists(table); err != nil {
+			return enums, err
+		} else if !loaded {
+			continue
+		}
+		count, estimated, err := CountRows(table, false)
+		if err != nil {
+			return enums, err
+		}
+		enums.NodeTypes[i].Count = &count
+		enums.NodeTypes[i].Estimated = estimated
+	}
+
+	enums.ConnectionTypes = make([]models.EnumValue, len(models.ConnectionTypes))
+	for i, t := range models.ConnectionTypes {
+		enums.ConnectionTypes[i] = models.EnumValue{Value: t}
+	}
+
+	if enums.SanctionTypes, err = countEnum(`
+		SELECT sanction_type, '', COUNT(*)
+		FROM vendor_sanctions
+		WHERE COALESCE(sanction_type, '') != ''
+		GROUP BY sanction_type
+		ORDER BY COUNT(*) DESC, sanction_type
+	`); err != nil {
+		return enums, fmt.Errorf("failed to count sanction types: %w", err)
+	}
+
+	if enums.ExpenseCategories, err = countEnum(`
+		SELECT transaction_category, '', COUNT(*)
+		FROM unified_financial_records
+		WHERE transaction_type = 'PARLIAMENTARY_EXPENSE' AND COALESCE(transaction_category, '') != ''
+		GROUP BY transaction_category
+		ORDER BY COUNT(*) DESC, transaction_category
+	`); err != nil {
+		return enums, fmt.Errorf("failed to count expense categories: %w", err)
+	}
+
+	if enums.UFs, err = countEnum(`
+		SELECT uf, '', COUNT(*)
+		FROM unified_politicians
+		WHERE COALESCE(uf, '') != ''
+		GROUP BY uf
+		ORDER BY uf
+	`); err != nil {
+		return enums, fmt.Errorf("failed to count UFs: %w", err)
+	}
+
+	// Parties without politicians in the dataset are listed too, with a count of 0
+	if enums.Parties, err = countEnum(`
+		SELECT pp.sigla, COALESCE(MAX(pp.nome), ''), COUNT(p.id)
+		FROM political_parties pp
+		LEFT JOIN unified_politicians p ON p.current_party = pp.sigla
+		WHERE COALESCE(pp.sigla, '') != ''
+		GROUP BY pp.sigla
+		ORDER BY COUNT(p.id) DESC, pp.sigla
+	`); err != nil {
+		return enums, fmt.Errorf("failed to count parties: %w", err)
+	}
+
+	return enums, nil
+}
+
+// countEnum reads value, label and count rows
+func countEnum(query string) ([]models.EnumValue, error) {
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := []models.EnumValue{}
+	for rows.Next() {
+		var v models.EnumValue
+		var count int
+		if err := rows.Scan(&v.Value, &v.Label, &count); err != nil {
+			return nil, err
+		}
+		v.Count = &count
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,388 @@
+package database
+
+import (
+	"fmt"
+	"log"
+)
+
+// migrations create the tables owned by the API itself; the data tables are created by
+// the ETL setup scripts. Statements must be idempotent because they run on every start.
+var migrations = []struct {
+	name string
+	sql  string
+}{
+	{"audit_log", `
+		CREATE TABLE IF NOT EXISTS audit_log (
+			id BIGSERIAL PRIMARY KEY,
+			actor VARCHAR(100) NOT NULL,
+			role VARCHAR(50) NOT NULL,
+			action VARCHAR(100) NOT NULL,
+			resource VARCHAR(255),
+			payload JSONB,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at DESC);
+	`},
+	{"sanction_status_changes", `
+		CREATE TABLE IF NOT EXISTS sanction_status_changes (
+			id BIGSERIAL PRIMARY KEY,
+			sanction_id INTEGER NOT NULL,
+			cnpj_cpf VARCHAR(14),
+			was_active BOOLEAN,
+			is_active BOOLEAN NOT NULL,
+			sanction_start_date DATE,
+			sanction_end_date DATE,
+			changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_sanction_status_changes_sanction ON sanction_status_changes(sanction_id, changed_at DESC);
+	`},
+	{"webhooks", `
+		CREATE TABLE IF NOT EXISTS webhooks (
+			id BIGSERIAL PRIMARY KEY,
+			url VARCHAR(2048) NOT NULL,
+			secret VARCHAR(100) NOT NULL,
+			events TEXT[] NOT NULL,
+			score_threshold INTEGER NOT NULL DEFAULT 10,
+			owner VARCHAR(100) NOT NULL,
+			active BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_webhooks_owner ON webhooks(owner);
+		CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			id BIGSERIAL PRIMARY KEY,
+			webhook_id BIGINT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+			event VARCHAR(50) NOT NULL,
+			payload JSONB NOT NULL,
+			status VARCHAR(20) NOT NULL DEFAULT 'pending',
+			attempts INTEGER NOT NULL DEFAULT 0,
+			response_status INTEGER,
+			last_error TEXT,
+			next_attempt_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			delivered_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at DESC);
+		CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+		CREATE TABLE IF NOT EXISTS webhook_cursors (
+			name VARCHAR(50) PRIMARY KEY,
+			position BIGINT NOT NULL,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE IF NOT EXISTS politician_score_snapshots (
+			politician_id INTEGER PRIMARY KEY,
+			score INTEGER NOT NULL,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+	`},
+	{"watchlists", `
+		CREATE TABLE IF NOT EXISTS watchlists (
+			id BIGSERIAL PRIMARY KEY,
+			owner VARCHAR(100) NOT NULL,
+			name VARCHAR(200) NOT NULL,
+			webhook_id BIGINT REFERENCES webhooks(id) ON DELETE SET NULL,
+			email VARCHAR(320),
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_watchlists_owner ON watchlists(owner);
+		CREATE TABLE IF NOT EXISTS watchlist_items (
+			watchlist_id BIGINT NOT NULL REFERENCES watchlists(id) ON DELETE CASCADE,
+			entity_type VARCHAR(20) NOT NULL,
+			entity_id VARCHAR(14) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (watchlist_id, entity_type, entity_id)
+		);
+		CREATE INDEX IF NOT EXISTS idx_watchlist_items_entity ON watchlist_items(entity_type, entity_id);
+		CREATE TABLE IF NOT EXISTS watchlist_alerts (
+			id BIGSERIAL PRIMARY KEY,
+			watchlist_id BIGINT NOT NULL REFERENCES watchlists(id) ON DELETE CASCADE,
+			entity_type VARCHAR(20) NOT NULL,
+			entity_id VARCHAR(14) NOT NULL,
+			event VARCHAR(50) NOT NULL,
+			summary TEXT NOT NULL,
+			data JSONB,
+			emailed_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_watchlist_alerts_watchlist ON watchlist_alerts(watchlist_id, created_at DESC);
+	`},
+	{"users", `
+		CREATE TABLE IF NOT EXISTS users (
+			id BIGSERIAL PRIMARY KEY,
+			email VARCHAR(320) NOT NULL UNIQUE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			last_login_at TIMESTAMP
+		);
+		CREATE TABLE IF NOT EXISTS login_tokens (
+			token_hash CHAR(64) PRIMARY KEY,
+			user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			expires_at TIMESTAMP NOT NULL,
+			used_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_login_tokens_user ON login_tokens(user_id, created_at DESC);
+		CREATE TABLE IF NOT EXISTS user_sessions (
+			token_hash CHAR(64) PRIMARY KEY,
+			user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			expires_at TIMESTAMP NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE IF NOT EXISTS saved_views (
+			id VARCHAR(16) PRIMARY KEY,
+			user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			name VARCHAR(200) NOT NULL,
+			filters JSONB,
+			selected_nodes TEXT[],
+			camera JSONB,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_saved_views_user ON saved_views(user_id, updated_at DESC);
+	`},
+	{"share_snapshots", `
+		CREATE TABLE IF NOT EXISTS share_snapshots (
+			id VARCHAR(16) PRIMARY KEY,
+			title VARCHAR(200) NOT NULL,
+			description TEXT NOT NULL,
+			filters JSONB,
+			graph JSONB NOT NULL,
+			created_by VARCHAR(100),
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			expires_at TIMESTAMP NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_share_snapshots_expires ON share_snapshots(expires_at);
+	`},
+	{"politician_relations", `
+		CREATE TABLE IF NOT EXISTS politician_relations (
+			id BIGSERIAL PRIMARY KEY,
+			politician_id INTEGER NOT NULL REFERENCES unified_politicians(id) ON DELETE CASCADE,
+			relative_id INTEGER NOT NULL REFERENCES unified_politicians(id) ON DELETE CASCADE,
+			relationship VARCHAR(20) NOT NULL DEFAULT 'relative',
+			status VARCHAR(20) NOT NULL DEFAULT 'suggested',
+			source VARCHAR(20) NOT NULL,
+			score DECIMAL(3,2),
+			evidence TEXT,
+			note TEXT,
+			reviewed_by VARCHAR(100),
+			reviewed_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			CHECK (politician_id < relative_id),
+			UNIQUE (politician_id, relative_id)
+		);
+		CREATE INDEX IF NOT EXISTS idx_politician_relations_relative ON politician_relations(relative_id);
+		CREATE INDEX IF NOT EXISTS idx_politician_relations_status ON politician_relations(status);
+	`},
+	{"entity_links", `
+		CREATE TABLE IF NOT EXISTS entity_links (
+			id BIGSERIAL PRIMARY KEY,
+			source VARCHAR(30) NOT NULL,
+			source_key TEXT NOT NULL,
+			source_name VARCHAR(255),
+			source_document VARCHAR(14),
+			entity_type VARCHAR(20) NOT NULL,
+			entity_id VARCHAR(14) NOT NULL,
+			method VARCHAR(20) NOT NULL,
+			confidence DECIMAL(3,2) NOT NULL,
+			evidence TEXT,
+			status VARCHAR(20) NOT NULL DEFAULT 'suggested',
+			note TEXT,
+			reviewed_by VARCHAR(100),
+			reviewed_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (source, source_key, entity_type, entity_id)
+		);
+		CREATE INDEX IF NOT EXISTS idx_entity_links_status ON entity_links(status, confidence DESC);
+		CREATE INDEX IF NOT EXISTS idx_entity_links_entity ON entity_links(entity_type, entity_id);
+	`},
+	{"dataset_versions", `
+		CREATE TABLE IF NOT EXISTS dataset_versions (
+			version SERIAL PRIMARY KEY,
+			status VARCHAR(20) NOT NULL DEFAULT 'building',
+			runs JSONB NOT NULL DEFAULT '[]',
+			table_rows JSONB,
+			note TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			published_at TIMESTAMP,
+			pruned_at TIMESTAMP
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_dataset_versions_building
+			ON dataset_versions(status) WHERE status = 'building';
+	`},
+	{"record_changes", `
+		CREATE TABLE IF NOT EXISTS record_changes (
+			id BIGSERIAL PRIMARY KEY,
+			entity VARCHAR(20) NOT NULL,
+			record_id INTEGER NOT NULL,
+			operation VARCHAR(10) NOT NULL,
+			changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_record_changes_entity ON record_changes(entity, id);
+		CREATE INDEX IF NOT EXISTS idx_record_changes_changed_at ON record_changes(entity, changed_at);
+
+		-- Updates that only bump updated_at/fetched_at (every upsert of an unchanged row) are
+		-- not changes
+		CREATE OR REPLACE FUNCTION record_change() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP = 'DELETE' THEN
+				INSERT INTO record_changes (entity, record_id, operation) VALUES (TG_ARGV[0], OLD.id, 'deleted');
+				RETURN OLD;
+			END IF;
+			IF TG_OP = 'UPDATE' AND to_jsonb(OLD) - 'updated_at' - 'fetched_at'
+					= to_jsonb(NEW) - 'updated_at' - 'fetched_at' THEN
+				RETURN NEW;
+			END IF;
+			INSERT INTO record_changes (entity, record_id, operation)
+			VALUES (TG_ARGV[0], NEW.id, CASE TG_OP WHEN 'INSERT' THEN 'created' ELSE 'updated' END);
+			RETURN NEW;
+		END $$ LANGUAGE plpgsql;
+
+		-- The ETL creates these tables: the triggers are attached to the ones that exist on
+		-- startup, so restart the API after (re)creating them
+		DO $$ BEGIN
+			IF to_regclass('public.unified_politicians') IS NOT NULL THEN
+				DROP TRIGGER IF EXISTS record_politician_changes ON public.unified_politicians;
+				CREATE TRIGGER record_politician_changes
+					AFTER INSERT OR UPDATE OR DELETE ON public.unified_politicians
+					FOR EACH ROW EXECUTE FUNCTION record_change('politicians');
+			END IF;
+			IF to_regclass('public.vendor_sanctions') IS NOT NULL THEN
+				DROP TRIGGER IF EXISTS record_sanction_changes ON public.vendor_sanctions;
+				CREATE TRIGGER record_sanction_changes
+					AFTER INSERT OR UPDATE OR DELETE ON public.vendor_sanctions
+					FOR EACH ROW EXECUTE FUNCTION record_change('sanctions');
+			END IF;
+		END $$;
+	`},
+	{"job_queue", `
+		CREATE TABLE IF NOT EXISTS job_queue (
+			id BIGSERIAL PRIMARY KEY,
+			kind VARCHAR(50) NOT NULL,
+			payload JSONB,
+			status VARCHAR(20) NOT NULL DEFAULT 'queued',
+			attempts INTEGER NOT NULL DEFAULT 0,
+			max_attempts INTEGER NOT NULL DEFAULT 3,
+			requested_by VARCHAR(100),
+			worker VARCHAR(100),
+			task_id VARCHAR(32),
+			stage VARCHAR(100),
+			done BIGINT NOT NULL DEFAULT 0,
+			total BIGINT NOT NULL DEFAULT 0,
+			error TEXT,
+			result JSONB,
+			run_after TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			started_at TIMESTAMP,
+			heartbeat_at TIMESTAMP,
+			finished_at TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_job_queue_due ON job_queue(run_after, id) WHERE status = 'queued';
+		CREATE INDEX IF NOT EXISTS idx_job_queue_running ON job_queue(heartbeat_at) WHERE status = 'running';
+		CREATE INDEX IF NOT EXISTS idx_job_queue_created ON job_queue(created_at DESC);
+	`},
+	{"scheduler_runs", `
+		CREATE TABLE IF NOT EXISTS scheduler_runs (
+			name VARCHAR(50) PRIMARY KEY,
+			last_run_at TIMESTAMP NOT NULL,
+			instance VARCHAR(100)
+		);
+	`},
+	{"corrections", `
+		CREATE TABLE IF NOT EXISTS corrections (
+			id BIGSERIAL PRIMARY KEY,
+			entity_type VARCHAR(20) NOT NULL,
+			entity_id VARCHAR(14) NOT NULL,
+			field VARCHAR(50),
+			current_value TEXT,
+			proposed_value TEXT,
+			description TEXT NOT NULL,
+			evidence TEXT[] NOT NULL DEFAULT '{}',
+			contact VARCHAR(320),
+			submitted_by VARCHAR(100) NOT NULL,
+			status VARCHAR(20) NOT NULL DEFAULT 'pending',
+			value TEXT,
+			note TEXT,
+			reviewed_by VARCHAR(100),
+			reviewed_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_corrections_status ON corrections(status, created_at);
+		CREATE INDEX IF NOT EXISTS idx_corrections_entity ON corrections(entity_type, entity_id);
+	`},
+	{"overrides", `
+		-- Corrections accepted before overrides existed become overrides, once, when the table is
+		-- created; overrides deleted later stay deleted
+		DO $$ BEGIN
+			IF to_regclass('public.overrides') IS NULL THEN
+				CREATE TABLE overrides (
+					id BIGSERIAL PRIMARY KEY,
+					entity_type VARCHAR(20) NOT NULL,
+					entity_id VARCHAR(14) NOT NULL,
+					field VARCHAR(50) NOT NULL,
+					value TEXT NOT NULL,
+					reason TEXT NOT NULL,
+					author VARCHAR(100) NOT NULL,
+					correction_id BIGINT REFERENCES corrections(id) ON DELETE SET NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE (entity_type, entity_id, field)
+				);
+				CREATE INDEX idx_overrides_correction ON overrides(correction_id);
+				INSERT INTO overrides (entity_type, entity_id, field, value, reason, author, correction_id, created_at, updated_at)
+				SELECT DISTINCT ON (entity_type, entity_id, field)
+					entity_type, entity_id, field, value, description, reviewed_by, id, reviewed_at, reviewed_at
+				FROM corrections
+				WHERE status = 'accepted' AND field IS NOT NULL AND value IS NOT NULL
+				ORDER BY entity_type, entity_id, field, reviewed_at DESC, id DESC;
+			END IF;
+		END $$;
+	`},
+	{"annotations", `
+		CREATE TABLE IF NOT EXISTS annotations (
+			id BIGSERIAL PRIMARY KEY,
+			entity_type VARCHAR(20) NOT NULL,
+			entity_id VARCHAR(14) NOT NULL,
+			tag VARCHAR(100),
+			note TEXT,
+			visibility VARCHAR(10) NOT NULL DEFAULT 'private',
+			author VARCHAR(100) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_annotations_tag ON annotations(LOWER(tag));
+		CREATE INDEX IF NOT EXISTS idx_annotations_entity ON annotations(entity_type, entity_id);
+	`},
+	{"watchlist_digests", `
+		CREATE TABLE IF NOT EXISTS watchlist_digests (
+			id BIGSERIAL PRIMARY KEY,
+			watchlist_id BIGINT NOT NULL REFERENCES watchlists(id) ON DELETE CASCADE,
+			email VARCHAR(320) NOT NULL,
+			unsubscribe_token CHAR(64) NOT NULL UNIQUE,
+			base_url TEXT NOT NULL,
+			reported_anomalies TEXT[] NOT NULL DEFAULT '{}',
+			last_sent_at TIMESTAMP,
+			unsubscribed_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (watchlist_id, email)
+		);
+	`},
+	{"politician_public_ids", `
+		CREATE TABLE IF NOT EXISTS politician_public_ids (
+			cpf CHAR(11) PRIMARY KEY,
+			public_id VARCHAR(32) NOT NULL UNIQUE,
+			slug VARCHAR(255) NOT NULL UNIQUE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+	`},
+}
+
+// Migrate applies the API's own schema
+func Migrate() error {
+	for _, m := range migrations {
+		if _, err := DB.Exec(m.sql); err != nil {
+			return fmt.Errorf("migration %s failed: %w", m.name, err)
+		}
+	}
+
+	log.Printf("✅ Schema migrations applied (%d)", len(migrations))
+	return nil
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"fmt"
+	"political-network-api/internal/models"
+	"strings"
+)
+
+// Nepotism match types, as written by populate-staff
+const (
+	NepotismSameOffice  = "same_office"
+	NepotismCrossOffice = "cross_office"
+)
+
+// NepotismFilter narrows GetNepotismFlags; zero values match everything
+type NepotismFilter struct {
+	PoliticianID int // the employer or the related politician
+	MatchType    string
+	MinScore     float64
+}
+
+// GetNepotismFlags retrieves possible nepotism flags, strongest first
+func GetNepotismFlags(filter NepotismFilter, limit, offset int) ([]models.NepotismFlag, error) {
+	query := `
+		SELECT
+			s.id,
+			s.name,
+			COALESCE(s.position, ''),
+			s.start_date::text,
+			COALESCE(s.end_date::text, ''),
+			f.employer_politician_id,
+			COALESCE(e.nome_civil, e.nome_eleitoral, 'Unknown'),
+			f.related_politician_id,
+			COALESCE(r.nome_civil, r.nome_eleitoral, 'Unknown'),
+			f.match_type,
+			f.shared_surnames,
+			CAST(f.score AS DOUBLE PRECISION)
+		FROM nepotism_flags f
+		JOIN parliamentary_staff s ON s.id = f.staff_id
+		JOIN unified_politicians e ON e.id = f.employer_politician_id
+		JOIN unified_politicians r ON r.id = f.related_politician_id
+		WHERE ($1 = 0 OR f.employer_politician_id = $1 OR f.related_politician_id = $1)
+		  AND ($2 = '' OR f.match_type = $2)
+		  AND f.score >= $3
+		ORDER BY f.score DESC, s.start_date DESC, s.id
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := DB.Query(query, filter.PoliticianID, filter.MatchType, filter.MinScore, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nepotism flags: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []models.NepotismFlag
+	for rows.Next() {
+		var f models.NepotismFlag
+		var shared string
+		err := rows.Scan(
+			&f.StaffID, &f.StaffName, &f.StaffPosition, &f.StartDate, &f.EndDate,
+			&f.EmployerID, &f.EmployerName, &f.RelatedID, &f.RelatedName,
+			&f.MatchType, &shared, &f.Score,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan nepotism flag: %w", err)
+		}
+
+		f.SharedSurnames = strings.Fields(shared)
+		flags = append(flags, f)
+	}
+
+	return flags, nil
+}
+
+// getNepotismConnections creates possible_nepotism connections from a politician to each other
+// politician whose apparent relatives work in their office. Same-office flags link a politician
+// to themselves and have no edge; they are served by /api/analysis/nepotism only.
+func getNepotismConnections() ([]models.Connection, error) {
+	query := `
+		SELECT
+			f.employer_politician_id,
+			f.related_politician_id,
+			COUNT(*) as staff,
+			MAX(f.score) as score
+		FROM nepotism_flags f
+		WHERE f.match_type = $1
+		GROUP BY f.employer_politician_id, f.related_politician_id
+	`
+
+	rows, err := DB.Query(query, NepotismCrossOffice)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var connections []models.Connection
+	for rows.Next() {
+		var employerID, relatedID, staff int
+		var score float64
+
+		if err := rows.Scan(&employerID, &relatedID, &staff, &score); err != nil {
+			return nil, err
+		}
+
+		connections = append(connections, models.Connection{
+			SourceID: fmt.Sprintf("politician_%d", employerID),
+			TargetID: fmt.Sprintf("politician_%d", relatedID),
+			Type:     "possible_nepotism",
+			Strength: score,
+		})
+	}
+
+	return connections, rows.Err()
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// listenerPingInterval is how often an idle listener checks its connection is still alive
+const listenerPingInterval = 90 * time.Second
+
+// Notify sends payload to every session listening on channel, through Postgres NOTIFY
+func Notify(channel, payload string) error {
+	_, err := DB.Exec(`SELECT pg_notify($1, $2)`, channel, payload)
+	return err
+}
+
+// Listen passes every payload sent on channel to handle, from a connection of its own to
+// connStr, or to the database when connStr is empty. LISTEN needs a session, so connStr must not
+// go through a transaction-pooling bouncer. The connection is re-established when it drops;
+// notifications sent meanwhile are lost, so resync is called after each reconnection.
+func Listen(connStr, channel string, handle func(payload string), resync func()) error {
+	if connStr == "" {
+		connStr = connString
+	}
+	listener := pq.NewListener(connStr, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventDisconnected:
+			log.Printf("⚠️ Lost the %s listener connection: %v", channel, err)
+		case pq.ListenerEventConnectionAttemptFailed:
+			log.Printf("⚠️ Failed to reconnect the %s listener: %v", channel, err)
+		}
+	})
+	if err := listener.Listen(channel); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to listen on %s: %w", channel, err)
+	}
+
+	go func() {
+		for {
+			select {
+			case n := <-listener.Notify:
+				if n == nil {
+					// Nil marks a reconnection
+					resync()
+					continue
+				}
+				handle(n.Extra)
+			case <-time.After(listenerPingInterval):
+				go listener.Ping()
+			}
+		}
+	}()
+	return nil
+}
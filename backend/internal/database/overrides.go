@@ -0,0 +1,201 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"political-network-api/internal/models"
+	"slices"
+)
+
+// overrideTarget is a table overrides apply to, with the column an entity ID matches and
+// whether that column is an integer
+type overrideTarget struct {
+	table   string
+	key     string
+	integer bool
+}
+
+// where matches the entity whose ID is the parameter param, using the key's index
+func (t overrideTarget) where(param string) string {
+	if t.integer {
+		return fmt.Sprintf("%s = CAST(%s AS BIGINT)", t.key, param)
+	}
+	return fmt.Sprintf("%s = %s", t.key, param)
+}
+
+var overrideTargets = map[string]overrideTarget{
+	models.CorrectionPolitician: {table: "unified_politicians", key: "id", integer: true},
+	models.CorrectionCompany:    {table: "financial_counterparts", key: "cnpj_cpf"},
+	models.CorrectionExpense:    {table: "unified_financial_records", key: "id", integer: true},
+}
+
+// OverrideFilter narrows GetOverrides; zero values match everything
+type OverrideFilter struct {
+	EntityType string
+	EntityID   string
+}
+
+// EntityFieldValue reads a field of a politician, company or expense, or its ID when field is
+// empty; sql.ErrNoRows is returned when the entity doesn't exist. entityID must be valid for the
+// type and field one of models.CorrectableFields.
+func EntityFieldValue(entityType, entityID, field string) (string, error) {
+	target, ok := overrideTargets[entityType]
+	if !ok {
+		return "", fmt.Errorf("unknown entity type %q", entityType)
+	}
+	if field == "" {
+		field = target.key
+	}
+
+	var value sql.NullString
+	err := DB.QueryRow(fmt.Sprintf(`SELECT CAST(%s AS TEXT) FROM %s WHERE %s`,
+		field, target.table, target.where("$1")), entityID).Scan(&value)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("failed to read %s %s: %w", entityType, entityID, err)
+	}
+	return value.String, err
+}
+
+// SetOverride stores o, replacing the override of the same field, and sets the field; it fills
+// in o's ID and times
+func SetOverride(o *models.Override) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := setOverride(tx, o); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func setOverride(tx *sql.Tx, o *models.Override) error {
+	err := tx.QueryRow(`
+		INSERT INTO overrides (entity_type, entity_id, field, value, reason, author, correction_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (entity_type, entity_id, field) DO UPDATE
+		SET value = EXCLUDED.value, reason = EXCLUDED.reason, author = EXCLUDED.author,
+		    correction_id = EXCLUDED.correction_id, updated_at = CURRENT_TIMESTAMP
+		RETURNING id, created_at, updated_at
+	`, o.EntityType, o.EntityID, o.Field, o.Value, o.Reason, o.Author, o.CorrectionID).Scan(&o.ID, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store override: %w", err)
+	}
+	_, err = applyOverride(tx, *o)
+	return err
+}
+
+const overrideSelect = `
+		SELECT id, entity_type, entity_id, field, value, reason, author, correction_id, created_at, updated_at
+		FROM overrides
+`
+
+func scanOverride(row interface{ Scan(...interface{}) error }) (models.Override, error) {
+	var o models.Override
+	var correctionID sql.NullInt64
+	err := row.Scan(&o.ID, &o.EntityType, &o.EntityID, &o.Field, &o.Value, &o.Reason, &o.Author,
+		&correctionID, &o.CreatedAt, &o.UpdatedAt)
+	if correctionID.Valid {
+		o.CorrectionID = &correctionID.Int64
+	}
+	return o, err
+}
+
+// GetOverrides retrieves overrides, most recently changed first
+func GetOverrides(filter OverrideFilter, limit, offset int) ([]models.Override, error) {
+	rows, err := DB.Query(overrideSelect+`
+		WHERE ($1 = '' OR entity_type = $1)
+		  AND ($2 = '' OR entity_id = $2)
+		ORDER BY updated_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`, filter.EntityType, filter.EntityID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query overrides: %w", err)
+	}
+	defer rows.Close()
+
+	var overrides []models.Override
+	for rows.Next() {
+		o, err := scanOverride(rows)
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, o)
+	}
+	return overrides, rows.Err()
+}
+
+// GetOverride retrieves one override; sql.ErrNoRows is returned when it doesn't exist
+func GetOverride(id int64) (models.Override, error) {
+	return scanOverride(DB.QueryRow(overrideSelect+" WHERE id = $1", id))
+}
+
+// DeleteOverride removes an override, reporting whether it existed. The field keeps the value
+// until the next ETL run restores the source's.
+func DeleteOverride(id int64) (bool, error) {
+	res, err := DB.Exec(`DELETE FROM overrides WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete override: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// ApplyOverrides sets every overridden field again and returns how many rows it changed. ETL
+// runs replace the rows overrides changed; call it after them.
+func ApplyOverrides() (int, error) {
+	rows, err := DB.Query(overrideSelect)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query overrides: %w", err)
+	}
+	var overrides []models.Override
+	for rows.Next() {
+		o, err := scanOverride(rows)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		overrides = append(overrides, o)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	changed := 0
+	for _, o := range overrides {
+		n, err := applyOverride(tx, o)
+		if err != nil {
+			return 0, err
+		}
+		changed += n
+	}
+	return changed, tx.Commit()
+}
+
+// applyOverride sets an entity's field to the override's value unless it already holds it,
+// returning the number of rows changed
+func applyOverride(tx *sql.Tx, o models.Override) (int, error) {
+	target, ok := overrideTargets[o.EntityType]
+	if !ok || !slices.Contains(models.CorrectableFields[o.EntityType], o.Field) {
+		return 0, fmt.Errorf("%s %s cannot be overridden", o.EntityType, o.Field)
+	}
+	res, err := tx.Exec(fmt.Sprintf(`
+		UPDATE %s SET %s = $1
+		WHERE %s AND %s IS DISTINCT FROM $1
+	`, target.table, o.Field, target.where("$2"), o.Field), o.Value, o.EntityID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to override %s %s %s: %w", o.EntityType, o.EntityID, o.Field, err)
+	}
+	n, _ := res.RowsAffected()
+	return int(n), nil
+}
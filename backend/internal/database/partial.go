@@ -0,0 +1,108 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// maxDroppedRatio is database.max_dropped_ratio, set by Initialize
+var maxDroppedRatio = 0.01
+
+// PartialError is returned together with the rows of a read when some of its rows failed to
+// scan and were dropped, few enough for the rest to be served. Callers that can report the
+// dropped rows use the rows; the others treat it as any error.
+type PartialError struct {
+	Dropped int
+	Read    int
+	// Err is the first scan error
+	Err error
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("dropped %d of %d rows that failed to scan: %v", e.Dropped, e.Read, e.Err)
+}
+
+func (e *PartialError) Unwrap() error {
+	return e.Err
+}
+
+// DroppedRows reports how many rows err says were dropped, and whether the data returned with
+// err is usable: err is nil or a *PartialError
+func DroppedRows(err error) (int, bool) {
+	if err == nil {
+		return 0, true
+	}
+	var partial *PartialError
+	if errors.As(err, &partial) {
+		return partial.Dropped, true
+	}
+	return 0, false
+}
+
+// JoinPartial sums the PartialErrors of several reads into one, nil when none dropped rows
+func JoinPartial(errs ...error) error {
+	var joined *PartialError
+	for _, err := range errs {
+		var partial *PartialError
+		if !errors.As(err, &partial) {
+			continue
+		}
+		if joined == nil {
+			joined = &PartialError{Err: partial.Err}
+		}
+		joined.Dropped += partial.Dropped
+		joined.Read += partial.Read
+	}
+	if joined == nil {
+		return nil
+	}
+	return joined
+}
+
+// scanErrors counts the rows of one read that failed to scan
+type scanErrors struct {
+	what    string
+	read    int
+	dropped int
+	first   error
+}
+
+// keep records a scanned row, reporting whether it scanned and should be kept
+func (s *scanErrors) keep(err error) bool {
+	s.read++
+	if err == nil {
+		return true
+	}
+	s.dropped++
+	if s.first == nil {
+		s.first = err
+	}
+	return false
+}
+
+// err is nil when every row scanned, a *PartialError when at most maxDroppedRatio of them were
+// dropped, and an error failing the read past that
+func (s *scanErrors) err() error {
+	if s.dropped == 0 {
+		return nil
+	}
+	log.Printf("⚠️ Dropped %d of %d rows of %s that failed to scan, first: %v", s.dropped, s.read, s.what, s.first)
+	if float64(s.dropped) > maxDroppedRatio*float64(s.read) {
+		return fmt.Errorf("failed to scan %s: %d of %d rows: %w", s.what, s.dropped, s.read, s.first)
+	}
+	return &PartialError{Dropped: s.dropped, Read: s.read, Err: s.first}
+}
+
+// rowsResult ends a scan loop: items with the read's *PartialError, if any, or the error
+// failing the read when reading the rows failed or too many were dropped
+func rowsResult[T any](items []T, readErr error, scanned *scanErrors) ([]T, error) {
+	if readErr != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", scanned.what, readErr)
+	}
+	err := scanned.err()
+	if _, usable := DroppedRows(err); !usable {
+		return nil, err
+	}
+	return items, err
+}
@@ -0,0 +1,177 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// partyMembersCTE reduces a party's memberships ($1) to each deputy's latest one. Current
+// members are active in the party's most recent legislature; everyone else is a former member.
+// ROW_NUMBER picks the latest rather than DISTINCT ON, which SQLite lacks.
+const partyMembersCTE = `
+		WITH members AS (
+			SELECT deputy_id, deputy_name, legislatura_id, status, data_inicio, data_fim
+			FROM (
+				SELECT
+					pm.deputy_id,
+					COALESCE(pm.deputy_name, '') as deputy_name,
+					COALESCE(pm.legislatura_id, 0) as legislatura_id,
+					COALESCE(pm.status, '') as status,
+					pm.data_inicio,
+					pm.data_fim,
+					ROW_NUMBER() OVER (
+						PARTITION BY pm.deputy_id ORDER BY pm.legislatura_id DESC NULLS LAST
+					) as recency
+				FROM party_memberships pm
+				WHERE pm.party_id = $1
+			) ranked
+			WHERE recency = 1
+		),
+		current_members AS (
+			SELECT deputy_id FROM members
+			WHERE status = 'Ativo'
+			  AND legislatura_id = (SELECT MAX(legislatura_id) FROM members)
+		)
+`
+
+// GetPartySummary aggregates a party's members, their CEAP spending and fund receipts
+func (rd Reader) GetPartySummary(party models.Party) (models.PartySummary, error) {
+	var s models.PartySummary
+
+	err := rd.q.QueryRow(partyMembersCTE+`
+		SELECT
+			(SELECT COUNT(*) FROM current_members),
+			(SELECT COUNT(*) FROM members) - (SELECT COUNT(*) FROM current_members),
+			COALESCE((
+				SELECT AVG(p.corruption_risk_score)
+				FROM current_members c
+				JOIN unified_politicians p ON p.deputy_id = c.deputy_id
+			), 0),
+			COUNT(fr.id),
+			COALESCE(SUM(fr.amount), 0)
+		FROM current_members c
+		JOIN unified_politicians p ON p.deputy_id = c.deputy_id
+		JOIN unified_financial_records fr ON fr.politician_id = p.id AND fr.source_system = 'DEPUTADOS'
+	`, party.ID).Scan(
+		&s.CurrentMembers, &s.FormerMembers, &s.AverageCorruptionScore,
+		&s.MemberExpenseCount, &s.MemberSpending,
+	)
+	if err != nil {
+		return s, fmt.Errorf("failed to summarize party: %w", err)
+	}
+
+	ingested, err := rd.tableExists("party_funds")
+	if err != nil || !ingested {
+		return s, err
+	}
+	err = rd.q.QueryRow(`
+		SELECT
+			COALESCE(SUM(amount) FILTER (WHERE fund_type = 'FUNDO_PARTIDARIO'), 0),
+			COALESCE(SUM(amount) FILTER (WHERE fund_type = 'FEFC'), 0)
+		FROM party_funds
+		WHERE party_sigla = $1
+	`, party.Sigla).Scan(&s.FundoPartidarioReceived, &s.FEFCReceived)
+	if err != nil {
+		return s, fmt.Errorf("failed to sum party funds: %w", err)
+	}
+
+	return s, nil
+}
+
+// GetPartyMembers retrieves a party's current or former members, by name
+func (rd Reader) GetPartyMembers(partyID int, current bool, limit int) ([]models.PartyMember, error) {
+	rows, err := rd.q.Query(partyMembersCTE+`
+		SELECT
+			p.id,
+			m.deputy_id,
+			COALESCE(p.nome_civil, NULLIF(m.deputy_name, ''), p.nome_eleitoral, 'Unknown') as nome,
+			m.legislatura_id,
+			m.status,
+			COALESCE(CAST(m.data_inicio AS TEXT), ''),
+			COALESCE(CAST(m.data_fim AS TEXT), ''),
+			COALESCE(CAST(p.corruption_risk_score AS INTEGER), 0)
+		FROM members m
+		JOIN unified_politicians p ON p.deputy_id = m.deputy_id
+		WHERE (m.deputy_id IN (SELECT deputy_id FROM current_members)) = $2
+		ORDER BY nome
+		LIMIT $3
+	`, partyID, current, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query party members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []models.PartyMember
+	scanned := scanErrors{what: "party members"}
+	for rows.Next() {
+		var m models.PartyMember
+		err := rows.Scan(
+			&m.PoliticianID, &m.DeputyID, &m.Nome, &m.LegislaturaID, &m.Status,
+			&m.DataInicio, &m.DataFim, &m.CorruptionScore,
+		)
+		if !scanned.keep(err) {
+			continue
+		}
+
+		members = append(members, m)
+	}
+
+	return rowsResult(members, rows.Err(), &scanned)
+}
+
+// GetPartyFunds retrieves a party's fund distributions, most recent first. It returns
+// nothing when party_funds hasn't been created (cli4 populate-party-funds is optional).
+func (rd Reader) GetPartyFunds(sigla string, limit int) ([]models.PartyFund, error) {
+	if ingested, err := rd.tableExists("party_funds"); err != nil || !ingested {
+		return nil, err
+	}
+
+	rows, err := rd.q.Query(`
+		SELECT year, month, fund_type, amount, COALESCE(data_source, 'TSE')
+		FROM party_funds
+		WHERE party_sigla = $1
+		ORDER BY year DESC, month DESC, fund_type
+		LIMIT $2
+	`, sigla, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query party funds: %w", err)
+	}
+	defer rows.Close()
+
+	var funds []models.PartyFund
+	scanned := scanErrors{what: "party funds"}
+	for rows.Next() {
+		var f models.PartyFund
+		if err := rows.Scan(&f.Year, &f.Month, &f.FundType, &f.Amount, &f.DataSource); !scanned.keep(err) {
+			continue
+		}
+
+		funds = append(funds, f)
+	}
+
+	return rowsResult(funds, rows.Err(), &scanned)
+}
+
+// tableExists reports whether an optional table, created by a cli4 populator, exists
+func (rd Reader) tableExists(table string) (bool, error) {
+	query := `SELECT to_regclass($1)::text`
+	if sqlite {
+		query = `SELECT name FROM sqlite_master WHERE type = 'table' AND name = $1`
+	}
+	var name sql.NullString
+	err := rd.q.QueryRow(query, table).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s: %w", table, err)
+	}
+	return name.Valid, nil
+}
+
+// tableExists reports whether an ETL table was created in the live database
+func tableExists(table string) (bool, error) {
+	return Live().tableExists(table)
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"fmt"
+	"political-network-api/internal/models"
+	"time"
+)
+
+// PartySwitchFilter narrows GetPartySwitches; zero values match everything
+type PartySwitchFilter struct {
+	PoliticianID int
+	Party        string // switches into or out of this sigla
+	Year         int
+}
+
+// GetPartySwitches retrieves party changes from the membership history, most recent first
+func GetPartySwitches(filter PartySwitchFilter, limit, offset int) ([]models.PartySwitch, error) {
+	query := `
+		WITH segments AS (
+			SELECT
+				h.politician_id, h.party_sigla, h.party_id, h.legislatura_id, h.start_date, h.start_event,
+				LAG(h.party_sigla) OVER w as previous_sigla,
+				LAG(h.party_id) OVER w as previous_party_id
+			FROM party_membership_history h
+			WINDOW w AS (PARTITION BY h.politician_id ORDER BY h.start_date)
+		)
+		SELECT
+			s.politician_id,
+			COALESCE(p.nome_civil, p.nome_eleitoral, 'Unknown') as nome,
+			s.previous_sigla,
+			s.party_sigla,
+			COALESCE(s.previous_party_id, 0),
+			COALESCE(s.party_id, 0),
+			s.start_date,
+			COALESCE(s.legislatura_id, 0),
+			COALESCE(s.start_event, '')
+		FROM segments s
+		JOIN unified_politicians p ON p.id = s.politician_id
+		WHERE s.previous_sigla IS NOT NULL
+		  AND ($1 = 0 OR s.politician_id = $1)
+		  AND ($2 = '' OR s.party_sigla = $2 OR s.previous_sigla = $2)
+		  AND ($3 = 0 OR EXTRACT(YEAR FROM s.start_date) = $3)
+		ORDER BY s.start_date DESC, s.politician_id
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := DB.Query(query, filter.PoliticianID, filter.Party, filter.Year, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query party switches: %w", err)
+	}
+	defer rows.Close()
+
+	var switches []models.PartySwitch
+	for rows.Next() {
+		var s models.PartySwitch
+		var date time.Time
+		err := rows.Scan(
+			&s.PoliticianID, &s.Nome, &s.FromParty, &s.ToParty, &s.FromPartyID, &s.ToPartyID,
+			&date, &s.LegislaturaID, &s.Event,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan party switch: %w", err)
+		}
+
+		s.SwitchDate = date.Format("2006-01-02")
+		s.ElectionYear, s.InPartyWindow = partyWindow(date)
+		switches = append(switches, s)
+	}
+
+	return switches, nil
+}
+
+// getPartyHistoryConnections creates time-ranged politician-party connections for the
+// parties politicians have left. Current memberships come from getPartyMembershipConnections.
+func getPartyHistoryConnections() ([]models.Connection, error) {
+	query := `
+		SELECT politician_id, party_id, start_date::text, end_date::text
+		FROM party_membership_history
+		WHERE end_date IS NOT NULL
+		  AND party_id IS NOT NULL
+		ORDER BY politician_id, start_date
+	`
+
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var connections []models.Connection
+	for rows.Next() {
+		var politicianID, partyID int
+		var startDate, endDate string
+
+		if err := rows.Scan(&politicianID, &partyID, &startDate, &endDate); err != nil {
+			return nil, err
+		}
+
+		connections = append(connections, models.Connection{
+			SourceID:  fmt.Sprintf("politician_%d", politicianID),
+			TargetID:  fmt.Sprintf("party_%d", partyID),
+			Type:      "party_switch",
+			Strength:  0.5,
+			StartDate: startDate,
+			EndDate:   endDate,
+		})
+	}
+
+	return connections, rows.Err()
+}
+
+// partyWindow returns the general election following a party switch and whether the switch
+// fell in that election's janela partidária: the 30 days before the party affiliation
+// deadline, six months before the first-round Sunday in October (Lei 9.096/95, art. 22-A).
+// 2016 had the one-off window of EC 91/2016.
+func partyWindow(date time.Time) (electionYear int, inWindow bool) {
+	year := date.Year()
+	for year%4 != 2 || !date.Before(electionDay(year)) {
+		year++
+	}
+
+	if date.Year() == 2016 {
+		ec91Start := time.Date(2016, 2, 19, 0, 0, 0, 0, time.UTC)
+		return year, !date.Before(ec91Start) && date.Before(ec91Start.AddDate(0, 0, 30))
+	}
+	if year < 2018 {
+		return year, false
+	}
+
+	deadline := electionDay(year).AddDate(0, -6, 0)
+	return year, !date.Before(deadline.AddDate(0, 0, -30)) && date.Before(deadline)
+}
+
+// electionDay is the first Sunday of October
+func electionDay(year int) time.Time {
+	d := time.Date(year, time.October, 1, 0, 0, 0, 0, time.UTC)
+	return d.AddDate(0, 0, (7-int(d.Weekday()))%7)
+}
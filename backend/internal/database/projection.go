@@ -0,0 +1,93 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// column is one expression of a SELECT list and the field of T it is scanned into
+type column[T any] struct {
+	expr  string
+	field func(*T) interface{}
+}
+
+// projection is a model's SELECT list and FROM clause, with every column declared next to the
+// field it lands in. The query text and the Scan destinations are built from the same list, so
+// they cannot drift apart: adding, removing or reordering a column changes both.
+type projection[T any] struct {
+	columns []column[T]
+	// from is the FROM clause and any fixed WHERE conditions
+	from string
+	// derive fills fields computed from the scanned ones; may be nil
+	derive func(*T)
+}
+
+// sql is the SELECT statement of the projection, to be followed by WHERE/AND, ORDER BY and
+// LIMIT clauses
+func (p projection[T]) sql() string {
+	exprs := make([]string, len(p.columns))
+	for i, c := range p.columns {
+		exprs[i] = c.expr
+	}
+	return "\n\t\tSELECT\n\t\t\t" + strings.Join(exprs, ",\n\t\t\t") + "\n\t\t" + strings.TrimSpace(p.from) + "\n"
+}
+
+// scan scans a row of the projection's SELECT into a new T
+func (p projection[T]) scan(rows *sql.Rows) (T, error) {
+	var v T
+	dest := make([]interface{}, len(p.columns))
+	for i, c := range p.columns {
+		dest[i] = c.field(&v)
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return v, err
+	}
+	if p.derive != nil {
+		p.derive(&v)
+	}
+	return v, nil
+}
+
+// selectAll runs query on q and scans every row. Rows that fail to scan are dropped and
+// reported by a *PartialError returned with the others, or fail the read when more than
+// maxDroppedRatio of them did (see partial.go): a list is never silently incomplete.
+func selectAll[T any](q querier, what string, scan func(*sql.Rows) (T, error), query string, args ...interface{}) ([]T, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", what, err)
+	}
+	defer rows.Close()
+
+	var items []T
+	scanned := scanErrors{what: what}
+	for rows.Next() {
+		item, err := scan(rows)
+		if scanned.keep(err) {
+			items = append(items, item)
+		}
+	}
+	return rowsResult(items, rows.Err(), &scanned)
+}
+
+// selectOne runs query on q and scans its first row; sql.ErrNoRows is returned when there is none
+func selectOne[T any](q querier, what string, scan func(*sql.Rows) (T, error), query string, args ...interface{}) (T, error) {
+	var zero T
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return zero, fmt.Errorf("failed to query %s: %w", what, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return zero, fmt.Errorf("failed to query %s: %w", what, err)
+		}
+		return zero, sql.ErrNoRows
+	}
+	item, err := scan(rows)
+	if err != nil {
+		return zero, fmt.Errorf("failed to scan %s: %w", what, err)
+	}
+	return item, nil
+}
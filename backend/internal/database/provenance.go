@@ -0,0 +1,213 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// provenanceTable names the provenance columns of an ingested table. cli4 adds the missing ones
+// (cli4/modules/provenance.py); recordID and url are SQL expressions, empty when there are none.
+type provenanceTable struct {
+	source   string
+	recordID string
+	url      string
+}
+
+var provenanceTables = map[string]provenanceTable{
+	"unified_politicians":                {source: "data_source", recordID: "source_record_id", url: "'https://dadosabertos.camara.leg.br/api/v2/deputados/' || deputy_id"},
+	"politician_wikidata":                {source: "data_source", recordID: "source_record_id", url: "'https://www.wikidata.org/wiki/' || qid"},
+	"financial_counterparts":             {source: "source_system", recordID: "source_record_id"},
+	"unified_financial_records":          {source: "source_system"},
+	"unified_electoral_records":          {source: "source_system"},
+	"unified_political_networks":         {source: "source_system"},
+	"unified_wealth_tracking":            {source: "data_source"},
+	"politician_career_history":          {source: "source_system"},
+	"politician_events":                  {source: "data_source"},
+	"politician_assets":                  {source: "data_source"},
+	"politician_professional_background": {source: "source_system"},
+	"vendor_sanctions":                   {source: "data_source", recordID: "api_reference_id"},
+	"tcu_disqualifications":              {source: "data_source"},
+	"political_parties":                  {source: "data_source", recordID: "source_record_id", url: "'https://dadosabertos.camara.leg.br/api/v2/partidos/' || id"},
+	"party_memberships":                  {source: "data_source"},
+	"party_funds":                        {source: "data_source"},
+	"party_membership_history":           {source: "data_source"},
+	"company_partners":                   {source: "data_source"},
+	"plenary_attendance":                 {source: "data_source"},
+	"politician_speeches":                {source: "data_source"},
+	"parliamentary_staff":                {source: "data_source"},
+	"tcu_rulings":                        {source: "data_source", recordID: "source_record_id", url: "url"},
+	"public_loans":                       {source: "lender"},
+}
+
+// provenanceQuery selects the rows of table that belong to a resource; where takes the id as $1
+type provenanceQuery struct {
+	table string
+	where string
+}
+
+// provenanceResources are, per resource, the rows describing it and the related datasets
+var provenanceResources = map[string]struct {
+	records []provenanceQuery
+	related []provenanceQuery
+}{
+	models.ProvenancePolitician: {
+		records: []provenanceQuery{
+			{"unified_politicians", "id = CAST($1 AS INTEGER)"},
+			{"politician_wikidata", "politician_id = CAST($1 AS INTEGER)"},
+		},
+		related: []provenanceQuery{
+			{"unified_financial_records", "politician_id = CAST($1 AS INTEGER)"},
+			{"unified_electoral_records", "politician_id = CAST($1 AS INTEGER)"},
+			{"unified_political_networks", "politician_id = CAST($1 AS INTEGER)"},
+			{"unified_wealth_tracking", "politician_id = CAST($1 AS INTEGER)"},
+			{"politician_assets", "politician_id = CAST($1 AS INTEGER)"},
+			{"politician_career_history", "politician_id = CAST($1 AS INTEGER)"},
+			{"politician_professional_background", "politician_id = CAST($1 AS INTEGER)"},
+			{"politician_events", "politician_id = CAST($1 AS INTEGER)"},
+			{"party_membership_history", "politician_id = CAST($1 AS INTEGER)"},
+			{"plenary_attendance", "politician_id = CAST($1 AS INTEGER)"},
+			{"politician_speeches", "politician_id = CAST($1 AS INTEGER)"},
+			{"parliamentary_staff", "politician_id = CAST($1 AS INTEGER)"},
+			{"tcu_disqualifications", "cpf = (SELECT cpf FROM unified_politicians WHERE id = CAST($1 AS INTEGER))"},
+		},
+	},
+	models.ProvenanceParty: {
+		records: []provenanceQuery{
+			{"political_parties", "id = CAST($1 AS INTEGER)"},
+		},
+		related: []provenanceQuery{
+			{"party_memberships", "party_id = CAST($1 AS INTEGER)"},
+			{"party_membership_history", "party_id = CAST($1 AS INTEGER)"},
+			{"party_funds", "party_sigla IN (SELECT sigla FROM political_parties WHERE id = CAST($1 AS INTEGER))"},
+		},
+	},
+	models.ProvenanceCompany: {
+		records: []provenanceQuery{
+			{"financial_counterparts", "cnpj_cpf = $1"},
+		},
+		related: []provenanceQuery{
+			{"unified_financial_records", "counterpart_cnpj_cpf = $1"},
+			{"vendor_sanctions", "cnpj_cpf = $1"},
+			{"public_loans", "cnpj = $1"},
+			{"company_partners", "cnpj_root = SUBSTR($1, 1, 8)"},
+		},
+	},
+	models.ProvenanceSanction: {
+		records: []provenanceQuery{
+			{"vendor_sanctions", "id = CAST($1 AS INTEGER)"},
+		},
+	},
+	models.ProvenanceTCURuling: {
+		records: []provenanceQuery{
+			{"tcu_rulings", "id = CAST($1 AS INTEGER)"},
+		},
+	},
+}
+
+// GetProvenance retrieves where a resource's data came from. sql.ErrNoRows is returned when no
+// row describes the resource; related datasets that were never ingested are skipped.
+func (rd Reader) GetProvenance(resource, id string) (models.Provenance, error) {
+	spec, ok := provenanceResources[resource]
+	if !ok {
+		return models.Provenance{}, fmt.Errorf("unknown provenance resource %q", resource)
+	}
+	provenance := models.Provenance{Resource: resource, ID: id, Records: []models.ProvenanceRecord{}}
+
+	for _, q := range spec.records {
+		records, err := rd.provenanceRecords(q, id)
+		if err != nil {
+			return provenance, err
+		}
+		provenance.Records = append(provenance.Records, records...)
+	}
+	if len(provenance.Records) == 0 {
+		return provenance, sql.ErrNoRows
+	}
+
+	for _, q := range spec.related {
+		sources, err := rd.provenanceSources(q, id)
+		if err != nil {
+			return provenance, err
+		}
+		provenance.Related = append(provenance.Related, sources...)
+	}
+
+	return provenance, nil
+}
+
+func (rd Reader) provenanceRecords(q provenanceQuery, id string) ([]models.ProvenanceRecord, error) {
+	if exists, err := rd.tableExists(q.table); err != nil || !exists {
+		return nil, err
+	}
+	t := provenanceTables[q.table]
+	recordID, url := orNull(t.recordID), orNull(t.url)
+
+	rows, err := rd.q.Query(fmt.Sprintf(`
+		SELECT COALESCE(CAST(%s AS TEXT), ''), COALESCE(CAST(%s AS TEXT), ''), COALESCE(CAST(%s AS TEXT), ''), fetched_at
+		FROM %s
+		WHERE %s
+		ORDER BY fetched_at DESC NULLS LAST
+	`, t.source, recordID, url, q.table, q.where), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s provenance: %w", q.table, err)
+	}
+	defer rows.Close()
+
+	var records []models.ProvenanceRecord
+	for rows.Next() {
+		r := models.ProvenanceRecord{Table: q.table}
+		var fetchedAt sql.NullTime
+		if err := rows.Scan(&r.SourceSystem, &r.SourceRecordID, &r.SourceURL, &fetchedAt); err != nil {
+			return nil, err
+		}
+		if fetchedAt.Valid {
+			r.FetchedAt = &fetchedAt.Time
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+func (rd Reader) provenanceSources(q provenanceQuery, id string) ([]models.ProvenanceSource, error) {
+	if exists, err := rd.tableExists(q.table); err != nil || !exists {
+		return nil, err
+	}
+	t := provenanceTables[q.table]
+
+	rows, err := rd.q.Query(fmt.Sprintf(`
+		SELECT COALESCE(CAST(%s AS TEXT), ''), COUNT(*), MIN(fetched_at), MAX(fetched_at)
+		FROM %s
+		WHERE %s
+		GROUP BY 1
+		ORDER BY 1
+	`, t.source, q.table, q.where), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s provenance: %w", q.table, err)
+	}
+	defer rows.Close()
+
+	var sources []models.ProvenanceSource
+	for rows.Next() {
+		s := models.ProvenanceSource{Table: q.table}
+		var first, last textTime
+		if err := rows.Scan(&s.SourceSystem, &s.Rows, &first, &last); err != nil {
+			return nil, err
+		}
+		if first.Valid {
+			s.FirstFetchedAt = &first.Time
+		}
+		if last.Valid {
+			s.LastFetchedAt = &last.Time
+		}
+		sources = append(sources, s)
+	}
+	return sources, rows.Err()
+}
+
+func orNull(expr string) string {
+	if expr == "" {
+		return "NULL"
+	}
+	return expr
+}
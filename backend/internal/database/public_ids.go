@@ -0,0 +1,130 @@
+package database
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"fmt"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"strings"
+)
+
+// publicIDEncoding writes public IDs in lowercase base32, which reads well in URLs
+var publicIDEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// newPublicID returns a random public ID such as pol_mfrggzdfmztwq2l. It is random rather than
+// derived from the CPF, which a hash of it would give away to anyone trying every CPF.
+func newPublicID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "pol_" + publicIDEncoding.EncodeToString(b), nil
+}
+
+// AssignPublicIDs gives every politician without one a public ID and a slug, kept by CPF so
+// they survive the ETL reloading unified_politicians under new IDs, and returns how many it
+// assigned. The slug is name-party-state at assignment and stays the same when the politician
+// switches party; one another politician already has gets the public ID appended. ETL runs add
+// politicians; call it after them.
+func AssignPublicIDs() (int, error) {
+	rows, err := DB.Query(`
+		SELECT p.cpf, COALESCE(p.nome_eleitoral, p.nome_civil, ''),
+		       COALESCE(p.current_party, ''), COALESCE(p.current_state, '')
+		FROM unified_politicians p
+		WHERE NOT EXISTS (SELECT 1 FROM politician_public_ids i WHERE i.cpf = p.cpf)
+		ORDER BY p.id
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query politicians without public IDs: %w", err)
+	}
+	type pending struct{ cpf, slug string }
+	var unassigned []pending
+	for rows.Next() {
+		var cpf, nome, party, uf string
+		if err := rows.Scan(&cpf, &nome, &party, &uf); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		unassigned = append(unassigned, pending{cpf, utils.Slug(nome, party, uf)})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(unassigned) == 0 {
+		return 0, nil
+	}
+
+	slugs, err := DB.Query(`SELECT slug FROM politician_public_ids`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query slugs: %w", err)
+	}
+	taken := map[string]bool{}
+	for slugs.Next() {
+		var slug string
+		if err := slugs.Scan(&slug); err != nil {
+			slugs.Close()
+			return 0, err
+		}
+		taken[slug] = true
+	}
+	slugs.Close()
+	if err := slugs.Err(); err != nil {
+		return 0, err
+	}
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	for _, p := range unassigned {
+		publicID, err := newPublicID()
+		if err != nil {
+			return 0, fmt.Errorf("failed to generate public ID: %w", err)
+		}
+		slug := p.slug
+		if slug == "" || taken[slug] {
+			slug = strings.TrimPrefix(slug+"-"+strings.TrimPrefix(publicID, "pol_"), "-")
+		}
+		taken[slug] = true
+		if _, err := tx.Exec(`
+			INSERT INTO politician_public_ids (cpf, public_id, slug) VALUES ($1, $2, $3)
+			ON CONFLICT (cpf) DO NOTHING
+		`, p.cpf, publicID, slug); err != nil {
+			return 0, fmt.Errorf("failed to assign public ID: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(unassigned), nil
+}
+
+// GetPoliticianKeys lists every politician in id order with the public ID and slug assigned to
+// their CPF, empty where none is, as on SQLite
+func (rd Reader) GetPoliticianKeys() ([]models.PoliticianKey, error) {
+	assigned, err := rd.hasTable("politician_public_ids")
+	if err != nil {
+		return nil, err
+	}
+	ids, join := `'', ''`, ``
+	if assigned {
+		ids, join = `COALESCE(i.public_id, ''), COALESCE(i.slug, '')`, `
+		LEFT JOIN politician_public_ids i ON i.cpf = p.cpf`
+	}
+
+	return selectAll(rd.q, "politician keys", func(rows *sql.Rows) (models.PoliticianKey, error) {
+		var k models.PoliticianKey
+		err := rows.Scan(&k.ID, &k.Nome, &k.SiglaPartido, &k.UF, &k.PublicID, &k.Slug)
+		return k, err
+	}, `
+		SELECT p.id, COALESCE(p.nome_eleitoral, p.nome_civil, ''),
+		       COALESCE(p.current_party, ''), COALESCE(p.current_state, ''), `+ids+`
+		FROM unified_politicians p`+join+`
+		ORDER BY p.id
+	`)
+}
@@ -4,196 +4,433 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"political-network-api/internal/config"
 	"political-network-api/internal/models"
+	"strconv"
+	"strings"
 	"time"
 )
 
-// GetPoliticians retrieves all politicians with optimized query
-func GetPoliticians(limit, offset int) ([]models.Politician, error) {
-	query := `
-		SELECT
-			p.id,
-			COALESCE(p.nome_civil, p.nome_eleitoral, 'Unknown') as nome,
-			COALESCE(p.cpf, '') as cpf,
-			COALESCE(p.current_state, '') as uf,
-			COALESCE(p.current_party, '') as sigla_partido,
-			COALESCE(p.situacao, '') as ultimo_status_situacao,
-			COALESCE(p.email, '') as ultimo_status_email,
-			p.created_at, p.updated_at,
-			0 as financial_records_count,
-			COALESCE(CAST(p.corruption_risk_score AS INTEGER), 0) as corruption_score
-		FROM unified_politicians p
-		ORDER BY p.id
-		LIMIT $1 OFFSET $2
-	`
+// politicianColumns is the shared projection for politician queries
+var politicianColumns = projection[models.Politician]{
+	columns: []column[models.Politician]{
+		{"p.id", func(p *models.Politician) interface{} { return &p.ID }},
+		{"COALESCE(p.nome_civil, p.nome_eleitoral, 'Unknown') as nome", func(p *models.Politician) interface{} { return &p.Nome }},
+		{"COALESCE(p.cpf, '') as cpf", func(p *models.Politician) interface{} { return &p.CPF }},
+		{"COALESCE(p.current_state, '') as uf", func(p *models.Politician) interface{} { return &p.UF }},
+		{"COALESCE(p.current_party, '') as sigla_partido", func(p *models.Politician) interface{} { return &p.SiglaPartido }},
+		{"COALESCE(p.situacao, '') as ultimo_status_situacao", func(p *models.Politician) interface{} { return &p.UltimoStatusSituacao }},
+		{"COALESCE(p.email, '') as ultimo_status_email", func(p *models.Politician) interface{} { return &p.UltimoStatusEmail }},
+		{"p.created_at", func(p *models.Politician) interface{} { return &p.CreatedAt }},
+		{"p.updated_at", func(p *models.Politician) interface{} { return &p.UpdatedAt }},
+		{"(SELECT COUNT(*) FROM unified_financial_records fr WHERE fr.politician_id = p.id) as financial_records_count", func(p *models.Politician) interface{} { return &p.FinancialRecordsCount }},
+		{"COALESCE(CAST(p.corruption_risk_score AS INTEGER), 0) as corruption_score", func(p *models.Politician) interface{} { return &p.CorruptionScore }},
+		{"COALESCE(p.url_foto, '') as url_foto", func(p *models.Politician) interface{} { return &p.URLFoto }},
+		{"COALESCE(CAST(p.birth_date AS TEXT), '') as data_nascimento", func(p *models.Politician) interface{} { return &p.DataNascimento }},
+		{"COALESCE(p.education_level, '') as escolaridade", func(p *models.Politician) interface{} { return &p.Escolaridade }},
+		{"COALESCE(p.occupation, '') as profissao", func(p *models.Politician) interface{} { return &p.Profissao }},
+		{"COALESCE(p.plenary_sessions_total, 0) as sessoes_plenario", func(p *models.Politician) interface{} { return &p.SessoesPlenario }},
+		{"COALESCE(p.plenary_sessions_present, 0) as presencas_plenario", func(p *models.Politician) interface{} { return &p.PresencasPlenario }},
+		{"CAST(p.plenary_absence_rate AS DOUBLE PRECISION) as taxa_ausencia", func(p *models.Politician) interface{} { return &p.TaxaAusencia }},
+		{"COALESCE(p.last_election_year, 0) as ano_eleicao", func(p *models.Politician) interface{} { return &p.AnoEleicao }},
+		{"p.last_election_votes as votos", func(p *models.Politician) interface{} { return &p.Votos }},
+		{"p.last_election_elected as eleito", func(p *models.Politician) interface{} { return &p.Eleito }},
+		{"COALESCE(p.last_election_coalition, '') as coligacao", func(p *models.Politician) interface{} { return &p.Coligacao }},
+		{"p.last_election_spending as gasto_campanha", func(p *models.Politician) interface{} { return &p.GastoCampanha }},
+	},
+	from: "FROM unified_politicians p",
+}
 
-	rows, err := DB.Query(query, limit, offset)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query politicians: %w", err)
-	}
-	defer rows.Close()
+// politicianSelect is politicianColumns' SELECT, scanned by scanPolitician
+var politicianSelect = politicianColumns.sql()
 
-	var politicians []models.Politician
-	for rows.Next() {
-		var p models.Politician
-		err := rows.Scan(
-			&p.ID, &p.Nome, &p.CPF, &p.UF, &p.SiglaPartido,
-			&p.UltimoStatusSituacao, &p.UltimoStatusEmail,
-			&p.CreatedAt, &p.UpdatedAt, &p.FinancialRecordsCount, &p.CorruptionScore,
-		)
-		if err != nil {
-			log.Printf("Error scanning politician: %v", err)
-			continue
+// scanPolitician scans a row produced by politicianSelect
+func scanPolitician(rows *sql.Rows) (models.Politician, error) {
+	return politicianColumns.scan(rows)
+}
+
+// PoliticianFilter narrows and orders GetPoliticians; zero values match everything in id order
+type PoliticianFilter struct {
+	MinAbsenceRate *float64 `json:"min_absence_rate,omitempty"`
+	MaxAbsenceRate *float64 `json:"max_absence_rate,omitempty"`
+	// Latest election (cli4 populate-electoral-results)
+	ElectionYear          int      `json:"election_year,omitempty"`
+	MinVotes              *int     `json:"min_votes,omitempty"`
+	MaxVotes              *int     `json:"max_votes,omitempty"`
+	Elected               *bool    `json:"elected,omitempty"`
+	MinSpendingPercentile *float64 `json:"min_spending_percentile,omitempty"` // among candidates of the same election
+	Sort                  string   `json:"sort,omitempty"`                    // one of politicianOrders
+	// Politicians carrying a tag (TaggedEntities); nil matches everyone, empty no one
+	IDs []int `json:"ids,omitempty"`
+	// Only politicians after this one in the Sort order, for cursor pagination
+	After *PoliticianCursor `json:"after,omitempty"`
+}
+
+// PoliticianCursor is the last politician of a page in a sort: their ID and, unless sorting by
+// id, their sort value as a decimal string, nil when they have none. The sort value is stored
+// rather than looked up so the page continues where it was even when the value changes.
+type PoliticianCursor struct {
+	Sort  string  `json:"s,omitempty"`
+	ID    int     `json:"id"`
+	Value *string `json:"v,omitempty"`
+}
+
+// politicianSortColumns are the columns of politicianOrders, per sort name without its direction
+var politicianSortColumns = map[string]string{
+	"absence_rate": "p.plenary_absence_rate",
+	"votes":        "p.last_election_votes",
+	"spending":     "p.last_election_spending",
+}
+
+// NextPoliticianCursor is the cursor of the page after p in sort
+func NextPoliticianCursor(p models.Politician, sort string) PoliticianCursor {
+	cursor := PoliticianCursor{Sort: sort, ID: p.ID}
+	var value string
+	switch strings.TrimPrefix(sort, "-") {
+	case "absence_rate":
+		if p.TaxaAusencia == nil {
+			return cursor
+		}
+		value = strconv.FormatFloat(*p.TaxaAusencia, 'f', -1, 64)
+	case "votes":
+		if p.Votos == nil {
+			return cursor
 		}
+		value = strconv.Itoa(*p.Votos)
+	case "spending":
+		if p.GastoCampanha == nil {
+			return cursor
+		}
+		value = p.GastoCampanha.String()
+	default:
+		return cursor
+	}
+	cursor.Value = &value
+	return cursor
+}
 
-		politicians = append(politicians, p)
+// politicianAfter is the condition matching the politicians after cursor, nulls last and ties
+// in id order as politicianOrders sorts them, with its parameters numbered from n
+func politicianAfter(cursor PoliticianCursor, n int) (string, []interface{}) {
+	column := politicianSortColumns[strings.TrimPrefix(cursor.Sort, "-")]
+	switch {
+	case column == "":
+		return fmt.Sprintf("p.id > $%d", n), []interface{}{cursor.ID}
+	case cursor.Value == nil:
+		return fmt.Sprintf("(%s IS NULL AND p.id > $%d)", column, n), []interface{}{cursor.ID}
 	}
+	op := ">"
+	if strings.HasPrefix(cursor.Sort, "-") {
+		op = "<"
+	}
+	return fmt.Sprintf("(%[1]s %[2]s CAST($%[3]d AS NUMERIC) OR (%[1]s = CAST($%[3]d AS NUMERIC) AND p.id > $%[4]d) OR %[1]s IS NULL)",
+		column, op, n, n+1), []interface{}{*cursor.Value, cursor.ID}
+}
 
-	return politicians, nil
+// politicianOrders maps the sort values of politician lists to ORDER BY clauses. Politicians
+// without attendance or election data sort last either way.
+var politicianOrders = map[string]string{
+	"":              "p.id",
+	"id":            "p.id",
+	"absence_rate":  "p.plenary_absence_rate ASC NULLS LAST, p.id",
+	"-absence_rate": "p.plenary_absence_rate DESC NULLS LAST, p.id",
+	"votes":         "p.last_election_votes ASC NULLS LAST, p.id",
+	"-votes":        "p.last_election_votes DESC NULLS LAST, p.id",
+	"spending":      "p.last_election_spending ASC NULLS LAST, p.id",
+	"-spending":     "p.last_election_spending DESC NULLS LAST, p.id",
 }
 
-// GetParties retrieves all political parties
-func GetParties(limit, offset int) ([]models.Party, error) {
-	query := `
-		SELECT
-			id, nome, sigla, COALESCE(numero_eleitoral, 0) as numero_eleitoral, COALESCE(status, '') as status,
-			COALESCE(lider_atual, '') as lider_atual, lider_id, COALESCE(total_membros, 0) as total_membros, COALESCE(total_efetivos, 0) as total_efetivos,
-			COALESCE(legislatura_id, 0) as legislatura_id, COALESCE(logo_url, '') as logo_url, created_at, updated_at
-		FROM political_parties
-		ORDER BY total_membros DESC
-		LIMIT $1 OFFSET $2
-	`
+// ValidPoliticianSort reports whether sort is a supported politician list order
+func ValidPoliticianSort(sort string) bool {
+	_, ok := politicianOrders[sort]
+	return ok
+}
 
-	rows, err := DB.Query(query, limit, offset)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query parties: %w", err)
+// GetPoliticians retrieves politicians whose corruption score is at least minScore, narrowed
+// and ordered by filter
+func (rd Reader) GetPoliticians(limit, offset, minScore int, filter PoliticianFilter) ([]models.Politician, error) {
+	order, ok := politicianOrders[filter.Sort]
+	if !ok {
+		return nil, fmt.Errorf("unsupported politician sort %q", filter.Sort)
 	}
-	defer rows.Close()
 
-	var parties []models.Party
-	for rows.Next() {
-		var p models.Party
-		var liderID sql.NullInt64
-
-		err := rows.Scan(
-			&p.ID, &p.Nome, &p.Sigla, &p.NumeroEleitoral, &p.Status,
-			&p.LiderAtual, &liderID, &p.TotalMembros, &p.TotalEfetivos,
-			&p.LegislaturaID, &p.LogoURL, &p.CreatedAt, &p.UpdatedAt,
-		)
-		if err != nil {
-			log.Printf("Error scanning party: %v", err)
-			continue
+	query := politicianSelect + `
+		WHERE COALESCE(CAST(p.corruption_risk_score AS INTEGER), 0) >= $3
+		  AND (CAST($4 AS NUMERIC) IS NULL OR p.plenary_absence_rate >= $4)
+		  AND (CAST($5 AS NUMERIC) IS NULL OR p.plenary_absence_rate <= $5)
+		  AND ($6 = 0 OR p.last_election_year = $6)
+		  AND (CAST($7 AS INTEGER) IS NULL OR p.last_election_votes >= $7)
+		  AND (CAST($8 AS INTEGER) IS NULL OR p.last_election_votes <= $8)
+		  AND (CAST($9 AS BOOLEAN) IS NULL OR p.last_election_elected = $9)
+		  AND (CAST($10 AS NUMERIC) IS NULL OR p.id IN (
+			SELECT id FROM (
+				SELECT id, PERCENT_RANK() OVER (
+					PARTITION BY last_election_year ORDER BY last_election_spending
+				) * 100 as spending_percentile
+				FROM unified_politicians
+				WHERE last_election_spending IS NOT NULL
+			) ranked
+			WHERE spending_percentile >= $10
+		  ))`
+	args := []interface{}{limit, offset, minScore,
+		filter.MinAbsenceRate, filter.MaxAbsenceRate, filter.ElectionYear, filter.MinVotes,
+		filter.MaxVotes, filter.Elected, filter.MinSpendingPercentile}
+	if filter.IDs != nil {
+		query += `
+		  AND ` + inCondition("p.id", len(args)+1, len(filter.IDs))
+		for _, id := range filter.IDs {
+			args = append(args, id)
 		}
+	}
+	if filter.After != nil {
+		condition, params := politicianAfter(*filter.After, len(args)+1)
+		query += `
+		  AND ` + condition
+		args = append(args, params...)
+	}
+	query += `
+		ORDER BY ` + order + `
+		LIMIT $1 OFFSET $2
+	`
 
-		if liderID.Valid {
-			p.LiderID = int(liderID.Int64)
-		}
+	return selectAll(rd.q, "politicians", scanPolitician, query, args...)
+}
 
-		parties = append(parties, p)
+// inCondition matches column against n placeholders numbered from first; with none it matches
+// nothing. It stands in for = ANY($n), which SQLite lacks.
+func inCondition(column string, first, n int) string {
+	if n == 0 {
+		return "1 = 0"
 	}
+	placeholders := make([]string, n)
+	for i := range placeholders {
+		placeholders[i] = "$" + strconv.Itoa(first+i)
+	}
+	return column + " IN (" + strings.Join(placeholders, ", ") + ")"
+}
 
-	return parties, nil
+// partyColumns is the shared projection for party queries
+var partyColumns = projection[models.Party]{
+	columns: []column[models.Party]{
+		{"id", func(p *models.Party) interface{} { return &p.ID }},
+		{"nome", func(p *models.Party) interface{} { return &p.Nome }},
+		{"sigla", func(p *models.Party) interface{} { return &p.Sigla }},
+		{"COALESCE(numero_eleitoral, 0) as numero_eleitoral", func(p *models.Party) interface{} { return &p.NumeroEleitoral }},
+		{"COALESCE(status, '') as status", func(p *models.Party) interface{} { return &p.Status }},
+		{"COALESCE(lider_atual, '') as lider_atual", func(p *models.Party) interface{} { return &p.LiderAtual }},
+		{"COALESCE(lider_id, 0) as lider_id", func(p *models.Party) interface{} { return &p.LiderID }},
+		{"COALESCE(total_membros, 0) as total_membros", func(p *models.Party) interface{} { return &p.TotalMembros }},
+		{"COALESCE(total_efetivos, 0) as total_efetivos", func(p *models.Party) interface{} { return &p.TotalEfetivos }},
+		{"COALESCE(legislatura_id, 0) as legislatura_id", func(p *models.Party) interface{} { return &p.LegislaturaID }},
+		{"COALESCE(logo_url, '') as logo_url", func(p *models.Party) interface{} { return &p.LogoURL }},
+		{"created_at", func(p *models.Party) interface{} { return &p.CreatedAt }},
+		{"updated_at", func(p *models.Party) interface{} { return &p.UpdatedAt }},
+	},
+	from: "FROM political_parties",
 }
 
-// GetCompanies retrieves company data with transaction aggregates
-func GetCompanies(limit, offset int) ([]models.Company, error) {
-	query := `
-		SELECT
-			fc.cnpj_cpf,
-			COALESCE(fc.name, 'Unknown Company') as nome_empresa,
-			COALESCE(fc.transaction_count, 0) as transaction_count,
-			COALESCE(fc.total_transaction_amount, 0) as total_value,
-			fc.created_at,
-			fc.updated_at
-		FROM financial_counterparts fc
-		WHERE fc.cnpj_cpf IS NOT NULL
-		  AND fc.entity_type = 'COMPANY'
-		ORDER BY fc.total_transaction_amount DESC NULLS LAST
+// partySelect is partyColumns' SELECT, scanned by scanParty
+var partySelect = partyColumns.sql()
+
+// scanParty scans a row produced by partySelect
+func scanParty(rows *sql.Rows) (models.Party, error) {
+	return partyColumns.scan(rows)
+}
+
+// GetParties retrieves all political parties, after the after cursor when it's non-nil
+func (rd Reader) GetParties(limit, offset int, after *ListCursor) ([]models.Party, error) {
+	query := partySelect
+	args := []interface{}{limit, offset}
+	if after != nil {
+		condition, params := partyKeyset.after(*after, len(args)+1)
+		query += `
+		WHERE ` + condition
+		args = append(args, params...)
+	}
+	query += `
+		ORDER BY ` + partyKeyset.orderBy() + `
 		LIMIT $1 OFFSET $2
 	`
 
-	rows, err := DB.Query(query, limit, offset)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query companies: %w", err)
-	}
-	defer rows.Close()
+	return selectAll(rd.q, "parties", scanParty, query, args...)
+}
 
-	var companies []models.Company
-	for rows.Next() {
-		var c models.Company
-		err := rows.Scan(
-			&c.CNPJ, &c.NomeEmpresa, &c.TransactionCount,
-			&c.TotalValue, &c.CreatedAt, &c.UpdatedAt,
-		)
-		if err != nil {
-			log.Printf("Error scanning company: %v", err)
-			continue
-		}
+// GetParty retrieves a single party; sql.ErrNoRows is returned when it does not exist
+func (rd Reader) GetParty(id int) (models.Party, error) {
+	return selectOne(rd.q, "party", scanParty, partySelect+`
+		WHERE id = $1
+	`, id)
+}
 
+// companyColumns is the shared projection for company queries
+var companyColumns = projection[models.Company]{
+	columns: []column[models.Company]{
+		{"fc.cnpj_cpf", func(c *models.Company) interface{} { return &c.CNPJ }},
+		{"COALESCE(fc.name, 'Unknown Company') as nome_empresa", func(c *models.Company) interface{} { return &c.NomeEmpresa }},
+		{"COALESCE(fc.transaction_count, 0) as transaction_count", func(c *models.Company) interface{} { return &c.TransactionCount }},
+		{"COALESCE(fc.total_transaction_amount, 0) as total_value", func(c *models.Company) interface{} { return &c.TotalValue }},
+		{"COALESCE(fc.cnae_code, '') as cnae", func(c *models.Company) interface{} { return &c.CNAE }},
+		{"COALESCE(fc.cnae_description, '') as cnae_description", func(c *models.Company) interface{} { return &c.CNAEDescription }},
+		{"COALESCE(fc.cnae_section, '') as sector", func(c *models.Company) interface{} { return &c.Sector }},
+		{"fc.created_at", func(c *models.Company) interface{} { return &c.CreatedAt }},
+		{"fc.updated_at", func(c *models.Company) interface{} { return &c.UpdatedAt }},
+	},
+	from: `FROM financial_counterparts fc
+		WHERE fc.cnpj_cpf IS NOT NULL
+		  AND fc.entity_type = 'COMPANY'`,
+	derive: func(c *models.Company) {
 		c.ID = c.CNPJ
-		companies = append(companies, c)
+		c.CNPJRoot = CNPJRoot(c.CNPJ)
+		c.SectorName = models.CNAESections[c.Sector]
+	},
+}
+
+// companySelect is companyColumns' SELECT, scanned by scanCompany
+var companySelect = companyColumns.sql()
+
+// scanCompany scans a row produced by companySelect
+func scanCompany(rows *sql.Rows) (models.Company, error) {
+	return companyColumns.scan(rows)
+}
+
+// CompanyFilter narrows GetCompanies; zero values match everything
+type CompanyFilter struct {
+	Sector string `json:"sector,omitempty"` // a CNAE section letter or code prefix
+	// Companies carrying a tag (TaggedEntities); nil matches every company, empty none
+	CNPJs []string `json:"cnpjs,omitempty"`
+	// Only companies after this one, for cursor pagination
+	After *ListCursor `json:"after,omitempty"`
+}
+
+// GetCompanies retrieves company data with transaction aggregates, narrowed by filter
+func (rd Reader) GetCompanies(limit, offset int, filter CompanyFilter) ([]models.Company, error) {
+	query := companySelect + `
+		  AND ` + sectorCondition("$3")
+	args := []interface{}{limit, offset, filter.Sector}
+	if filter.CNPJs != nil {
+		query += `
+		  AND ` + inCondition("fc.cnpj_cpf", len(args)+1, len(filter.CNPJs))
+		for _, cnpj := range filter.CNPJs {
+			args = append(args, cnpj)
+		}
+	}
+	if filter.After != nil {
+		condition, params := companyKeyset.after(*filter.After, len(args)+1)
+		query += `
+		  AND ` + condition
+		args = append(args, params...)
 	}
+	query += `
+		ORDER BY ` + companyKeyset.orderBy() + `
+		LIMIT $1 OFFSET $2
+	`
 
-	return companies, nil
+	return selectAll(rd.q, "companies", scanCompany, query, args...)
 }
 
-// GetSanctions retrieves sanctions data
-func GetSanctions(limit, offset int) ([]models.Sanction, error) {
-	query := `
-		SELECT
-			id,
-			COALESCE(sanction_type, '') as tipo_sancao,
-			COALESCE(cnpj_cpf, '') as cnpj,
-			'' as cpf,
-			COALESCE(penalty_amount, 0) as valor_multa,
-			COALESCE(sanction_start_date::text, '') as data_inicio_sancao,
-			created_at
-		FROM vendor_sanctions
-		WHERE cnpj_cpf IS NOT NULL AND cnpj_cpf != ''
+// sanctionColumns is the shared projection for sanction queries, expired ones included
+var sanctionColumns = projection[models.Sanction]{
+	columns: []column[models.Sanction]{
+		{"id", func(s *models.Sanction) interface{} { return &s.ID }},
+		{"COALESCE(sanction_type, '') as tipo_sancao", func(s *models.Sanction) interface{} { return &s.TipoSancao }},
+		{"COALESCE(cnpj_cpf, '') as cnpj", func(s *models.Sanction) interface{} { return &s.CNPJ }},
+		{"'' as cpf", func(s *models.Sanction) interface{} { return &s.CPF }},
+		{"COALESCE(penalty_amount, 0) as valor_multa", func(s *models.Sanction) interface{} { return &s.ValorMulta }},
+		{"COALESCE(CAST(sanction_start_date AS TEXT), '') as data_inicio_sancao", func(s *models.Sanction) interface{} { return &s.DataInicioSancao }},
+		{"COALESCE(CAST(sanction_end_date AS TEXT), '') as data_fim_sancao", func(s *models.Sanction) interface{} { return &s.DataFimSancao }},
+		{"COALESCE(is_active, false) as ativa", func(s *models.Sanction) interface{} { return &s.Ativa }},
+		{"created_at", func(s *models.Sanction) interface{} { return &s.CreatedAt }},
+	},
+	from: `FROM vendor_sanctions
+		WHERE cnpj_cpf IS NOT NULL AND cnpj_cpf != ''`,
+}
+
+// allSanctionsSelect is sanctionColumns' SELECT, scanned by scanSanction
+var allSanctionsSelect = sanctionColumns.sql()
+
+// sanctionSelect is the shared projection for active sanctions
+var sanctionSelect = allSanctionsSelect + `
 		  AND is_active = true
-		ORDER BY penalty_amount DESC NULLS LAST
+`
+
+// scanSanction scans a row produced by sanctionSelect
+func scanSanction(rows *sql.Rows) (models.Sanction, error) {
+	return sanctionColumns.scan(rows)
+}
+
+// GetSanctions retrieves sanctions data, after the after cursor when it's non-nil
+func (rd Reader) GetSanctions(limit, offset int, after *ListCursor) ([]models.Sanction, error) {
+	query := sanctionSelect
+	args := []interface{}{limit, offset}
+	if after != nil {
+		condition, params := sanctionKeyset.after(*after, len(args)+1)
+		query += `
+		  AND ` + condition
+		args = append(args, params...)
+	}
+	query += `
+		ORDER BY ` + sanctionKeyset.orderBy() + `
 		LIMIT $1 OFFSET $2
 	`
 
-	rows, err := DB.Query(query, limit, offset)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query sanctions: %w", err)
-	}
-	defer rows.Close()
+	return selectAll(rd.q, "sanctions", scanSanction, query, args...)
+}
 
-	var sanctions []models.Sanction
-	for rows.Next() {
-		var s models.Sanction
-		var cnpj, cpf sql.NullString
-		var valorMulta sql.NullFloat64
+// financialRecordColumns is the shared projection for expense queries
+var financialRecordColumns = projection[models.FinancialRecord]{
+	columns: []column[models.FinancialRecord]{
+		{"fr.id", func(f *models.FinancialRecord) interface{} { return &f.ID }},
+		{"fr.politician_id", func(f *models.FinancialRecord) interface{} { return &f.PoliticianID }},
+		{"COALESCE(fr.counterpart_cnpj_cpf, '') as cnpj_cpf", func(f *models.FinancialRecord) interface{} { return &f.CNPJ }},
+		{"fr.amount as valor", func(f *models.FinancialRecord) interface{} { return &f.Valor }},
+		{"COALESCE(CAST(fr.transaction_date AS TEXT), '') as data_doc", func(f *models.FinancialRecord) interface{} { return &f.DataDoc }},
+		{"COALESCE(fr.counterpart_name, '') as nome_empresa", func(f *models.FinancialRecord) interface{} { return &f.NomeEmpresa }},
+		{"fr.created_at", func(f *models.FinancialRecord) interface{} { return &f.CreatedAt }},
+	},
+	from: "FROM unified_financial_records fr",
+}
 
-		err := rows.Scan(
-			&s.ID, &s.TipoSancao, &cnpj, &cpf, &valorMulta,
-			&s.DataInicioSancao, &s.CreatedAt,
-		)
-		if err != nil {
-			log.Printf("Error scanning sanction: %v", err)
-			continue
-		}
+// financialRecordSelect is financialRecordColumns' SELECT, scanned by scanFinancialRecord
+var financialRecordSelect = financialRecordColumns.sql()
 
-		if cnpj.Valid {
-			s.CNPJ = cnpj.String
-		}
-		if cpf.Valid {
-			s.CPF = cpf.String
-		}
-		if valorMulta.Valid {
-			s.ValorMulta = valorMulta.Float64
-		}
+// scanFinancialRecord scans a row produced by financialRecordSelect
+func scanFinancialRecord(rows *sql.Rows) (models.FinancialRecord, error) {
+	return financialRecordColumns.scan(rows)
+}
 
-		sanctions = append(sanctions, s)
+// GetFinancialRecords retrieves expenses, optionally restricted to one politician (0 = all), after
+// the after cursor when it's non-nil
+func (rd Reader) GetFinancialRecords(politicianID, limit, offset int, after *ListCursor) ([]models.FinancialRecord, error) {
+	query := financialRecordSelect + `
+		WHERE ($1 = 0 OR fr.politician_id = $1)`
+	args := []interface{}{politicianID, limit, offset}
+	if after != nil {
+		condition, params := expenseKeyset.after(*after, len(args)+1)
+		query += `
+		  AND ` + condition
+		args = append(args, params...)
 	}
+	query += `
+		ORDER BY ` + expenseKeyset.orderBy() + `
+		LIMIT $2 OFFSET $3
+	`
 
-	return sanctions, nil
+	return selectAll(rd.q, "financial records", scanFinancialRecord, query, args...)
 }
 
 // GetConnections builds network connections between entities
 func GetConnections() ([]models.Connection, error) {
+	limits := config.Get().Network
+	return buildConnections(limits.FinancialConnectionsLimit, limits.SanctionConnectionsLimit, TopicNodeLimit, FrontNodeLimit, TCURulingNodeLimit)
+}
+
+// GetAllConnections builds the complete, uncapped set of network connections
+func GetAllConnections() ([]models.Connection, error) {
+	return buildConnections(0, 0, 0, 0, 0)
+}
+
+// buildConnections generates all connection types; a limit of 0 means no limit
+func buildConnections(financialLimit, sanctionLimit, topicLimit, frontLimit, tcuRulingLimit int) ([]models.Connection, error) {
 	var connections []models.Connection
 
 	// 1. Party memberships (politicians -> parties)
@@ -204,22 +441,90 @@ func GetConnections() ([]models.Connection, error) {
 		connections = append(connections, partyConnections...)
 	}
 
+	// 1b. Former party memberships (politicians -> parties they left), time-ranged
+	partyHistoryConnections, err := getPartyHistoryConnections()
+	if err != nil {
+		log.Printf("Error getting party history connections: %v", err)
+	} else {
+		connections = append(connections, partyHistoryConnections...)
+	}
+
 	// 2. Financial connections (politicians -> companies)
-	financialConnections, err := getFinancialConnections()
+	financialConnections, err := getFinancialConnections(financialLimit)
 	if err != nil {
 		log.Printf("Error getting financial connections: %v", err)
 	} else {
 		connections = append(connections, financialConnections...)
 	}
 
-	// 3. Sanction connections (companies/politicians -> sanctions)
-	sanctionConnections, err := getSanctionConnections()
+	// 3. Group financial connections (politicians -> company groups), catching payments
+	// split across branches that stay under the per-company thresholds
+	groupConnections, err := getGroupFinancialConnections(financialLimit)
+	if err != nil {
+		log.Printf("Error getting company group connections: %v", err)
+	} else {
+		connections = append(connections, groupConnections...)
+	}
+
+	// 4. Sanction connections (companies/politicians -> sanctions)
+	sanctionConnections, err := getSanctionConnections(sanctionLimit)
 	if err != nil {
 		log.Printf("Error getting sanction connections: %v", err)
 	} else {
 		connections = append(connections, sanctionConnections...)
 	}
 
+	// 5. Speech topics (politicians -> topics they talk about), for the topicLimit most
+	// widely discussed topics
+	topicConnections, err := getTopicConnections(topicLimit)
+	if err != nil {
+		log.Printf("Error getting topic connections: %v", err)
+	} else {
+		connections = append(connections, topicConnections...)
+	}
+
+	// 6. Frentes parlamentares (politicians -> the frontLimit largest fronts they signed)
+	frontConnections, err := getFrontConnections(frontLimit)
+	if err != nil {
+		log.Printf("Error getting front connections: %v", err)
+	} else {
+		connections = append(connections, frontConnections...)
+	}
+
+	// 7. Possible cross-office nepotism (politicians -> politicians whose apparent relatives
+	// they employ)
+	nepotismConnections, err := getNepotismConnections()
+	if err != nil {
+		log.Printf("Error getting nepotism connections: %v", err)
+	} else {
+		connections = append(connections, nepotismConnections...)
+	}
+
+	// 8. Confirmed family relations (politicians -> their relatives)
+	familyConnections, err := getFamilyConnections()
+	if err != nil {
+		log.Printf("Error getting family connections: %v", err)
+	} else {
+		connections = append(connections, familyConnections...)
+	}
+
+	// 9. TCU rulings (companies and politicians -> the tcuRulingLimit most recent acórdãos
+	// naming them)
+	tcuConnections, err := getTCURulingConnections(tcuRulingLimit)
+	if err != nil {
+		log.Printf("Error getting TCU ruling connections: %v", err)
+	} else {
+		connections = append(connections, tcuConnections...)
+	}
+
+	// 10. Public loans (companies -> the public banks financing them)
+	loanConnections, err := getPublicLoanConnections()
+	if err != nil {
+		log.Printf("Error getting public loan connections: %v", err)
+	} else {
+		connections = append(connections, loanConnections...)
+	}
+
 	log.Printf("✅ Generated %d total connections", len(connections))
 	return connections, nil
 }
@@ -248,9 +553,8 @@ func getPartyMembershipConnections() ([]models.Connection, error) {
 		var politicianID, partyID int
 		var strength int
 
-		err := rows.Scan(&politicianID, &partyID, &strength)
-		if err != nil {
-			continue
+		if err := rows.Scan(&politicianID, &partyID, &strength); err != nil {
+			return nil, err
 		}
 
 		connections = append(connections, models.Connection{
@@ -262,11 +566,12 @@ func getPartyMembershipConnections() ([]models.Connection, error) {
 		})
 	}
 
-	return connections, nil
+	return connections, rows.Err()
 }
 
-// getFinancialConnections creates politician-company financial connections
-func getFinancialConnections() ([]models.Connection, error) {
+// getFinancialConnections creates politician-company financial connections. Only companies are
+// linked: individual counterparts would put their CPF in the node ID, and have no node anyway.
+func getFinancialConnections(limit int) ([]models.Connection, error) {
 	query := `
 		SELECT
 			fr.politician_id,
@@ -274,16 +579,16 @@ func getFinancialConnections() ([]models.Connection, error) {
 			COUNT(*) as transaction_count,
 			SUM(fr.amount) as total_value
 		FROM unified_financial_records fr
-		WHERE fr.counterpart_cnpj_cpf IS NOT NULL
-		  AND fr.counterpart_cnpj_cpf != ''
-		  AND fr.amount > 0
+		JOIN financial_counterparts fc ON fc.cnpj_cpf = fr.counterpart_cnpj_cpf
+		 AND fc.entity_type = 'COMPANY'
+		WHERE fr.amount > 0
 		GROUP BY fr.politician_id, fr.counterpart_cnpj_cpf
 		HAVING COUNT(*) >= 2 OR SUM(fr.amount) > 50000
 		ORDER BY total_value DESC
-		LIMIT 5000
+		LIMIT $1
 	`
 
-	rows, err := DB.Query(query)
+	rows, err := DB.Query(query, sqlLimit(limit))
 	if err != nil {
 		return nil, err
 	}
@@ -294,17 +599,10 @@ func getFinancialConnections() ([]models.Connection, error) {
 		var politicianID int
 		var cnpj string
 		var transactionCount int
-		var totalValue float64
-
-		err := rows.Scan(&politicianID, &cnpj, &transactionCount, &totalValue)
-		if err != nil {
-			continue
-		}
+		var totalValue models.Money
 
-		// Calculate connection strength (0.1 to 1.0)
-		strength := 0.1 + (float64(transactionCount)/50.0)*0.9
-		if strength > 1.0 {
-			strength = 1.0
+		if err := rows.Scan(&politicianID, &cnpj, &transactionCount, &totalValue); err != nil {
+			return nil, err
 		}
 
 		connections = append(connections, models.Connection{
@@ -312,15 +610,24 @@ func getFinancialConnections() ([]models.Connection, error) {
 			TargetID: fmt.Sprintf("company_%s", cnpj),
 			Type:     "financial",
 			Value:    totalValue,
-			Strength: strength,
+			Strength: ConnectionStrength(transactionCount),
 		})
 	}
 
-	return connections, nil
+	return connections, rows.Err()
+}
+
+// ConnectionStrength scales a transaction count to a connection strength (0.1 to 1.0)
+func ConnectionStrength(transactionCount int) float64 {
+	strength := 0.1 + (float64(transactionCount)/50.0)*0.9
+	if strength > 1.0 {
+		strength = 1.0
+	}
+	return strength
 }
 
 // getSanctionConnections creates sanction connections
-func getSanctionConnections() ([]models.Connection, error) {
+func getSanctionConnections(limit int) ([]models.Connection, error) {
 	query := `
 		SELECT
 			vs.id,
@@ -329,10 +636,10 @@ func getSanctionConnections() ([]models.Connection, error) {
 		FROM vendor_sanctions vs
 		WHERE vs.cnpj_cpf IS NOT NULL AND vs.cnpj_cpf != ''
 		  AND vs.is_active = true
-		LIMIT 2000
+		LIMIT $1
 	`
 
-	rows, err := DB.Query(query)
+	rows, err := DB.Query(query, sqlLimit(limit))
 	if err != nil {
 		return nil, err
 	}
@@ -342,16 +649,10 @@ func getSanctionConnections() ([]models.Connection, error) {
 	for rows.Next() {
 		var sanctionID int
 		var cnpjCpf string
-		var valorMulta sql.NullFloat64
-
-		err := rows.Scan(&sanctionID, &cnpjCpf, &valorMulta)
-		if err != nil {
-			continue
-		}
+		var value models.Money
 
-		value := 0.0
-		if valorMulta.Valid {
-			value = valorMulta.Float64
+		if err := rows.Scan(&sanctionID, &cnpjCpf, &value); err != nil {
+			return nil, err
 		}
 
 		// Connect to companies by CNPJ (assuming CNPJ if length > 11)
@@ -379,7 +680,16 @@ func getSanctionConnections() ([]models.Connection, error) {
 		}
 	}
 
-	return connections, nil
+	return connections, rows.Err()
+}
+
+// sqlLimit converts a limit into a LIMIT parameter; PostgreSQL treats LIMIT NULL as no limit,
+// SQLite a negative one
+func sqlLimit(limit int) sql.NullInt64 {
+	if limit <= 0 && sqlite {
+		return sql.NullInt64{Int64: -1, Valid: true}
+	}
+	return sql.NullInt64{Int64: int64(limit), Valid: limit > 0}
 }
 
 // calculateCorruptionScore calculates corruption risk score for a politician (now using pre-calculated field)
@@ -389,17 +699,41 @@ func calculateCorruptionScore(politicianID int, cpf string) int {
 	return 0
 }
 
-// GetNetworkStats calculates network statistics
-func GetNetworkStats() (models.NetworkStats, error) {
+// GetNetworkStats calculates network statistics. Table sizes are planner estimates for large
+// tables (see CountRows) unless exact is set; stats.Estimated names the fields that are.
+func GetNetworkStats(exact bool) (models.NetworkStats, error) {
 	var stats models.NetworkStats
 	start := time.Now()
 
 	// Count entities
+	tables := []struct {
+		field, table string
+		target       *int
+	}{
+		{"politicians", "unified_politicians", &stats.Politicians},
+		{"parties", "political_parties", &stats.Parties},
+		{"companies", "financial_counterparts", &stats.Companies},
+		{"financial_records", "unified_financial_records", &stats.FinancialRecords},
+	}
+	for _, t := range tables {
+		count, estimated, err := CountRows(t.table, exact)
+		if err != nil {
+			log.Printf("Error executing stats query: %v", err)
+			continue
+		}
+		*t.target = count
+		if estimated {
+			stats.Estimated = append(stats.Estimated, t.field)
+		}
+	}
+
 	queries := map[string]*int{
-		"SELECT COUNT(*) FROM unified_politicians":     &stats.Politicians,
-		"SELECT COUNT(*) FROM political_parties":                                 &stats.Parties,
-		"SELECT COUNT(*) FROM financial_counterparts": &stats.Companies,
 		"SELECT COUNT(*) FROM vendor_sanctions WHERE is_active = true": &stats.Sanctions,
+		`SELECT COUNT(*) FROM (
+			SELECT 1 FROM financial_counterparts
+			WHERE entity_type = 'COMPANY' AND LENGTH(cnpj_cpf) = 14
+			GROUP BY SUBSTR(cnpj_cpf, 1, 8) HAVING COUNT(*) > 1
+		) g`: &stats.CompanyGroups,
 	}
 
 	for query, target := range queries {
@@ -408,7 +742,7 @@ func GetNetworkStats() (models.NetworkStats, error) {
 		}
 	}
 
-	stats.TotalNodes = stats.Politicians + stats.Parties + stats.Companies + stats.Sanctions
+	stats.TotalNodes = stats.Politicians + stats.Parties + stats.Companies + stats.CompanyGroups + stats.Sanctions
 	stats.TotalLinks = 0 // Will be calculated by connections
 
 	stats.LastUpdated = time.Now()
@@ -417,10 +751,83 @@ func GetNetworkStats() (models.NetworkStats, error) {
 	return stats, nil
 }
 
+// exactCountBelow is the planner estimate under which CountRows still runs COUNT(*): small
+// tables are counted exactly, since that is cheap
+const exactCountBelow = 100000
+
+// CountRows counts the rows of table. Unless exact is set, a table the planner estimates at
+// exactCountBelow rows or more is not scanned: its pg_class.reltuples as of the last ANALYZE
+// (or autovacuum) is returned instead, with estimated set. COUNT(*) over
+// unified_financial_records takes seconds; the estimate is usually within a few percent. A
+// SQLite file has no such estimates and is always counted.
+func CountRows(table string, exact bool) (count int, estimated bool, err error) {
+	if !exact && !sqlite {
+		var reltuples float64
+		err := DB.QueryRow(`SELECT reltuples FROM pg_class WHERE oid = $1::regclass`, table).Scan(&reltuples)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to estimate %s rows: %w", table, err)
+		}
+		// reltuples is -1 (0 before PostgreSQL 14) until the table is first analyzed
+		if reltuples >= exactCountBelow {
+			return int(reltuples), true, nil
+		}
+	}
+
+	count, err = GetCount(table)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to count %s rows: %w", table, err)
+	}
+	return count, false, nil
+}
+
 // GetCount returns total count for a table
 func GetCount(table string) (int, error) {
 	var count int
 	query := fmt.Sprintf("SELECT COUNT(*) FROM %s", table)
 	err := DB.QueryRow(query).Scan(&count)
 	return count, err
-}
\ No newline at end of file
+}
+
+// GetPolitician retrieves a single politician; sql.ErrNoRows is returned when it does not exist
+func (rd Reader) GetPolitician(id int) (models.Politician, error) {
+	return selectOne(rd.q, "politician", scanPolitician, politicianSelect+`
+		WHERE p.id = $1
+	`, id)
+}
+
+// GetPoliticianSanctions retrieves active sanctions registered against a politician's CPF
+func (rd Reader) GetPoliticianSanctions(politicianID, limit int) ([]models.Sanction, error) {
+	query := sanctionSelect + `
+		  AND cnpj_cpf = (SELECT cpf FROM unified_politicians WHERE id = $1)
+		ORDER BY sanction_start_date DESC NULLS LAST
+		LIMIT $2
+	`
+
+	return selectAll(rd.q, "politician sanctions", scanSanction, query, politicianID, limit)
+}
+
+// GetPoliticianMemberships retrieves a politician's party memberships, newest legislature first
+func (rd Reader) GetPoliticianMemberships(politicianID, limit int) ([]models.PartyMembership, error) {
+	query := `
+		SELECT
+			pm.id, pm.party_id, pm.deputy_id,
+			COALESCE(pm.deputy_name, '') as deputy_name,
+			COALESCE(pm.legislatura_id, 0) as legislatura_id,
+			COALESCE(pm.status, '') as status,
+			pm.created_at
+		FROM party_memberships pm
+		JOIN unified_politicians p ON p.deputy_id = pm.deputy_id
+		WHERE p.id = $1
+		ORDER BY pm.legislatura_id DESC NULLS LAST
+		LIMIT $2
+	`
+
+	return selectAll(rd.q, "party memberships", func(rows *sql.Rows) (models.PartyMembership, error) {
+		var m models.PartyMembership
+		err := rows.Scan(
+			&m.ID, &m.PartyID, &m.DeputyID, &m.DeputyName,
+			&m.LegislaturaID, &m.Status, &m.CreatedAt,
+		)
+		return m, err
+	}, query, politicianID, limit)
+}
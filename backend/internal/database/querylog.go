@@ -0,0 +1,247 @@
+package database
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"political-network-api/internal/models"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// packagePrefix is stripped from the functions queries are named after
+	packagePrefix = "political-network-api/internal/database."
+	// slowQueryLength caps the SQL shown in the slow query log
+	slowQueryLength = 300
+)
+
+// queryLatencyBounds are the histogram bucket edges, in milliseconds; slower queries land in a
+// final overflow bucket
+var queryLatencyBounds = []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+var (
+	// slowQueryThreshold is database.slow_query_threshold, set by Initialize; 0 logs nothing
+	slowQueryThreshold time.Duration
+
+	queryStatsMu    sync.Mutex
+	queryStats      = map[string]*queryStat{}
+	queryStatsSince = time.Now()
+
+	// queryHelpers run queries for other functions, which the queries are named after instead
+	queryHelpers = map[string]bool{"timeQuery": true, "timeExec": true, "selectAll": true, "selectOne": true, "eachRow": true}
+
+	closureSuffix = regexp.MustCompile(`(\.func\d+)+$`)
+	whitespace    = regexp.MustCompile(`\s+`)
+)
+
+// queryStat aggregates the runs of one named query since startup
+type queryStat struct {
+	calls, errors, slow int64
+	total, max          time.Duration
+	histogram           [13]int64 // len(queryLatencyBounds) + overflow
+}
+
+// QueryStats reports per-query latencies since startup, by total time spent, highest first
+func QueryStats() models.QueryReport {
+	queryStatsMu.Lock()
+	defer queryStatsMu.Unlock()
+
+	report := models.QueryReport{
+		Since:           queryStatsSince,
+		SlowThresholdMs: milliseconds(slowQueryThreshold),
+		Queries:         make([]models.QueryStats, 0, len(queryStats)),
+	}
+	for name, s := range queryStats {
+		report.Queries = append(report.Queries, s.stats(name))
+	}
+	sort.Slice(report.Queries, func(i, j int) bool {
+		a, b := report.Queries[i], report.Queries[j]
+		if a.TotalMs != b.TotalMs {
+			return a.TotalMs > b.TotalMs
+		}
+		return a.Name < b.Name
+	})
+	return report
+}
+
+func (s *queryStat) stats(name string) models.QueryStats {
+	qs := models.QueryStats{
+		Name:    name,
+		Calls:   s.calls,
+		Errors:  s.errors,
+		Slow:    s.slow,
+		TotalMs: milliseconds(s.total),
+		MaxMs:   milliseconds(s.max),
+	}
+	if s.calls == 0 {
+		return qs
+	}
+	qs.AvgMs = milliseconds(s.total / time.Duration(s.calls))
+
+	// The 95th percentile falls in the first bucket whose running count reaches 95%
+	target, seen := (s.calls*95+99)/100, int64(0)
+	qs.P95Ms = qs.MaxMs
+	for i, n := range s.histogram[:len(queryLatencyBounds)] {
+		if seen += n; seen >= target {
+			qs.P95Ms = min(queryLatencyBounds[i], qs.MaxMs)
+			break
+		}
+	}
+	return qs
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// queryRun is one query in flight, from being sent until its rows are closed
+type queryRun struct {
+	name  string
+	query string
+	args  []driver.NamedValue
+	start time.Time
+}
+
+func startQuery(query string, args []driver.NamedValue) *queryRun {
+	return &queryRun{name: queryName(), query: query, args: args, start: time.Now()}
+}
+
+// finish records the run and logs it when it took slowQueryThreshold or longer
+func (r *queryRun) finish(err error) {
+	elapsed := time.Since(r.start)
+	slow := slowQueryThreshold > 0 && elapsed >= slowQueryThreshold
+
+	queryStatsMu.Lock()
+	s, ok := queryStats[r.name]
+	if !ok {
+		s = &queryStat{}
+		queryStats[r.name] = s
+	}
+	s.calls++
+	if err != nil {
+		s.errors++
+	}
+	if slow {
+		s.slow++
+	}
+	s.total += elapsed
+	s.max = max(s.max, elapsed)
+	s.histogram[sort.SearchFloat64s(queryLatencyBounds, milliseconds(elapsed))]++
+	queryStatsMu.Unlock()
+
+	if slow {
+		log.Printf("🐢 Slow query %s took %s: %s %s", r.name, elapsed.Round(time.Millisecond), shortSQL(r.query), redactArgs(r.args))
+	}
+}
+
+// queryName names a query after the database package function that ran it, such as
+// getFinancialConnections or Reader.GetPoliticians
+func queryName() string {
+	pcs := make([]uintptr, 32)
+	// Skip runtime.Callers, queryName and startQuery, then the driver wrappers and queryHelpers
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		name, ok := strings.CutPrefix(frame.Function, packagePrefix)
+		if ok && !strings.HasPrefix(name, "(*resilient") && !queryHelpers[strings.SplitN(name, "[", 2)[0]] {
+			return closureSuffix.ReplaceAllString(name, "")
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// shortSQL collapses whitespace and cuts the query to slowQueryLength
+func shortSQL(query string) string {
+	query = strings.TrimSpace(whitespace.ReplaceAllString(query, " "))
+	if len(query) > slowQueryLength {
+		return query[:slowQueryLength] + "…"
+	}
+	return query
+}
+
+// redactArgs lists query arguments for the log. Numbers, booleans and times are shown; text and
+// bytes, which may hold CPFs, emails or tokens, are replaced by their length.
+func redactArgs(args []driver.NamedValue) string {
+	if len(args) == 0 {
+		return ""
+	}
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		var value string
+		switch v := arg.Value.(type) {
+		case nil:
+			value = "NULL"
+		case string:
+			value = fmt.Sprintf("<redacted, %d chars>", len([]rune(v)))
+		case []byte:
+			value = fmt.Sprintf("<redacted, %d bytes>", len(v))
+		case time.Time:
+			value = v.Format(time.RFC3339)
+		default:
+			value = fmt.Sprint(v)
+		}
+		parts[i] = fmt.Sprintf("$%d=%s", arg.Ordinal, value)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// timedRows finishes its query's run when the rows are closed
+type timedRows struct {
+	driver.Rows
+	run *queryRun
+	err error
+}
+
+func (r *timedRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if err != nil && !errors.Is(err, io.EOF) {
+		r.err = err
+	}
+	return err
+}
+
+func (r *timedRows) Close() error {
+	err := r.Rows.Close()
+	if r.run != nil {
+		r.run.finish(r.err)
+		r.run = nil
+	}
+	return err
+}
+
+func (r *timedRows) ColumnTypeScanType(index int) reflect.Type {
+	return r.Rows.(driver.RowsColumnTypeScanType).ColumnTypeScanType(index)
+}
+
+func (r *timedRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.Rows.(driver.RowsColumnTypeDatabaseTypeName).ColumnTypeDatabaseTypeName(index)
+}
+
+// timeQuery runs a query, timing it until its rows are closed
+func timeQuery(query string, args []driver.NamedValue, run func() (driver.Rows, error)) (driver.Rows, error) {
+	r := startQuery(query, args)
+	rows, err := run()
+	if err != nil {
+		r.finish(err)
+		return nil, err
+	}
+	return &timedRows{Rows: rows, run: r}, nil
+}
+
+// timeExec runs a statement, timing it
+func timeExec(query string, args []driver.NamedValue, run func() (driver.Result, error)) (driver.Result, error) {
+	r := startQuery(query, args)
+	res, err := run()
+	r.finish(err)
+	return res, err
+}
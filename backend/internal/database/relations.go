@@ -0,0 +1,182 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// RelationFilter narrows GetFamilyRelations; zero values match everything
+type RelationFilter struct {
+	PoliticianID int // either side of the relation
+	Status       string
+}
+
+// relationSelect reads politician_relations with both names. Rows are stored with
+// politician_id < relative_id; OrientRelation flips them to a given politician's side.
+const relationSelect = `
+		SELECT
+			r.id, r.politician_id,
+			COALESCE(p.nome_civil, p.nome_eleitoral, 'Unknown'),
+			r.relative_id,
+			COALESCE(q.nome_civil, q.nome_eleitoral, 'Unknown'),
+			r.relationship, r.status, r.source,
+			CAST(r.score AS DOUBLE PRECISION),
+			COALESCE(r.evidence, ''), COALESCE(r.note, ''), COALESCE(r.reviewed_by, ''),
+			r.reviewed_at, r.created_at
+		FROM politician_relations r
+		JOIN unified_politicians p ON p.id = r.politician_id
+		JOIN unified_politicians q ON q.id = r.relative_id
+`
+
+func scanRelation(row interface{ Scan(...interface{}) error }) (models.FamilyRelation, error) {
+	var r models.FamilyRelation
+	var score sql.NullFloat64
+	var reviewedAt sql.NullTime
+	err := row.Scan(
+		&r.ID, &r.PoliticianID, &r.Nome, &r.RelativeID, &r.RelativeNome,
+		&r.Relationship, &r.Status, &r.Source, &score,
+		&r.Evidence, &r.Note, &r.ReviewedBy, &reviewedAt, &r.CreatedAt,
+	)
+	if score.Valid {
+		r.Score = &score.Float64
+	}
+	if reviewedAt.Valid {
+		r.ReviewedAt = &reviewedAt.Time
+	}
+	return r, err
+}
+
+// OrientRelation returns r as seen from politicianID: that politician first, and the
+// relationship saying what the other one is to them
+func OrientRelation(r models.FamilyRelation, politicianID int) models.FamilyRelation {
+	if r.PoliticianID == politicianID {
+		return r
+	}
+	r.PoliticianID, r.RelativeID = r.RelativeID, r.PoliticianID
+	r.Nome, r.RelativeNome = r.RelativeNome, r.Nome
+	r.Relationship = models.Relationships[r.Relationship]
+	return r
+}
+
+// GetFamilyRelations retrieves relations, best-scored first; with a PoliticianID they are
+// oriented to that politician
+func GetFamilyRelations(filter RelationFilter, limit, offset int) ([]models.FamilyRelation, error) {
+	rows, err := DB.Query(relationSelect+`
+		WHERE ($1 = 0 OR r.politician_id = $1 OR r.relative_id = $1)
+		  AND ($2 = '' OR r.status = $2)
+		ORDER BY r.score DESC NULLS LAST, r.id
+		LIMIT $3 OFFSET $4
+	`, filter.PoliticianID, filter.Status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query family relations: %w", err)
+	}
+	defer rows.Close()
+
+	var relations []models.FamilyRelation
+	for rows.Next() {
+		r, err := scanRelation(rows)
+		if err != nil {
+			return nil, err
+		}
+		if filter.PoliticianID != 0 {
+			r = OrientRelation(r, filter.PoliticianID)
+		}
+		relations = append(relations, r)
+	}
+	return relations, rows.Err()
+}
+
+// GetPoliticianRelatives retrieves a politician's confirmed relatives
+func GetPoliticianRelatives(politicianID, limit int) ([]models.FamilyRelation, error) {
+	return GetFamilyRelations(RelationFilter{PoliticianID: politicianID, Status: models.RelationConfirmed}, limit, 0)
+}
+
+// GetPoliticianRelatives implements PoliticianRepo. Relations are reviewed through the API, not
+// published with dataset versions, so every reader returns the live ones.
+func (rd Reader) GetPoliticianRelatives(politicianID, limit int) ([]models.FamilyRelation, error) {
+	if loaded, err := rd.hasTable("politician_relations"); err != nil || !loaded {
+		return nil, err
+	}
+	return GetPoliticianRelatives(politicianID, limit)
+}
+
+// GetFamilyRelation retrieves one relation; sql.ErrNoRows is returned when it doesn't exist
+func GetFamilyRelation(id int64) (models.FamilyRelation, error) {
+	return scanRelation(DB.QueryRow(relationSelect+" WHERE r.id = $1", id))
+}
+
+// SaveFamilyRelation records a relation entered by a researcher as confirmed, replacing any
+// suggestion or earlier review of the same pair. r is normalized to the stored orientation.
+func SaveFamilyRelation(r *models.FamilyRelation) error {
+	if r.PoliticianID > r.RelativeID {
+		*r = OrientRelation(*r, r.RelativeID)
+	}
+
+	return DB.QueryRow(`
+		INSERT INTO politician_relations (
+			politician_id, relative_id, relationship, status, source, note, reviewed_by, reviewed_at
+		)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, CURRENT_TIMESTAMP)
+		ON CONFLICT (politician_id, relative_id) DO UPDATE SET
+			relationship = EXCLUDED.relationship,
+			status = EXCLUDED.status,
+			note = EXCLUDED.note,
+			reviewed_by = EXCLUDED.reviewed_by,
+			reviewed_at = EXCLUDED.reviewed_at
+		RETURNING id, created_at
+	`, r.PoliticianID, r.RelativeID, r.Relationship, r.Status, r.Source, r.Note, r.ReviewedBy,
+	).Scan(&r.ID, &r.CreatedAt)
+}
+
+// ReviewFamilyRelation sets a relation's status, relationship and note; the relationship is
+// given as stored (what relative_id is to politician_id)
+func ReviewFamilyRelation(id int64, status, relationship, note, reviewer string) error {
+	result, err := DB.Exec(`
+		UPDATE politician_relations
+		SET status = $2, relationship = $3, note = NULLIF($4, ''),
+		    reviewed_by = $5, reviewed_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`, id, status, relationship, note, reviewer)
+	if err != nil {
+		return fmt.Errorf("failed to review family relation: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// getFamilyConnections creates family connections between confirmed relatives, carrying what
+// the target is to the source. Suggestions stay out of the graph until a researcher confirms them.
+func getFamilyConnections() ([]models.Connection, error) {
+	rows, err := DB.Query(`
+		SELECT politician_id, relative_id, relationship
+		FROM politician_relations
+		WHERE status = $1
+	`, models.RelationConfirmed)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var connections []models.Connection
+	for rows.Next() {
+		var politicianID, relativeID int
+		var relationship string
+
+		if err := rows.Scan(&politicianID, &relativeID, &relationship); err != nil {
+			return nil, err
+		}
+
+		connections = append(connections, models.Connection{
+			SourceID: fmt.Sprintf("politician_%d", politicianID),
+			TargetID: fmt.Sprintf("politician_%d", relativeID),
+			Type:     "family",
+			Strength: 1.0,
+			Data:     map[string]string{"relationship": relationship},
+		})
+	}
+
+	return connections, rows.Err()
+}
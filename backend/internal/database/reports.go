@@ -0,0 +1,205 @@
+package database
+
+import (
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// spendingTypes are the financial records that are a politician's own spending: CEAP expenses
+// and campaign expenses paid, as in the money flows and spending maps
+const spendingTypes = `('PARLIAMENTARY_EXPENSE', 'CAMPAIGN_EXPENSE_PAID')`
+
+// GetPoliticianSpending sums a politician's spending, per year and for the topVendors vendors
+// paid the most
+func GetPoliticianSpending(politicianID, topVendors int) (models.ReportSpending, error) {
+	spending := models.ReportSpending{ByYear: []models.YearAmount{}, TopVendors: []models.ReportVendor{}}
+
+	err := DB.QueryRow(`
+		SELECT COUNT(*), COALESCE(SUM(amount), 0), COUNT(DISTINCT counterpart_cnpj_cpf),
+		       COALESCE(MIN(transaction_date)::text, ''), COALESCE(MAX(transaction_date)::text, '')
+		FROM unified_financial_records
+		WHERE politician_id = $1 AND transaction_type IN `+spendingTypes+`
+	`, politicianID).Scan(&spending.Records, &spending.Total, &spending.Vendors, &spending.FirstDate, &spending.LastDate)
+	if err != nil {
+		return spending, fmt.Errorf("failed to sum spending: %w", err)
+	}
+
+	rows, err := DB.Query(`
+		SELECT EXTRACT(YEAR FROM transaction_date)::int, SUM(amount), COUNT(*)
+		FROM unified_financial_records
+		WHERE politician_id = $1 AND transaction_type IN `+spendingTypes+`
+		  AND transaction_date IS NOT NULL
+		GROUP BY 1
+		ORDER BY 1
+	`, politicianID)
+	if err != nil {
+		return spending, fmt.Errorf("failed to sum spending per year: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var y models.YearAmount
+		if err := rows.Scan(&y.Year, &y.Amount, &y.Records); err != nil {
+			return spending, err
+		}
+		spending.ByYear = append(spending.ByYear, y)
+	}
+	if err := rows.Err(); err != nil {
+		return spending, err
+	}
+
+	vendors, err := DB.Query(`
+		SELECT counterpart_cnpj_cpf, COALESCE(MAX(counterpart_name), counterpart_cnpj_cpf), SUM(amount), COUNT(*)
+		FROM unified_financial_records
+		WHERE politician_id = $1 AND transaction_type IN `+spendingTypes+`
+		  AND counterpart_cnpj_cpf IS NOT NULL AND counterpart_cnpj_cpf != ''
+		GROUP BY counterpart_cnpj_cpf
+		ORDER BY SUM(amount) DESC, counterpart_cnpj_cpf
+		LIMIT $2
+	`, politicianID, topVendors)
+	if err != nil {
+		return spending, fmt.Errorf("failed to query top vendors: %w", err)
+	}
+	defer vendors.Close()
+	for vendors.Next() {
+		var v models.ReportVendor
+		if err := vendors.Scan(&v.CNPJ, &v.Name, &v.Total, &v.Payments); err != nil {
+			return spending, err
+		}
+		if spending.Total > 0 {
+			v.Share = float64(v.Total*10000/spending.Total) / 100
+		}
+		spending.TopVendors = append(spending.TopVendors, v)
+	}
+	return spending, vendors.Err()
+}
+
+// GetSanctionedVendorPayments groups a politician's payments to companies made while one of
+// their sanctions was in force, the largest first
+func GetSanctionedVendorPayments(politicianID int) ([]models.SanctionedPayment, error) {
+	rows, err := DB.Query(`
+		SELECT
+			vs.cnpj_cpf,
+			COALESCE(MAX(fr.counterpart_name), vs.entity_name, vs.cnpj_cpf),
+			vs.id,
+			COALESCE(vs.sanction_type, ''),
+			COALESCE(vs.data_source, ''),
+			COALESCE(vs.sanction_start_date::text, ''),
+			COALESCE(vs.sanction_end_date::text, ''),
+			COUNT(fr.id),
+			SUM(fr.amount),
+			MIN(fr.transaction_date)::text,
+			MAX(fr.transaction_date)::text
+		FROM unified_financial_records fr
+		JOIN vendor_sanctions vs ON vs.cnpj_cpf = fr.counterpart_cnpj_cpf
+		WHERE fr.politician_id = $1
+		  AND LENGTH(vs.cnpj_cpf) = 14
+		  AND fr.transaction_date >= vs.sanction_start_date
+		  AND (vs.sanction_end_date IS NULL OR fr.transaction_date <= vs.sanction_end_date)
+		GROUP BY vs.id
+		ORDER BY SUM(fr.amount) DESC, vs.id
+	`, politicianID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sanctioned vendor payments: %w", err)
+	}
+	defer rows.Close()
+
+	payments := []models.SanctionedPayment{}
+	for rows.Next() {
+		var p models.SanctionedPayment
+		var source string
+		err := rows.Scan(&p.CNPJ, &p.Company, &p.SanctionID, &p.SanctionType, &source, &p.SanctionFrom,
+			&p.SanctionTo, &p.Payments, &p.Total, &p.FirstPayment, &p.LastPayment)
+		if err != nil {
+			return nil, err
+		}
+		p.Registry = sanctionList(source)
+		payments = append(payments, p)
+	}
+	return payments, rows.Err()
+}
+
+// ScoreSignals are the counts behind a politician's corruption risk score, read as cli4
+// post-process --enhanced reads them
+type ScoreSignals struct {
+	TCUDisqualifications int
+	TCUActive            int // without an end date or ending in the future
+	SanctionedVendors    int // paid vendors with an active sanction
+	SanctionedAmount     models.Money
+	GeoMismatchVendors   int
+	GeoMismatchAmount    models.Money
+}
+
+// GetScoreSignals reads the signals of a politician's risk score. Geographic mismatches count
+// once detect-anomalies has run.
+func GetScoreSignals(politicianID int) (ScoreSignals, error) {
+	var s ScoreSignals
+	err := DB.QueryRow(`
+		SELECT
+			(SELECT COUNT(*) FROM tcu_disqualifications t
+			 WHERE t.cpf = p.cpf),
+			(SELECT COUNT(*) FROM tcu_disqualifications t
+			 WHERE t.cpf = p.cpf AND (t.data_final IS NULL OR t.data_final >= CURRENT_DATE)),
+			(SELECT COUNT(DISTINCT vs.cnpj_cpf) FROM unified_financial_records fr
+			 JOIN vendor_sanctions vs ON fr.counterpart_cnpj_cpf = vs.cnpj_cpf
+			 WHERE fr.politician_id = p.id AND vs.is_active = TRUE),
+			(SELECT COALESCE(SUM(fr.amount), 0) FROM unified_financial_records fr
+			 WHERE fr.politician_id = p.id AND fr.counterpart_cnpj_cpf IN (
+				SELECT cnpj_cpf FROM vendor_sanctions WHERE is_active = TRUE
+			 ))
+		FROM unified_politicians p
+		WHERE p.id = $1
+	`, politicianID).Scan(&s.TCUDisqualifications, &s.TCUActive, &s.SanctionedVendors, &s.SanctionedAmount)
+	if err != nil {
+		return s, fmt.Errorf("failed to read score signals: %w", err)
+	}
+
+	if detected, err := tableExists("expense_anomalies"); err != nil || !detected {
+		return s, err
+	}
+	err = DB.QueryRow(`
+		SELECT COUNT(DISTINCT cnpj_cpf), COALESCE(SUM(total_amount), 0)
+		FROM expense_anomalies
+		WHERE politician_id = $1 AND anomaly_type = 'geo_mismatch'
+	`, politicianID).Scan(&s.GeoMismatchVendors, &s.GeoMismatchAmount)
+	if err != nil {
+		return s, fmt.Errorf("failed to read geographic mismatches: %w", err)
+	}
+	return s, nil
+}
+
+// GetConnectionSummary counts a politician's links: vendors paid, party memberships, fronts,
+// confirmed relatives, TCU rulings naming them and sanctions against their CPF
+func GetConnectionSummary(politicianID int) (models.ReportConnectionSummary, error) {
+	var s models.ReportConnectionSummary
+
+	// TCU rulings count once populate-tcu-rulings has run
+	rulings := "0"
+	if loaded, err := tableExists("tcu_ruling_parties"); err != nil {
+		return s, err
+	} else if loaded {
+		rulings = "(SELECT COUNT(DISTINCT ruling_id) FROM tcu_ruling_parties WHERE politician_id = p.id)"
+	}
+
+	err := DB.QueryRow(`
+		SELECT
+			(SELECT COUNT(DISTINCT counterpart_cnpj_cpf) FROM unified_financial_records
+			 WHERE politician_id = p.id),
+			(SELECT COUNT(DISTINCT fr.counterpart_cnpj_cpf) FROM unified_financial_records fr
+			 JOIN vendor_sanctions vs ON vs.cnpj_cpf = fr.counterpart_cnpj_cpf
+			 WHERE fr.politician_id = p.id),
+			(SELECT COUNT(*) FROM party_memberships pm WHERE pm.deputy_id = p.deputy_id),
+			(SELECT COUNT(DISTINCT network_id) FROM unified_political_networks
+			 WHERE network_type = 'PARLIAMENTARY_FRONT' AND politician_id = p.id),
+			(SELECT COUNT(*) FROM politician_relations
+			 WHERE status = $2 AND (politician_id = p.id OR relative_id = p.id)),
+			`+rulings+`,
+			(SELECT COUNT(*) FROM vendor_sanctions WHERE cnpj_cpf = p.cpf)
+		FROM unified_politicians p
+		WHERE p.id = $1
+	`, politicianID, models.RelationConfirmed).Scan(&s.Vendors, &s.SanctionedVendors, &s.Memberships,
+		&s.Fronts, &s.Relatives, &s.TCURulings, &s.Sanctions)
+	if err != nil {
+		return s, fmt.Errorf("failed to count connections: %w", err)
+	}
+	return s, nil
+}
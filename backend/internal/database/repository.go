@@ -0,0 +1,137 @@
+package database
+
+import "political-network-api/internal/models"
+
+// PoliticianRepo reads politicians and what hangs off them
+type PoliticianRepo interface {
+	GetPoliticians(limit, offset, minScore int, filter PoliticianFilter) ([]models.Politician, error)
+	GetPolitician(id int) (models.Politician, error)
+	GetPoliticianKeys() ([]models.PoliticianKey, error)
+	GetPoliticianSanctions(politicianID, limit int) ([]models.Sanction, error)
+	GetPoliticianMemberships(politicianID, limit int) ([]models.PartyMembership, error)
+	GetPoliticianFronts(politicianID, limit int) ([]models.Front, error)
+	GetFrontPeers(politicianID, limit int) ([]models.FrontPeer, error)
+	GetPoliticianWikidata(politicianID, officeLimit int) (*models.WikidataLink, error)
+	GetPoliticianRelatives(politicianID, limit int) ([]models.FamilyRelation, error)
+}
+
+// PartyRepo reads parties, their members and fund receipts
+type PartyRepo interface {
+	GetParties(limit, offset int, after *ListCursor) ([]models.Party, error)
+	GetParty(id int) (models.Party, error)
+	GetPartySummary(party models.Party) (models.PartySummary, error)
+	GetPartyMembers(partyID int, current bool, limit int) ([]models.PartyMember, error)
+	GetPartyFunds(sigla string, limit int) ([]models.PartyFund, error)
+}
+
+// CompanyRepo reads companies and their payers, owners, sanctions and public loans
+type CompanyRepo interface {
+	GetCompanies(limit, offset int, filter CompanyFilter) ([]models.Company, error)
+	GetCompany(cnpj string) (models.Company, error)
+	GetCompanyPayers(cnpj string) ([]models.CompanyPayer, error)
+	GetCompanySanctions(cnpj string) ([]models.Sanction, error)
+	GetCompanyOwners(cnpj string) ([]models.CompanyOwner, error)
+	GetCompanyPublicLoans(cnpj string, limit int) ([]models.PublicLoan, error)
+	GetCompanyLoanTotals(cnpj string) (models.PublicLoanTotals, error)
+}
+
+// SanctionRepo reads sanctions
+type SanctionRepo interface {
+	GetSanctions(limit, offset int, after *ListCursor) ([]models.Sanction, error)
+	GetSanction(id int) (models.SanctionDetail, error)
+}
+
+// FinancialRepo reads expenses and donations, and ranks who expenses were paid to and by
+type FinancialRepo interface {
+	GetFinancialRecords(politicianID, limit, offset int, after *ListCursor) ([]models.FinancialRecord, error)
+	GetTopVendors(politicianID, limit int) ([]models.TopVendor, error)
+	GetTopPoliticians(cnpj string, limit int) ([]models.TopPolitician, error)
+}
+
+// TCURepo reads TCU rulings
+type TCURepo interface {
+	GetTCURulings(filter TCURulingFilter, limit, offset int) ([]models.TCURuling, error)
+	GetTCURuling(id int) (models.TCURuling, error)
+	GetTCURulingParties(rulingID int) ([]models.TCURulingParty, error)
+}
+
+// ProvenanceRepo reads where a resource's data came from
+type ProvenanceRepo interface {
+	GetProvenance(resource, id string) (models.Provenance, error)
+}
+
+// Repository is every read behind the versioned endpoints, against the live data or one
+// dataset version. Reader is the Postgres implementation; handlers are given one, so tests and
+// alternate backends can supply their own.
+type Repository interface {
+	PoliticianRepo
+	PartyRepo
+	CompanyRepo
+	SanctionRepo
+	FinancialRepo
+	TCURepo
+	ProvenanceRepo
+
+	// DatasetVersion is the pinned dataset version, 0 for the live data
+	DatasetVersion() int
+}
+
+// ConnectionRepo builds the network connections and statistics, and reads the nodes of the
+// network that have no endpoint of their own behind Repository, always from the live data
+type ConnectionRepo interface {
+	GetConnections() ([]models.Connection, error)
+	GetAllConnections() ([]models.Connection, error)
+	GetNetworkStats(exact bool) (models.NetworkStats, error)
+	GetCompanyGroups(limit, offset int) ([]models.CompanyGroup, error)
+	GetTopics(limit, offset int) ([]models.Topic, error)
+	GetFronts(legislaturaID, limit, offset int) ([]models.Front, error)
+	GetPublicBanks() ([]models.PublicBank, error)
+}
+
+var (
+	_ Repository     = Reader{}
+	_ ConnectionRepo = Network{}
+)
+
+// DatasetVersion implements Repository
+func (rd Reader) DatasetVersion() int {
+	return rd.Version
+}
+
+// Network is the Postgres ConnectionRepo
+type Network struct{}
+
+// GetConnections implements ConnectionRepo
+func (Network) GetConnections() ([]models.Connection, error) {
+	return GetConnections()
+}
+
+// GetAllConnections implements ConnectionRepo
+func (Network) GetAllConnections() ([]models.Connection, error) {
+	return GetAllConnections()
+}
+
+// GetNetworkStats implements ConnectionRepo
+func (Network) GetNetworkStats(exact bool) (models.NetworkStats, error) {
+	return GetNetworkStats(exact)
+}
+
+// GetCompanyGroups implements ConnectionRepo
+func (Network) GetCompanyGroups(limit, offset int) ([]models.CompanyGroup, error) {
+	return GetCompanyGroups(limit, offset)
+}
+
+// GetTopics implements ConnectionRepo
+func (Network) GetTopics(limit, offset int) ([]models.Topic, error) {
+	return GetTopics(limit, offset)
+}
+
+// GetFronts implements ConnectionRepo
+func (Network) GetFronts(legislaturaID, limit, offset int) ([]models.Front, error) {
+	return GetFronts(legislaturaID, limit, offset)
+}
+
+// GetPublicBanks implements ConnectionRepo
+func (Network) GetPublicBanks() ([]models.PublicBank, error) {
+	return GetPublicBanks()
+}
@@ -0,0 +1,280 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"political-network-api/internal/config"
+	"political-network-api/internal/models"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// maxRetryBackoff caps the wait between two connection attempts
+const maxRetryBackoff = 5 * time.Second
+
+// ErrUnavailable is returned without trying the database while the circuit breaker is open
+var ErrUnavailable = errors.New("database unavailable (circuit breaker open)")
+
+// Breaker states
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// breaker is the circuit breaker in front of new database connections, set by Initialize
+var breaker = &circuitBreaker{}
+
+// Unavailable reports whether err means the database could not be reached, as opposed to a
+// query that failed on a healthy connection. Reads failing this way can be served from stale
+// cached data.
+func Unavailable(err error) bool {
+	return errors.Is(err, ErrUnavailable) || retryable(err)
+}
+
+// Breaker reports the state of the database circuit breaker
+func Breaker() models.BreakerState {
+	return breaker.state()
+}
+
+// retryable reports whether err is a connection failure worth retrying on a new connection:
+// network errors, connections closed under us and the Postgres connection_exception (08),
+// admin/crash shutdown, cannot_connect_now and too_many_connections errors
+func retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "57P01", "57P02", "57P03", "53300":
+			return true
+		}
+		return pqErr.Code.Class() == "08"
+	}
+	return false
+}
+
+// resilientConnector opens pq connections, retrying retryable failures with exponential backoff
+// and jitter, behind a circuit breaker that fails fast once the database looks down
+type resilientConnector struct {
+	pq       driver.Connector
+	attempts int
+	backoff  time.Duration
+}
+
+func newResilientConnector(connStr string, cfg config.DatabaseConfig) (*resilientConnector, error) {
+	connector, err := pq.NewConnector(connStr)
+	if err != nil {
+		return nil, err
+	}
+	breaker = &circuitBreaker{threshold: cfg.BreakerThreshold, cooldown: cfg.BreakerCooldown}
+	return &resilientConnector{pq: connector, attempts: cfg.RetryAttempts, backoff: cfg.RetryBackoff}, nil
+}
+
+func (rc *resilientConnector) Driver() driver.Driver {
+	return rc.pq.Driver()
+}
+
+// Connect opens a connection, waiting backoff, 2×backoff, 4×backoff... (±50%) between attempts
+func (rc *resilientConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if !breaker.allow() {
+		return nil, ErrUnavailable
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		var conn driver.Conn
+		conn, err = rc.pq.Connect(ctx)
+		if err == nil {
+			breaker.success()
+			return &resilientConn{conn: conn}, nil
+		}
+		if !retryable(err) || attempt >= rc.attempts {
+			break
+		}
+
+		delay := min(rc.backoff<<(attempt-1), maxRetryBackoff)
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay)))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			breaker.failure(err)
+			return nil, ctx.Err()
+		}
+	}
+
+	if retryable(err) {
+		breaker.failure(err)
+	} else {
+		// The server answered (bad password, unknown database...): it is up
+		breaker.success()
+	}
+	return nil, err
+}
+
+// resilientConn hands database/sql a driver.ErrBadConn when a SELECT outside a transaction
+// loses its connection, so the query is run again on another one. Writes and transactions are
+// never replayed: the server may have applied them before the connection dropped. Every query
+// and statement is timed for QueryStats and the slow query log (see querylog.go).
+type resilientConn struct {
+	conn driver.Conn
+	inTx bool
+}
+
+func (rc *resilientConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := timeQuery(query, args, func() (driver.Rows, error) {
+		return rc.conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+	})
+	if err != nil && !rc.inTx && retryable(err) && isSelect(query) {
+		return nil, driver.ErrBadConn
+	}
+	return rows, err
+}
+
+func (rc *resilientConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return timeExec(query, args, func() (driver.Result, error) {
+		return rc.conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+	})
+}
+
+func (rc *resilientConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	tx, err := rc.conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	rc.inTx = true
+	return &resilientTx{tx: tx, conn: rc}, nil
+}
+
+func (rc *resilientConn) Begin() (driver.Tx, error) {
+	return rc.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (rc *resilientConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return rc.conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+}
+
+func (rc *resilientConn) Prepare(query string) (driver.Stmt, error) {
+	return rc.conn.Prepare(query)
+}
+
+func (rc *resilientConn) Ping(ctx context.Context) error {
+	return rc.conn.(driver.Pinger).Ping(ctx)
+}
+
+func (rc *resilientConn) ResetSession(ctx context.Context) error {
+	return rc.conn.(driver.SessionResetter).ResetSession(ctx)
+}
+
+func (rc *resilientConn) IsValid() bool {
+	return rc.conn.(driver.Validator).IsValid()
+}
+
+func (rc *resilientConn) Close() error {
+	return rc.conn.Close()
+}
+
+// resilientTx clears its connection's transaction flag when it ends
+type resilientTx struct {
+	tx   driver.Tx
+	conn *resilientConn
+}
+
+func (t *resilientTx) Commit() error {
+	t.conn.inTx = false
+	return t.tx.Commit()
+}
+
+func (t *resilientTx) Rollback() error {
+	t.conn.inTx = false
+	return t.tx.Rollback()
+}
+
+// isSelect reports whether query is a plain SELECT, which is safe to run twice
+func isSelect(query string) bool {
+	query = strings.TrimSpace(query)
+	return len(query) >= 6 && strings.EqualFold(query[:6], "SELECT")
+}
+
+// circuitBreaker opens after threshold consecutive failed connects (0 never opens it). While
+// open, connects fail at once with ErrUnavailable; after cooldown one connect is let through as
+// a probe, closing the breaker when it succeeds and opening it again when it fails.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openedAt.IsZero() {
+		return true
+	}
+	if b.probing || time.Since(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.openedAt.IsZero() {
+		log.Printf("✅ Database reachable again, circuit breaker closed after %s", time.Since(b.openedAt).Round(time.Second))
+	}
+	b.failures, b.openedAt, b.probing = 0, time.Time{}, false
+}
+
+func (b *circuitBreaker) failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.probing {
+		b.openedAt, b.probing = time.Now(), false
+		return
+	}
+	if b.threshold > 0 && b.openedAt.IsZero() && b.failures >= b.threshold {
+		b.openedAt = time.Now()
+		log.Printf("🔌 Database circuit breaker open after %d failed connects, last: %v", b.failures, err)
+	}
+}
+
+func (b *circuitBreaker) state() models.BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := models.BreakerState{State: BreakerClosed, Failures: b.failures}
+	if !b.openedAt.IsZero() {
+		openedAt := b.openedAt
+		s.State, s.OpenedAt = BreakerOpen, &openedAt
+		if b.probing || time.Since(b.openedAt) >= b.cooldown {
+			s.State = BreakerHalfOpen
+		}
+	}
+	return s
+}
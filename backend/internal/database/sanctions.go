@@ -0,0 +1,185 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// GetSanction retrieves the full record of one sanction, expired or not; sql.ErrNoRows is
+// returned when it does not exist
+func (rd Reader) GetSanction(id int) (models.SanctionDetail, error) {
+	// legal_basis was added after vendor_sanctions; databases loaded before it lack the column
+	hasLegalBasis, err := rd.columnExists("vendor_sanctions", "legal_basis")
+	if err != nil {
+		return models.SanctionDetail{}, err
+	}
+	legalBasis := "''"
+	if hasLegalBasis {
+		legalBasis = "COALESCE(legal_basis, '')"
+	}
+
+	var s models.SanctionDetail
+	var verifiedAt, updatedAt sql.NullTime
+	err = rd.q.QueryRow(`
+		SELECT
+			id,
+			COALESCE(sanction_type, ''),
+			COALESCE(cnpj_cpf, ''),
+			COALESCE(penalty_amount, 0),
+			COALESCE(CAST(sanction_start_date AS TEXT), ''),
+			COALESCE(CAST(sanction_end_date AS TEXT), ''),
+			COALESCE(is_active, false),
+			created_at,
+			COALESCE(entity_name, ''),
+			COALESCE(sanction_description, ''),
+			`+legalBasis+`,
+			COALESCE(sanctioning_agency, ''),
+			COALESCE(sanctioning_state, ''),
+			COALESCE(sanctioning_process, ''),
+			COALESCE(data_source, ''),
+			COALESCE(api_reference_id, ''),
+			verification_date,
+			updated_at
+		FROM vendor_sanctions
+		WHERE id = $1
+	`, id).Scan(
+		&s.ID, &s.TipoSancao, &s.CNPJ, &s.ValorMulta, &s.DataInicioSancao, &s.DataFimSancao,
+		&s.Ativa, &s.CreatedAt, &s.NomeSancionado, &s.Descricao, &s.FundamentacaoLegal,
+		&s.OrgaoSancionador, &s.UFOrgaoSancionador, &s.NumeroProcesso, &s.DataSource,
+		&s.PortalID, &verifiedAt, &updatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return s, err
+	}
+	if err != nil {
+		return s, fmt.Errorf("failed to query sanction: %w", err)
+	}
+
+	s.Cadastro = sanctionList(s.DataSource)
+	s.VerificadoEm = verifiedAt.Time
+	s.UpdatedAt = updatedAt.Time
+	return s, nil
+}
+
+// GetSanctionSourceStats aggregates sanctions by the registry they came from, largest first
+func GetSanctionSourceStats() ([]models.SanctionSourceStats, error) {
+	rows, err := DB.Query(`
+		SELECT
+			COALESCE(vs.data_source, '') as data_source,
+			COUNT(*) as total,
+			COUNT(*) FILTER (WHERE vs.is_active) as active,
+			COUNT(DISTINCT vs.cnpj_cpf) as entities,
+			COUNT(DISTINCT vs.cnpj_cpf) FILTER (WHERE EXISTS (
+				SELECT 1 FROM unified_financial_records fr WHERE fr.counterpart_cnpj_cpf = vs.cnpj_cpf
+			)) as linked_entities,
+			COALESCE(SUM(vs.penalty_amount), 0) as total_penalty,
+			COALESCE(MIN(vs.sanction_start_date)::text, '') as first_start,
+			COALESCE(MAX(vs.sanction_start_date)::text, '') as last_start
+		FROM vendor_sanctions vs
+		GROUP BY 1
+		ORDER BY total DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sanction source stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.SanctionSourceStats
+	for rows.Next() {
+		var s models.SanctionSourceStats
+		err := rows.Scan(
+			&s.DataSource, &s.Total, &s.Active, &s.Entities, &s.LinkedEntities,
+			&s.TotalPenalty, &s.FirstStart, &s.LastStart,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		s.Cadastro = sanctionList(s.DataSource)
+		s.Expired = s.Total - s.Active
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+// RecalculateSanctionStatus sets is_active from the sanction dates, the rule the CEIS/CNEP
+// populators apply at import time: active from the start date through the end date, with no
+// end date meaning indefinite. Each flipped sanction is recorded in sanction_status_changes.
+func RecalculateSanctionStatus() (expired, reactivated int, err error) {
+	rows, err := DB.Query(`
+		WITH recalculated AS (
+			SELECT
+				id,
+				is_active as was_active,
+				COALESCE(sanction_start_date <= CURRENT_DATE
+					AND (sanction_end_date IS NULL OR sanction_end_date >= CURRENT_DATE), false) as active
+			FROM vendor_sanctions
+		),
+		changed AS (
+			UPDATE vendor_sanctions vs
+			SET is_active = r.active, updated_at = CURRENT_TIMESTAMP
+			FROM recalculated r
+			WHERE vs.id = r.id
+			  AND vs.is_active IS DISTINCT FROM r.active
+			RETURNING vs.id, vs.cnpj_cpf, r.was_active, vs.is_active, vs.sanction_start_date, vs.sanction_end_date
+		)
+		INSERT INTO sanction_status_changes (
+			sanction_id, cnpj_cpf, was_active, is_active, sanction_start_date, sanction_end_date
+		)
+		SELECT id, cnpj_cpf, was_active, is_active, sanction_start_date, sanction_end_date
+		FROM changed
+		RETURNING is_active
+	`)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to recalculate sanction status: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var active bool
+		if err := rows.Scan(&active); err != nil {
+			return expired, reactivated, err
+		}
+		if active {
+			reactivated++
+		} else {
+			expired++
+		}
+	}
+
+	return expired, reactivated, rows.Err()
+}
+
+// sanctionList maps a data_source to its registry name, passing unknown sources through
+func sanctionList(dataSource string) string {
+	if list, ok := models.SanctionLists[dataSource]; ok {
+		return list
+	}
+	return dataSource
+}
+
+// columnExists reports whether a table has a column added by a later cli4 release
+func (rd Reader) columnExists(table, column string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = $1 AND column_name = $2
+		)
+	`
+	if sqlite {
+		query = `SELECT EXISTS (SELECT 1 FROM pragma_table_info($1) WHERE name = $2)`
+	}
+	var exists bool
+	err := rd.q.QueryRow(query, table, column).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s.%s: %w", table, column, err)
+	}
+	return exists, nil
+}
+
+// columnExists reports whether a live ETL table has a column
+func columnExists(table, column string) (bool, error) {
+	return Live().columnExists(table, column)
+}
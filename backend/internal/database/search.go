@@ -0,0 +1,175 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// searchSources select each indexed entity as id, name, aliases and detail parts, limited to
+// rows updated after $1 when it is not NULL
+var searchSources = []struct {
+	docType string
+	query   string
+}{
+	{models.SearchPolitician, `
+		SELECT id::text,
+		       COALESCE(NULLIF(nome_eleitoral, ''), nome_civil),
+		       ARRAY[nome_civil],
+		       ARRAY[COALESCE(current_party, ''), COALESCE(current_state, '')]
+		FROM unified_politicians
+		WHERE $1::timestamptz IS NULL OR updated_at > $1
+	`},
+	{models.SearchParty, `
+		SELECT id::text, nome, ARRAY[sigla], ARRAY[sigla]
+		FROM political_parties
+		WHERE $1::timestamptz IS NULL OR updated_at > $1
+	`},
+	{models.SearchCompany, `
+		SELECT cnpj_cpf,
+		       name,
+		       ARRAY[COALESCE(trade_name, ''), cnpj_cpf],
+		       ARRAY[COALESCE(municipality, ''), COALESCE(state, '')]
+		FROM financial_counterparts
+		WHERE entity_type = 'COMPANY'
+		  AND ($1::timestamptz IS NULL OR updated_at > $1)
+	`},
+}
+
+// EachSearchDocument streams the politicians, parties and companies updated after since to
+// fn in batches of up to size documents, for the search index. A zero since streams them
+// all. Batches are reused, so fn must not keep them. It returns how many documents were
+// streamed.
+func EachSearchDocument(since time.Time, size int, fn func([]models.SearchDocument) error) (int, error) {
+	var after sql.NullTime
+	if !since.IsZero() {
+		after = sql.NullTime{Time: since, Valid: true}
+	}
+
+	total := 0
+	for _, source := range searchSources {
+		n, err := eachSearchSource(source.docType, source.query, after, size, fn)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func eachSearchSource(docType, query string, after sql.NullTime, size int, fn func([]models.SearchDocument) error) (int, error) {
+	rows, err := DB.Query(query, after)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query %s search documents: %w", docType, err)
+	}
+	defer rows.Close()
+
+	total := 0
+	batch := make([]models.SearchDocument, 0, size)
+	for rows.Next() {
+		var (
+			doc              models.SearchDocument
+			aliases, details []string
+		)
+		if err := rows.Scan(&doc.ID, &doc.Name, pq.Array(&aliases), pq.Array(&details)); err != nil {
+			return total, fmt.Errorf("failed to scan %s search document: %w", docType, err)
+		}
+		doc.Type = docType
+		doc.Aliases = nonEmpty(aliases, doc.Name)
+		doc.Detail = strings.Join(nonEmpty(details, ""), " - ")
+
+		batch = append(batch, doc)
+		if len(batch) == size {
+			if err := fn(batch); err != nil {
+				return total, err
+			}
+			total += len(batch)
+			batch = batch[:0]
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return total, fmt.Errorf("failed to read %s search documents: %w", docType, err)
+	}
+
+	if len(batch) > 0 {
+		if err := fn(batch); err != nil {
+			return total, err
+		}
+		total += len(batch)
+	}
+	return total, nil
+}
+
+// likeEscaper quotes LIKE wildcards so user input matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SuggestSearch returns up to limit entities of types whose name, or a word in it, starts
+// with prefix, ignoring accents and case. It backs /api/search/suggest when the search index is
+// disabled; the normalize_text() function and the pg_trgm indexes on it come from the setup
+// scripts.
+func SuggestSearch(prefix string, types []string, limit int) ([]models.SearchHit, error) {
+	query := `
+		SELECT type, id, name, detail FROM (
+			(SELECT 'politician' AS type, id::text AS id,
+			        COALESCE(NULLIF(nome_eleitoral, ''), nome_civil) AS name,
+			        CONCAT_WS(' - ', NULLIF(current_party, ''), NULLIF(current_state, '')) AS detail
+			 FROM unified_politicians
+			 WHERE 'politician' = ANY($3)
+			   AND (normalize_text(nome_eleitoral) LIKE $1 OR normalize_text(nome_eleitoral) LIKE $2
+			        OR normalize_text(nome_civil) LIKE $1 OR normalize_text(nome_civil) LIKE $2)
+			 LIMIT $4)
+			UNION ALL
+			(SELECT DISTINCT ON (id) 'party', id::text, nome, sigla
+			 FROM political_parties
+			 WHERE 'party' = ANY($3)
+			   AND (normalize_text(sigla) LIKE $1 OR normalize_text(nome) LIKE $1
+			        OR normalize_text(nome) LIKE $2)
+			 ORDER BY id, updated_at DESC
+			 LIMIT $4)
+			UNION ALL
+			(SELECT 'company', cnpj_cpf, name,
+			        CONCAT_WS(' - ', NULLIF(municipality, ''), NULLIF(state, ''))
+			 FROM financial_counterparts
+			 WHERE entity_type = 'COMPANY'
+			   AND 'company' = ANY($3)
+			   AND (normalize_text(name) LIKE $1 OR normalize_text(name) LIKE $2
+			        OR normalize_text(trade_name) LIKE $1 OR normalize_text(trade_name) LIKE $2)
+			 LIMIT $4)
+		) matches
+		ORDER BY normalize_text(name) LIKE $1 DESC, LENGTH(name), name
+		LIMIT $4
+	`
+
+	escaped := likeEscaper.Replace(utils.NormalizeText(prefix))
+	rows, err := DB.Query(query, escaped+"%", "% "+escaped+"%", pq.Array(types), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query search suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	hits := []models.SearchHit{}
+	for rows.Next() {
+		var hit models.SearchHit
+		if err := rows.Scan(&hit.Type, &hit.ID, &hit.Name, &hit.Detail); err != nil {
+			return nil, fmt.Errorf("failed to scan search suggestion: %w", err)
+		}
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
+
+// nonEmpty keeps the non-blank values other than skip, in order
+func nonEmpty(values []string, skip string) []string {
+	var kept []string
+	for _, v := range values {
+		if s := strings.TrimSpace(v); s != "" && s != skip {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,201 @@
+package database
+
+import (
+	"fmt"
+	"math"
+	"political-network-api/internal/models"
+	"sort"
+)
+
+// A year has an end-of-year spike when December spending is at least twice the average month
+// before it (unused CEAP quota expires with the year), and an election year a pre-election
+// surge when July-September average 1.5 times its first half. Ratios need a baseline of at
+// least minBaselineMonths months in which the politician was spending.
+const (
+	endOfYearSpikeRatio   = 2.0
+	preElectionSurgeRatio = 1.5
+	minBaselineMonths     = 3
+)
+
+// Seasonality flags, as accepted by ?flag=
+const (
+	SeasonalityEndOfYear   = "end_of_year"
+	SeasonalityPreElection = "pre_election"
+)
+
+// GetSpendingSeasonality computes the monthly CEAP spending patterns of every politician with
+// expenses (or only politicianID), politicians with the most flagged years first
+func GetSpendingSeasonality(politicianID int) ([]models.SpendingSeasonality, error) {
+	query := `
+		SELECT
+			fr.politician_id,
+			COALESCE(p.nome_civil, p.nome_eleitoral, 'Unknown'),
+			COALESCE(p.current_state, ''),
+			fr.year,
+			COALESCE(NULLIF(fr.month, 0), EXTRACT(MONTH FROM fr.transaction_date)::int) as month,
+			SUM(fr.amount)
+		FROM unified_financial_records fr
+		JOIN unified_politicians p ON p.id = fr.politician_id
+		WHERE fr.transaction_type = 'PARLIAMENTARY_EXPENSE'
+		  AND ($1 = 0 OR fr.politician_id = $1)
+		GROUP BY 1, 2, 3, 4, 5
+		ORDER BY 1, 4, 5
+	`
+
+	rows, err := DB.Query(query, politicianID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query monthly spending: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.SpendingSeasonality
+	var current *models.SpendingSeasonality
+	for rows.Next() {
+		var id, year, month int
+		var nome, uf string
+		var amount models.Money
+		if err := rows.Scan(&id, &nome, &uf, &year, &month, &amount); err != nil {
+			return nil, err
+		}
+		if month < 1 || month > 12 {
+			continue
+		}
+
+		if current == nil || current.PoliticianID != id {
+			result = append(result, models.SpendingSeasonality{PoliticianID: id, Nome: nome, UF: uf})
+			current = &result[len(result)-1]
+		}
+		if n := len(current.Years); n == 0 || current.Years[n-1].Year != year {
+			current.Years = append(current.Years, models.YearSpending{Year: year, Election: year%4 == 2})
+		}
+		y := &current.Years[len(current.Years)-1]
+		y.Monthly[month-1] += amount
+		y.Total += amount
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		analyzeSeasonality(&result[i])
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		fi := len(result[i].EndOfYearSpikeYears) + len(result[i].PreElectionSurgeYears)
+		fj := len(result[j].EndOfYearSpikeYears) + len(result[j].PreElectionSurgeYears)
+		if fi != fj {
+			return fi > fj
+		}
+		return ratioValue(result[i].ElectionRatio) > ratioValue(result[j].ElectionRatio)
+	})
+
+	return result, nil
+}
+
+// analyzeSeasonality fills the month profile, the election comparison and the flags of s from
+// its yearly spending. Each year only counts the months between the first and last in which
+// the politician spent, so partial years of a mandate don't read as spikes.
+func analyzeSeasonality(s *models.SpendingSeasonality) {
+	var monthSums [2][12]models.Money
+	var monthCounts [2][12]int
+	var activeSums [2]models.Money
+	var activeMonths [2]int
+
+	s.EndOfYearSpikeYears = []int{}
+	s.PreElectionSurgeYears = []int{}
+	for i := range s.Years {
+		y := &s.Years[i]
+		first, last := spendingRange(y.Monthly)
+		if first < 0 {
+			continue
+		}
+
+		group := 0
+		if y.Election {
+			group = 1
+		}
+		for m := first; m <= last; m++ {
+			monthSums[group][m] += y.Monthly[m]
+			monthCounts[group][m]++
+		}
+		activeSums[group] += y.Total
+		activeMonths[group] += last - first + 1
+
+		// December (index 11) against the active months before it
+		if last == 11 && 11-first >= minBaselineMonths {
+			y.DecemberRatio = spendingRatio(y.Monthly[11], average(y.Monthly[first:11]))
+			if ratioValue(y.DecemberRatio) >= endOfYearSpikeRatio {
+				s.EndOfYearSpikeYears = append(s.EndOfYearSpikeYears, y.Year)
+			}
+		}
+
+		// July-September (indexes 6-8), right before the October election, against the first half
+		if y.Election && last >= 8 && 6-first >= minBaselineMonths {
+			y.PreElectionRatio = spendingRatio(average(y.Monthly[6:9]), average(y.Monthly[first:6]))
+			if ratioValue(y.PreElectionRatio) >= preElectionSurgeRatio {
+				s.PreElectionSurgeYears = append(s.PreElectionSurgeYears, y.Year)
+			}
+		}
+	}
+
+	s.Months = make([]models.MonthProfile, 12)
+	for m := range s.Months {
+		s.Months[m] = models.MonthProfile{
+			Month:            m + 1,
+			OtherYearsAvg:    divide(monthSums[0][m], monthCounts[0][m]),
+			ElectionYearsAvg: divide(monthSums[1][m], monthCounts[1][m]),
+		}
+	}
+
+	s.OtherYearsMonthlyAvg = divide(activeSums[0], activeMonths[0])
+	s.ElectionYearsMonthlyAvg = divide(activeSums[1], activeMonths[1])
+	if activeMonths[0] > 0 && activeMonths[1] > 0 {
+		s.ElectionRatio = spendingRatio(s.ElectionYearsMonthlyAvg, s.OtherYearsMonthlyAvg)
+	}
+}
+
+// spendingRange returns the indexes of the first and last months with spending, -1 if none
+func spendingRange(monthly [12]models.Money) (first, last int) {
+	first, last = -1, -1
+	for m, amount := range monthly {
+		if amount == 0 {
+			continue
+		}
+		if first < 0 {
+			first = m
+		}
+		last = m
+	}
+	return first, last
+}
+
+func average(amounts []models.Money) models.Money {
+	var sum models.Money
+	for _, a := range amounts {
+		sum += a
+	}
+	return divide(sum, len(amounts))
+}
+
+func divide(sum models.Money, n int) models.Money {
+	if n == 0 {
+		return 0
+	}
+	return sum / models.Money(n)
+}
+
+// spendingRatio is a over b rounded to two decimals, nil when b isn't positive
+func spendingRatio(a, b models.Money) *float64 {
+	if b <= 0 {
+		return nil
+	}
+	r := math.Round(float64(a)/float64(b)*100) / 100
+	return &r
+}
+
+func ratioValue(r *float64) float64 {
+	if r == nil {
+		return 0
+	}
+	return *r
+}
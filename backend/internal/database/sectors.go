@@ -0,0 +1,101 @@
+package database
+
+import (
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// sectorLevels maps ?level= to the grouping expression over financial_counterparts.
+// Subclass codes are 7 digits: division (2), group (3), class (5 with check digit).
+var sectorLevels = map[string]string{
+	"section":  "fc.cnae_section",
+	"division": "LEFT(fc.cnae_code, 2)",
+	"group":    "LEFT(fc.cnae_code, 3)",
+	"class":    "LEFT(fc.cnae_code, 5)",
+	"subclass": "fc.cnae_code",
+}
+
+// ValidSectorLevel reports whether level is a supported CNAE aggregation level
+func ValidSectorLevel(level string) bool {
+	_, ok := sectorLevels[level]
+	return ok
+}
+
+// sectorCondition matches companies in a sector given by the placeholder: a section
+// letter or a CNAE code prefix; an empty value matches everything
+func sectorCondition(placeholder string) string {
+	return fmt.Sprintf("(%[1]s = '' OR fc.cnae_section = %[1]s OR fc.cnae_code LIKE %[1]s || '%%')", placeholder)
+}
+
+// GetSectorStats aggregates financial records by counterpart sector, optionally for one
+// source system (e.g. DEPUTADOS for CEAP expenses, TSE for campaign finance)
+func GetSectorStats(level, source string) ([]models.SectorStats, error) {
+	expr, ok := sectorLevels[level]
+	if !ok {
+		return nil, fmt.Errorf("unknown sector level %q", level)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			%s as sector,
+			MIN(fc.cnae_description) as description,
+			COUNT(*) as transaction_count,
+			SUM(fr.amount) as total_value,
+			COUNT(DISTINCT fr.counterpart_cnpj_cpf) as companies,
+			COUNT(DISTINCT fr.politician_id) as politicians
+		FROM unified_financial_records fr
+		JOIN financial_counterparts fc ON fc.cnpj_cpf = fr.counterpart_cnpj_cpf
+		WHERE fc.cnae_code IS NOT NULL
+		  AND ($1 = '' OR fr.source_system = $1)
+		GROUP BY 1
+		ORDER BY total_value DESC
+	`, expr)
+
+	rows, err := DB.Query(query, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sector stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.SectorStats
+	for rows.Next() {
+		var s models.SectorStats
+		var description string
+		if err := rows.Scan(&s.Sector, &description, &s.TransactionCount, &s.TotalValue, &s.Companies, &s.Politicians); err != nil {
+			return nil, err
+		}
+
+		switch level {
+		case "section":
+			s.Name = models.CNAESections[s.Sector]
+		case "subclass":
+			s.Name = description
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+// GetSectorCompanies returns the CNPJs of every company in a sector
+func GetSectorCompanies(sector string) (map[string]bool, error) {
+	rows, err := DB.Query(`
+		SELECT fc.cnpj_cpf
+		FROM financial_counterparts fc
+		WHERE fc.cnae_code IS NOT NULL
+		  AND `+sectorCondition("$1"), sector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sector companies: %w", err)
+	}
+	defer rows.Close()
+
+	cnpjs := make(map[string]bool)
+	for rows.Next() {
+		var cnpj string
+		if err := rows.Scan(&cnpj); err != nil {
+			return nil, err
+		}
+		cnpjs[cnpj] = true
+	}
+	return cnpjs, rows.Err()
+}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// shareGraph is how a snapshot's subgraph is stored
+type shareGraph struct {
+	Nodes []models.ShareNode `json:"nodes"`
+	Links []models.ShareLink `json:"links"`
+}
+
+// CreateShareSnapshot stores s and fills in its creation time. Expired snapshots are purged on
+// the way, which keeps the table small without a separate job.
+func CreateShareSnapshot(s *models.ShareSnapshot, createdBy string) error {
+	graph, err := json.Marshal(shareGraph{Nodes: s.Nodes, Links: s.Links})
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	if _, err := DB.Exec(`DELETE FROM share_snapshots WHERE expires_at < CURRENT_TIMESTAMP`); err != nil {
+		return fmt.Errorf("failed to purge expired snapshots: %w", err)
+	}
+
+	err = DB.QueryRow(`
+		INSERT INTO share_snapshots (id, title, description, filters, graph, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at
+	`, s.ID, s.Title, s.Description, nullJSON(s.Filters), graph, createdBy, s.ExpiresAt).Scan(&s.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetShareSnapshot retrieves an unexpired snapshot. It returns sql.ErrNoRows when the snapshot
+// does not exist or has expired.
+func GetShareSnapshot(id string) (*models.ShareSnapshot, error) {
+	var s models.ShareSnapshot
+	var graph []byte
+	err := DB.QueryRow(`
+		SELECT id, title, description, filters, graph, created_at, expires_at
+		FROM share_snapshots
+		WHERE id = $1 AND expires_at > CURRENT_TIMESTAMP
+	`, id).Scan(&s.ID, &s.Title, &s.Description, &s.Filters, &graph, &s.CreatedAt, &s.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var g shareGraph
+	if err := json.Unmarshal(graph, &g); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot %s: %w", id, err)
+	}
+	s.Nodes, s.Links = g.Nodes, g.Links
+	if s.Nodes == nil {
+		s.Nodes = []models.ShareNode{}
+	}
+	if s.Links == nil {
+		s.Links = []models.ShareLink{}
+	}
+	return &s, nil
+}
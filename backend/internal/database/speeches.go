@@ -0,0 +1,161 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// TopicNodeLimit caps the topics in the interactive network, keeping the most widely discussed
+const TopicNodeLimit = 100
+
+// topicSelect aggregates speech topics by slug. A slug can be spelled more than one way
+// (with or without accents), so the most used spelling names it.
+const topicSelect = `
+		SELECT
+			st.topic_slug,
+			MODE() WITHIN GROUP (ORDER BY st.topic),
+			COUNT(DISTINCT st.speech_id) as speeches,
+			COUNT(DISTINCT ps.politician_id) as politicians
+		FROM speech_topics st
+		JOIN politician_speeches ps ON ps.id = st.speech_id
+		WHERE ps.politician_id IS NOT NULL
+		GROUP BY st.topic_slug
+`
+
+// scanTopic scans a row produced by topicSelect
+func scanTopic(rows *sql.Rows) (models.Topic, error) {
+	var t models.Topic
+	err := rows.Scan(&t.Slug, &t.Topic, &t.Speeches, &t.Politicians)
+	return t, err
+}
+
+// GetTopics retrieves the topics discussed by the most politicians
+func GetTopics(limit, offset int) ([]models.Topic, error) {
+	if loaded, err := Live().hasTable("speech_topics"); err != nil || !loaded {
+		return nil, err
+	}
+
+	rows, err := DB.Query(topicSelect+`
+		ORDER BY politicians DESC, speeches DESC, st.topic_slug
+		LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query topics: %w", err)
+	}
+	defer rows.Close()
+
+	var topics []models.Topic
+	for rows.Next() {
+		t, err := scanTopic(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan topic: %w", err)
+		}
+
+		topics = append(topics, t)
+	}
+
+	return topics, nil
+}
+
+// GetPoliticianTopics retrieves the topics of a politician's speeches, most frequent first
+func GetPoliticianTopics(politicianID, limit int) ([]models.PoliticianTopic, error) {
+	query := `
+		SELECT
+			st.topic_slug,
+			MODE() WITHIN GROUP (ORDER BY st.topic),
+			COUNT(DISTINCT st.speech_id) as speeches,
+			MIN(ps.speech_datetime)::date::text,
+			MAX(ps.speech_datetime)::date::text
+		FROM speech_topics st
+		JOIN politician_speeches ps ON ps.id = st.speech_id
+		WHERE ps.politician_id = $1
+		GROUP BY st.topic_slug
+		ORDER BY speeches DESC, SUM(st.weight) DESC, st.topic_slug
+		LIMIT $2
+	`
+
+	rows, err := DB.Query(query, politicianID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query politician topics: %w", err)
+	}
+	defer rows.Close()
+
+	var topics []models.PoliticianTopic
+	for rows.Next() {
+		var t models.PoliticianTopic
+		if err := rows.Scan(&t.Slug, &t.Topic, &t.Speeches, &t.FirstSpokenAt, &t.LastSpokenAt); err != nil {
+			return nil, fmt.Errorf("failed to scan politician topic: %w", err)
+		}
+
+		topics = append(topics, t)
+	}
+
+	return topics, nil
+}
+
+// EachTopic streams every speech topic to fn
+func EachTopic(fn func(models.Topic) error) error {
+	return eachRow(topicSelect+" ORDER BY st.topic_slug", "topics", func(rows *sql.Rows) error {
+		t, err := scanTopic(rows)
+		if err != nil {
+			return err
+		}
+		return fn(t)
+	})
+}
+
+// getTopicConnections creates politician-topic connections weighted by how many speeches the
+// politician gave on the topic. Only the limit most widely discussed topics are linked, the
+// same ones GetTopics puts in the network.
+func getTopicConnections(limit int) ([]models.Connection, error) {
+	query := `
+		WITH top_topics AS (
+			SELECT st.topic_slug
+			FROM speech_topics st
+			JOIN politician_speeches ps ON ps.id = st.speech_id
+			WHERE ps.politician_id IS NOT NULL
+			GROUP BY st.topic_slug
+			ORDER BY COUNT(DISTINCT ps.politician_id) DESC, COUNT(DISTINCT st.speech_id) DESC, st.topic_slug
+			LIMIT $1
+		)
+		SELECT
+			ps.politician_id,
+			st.topic_slug,
+			COUNT(DISTINCT st.speech_id) as speeches,
+			MIN(ps.speech_datetime)::date::text,
+			MAX(ps.speech_datetime)::date::text
+		FROM speech_topics st
+		JOIN politician_speeches ps ON ps.id = st.speech_id
+		JOIN top_topics tt ON tt.topic_slug = st.topic_slug
+		WHERE ps.politician_id IS NOT NULL
+		GROUP BY ps.politician_id, st.topic_slug
+	`
+
+	rows, err := DB.Query(query, sqlLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var connections []models.Connection
+	for rows.Next() {
+		var politicianID, speeches int
+		var slug, first, last string
+
+		if err := rows.Scan(&politicianID, &slug, &speeches, &first, &last); err != nil {
+			return nil, err
+		}
+
+		connections = append(connections, models.Connection{
+			SourceID:  fmt.Sprintf("politician_%d", politicianID),
+			TargetID:  fmt.Sprintf("%s_%s", models.NodeTypeTopic, slug),
+			Type:      "speaks_about",
+			Strength:  ConnectionStrength(speeches),
+			StartDate: first,
+			EndDate:   last,
+		})
+	}
+
+	return connections, rows.Err()
+}
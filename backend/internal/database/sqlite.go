@@ -0,0 +1,140 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"political-network-api/internal/config"
+	"political-network-api/internal/models"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqlite is set when DB is a SQLite file opened by OpenSQLite rather than Postgres. The read
+// queries behind the core endpoints are written in the SQL both understand (CAST rather than
+// ::, ROW_NUMBER rather than DISTINCT ON, SUBSTR rather than LEFT); the catalog lookups and
+// LIMIT NULL, which differ, check this.
+var sqlite bool
+
+// OpenSQLite opens the SQLite file at cfg.SQLitePath as DB, creating the subset of the data
+// tables the core read endpoints need (sqliteSchema) and loading the synthetic seed dataset
+// (sqliteSeed) into a new file. It stands in for Initialize in local development and demos.
+func OpenSQLite(cfg config.DatabaseConfig) error {
+	if dir := filepath.Dir(cfg.SQLitePath); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+
+	db, err := sql.Open("sqlite", cfg.SQLitePath+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(wal)")
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", cfg.SQLitePath, err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return fmt.Errorf("failed to open %s: %w", cfg.SQLitePath, err)
+	}
+	DB = db
+	sqlite = true
+	slowQueryThreshold = cfg.SlowQueryThreshold
+	maxDroppedRatio = cfg.MaxDroppedRatio
+
+	if _, err := DB.Exec(sqliteSchema); err != nil {
+		return fmt.Errorf("failed to create SQLite schema: %w", err)
+	}
+
+	var politicians int
+	if err := DB.QueryRow(`SELECT COUNT(*) FROM unified_politicians`).Scan(&politicians); err != nil {
+		return fmt.Errorf("failed to check SQLite data: %w", err)
+	}
+	if politicians == 0 {
+		if _, err := DB.Exec(sqliteSeed); err != nil {
+			return fmt.Errorf("failed to seed %s: %w", cfg.SQLitePath, err)
+		}
+		log.Printf("🌱 Seeded %s with the synthetic demo dataset", cfg.SQLitePath)
+	}
+
+	log.Printf("✅ SQLite database %s opened", cfg.SQLitePath)
+	return nil
+}
+
+// hasTable reports whether a table the ETL setup creates is present. It always is on Postgres;
+// a SQLite file only has the tables of sqliteSchema, so reads of the others return nothing.
+func (rd Reader) hasTable(table string) (bool, error) {
+	if !sqlite {
+		return true, nil
+	}
+	return rd.tableExists(table)
+}
+
+// textTime is a sql.NullTime that also scans the text SQLite returns for timestamps it cannot
+// type, such as MIN and MAX of a TIMESTAMP column
+type textTime struct {
+	sql.NullTime
+}
+
+// sqliteTimeLayouts are the text forms of SQLite timestamps: CURRENT_TIMESTAMP's and ISO 8601
+var sqliteTimeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02T15:04:05Z07:00", "2006-01-02"}
+
+func (t *textTime) Scan(src interface{}) error {
+	s, ok := src.(string)
+	if !ok {
+		return t.NullTime.Scan(src)
+	}
+	for _, layout := range sqliteTimeLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time, t.Valid = parsed, true
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse %q as a timestamp", s)
+}
+
+// SQLiteNetwork is the ConnectionRepo of a SQLite file: party membership, financial, company
+// group and sanction connections, the ones its tables hold. The other node sources of the
+// network find no tables there and return nothing.
+type SQLiteNetwork struct {
+	Network
+}
+
+var _ ConnectionRepo = SQLiteNetwork{}
+
+// GetConnections implements ConnectionRepo
+func (SQLiteNetwork) GetConnections() ([]models.Connection, error) {
+	limits := config.Get().Network
+	return sqliteConnections(limits.FinancialConnectionsLimit, limits.SanctionConnectionsLimit)
+}
+
+// GetAllConnections implements ConnectionRepo
+func (SQLiteNetwork) GetAllConnections() ([]models.Connection, error) {
+	return sqliteConnections(0, 0)
+}
+
+// sqliteConnections builds the connections of buildConnections that sqliteSchema has the
+// tables for; a limit of 0 means no limit
+func sqliteConnections(financialLimit, sanctionLimit int) ([]models.Connection, error) {
+	builders := []struct {
+		name  string
+		build func() ([]models.Connection, error)
+	}{
+		{"party", getPartyMembershipConnections},
+		{"financial", func() ([]models.Connection, error) { return getFinancialConnections(financialLimit) }},
+		{"company group", func() ([]models.Connection, error) { return getGroupFinancialConnections(financialLimit) }},
+		{"sanction", func() ([]models.Connection, error) { return getSanctionConnections(sanctionLimit) }},
+	}
+
+	var connections []models.Connection
+	for _, b := range builders {
+		built, err := b.build()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s connections: %w", b.name, err)
+		}
+		connections = append(connections, built...)
+	}
+
+	log.Printf("✅ Generated %d total connections", len(connections))
+	return connections, nil
+}
@@ -0,0 +1,233 @@
+package database
+
+// sqliteSchema creates the data tables the core read endpoints query, with the columns of their
+// Postgres counterparts that those queries use. Statements must be idempotent because they run
+// on every start.
+const sqliteSchema = `
+	CREATE TABLE IF NOT EXISTS unified_politicians (
+		id INTEGER PRIMARY KEY,
+		deputy_id INTEGER UNIQUE,
+		cpf VARCHAR(11),
+		nome_civil VARCHAR(255),
+		nome_eleitoral VARCHAR(255),
+		current_state VARCHAR(2),
+		current_party VARCHAR(20),
+		situacao VARCHAR(50),
+		email VARCHAR(255),
+		url_foto TEXT,
+		birth_date DATE,
+		education_level VARCHAR(100),
+		occupation VARCHAR(255),
+		corruption_risk_score NUMERIC(5,2),
+		plenary_sessions_total INTEGER,
+		plenary_sessions_present INTEGER,
+		plenary_absence_rate NUMERIC(5,2),
+		last_election_year INTEGER,
+		last_election_votes INTEGER,
+		last_election_elected BOOLEAN,
+		last_election_coalition VARCHAR(255),
+		last_election_spending NUMERIC(15,2),
+		data_source VARCHAR(50),
+		source_record_id VARCHAR(100),
+		fetched_at TIMESTAMP,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_unified_politicians_cpf ON unified_politicians(cpf);
+
+	CREATE TABLE IF NOT EXISTS political_parties (
+		id INTEGER PRIMARY KEY,
+		nome VARCHAR(255) NOT NULL,
+		sigla VARCHAR(20) NOT NULL,
+		numero_eleitoral INTEGER,
+		status VARCHAR(50),
+		lider_atual VARCHAR(255),
+		lider_id INTEGER,
+		total_membros INTEGER,
+		total_efetivos INTEGER,
+		legislatura_id INTEGER,
+		logo_url TEXT,
+		data_source VARCHAR(50),
+		source_record_id VARCHAR(100),
+		fetched_at TIMESTAMP,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS party_memberships (
+		id INTEGER PRIMARY KEY,
+		party_id INTEGER NOT NULL,
+		deputy_id INTEGER NOT NULL,
+		deputy_name VARCHAR(255),
+		legislatura_id INTEGER,
+		status VARCHAR(50),
+		data_inicio DATE,
+		data_fim DATE,
+		data_source VARCHAR(50),
+		fetched_at TIMESTAMP,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_party_memberships_party ON party_memberships(party_id);
+	CREATE INDEX IF NOT EXISTS idx_party_memberships_deputy ON party_memberships(deputy_id);
+
+	CREATE TABLE IF NOT EXISTS financial_counterparts (
+		id INTEGER PRIMARY KEY,
+		cnpj_cpf VARCHAR(14) UNIQUE,
+		name VARCHAR(255),
+		entity_type VARCHAR(20),
+		transaction_count INTEGER,
+		total_transaction_amount NUMERIC(15,2),
+		cnae_code VARCHAR(10),
+		cnae_description TEXT,
+		cnae_section VARCHAR(1),
+		source_system VARCHAR(50),
+		source_record_id VARCHAR(100),
+		fetched_at TIMESTAMP,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS unified_financial_records (
+		id INTEGER PRIMARY KEY,
+		politician_id INTEGER NOT NULL,
+		counterpart_cnpj_cpf VARCHAR(14),
+		counterpart_name VARCHAR(255),
+		amount NUMERIC(15,2),
+		transaction_date DATE,
+		source_system VARCHAR(50),
+		fetched_at TIMESTAMP,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_unified_financial_records_politician ON unified_financial_records(politician_id);
+	CREATE INDEX IF NOT EXISTS idx_unified_financial_records_counterpart ON unified_financial_records(counterpart_cnpj_cpf);
+
+	CREATE TABLE IF NOT EXISTS vendor_sanctions (
+		id INTEGER PRIMARY KEY,
+		cnpj_cpf VARCHAR(14),
+		entity_name VARCHAR(255),
+		sanction_type VARCHAR(255),
+		sanction_description TEXT,
+		legal_basis TEXT,
+		sanctioning_agency VARCHAR(255),
+		sanctioning_state VARCHAR(2),
+		sanctioning_process VARCHAR(100),
+		penalty_amount NUMERIC(15,2),
+		sanction_start_date DATE,
+		sanction_end_date DATE,
+		is_active BOOLEAN,
+		data_source VARCHAR(50),
+		api_reference_id VARCHAR(100),
+		verification_date TIMESTAMP,
+		fetched_at TIMESTAMP,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_vendor_sanctions_cnpj_cpf ON vendor_sanctions(cnpj_cpf);
+`
+
+// sqliteSeed is the demo dataset loaded into a new SQLite file. Every person, company and
+// sanction in it is fictional. The expenses are generated: each politician pays three vendors
+// repeatedly, some of them both branches of a group, so the network has edges of every kind.
+// Counterpart and party totals are derived from the rows, as the ETL does.
+const sqliteSeed = `
+	INSERT INTO political_parties (id, nome, sigla, numero_eleitoral, status, legislatura_id, data_source, source_record_id, fetched_at) VALUES
+		(1, 'Partido da Renovação Demonstrativa', 'PRD', 81, 'Ativo', 57, 'SEED', '1', CURRENT_TIMESTAMP),
+		(2, 'Movimento Exemplo Nacional', 'MEN', 82, 'Ativo', 57, 'SEED', '2', CURRENT_TIMESTAMP),
+		(3, 'União dos Dados Abertos', 'UDA', 83, 'Ativo', 57, 'SEED', '3', CURRENT_TIMESTAMP),
+		(4, 'Frente Fictícia Popular', 'FFP', 84, 'Ativo', 57, 'SEED', '4', CURRENT_TIMESTAMP);
+
+	INSERT INTO unified_politicians (
+		id, deputy_id, cpf, nome_civil, nome_eleitoral, current_state, current_party, situacao, email,
+		birth_date, education_level, occupation, corruption_risk_score,
+		plenary_sessions_total, plenary_sessions_present, plenary_absence_rate,
+		last_election_year, last_election_votes, last_election_elected, last_election_coalition, last_election_spending,
+		data_source, source_record_id, fetched_at
+	) VALUES
+		(1, 900001, '00000000101', 'Ana Beatriz Exemplo', 'Ana Exemplo', 'SP', 'PRD', 'Exercício', 'dep.anaexemplo@example.org', '1975-03-14', 'Superior', 'Advogada', 12, 180, 171, 5.00, 2022, 154320, 1, 'Coligação Demonstrativa', 1850000.00, 'SEED', '900001', CURRENT_TIMESTAMP),
+		(2, 900002, '00000000202', 'Bruno Carvalho Fictício', 'Bruno Fictício', 'RJ', 'PRD', 'Exercício', 'dep.brunoficticio@example.org', '1968-11-02', 'Superior', 'Empresário', 64, 180, 122, 32.22, 2022, 98210, 1, 'Coligação Demonstrativa', 2400000.00, 'SEED', '900002', CURRENT_TIMESTAMP),
+		(3, 900003, '00000000303', 'Carla Dias Amostra', 'Carla Amostra', 'MG', 'MEN', 'Exercício', 'dep.carlaamostra@example.org', '1982-07-21', 'Pós-graduação', 'Professora', 8, 180, 176, 2.22, 2022, 201450, 1, 'Aliança Exemplo', 920000.00, 'SEED', '900003', CURRENT_TIMESTAMP),
+		(4, 900004, '00000000404', 'Daniel Esteves Modelo', 'Daniel Modelo', 'BA', 'MEN', 'Exercício', 'dep.danielmodelo@example.org', '1971-01-30', 'Superior', 'Médico', 27, 180, 158, 12.22, 2022, 76540, 1, 'Aliança Exemplo', 1310000.00, 'SEED', '900004', CURRENT_TIMESTAMP),
+		(5, 900005, '00000000505', 'Elisa Fonseca Teste', 'Elisa Teste', 'RS', 'UDA', 'Exercício', 'dep.elisateste@example.org', '1979-05-09', 'Superior', 'Engenheira', 41, 180, 140, 22.22, 2022, 112300, 1, 'Dados para Todos', 1575000.00, 'SEED', '900005', CURRENT_TIMESTAMP),
+		(6, 900006, '00000000606', 'Fábio Gomes Ilustrativo', 'Fábio Ilustrativo', 'PE', 'UDA', 'Exercício', 'dep.fabioilustrativo@example.org', '1965-09-17', 'Médio', 'Comerciante', 73, 180, 109, 39.44, 2022, 65120, 1, 'Dados para Todos', 2890000.00, 'SEED', '900006', CURRENT_TIMESTAMP),
+		(7, 900007, '00000000707', 'Gabriela Hora Simulada', 'Gabriela Simulada', 'PR', 'FFP', 'Exercício', 'dep.gabrielasimulada@example.org', '1988-12-03', 'Superior', 'Jornalista', 5, 180, 178, 1.11, 2022, 87900, 1, 'Frente Popular de Teste', 640000.00, 'SEED', '900007', CURRENT_TIMESTAMP),
+		(8, 900008, '00000000808', 'Heitor Lima Hipotético', 'Heitor Hipotético', 'CE', 'FFP', 'Exercício', 'dep.heitorhipotetico@example.org', '1959-04-26', 'Superior', 'Agropecuarista', 55, 180, 131, 27.22, 2022, 70210, 1, 'Frente Popular de Teste', 2210000.00, 'SEED', '900008', CURRENT_TIMESTAMP),
+		(9, 900009, '00000000909', 'Isabela Moura Provisória', 'Isabela Provisória', 'GO', 'PRD', 'Exercício', 'dep.isabelaprovisoria@example.org', '1984-08-11', 'Superior', 'Administradora', 19, 180, 165, 8.33, 2022, 59870, 1, 'Coligação Demonstrativa', 780000.00, 'SEED', '900009', CURRENT_TIMESTAMP),
+		(10, 900010, '00000001010', 'João Nunes Substituto', 'João Substituto', 'PA', 'MEN', 'Exercício', 'dep.joaosubstituto@example.org', '1973-02-19', 'Médio', 'Policial', 36, 180, 147, 18.33, 2022, 48230, 1, 'Aliança Exemplo', 1120000.00, 'SEED', '900010', CURRENT_TIMESTAMP),
+		(11, 900011, '00000001111', 'Karina Oliveira Rascunho', 'Karina Rascunho', 'SC', 'UDA', 'Fim de Mandato', NULL, '1977-10-05', 'Superior', 'Economista', 23, 120, 101, 15.83, 2018, 41200, 0, 'Dados para Todos', 530000.00, 'SEED', '900011', CURRENT_TIMESTAMP),
+		(12, 900012, '00000001212', 'Lucas Pereira Esboço', 'Lucas Esboço', 'AM', 'FFP', 'Exercício', 'dep.lucasesboco@example.org', '1990-06-28', 'Superior', 'Estudante', 2, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, 'SEED', '900012', CURRENT_TIMESTAMP);
+
+	INSERT INTO party_memberships (party_id, deputy_id, deputy_name, legislatura_id, status, data_inicio, data_fim, data_source, fetched_at) VALUES
+		(1, 900001, 'Ana Exemplo', 57, 'Ativo', '2023-02-01', NULL, 'SEED', CURRENT_TIMESTAMP),
+		(1, 900002, 'Bruno Fictício', 57, 'Ativo', '2023-02-01', NULL, 'SEED', CURRENT_TIMESTAMP),
+		(1, 900009, 'Isabela Provisória', 57, 'Ativo', '2023-02-01', NULL, 'SEED', CURRENT_TIMESTAMP),
+		(2, 900003, 'Carla Amostra', 57, 'Ativo', '2023-02-01', NULL, 'SEED', CURRENT_TIMESTAMP),
+		(2, 900004, 'Daniel Modelo', 57, 'Ativo', '2023-02-01', NULL, 'SEED', CURRENT_TIMESTAMP),
+		(2, 900010, 'João Substituto', 57, 'Ativo', '2023-02-01', NULL, 'SEED', CURRENT_TIMESTAMP),
+		(2, 900002, 'Bruno Fictício', 56, 'Inativo', '2019-02-01', '2022-03-31', 'SEED', CURRENT_TIMESTAMP),
+		(3, 900005, 'Elisa Teste', 57, 'Ativo', '2023-02-01', NULL, 'SEED', CURRENT_TIMESTAMP),
+		(3, 900006, 'Fábio Ilustrativo', 57, 'Ativo', '2023-02-01', NULL, 'SEED', CURRENT_TIMESTAMP),
+		(3, 900011, 'Karina Rascunho', 56, 'Ativo', '2019-02-01', '2023-01-31', 'SEED', CURRENT_TIMESTAMP),
+		(4, 900007, 'Gabriela Simulada', 57, 'Ativo', '2023-02-01', NULL, 'SEED', CURRENT_TIMESTAMP),
+		(4, 900008, 'Heitor Hipotético', 57, 'Ativo', '2023-02-01', NULL, 'SEED', CURRENT_TIMESTAMP),
+		(4, 900012, 'Lucas Esboço', 57, 'Ativo', '2023-02-01', NULL, 'SEED', CURRENT_TIMESTAMP),
+		(4, 900006, 'Fábio Ilustrativo', 56, 'Inativo', '2019-02-01', '2022-03-31', 'SEED', CURRENT_TIMESTAMP);
+
+	UPDATE political_parties SET
+		total_membros = (
+			SELECT COUNT(DISTINCT deputy_id) FROM party_memberships pm
+			WHERE pm.party_id = political_parties.id AND pm.status = 'Ativo'
+		),
+		total_efetivos = (
+			SELECT COUNT(DISTINCT deputy_id) FROM party_memberships pm
+			WHERE pm.party_id = political_parties.id AND pm.status = 'Ativo' AND pm.legislatura_id = 57
+		);
+
+	INSERT INTO financial_counterparts (cnpj_cpf, name, entity_type, cnae_code, cnae_description, cnae_section, source_system, source_record_id, fetched_at) VALUES
+		('11111111000191', 'Locadora de Veículos Exemplo Ltda', 'COMPANY', '7711-0/00', 'Locação de automóveis sem condutor', 'N', 'SEED', '11111111000191', CURRENT_TIMESTAMP),
+		('11111111000272', 'Locadora de Veículos Exemplo Ltda - Filial Rio', 'COMPANY', '7711-0/00', 'Locação de automóveis sem condutor', 'N', 'SEED', '11111111000272', CURRENT_TIMESTAMP),
+		('22222222000150', 'Gráfica Demonstrativa S.A.', 'COMPANY', '1813-0/01', 'Impressão de material para uso publicitário', 'C', 'SEED', '22222222000150', CURRENT_TIMESTAMP),
+		('33333333000107', 'Consultoria Fictícia em Comunicação Ltda', 'COMPANY', '7319-0/04', 'Consultoria em publicidade', 'M', 'SEED', '33333333000107', CURRENT_TIMESTAMP),
+		('44444444000166', 'Posto de Combustível Amostra Ltda', 'COMPANY', '4731-8/00', 'Comércio varejista de combustíveis para veículos automotores', 'G', 'SEED', '44444444000166', CURRENT_TIMESTAMP),
+		('44444444000247', 'Posto de Combustível Amostra Ltda - Filial Centro', 'COMPANY', '4731-8/00', 'Comércio varejista de combustíveis para veículos automotores', 'G', 'SEED', '44444444000247', CURRENT_TIMESTAMP),
+		('55555555000129', 'Companhia Aérea Hipotética S.A.', 'COMPANY', '5111-1/00', 'Transporte aéreo de passageiros regular', 'H', 'SEED', '55555555000129', CURRENT_TIMESTAMP),
+		('66666666000184', 'Restaurante Modelo Eireli', 'COMPANY', '5611-2/01', 'Restaurantes e similares', 'I', 'SEED', '66666666000184', CURRENT_TIMESTAMP);
+
+	INSERT INTO unified_financial_records (politician_id, counterpart_cnpj_cpf, counterpart_name, amount, transaction_date, source_system, fetched_at)
+	WITH RECURSIVE n(i) AS (
+		SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 96
+	),
+	vendors(k, cnpj) AS (
+		SELECT ROW_NUMBER() OVER (ORDER BY cnpj_cpf) - 1, cnpj_cpf FROM financial_counterparts
+	)
+	SELECT
+		1 + i % 12,
+		v.cnpj,
+		fc.name,
+		ROUND(150 + (i * 7919) % 4800 + (i % 7) * 0.37, 2),
+		DATE('2023-02-01', '+' || ((i * 37) % 600) || ' days'),
+		'DEPUTADOS',
+		CURRENT_TIMESTAMP
+	FROM n
+	JOIN vendors v ON v.k = (i % 12 + ((i / 12) % 3) * ((i / 12) % 3)) % 8
+	JOIN financial_counterparts fc ON fc.cnpj_cpf = v.cnpj;
+
+	UPDATE financial_counterparts SET
+		transaction_count = (
+			SELECT COUNT(*) FROM unified_financial_records fr
+			WHERE fr.counterpart_cnpj_cpf = financial_counterparts.cnpj_cpf
+		),
+		total_transaction_amount = (
+			SELECT COALESCE(SUM(amount), 0) FROM unified_financial_records fr
+			WHERE fr.counterpart_cnpj_cpf = financial_counterparts.cnpj_cpf
+		);
+
+	INSERT INTO vendor_sanctions (
+		cnpj_cpf, entity_name, sanction_type, sanction_description, legal_basis, sanctioning_agency,
+		sanctioning_state, sanctioning_process, penalty_amount, sanction_start_date, sanction_end_date,
+		is_active, data_source, api_reference_id, verification_date, fetched_at
+	) VALUES
+		('22222222000150', 'Gráfica Demonstrativa S.A.', 'Impedimento/proibição de contratar com prazo determinado', 'Sanção fictícia para demonstração', 'Lei nº 14.133/2021, art. 156, III', 'Ministério Exemplo', 'DF', '00000.000001/2023-01', 125000.00, '2023-06-01', '2027-05-31', 1, 'PORTAL_TRANSPARENCIA', 'SEED-1', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+		('33333333000107', 'Consultoria Fictícia em Comunicação Ltda', 'Multa', 'Sanção fictícia para demonstração', 'Lei nº 12.846/2013, art. 6º, I', 'Controladoria Exemplo', 'SP', '00000.000002/2023-02', 480000.00, '2023-09-15', NULL, 1, 'PORTAL_TRANSPARENCIA_CNEP', 'SEED-2', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+		('55555555000129', 'Companhia Aérea Hipotética S.A.', 'Suspensão', 'Sanção fictícia já encerrada', 'Lei nº 8.666/1993, art. 87, III', 'Agência Exemplo', 'RJ', '00000.000003/2020-03', 0, '2020-01-10', '2021-01-09', 0, 'PORTAL_TRANSPARENCIA', 'SEED-3', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+		('00000000606', 'Fábio Gomes Ilustrativo', 'Inidoneidade', 'Sanção fictícia para demonstração', 'Lei nº 8.443/1992, art. 46', 'Tribunal Exemplo', 'PE', '00000.000004/2022-04', 60000.00, '2022-11-20', '2027-11-19', 1, 'PORTAL_TRANSPARENCIA', 'SEED-4', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
+`
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// EachPolitician streams every politician to fn without pagination
+func EachPolitician(fn func(models.Politician) error) error {
+	return eachRow(politicianSelect+" ORDER BY p.id", "politicians", func(rows *sql.Rows) error {
+		p, err := scanPolitician(rows)
+		if err != nil {
+			return err
+		}
+		return fn(p)
+	})
+}
+
+// EachParty streams every political party to fn
+func EachParty(fn func(models.Party) error) error {
+	return eachRow(partySelect+" ORDER BY id", "parties", func(rows *sql.Rows) error {
+		p, err := scanParty(rows)
+		if err != nil {
+			return err
+		}
+		return fn(p)
+	})
+}
+
+// EachCompany streams every company to fn
+func EachCompany(fn func(models.Company) error) error {
+	return eachRow(companySelect+" ORDER BY fc.cnpj_cpf", "companies", func(rows *sql.Rows) error {
+		c, err := scanCompany(rows)
+		if err != nil {
+			return err
+		}
+		return fn(c)
+	})
+}
+
+// EachCompanyGroup streams every multi-branch corporate group to fn
+func EachCompanyGroup(fn func(models.CompanyGroup) error) error {
+	return eachRow(companyGroupSelect+" GROUP BY cnpj_root HAVING COUNT(*) > 1 ORDER BY cnpj_root", "company groups", func(rows *sql.Rows) error {
+		g, err := scanCompanyGroup(rows)
+		if err != nil {
+			return err
+		}
+		return fn(g)
+	})
+}
+
+// EachSanction streams every active sanction to fn
+func EachSanction(fn func(models.Sanction) error) error {
+	return eachRow(sanctionSelect+" ORDER BY id", "sanctions", func(rows *sql.Rows) error {
+		s, err := scanSanction(rows)
+		if err != nil {
+			return err
+		}
+		return fn(s)
+	})
+}
+
+// eachRow runs query and hands each row to fn, stopping at the first error
+func eachRow(query, entity string, fn func(*sql.Rows) error) error {
+	rows, err := DB.Query(query)
+	if err != nil {
+		return fmt.Errorf("failed to query %s: %w", entity, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// EachFinancialRecord streams every expense record to fn
+func EachFinancialRecord(fn func(models.FinancialRecord) error) error {
+	return eachRow(financialRecordSelect+" ORDER BY fr.id", "financial records", func(rows *sql.Rows) error {
+		f, err := scanFinancialRecord(rows)
+		if err != nil {
+			return err
+		}
+		return fn(f)
+	})
+}
@@ -0,0 +1,204 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+)
+
+// TCURulingNodeLimit caps the TCU rulings in the interactive network, keeping the most recent
+const TCURulingNodeLimit = 200
+
+// TCURulingFilter narrows GetTCURulings; zero values match everything
+type TCURulingFilter struct {
+	CNPJ         string
+	PoliticianID int
+}
+
+// tcuRulingSelect reads tcu_rulings (loaded by cli4 populate-tcu-rulings) with how many
+// tracked companies and politicians each names. Callers add conditions, then tcuRulingGroupBy.
+const tcuRulingSelect = `
+		SELECT
+			r.id,
+			r.ruling_key,
+			COALESCE(r.numero, 0),
+			COALESCE(r.ano, 0),
+			COALESCE(r.colegiado, ''),
+			COALESCE(r.relator, ''),
+			COALESCE(r.session_date::text, ''),
+			COALESCE(r.titulo, ''),
+			COALESCE(r.sumario, ''),
+			COALESCE(r.url, ''),
+			COALESCE(r.document_url, ''),
+			COUNT(rp.id) as party_count
+		FROM tcu_rulings r
+		LEFT JOIN tcu_ruling_parties rp ON rp.ruling_id = r.id
+		WHERE TRUE
+`
+
+const tcuRulingGroupBy = `
+		GROUP BY r.id
+`
+
+// scanTCURuling scans a row produced by tcuRulingSelect
+func scanTCURuling(rows *sql.Rows) (models.TCURuling, error) {
+	var r models.TCURuling
+	err := rows.Scan(
+		&r.ID, &r.Key, &r.Numero, &r.Ano, &r.Colegiado, &r.Relator, &r.DataSessao,
+		&r.Titulo, &r.Sumario, &r.URL, &r.DocumentURL, &r.PartyCount,
+	)
+	return r, err
+}
+
+// queryTCURulings runs a tcuRulingSelect query and scans every row. It returns nothing when
+// tcu_rulings hasn't been created (cli4 populate-tcu-rulings is optional).
+func (rd Reader) queryTCURulings(query string, args ...interface{}) ([]models.TCURuling, error) {
+	rulings := []models.TCURuling{}
+	if ingested, err := rd.tableExists("tcu_rulings"); err != nil || !ingested {
+		return rulings, err
+	}
+
+	rows, err := rd.q.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query TCU rulings: %w", err)
+	}
+	defer rows.Close()
+
+	scanned := scanErrors{what: "TCU rulings"}
+	for rows.Next() {
+		r, err := scanTCURuling(rows)
+		if !scanned.keep(err) {
+			continue
+		}
+
+		rulings = append(rulings, r)
+	}
+
+	return rowsResult(rulings, rows.Err(), &scanned)
+}
+
+// GetTCURulings retrieves TCU rulings, most recent session first, optionally only those naming
+// a company or a politician
+func (rd Reader) GetTCURulings(filter TCURulingFilter, limit, offset int) ([]models.TCURuling, error) {
+	return rd.queryTCURulings(tcuRulingSelect+`
+		  AND ($1 = '' OR r.id IN (SELECT ruling_id FROM tcu_ruling_parties WHERE cnpj_cpf = $1))
+		  AND ($2 = 0 OR r.id IN (SELECT ruling_id FROM tcu_ruling_parties WHERE politician_id = $2))
+	`+tcuRulingGroupBy+`
+		ORDER BY r.session_date DESC NULLS LAST, r.id DESC
+		LIMIT $3 OFFSET $4
+	`, filter.CNPJ, filter.PoliticianID, limit, offset)
+}
+
+// GetTCURuling retrieves one TCU ruling; sql.ErrNoRows is returned when it doesn't exist
+func (rd Reader) GetTCURuling(id int) (models.TCURuling, error) {
+	rulings, err := rd.queryTCURulings(tcuRulingSelect+`
+		  AND r.id = $1
+	`+tcuRulingGroupBy, id)
+	if err != nil {
+		return models.TCURuling{}, err
+	}
+	if len(rulings) == 0 {
+		return models.TCURuling{}, sql.ErrNoRows
+	}
+	return rulings[0], nil
+}
+
+// GetTCURulingParties retrieves the companies and politicians a ruling names, politicians first
+func (rd Reader) GetTCURulingParties(rulingID int) ([]models.TCURulingParty, error) {
+	query := `
+		SELECT
+			CASE WHEN rp.politician_id IS NULL THEN 'company' ELSE 'politician' END,
+			CASE WHEN rp.politician_id IS NULL THEN rp.cnpj_cpf ELSE '' END,
+			COALESCE(rp.politician_id, 0),
+			COALESCE(p.nome_civil, p.nome_eleitoral, fc.name, 'Unknown')
+		FROM tcu_ruling_parties rp
+		LEFT JOIN unified_politicians p ON p.id = rp.politician_id
+		LEFT JOIN financial_counterparts fc ON fc.cnpj_cpf = rp.cnpj_cpf AND rp.politician_id IS NULL
+		WHERE rp.ruling_id = $1
+		ORDER BY rp.politician_id IS NULL, 4
+	`
+
+	rows, err := rd.q.Query(query, rulingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query TCU ruling parties: %w", err)
+	}
+	defer rows.Close()
+
+	parties := []models.TCURulingParty{}
+	scanned := scanErrors{what: "TCU ruling parties"}
+	for rows.Next() {
+		var p models.TCURulingParty
+		if err := rows.Scan(&p.EntityType, &p.CNPJ, &p.PoliticianID, &p.Nome); !scanned.keep(err) {
+			continue
+		}
+
+		parties = append(parties, p)
+	}
+
+	return rowsResult(parties, rows.Err(), &scanned)
+}
+
+// EachTCURuling streams every TCU ruling to fn
+func EachTCURuling(fn func(models.TCURuling) error) error {
+	if ingested, err := tableExists("tcu_rulings"); err != nil || !ingested {
+		return err
+	}
+	return eachRow(tcuRulingSelect+tcuRulingGroupBy+" ORDER BY r.id", "TCU rulings", func(rows *sql.Rows) error {
+		r, err := scanTCURuling(rows)
+		if err != nil {
+			return err
+		}
+		return fn(r)
+	})
+}
+
+// getTCURulingConnections creates company/politician-ruling connections for the limit most
+// recent rulings, the same ones GetTCURulings puts in the network
+func getTCURulingConnections(limit int) ([]models.Connection, error) {
+	if ingested, err := tableExists("tcu_rulings"); err != nil || !ingested {
+		return nil, err
+	}
+
+	query := `
+		WITH recent AS (
+			SELECT id, COALESCE(session_date::text, '') as session_date
+			FROM tcu_rulings
+			ORDER BY session_date DESC NULLS LAST, id DESC
+			LIMIT $1
+		)
+		SELECT rp.ruling_id, rp.cnpj_cpf, COALESCE(rp.politician_id, 0), recent.session_date
+		FROM tcu_ruling_parties rp
+		JOIN recent ON recent.id = rp.ruling_id
+	`
+
+	rows, err := DB.Query(query, sqlLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var connections []models.Connection
+	for rows.Next() {
+		var rulingID, politicianID int
+		var cnpj, sessionDate string
+
+		if err := rows.Scan(&rulingID, &cnpj, &politicianID, &sessionDate); err != nil {
+			return nil, err
+		}
+
+		source := fmt.Sprintf("company_%s", cnpj)
+		if politicianID != 0 {
+			source = fmt.Sprintf("politician_%d", politicianID)
+		}
+
+		connections = append(connections, models.Connection{
+			SourceID:  source,
+			TargetID:  fmt.Sprintf("%s_%d", models.NodeTypeTCURuling, rulingID),
+			Type:      "tcu_ruling",
+			Strength:  1.0,
+			StartDate: sessionDate,
+		})
+	}
+
+	return connections, rows.Err()
+}
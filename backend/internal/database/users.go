@@ -0,0 +1,218 @@
+package database
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"political-network-api/internal/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// hashToken is how login and session tokens are stored, so a database leak doesn't leak sign-ins
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateLoginToken stores a magic link token for email, creating the user on first sign-in.
+// It reports throttled, storing nothing, when a link was already sent in the last minute.
+func CreateLoginToken(email, token string, ttl time.Duration) (throttled bool, err error) {
+	tx, err := DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var userID int64
+	err = tx.QueryRow(`
+		INSERT INTO users (email) VALUES ($1)
+		ON CONFLICT (email) DO UPDATE SET email = EXCLUDED.email
+		RETURNING id
+	`, email).Scan(&userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	err = tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM login_tokens
+			WHERE user_id = $1 AND created_at > CURRENT_TIMESTAMP - INTERVAL '1 minute'
+		)
+	`, userID).Scan(&throttled)
+	if err != nil || throttled {
+		return throttled, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO login_tokens (token_hash, user_id, expires_at)
+		VALUES ($1, $2, $3)
+	`, hashToken(token), userID, time.Now().Add(ttl))
+	if err != nil {
+		return false, fmt.Errorf("failed to store login token: %w", err)
+	}
+
+	return false, tx.Commit()
+}
+
+// ConsumeLoginToken exchanges an unused, unexpired magic link token for a session. It returns
+// sql.ErrNoRows when the token is unknown, used or expired.
+func ConsumeLoginToken(token, sessionToken string, sessionTTL time.Duration) (*models.Session, error) {
+	tx, err := DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var userID int64
+	err = tx.QueryRow(`
+		UPDATE login_tokens
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING user_id
+	`, hashToken(token)).Scan(&userID)
+	if err != nil {
+		return nil, err
+	}
+
+	session := models.Session{Token: sessionToken, ExpiresAt: time.Now().Add(sessionTTL)}
+	_, err = tx.Exec(`
+		INSERT INTO user_sessions (token_hash, user_id, expires_at) VALUES ($1, $2, $3)
+	`, hashToken(sessionToken), userID, session.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	var lastLogin time.Time
+	err = tx.QueryRow(`
+		UPDATE users SET last_login_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING id, email, created_at, last_login_at
+	`, userID).Scan(&session.User.ID, &session.User.Email, &session.User.CreatedAt, &lastLogin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	session.User.LastLoginAt = &lastLogin
+
+	return &session, tx.Commit()
+}
+
+// GetSessionUser resolves a session token to its user. It returns sql.ErrNoRows when the
+// session is unknown or expired.
+func GetSessionUser(sessionToken string) (*models.User, error) {
+	var u models.User
+	var lastLogin sql.NullTime
+	err := DB.QueryRow(`
+		SELECT u.id, u.email, u.created_at, u.last_login_at
+		FROM user_sessions s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.token_hash = $1 AND s.expires_at > CURRENT_TIMESTAMP
+	`, hashToken(sessionToken)).Scan(&u.ID, &u.Email, &u.CreatedAt, &lastLogin)
+	if err != nil {
+		return nil, err
+	}
+	if lastLogin.Valid {
+		u.LastLoginAt = &lastLogin.Time
+	}
+	return &u, nil
+}
+
+// DeleteSession signs a session out
+func DeleteSession(sessionToken string) error {
+	if _, err := DB.Exec(`DELETE FROM user_sessions WHERE token_hash = $1`, hashToken(sessionToken)); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// CreateSavedView stores v under its ID and fills in its timestamps
+func CreateSavedView(v *models.SavedView) error {
+	err := DB.QueryRow(`
+		INSERT INTO saved_views (id, user_id, name, filters, selected_nodes, camera)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at, updated_at
+	`, v.ID, v.UserID, v.Name, nullJSON(v.Filters), pq.Array(v.SelectedNodes), nullJSON(v.Camera)).
+		Scan(&v.CreatedAt, &v.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save view: %w", err)
+	}
+	return nil
+}
+
+// UpdateSavedView replaces a view's name and state and fills in its new updated_at
+func UpdateSavedView(v *models.SavedView) error {
+	err := DB.QueryRow(`
+		UPDATE saved_views
+		SET name = $2, filters = $3, selected_nodes = $4, camera = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at
+	`, v.ID, v.Name, nullJSON(v.Filters), pq.Array(v.SelectedNodes), nullJSON(v.Camera)).Scan(&v.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update view: %w", err)
+	}
+	return nil
+}
+
+// DeleteSavedView removes a view
+func DeleteSavedView(id string) error {
+	if _, err := DB.Exec(`DELETE FROM saved_views WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete view: %w", err)
+	}
+	return nil
+}
+
+const savedViewSelect = `
+	SELECT id, user_id, name, filters, COALESCE(selected_nodes, '{}'), camera, created_at, updated_at
+	FROM saved_views
+`
+
+func scanSavedView(row interface{ Scan(...interface{}) error }) (models.SavedView, error) {
+	var v models.SavedView
+	err := row.Scan(&v.ID, &v.UserID, &v.Name, &v.Filters, pq.Array(&v.SelectedNodes), &v.Camera,
+		&v.CreatedAt, &v.UpdatedAt)
+	if v.SelectedNodes == nil {
+		v.SelectedNodes = []string{}
+	}
+	return v, err
+}
+
+// GetSavedView retrieves a view by ID. It returns sql.ErrNoRows when the view does not exist.
+func GetSavedView(id string) (*models.SavedView, error) {
+	v, err := scanSavedView(DB.QueryRow(savedViewSelect+`WHERE id = $1`, id))
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// GetSavedViews lists a user's views, most recently updated first
+func GetSavedViews(userID int64) ([]models.SavedView, error) {
+	rows, err := DB.Query(savedViewSelect+`
+		WHERE user_id = $1
+		ORDER BY updated_at DESC, id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved views: %w", err)
+	}
+	defer rows.Close()
+
+	views := []models.SavedView{}
+	for rows.Next() {
+		v, err := scanSavedView(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, v)
+	}
+	return views, rows.Err()
+}
+
+// nullJSON stores an absent or null JSON value as SQL NULL
+func nullJSON(v []byte) interface{} {
+	if len(v) == 0 || string(v) == "null" {
+		return nil
+	}
+	return []byte(v)
+}
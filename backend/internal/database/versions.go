@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"political-network-api/internal/models"
+	"strconv"
+
+	"github.com/lib/pq"
+)
+
+// querier is what read queries run on: the pool, or a transaction pinned to a dataset version
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// Reader runs the read queries behind the versioned endpoints against the live tables or a
+// published dataset version. Version is 0 for the live data.
+type Reader struct {
+	q       querier
+	Version int
+}
+
+// Live returns the reader of the current data
+func Live() Reader {
+	return Reader{q: DB}
+}
+
+// DatasetSchema is the schema cli4 publish-version copies a dataset version's tables into
+func DatasetSchema(version int) string {
+	return "dataset_v" + strconv.Itoa(version)
+}
+
+// Pin returns a reader of a published dataset version, and release to call when done with it.
+// Its queries run in a read-only transaction with the version's schema first on the
+// search_path: the snapshot replaces the ETL tables, and the API's own tables (users, watchlists,
+// reviewed relations...) still resolve to the live ones. sql.ErrNoRows is returned when the
+// version was never published or has been pruned.
+func Pin(version int) (Reader, func(), error) {
+	if sqlite {
+		// A SQLite file has no published versions, only its live tables
+		return Reader{}, nil, sql.ErrNoRows
+	}
+
+	tx, err := DB.BeginTx(context.Background(), &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return Reader{}, nil, err
+	}
+	release := func() { tx.Rollback() }
+
+	var available bool
+	err = tx.QueryRow(`
+		SELECT status = $2 AND EXISTS (SELECT 1 FROM pg_namespace WHERE nspname = $3)
+		FROM dataset_versions
+		WHERE version = $1
+	`, version, models.VersionPublished, DatasetSchema(version)).Scan(&available)
+	if err == nil && !available {
+		err = sql.ErrNoRows
+	}
+	if err == nil {
+		_, err = tx.Exec("SET LOCAL search_path TO " + pq.QuoteIdentifier(DatasetSchema(version)) + ", public")
+	}
+	if err != nil {
+		release()
+		if errors.Is(err, sql.ErrNoRows) {
+			return Reader{}, nil, err
+		}
+		return Reader{}, nil, fmt.Errorf("failed to pin dataset version %d: %w", version, err)
+	}
+
+	return Reader{q: tx, Version: version}, release, nil
+}
+
+// GetDatasetVersions retrieves the dataset versions, newest first: the one being built by the
+// current ETL runs, the published ones that can be pinned and the pruned ones
+func GetDatasetVersions() ([]models.DatasetVersion, error) {
+	rows, err := DB.Query(`
+		SELECT version, status, runs, COALESCE(table_rows, '{}'), COALESCE(note, ''),
+		       created_at, published_at, pruned_at
+		FROM dataset_versions
+		ORDER BY version DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dataset versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []models.DatasetVersion{}
+	for rows.Next() {
+		var v models.DatasetVersion
+		var publishedAt, prunedAt sql.NullTime
+		if err := rows.Scan(&v.Version, &v.Status, &v.Runs, &v.TableRows, &v.Note,
+			&v.CreatedAt, &publishedAt, &prunedAt); err != nil {
+			return nil, err
+		}
+		if publishedAt.Valid {
+			v.PublishedAt = &publishedAt.Time
+		}
+		if prunedAt.Valid {
+			v.PrunedAt = &prunedAt.Time
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
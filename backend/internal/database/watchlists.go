@@ -0,0 +1,349 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/models"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// CreateWatchlist stores w with its items and fills in its ID and creation time
+func CreateWatchlist(w *models.Watchlist) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO watchlists (owner, name, webhook_id, email)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		RETURNING id, created_at
+	`, w.Owner, w.Name, w.WebhookID, w.Email).Scan(&w.ID, &w.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create watchlist: %w", err)
+	}
+	if err := insertWatchlistItems(tx, w.ID, w.Items); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// AddWatchlistItems adds entities to a watchlist, ignoring those already on it
+func AddWatchlistItems(watchlistID int64, items []models.WatchlistItem) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertWatchlistItems(tx, watchlistID, items); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertWatchlistItems(tx *sql.Tx, watchlistID int64, items []models.WatchlistItem) error {
+	for _, item := range items {
+		_, err := tx.Exec(`
+			INSERT INTO watchlist_items (watchlist_id, entity_type, entity_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+		`, watchlistID, item.Type, item.ID)
+		if err != nil {
+			return fmt.Errorf("failed to add watchlist item: %w", err)
+		}
+	}
+	return nil
+}
+
+// RemoveWatchlistItem takes an entity off a watchlist, reporting whether it was on it
+func RemoveWatchlistItem(watchlistID int64, entityType, entityID string) (bool, error) {
+	res, err := DB.Exec(`
+		DELETE FROM watchlist_items
+		WHERE watchlist_id = $1 AND entity_type = $2 AND entity_id = $3
+	`, watchlistID, entityType, entityID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove watchlist item: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// DeleteWatchlist removes a watchlist with its items and alerts
+func DeleteWatchlist(id int64) error {
+	if _, err := DB.Exec(`DELETE FROM watchlists WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete watchlist: %w", err)
+	}
+	return nil
+}
+
+const watchlistSelect = `
+	SELECT id, owner, name, webhook_id, COALESCE(email, ''), created_at
+	FROM watchlists
+`
+
+// GetWatchlists lists watchlists with their items, oldest first; an empty owner lists everyone's
+func GetWatchlists(owner string) ([]models.Watchlist, error) {
+	rows, err := DB.Query(watchlistSelect+`
+		WHERE ($1 = '' OR owner = $1)
+		ORDER BY id
+	`, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query watchlists: %w", err)
+	}
+	defer rows.Close()
+
+	watchlists := []models.Watchlist{}
+	for rows.Next() {
+		w, err := scanWatchlist(rows)
+		if err != nil {
+			return nil, err
+		}
+		watchlists = append(watchlists, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadWatchlistItems(watchlists); err != nil {
+		return nil, err
+	}
+	return watchlists, nil
+}
+
+// GetWatchlist retrieves one watchlist with its items. It returns sql.ErrNoRows when the
+// watchlist does not exist.
+func GetWatchlist(id int64) (*models.Watchlist, error) {
+	w, err := scanWatchlist(DB.QueryRow(watchlistSelect+`WHERE id = $1`, id))
+	if err != nil {
+		return nil, err
+	}
+
+	watchlists := []models.Watchlist{w}
+	if err := loadWatchlistItems(watchlists); err != nil {
+		return nil, err
+	}
+	return &watchlists[0], nil
+}
+
+func scanWatchlist(row interface{ Scan(...interface{}) error }) (models.Watchlist, error) {
+	var w models.Watchlist
+	var webhookID sql.NullInt64
+	if err := row.Scan(&w.ID, &w.Owner, &w.Name, &webhookID, &w.Email, &w.CreatedAt); err != nil {
+		return w, err
+	}
+	if webhookID.Valid {
+		w.WebhookID = &webhookID.Int64
+	}
+	w.Items = []models.WatchlistItem{}
+	return w, nil
+}
+
+// loadWatchlistItems fills in the items of each watchlist, labelled with the politician's
+// name or the company's name as recorded on its payments
+func loadWatchlistItems(watchlists []models.Watchlist) error {
+	if len(watchlists) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(watchlists))
+	ids := make([]int64, len(watchlists))
+	for i, w := range watchlists {
+		index[w.ID] = i
+		ids[i] = w.ID
+	}
+
+	rows, err := DB.Query(`
+		SELECT
+			i.watchlist_id,
+			i.entity_type,
+			i.entity_id,
+			COALESCE(CASE i.entity_type
+				WHEN 'politician' THEN (
+					SELECT COALESCE(p.nome_eleitoral, p.nome_civil)
+					FROM unified_politicians p
+					WHERE p.id::text = i.entity_id
+				)
+				WHEN 'company' THEN (
+					SELECT MAX(fr.counterpart_name)
+					FROM unified_financial_records fr
+					WHERE fr.counterpart_cnpj_cpf = i.entity_id
+				)
+			END, ''),
+			i.created_at
+		FROM watchlist_items i
+		WHERE i.watchlist_id = ANY($1)
+		ORDER BY i.watchlist_id, i.created_at, i.entity_type, i.entity_id
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query watchlist items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var watchlistID int64
+		var item models.WatchlistItem
+		var added time.Time
+		if err := rows.Scan(&watchlistID, &item.Type, &item.ID, &item.Label, &added); err != nil {
+			return err
+		}
+		item.AddedAt = &added
+
+		w := &watchlists[index[watchlistID]]
+		w.Items = append(w.Items, item)
+	}
+	return rows.Err()
+}
+
+// MissingPoliticians returns the IDs among ids that match no politician
+func MissingPoliticians(ids []int) ([]int, error) {
+	rows, err := DB.Query(`
+		SELECT u.id FROM unnest($1::int[]) AS u(id)
+		WHERE NOT EXISTS (SELECT 1 FROM unified_politicians p WHERE p.id = u.id)
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check politicians: %w", err)
+	}
+	defer rows.Close()
+
+	var missing []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		missing = append(missing, id)
+	}
+	return missing, rows.Err()
+}
+
+// GetWatchlistAlerts retrieves a watchlist's alerts newest first
+func GetWatchlistAlerts(watchlistID int64, limit, offset int) ([]models.WatchlistAlert, error) {
+	rows, err := DB.Query(`
+		SELECT id, watchlist_id, entity_type, entity_id, event, summary, data, emailed_at, created_at
+		FROM watchlist_alerts
+		WHERE watchlist_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, watchlistID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query watchlist alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []models.WatchlistAlert{}
+	for rows.Next() {
+		var a models.WatchlistAlert
+		var emailed sql.NullTime
+		err := rows.Scan(&a.ID, &a.WatchlistID, &a.EntityType, &a.EntityID, &a.Event, &a.Summary,
+			&a.Data, &emailed, &a.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if emailed.Valid {
+			a.EmailedAt = &emailed.Time
+		}
+		alerts = append(alerts, a)
+	}
+	return alerts, rows.Err()
+}
+
+// queueWatchlistAlerts records an alert on every watchlist following the politician or company
+// behind event e, and queues a watchlist_alert delivery for the watchlists notifying a webhook.
+// payload is the encoded event.
+func queueWatchlistAlerts(tx *sql.Tx, e models.WebhookEvent, payload []byte) (int, error) {
+	if e.PoliticianID == 0 && e.CNPJ == "" {
+		return 0, nil
+	}
+	politicianID := ""
+	if e.PoliticianID != 0 {
+		politicianID = strconv.Itoa(e.PoliticianID)
+	}
+
+	var alerts int
+	err := tx.QueryRow(`
+		WITH matched AS (
+			INSERT INTO watchlist_alerts (watchlist_id, entity_type, entity_id, event, summary, data)
+			SELECT i.watchlist_id, i.entity_type, i.entity_id, $1::text, $2::text, ($3::jsonb)->'data'
+			FROM watchlist_items i
+			WHERE (i.entity_type = 'politician' AND i.entity_id = $4::text)
+			   OR (i.entity_type = 'company' AND i.entity_id = $5::text)
+			RETURNING id, watchlist_id, entity_type, entity_id
+		),
+		notified AS (
+			INSERT INTO webhook_deliveries (webhook_id, event, payload)
+			SELECT w.webhook_id, $6::text, jsonb_build_object(
+				'event', $6::text,
+				'occurred_at', $7::text,
+				'data', jsonb_build_object(
+					'alert_id', m.id,
+					'watchlist_id', w.id,
+					'watchlist', w.name,
+					'entity_type', m.entity_type,
+					'entity_id', m.entity_id,
+					'summary', $2::text,
+					'source_event', $1::text,
+					'source', ($3::jsonb)->'data'
+				)
+			)
+			FROM matched m
+			JOIN watchlists w ON w.id = m.watchlist_id
+			JOIN webhooks h ON h.id = w.webhook_id AND h.active = true
+		)
+		SELECT COUNT(*) FROM matched
+	`, e.Event, e.Summary, payload, politicianID, e.CNPJ,
+		models.EventWatchlistAlert, e.OccurredAt.Format(time.RFC3339)).Scan(&alerts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record watchlist alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// GetPendingAlertEmails groups alerts not yet emailed by watchlist, for watchlists with an email
+// address, capped at limit alerts
+func GetPendingAlertEmails(limit int) ([]models.PendingAlertEmail, error) {
+	rows, err := DB.Query(`
+		SELECT w.id, w.name, w.email, a.id, a.summary
+		FROM watchlist_alerts a
+		JOIN watchlists w ON w.id = a.watchlist_id
+		WHERE a.emailed_at IS NULL
+		  AND COALESCE(w.email, '') != ''
+		ORDER BY w.id, a.id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending alert emails: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []models.PendingAlertEmail
+	for rows.Next() {
+		var watchlistID, alertID int64
+		var name, email, summary string
+		if err := rows.Scan(&watchlistID, &name, &email, &alertID, &summary); err != nil {
+			return nil, err
+		}
+
+		if n := len(pending); n == 0 || pending[n-1].WatchlistID != watchlistID {
+			pending = append(pending, models.PendingAlertEmail{WatchlistID: watchlistID, Name: name, Email: email})
+		}
+		p := &pending[len(pending)-1]
+		p.AlertIDs = append(p.AlertIDs, alertID)
+		p.Summaries = append(p.Summaries, summary)
+	}
+	return pending, rows.Err()
+}
+
+// MarkAlertsEmailed records that alerts were sent by email
+func MarkAlertsEmailed(ids []int64) error {
+	_, err := DB.Exec(`UPDATE watchlist_alerts SET emailed_at = CURRENT_TIMESTAMP WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to mark alerts emailed: %w", err)
+	}
+	return nil
+}
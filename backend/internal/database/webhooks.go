@@ -0,0 +1,483 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"political-network-api/internal/models"
+	"political-network-api/internal/privacy"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// CreateWebhook stores w and fills in its ID and creation time
+func CreateWebhook(w *models.Webhook) error {
+	err := DB.QueryRow(`
+		INSERT INTO webhooks (url, secret, events, score_threshold, owner)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, active, created_at
+	`, w.URL, w.Secret, pq.Array(w.Events), w.ScoreThreshold, w.Owner).Scan(&w.ID, &w.Active, &w.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+const webhookSelect = `
+	SELECT id, url, events, score_threshold, owner, active, created_at
+	FROM webhooks
+`
+
+func scanWebhook(row interface{ Scan(...interface{}) error }) (models.Webhook, error) {
+	var w models.Webhook
+	err := row.Scan(&w.ID, &w.URL, pq.Array(&w.Events), &w.ScoreThreshold, &w.Owner, &w.Active, &w.CreatedAt)
+	return w, err
+}
+
+// GetWebhooks lists webhooks oldest first; an empty owner lists everyone's
+func GetWebhooks(owner string) ([]models.Webhook, error) {
+	rows, err := DB.Query(webhookSelect+`
+		WHERE ($1 = '' OR owner = $1)
+		ORDER BY id
+	`, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []models.Webhook{}
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, rows.Err()
+}
+
+// GetWebhook retrieves one webhook. It returns sql.ErrNoRows when the webhook does not exist.
+func GetWebhook(id int64) (*models.Webhook, error) {
+	w, err := scanWebhook(DB.QueryRow(webhookSelect+`WHERE id = $1`, id))
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// DeleteWebhook removes a webhook and its delivery log
+func DeleteWebhook(id int64) error {
+	if _, err := DB.Exec(`DELETE FROM webhooks WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// GetWebhookDeliveries retrieves a webhook's delivery log newest first, optionally filtered by status
+func GetWebhookDeliveries(webhookID int64, status string, limit, offset int) ([]models.WebhookDelivery, error) {
+	rows, err := DB.Query(`
+		SELECT id, webhook_id, event, status, attempts, COALESCE(response_status, 0),
+		       COALESCE(last_error, ''), next_attempt_at, delivered_at, created_at, payload
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		  AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`, webhookID, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var d models.WebhookDelivery
+		var nextAttempt, delivered sql.NullTime
+		err := rows.Scan(&d.ID, &d.WebhookID, &d.Event, &d.Status, &d.Attempts, &d.ResponseStatus,
+			&d.LastError, &nextAttempt, &delivered, &d.CreatedAt, &d.Payload)
+		if err != nil {
+			return nil, err
+		}
+		if nextAttempt.Valid && d.Status == models.DeliveryPending {
+			d.NextAttemptAt = &nextAttempt.Time
+		}
+		if delivered.Valid {
+			d.DeliveredAt = &delivered.Time
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// GetDueDeliveries retrieves pending deliveries whose next attempt is due, oldest first
+func GetDueDeliveries(limit int) ([]models.PendingDelivery, error) {
+	rows, err := DB.Query(`
+		SELECT d.id, d.event, d.payload, d.attempts, w.url, w.secret
+		FROM webhook_deliveries d
+		JOIN webhooks w ON w.id = d.webhook_id
+		WHERE d.status = 'pending'
+		  AND d.next_attempt_at <= CURRENT_TIMESTAMP
+		  AND w.active = true
+		ORDER BY d.next_attempt_at, d.id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var due []models.PendingDelivery
+	for rows.Next() {
+		var d models.PendingDelivery
+		if err := rows.Scan(&d.ID, &d.Event, &d.Payload, &d.Attempts, &d.URL, &d.Secret); err != nil {
+			return nil, err
+		}
+		due = append(due, d)
+	}
+	return due, rows.Err()
+}
+
+// RecordDeliveryAttempt logs the outcome of one attempt. A nil retryAt means no further attempt:
+// the delivery is marked delivered when it succeeded and failed otherwise.
+func RecordDeliveryAttempt(id int64, succeeded bool, responseStatus int, attemptErr string, retryAt *time.Time) error {
+	status := models.DeliveryFailed
+	switch {
+	case succeeded:
+		status = models.DeliveryDelivered
+	case retryAt != nil:
+		status = models.DeliveryPending
+	}
+
+	_, err := DB.Exec(`
+		UPDATE webhook_deliveries
+		SET status = $2,
+		    attempts = attempts + 1,
+		    response_status = NULLIF($3, 0),
+		    last_error = NULLIF($4, ''),
+		    next_attempt_at = $5,
+		    delivered_at = CASE WHEN $6 THEN CURRENT_TIMESTAMP END
+		WHERE id = $1
+	`, id, status, responseStatus, attemptErr, retryAt, succeeded)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery %d: %w", id, err)
+	}
+	return nil
+}
+
+// webhookDetectors find the changes behind each event type since the previous run
+var webhookDetectors = []struct {
+	event  string
+	detect func(tx *sql.Tx) ([]models.WebhookEvent, error)
+}{
+	{models.EventNewSanction, detectNewSanctions},
+	{models.EventNewConnection, detectNewConnections},
+	{models.EventScoreChange, detectScoreChanges},
+}
+
+// QueueWebhookEvents detects new sanctions, new politician-company connections and risk score
+// changes, queues a delivery for every active webhook subscribed to each and records an alert
+// on every watchlist following an entity involved. Detection and queueing share a transaction
+// per event type, so a failed run is retried in full by the next. The first run only records
+// where the data stands and queues nothing.
+func QueueWebhookEvents() (queued, alerts int, err error) {
+	for _, d := range webhookDetectors {
+		n, a, err := queueDetected(d.detect)
+		if err != nil {
+			return queued, alerts, fmt.Errorf("%s: %w", d.event, err)
+		}
+		queued += n
+		alerts += a
+	}
+	return queued, alerts, nil
+}
+
+func queueDetected(detect func(tx *sql.Tx) ([]models.WebhookEvent, error)) (queued, alerts int, err error) {
+	tx, err := DB.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	events, err := detect(tx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to encode webhook payload: %w", err)
+		}
+
+		// Only score changes carry a magnitude to compare against each webhook's threshold
+		magnitude := sql.NullInt64{Int64: int64(e.Magnitude), Valid: e.Event == models.EventScoreChange}
+		res, err := tx.Exec(`
+			INSERT INTO webhook_deliveries (webhook_id, event, payload)
+			SELECT id, $1, $2
+			FROM webhooks
+			WHERE active = true
+			  AND $1 = ANY(events)
+			  AND ($3::int IS NULL OR score_threshold <= $3::int)
+		`, e.Event, payload, magnitude)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to queue webhook deliveries: %w", err)
+		}
+		n, _ := res.RowsAffected()
+		queued += int(n)
+
+		alerted, err := queueWatchlistAlerts(tx, e, payload)
+		if err != nil {
+			return 0, 0, err
+		}
+		alerts += alerted
+	}
+
+	return queued, alerts, tx.Commit()
+}
+
+// webhookCursor locks and returns the last position processed for name; ok is false on the first run
+func webhookCursor(tx *sql.Tx, name string) (position int64, ok bool, err error) {
+	err = tx.QueryRow(`SELECT position FROM webhook_cursors WHERE name = $1 FOR UPDATE`, name).Scan(&position)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	return position, err == nil, err
+}
+
+func setWebhookCursor(tx *sql.Tx, name string, position int64) error {
+	_, err := tx.Exec(`
+		INSERT INTO webhook_cursors (name, position) VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET position = EXCLUDED.position, updated_at = CURRENT_TIMESTAMP
+	`, name, position)
+	return err
+}
+
+// advanceCursor moves a table cursor to the table's current maximum id, returning the previous
+// position; ok is false on the first run, when there is nothing to compare against yet
+func advanceCursor(tx *sql.Tx, name, table string) (from, to int64, ok bool, err error) {
+	from, ok, err = webhookCursor(tx, name)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if err = tx.QueryRow(`SELECT COALESCE(MAX(id), 0) FROM ` + table).Scan(&to); err != nil {
+		return 0, 0, false, err
+	}
+	if err = setWebhookCursor(tx, name, to); err != nil {
+		return 0, 0, false, err
+	}
+	return from, to, ok && to > from, nil
+}
+
+// detectNewSanctions lists sanctions imported since the previous run
+func detectNewSanctions(tx *sql.Tx) ([]models.WebhookEvent, error) {
+	from, to, ok, err := advanceCursor(tx, models.EventNewSanction, "vendor_sanctions")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	rows, err := tx.Query(`
+		SELECT
+			id,
+			cnpj_cpf,
+			COALESCE(entity_name, ''),
+			COALESCE(sanction_type, ''),
+			COALESCE(sanctioning_agency, ''),
+			COALESCE(data_source, ''),
+			COALESCE(sanction_start_date::text, ''),
+			COALESCE(sanction_end_date::text, ''),
+			COALESCE(is_active, false)
+		FROM vendor_sanctions
+		WHERE id > $1 AND id <= $2
+		ORDER BY id
+	`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query new sanctions: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now().UTC()
+	var events []models.WebhookEvent
+	for rows.Next() {
+		var id int
+		var doc, name, sanctionType, agency, source, startDate, endDate string
+		var active bool
+		if err := rows.Scan(&id, &doc, &name, &sanctionType, &agency, &source, &startDate, &endDate, &active); err != nil {
+			return nil, err
+		}
+
+		if name == "" {
+			name = privacy.Public.Document(doc)
+		}
+		event := models.WebhookEvent{
+			Event:      models.EventNewSanction,
+			OccurredAt: now,
+			Summary:    fmt.Sprintf("New %s sanction (%s) against %s", sanctionList(source), sanctionType, name),
+			Data: map[string]interface{}{
+				"sanction_id":        id,
+				"cnpj_cpf":           privacy.Public.Document(doc),
+				"entity_name":        name,
+				"sanction_type":      sanctionType,
+				"sanctioning_agency": agency,
+				"cadastro":           sanctionList(source),
+				"start_date":         startDate,
+				"end_date":           endDate,
+				"active":             active,
+				"resource":           "/api/sanctions/" + strconv.Itoa(id),
+			},
+		}
+		if !privacy.IsCPF(doc) {
+			event.CNPJ = doc
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// detectNewConnections lists politician-counterpart pairs whose first financial record was
+// loaded since the previous run
+func detectNewConnections(tx *sql.Tx) ([]models.WebhookEvent, error) {
+	from, to, ok, err := advanceCursor(tx, models.EventNewConnection, "unified_financial_records")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	rows, err := tx.Query(`
+		SELECT
+			p.id,
+			`+politicianLabel+`,
+			fr.counterpart_cnpj_cpf,
+			COALESCE(MAX(fr.counterpart_name), ''),
+			COUNT(*),
+			SUM(fr.amount),
+			MIN(fr.transaction_date)::text
+		FROM unified_financial_records fr
+		JOIN unified_politicians p ON p.id = fr.politician_id
+		WHERE fr.id > $1 AND fr.id <= $2
+		  AND fr.counterpart_cnpj_cpf IS NOT NULL AND fr.counterpart_cnpj_cpf != ''
+		  AND NOT EXISTS (
+			SELECT 1 FROM unified_financial_records prev
+			WHERE prev.politician_id = fr.politician_id
+			  AND prev.counterpart_cnpj_cpf = fr.counterpart_cnpj_cpf
+			  AND prev.id <= $1
+		  )
+		GROUP BY p.id, fr.counterpart_cnpj_cpf
+		ORDER BY p.id, fr.counterpart_cnpj_cpf
+	`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query new connections: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now().UTC()
+	var events []models.WebhookEvent
+	for rows.Next() {
+		var politicianID, count int
+		var politician, doc, counterpart, firstDate string
+		var total models.Money
+		if err := rows.Scan(&politicianID, &politician, &doc, &counterpart, &count, &total, &firstDate); err != nil {
+			return nil, err
+		}
+
+		data := map[string]interface{}{
+			"politician_id":     politicianID,
+			"politician":        politician,
+			"counterpart_name":  counterpart,
+			"counterpart_cnpj":  privacy.Public.Document(doc),
+			"transaction_count": count,
+			"total_value":       total,
+			"first_transaction": firstDate,
+			"resource":          "/api/politicians/" + strconv.Itoa(politicianID),
+		}
+		event := models.WebhookEvent{
+			Event:        models.EventNewConnection,
+			OccurredAt:   now,
+			Data:         data,
+			PoliticianID: politicianID,
+			Summary: fmt.Sprintf("%s paid %s for the first time: R$ %s in %d transaction(s) since %s",
+				politician, counterpart, total, count, firstDate),
+		}
+		if !privacy.IsCPF(doc) {
+			data["resource_company"] = "/api/companies/" + doc
+			event.CNPJ = doc
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// detectScoreChanges compares each politician's risk score with the snapshot taken on the
+// previous run, then refreshes the snapshot
+func detectScoreChanges(tx *sql.Tx) ([]models.WebhookEvent, error) {
+	var snapshots int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM politician_score_snapshots`).Scan(&snapshots); err != nil {
+		return nil, err
+	}
+
+	var events []models.WebhookEvent
+	if snapshots > 0 {
+		rows, err := tx.Query(`
+			SELECT
+				p.id,
+				` + politicianLabel + `,
+				s.score,
+				COALESCE(CAST(p.corruption_risk_score AS INTEGER), 0)
+			FROM unified_politicians p
+			JOIN politician_score_snapshots s ON s.politician_id = p.id
+			WHERE s.score != COALESCE(CAST(p.corruption_risk_score AS INTEGER), 0)
+			ORDER BY p.id
+		`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query score changes: %w", err)
+		}
+		defer rows.Close()
+
+		now := time.Now().UTC()
+		for rows.Next() {
+			var id, previous, score int
+			var politician string
+			if err := rows.Scan(&id, &politician, &previous, &score); err != nil {
+				return nil, err
+			}
+
+			change := score - previous
+			magnitude := change
+			if magnitude < 0 {
+				magnitude = -magnitude
+			}
+
+			events = append(events, models.WebhookEvent{
+				Event:        models.EventScoreChange,
+				OccurredAt:   now,
+				Magnitude:    magnitude,
+				PoliticianID: id,
+				Summary:      fmt.Sprintf("%s: corruption risk score changed from %d to %d", politician, previous, score),
+				Data: map[string]interface{}{
+					"politician_id":  id,
+					"politician":     politician,
+					"previous_score": previous,
+					"score":          score,
+					"change":         change,
+					"resource":       "/api/politicians/" + strconv.Itoa(id),
+				},
+			})
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO politician_score_snapshots (politician_id, score)
+		SELECT id, COALESCE(CAST(corruption_risk_score AS INTEGER), 0)
+		FROM unified_politicians
+		ON CONFLICT (politician_id) DO UPDATE
+		SET score = EXCLUDED.score, updated_at = CURRENT_TIMESTAMP
+		WHERE politician_score_snapshots.score != EXCLUDED.score
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot risk scores: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"political-network-api/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// GetPoliticianWikidata returns a politician's Wikidata link with at most officeLimit
+// previous offices (most recent first), or nil when the politician isn't linked
+func (rd Reader) GetPoliticianWikidata(politicianID, officeLimit int) (*models.WikidataLink, error) {
+	var link models.WikidataLink
+	var wikipediaPT, wikipediaEN sql.NullString
+	var revisionID sql.NullInt64
+	var offices []byte
+
+	if loaded, err := rd.hasTable("politician_wikidata"); err != nil || !loaded {
+		return nil, err
+	}
+	err := rd.q.QueryRow(`
+		SELECT qid, wikipedia_pt, wikipedia_en, COALESCE(aliases, '{}'), COALESCE(previous_offices, '[]'),
+			match_method, revision_id, COALESCE(data_source, 'WIKIDATA'), retrieved_at
+		FROM politician_wikidata
+		WHERE politician_id = $1
+	`, politicianID).Scan(
+		&link.QID, &wikipediaPT, &wikipediaEN, pq.Array(&link.Aliases), &offices,
+		&link.MatchMethod, &revisionID, &link.DataSource, &link.RetrievedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query politician wikidata: %w", err)
+	}
+
+	if err := json.Unmarshal(offices, &link.PreviousOffices); err != nil {
+		return nil, fmt.Errorf("invalid previous_offices for politician %d: %w", politicianID, err)
+	}
+	// Stored oldest first; the detail shows the latest offices
+	for i, j := 0, len(link.PreviousOffices)-1; i < j; i, j = i+1, j-1 {
+		link.PreviousOffices[i], link.PreviousOffices[j] = link.PreviousOffices[j], link.PreviousOffices[i]
+	}
+	if len(link.PreviousOffices) > officeLimit {
+		link.PreviousOffices = link.PreviousOffices[:officeLimit]
+	}
+
+	link.WikidataURL = "https://www.wikidata.org/wiki/" + link.QID
+	link.WikipediaPT = wikipediaPT.String
+	link.WikipediaEN = wikipediaEN.String
+	link.RevisionID = revisionID.Int64
+	return &link, nil
+}
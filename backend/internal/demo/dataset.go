@@ -0,0 +1,208 @@
+// Package demo serves a bundled synthetic dataset from memory through the database repository
+// interfaces, for the public demo frontend and offline development (server.demo). Every name,
+// CPF and CNPJ in it is fictional; it is the same dataset the SQLite driver seeds.
+package demo
+
+import (
+	"bytes"
+	"cmp"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"time"
+)
+
+//go:embed dataset.json
+var datasetJSON []byte
+
+// politician is a dataset politician with the Câmara deputy ID memberships refer to
+type politician struct {
+	models.Politician
+	DeputyID int `json:"deputy_id"`
+}
+
+// membership is a deputy's membership of a party in one legislature
+type membership struct {
+	ID            int    `json:"id"`
+	PartyID       int    `json:"party_id"`
+	DeputyID      int    `json:"deputy_id"`
+	DeputyName    string `json:"deputy_name"`
+	LegislaturaID int    `json:"legislatura_id"`
+	Status        string `json:"status"`
+	DataInicio    string `json:"data_inicio"`
+	DataFim       string `json:"data_fim"`
+}
+
+// Dataset is the demo data with the aggregates the ETL would have stored (company totals,
+// politicians' expense counts, party sizes) derived on load. It is read-only once loaded.
+type Dataset struct {
+	parties     []models.Party
+	politicians []politician
+	memberships []membership
+	companies   []models.Company
+	expenses    []models.FinancialRecord
+	sanctions   []models.SanctionDetail
+
+	politicianByID     map[int]int
+	politicianByDeputy map[int]int
+	companyByCNPJ      map[string]int
+}
+
+// Load decodes the bundled dataset
+func Load() (*Dataset, error) {
+	var raw struct {
+		Parties     []models.Party           `json:"parties"`
+		Politicians []politician             `json:"politicians"`
+		Memberships []membership             `json:"memberships"`
+		Companies   []models.Company         `json:"companies"`
+		Expenses    []models.FinancialRecord `json:"expenses"`
+		Sanctions   []models.SanctionDetail  `json:"sanctions"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(datasetJSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode demo dataset: %w", err)
+	}
+
+	d := &Dataset{
+		parties:            raw.Parties,
+		politicians:        raw.Politicians,
+		memberships:        raw.Memberships,
+		companies:          raw.Companies,
+		expenses:           raw.Expenses,
+		sanctions:          raw.Sanctions,
+		politicianByID:     make(map[int]int, len(raw.Politicians)),
+		politicianByDeputy: make(map[int]int, len(raw.Politicians)),
+		companyByCNPJ:      make(map[string]int, len(raw.Companies)),
+	}
+	d.derive(time.Now().UTC().Truncate(time.Second))
+	return d, nil
+}
+
+// derive indexes the dataset and fills in what the ETL would have stored, stamping every row
+// as loaded at now
+func (d *Dataset) derive(now time.Time) {
+	for i := range d.politicians {
+		p := &d.politicians[i]
+		p.CreatedAt, p.UpdatedAt = now, now
+		d.politicianByID[p.ID] = i
+		d.politicianByDeputy[p.DeputyID] = i
+	}
+
+	for i := range d.companies {
+		c := &d.companies[i]
+		c.ID = c.CNPJ
+		c.CNPJRoot = database.CNPJRoot(c.CNPJ)
+		c.SectorName = models.CNAESections[c.Sector]
+		c.CreatedAt, c.UpdatedAt = now, now
+		d.companyByCNPJ[c.CNPJ] = i
+	}
+
+	for i := range d.expenses {
+		e := &d.expenses[i]
+		e.CreatedAt = now
+		if c, ok := d.company(e.CNPJ); ok {
+			e.NomeEmpresa = c.NomeEmpresa
+			d.companies[d.companyByCNPJ[e.CNPJ]].TransactionCount++
+			d.companies[d.companyByCNPJ[e.CNPJ]].TotalValue += e.Valor
+		}
+		if i, ok := d.politicianByID[e.PoliticianID]; ok {
+			d.politicians[i].FinancialRecordsCount++
+		}
+	}
+
+	for i := range d.parties {
+		p := &d.parties[i]
+		p.CreatedAt, p.UpdatedAt = now, now
+		_, current := d.partyMembers(p.ID)
+		p.TotalMembros, p.TotalEfetivos = len(current), len(current)
+	}
+
+	for i := range d.sanctions {
+		s := &d.sanctions[i]
+		s.CreatedAt, s.UpdatedAt, s.VerificadoEm = now, now, now
+		s.Cadastro = s.DataSource
+		if list, ok := models.SanctionLists[s.DataSource]; ok {
+			s.Cadastro = list
+		}
+	}
+}
+
+func (d *Dataset) politician(id int) (politician, bool) {
+	i, ok := d.politicianByID[id]
+	if !ok {
+		return politician{}, false
+	}
+	return d.politicians[i], true
+}
+
+func (d *Dataset) company(cnpj string) (models.Company, bool) {
+	i, ok := d.companyByCNPJ[cnpj]
+	if !ok {
+		return models.Company{}, false
+	}
+	return d.companies[i], true
+}
+
+// partyMembers reduces a party's memberships to each deputy's latest one, and says which
+// deputies are current members: active in the party's most recent legislature. Everyone else
+// is a former member, as in the Postgres queries.
+func (d *Dataset) partyMembers(partyID int) ([]membership, map[int]bool) {
+	latest := map[int]membership{}
+	var order []int
+	for _, m := range d.memberships {
+		if m.PartyID != partyID {
+			continue
+		}
+		prev, seen := latest[m.DeputyID]
+		if !seen {
+			order = append(order, m.DeputyID)
+		}
+		if !seen || m.LegislaturaID > prev.LegislaturaID {
+			latest[m.DeputyID] = m
+		}
+	}
+
+	newest := 0
+	members := make([]membership, 0, len(order))
+	for _, deputyID := range order {
+		m := latest[deputyID]
+		members = append(members, m)
+		newest = max(newest, m.LegislaturaID)
+	}
+
+	current := map[int]bool{}
+	for _, m := range members {
+		if m.Status == "Ativo" && m.LegislaturaID == newest {
+			current[m.DeputyID] = true
+		}
+	}
+	return members, current
+}
+
+// follows reports whether a row with value and key comes after the cursor's row in a list sorted
+// by value descending, ties by key ascending (descending when keyDesc), as database.ListCursor
+// lists are
+func follows[V, K cmp.Ordered](value V, key K, cursorValue V, cursorKey K, keyDesc bool) bool {
+	if c := cmp.Compare(value, cursorValue); c != 0 {
+		return c < 0
+	}
+	if keyDesc {
+		return key < cursorKey
+	}
+	return key > cursorKey
+}
+
+// page returns the limit items after the first offset
+func page[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit < len(items) {
+		items = items[:max(limit, 0)]
+	}
+	return items
+}
@@ -0,0 +1,283 @@
+package demo
+
+import (
+	"fmt"
+	"log"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"sort"
+	"time"
+)
+
+// Network is the database.ConnectionRepo of the demo dataset: party membership and switch,
+// financial, company group and sanction connections, built with the thresholds of the Postgres
+// queries. It has no topics, fronts or public banks.
+type Network struct {
+	d *Dataset
+}
+
+var _ database.ConnectionRepo = Network{}
+
+// Network returns the connection repository of the dataset
+func (d *Dataset) Network() Network {
+	return Network{d: d}
+}
+
+// GetConnections implements database.ConnectionRepo
+func (n Network) GetConnections() ([]models.Connection, error) {
+	limits := config.Get().Network
+	return n.connections(limits.FinancialConnectionsLimit, limits.SanctionConnectionsLimit), nil
+}
+
+// GetAllConnections implements database.ConnectionRepo
+func (n Network) GetAllConnections() ([]models.Connection, error) {
+	return n.connections(0, 0), nil
+}
+
+// connections builds every connection type; a limit of 0 means no limit
+func (n Network) connections(financialLimit, sanctionLimit int) []models.Connection {
+	var connections []models.Connection
+	connections = append(connections, n.partyConnections()...)
+	connections = append(connections, n.financialConnections(financialLimit, false)...)
+	connections = append(connections, n.financialConnections(financialLimit, true)...)
+	connections = append(connections, n.sanctionConnections(sanctionLimit)...)
+
+	log.Printf("✅ Generated %d total connections", len(connections))
+	return connections
+}
+
+// partyConnections links politicians to their active parties, and with time-ranged
+// party_switch links to the parties they left
+func (n Network) partyConnections() []models.Connection {
+	var connections []models.Connection
+	for _, m := range n.d.memberships {
+		i, ok := n.d.politicianByDeputy[m.DeputyID]
+		if !ok {
+			continue
+		}
+		source := fmt.Sprintf("politician_%d", n.d.politicians[i].ID)
+		target := fmt.Sprintf("party_%d", m.PartyID)
+
+		switch {
+		case m.Status == "Ativo":
+			connections = append(connections, models.Connection{
+				SourceID: source,
+				TargetID: target,
+				Type:     "party_membership",
+				Value:    1.0,
+				Strength: 1.0,
+			})
+		case m.DataFim != "":
+			connections = append(connections, models.Connection{
+				SourceID:  source,
+				TargetID:  target,
+				Type:      "party_switch",
+				Strength:  0.5,
+				StartDate: m.DataInicio,
+				EndDate:   m.DataFim,
+			})
+		}
+	}
+	return connections
+}
+
+// financialConnections links politicians to the companies, or with byGroup the corporate
+// groups spanning several of the companies, they paid at least twice or over R$ 50,000,
+// largest total first
+func (n Network) financialConnections(limit int, byGroup bool) []models.Connection {
+	type pair struct {
+		politicianID int
+		target       string
+	}
+	type totals struct {
+		transactions int
+		value        models.Money
+		companies    map[string]bool
+	}
+
+	sums := map[pair]*totals{}
+	for _, e := range n.d.expenses {
+		if len(e.CNPJ) <= 11 || e.Valor <= 0 { // individuals (CPFs) are not network nodes
+			continue
+		}
+		target := e.CNPJ
+		if byGroup {
+			if target = database.CNPJRoot(e.CNPJ); target == "" {
+				continue
+			}
+		}
+		key := pair{e.PoliticianID, target}
+		if sums[key] == nil {
+			sums[key] = &totals{companies: map[string]bool{}}
+		}
+		sums[key].transactions++
+		sums[key].value += e.Valor
+		sums[key].companies[e.CNPJ] = true
+	}
+
+	var pairs []pair
+	for key, t := range sums {
+		if (byGroup && len(t.companies) < 2) || (t.transactions < 2 && t.value <= models.MoneyFromFloat(50000)) {
+			continue
+		}
+		pairs = append(pairs, key)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		a, b := sums[pairs[i]], sums[pairs[j]]
+		if a.value != b.value {
+			return a.value > b.value
+		}
+		if pairs[i].politicianID != pairs[j].politicianID {
+			return pairs[i].politicianID < pairs[j].politicianID
+		}
+		return pairs[i].target < pairs[j].target
+	})
+	if limit > 0 && len(pairs) > limit {
+		pairs = pairs[:limit]
+	}
+
+	target, kind := "company_%s", "financial"
+	if byGroup {
+		target, kind = string(models.NodeTypeCompanyGroup)+"_%s", "financial_group"
+	}
+	connections := make([]models.Connection, 0, len(pairs))
+	for _, key := range pairs {
+		t := sums[key]
+		connections = append(connections, models.Connection{
+			SourceID: fmt.Sprintf("politician_%d", key.politicianID),
+			TargetID: fmt.Sprintf(target, key.target),
+			Type:     kind,
+			Value:    t.value,
+			Strength: database.ConnectionStrength(t.transactions),
+		})
+	}
+	return connections
+}
+
+// sanctionConnections links active sanctions to the company (by CNPJ) or the politician (by
+// CPF) they were imposed on
+func (n Network) sanctionConnections(limit int) []models.Connection {
+	cpfs := map[string]int{}
+	for _, p := range n.d.politicians {
+		if p.CPF != "" {
+			cpfs[p.CPF] = p.ID
+		}
+	}
+
+	var connections []models.Connection
+	for _, s := range n.d.sanctions {
+		if !s.Ativa || s.CNPJ == "" {
+			continue
+		}
+		if limit > 0 && len(connections) == limit {
+			break
+		}
+
+		source := "company_" + s.CNPJ
+		if len(s.CNPJ) <= 11 {
+			politicianID, ok := cpfs[s.CNPJ]
+			if !ok {
+				continue
+			}
+			source = fmt.Sprintf("politician_%d", politicianID)
+		}
+		connections = append(connections, models.Connection{
+			SourceID: source,
+			TargetID: fmt.Sprintf("sanction_%d", s.ID),
+			Type:     "sanction",
+			Value:    s.ValorMulta,
+			Strength: 1.0,
+		})
+	}
+	return connections
+}
+
+// GetNetworkStats implements database.ConnectionRepo; the counts are always exact
+func (n Network) GetNetworkStats(exact bool) (models.NetworkStats, error) {
+	start := time.Now()
+	stats := models.NetworkStats{
+		Politicians:      len(n.d.politicians),
+		Parties:          len(n.d.parties),
+		Companies:        len(n.d.companies),
+		CompanyGroups:    len(n.groups()),
+		FinancialRecords: len(n.d.expenses),
+	}
+	for _, s := range n.d.sanctions {
+		if s.Ativa {
+			stats.Sanctions++
+		}
+	}
+
+	stats.TotalNodes = stats.Politicians + stats.Parties + stats.Companies + stats.CompanyGroups + stats.Sanctions
+	stats.LastUpdated = time.Now()
+	stats.ProcessingTime = time.Since(start).String()
+	return stats, nil
+}
+
+// GetCompanyGroups implements database.ConnectionRepo
+func (n Network) GetCompanyGroups(limit, offset int) ([]models.CompanyGroup, error) {
+	return page(n.groups(), limit, offset), nil
+}
+
+// groups aggregates the companies by CNPJ root into the groups with more than one branch,
+// largest first, each named after its matriz (branch 0001) or else its largest branch
+func (n Network) groups() []models.CompanyGroup {
+	byRoot := map[string][]models.Company{}
+	var roots []string
+	for _, c := range n.d.companies {
+		if c.CNPJRoot == "" {
+			continue
+		}
+		if byRoot[c.CNPJRoot] == nil {
+			roots = append(roots, c.CNPJRoot)
+		}
+		byRoot[c.CNPJRoot] = append(byRoot[c.CNPJRoot], c)
+	}
+
+	var groups []models.CompanyGroup
+	for _, root := range roots {
+		branches := byRoot[root]
+		if len(branches) < 2 {
+			continue
+		}
+
+		g := models.CompanyGroup{ID: root, CNPJRoot: root, BranchCount: len(branches)}
+		var named models.Company
+		for _, c := range branches {
+			g.TransactionCount += c.TransactionCount
+			g.TotalValue += c.TotalValue
+			if named.CNPJ == "" || isMatriz(c) && !isMatriz(named) ||
+				isMatriz(c) == isMatriz(named) && c.TotalValue > named.TotalValue {
+				named = c
+			}
+		}
+		g.NomeEmpresa = named.NomeEmpresa
+		groups = append(groups, g)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].TotalValue > groups[j].TotalValue
+	})
+	return groups
+}
+
+// isMatriz reports whether a company is the head office of its group
+func isMatriz(c models.Company) bool {
+	return c.CNPJ[8:12] == "0001"
+}
+
+// GetTopics implements database.ConnectionRepo; the dataset has no speeches
+func (Network) GetTopics(limit, offset int) ([]models.Topic, error) {
+	return nil, nil
+}
+
+// GetFronts implements database.ConnectionRepo; the dataset has no frentes parlamentares
+func (Network) GetFronts(legislaturaID, limit, offset int) ([]models.Front, error) {
+	return nil, nil
+}
+
+// GetPublicBanks implements database.ConnectionRepo; the dataset has no public loans
+func (Network) GetPublicBanks() ([]models.PublicBank, error) {
+	return []models.PublicBank{}, nil
+}
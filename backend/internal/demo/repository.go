@@ -0,0 +1,699 @@
+package demo
+
+import (
+	"cmp"
+	"database/sql"
+	"fmt"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Repository is the database.Repository of the demo dataset. It answers the reads the dataset
+// has data for the way the Postgres queries do (same filters, orders and not-found errors);
+// fronts, relatives, Wikidata links, party funds, owners, public loans and TCU rulings are
+// empty.
+type Repository struct {
+	d *Dataset
+}
+
+var _ database.Repository = Repository{}
+
+// Repository returns the repository reading the dataset
+func (d *Dataset) Repository() Repository {
+	return Repository{d: d}
+}
+
+// DatasetVersion implements database.Repository; the demo dataset has no versions
+func (Repository) DatasetVersion() int {
+	return 0
+}
+
+// GetPoliticians implements database.Repository
+func (r Repository) GetPoliticians(limit, offset, minScore int, filter database.PoliticianFilter) ([]models.Politician, error) {
+	if !database.ValidPoliticianSort(filter.Sort) {
+		return nil, fmt.Errorf("unsupported politician sort %q", filter.Sort)
+	}
+	percentiles := r.spendingPercentiles()
+
+	var politicians []models.Politician
+	for _, p := range r.d.politicians {
+		if p.CorruptionScore < minScore ||
+			!floatAtLeast(p.TaxaAusencia, filter.MinAbsenceRate) ||
+			!floatAtMost(p.TaxaAusencia, filter.MaxAbsenceRate) ||
+			(filter.ElectionYear != 0 && p.AnoEleicao != filter.ElectionYear) ||
+			!intAtLeast(p.Votos, filter.MinVotes) ||
+			!intAtMost(p.Votos, filter.MaxVotes) ||
+			(filter.Elected != nil && (p.Eleito == nil || *p.Eleito != *filter.Elected)) ||
+			(filter.IDs != nil && !slices.Contains(filter.IDs, p.ID)) {
+			continue
+		}
+		if filter.MinSpendingPercentile != nil {
+			percentile, ok := percentiles[p.ID]
+			if !ok || percentile < *filter.MinSpendingPercentile {
+				continue
+			}
+		}
+		politicians = append(politicians, p.Politician)
+	}
+
+	key := politicianSortKeys[strings.TrimPrefix(filter.Sort, "-")]
+	descending := strings.HasPrefix(filter.Sort, "-")
+	if key != nil {
+		sort.SliceStable(politicians, func(i, j int) bool {
+			a, b := key(politicians[i]), key(politicians[j])
+			switch {
+			case a == nil || b == nil:
+				// NULLS LAST either way
+				return a != nil && b == nil
+			case descending:
+				return *a > *b
+			default:
+				return *a < *b
+			}
+		})
+	}
+	if filter.After != nil {
+		politicians = slices.DeleteFunc(politicians, func(p models.Politician) bool {
+			return !after(p, *filter.After, key, descending)
+		})
+	}
+
+	return page(politicians, limit, offset), nil
+}
+
+// after reports whether p comes after cursor in the order sorted by key, as the query's keyset
+// condition does (database.PoliticianCursor)
+func after(p models.Politician, cursor database.PoliticianCursor, key func(models.Politician) *float64, descending bool) bool {
+	if key == nil {
+		return p.ID > cursor.ID
+	}
+	a := key(p)
+	if cursor.Value == nil {
+		return a == nil && p.ID > cursor.ID
+	}
+	v, err := strconv.ParseFloat(*cursor.Value, 64)
+	switch {
+	case err != nil:
+		return false
+	case a == nil:
+		return true
+	case *a == v:
+		return p.ID > cursor.ID
+	case descending:
+		return *a < v
+	default:
+		return *a > v
+	}
+}
+
+// cursorInt and cursorFloat read a value or key of a database.ListCursor; the cursors are signed
+// by the server, so they always parse
+func cursorInt(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func cursorFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
+
+// politicianSortKeys are the values politician lists sort by, per sort name without its
+// direction; the dataset is already in id order
+var politicianSortKeys = map[string]func(models.Politician) *float64{
+	"absence_rate": func(p models.Politician) *float64 { return p.TaxaAusencia },
+	"votes": func(p models.Politician) *float64 {
+		if p.Votos == nil {
+			return nil
+		}
+		v := float64(*p.Votos)
+		return &v
+	},
+	"spending": func(p models.Politician) *float64 {
+		if p.GastoCampanha == nil {
+			return nil
+		}
+		v := p.GastoCampanha.Float64()
+		return &v
+	},
+}
+
+// spendingPercentiles ranks politicians' campaign spending among those of the same election,
+// as PERCENT_RANK does: the share of the others who spent less, times 100
+func (r Repository) spendingPercentiles() map[int]float64 {
+	byYear := map[int][]politician{}
+	for _, p := range r.d.politicians {
+		if p.GastoCampanha != nil {
+			byYear[p.AnoEleicao] = append(byYear[p.AnoEleicao], p)
+		}
+	}
+
+	percentiles := map[int]float64{}
+	for _, candidates := range byYear {
+		for _, p := range candidates {
+			below := 0
+			for _, other := range candidates {
+				if *other.GastoCampanha < *p.GastoCampanha {
+					below++
+				}
+			}
+			if len(candidates) > 1 {
+				percentiles[p.ID] = float64(below) / float64(len(candidates)-1) * 100
+			} else {
+				percentiles[p.ID] = 0
+			}
+		}
+	}
+	return percentiles
+}
+
+// floatAtLeast compares like SQL's v >= bound in a filter that an unset bound turns off: a
+// missing value fails any set bound. floatAtMost, intAtLeast and intAtMost do the same.
+func floatAtLeast(v, bound *float64) bool { return bound == nil || (v != nil && *v >= *bound) }
+func floatAtMost(v, bound *float64) bool  { return bound == nil || (v != nil && *v <= *bound) }
+func intAtLeast(v, bound *int) bool       { return bound == nil || (v != nil && *v >= *bound) }
+func intAtMost(v, bound *int) bool        { return bound == nil || (v != nil && *v <= *bound) }
+
+// GetPolitician implements database.Repository
+func (r Repository) GetPolitician(id int) (models.Politician, error) {
+	p, ok := r.d.politician(id)
+	if !ok {
+		return models.Politician{}, sql.ErrNoRows
+	}
+	return p.Politician, nil
+}
+
+// GetPoliticianKeys implements database.Repository. The bundled dataset's IDs never shift, so
+// its politicians have no public IDs; their slugs are made from their names.
+func (r Repository) GetPoliticianKeys() ([]models.PoliticianKey, error) {
+	keys := make([]models.PoliticianKey, len(r.d.politicians))
+	for i, p := range r.d.politicians {
+		keys[i] = models.PoliticianKey{ID: p.ID, Nome: p.Nome, SiglaPartido: p.SiglaPartido, UF: p.UF}
+	}
+	return keys, nil
+}
+
+// GetPoliticianSanctions implements database.Repository
+func (r Repository) GetPoliticianSanctions(politicianID, limit int) ([]models.Sanction, error) {
+	p, ok := r.d.politician(politicianID)
+	if !ok || p.CPF == "" {
+		return nil, nil
+	}
+
+	var sanctions []models.Sanction
+	for _, s := range r.d.sanctions {
+		if s.Ativa && s.CNPJ == p.CPF {
+			sanctions = append(sanctions, s.Sanction)
+		}
+	}
+	sort.SliceStable(sanctions, func(i, j int) bool {
+		return sanctions[i].DataInicioSancao > sanctions[j].DataInicioSancao
+	})
+	return page(sanctions, limit, 0), nil
+}
+
+// GetPoliticianMemberships implements database.Repository
+func (r Repository) GetPoliticianMemberships(politicianID, limit int) ([]models.PartyMembership, error) {
+	p, ok := r.d.politician(politicianID)
+	if !ok {
+		return nil, nil
+	}
+
+	var memberships []models.PartyMembership
+	for _, m := range r.d.memberships {
+		if m.DeputyID == p.DeputyID {
+			memberships = append(memberships, models.PartyMembership{
+				ID:            m.ID,
+				PartyID:       m.PartyID,
+				DeputyID:      m.DeputyID,
+				DeputyName:    m.DeputyName,
+				LegislaturaID: m.LegislaturaID,
+				Status:        m.Status,
+				CreatedAt:     p.CreatedAt,
+			})
+		}
+	}
+	sort.SliceStable(memberships, func(i, j int) bool {
+		return memberships[i].LegislaturaID > memberships[j].LegislaturaID
+	})
+	return page(memberships, limit, 0), nil
+}
+
+// GetPoliticianFronts implements database.Repository; the dataset has no frentes parlamentares
+func (Repository) GetPoliticianFronts(politicianID, limit int) ([]models.Front, error) {
+	return nil, nil
+}
+
+// GetFrontPeers implements database.Repository; the dataset has no frentes parlamentares
+func (Repository) GetFrontPeers(politicianID, limit int) ([]models.FrontPeer, error) {
+	return nil, nil
+}
+
+// GetPoliticianWikidata implements database.Repository; no politician is linked
+func (Repository) GetPoliticianWikidata(politicianID, officeLimit int) (*models.WikidataLink, error) {
+	return nil, nil
+}
+
+// GetPoliticianRelatives implements database.Repository; the dataset has no family relations
+func (Repository) GetPoliticianRelatives(politicianID, limit int) ([]models.FamilyRelation, error) {
+	return nil, nil
+}
+
+// GetParties implements database.Repository
+func (r Repository) GetParties(limit, offset int, after *database.ListCursor) ([]models.Party, error) {
+	var parties []models.Party
+	for _, p := range r.d.parties {
+		if after == nil || follows(p.TotalMembros, p.ID, cursorInt(after.Value), cursorInt(after.Key), false) {
+			parties = append(parties, p)
+		}
+	}
+	sort.Slice(parties, func(i, j int) bool {
+		if parties[i].TotalMembros != parties[j].TotalMembros {
+			return parties[i].TotalMembros > parties[j].TotalMembros
+		}
+		return parties[i].ID < parties[j].ID
+	})
+	return page(parties, limit, offset), nil
+}
+
+// GetParty implements database.Repository
+func (r Repository) GetParty(id int) (models.Party, error) {
+	for _, p := range r.d.parties {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return models.Party{}, sql.ErrNoRows
+}
+
+// GetPartySummary implements database.Repository. Every demo expense counts as CEAP spending.
+func (r Repository) GetPartySummary(party models.Party) (models.PartySummary, error) {
+	members, current := r.d.partyMembers(party.ID)
+	s := models.PartySummary{
+		CurrentMembers: len(current),
+		FormerMembers:  len(members) - len(current),
+	}
+
+	ids := map[int]bool{}
+	scores := 0
+	for deputyID := range current {
+		if i, ok := r.d.politicianByDeputy[deputyID]; ok {
+			p := r.d.politicians[i]
+			ids[p.ID] = true
+			scores += p.CorruptionScore
+		}
+	}
+	if len(ids) > 0 {
+		s.AverageCorruptionScore = float64(scores) / float64(len(ids))
+	}
+
+	for _, e := range r.d.expenses {
+		if ids[e.PoliticianID] {
+			s.MemberExpenseCount++
+			s.MemberSpending += e.Valor
+		}
+	}
+	return s, nil
+}
+
+// GetPartyMembers implements database.Repository
+func (r Repository) GetPartyMembers(partyID int, current bool, limit int) ([]models.PartyMember, error) {
+	memberships, currentMembers := r.d.partyMembers(partyID)
+
+	var members []models.PartyMember
+	for _, m := range memberships {
+		i, ok := r.d.politicianByDeputy[m.DeputyID]
+		if !ok || currentMembers[m.DeputyID] != current {
+			continue
+		}
+		p := r.d.politicians[i]
+		members = append(members, models.PartyMember{
+			PoliticianID:    p.ID,
+			DeputyID:        m.DeputyID,
+			Nome:            p.Nome,
+			LegislaturaID:   m.LegislaturaID,
+			Status:          m.Status,
+			DataInicio:      m.DataInicio,
+			DataFim:         m.DataFim,
+			CorruptionScore: p.CorruptionScore,
+		})
+	}
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].Nome < members[j].Nome
+	})
+	return page(members, limit, 0), nil
+}
+
+// GetPartyFunds implements database.Repository; the dataset has no fund distributions
+func (Repository) GetPartyFunds(sigla string, limit int) ([]models.PartyFund, error) {
+	return nil, nil
+}
+
+// GetCompanies implements database.Repository
+func (r Repository) GetCompanies(limit, offset int, filter database.CompanyFilter) ([]models.Company, error) {
+	sector := filter.Sector
+	var companies []models.Company
+	for _, c := range r.d.companies {
+		if filter.CNPJs != nil && !slices.Contains(filter.CNPJs, c.CNPJ) {
+			continue
+		}
+		if a := filter.After; a != nil && !follows(c.TotalValue.Float64(), c.CNPJ, cursorFloat(a.Value), a.Key, false) {
+			continue
+		}
+		if sector == "" || c.Sector == sector || strings.HasPrefix(c.CNAE, sector) {
+			companies = append(companies, c)
+		}
+	}
+	sort.Slice(companies, func(i, j int) bool {
+		if companies[i].TotalValue != companies[j].TotalValue {
+			return companies[i].TotalValue > companies[j].TotalValue
+		}
+		return companies[i].CNPJ < companies[j].CNPJ
+	})
+	return page(companies, limit, offset), nil
+}
+
+// GetCompany implements database.Repository
+func (r Repository) GetCompany(cnpj string) (models.Company, error) {
+	c, ok := r.d.company(cnpj)
+	if !ok {
+		return models.Company{}, sql.ErrNoRows
+	}
+	return c, nil
+}
+
+// GetCompanyPayers implements database.Repository
+func (r Repository) GetCompanyPayers(cnpj string) ([]models.CompanyPayer, error) {
+	byPolitician := map[int]*models.CompanyPayer{}
+	payers := []models.CompanyPayer{}
+	for _, e := range r.d.expenses {
+		if e.CNPJ != cnpj {
+			continue
+		}
+		payer, seen := byPolitician[e.PoliticianID]
+		if !seen {
+			p, ok := r.d.politician(e.PoliticianID)
+			if !ok {
+				continue
+			}
+			payer = &models.CompanyPayer{
+				PoliticianID: p.ID,
+				Nome:         p.Nome,
+				SiglaPartido: p.SiglaPartido,
+				UF:           p.UF,
+				FirstPayment: e.DataDoc,
+				LastPayment:  e.DataDoc,
+			}
+			byPolitician[e.PoliticianID] = payer
+		}
+		payer.TransactionCount++
+		payer.TotalValue += e.Valor
+		payer.FirstPayment = min(payer.FirstPayment, e.DataDoc)
+		payer.LastPayment = max(payer.LastPayment, e.DataDoc)
+	}
+
+	for _, payer := range byPolitician {
+		payers = append(payers, *payer)
+	}
+	sort.Slice(payers, func(i, j int) bool {
+		if payers[i].TotalValue != payers[j].TotalValue {
+			return payers[i].TotalValue > payers[j].TotalValue
+		}
+		return payers[i].PoliticianID < payers[j].PoliticianID
+	})
+	return payers, nil
+}
+
+// GetCompanySanctions implements database.Repository
+func (r Repository) GetCompanySanctions(cnpj string) ([]models.Sanction, error) {
+	sanctions := []models.Sanction{}
+	for _, s := range r.d.sanctions {
+		if s.CNPJ == cnpj {
+			sanctions = append(sanctions, s.Sanction)
+		}
+	}
+	sort.SliceStable(sanctions, func(i, j int) bool {
+		return sanctions[i].DataInicioSancao > sanctions[j].DataInicioSancao
+	})
+	return sanctions, nil
+}
+
+// GetCompanyOwners implements database.Repository; the dataset has no QSA
+func (Repository) GetCompanyOwners(cnpj string) ([]models.CompanyOwner, error) {
+	return nil, nil
+}
+
+// GetCompanyPublicLoans implements database.Repository; the dataset has no public loans
+func (Repository) GetCompanyPublicLoans(cnpj string, limit int) ([]models.PublicLoan, error) {
+	return []models.PublicLoan{}, nil
+}
+
+// GetCompanyLoanTotals implements database.Repository; the dataset has no public loans
+func (Repository) GetCompanyLoanTotals(cnpj string) (models.PublicLoanTotals, error) {
+	return models.PublicLoanTotals{}, nil
+}
+
+// GetSanctions implements database.Repository: active sanctions, largest fine first
+func (r Repository) GetSanctions(limit, offset int, after *database.ListCursor) ([]models.Sanction, error) {
+	var sanctions []models.Sanction
+	for _, s := range r.d.sanctions {
+		if !s.Ativa {
+			continue
+		}
+		if after == nil || follows(s.ValorMulta.Float64(), s.ID, cursorFloat(after.Value), cursorInt(after.Key), false) {
+			sanctions = append(sanctions, s.Sanction)
+		}
+	}
+	sort.Slice(sanctions, func(i, j int) bool {
+		if sanctions[i].ValorMulta != sanctions[j].ValorMulta {
+			return sanctions[i].ValorMulta > sanctions[j].ValorMulta
+		}
+		return sanctions[i].ID < sanctions[j].ID
+	})
+	return page(sanctions, limit, offset), nil
+}
+
+// GetSanction implements database.Repository
+func (r Repository) GetSanction(id int) (models.SanctionDetail, error) {
+	for _, s := range r.d.sanctions {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return models.SanctionDetail{}, sql.ErrNoRows
+}
+
+// GetFinancialRecords implements database.Repository
+func (r Repository) GetFinancialRecords(politicianID, limit, offset int, after *database.ListCursor) ([]models.FinancialRecord, error) {
+	var records []models.FinancialRecord
+	for _, e := range r.d.expenses {
+		if politicianID != 0 && e.PoliticianID != politicianID {
+			continue
+		}
+		if after == nil || follows(e.DataDoc, e.ID, after.Value, cursorInt(after.Key), true) {
+			records = append(records, e)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].DataDoc != records[j].DataDoc {
+			return records[i].DataDoc > records[j].DataDoc
+		}
+		return records[i].ID > records[j].ID
+	})
+	return page(records, limit, offset), nil
+}
+
+// tally is what the expenses paid to or by one counterparty add up to
+type tally struct {
+	name                    string
+	total, recent, previous models.Money
+	payments                int
+	first, last             string
+}
+
+// tallyExpenses groups expenses by key as the Postgres rankings do: with the payments of the 12
+// months up to the latest expense and of the 12 before. It returns the tallies, their keys largest
+// total first (ties by key) and the total of all expenses.
+func tallyExpenses[K cmp.Ordered](expenses []models.FinancialRecord, key func(models.FinancialRecord) K) (map[K]*tally, []K, models.Money) {
+	var total models.Money
+	latest := ""
+	for _, e := range expenses {
+		total += e.Valor
+		latest = max(latest, e.DataDoc)
+	}
+	day, _ := time.Parse("2006-01-02", latest)
+	recent, previous := day.AddDate(-1, 0, 0).Format("2006-01-02"), day.AddDate(-2, 0, 0).Format("2006-01-02")
+
+	tallies := map[K]*tally{}
+	var keys []K
+	for _, e := range expenses {
+		k := key(e)
+		t, ok := tallies[k]
+		if !ok {
+			t = &tally{first: e.DataDoc, last: e.DataDoc}
+			tallies[k] = t
+			keys = append(keys, k)
+		}
+		t.name = max(t.name, e.NomeEmpresa)
+		t.total += e.Valor
+		t.payments++
+		t.first, t.last = min(t.first, e.DataDoc), max(t.last, e.DataDoc)
+		switch {
+		case e.DataDoc > recent:
+			t.recent += e.Valor
+		case e.DataDoc > previous:
+			t.previous += e.Valor
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if a, b := tallies[keys[i]].total, tallies[keys[j]].total; a != b {
+			return a > b
+		}
+		return keys[i] < keys[j]
+	})
+	return tallies, keys, total
+}
+
+// GetTopVendors implements database.Repository; every dataset expense is a CEAP expense
+func (r Repository) GetTopVendors(politicianID, limit int) ([]models.TopVendor, error) {
+	var expenses []models.FinancialRecord
+	for _, e := range r.d.expenses {
+		if e.PoliticianID == politicianID {
+			expenses = append(expenses, e)
+		}
+	}
+	tallies, cnpjs, total := tallyExpenses(expenses, func(e models.FinancialRecord) string { return e.CNPJ })
+
+	vendors := []models.TopVendor{}
+	for _, cnpj := range cnpjs {
+		if cnpj == "" {
+			continue
+		}
+		t := tallies[cnpj]
+		name := t.name
+		if name == "" {
+			name = cnpj
+		}
+		vendors = append(vendors, models.TopVendor{
+			CNPJCPF:   cnpj,
+			Name:      name,
+			Total:     t.total,
+			Payments:  t.payments,
+			Share:     models.Share(t.total, total),
+			FirstDate: t.first,
+			LastDate:  t.last,
+			Trend:     models.NewCounterpartyTrend(t.recent, t.previous),
+		})
+	}
+	return page(vendors, limit, 0), nil
+}
+
+// GetTopPoliticians implements database.Repository; every dataset expense is a CEAP expense
+func (r Repository) GetTopPoliticians(cnpj string, limit int) ([]models.TopPolitician, error) {
+	var expenses []models.FinancialRecord
+	for _, e := range r.d.expenses {
+		if e.CNPJ == cnpj {
+			expenses = append(expenses, e)
+		}
+	}
+	tallies, ids, total := tallyExpenses(expenses, func(e models.FinancialRecord) int { return e.PoliticianID })
+
+	politicians := []models.TopPolitician{}
+	for _, id := range ids {
+		p, ok := r.d.politician(id)
+		if !ok {
+			continue
+		}
+		t := tallies[id]
+		politicians = append(politicians, models.TopPolitician{
+			PoliticianID: id,
+			Nome:         p.Nome,
+			SiglaPartido: p.SiglaPartido,
+			UF:           p.UF,
+			Total:        t.total,
+			Payments:     t.payments,
+			Share:        models.Share(t.total, total),
+			FirstDate:    t.first,
+			LastDate:     t.last,
+			Trend:        models.NewCounterpartyTrend(t.recent, t.previous),
+		})
+	}
+	return page(politicians, limit, 0), nil
+}
+
+// GetTCURulings implements database.Repository; the dataset has no TCU rulings
+func (Repository) GetTCURulings(filter database.TCURulingFilter, limit, offset int) ([]models.TCURuling, error) {
+	return nil, nil
+}
+
+// GetTCURuling implements database.Repository; the dataset has no TCU rulings
+func (Repository) GetTCURuling(id int) (models.TCURuling, error) {
+	return models.TCURuling{}, sql.ErrNoRows
+}
+
+// GetTCURulingParties implements database.Repository; the dataset has no TCU rulings
+func (Repository) GetTCURulingParties(rulingID int) ([]models.TCURulingParty, error) {
+	return nil, nil
+}
+
+// demoSource is the source system every demo row cites
+const demoSource = "DEMO"
+
+// GetProvenance implements database.Repository: every row comes from the demo dataset, under
+// the table the ETL would have loaded it into
+func (r Repository) GetProvenance(resource, id string) (models.Provenance, error) {
+	provenance := models.Provenance{Resource: resource, ID: id, Records: []models.ProvenanceRecord{}}
+	record := func(table string) {
+		provenance.Records = append(provenance.Records, models.ProvenanceRecord{
+			Table: table, SourceSystem: demoSource, SourceRecordID: id,
+		})
+	}
+	expenses := func(match func(models.FinancialRecord) bool) {
+		rows := 0
+		for _, e := range r.d.expenses {
+			if match(e) {
+				rows++
+			}
+		}
+		if rows > 0 {
+			provenance.Related = append(provenance.Related, models.ProvenanceSource{
+				Table: "unified_financial_records", SourceSystem: demoSource, Rows: rows,
+			})
+		}
+	}
+	n, numeric := strconv.Atoi(id)
+
+	switch resource {
+	case models.ProvenancePolitician:
+		if _, ok := r.d.politician(n); numeric == nil && ok {
+			record("unified_politicians")
+			expenses(func(e models.FinancialRecord) bool { return e.PoliticianID == n })
+		}
+	case models.ProvenanceParty:
+		if _, err := r.GetParty(n); numeric == nil && err == nil {
+			record("political_parties")
+		}
+	case models.ProvenanceCompany:
+		if _, ok := r.d.company(id); ok {
+			record("financial_counterparts")
+			expenses(func(e models.FinancialRecord) bool { return e.CNPJ == id })
+		}
+	case models.ProvenanceSanction:
+		if _, err := r.GetSanction(n); numeric == nil && err == nil {
+			record("vendor_sanctions")
+		}
+	case models.ProvenanceTCURuling:
+	default:
+		return models.Provenance{}, fmt.Errorf("unknown provenance resource %q", resource)
+	}
+
+	if len(provenance.Records) == 0 {
+		return provenance, sql.ErrNoRows
+	}
+	return provenance, nil
+}
@@ -0,0 +1,114 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// ArchiveManifest describes the contents of a dataset archive
+type ArchiveManifest struct {
+	Version     string         `json:"version"`
+	Format      string         `json:"format"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	Rows        map[string]int `json:"rows"`
+}
+
+// Archive writes a zip bundle of dataset files plus a manifest.json
+type Archive struct {
+	zw       *zip.Writer
+	manifest ArchiveManifest
+}
+
+// NewArchive starts an archive for the given dataset version and file format
+func NewArchive(w io.Writer, version, format string) *Archive {
+	return &Archive{
+		zw: zip.NewWriter(w),
+		manifest: ArchiveManifest{
+			Version:     version,
+			Format:      format,
+			GeneratedAt: time.Now(),
+			Rows:        map[string]int{},
+		},
+	}
+}
+
+// Each is a streaming source: it calls fn once per item until fn errors
+type Each[T any] func(fn func(T) error) error
+
+// EachOf adapts an in-memory slice to a streaming source
+func EachOf[T any](items []T) Each[T] {
+	return func(fn func(T) error) error {
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// AddCSV streams every item from each into <name>.csv inside the archive
+func AddCSV[T any](a *Archive, name string, columns []CSVColumn[T], each Each[T]) error {
+	f, err := a.zw.Create(name + ".csv")
+	if err != nil {
+		return err
+	}
+	cw, err := NewCSVWriter(f, columns)
+	if err != nil {
+		return err
+	}
+
+	rows := 0
+	err = each(func(item T) error {
+		rows++
+		return cw.Write(item)
+	})
+	if err != nil {
+		return err
+	}
+
+	a.manifest.Rows[name] = rows
+	return cw.Flush()
+}
+
+// AddJSONL streams every item from each into <name>.jsonl inside the archive
+func AddJSONL[T any](a *Archive, name string, each Each[T]) error {
+	f, err := a.zw.Create(name + ".jsonl")
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+
+	rows := 0
+	err = each(func(item T) error {
+		rows++
+		return enc.Encode(item)
+	})
+	if err != nil {
+		return err
+	}
+
+	a.manifest.Rows[name] = rows
+	return nil
+}
+
+// Manifest returns the manifest accumulated so far
+func (a *Archive) Manifest() ArchiveManifest {
+	return a.manifest
+}
+
+// Close writes manifest.json and finalizes the zip
+func (a *Archive) Close() error {
+	f, err := a.zw.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(a.manifest); err != nil {
+		return err
+	}
+	return a.zw.Close()
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"political-network-api/internal/models"
+	"time"
+)
+
+// AtomFeed describes an Atom 1.0 (RFC 4287) feed. BaseURL makes entry links absolute, as
+// feed readers expect; ID is a stable URN naming the feed.
+type AtomFeed struct {
+	ID       string
+	Title    string
+	Subtitle string
+	BaseURL  string
+	Path     string
+	Entries  []models.FeedEntry
+}
+
+type atomDocument struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   atomAuthor  `xml:"author"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID       string        `xml:"id"`
+	Title    string        `xml:"title"`
+	Updated  string        `xml:"updated"`
+	Link     atomLink      `xml:"link"`
+	Category *atomCategory `xml:"category"`
+	Summary  string        `xml:"summary"`
+}
+
+// WriteAtom writes feed as Atom XML. The feed's updated time is that of its newest entry.
+func WriteAtom(w io.Writer, feed AtomFeed) error {
+	doc := atomDocument{
+		ID:       feed.ID,
+		Title:    feed.Title,
+		Subtitle: feed.Subtitle,
+		Updated:  time.Unix(0, 0).UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: feed.BaseURL + feed.Path},
+		},
+		Author: atomAuthor{Name: "Political Network API"},
+	}
+
+	for _, e := range feed.Entries {
+		updated := e.Updated.UTC().Format(time.RFC3339)
+		if updated > doc.Updated {
+			doc.Updated = updated
+		}
+
+		entry := atomEntry{
+			ID:      feed.ID + ":" + e.Kind + ":" + e.Key,
+			Title:   e.Title,
+			Updated: updated,
+			Link:    atomLink{Rel: "alternate", Type: "application/json", Href: feed.BaseURL + e.Path},
+			Summary: e.Summary,
+		}
+		if e.Category != "" {
+			entry.Category = &atomCategory{Term: e.Category}
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,223 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"political-network-api/internal/models"
+	"strconv"
+	"time"
+)
+
+// CSVColumn describes one exported column of T
+type CSVColumn[T any] struct {
+	Name  string
+	Value func(T) string
+}
+
+// CSVWriter streams values of T as CSV rows, flushing every flushEvery rows
+type CSVWriter[T any] struct {
+	w          *csv.Writer
+	columns    []CSVColumn[T]
+	flushEvery int
+	written    int
+}
+
+// NewCSVWriter writes the header row and returns a writer for the remaining rows
+func NewCSVWriter[T any](w io.Writer, columns []CSVColumn[T]) (*CSVWriter[T], error) {
+	cw := &CSVWriter[T]{w: csv.NewWriter(w), columns: columns, flushEvery: 500}
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Name
+	}
+	if err := cw.w.Write(header); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+// Write appends a row, periodically flushing so large exports stream to the client
+func (cw *CSVWriter[T]) Write(item T) error {
+	row := make([]string, len(cw.columns))
+	for i, col := range cw.columns {
+		row[i] = col.Value(item)
+	}
+	if err := cw.w.Write(row); err != nil {
+		return err
+	}
+
+	cw.written++
+	if cw.written%cw.flushEvery == 0 {
+		cw.w.Flush()
+		return cw.w.Error()
+	}
+	return nil
+}
+
+// Flush writes any buffered rows
+func (cw *CSVWriter[T]) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// WriteCSV writes a header followed by one row per item
+func WriteCSV[T any](w io.Writer, columns []CSVColumn[T], items []T) error {
+	cw, err := NewCSVWriter(w, columns)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := cw.Write(item); err != nil {
+			return err
+		}
+	}
+	return cw.Flush()
+}
+
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func csvMoney(v models.Money) string {
+	return v.String()
+}
+
+// csvOptionalMoney renders unset amounts (e.g. no ?adjust_to) as empty cells
+func csvOptionalMoney(v *models.Money) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+// csvOptionalFloat renders unknown rates as empty cells
+func csvOptionalFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+// csvOptionalInt renders unknown counts (e.g. votes before results are loaded) as empty cells
+func csvOptionalInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+// csvNonZero renders zero (unknown) years as empty cells
+func csvNonZero(v int) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.Itoa(v)
+}
+
+// csvOptionalBool renders unknown flags as empty cells
+func csvOptionalBool(v *bool) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatBool(*v)
+}
+
+// PoliticianColumns is the CSV layout for politicians
+var PoliticianColumns = []CSVColumn[models.Politician]{
+	{"id", func(p models.Politician) string { return strconv.Itoa(p.ID) }},
+	{"nome", func(p models.Politician) string { return p.Nome }},
+	{"cpf", func(p models.Politician) string { return p.CPF }},
+	{"uf", func(p models.Politician) string { return p.UF }},
+	{"sigla_partido", func(p models.Politician) string { return p.SiglaPartido }},
+	{"ultimo_status_situacao", func(p models.Politician) string { return p.UltimoStatusSituacao }},
+	{"ultimo_status_email", func(p models.Politician) string { return p.UltimoStatusEmail }},
+	{"corruption_score", func(p models.Politician) string { return strconv.Itoa(p.CorruptionScore) }},
+	{"financial_records_count", func(p models.Politician) string { return strconv.Itoa(p.FinancialRecordsCount) }},
+	{"url_foto", func(p models.Politician) string { return p.URLFoto }},
+	{"data_nascimento", func(p models.Politician) string { return p.DataNascimento }},
+	{"escolaridade", func(p models.Politician) string { return p.Escolaridade }},
+	{"profissao", func(p models.Politician) string { return p.Profissao }},
+	{"sessoes_plenario", func(p models.Politician) string { return strconv.Itoa(p.SessoesPlenario) }},
+	{"presencas_plenario", func(p models.Politician) string { return strconv.Itoa(p.PresencasPlenario) }},
+	{"taxa_ausencia", func(p models.Politician) string { return csvOptionalFloat(p.TaxaAusencia) }},
+	{"ano_eleicao", func(p models.Politician) string { return csvNonZero(p.AnoEleicao) }},
+	{"votos", func(p models.Politician) string { return csvOptionalInt(p.Votos) }},
+	{"eleito", func(p models.Politician) string { return csvOptionalBool(p.Eleito) }},
+	{"coligacao", func(p models.Politician) string { return p.Coligacao }},
+	{"gasto_campanha", func(p models.Politician) string { return csvOptionalMoney(p.GastoCampanha) }},
+	{"created_at", func(p models.Politician) string { return csvTime(p.CreatedAt) }},
+	{"updated_at", func(p models.Politician) string { return csvTime(p.UpdatedAt) }},
+}
+
+// CompanyColumns is the CSV layout for companies
+var CompanyColumns = []CSVColumn[models.Company]{
+	{"cnpj", func(c models.Company) string { return c.CNPJ }},
+	{"nome_empresa", func(c models.Company) string { return c.NomeEmpresa }},
+	{"transaction_count", func(c models.Company) string { return strconv.Itoa(c.TransactionCount) }},
+	{"total_value", func(c models.Company) string { return csvMoney(c.TotalValue) }},
+	{"cnae", func(c models.Company) string { return c.CNAE }},
+	{"sector", func(c models.Company) string { return c.Sector }},
+	{"created_at", func(c models.Company) string { return csvTime(c.CreatedAt) }},
+	{"updated_at", func(c models.Company) string { return csvTime(c.UpdatedAt) }},
+}
+
+// AdjustedCompanyColumns adds the inflation-adjusted total (?adjust_to)
+var AdjustedCompanyColumns = append(CompanyColumns[:len(CompanyColumns):len(CompanyColumns)],
+	CSVColumn[models.Company]{"total_value_adjusted", func(c models.Company) string { return csvOptionalMoney(c.TotalValueAdjusted) }},
+)
+
+// SanctionColumns is the CSV layout for sanctions
+var SanctionColumns = []CSVColumn[models.Sanction]{
+	{"id", func(s models.Sanction) string { return strconv.Itoa(s.ID) }},
+	{"tipo_sancao", func(s models.Sanction) string { return s.TipoSancao }},
+	{"cnpj", func(s models.Sanction) string { return s.CNPJ }},
+	{"cpf", func(s models.Sanction) string { return s.CPF }},
+	{"valor_multa", func(s models.Sanction) string { return csvMoney(s.ValorMulta) }},
+	{"data_inicio_sancao", func(s models.Sanction) string { return s.DataInicioSancao }},
+	{"data_fim_sancao", func(s models.Sanction) string { return s.DataFimSancao }},
+	{"ativa", func(s models.Sanction) string { return strconv.FormatBool(s.Ativa) }},
+	{"created_at", func(s models.Sanction) string { return csvTime(s.CreatedAt) }},
+}
+
+// FinancialRecordColumns is the CSV layout for expenses
+var FinancialRecordColumns = []CSVColumn[models.FinancialRecord]{
+	{"id", func(f models.FinancialRecord) string { return strconv.Itoa(f.ID) }},
+	{"politician_id", func(f models.FinancialRecord) string { return strconv.Itoa(f.PoliticianID) }},
+	{"cnpj", func(f models.FinancialRecord) string { return f.CNPJ }},
+	{"nome_empresa", func(f models.FinancialRecord) string { return f.NomeEmpresa }},
+	{"valor", func(f models.FinancialRecord) string { return csvMoney(f.Valor) }},
+	{"data_doc", func(f models.FinancialRecord) string { return f.DataDoc }},
+	{"created_at", func(f models.FinancialRecord) string { return csvTime(f.CreatedAt) }},
+}
+
+// AdjustedFinancialRecordColumns adds the inflation-adjusted amount (?adjust_to)
+var AdjustedFinancialRecordColumns = append(FinancialRecordColumns[:len(FinancialRecordColumns):len(FinancialRecordColumns)],
+	CSVColumn[models.FinancialRecord]{"valor_ajustado", func(f models.FinancialRecord) string { return csvOptionalMoney(f.ValorAjustado) }},
+)
+
+// PartyColumns is the CSV layout for parties
+var PartyColumns = []CSVColumn[models.Party]{
+	{"id", func(p models.Party) string { return strconv.Itoa(p.ID) }},
+	{"nome", func(p models.Party) string { return p.Nome }},
+	{"sigla", func(p models.Party) string { return p.Sigla }},
+	{"numero_eleitoral", func(p models.Party) string { return strconv.Itoa(p.NumeroEleitoral) }},
+	{"status", func(p models.Party) string { return p.Status }},
+	{"lider_atual", func(p models.Party) string { return p.LiderAtual }},
+	{"total_membros", func(p models.Party) string { return strconv.Itoa(p.TotalMembros) }},
+	{"total_efetivos", func(p models.Party) string { return strconv.Itoa(p.TotalEfetivos) }},
+	{"legislatura_id", func(p models.Party) string { return strconv.Itoa(p.LegislaturaID) }},
+}
+
+// ConnectionColumns is the CSV layout for network connections
+var ConnectionColumns = []CSVColumn[models.Connection]{
+	{"source_id", func(c models.Connection) string { return c.SourceID }},
+	{"target_id", func(c models.Connection) string { return c.TargetID }},
+	{"type", func(c models.Connection) string { return c.Type }},
+	{"value", func(c models.Connection) string { return csvMoney(c.Value) }},
+	{"strength", func(c models.Connection) string { return formatFloat(c.Strength) }},
+	{"start_date", func(c models.Connection) string { return c.StartDate }},
+	{"end_date", func(c models.Connection) string { return c.EndDate }},
+}
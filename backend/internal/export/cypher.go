@@ -0,0 +1,220 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"political-network-api/internal/models"
+	"strings"
+)
+
+// cypherLabels maps node types to Neo4j labels
+var cypherLabels = map[models.NodeType]string{
+	models.NodeTypePolitician:   "Politician",
+	models.NodeTypeParty:        "Party",
+	models.NodeTypeCompany:      "Company",
+	models.NodeTypeCompanyGroup: "CompanyGroup",
+	models.NodeTypeSanction:     "Sanction",
+	models.NodeTypeTopic:        "Topic",
+	models.NodeTypeFront:        "Front",
+	models.NodeTypeTCURuling:    "TCURuling",
+	models.NodeTypePublicBank:   "PublicBank",
+}
+
+// cypherProp is a single ordered node or relationship property
+type cypherProp struct {
+	key   string
+	value interface{}
+}
+
+// CypherWriter emits Cypher statements that recreate the entity/edge model in Neo4j.
+// Output is meant to be piped through cypher-shell; statements are batched in transactions.
+type CypherWriter struct {
+	w         *bufio.Writer
+	batchSize int
+	pending   int
+	err       error
+}
+
+// NewCypherWriter creates a writer committing every batchSize statements
+func NewCypherWriter(w io.Writer, batchSize int) *CypherWriter {
+	return &CypherWriter{w: bufio.NewWriter(w), batchSize: batchSize}
+}
+
+// WriteSchema emits uniqueness constraints so edge MATCHes use an index
+func (cw *CypherWriter) WriteSchema() {
+	for _, t := range []models.NodeType{models.NodeTypePolitician, models.NodeTypeParty, models.NodeTypeCompany, models.NodeTypeCompanyGroup, models.NodeTypeSanction, models.NodeTypeTopic, models.NodeTypeFront, models.NodeTypeTCURuling, models.NodeTypePublicBank} {
+		label := cypherLabels[t]
+		cw.printf("CREATE CONSTRAINT %s_id IF NOT EXISTS FOR (n:%s) REQUIRE n.id IS UNIQUE;\n", strings.ToLower(label), label)
+	}
+}
+
+// WritePolitician emits a CREATE for a politician node
+func (cw *CypherWriter) WritePolitician(p models.Politician) {
+	cw.writeNode(p, fmt.Sprint(p.ID), []cypherProp{
+		{"nome", p.Nome}, {"cpf", p.CPF}, {"uf", p.UF}, {"sigla_partido", p.SiglaPartido},
+		{"situacao", p.UltimoStatusSituacao}, {"corruption_score", p.CorruptionScore},
+	})
+}
+
+// WriteParty emits a CREATE for a party node
+func (cw *CypherWriter) WriteParty(p models.Party) {
+	cw.writeNode(p, fmt.Sprint(p.ID), []cypherProp{
+		{"nome", p.Nome}, {"sigla", p.Sigla}, {"numero_eleitoral", p.NumeroEleitoral},
+		{"status", p.Status}, {"total_membros", p.TotalMembros},
+	})
+}
+
+// WriteCompany emits a CREATE for a company node
+func (cw *CypherWriter) WriteCompany(c models.Company) {
+	cw.writeNode(c, c.CNPJ, []cypherProp{
+		{"cnpj", c.CNPJ}, {"nome_empresa", c.NomeEmpresa},
+		{"transaction_count", c.TransactionCount}, {"total_value", c.TotalValue},
+	})
+}
+
+// WriteCompanyGroup emits a CREATE for a corporate group node
+func (cw *CypherWriter) WriteCompanyGroup(g models.CompanyGroup) {
+	cw.writeNode(g, g.CNPJRoot, []cypherProp{
+		{"cnpj_root", g.CNPJRoot}, {"nome_empresa", g.NomeEmpresa}, {"branch_count", g.BranchCount},
+		{"transaction_count", g.TransactionCount}, {"total_value", g.TotalValue},
+	})
+}
+
+// WriteSanction emits a CREATE for a sanction node
+func (cw *CypherWriter) WriteSanction(s models.Sanction) {
+	cw.writeNode(s, fmt.Sprint(s.ID), []cypherProp{
+		{"tipo_sancao", s.TipoSancao}, {"cnpj", s.CNPJ},
+		{"valor_multa", s.ValorMulta}, {"data_inicio_sancao", s.DataInicioSancao},
+	})
+}
+
+// WriteTopic emits a CREATE for a speech topic node
+func (cw *CypherWriter) WriteTopic(t models.Topic) {
+	cw.writeNode(t, t.Slug, []cypherProp{
+		{"slug", t.Slug}, {"topic", t.Topic}, {"speeches", t.Speeches}, {"politicians", t.Politicians},
+	})
+}
+
+// WriteFront emits a CREATE for a frente parlamentar node
+func (cw *CypherWriter) WriteFront(f models.Front) {
+	cw.writeNode(f, fmt.Sprint(f.ID), []cypherProp{
+		{"titulo", f.Titulo}, {"legislatura_id", f.LegislaturaID}, {"member_count", f.MemberCount},
+	})
+}
+
+// WriteTCURuling emits a CREATE for a TCU ruling node
+func (cw *CypherWriter) WriteTCURuling(r models.TCURuling) {
+	cw.writeNode(r, fmt.Sprint(r.ID), []cypherProp{
+		{"key", r.Key}, {"titulo", r.Titulo}, {"colegiado", r.Colegiado},
+		{"data_sessao", r.DataSessao}, {"url", r.URL},
+	})
+}
+
+// WritePublicBank emits a CREATE for a public bank node
+func (cw *CypherWriter) WritePublicBank(b models.PublicBank) {
+	cw.writeNode(b, b.Slug, []cypherProp{
+		{"nome", b.Nome}, {"loan_count", b.LoanCount}, {"company_count", b.CompanyCount},
+		{"valor_desembolsado", b.ValorDesembolsado},
+	})
+}
+
+// WriteConnection emits a MATCH/CREATE for a relationship between two existing nodes
+func (cw *CypherWriter) WriteConnection(c models.Connection) {
+	sourceLabel, okSource := labelForID(c.SourceID)
+	targetLabel, okTarget := labelForID(c.TargetID)
+	if !okSource || !okTarget {
+		return
+	}
+
+	props := []cypherProp{{"value", c.Value}, {"strength", c.Strength}}
+	if c.StartDate != "" {
+		props = append(props, cypherProp{"start_date", c.StartDate})
+	}
+	if c.EndDate != "" {
+		props = append(props, cypherProp{"end_date", c.EndDate})
+	}
+
+	cw.statement(fmt.Sprintf(
+		"MATCH (a:%s {id: %s}), (b:%s {id: %s}) CREATE (a)-[:%s %s]->(b);",
+		sourceLabel, cypherValue(c.SourceID), targetLabel, cypherValue(c.TargetID),
+		strings.ToUpper(c.Type),
+		cypherMap(props),
+	))
+}
+
+// Close commits the open transaction and flushes the output
+func (cw *CypherWriter) Close() error {
+	if cw.pending > 0 {
+		cw.printf(":commit\n")
+	}
+	if cw.err != nil {
+		return cw.err
+	}
+	return cw.w.Flush()
+}
+
+// Err reports the first write error encountered
+func (cw *CypherWriter) Err() error {
+	return cw.err
+}
+
+func (cw *CypherWriter) writeNode(data models.NodeData, key string, props []cypherProp) {
+	props = append([]cypherProp{{"id", string(data.NodeType()) + "_" + key}}, props...)
+	cw.statement(fmt.Sprintf("CREATE (:%s %s);", cypherLabels[data.NodeType()], cypherMap(props)))
+}
+
+// statement writes one statement, opening and committing transactions in batches
+func (cw *CypherWriter) statement(stmt string) {
+	if cw.pending == 0 {
+		cw.printf(":begin\n")
+	}
+	cw.printf("%s\n", stmt)
+	cw.pending++
+	if cw.pending >= cw.batchSize {
+		cw.printf(":commit\n")
+		cw.pending = 0
+	}
+}
+
+func (cw *CypherWriter) printf(format string, args ...interface{}) {
+	if cw.err != nil {
+		return
+	}
+	_, cw.err = fmt.Fprintf(cw.w, format, args...)
+}
+
+// labelForID resolves the Neo4j label from a "<type>_<key>" node ID. Keys never contain
+// underscores but types may (company_group), so the split is at the last one.
+func labelForID(id string) (string, bool) {
+	i := strings.LastIndex(id, "_")
+	if i < 0 {
+		return "", false
+	}
+	prefix := id[:i]
+	label, ok := cypherLabels[models.NodeType(prefix)]
+	return label, ok
+}
+
+func cypherMap(props []cypherProp) string {
+	parts := make([]string, 0, len(props))
+	for _, p := range props {
+		parts = append(parts, p.key+": "+cypherValue(p.value))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+var cypherEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+func cypherValue(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return "'" + cypherEscaper.Replace(val) + "'"
+	case float64:
+		return formatFloat(val)
+	case models.Money:
+		return val.String()
+	default:
+		return fmt.Sprint(val)
+	}
+}
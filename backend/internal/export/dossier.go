@@ -0,0 +1,182 @@
+package export
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"political-network-api/internal/models"
+	"strconv"
+)
+
+// dossierTemplate renders a politician report as a standalone page that prints well
+var dossierTemplate = template.Must(template.New("dossier").Funcs(template.FuncMap{
+	"money": func(m models.Money) string { return "R$ " + m.String() },
+}).Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<title>Dossier: {{.Politician.Nome}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; max-width: 960px; margin: 2em auto; color: #1e293b; }
+h1 { margin-bottom: 0; }
+h2 { border-bottom: 1px solid #cbd5e1; padding-bottom: .2em; margin-top: 1.5em; }
+table { border-collapse: collapse; width: 100%; font-size: .9em; }
+th, td { text-align: left; padding: .3em .5em; border-bottom: 1px solid #e2e8f0; }
+td.n, th.n { text-align: right; }
+.meta { color: #64748b; font-size: .85em; }
+</style>
+</head>
+<body>
+<h1>{{.Politician.Nome}}</h1>
+<p class="meta">{{.Politician.SiglaPartido}}-{{.Politician.UF}} · ID {{.Politician.ID}} · generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}{{if .DatasetVersion}} · dataset version {{.DatasetVersion}}{{end}}</p>
+
+<h2>Profile</h2>
+<table>
+<tr><th>Status</th><td>{{.Politician.UltimoStatusSituacao}}</td></tr>
+{{if .Politician.Profissao}}<tr><th>Occupation</th><td>{{.Politician.Profissao}}</td></tr>{{end}}
+{{if .Politician.Escolaridade}}<tr><th>Education</th><td>{{.Politician.Escolaridade}}</td></tr>{{end}}
+{{if .Politician.AnoEleicao}}<tr><th>Latest election</th><td>{{.Politician.AnoEleicao}}{{if .Politician.Votos}}, {{.Politician.Votos}} votes{{end}}</td></tr>{{end}}
+{{if .Politician.TaxaAusencia}}<tr><th>Plenary absence rate</th><td>{{.Politician.TaxaAusencia}}%</td></tr>{{end}}
+<tr><th>Corruption risk score</th><td>{{.Score.Score}} / 100</td></tr>
+</table>
+
+<h2>Score Breakdown</h2>
+<table>
+<tr><th>Factor</th><th class="n">Points</th><th>Detail</th></tr>
+{{range .Score.Factors}}<tr><td>{{.Factor}}</td><td class="n">{{.Points}} / {{.MaxPoints}}</td><td>{{.Detail}}</td></tr>
+{{end}}<tr><th>Total on current data</th><th class="n">{{.Score.Computed}}</th><td></td></tr>
+</table>
+
+<h2>Spending</h2>
+<p>{{money .Spending.Total}} in {{.Spending.Records}} records to {{.Spending.Vendors}} vendors{{if .Spending.FirstDate}}, {{.Spending.FirstDate}} to {{.Spending.LastDate}}{{end}}.</p>
+{{if .Spending.ByYear}}<table>
+<tr><th>Year</th><th class="n">Records</th><th class="n">Amount</th></tr>
+{{range .Spending.ByYear}}<tr><td>{{.Year}}</td><td class="n">{{.Records}}</td><td class="n">{{money .Amount}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Spending.TopVendors}}<h3>Top Vendors</h3>
+<table>
+<tr><th>CNPJ/CPF</th><th>Name</th><th class="n">Payments</th><th class="n">Total</th><th class="n">Share</th></tr>
+{{range .Spending.TopVendors}}<tr><td>{{.CNPJ}}</td><td>{{.Name}}</td><td class="n">{{.Payments}}</td><td class="n">{{money .Total}}</td><td class="n">{{printf "%.2f" .Share}}%</td></tr>
+{{end}}</table>{{end}}
+
+<h2>Payments to Sanctioned Vendors</h2>
+{{if .SanctionedVendorPayments}}<table>
+<tr><th>CNPJ</th><th>Company</th><th>Sanction</th><th>In force</th><th class="n">Payments</th><th class="n">Total</th></tr>
+{{range .SanctionedVendorPayments}}<tr><td>{{.CNPJ}}</td><td>{{.Company}}</td><td>{{.Registry}} {{.SanctionType}}</td><td>{{.SanctionFrom}} – {{.SanctionTo}}</td><td class="n">{{.Payments}}</td><td class="n">{{money .Total}}</td></tr>
+{{end}}</table>{{else}}<p>None found.</p>{{end}}
+
+<h2>Connections</h2>
+<table>
+<tr><th>Vendors paid</th><td>{{.Connections.Vendors}}</td></tr>
+<tr><th>Sanctioned vendors paid</th><td>{{.Connections.SanctionedVendors}}</td></tr>
+<tr><th>Party memberships</th><td>{{.Connections.Memberships}}</td></tr>
+<tr><th>Parliamentary fronts</th><td>{{.Connections.Fronts}}</td></tr>
+<tr><th>Confirmed relatives</th><td>{{.Connections.Relatives}}</td></tr>
+<tr><th>TCU rulings</th><td>{{.Connections.TCURulings}}</td></tr>
+<tr><th>Sanctions against the politician</th><td>{{.Connections.Sanctions}}</td></tr>
+</table>
+</body>
+</html>
+`))
+
+// WritePoliticianReportHTML renders a politician report as an HTML page
+func WritePoliticianReportHTML(w io.Writer, report models.PoliticianReport) error {
+	return dossierTemplate.Execute(w, report)
+}
+
+// WritePoliticianReportPDF renders a politician report as a PDF document with the sections of
+// the HTML page
+func WritePoliticianReportPDF(w io.Writer, report models.PoliticianReport) error {
+	p := report.Politician
+	doc := NewPDF()
+
+	doc.Title("Dossier: " + p.Nome)
+	meta := fmt.Sprintf("%s-%s · ID %d · generated %s", p.SiglaPartido, p.UF, p.ID, report.GeneratedAt.Format("2006-01-02 15:04 MST"))
+	if report.DatasetVersion != 0 {
+		meta += fmt.Sprintf(" · dataset version %d", report.DatasetVersion)
+	}
+	doc.Text(meta)
+
+	doc.Heading("Profile")
+	profile := [][]string{{"Status", p.UltimoStatusSituacao}}
+	if p.Profissao != "" {
+		profile = append(profile, []string{"Occupation", p.Profissao})
+	}
+	if p.Escolaridade != "" {
+		profile = append(profile, []string{"Education", p.Escolaridade})
+	}
+	if p.AnoEleicao != 0 {
+		election := strconv.Itoa(p.AnoEleicao)
+		if p.Votos != nil {
+			election += fmt.Sprintf(", %d votes", *p.Votos)
+		}
+		profile = append(profile, []string{"Latest election", election})
+	}
+	if p.TaxaAusencia != nil {
+		profile = append(profile, []string{"Plenary absence rate", fmt.Sprintf("%.2f%%", *p.TaxaAusencia)})
+	}
+	profile = append(profile, []string{"Corruption risk score", fmt.Sprintf("%d / 100", report.Score.Score)})
+	for _, row := range profile {
+		doc.Row(row, []float64{0.35, 0.65}, false)
+	}
+
+	doc.Heading("Score Breakdown")
+	widths := []float64{0.3, 0.12, 0.58}
+	doc.Row([]string{"Factor", "Points", "Detail"}, widths, true)
+	for _, f := range report.Score.Factors {
+		doc.Row([]string{f.Factor, fmt.Sprintf("%d / %d", f.Points, f.MaxPoints), f.Detail}, widths, false)
+	}
+	doc.Row([]string{"Total on current data", strconv.Itoa(report.Score.Computed), ""}, widths, true)
+
+	s := report.Spending
+	doc.Heading("Spending")
+	summary := fmt.Sprintf("R$ %s in %d records to %d vendors", s.Total, s.Records, s.Vendors)
+	if s.FirstDate != "" {
+		summary += fmt.Sprintf(", %s to %s", s.FirstDate, s.LastDate)
+	}
+	doc.Text(summary + ".")
+	if len(s.ByYear) > 0 {
+		widths := []float64{0.2, 0.2, 0.3}
+		doc.Row([]string{"Year", "Records", "Amount"}, widths, true)
+		for _, y := range s.ByYear {
+			doc.Row([]string{strconv.Itoa(y.Year), strconv.Itoa(y.Records), "R$ " + y.Amount.String()}, widths, false)
+		}
+	}
+	if len(s.TopVendors) > 0 {
+		doc.Heading("Top Vendors")
+		widths := []float64{0.2, 0.4, 0.1, 0.18, 0.12}
+		doc.Row([]string{"CNPJ/CPF", "Name", "Payments", "Total", "Share"}, widths, true)
+		for _, v := range s.TopVendors {
+			doc.Row([]string{v.CNPJ, v.Name, strconv.Itoa(v.Payments), "R$ " + v.Total.String(), fmt.Sprintf("%.2f%%", v.Share)}, widths, false)
+		}
+	}
+
+	doc.Heading("Payments to Sanctioned Vendors")
+	if len(report.SanctionedVendorPayments) == 0 {
+		doc.Text("None found.")
+	} else {
+		widths := []float64{0.18, 0.26, 0.16, 0.14, 0.08, 0.18}
+		doc.Row([]string{"CNPJ", "Company", "Sanction", "In force", "Payments", "Total"}, widths, true)
+		for _, sp := range report.SanctionedVendorPayments {
+			doc.Row([]string{sp.CNPJ, sp.Company, sp.Registry + " " + sp.SanctionType,
+				sp.SanctionFrom + " – " + sp.SanctionTo, strconv.Itoa(sp.Payments), "R$ " + sp.Total.String()}, widths, false)
+		}
+	}
+
+	cs := report.Connections
+	doc.Heading("Connections")
+	for _, row := range [][]string{
+		{"Vendors paid", strconv.Itoa(cs.Vendors)},
+		{"Sanctioned vendors paid", strconv.Itoa(cs.SanctionedVendors)},
+		{"Party memberships", strconv.Itoa(cs.Memberships)},
+		{"Parliamentary fronts", strconv.Itoa(cs.Fronts)},
+		{"Confirmed relatives", strconv.Itoa(cs.Relatives)},
+		{"TCU rulings", strconv.Itoa(cs.TCURulings)},
+		{"Sanctions against the politician", strconv.Itoa(cs.Sanctions)},
+	} {
+		doc.Row(row, []float64{0.5, 0.5}, false)
+	}
+
+	_, err := doc.WriteTo(w)
+	return err
+}
@@ -0,0 +1,139 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"political-network-api/internal/models"
+	"strconv"
+	"time"
+)
+
+type gexfDocument struct {
+	XMLName xml.Name  `xml:"gexf"`
+	XMLNS   string    `xml:"xmlns,attr"`
+	VizNS   string    `xml:"xmlns:viz,attr"`
+	Version string    `xml:"version,attr"`
+	Meta    gexfMeta  `xml:"meta"`
+	Graph   gexfGraph `xml:"graph"`
+}
+
+type gexfMeta struct {
+	LastModified string `xml:"lastmodifieddate,attr"`
+	Creator      string `xml:"creator"`
+	Description  string `xml:"description"`
+}
+
+type gexfGraph struct {
+	DefaultEdgeType string           `xml:"defaultedgetype,attr"`
+	Mode            string           `xml:"mode,attr"`
+	TimeFormat      string           `xml:"timeformat,attr,omitempty"`
+	Attributes      []gexfAttributes `xml:"attributes"`
+	Nodes           []gexfNode       `xml:"nodes>node"`
+	Edges           []gexfEdge       `xml:"edges>edge"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttribute struct {
+	ID    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfNode struct {
+	ID        string         `xml:"id,attr"`
+	Label     string         `xml:"label,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+	Size      gexfVizSize    `xml:"viz:size"`
+	Color     gexfVizColor   `xml:"viz:color"`
+}
+
+type gexfEdge struct {
+	ID        string         `xml:"id,attr"`
+	Source    string         `xml:"source,attr"`
+	Target    string         `xml:"target,attr"`
+	Weight    string         `xml:"weight,attr"`
+	Label     string         `xml:"label,attr"`
+	Start     string         `xml:"start,attr,omitempty"`
+	End       string         `xml:"end,attr,omitempty"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfAttValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type gexfVizSize struct {
+	Value string `xml:"value,attr"`
+}
+
+type gexfVizColor struct {
+	R int `xml:"r,attr"`
+	G int `xml:"g,attr"`
+	B int `xml:"b,attr"`
+}
+
+// WriteGEXF writes the network as a GEXF 1.3 document
+func WriteGEXF(w io.Writer, network *models.NetworkResponse) error {
+	doc := gexfDocument{
+		XMLNS:   "http://gexf.net/1.3",
+		VizNS:   "http://gexf.net/1.3/viz",
+		Version: "1.3",
+		Meta: gexfMeta{
+			LastModified: time.Now().Format("2006-01-02"),
+			Creator:      "Political Network API",
+			Description:  "Brazilian political network: politicians, parties, companies and sanctions",
+		},
+		Graph: gexfGraph{
+			DefaultEdgeType: "directed",
+			Mode:            "static",
+			Attributes: []gexfAttributes{
+				{Class: "node", Attributes: []gexfAttribute{
+					{ID: "type", Title: "type", Type: "string"},
+					{ID: "corruption_score", Title: "corruption_score", Type: "integer"},
+				}},
+				{Class: "edge", Attributes: []gexfAttribute{
+					{ID: "value", Title: "value", Type: "double"},
+				}},
+			},
+		},
+	}
+
+	for _, n := range network.Nodes {
+		r, g, b := parseHexColor(n.Color)
+		doc.Graph.Nodes = append(doc.Graph.Nodes, gexfNode{
+			ID:    n.ID,
+			Label: n.Name,
+			AttValues: []gexfAttValue{
+				{For: "type", Value: string(n.Type)},
+				{For: "corruption_score", Value: strconv.Itoa(n.CorruptionScore)},
+			},
+			Size:  gexfVizSize{Value: formatFloat(n.Size)},
+			Color: gexfVizColor{R: r, G: g, B: b},
+		})
+	}
+
+	for i, e := range graphEdges(network) {
+		doc.Graph.Edges = append(doc.Graph.Edges, gexfEdge{
+			ID:        strconv.Itoa(i),
+			Source:    e.SourceID,
+			Target:    e.TargetID,
+			Weight:    formatFloat(e.Strength),
+			Label:     e.Type,
+			Start:     e.StartDate,
+			End:       e.EndDate,
+			AttValues: []gexfAttValue{{For: "value", Value: e.Value.String()}},
+		})
+
+		// Time-ranged edges (former party memberships) make the graph dynamic for Gephi's timeline
+		if e.StartDate != "" || e.EndDate != "" {
+			doc.Graph.Mode, doc.Graph.TimeFormat = "dynamic", "date"
+		}
+	}
+
+	return encodeXML(w, doc)
+}
@@ -0,0 +1,40 @@
+package export
+
+import (
+	"political-network-api/internal/models"
+	"strconv"
+)
+
+// graphEdges returns the links whose endpoints are both present as nodes.
+// Graph tools such as Gephi and Cytoscape reject edges to unknown nodes.
+func graphEdges(network *models.NetworkResponse) []models.Connection {
+	known := make(map[string]bool, len(network.Nodes))
+	for _, n := range network.Nodes {
+		known[n.ID] = true
+	}
+
+	var edges []models.Connection
+	for _, l := range network.Links {
+		if known[l.SourceID] && known[l.TargetID] {
+			edges = append(edges, l)
+		}
+	}
+	return edges
+}
+
+// formatFloat renders a float without trailing zeros
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// parseHexColor converts a #rrggbb color into its RGB components
+func parseHexColor(hex string) (r, g, b int) {
+	if len(hex) != 7 || hex[0] != '#' {
+		return 128, 128, 128
+	}
+	v, err := strconv.ParseUint(hex[1:], 16, 32)
+	if err != nil {
+		return 128, 128, 128
+	}
+	return int(v >> 16 & 0xff), int(v >> 8 & 0xff), int(v & 0xff)
+}
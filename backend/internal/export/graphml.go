@@ -0,0 +1,117 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"political-network-api/internal/models"
+	"strconv"
+)
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+var graphMLKeys = []graphMLKey{
+	{ID: "n_type", For: "node", AttrName: "type", AttrType: "string"},
+	{ID: "n_label", For: "node", AttrName: "label", AttrType: "string"},
+	{ID: "n_size", For: "node", AttrName: "size", AttrType: "double"},
+	{ID: "n_color", For: "node", AttrName: "color", AttrType: "string"},
+	{ID: "n_score", For: "node", AttrName: "corruption_score", AttrType: "int"},
+	{ID: "e_type", For: "edge", AttrName: "type", AttrType: "string"},
+	{ID: "e_value", For: "edge", AttrName: "value", AttrType: "double"},
+	{ID: "e_weight", For: "edge", AttrName: "weight", AttrType: "double"},
+	{ID: "e_start", For: "edge", AttrName: "start_date", AttrType: "string"},
+	{ID: "e_end", For: "edge", AttrName: "end_date", AttrType: "string"},
+}
+
+// WriteGraphML writes the network as a GraphML document
+func WriteGraphML(w io.Writer, network *models.NetworkResponse) error {
+	doc := graphMLDocument{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys:  graphMLKeys,
+		Graph: graphMLGraph{ID: "political-network", EdgeDefault: "directed"},
+	}
+
+	for _, n := range network.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: n.ID,
+			Data: []graphMLData{
+				{Key: "n_type", Value: string(n.Type)},
+				{Key: "n_label", Value: n.Name},
+				{Key: "n_size", Value: formatFloat(n.Size)},
+				{Key: "n_color", Value: n.Color},
+				{Key: "n_score", Value: strconv.Itoa(n.CorruptionScore)},
+			},
+		})
+	}
+
+	for i, e := range graphEdges(network) {
+		data := []graphMLData{
+			{Key: "e_type", Value: e.Type},
+			{Key: "e_value", Value: e.Value.String()},
+			{Key: "e_weight", Value: formatFloat(e.Strength)},
+		}
+		if e.StartDate != "" {
+			data = append(data, graphMLData{Key: "e_start", Value: e.StartDate})
+		}
+		if e.EndDate != "" {
+			data = append(data, graphMLData{Key: "e_end", Value: e.EndDate})
+		}
+
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			ID:     "e" + strconv.Itoa(i),
+			Source: e.SourceID,
+			Target: e.TargetID,
+			Data:   data,
+		})
+	}
+
+	return encodeXML(w, doc)
+}
+
+// encodeXML writes an indented XML document with its header
+func encodeXML(w io.Writer, doc interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
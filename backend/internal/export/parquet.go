@@ -0,0 +1,99 @@
+package export
+
+import (
+	"io"
+	"political-network-api/internal/models"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetBatchSize is the number of rows buffered before each write
+const parquetBatchSize = 4096
+
+// Amounts are DECIMAL(18,2) over int64 centavos, matching models.Money
+type financialRecordRow struct {
+	ID           int64     `parquet:"id"`
+	PoliticianID int64     `parquet:"politician_id"`
+	CNPJ         string    `parquet:"cnpj,dict"`
+	NomeEmpresa  string    `parquet:"nome_empresa,dict"`
+	Valor        int64     `parquet:"valor,decimal(2:18)"`
+	DataDoc      int32     `parquet:"data_doc,date,optional"`
+	CreatedAt    time.Time `parquet:"created_at,timestamp(millisecond)"`
+}
+
+type connectionRow struct {
+	SourceID string  `parquet:"source_id,dict"`
+	TargetID string  `parquet:"target_id,dict"`
+	Type     string  `parquet:"type,dict"`
+	Value    int64   `parquet:"value,decimal(2:18)"`
+	Strength float64 `parquet:"strength"`
+}
+
+// WriteFinancialRecordsParquet writes expenses as a snappy-compressed parquet file
+func WriteFinancialRecordsParquet(w io.Writer, each Each[models.FinancialRecord]) error {
+	return writeParquet(w, each, func(f models.FinancialRecord) financialRecordRow {
+		return financialRecordRow{
+			ID:           int64(f.ID),
+			PoliticianID: int64(f.PoliticianID),
+			CNPJ:         f.CNPJ,
+			NomeEmpresa:  f.NomeEmpresa,
+			Valor:        int64(f.Valor),
+			DataDoc:      parseDate(f.DataDoc),
+			CreatedAt:    f.CreatedAt,
+		}
+	})
+}
+
+// WriteConnectionsParquet writes network connections as a snappy-compressed parquet file
+func WriteConnectionsParquet(w io.Writer, each Each[models.Connection]) error {
+	return writeParquet(w, each, func(c models.Connection) connectionRow {
+		return connectionRow{
+			SourceID: c.SourceID,
+			TargetID: c.TargetID,
+			Type:     c.Type,
+			Value:    int64(c.Value),
+			Strength: c.Strength,
+		}
+	})
+}
+
+// parseDate converts an ISO date into days since the Unix epoch (parquet DATE).
+// Invalid dates become 0, which the optional column writes as null.
+func parseDate(s string) int32 {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return 0
+	}
+	return int32(t.Unix() / 86400)
+}
+
+// writeParquet converts each item to a row type R and writes them in batches
+func writeParquet[M any, R any](w io.Writer, each Each[M], convert func(M) R) error {
+	pw := parquet.NewGenericWriter[R](w, parquet.Compression(&parquet.Snappy))
+	batch := make([]R, 0, parquetBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		_, err := pw.Write(batch)
+		batch = batch[:0]
+		return err
+	}
+
+	err := each(func(item M) error {
+		batch = append(batch, convert(item))
+		if len(batch) == cap(batch) {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return pw.Close()
+}
@@ -0,0 +1,177 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// A4 pages in points, with 2cm margins
+const (
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+	pdfMargin     = 56.0
+)
+
+// pdfCharWidth approximates Helvetica's average glyph width as a fraction of the font size, to
+// wrap lines without the font metrics
+const pdfCharWidth = 0.5
+
+// PDF lays out text on A4 pages in the standard Helvetica fonts, which every reader has, so
+// documents need no embedded fonts. Text is encoded as WinAnsi, which covers Portuguese.
+type PDF struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+// NewPDF starts a document with one empty page
+func NewPDF() *PDF {
+	p := &PDF{}
+	p.newPage()
+	return p
+}
+
+func (p *PDF) newPage() {
+	p.pages = append(p.pages, &bytes.Buffer{})
+	p.y = pdfPageHeight - pdfMargin
+}
+
+// line writes one line of text at x, moving to a new page when the current one is full
+func (p *PDF) line(text string, x, size float64, bold bool) {
+	if p.y-size < pdfMargin {
+		p.newPage()
+	}
+	p.y -= size * 1.4
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(p.pages[len(p.pages)-1], "BT /%s %.1f Tf %.1f %.1f Td (%s) Tj ET\n", font, size, x, p.y, pdfString(text))
+}
+
+// wrap splits text into lines fitting width at size
+func wrap(text string, width, size float64) []string {
+	limit := int(width / (size * pdfCharWidth))
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		current := ""
+		for _, word := range strings.Fields(paragraph) {
+			switch {
+			case current == "":
+				current = word
+			case len([]rune(current))+1+len([]rune(word)) <= limit:
+				current += " " + word
+			default:
+				lines = append(lines, current)
+				current = word
+			}
+		}
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// Title writes the document title
+func (p *PDF) Title(text string) {
+	for _, l := range wrap(text, pdfPageWidth-2*pdfMargin, 18) {
+		p.line(l, pdfMargin, 18, true)
+	}
+}
+
+// Heading writes a section heading, after some space
+func (p *PDF) Heading(text string) {
+	p.y -= 10
+	p.line(text, pdfMargin, 13, true)
+}
+
+// Text writes a paragraph, wrapped to the page width
+func (p *PDF) Text(text string) {
+	for _, l := range wrap(text, pdfPageWidth-2*pdfMargin, 10) {
+		p.line(l, pdfMargin, 10, false)
+	}
+}
+
+// Row writes a table row, one cell per column; widths are fractions of the page width and
+// cells are cut to fit them. A bold row is a header.
+func (p *PDF) Row(cells []string, widths []float64, bold bool) {
+	if p.y-10 < pdfMargin {
+		p.newPage()
+	}
+	y := p.y
+	x := pdfMargin
+	for i, cell := range cells {
+		width := widths[i] * (pdfPageWidth - 2*pdfMargin)
+		if limit := int(width/(9*pdfCharWidth)) - 1; len([]rune(cell)) > limit && limit > 1 {
+			cell = string([]rune(cell)[:limit-1]) + "…"
+		}
+		p.y = y
+		p.line(cell, x, 9, bold)
+		x += width
+	}
+}
+
+// pdfEncoding is WinAnsiEncoding, the encoding of the standard fonts
+var pdfEncoding = charmap.Windows1252.NewEncoder()
+
+// pdfString encodes text as the body of a PDF literal string. Characters WinAnsi lacks become ?.
+func pdfString(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		encoded, err := pdfEncoding.String(string(r))
+		if err != nil || len(encoded) != 1 {
+			encoded = "?"
+		}
+		switch c := encoded[0]; c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// WriteTo writes the document, numbering its pages in the footer
+func (p *PDF) WriteTo(w io.Writer) (int64, error) {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-4 are the catalog, the page tree and the fonts; each page then takes two
+	// objects, the page and its content stream
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range p.pages {
+		content := page.String() + fmt.Sprintf("BT /F1 8.0 Tf %.1f %.1f Td (%d / %d) Tj ET\n",
+			pdfPageWidth-pdfMargin-20, pdfMargin/2, i+1, len(p.pages))
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	n, err := w.Write(out.Bytes())
+	return int64(n), err
+}
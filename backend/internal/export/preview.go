@@ -0,0 +1,151 @@
+package export
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"political-network-api/internal/models"
+)
+
+// Preview images use the 1.91:1 size social networks crop link cards to
+const (
+	previewWidth  = 1200
+	previewHeight = 630
+)
+
+var (
+	previewBackground = color.RGBA{15, 23, 42, 255}
+	previewEdge       = color.RGBA{148, 163, 184, 255}
+)
+
+// WritePreviewPNG draws a snapshot's subgraph as a PNG for link previews. Politicians and
+// parties sit on an inner ring and everything else on an outer one, so the picture is
+// deterministic and readable without labels; nodes grow with their number of links.
+func WritePreviewPNG(w io.Writer, nodes []models.ShareNode, links []models.ShareLink) error {
+	img := image.NewRGBA(image.Rect(0, 0, previewWidth, previewHeight))
+	fillRect(img, img.Bounds(), previewBackground)
+
+	var inner, outer []int
+	for i, n := range nodes {
+		if n.Type == models.NodeTypePolitician || n.Type == models.NodeTypeParty {
+			inner = append(inner, i)
+		} else {
+			outer = append(outer, i)
+		}
+	}
+
+	cx, cy := float64(previewWidth)/2, float64(previewHeight)/2
+	positions := make([]image.Point, len(nodes))
+	innerRadius, outerRadius := 150.0, 270.0
+	if len(inner) == 0 || len(outer) == 0 {
+		innerRadius, outerRadius = 240.0, 240.0
+	}
+	placeRing(positions, inner, cx, cy, innerRadius)
+	placeRing(positions, outer, cx, cy, outerRadius)
+	if len(nodes) == 1 {
+		positions[0] = image.Pt(int(cx), int(cy))
+	}
+
+	index := make(map[string]int, len(nodes))
+	for i, n := range nodes {
+		index[n.ID] = i
+	}
+	degree := make([]int, len(nodes))
+	for _, l := range links {
+		s, okS := index[l.Source]
+		t, okT := index[l.Target]
+		if !okS || !okT {
+			continue
+		}
+		degree[s]++
+		degree[t]++
+		drawLine(img, positions[s], positions[t], previewEdge, 0.35)
+	}
+
+	base := 7.0
+	if len(nodes) > 150 {
+		base = 3
+	}
+	for i, n := range nodes {
+		r, g, b := parseHexColor(n.Color)
+		radius := base + math.Min(float64(degree[i]), 12)*0.8
+		fillCircle(img, positions[i], radius, color.RGBA{uint8(r), uint8(g), uint8(b), 255})
+	}
+
+	return png.Encode(w, img)
+}
+
+// placeRing spreads the nodes at idx evenly around a circle, starting at the top
+func placeRing(positions []image.Point, idx []int, cx, cy, radius float64) {
+	for k, i := range idx {
+		angle := 2*math.Pi*float64(k)/float64(len(idx)) - math.Pi/2
+		positions[i] = image.Pt(int(cx+radius*math.Cos(angle)), int(cy+radius*math.Sin(angle)))
+	}
+}
+
+func fillRect(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
+
+func fillCircle(img *image.RGBA, center image.Point, radius float64, c color.RGBA) {
+	r := int(math.Ceil(radius))
+	for dy := -r; dy <= r; dy++ {
+		for dx := -r; dx <= r; dx++ {
+			if float64(dx*dx+dy*dy) <= radius*radius {
+				blend(img, center.X+dx, center.Y+dy, c, 1)
+			}
+		}
+	}
+}
+
+// drawLine draws a one-pixel line with Bresenham's algorithm
+func drawLine(img *image.RGBA, from, to image.Point, c color.RGBA, alpha float64) {
+	dx, dy := abs(to.X-from.X), -abs(to.Y-from.Y)
+	sx, sy := 1, 1
+	if from.X > to.X {
+		sx = -1
+	}
+	if from.Y > to.Y {
+		sy = -1
+	}
+
+	x, y, e := from.X, from.Y, dx+dy
+	for {
+		blend(img, x, y, c, alpha)
+		if x == to.X && y == to.Y {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y += sy
+		}
+	}
+}
+
+// blend paints c over the pixel at x, y with the given opacity
+func blend(img *image.RGBA, x, y int, c color.RGBA, alpha float64) {
+	if !(image.Point{x, y}).In(img.Rect) {
+		return
+	}
+	bg := img.RGBAAt(x, y)
+	mix := func(fg, bg uint8) uint8 { return uint8(float64(fg)*alpha + float64(bg)*(1-alpha)) }
+	img.SetRGBA(x, y, color.RGBA{mix(c.R, bg.R), mix(c.G, bg.G), mix(c.B, bg.B), 255})
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,78 @@
+package grpcapi
+
+import (
+	"context"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// caller is who made a call, resolved from its API key
+type caller struct {
+	actor string
+	role  models.Role
+}
+
+type callerKey struct{}
+
+// callerFrom returns the caller stored by the interceptors
+func callerFrom(ctx context.Context) caller {
+	if c, ok := ctx.Value(callerKey{}).(caller); ok {
+		return c
+	}
+	return caller{actor: "anonymous", role: models.RolePublic}
+}
+
+// authenticate resolves the x-api-key or authorization: Bearer metadata like the HTTP API.
+// Calls without a key are public; unknown keys are rejected. User sessions are for the
+// frontend and are not accepted here.
+func authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	key := ""
+	if values := md.Get("x-api-key"); len(values) > 0 {
+		key = values[0]
+	} else if values := md.Get("authorization"); len(values) > 0 {
+		key, _ = strings.CutPrefix(values[0], "Bearer ")
+		key = strings.TrimSpace(key)
+	}
+	if key == "" {
+		return context.WithValue(ctx, callerKey{}, caller{actor: "anonymous", role: models.RolePublic}), nil
+	}
+
+	label, role, ok := middleware.ResolveAPIKey(key)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid API key")
+	}
+	return context.WithValue(ctx, callerKey{}, caller{actor: label, role: role}), nil
+}
+
+func unaryAuth(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func streamAuth(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream carries the caller in its context
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
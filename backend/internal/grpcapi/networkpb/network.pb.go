@@ -0,0 +1,1047 @@
+// Read API of the political network for internal services and data pipelines. It serves the
+// same data as the REST API: CPFs and emails are shaped by the caller's API key role, and
+// connections come from the same cache as /api/connections.
+//
+// Regenerate the Go code with `make proto`.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: politicalnetwork/v1/network.proto
+
+package networkpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Politician struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Nome string `protobuf:"bytes,2,opt,name=nome,proto3" json:"nome,omitempty"`
+	// Masked (***.456.789-**) for callers without a researcher or admin key
+	Cpf                  string `protobuf:"bytes,3,opt,name=cpf,proto3" json:"cpf,omitempty"`
+	Uf                   string `protobuf:"bytes,4,opt,name=uf,proto3" json:"uf,omitempty"`
+	SiglaPartido         string `protobuf:"bytes,5,opt,name=sigla_partido,json=siglaPartido,proto3" json:"sigla_partido,omitempty"`
+	UltimoStatusSituacao string `protobuf:"bytes,6,opt,name=ultimo_status_situacao,json=ultimoStatusSituacao,proto3" json:"ultimo_status_situacao,omitempty"`
+	// Masked for public callers, empty for researchers
+	UltimoStatusEmail     string                 `protobuf:"bytes,7,opt,name=ultimo_status_email,json=ultimoStatusEmail,proto3" json:"ultimo_status_email,omitempty"`
+	CorruptionScore       int32                  `protobuf:"varint,8,opt,name=corruption_score,json=corruptionScore,proto3" json:"corruption_score,omitempty"`
+	FinancialRecordsCount int32                  `protobuf:"varint,9,opt,name=financial_records_count,json=financialRecordsCount,proto3" json:"financial_records_count,omitempty"`
+	UrlFoto               string                 `protobuf:"bytes,10,opt,name=url_foto,json=urlFoto,proto3" json:"url_foto,omitempty"`
+	DataNascimento        string                 `protobuf:"bytes,11,opt,name=data_nascimento,json=dataNascimento,proto3" json:"data_nascimento,omitempty"`
+	Escolaridade          string                 `protobuf:"bytes,12,opt,name=escolaridade,proto3" json:"escolaridade,omitempty"`
+	Profissao             string                 `protobuf:"bytes,13,opt,name=profissao,proto3" json:"profissao,omitempty"`
+	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt             *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *Politician) Reset() {
+	*x = Politician{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_politicalnetwork_v1_network_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Politician) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Politician) ProtoMessage() {}
+
+func (x *Politician) ProtoReflect() protoreflect.Message {
+	mi := &file_politicalnetwork_v1_network_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Politician.ProtoReflect.Descriptor instead.
+func (*Politician) Descriptor() ([]byte, []int) {
+	return file_politicalnetwork_v1_network_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Politician) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Politician) GetNome() string {
+	if x != nil {
+		return x.Nome
+	}
+	return ""
+}
+
+func (x *Politician) GetCpf() string {
+	if x != nil {
+		return x.Cpf
+	}
+	return ""
+}
+
+func (x *Politician) GetUf() string {
+	if x != nil {
+		return x.Uf
+	}
+	return ""
+}
+
+func (x *Politician) GetSiglaPartido() string {
+	if x != nil {
+		return x.SiglaPartido
+	}
+	return ""
+}
+
+func (x *Politician) GetUltimoStatusSituacao() string {
+	if x != nil {
+		return x.UltimoStatusSituacao
+	}
+	return ""
+}
+
+func (x *Politician) GetUltimoStatusEmail() string {
+	if x != nil {
+		return x.UltimoStatusEmail
+	}
+	return ""
+}
+
+func (x *Politician) GetCorruptionScore() int32 {
+	if x != nil {
+		return x.CorruptionScore
+	}
+	return 0
+}
+
+func (x *Politician) GetFinancialRecordsCount() int32 {
+	if x != nil {
+		return x.FinancialRecordsCount
+	}
+	return 0
+}
+
+func (x *Politician) GetUrlFoto() string {
+	if x != nil {
+		return x.UrlFoto
+	}
+	return ""
+}
+
+func (x *Politician) GetDataNascimento() string {
+	if x != nil {
+		return x.DataNascimento
+	}
+	return ""
+}
+
+func (x *Politician) GetEscolaridade() string {
+	if x != nil {
+		return x.Escolaridade
+	}
+	return ""
+}
+
+func (x *Politician) GetProfissao() string {
+	if x != nil {
+		return x.Profissao
+	}
+	return ""
+}
+
+func (x *Politician) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Politician) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListPoliticiansRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 1 to 1000; 0 means 500
+	Limit    int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset   int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	MinScore int32 `protobuf:"varint,3,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+}
+
+func (x *ListPoliticiansRequest) Reset() {
+	*x = ListPoliticiansRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_politicalnetwork_v1_network_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPoliticiansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoliticiansRequest) ProtoMessage() {}
+
+func (x *ListPoliticiansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_politicalnetwork_v1_network_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoliticiansRequest.ProtoReflect.Descriptor instead.
+func (*ListPoliticiansRequest) Descriptor() ([]byte, []int) {
+	return file_politicalnetwork_v1_network_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListPoliticiansRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPoliticiansRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListPoliticiansRequest) GetMinScore() int32 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+type ListPoliticiansResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Politicians []*Politician `protobuf:"bytes,1,rep,name=politicians,proto3" json:"politicians,omitempty"`
+}
+
+func (x *ListPoliticiansResponse) Reset() {
+	*x = ListPoliticiansResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_politicalnetwork_v1_network_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPoliticiansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoliticiansResponse) ProtoMessage() {}
+
+func (x *ListPoliticiansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_politicalnetwork_v1_network_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoliticiansResponse.ProtoReflect.Descriptor instead.
+func (*ListPoliticiansResponse) Descriptor() ([]byte, []int) {
+	return file_politicalnetwork_v1_network_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPoliticiansResponse) GetPoliticians() []*Politician {
+	if x != nil {
+		return x.Politicians
+	}
+	return nil
+}
+
+type GetPoliticianRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetPoliticianRequest) Reset() {
+	*x = GetPoliticianRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_politicalnetwork_v1_network_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPoliticianRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPoliticianRequest) ProtoMessage() {}
+
+func (x *GetPoliticianRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_politicalnetwork_v1_network_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPoliticianRequest.ProtoReflect.Descriptor instead.
+func (*GetPoliticianRequest) Descriptor() ([]byte, []int) {
+	return file_politicalnetwork_v1_network_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPoliticianRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type Connection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceId string `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	TargetId string `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Type     string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// Exact amount in centavos (the REST API renders reais)
+	ValueCentavos int64   `protobuf:"varint,4,opt,name=value_centavos,json=valueCentavos,proto3" json:"value_centavos,omitempty"`
+	Strength      float64 `protobuf:"fixed64,5,opt,name=strength,proto3" json:"strength,omitempty"`
+	// Validity range of time-ranged edges (party_switch)
+	StartDate string `protobuf:"bytes,6,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate   string `protobuf:"bytes,7,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+}
+
+func (x *Connection) Reset() {
+	*x = Connection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_politicalnetwork_v1_network_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Connection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connection) ProtoMessage() {}
+
+func (x *Connection) ProtoReflect() protoreflect.Message {
+	mi := &file_politicalnetwork_v1_network_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connection.ProtoReflect.Descriptor instead.
+func (*Connection) Descriptor() ([]byte, []int) {
+	return file_politicalnetwork_v1_network_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Connection) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *Connection) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *Connection) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Connection) GetValueCentavos() int64 {
+	if x != nil {
+		return x.ValueCentavos
+	}
+	return 0
+}
+
+func (x *Connection) GetStrength() float64 {
+	if x != nil {
+		return x.Strength
+	}
+	return 0
+}
+
+func (x *Connection) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *Connection) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+type StreamConnectionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only these connection types, e.g. financial or sanction; empty means all
+	Types []string `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	// Only edges touching this node ID, e.g. politician_204554
+	NodeId string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+}
+
+func (x *StreamConnectionsRequest) Reset() {
+	*x = StreamConnectionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_politicalnetwork_v1_network_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamConnectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamConnectionsRequest) ProtoMessage() {}
+
+func (x *StreamConnectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_politicalnetwork_v1_network_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamConnectionsRequest.ProtoReflect.Descriptor instead.
+func (*StreamConnectionsRequest) Descriptor() ([]byte, []int) {
+	return file_politicalnetwork_v1_network_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StreamConnectionsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *StreamConnectionsRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+type StreamNetworkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StreamNetworkRequest) Reset() {
+	*x = StreamNetworkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_politicalnetwork_v1_network_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamNetworkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamNetworkRequest) ProtoMessage() {}
+
+func (x *StreamNetworkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_politicalnetwork_v1_network_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamNetworkRequest.ProtoReflect.Descriptor instead.
+func (*StreamNetworkRequest) Descriptor() ([]byte, []int) {
+	return file_politicalnetwork_v1_network_proto_rawDescGZIP(), []int{6}
+}
+
+type NetworkNode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type            string  `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Name            string  `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Size            float64 `protobuf:"fixed64,4,opt,name=size,proto3" json:"size,omitempty"`
+	Color           string  `protobuf:"bytes,5,opt,name=color,proto3" json:"color,omitempty"`
+	CorruptionScore int32   `protobuf:"varint,6,opt,name=corruption_score,json=corruptionScore,proto3" json:"corruption_score,omitempty"`
+	ImageUrl        string  `protobuf:"bytes,7,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	// The entity itself, with the fields of its /api/network JSON
+	Data *structpb.Struct `protobuf:"bytes,8,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *NetworkNode) Reset() {
+	*x = NetworkNode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_politicalnetwork_v1_network_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkNode) ProtoMessage() {}
+
+func (x *NetworkNode) ProtoReflect() protoreflect.Message {
+	mi := &file_politicalnetwork_v1_network_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkNode.ProtoReflect.Descriptor instead.
+func (*NetworkNode) Descriptor() ([]byte, []int) {
+	return file_politicalnetwork_v1_network_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NetworkNode) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NetworkNode) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *NetworkNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NetworkNode) GetSize() float64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *NetworkNode) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *NetworkNode) GetCorruptionScore() int32 {
+	if x != nil {
+		return x.CorruptionScore
+	}
+	return 0
+}
+
+func (x *NetworkNode) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *NetworkNode) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type NetworkItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Item:
+	//	*NetworkItem_Node
+	//	*NetworkItem_Link
+	Item isNetworkItem_Item `protobuf_oneof:"item"`
+}
+
+func (x *NetworkItem) Reset() {
+	*x = NetworkItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_politicalnetwork_v1_network_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkItem) ProtoMessage() {}
+
+func (x *NetworkItem) ProtoReflect() protoreflect.Message {
+	mi := &file_politicalnetwork_v1_network_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkItem.ProtoReflect.Descriptor instead.
+func (*NetworkItem) Descriptor() ([]byte, []int) {
+	return file_politicalnetwork_v1_network_proto_rawDescGZIP(), []int{8}
+}
+
+func (m *NetworkItem) GetItem() isNetworkItem_Item {
+	if m != nil {
+		return m.Item
+	}
+	return nil
+}
+
+func (x *NetworkItem) GetNode() *NetworkNode {
+	if x, ok := x.GetItem().(*NetworkItem_Node); ok {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *NetworkItem) GetLink() *Connection {
+	if x, ok := x.GetItem().(*NetworkItem_Link); ok {
+		return x.Link
+	}
+	return nil
+}
+
+type isNetworkItem_Item interface {
+	isNetworkItem_Item()
+}
+
+type NetworkItem_Node struct {
+	Node *NetworkNode `protobuf:"bytes,1,opt,name=node,proto3,oneof"`
+}
+
+type NetworkItem_Link struct {
+	Link *Connection `protobuf:"bytes,2,opt,name=link,proto3,oneof"`
+}
+
+func (*NetworkItem_Node) isNetworkItem_Item() {}
+
+func (*NetworkItem_Link) isNetworkItem_Item() {}
+
+var File_politicalnetwork_v1_network_proto protoreflect.FileDescriptor
+
+var file_politicalnetwork_v1_network_proto_rawDesc = []byte{
+	0x0a, 0x21, 0x70, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x13, 0x70, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbc, 0x04, 0x0a, 0x0a, 0x50, 0x6f, 0x6c, 0x69,
+	0x74, 0x69, 0x63, 0x69, 0x61, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x70,
+	0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x70, 0x66, 0x12, 0x0e, 0x0a, 0x02,
+	0x75, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x75, 0x66, 0x12, 0x23, 0x0a, 0x0d,
+	0x73, 0x69, 0x67, 0x6c, 0x61, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x69, 0x64, 0x6f, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x69, 0x67, 0x6c, 0x61, 0x50, 0x61, 0x72, 0x74, 0x69, 0x64,
+	0x6f, 0x12, 0x34, 0x0a, 0x16, 0x75, 0x6c, 0x74, 0x69, 0x6d, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x5f, 0x73, 0x69, 0x74, 0x75, 0x61, 0x63, 0x61, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x14, 0x75, 0x6c, 0x74, 0x69, 0x6d, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53,
+	0x69, 0x74, 0x75, 0x61, 0x63, 0x61, 0x6f, 0x12, 0x2e, 0x0a, 0x13, 0x75, 0x6c, 0x74, 0x69, 0x6d,
+	0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x75, 0x6c, 0x74, 0x69, 0x6d, 0x6f, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x72, 0x72, 0x75,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0f, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x12, 0x36, 0x0a, 0x17, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x5f,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x15, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x72,
+	0x6c, 0x5f, 0x66, 0x6f, 0x74, 0x6f, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x75, 0x72,
+	0x6c, 0x46, 0x6f, 0x74, 0x6f, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6e, 0x61,
+	0x73, 0x63, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x6f, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x64, 0x61, 0x74, 0x61, 0x4e, 0x61, 0x73, 0x63, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x6f, 0x12, 0x22,
+	0x0a, 0x0c, 0x65, 0x73, 0x63, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x64, 0x61, 0x64, 0x65, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x73, 0x63, 0x6f, 0x6c, 0x61, 0x72, 0x69, 0x64, 0x61,
+	0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x73, 0x73, 0x61, 0x6f, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x73, 0x73, 0x61, 0x6f,
+	0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x63, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f,
+	0x6c, 0x69, 0x74, 0x69, 0x63, 0x69, 0x61, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x5c, 0x0a, 0x17, 0x4c,
+	0x69, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x69, 0x61, 0x6e, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0b, 0x70, 0x6f, 0x6c, 0x69, 0x74, 0x69,
+	0x63, 0x69, 0x61, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x6f,
+	0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x69, 0x61, 0x6e, 0x52, 0x0b, 0x70, 0x6f,
+	0x6c, 0x69, 0x74, 0x69, 0x63, 0x69, 0x61, 0x6e, 0x73, 0x22, 0x26, 0x0a, 0x14, 0x47, 0x65, 0x74,
+	0x50, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x69, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0xd7, 0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a,
+	0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x25,
+	0x0a, 0x0e, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x76, 0x6f, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x65, 0x6e,
+	0x74, 0x61, 0x76, 0x6f, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x22, 0x49, 0x0a, 0x18, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x12, 0x17, 0x0a,
+	0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xe4,
+	0x01, 0x0a, 0x0b, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x6c, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x63, 0x6f, 0x72, 0x72,
+	0x75, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x2b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x84, 0x01, 0x0a, 0x0b, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x36, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x4e, 0x6f, 0x64, 0x65, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x35, 0x0a,
+	0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x6f,
+	0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x04,
+	0x6c, 0x69, 0x6e, 0x6b, 0x42, 0x06, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x32, 0xa4, 0x03, 0x0a,
+	0x10, 0x50, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x12, 0x6c, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63,
+	0x69, 0x61, 0x6e, 0x73, 0x12, 0x2b, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x69, 0x61, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2c, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69,
+	0x74, 0x69, 0x63, 0x69, 0x61, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x5b, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x69, 0x61, 0x6e,
+	0x12, 0x29, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x74, 0x69,
+	0x63, 0x69, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x6f,
+	0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x69, 0x61, 0x6e, 0x12, 0x65, 0x0a, 0x11,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x2d, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1f, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x30, 0x01, 0x12, 0x5e, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x12, 0x29, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x20, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x74, 0x65,
+	0x6d, 0x30, 0x01, 0x42, 0x32, 0x5a, 0x30, 0x70, 0x6f, 0x6c, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c,
+	0x2d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x61, 0x70, 0x69, 0x2f, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_politicalnetwork_v1_network_proto_rawDescOnce sync.Once
+	file_politicalnetwork_v1_network_proto_rawDescData = file_politicalnetwork_v1_network_proto_rawDesc
+)
+
+func file_politicalnetwork_v1_network_proto_rawDescGZIP() []byte {
+	file_politicalnetwork_v1_network_proto_rawDescOnce.Do(func() {
+		file_politicalnetwork_v1_network_proto_rawDescData = protoimpl.X.CompressGZIP(file_politicalnetwork_v1_network_proto_rawDescData)
+	})
+	return file_politicalnetwork_v1_network_proto_rawDescData
+}
+
+var file_politicalnetwork_v1_network_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_politicalnetwork_v1_network_proto_goTypes = []any{
+	(*Politician)(nil),               // 0: politicalnetwork.v1.Politician
+	(*ListPoliticiansRequest)(nil),   // 1: politicalnetwork.v1.ListPoliticiansRequest
+	(*ListPoliticiansResponse)(nil),  // 2: politicalnetwork.v1.ListPoliticiansResponse
+	(*GetPoliticianRequest)(nil),     // 3: politicalnetwork.v1.GetPoliticianRequest
+	(*Connection)(nil),               // 4: politicalnetwork.v1.Connection
+	(*StreamConnectionsRequest)(nil), // 5: politicalnetwork.v1.StreamConnectionsRequest
+	(*StreamNetworkRequest)(nil),     // 6: politicalnetwork.v1.StreamNetworkRequest
+	(*NetworkNode)(nil),              // 7: politicalnetwork.v1.NetworkNode
+	(*NetworkItem)(nil),              // 8: politicalnetwork.v1.NetworkItem
+	(*timestamppb.Timestamp)(nil),    // 9: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),          // 10: google.protobuf.Struct
+}
+var file_politicalnetwork_v1_network_proto_depIdxs = []int32{
+	9,  // 0: politicalnetwork.v1.Politician.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 1: politicalnetwork.v1.Politician.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: politicalnetwork.v1.ListPoliticiansResponse.politicians:type_name -> politicalnetwork.v1.Politician
+	10, // 3: politicalnetwork.v1.NetworkNode.data:type_name -> google.protobuf.Struct
+	7,  // 4: politicalnetwork.v1.NetworkItem.node:type_name -> politicalnetwork.v1.NetworkNode
+	4,  // 5: politicalnetwork.v1.NetworkItem.link:type_name -> politicalnetwork.v1.Connection
+	1,  // 6: politicalnetwork.v1.PoliticalNetwork.ListPoliticians:input_type -> politicalnetwork.v1.ListPoliticiansRequest
+	3,  // 7: politicalnetwork.v1.PoliticalNetwork.GetPolitician:input_type -> politicalnetwork.v1.GetPoliticianRequest
+	5,  // 8: politicalnetwork.v1.PoliticalNetwork.StreamConnections:input_type -> politicalnetwork.v1.StreamConnectionsRequest
+	6,  // 9: politicalnetwork.v1.PoliticalNetwork.StreamNetwork:input_type -> politicalnetwork.v1.StreamNetworkRequest
+	2,  // 10: politicalnetwork.v1.PoliticalNetwork.ListPoliticians:output_type -> politicalnetwork.v1.ListPoliticiansResponse
+	0,  // 11: politicalnetwork.v1.PoliticalNetwork.GetPolitician:output_type -> politicalnetwork.v1.Politician
+	4,  // 12: politicalnetwork.v1.PoliticalNetwork.StreamConnections:output_type -> politicalnetwork.v1.Connection
+	8,  // 13: politicalnetwork.v1.PoliticalNetwork.StreamNetwork:output_type -> politicalnetwork.v1.NetworkItem
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_politicalnetwork_v1_network_proto_init() }
+func file_politicalnetwork_v1_network_proto_init() {
+	if File_politicalnetwork_v1_network_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_politicalnetwork_v1_network_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Politician); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_politicalnetwork_v1_network_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ListPoliticiansRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_politicalnetwork_v1_network_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ListPoliticiansResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_politicalnetwork_v1_network_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPoliticianRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_politicalnetwork_v1_network_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*Connection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_politicalnetwork_v1_network_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*StreamConnectionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_politicalnetwork_v1_network_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*StreamNetworkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_politicalnetwork_v1_network_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*NetworkNode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_politicalnetwork_v1_network_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*NetworkItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_politicalnetwork_v1_network_proto_msgTypes[8].OneofWrappers = []any{
+		(*NetworkItem_Node)(nil),
+		(*NetworkItem_Link)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_politicalnetwork_v1_network_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_politicalnetwork_v1_network_proto_goTypes,
+		DependencyIndexes: file_politicalnetwork_v1_network_proto_depIdxs,
+		MessageInfos:      file_politicalnetwork_v1_network_proto_msgTypes,
+	}.Build()
+	File_politicalnetwork_v1_network_proto = out.File
+	file_politicalnetwork_v1_network_proto_rawDesc = nil
+	file_politicalnetwork_v1_network_proto_goTypes = nil
+	file_politicalnetwork_v1_network_proto_depIdxs = nil
+}
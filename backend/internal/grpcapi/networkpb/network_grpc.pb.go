@@ -0,0 +1,293 @@
+// Read API of the political network for internal services and data pipelines. It serves the
+// same data as the REST API: CPFs and emails are shaped by the caller's API key role, and
+// connections come from the same cache as /api/connections.
+//
+// Regenerate the Go code with `make proto`.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: politicalnetwork/v1/network.proto
+
+package networkpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	PoliticalNetwork_ListPoliticians_FullMethodName   = "/politicalnetwork.v1.PoliticalNetwork/ListPoliticians"
+	PoliticalNetwork_GetPolitician_FullMethodName     = "/politicalnetwork.v1.PoliticalNetwork/GetPolitician"
+	PoliticalNetwork_StreamConnections_FullMethodName = "/politicalnetwork.v1.PoliticalNetwork/StreamConnections"
+	PoliticalNetwork_StreamNetwork_FullMethodName     = "/politicalnetwork.v1.PoliticalNetwork/StreamNetwork"
+)
+
+// PoliticalNetworkClient is the client API for PoliticalNetwork service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PoliticalNetworkClient interface {
+	// Politicians ordered by ID, like GET /api/politicians
+	ListPoliticians(ctx context.Context, in *ListPoliticiansRequest, opts ...grpc.CallOption) (*ListPoliticiansResponse, error)
+	// One politician, like GET /api/politicians/:id; NOT_FOUND when unknown
+	GetPolitician(ctx context.Context, in *GetPoliticianRequest, opts ...grpc.CallOption) (*Politician, error)
+	// Every connection, optionally filtered, one message per edge
+	StreamConnections(ctx context.Context, in *StreamConnectionsRequest, opts ...grpc.CallOption) (PoliticalNetwork_StreamConnectionsClient, error)
+	// The /api/network graph: all nodes first, then all links
+	StreamNetwork(ctx context.Context, in *StreamNetworkRequest, opts ...grpc.CallOption) (PoliticalNetwork_StreamNetworkClient, error)
+}
+
+type politicalNetworkClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPoliticalNetworkClient(cc grpc.ClientConnInterface) PoliticalNetworkClient {
+	return &politicalNetworkClient{cc}
+}
+
+func (c *politicalNetworkClient) ListPoliticians(ctx context.Context, in *ListPoliticiansRequest, opts ...grpc.CallOption) (*ListPoliticiansResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPoliticiansResponse)
+	err := c.cc.Invoke(ctx, PoliticalNetwork_ListPoliticians_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *politicalNetworkClient) GetPolitician(ctx context.Context, in *GetPoliticianRequest, opts ...grpc.CallOption) (*Politician, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Politician)
+	err := c.cc.Invoke(ctx, PoliticalNetwork_GetPolitician_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *politicalNetworkClient) StreamConnections(ctx context.Context, in *StreamConnectionsRequest, opts ...grpc.CallOption) (PoliticalNetwork_StreamConnectionsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PoliticalNetwork_ServiceDesc.Streams[0], PoliticalNetwork_StreamConnections_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &politicalNetworkStreamConnectionsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PoliticalNetwork_StreamConnectionsClient interface {
+	Recv() (*Connection, error)
+	grpc.ClientStream
+}
+
+type politicalNetworkStreamConnectionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *politicalNetworkStreamConnectionsClient) Recv() (*Connection, error) {
+	m := new(Connection)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *politicalNetworkClient) StreamNetwork(ctx context.Context, in *StreamNetworkRequest, opts ...grpc.CallOption) (PoliticalNetwork_StreamNetworkClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PoliticalNetwork_ServiceDesc.Streams[1], PoliticalNetwork_StreamNetwork_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &politicalNetworkStreamNetworkClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PoliticalNetwork_StreamNetworkClient interface {
+	Recv() (*NetworkItem, error)
+	grpc.ClientStream
+}
+
+type politicalNetworkStreamNetworkClient struct {
+	grpc.ClientStream
+}
+
+func (x *politicalNetworkStreamNetworkClient) Recv() (*NetworkItem, error) {
+	m := new(NetworkItem)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PoliticalNetworkServer is the server API for PoliticalNetwork service.
+// All implementations must embed UnimplementedPoliticalNetworkServer
+// for forward compatibility
+type PoliticalNetworkServer interface {
+	// Politicians ordered by ID, like GET /api/politicians
+	ListPoliticians(context.Context, *ListPoliticiansRequest) (*ListPoliticiansResponse, error)
+	// One politician, like GET /api/politicians/:id; NOT_FOUND when unknown
+	GetPolitician(context.Context, *GetPoliticianRequest) (*Politician, error)
+	// Every connection, optionally filtered, one message per edge
+	StreamConnections(*StreamConnectionsRequest, PoliticalNetwork_StreamConnectionsServer) error
+	// The /api/network graph: all nodes first, then all links
+	StreamNetwork(*StreamNetworkRequest, PoliticalNetwork_StreamNetworkServer) error
+	mustEmbedUnimplementedPoliticalNetworkServer()
+}
+
+// UnimplementedPoliticalNetworkServer must be embedded to have forward compatible implementations.
+type UnimplementedPoliticalNetworkServer struct {
+}
+
+func (UnimplementedPoliticalNetworkServer) ListPoliticians(context.Context, *ListPoliticiansRequest) (*ListPoliticiansResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPoliticians not implemented")
+}
+func (UnimplementedPoliticalNetworkServer) GetPolitician(context.Context, *GetPoliticianRequest) (*Politician, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPolitician not implemented")
+}
+func (UnimplementedPoliticalNetworkServer) StreamConnections(*StreamConnectionsRequest, PoliticalNetwork_StreamConnectionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamConnections not implemented")
+}
+func (UnimplementedPoliticalNetworkServer) StreamNetwork(*StreamNetworkRequest, PoliticalNetwork_StreamNetworkServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamNetwork not implemented")
+}
+func (UnimplementedPoliticalNetworkServer) mustEmbedUnimplementedPoliticalNetworkServer() {}
+
+// UnsafePoliticalNetworkServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PoliticalNetworkServer will
+// result in compilation errors.
+type UnsafePoliticalNetworkServer interface {
+	mustEmbedUnimplementedPoliticalNetworkServer()
+}
+
+func RegisterPoliticalNetworkServer(s grpc.ServiceRegistrar, srv PoliticalNetworkServer) {
+	s.RegisterService(&PoliticalNetwork_ServiceDesc, srv)
+}
+
+func _PoliticalNetwork_ListPoliticians_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPoliticiansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoliticalNetworkServer).ListPoliticians(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoliticalNetwork_ListPoliticians_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoliticalNetworkServer).ListPoliticians(ctx, req.(*ListPoliticiansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoliticalNetwork_GetPolitician_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPoliticianRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoliticalNetworkServer).GetPolitician(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoliticalNetwork_GetPolitician_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoliticalNetworkServer).GetPolitician(ctx, req.(*GetPoliticianRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoliticalNetwork_StreamConnections_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamConnectionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PoliticalNetworkServer).StreamConnections(m, &politicalNetworkStreamConnectionsServer{ServerStream: stream})
+}
+
+type PoliticalNetwork_StreamConnectionsServer interface {
+	Send(*Connection) error
+	grpc.ServerStream
+}
+
+type politicalNetworkStreamConnectionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *politicalNetworkStreamConnectionsServer) Send(m *Connection) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PoliticalNetwork_StreamNetwork_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamNetworkRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PoliticalNetworkServer).StreamNetwork(m, &politicalNetworkStreamNetworkServer{ServerStream: stream})
+}
+
+type PoliticalNetwork_StreamNetworkServer interface {
+	Send(*NetworkItem) error
+	grpc.ServerStream
+}
+
+type politicalNetworkStreamNetworkServer struct {
+	grpc.ServerStream
+}
+
+func (x *politicalNetworkStreamNetworkServer) Send(m *NetworkItem) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// PoliticalNetwork_ServiceDesc is the grpc.ServiceDesc for PoliticalNetwork service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PoliticalNetwork_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "politicalnetwork.v1.PoliticalNetwork",
+	HandlerType: (*PoliticalNetworkServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPoliticians",
+			Handler:    _PoliticalNetwork_ListPoliticians_Handler,
+		},
+		{
+			MethodName: "GetPolitician",
+			Handler:    _PoliticalNetwork_GetPolitician_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamConnections",
+			Handler:       _PoliticalNetwork_StreamConnections_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamNetwork",
+			Handler:       _PoliticalNetwork_StreamNetwork_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "politicalnetwork/v1/network.proto",
+}
@@ -0,0 +1,235 @@
+package grpcapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"political-network-api/internal/database"
+	"political-network-api/internal/grpcapi/networkpb"
+	"political-network-api/internal/models"
+	"political-network-api/internal/privacy"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server implements the PoliticalNetwork gRPC service over the REST API's repositories
+// and caches. The repository and cache loaders are installed by main, keeping this package
+// independent of the HTTP handlers.
+type Server struct {
+	networkpb.UnimplementedPoliticalNetworkServer
+
+	politicians database.PoliticianRepo
+	connections func() ([]models.Connection, error)
+	network     func() (*models.NetworkResponse, error)
+}
+
+// NewServer returns a service reading politicians from the given repository, and connections
+// and the network graph through the given cached loaders
+func NewServer(politicians database.PoliticianRepo, connections func() ([]models.Connection, error), network func() (*models.NetworkResponse, error)) *Server {
+	return &Server{politicians: politicians, connections: connections, network: network}
+}
+
+// Serve listens on addr and serves s until the listener fails. Server reflection is enabled
+// so tools like grpcurl can discover the service.
+func Serve(addr string, s *Server) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	srv := grpc.NewServer(grpc.UnaryInterceptor(unaryAuth), grpc.StreamInterceptor(streamAuth))
+	networkpb.RegisterPoliticalNetworkServer(srv, s)
+	reflection.Register(srv)
+
+	log.Printf("🔌 gRPC service listening on %s", addr)
+	return srv.Serve(lis)
+}
+
+// ListPoliticians implements PoliticalNetworkServer
+func (s *Server) ListPoliticians(ctx context.Context, req *networkpb.ListPoliticiansRequest) (*networkpb.ListPoliticiansResponse, error) {
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = 500
+	}
+	if limit < 1 || limit > 1000 || req.GetOffset() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must be between 1 and 1000 and offset not negative")
+	}
+
+	politicians, err := s.politicians.GetPoliticians(limit, int(req.GetOffset()), int(req.GetMinScore()), database.PoliticianFilter{})
+	dropped, usable := database.DroppedRows(err)
+	if !usable {
+		return nil, status.Errorf(codes.Internal, "failed to fetch politicians: %v", err)
+	}
+	if dropped > 0 {
+		// Rows that failed to scan are left out, as in the REST API's dropped_rows
+		grpc.SetHeader(ctx, metadata.Pairs("x-dropped-rows", strconv.Itoa(dropped)))
+	}
+
+	policy := policyFor(ctx, "politicians", "ListPoliticians")
+	resp := &networkpb.ListPoliticiansResponse{Politicians: make([]*networkpb.Politician, len(politicians))}
+	for i, p := range politicians {
+		resp.Politicians[i] = politicianMessage(policy.Politician(p))
+	}
+	return resp, nil
+}
+
+// GetPolitician implements PoliticalNetworkServer
+func (s *Server) GetPolitician(ctx context.Context, req *networkpb.GetPoliticianRequest) (*networkpb.Politician, error) {
+	if req.GetId() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "id must be positive")
+	}
+
+	politician, err := s.politicians.GetPolitician(int(req.GetId()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "politician not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch politician: %v", err)
+	}
+
+	policy := policyFor(ctx, "politicians", "GetPolitician")
+	return politicianMessage(policy.Politician(politician)), nil
+}
+
+// StreamConnections implements PoliticalNetworkServer
+func (s *Server) StreamConnections(req *networkpb.StreamConnectionsRequest, stream networkpb.PoliticalNetwork_StreamConnectionsServer) error {
+	connections, err := s.connections()
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to fetch connections: %v", err)
+	}
+
+	types := map[string]bool{}
+	for _, t := range req.GetTypes() {
+		types[t] = true
+	}
+	node := req.GetNodeId()
+
+	for _, conn := range connections {
+		if len(types) > 0 && !types[conn.Type] {
+			continue
+		}
+		if node != "" && conn.SourceID != node && conn.TargetID != node {
+			continue
+		}
+		if err := stream.Send(connectionMessage(conn)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StreamNetwork implements PoliticalNetworkServer. The graph is the shared, PII-masked one
+// served by /api/network, whatever the caller's role.
+func (s *Server) StreamNetwork(_ *networkpb.StreamNetworkRequest, stream networkpb.PoliticalNetwork_StreamNetworkServer) error {
+	network, err := s.network()
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to build network data: %v", err)
+	}
+
+	for _, node := range network.Nodes {
+		msg, err := nodeMessage(node)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to encode node %s: %v", node.ID, err)
+		}
+		if err := stream.Send(&networkpb.NetworkItem{Item: &networkpb.NetworkItem_Node{Node: msg}}); err != nil {
+			return err
+		}
+	}
+	for _, link := range network.Links {
+		if err := stream.Send(&networkpb.NetworkItem{Item: &networkpb.NetworkItem_Link{Link: connectionMessage(link)}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// policyFor returns the caller's privacy policy, auditing calls that reveal personal data
+// like the HTTP API does
+func policyFor(ctx context.Context, resource, method string) privacy.Policy {
+	c := callerFrom(ctx)
+	policy := privacy.ForRole(c.role)
+	if policy.Reveals() {
+		entry := models.AuditEntry{
+			Actor:    c.actor,
+			Role:     c.role,
+			Action:   "pii_access",
+			Resource: resource,
+			Payload:  map[string]interface{}{"grpc_method": method},
+		}
+		go func() {
+			if err := database.RecordAudit(entry); err != nil {
+				log.Printf("⚠️ Audit entry %s on %s not recorded: %v", entry.Action, entry.Resource, err)
+			}
+		}()
+	}
+	return policy
+}
+
+func politicianMessage(p models.Politician) *networkpb.Politician {
+	return &networkpb.Politician{
+		Id:                    int32(p.ID),
+		Nome:                  p.Nome,
+		Cpf:                   p.CPF,
+		Uf:                    p.UF,
+		SiglaPartido:          p.SiglaPartido,
+		UltimoStatusSituacao:  p.UltimoStatusSituacao,
+		UltimoStatusEmail:     p.UltimoStatusEmail,
+		CorruptionScore:       int32(p.CorruptionScore),
+		FinancialRecordsCount: int32(p.FinancialRecordsCount),
+		UrlFoto:               p.URLFoto,
+		DataNascimento:        p.DataNascimento,
+		Escolaridade:          p.Escolaridade,
+		Profissao:             p.Profissao,
+		CreatedAt:             timestamppb.New(p.CreatedAt),
+		UpdatedAt:             timestamppb.New(p.UpdatedAt),
+	}
+}
+
+func connectionMessage(c models.Connection) *networkpb.Connection {
+	return &networkpb.Connection{
+		SourceId:      c.SourceID,
+		TargetId:      c.TargetID,
+		Type:          c.Type,
+		ValueCentavos: int64(c.Value),
+		Strength:      c.Strength,
+		StartDate:     c.StartDate,
+		EndDate:       c.EndDate,
+	}
+}
+
+// nodeMessage converts a network node, carrying its entity as the same fields as its JSON
+func nodeMessage(n models.NetworkNode) (*networkpb.NetworkNode, error) {
+	encoded, err := json.Marshal(n.Data)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	data, err := structpb.NewStruct(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &networkpb.NetworkNode{
+		Id:              n.ID,
+		Type:            string(n.Type),
+		Name:            n.Name,
+		Size:            n.Size,
+		Color:           n.Color,
+		CorruptionScore: int32(n.CorruptionScore),
+		ImageUrl:        n.ImageURL,
+		Data:            data,
+	}, nil
+}
@@ -0,0 +1,394 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxTagLength caps an annotation's tag
+	maxTagLength = 100
+	// maxNoteLength caps an annotation's note
+	maxNoteLength = 4000
+)
+
+var annotationVisibilities = []string{models.VisibilityPrivate, models.VisibilityTeam, models.VisibilityPublic}
+
+// annotationViewer is the caller as a reader of annotations: researcher and admin keys are the
+// team, and anonymous callers only see public annotations
+func annotationViewer(c *gin.Context) database.AnnotationViewer {
+	role := middleware.Role(c)
+	viewer := database.AnnotationViewer{
+		Team:  role == models.RoleResearcher || role == models.RoleAdmin,
+		Admin: role == models.RoleAdmin,
+	}
+	if role != models.RolePublic {
+		viewer.Actor = middleware.Actor(c)
+	}
+	return viewer
+}
+
+// taggedEntities resolves ?tag= to the politicians and companies carrying the tag in an
+// annotation the caller may see; both are nil without a tag. On failure it writes the response
+// and returns false.
+func taggedEntities(c *gin.Context, start time.Time) ([]int, []string, bool) {
+	tag := strings.TrimSpace(c.Query("tag"))
+	if tag == "" {
+		return nil, nil, true
+	}
+	if len(tag) > maxTagLength {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  map[string]string{"tag": "must be at most 100 characters"},
+			Time:    time.Since(start).String(),
+		})
+		return nil, nil, false
+	}
+
+	ids, companies, err := database.TaggedEntities(tag, annotationViewer(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch tagged entities: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return nil, nil, false
+	}
+
+	politicians := []int{}
+	for _, id := range ids {
+		if n, err := strconv.Atoi(id); err == nil {
+			politicians = append(politicians, n)
+		}
+	}
+	return politicians, companies, true
+}
+
+// GetAnnotations handles GET /api/annotations - the annotations the caller may see, newest first
+// (?entity_type=politician|company, ?entity_id=, ?tag=, ?mine=true for the caller's own)
+func GetAnnotations(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.Annotation](c, params.Fields) {
+		return
+	}
+
+	viewer := annotationViewer(c)
+	filter := database.AnnotationFilter{
+		EntityType: c.Query("entity_type"),
+		Tag:        strings.TrimSpace(c.Query("tag")),
+	}
+	fieldErrors := map[string]string{}
+	if entityID := c.Query("entity_id"); entityID != "" {
+		filter.EntityID = annotatedEntityID(filter.EntityType, entityID, fieldErrors)
+	} else if filter.EntityType != "" && filter.EntityType != models.AnnotatePolitician && filter.EntityType != models.AnnotateCompany {
+		fieldErrors["entity_type"] = "must be politician or company"
+	}
+	if c.Query("mine") == "true" {
+		if viewer.Actor == "" {
+			fieldErrors["mine"] = "requires an API key"
+		}
+		filter.Author = viewer.Actor
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	annotations, err := database.GetAnnotations(filter, viewer, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch annotations: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	respondList(c, start, annotations, params.Fields)
+}
+
+// GetTags handles GET /api/tags - the tags the caller may see, with how many politicians and
+// companies carry each, most used first
+func GetTags(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.TagCount](c, params.Fields) {
+		return
+	}
+
+	tags, err := database.GetTags(annotationViewer(c), params.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch tags: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	respondList(c, start, tags, params.Fields)
+}
+
+// CreateAnnotation handles POST /api/annotations - tags a politician or company and/or attaches
+// a note to it, visible to its author only unless shared with the team or made public
+func CreateAnnotation(c *gin.Context) {
+	start := time.Now()
+
+	var req models.AnnotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid annotation: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	annotation := models.Annotation{
+		EntityType: req.EntityType,
+		Tag:        strings.TrimSpace(req.Tag),
+		Note:       strings.TrimSpace(req.Note),
+		Visibility: req.Visibility,
+		Author:     middleware.Actor(c),
+	}
+	if annotation.Visibility == "" {
+		annotation.Visibility = models.VisibilityPrivate
+	}
+	fieldErrors := map[string]string{}
+	annotation.EntityID = annotatedEntityID(req.EntityType, req.EntityID, fieldErrors)
+	validateAnnotation(annotation, fieldErrors)
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid annotation",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	if _, ok := entityFieldValue(c, start, "Invalid annotation", annotation.EntityType, annotation.EntityID, ""); !ok {
+		return
+	}
+
+	if err := database.CreateAnnotation(&annotation); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    annotation,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// UpdateAnnotation handles PATCH /api/annotations/:id - changes the tag, note or visibility of
+// one of the caller's annotations (admins may change anyone's)
+func UpdateAnnotation(c *gin.Context) {
+	start := time.Now()
+
+	annotation, ok := fetchOwnAnnotation(c, start)
+	if !ok {
+		return
+	}
+
+	var req models.AnnotationUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid annotation: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if req.Tag != nil {
+		annotation.Tag = strings.TrimSpace(*req.Tag)
+	}
+	if req.Note != nil {
+		annotation.Note = strings.TrimSpace(*req.Note)
+	}
+	if req.Visibility != nil {
+		annotation.Visibility = *req.Visibility
+	}
+
+	fieldErrors := map[string]string{}
+	validateAnnotation(annotation, fieldErrors)
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid annotation",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	err := database.UpdateAnnotation(&annotation)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Annotation not found",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    annotation,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// DeleteAnnotation handles DELETE /api/annotations/:id - removes one of the caller's annotations
+// (admins may remove anyone's)
+func DeleteAnnotation(c *gin.Context) {
+	start := time.Now()
+
+	annotation, ok := fetchOwnAnnotation(c, start)
+	if !ok {
+		return
+	}
+
+	if err := database.DeleteAnnotation(annotation.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"deleted": annotation.ID},
+		Time:    time.Since(start).String(),
+	})
+}
+
+// annotatedEntityID normalizes the ID of an annotated politician or company, recording why it or
+// the type is invalid in fieldErrors
+func annotatedEntityID(entityType, entityID string, fieldErrors map[string]string) string {
+	if entityType != models.AnnotatePolitician && entityType != models.AnnotateCompany {
+		fieldErrors["entity_type"] = "must be politician or company"
+		return entityID
+	}
+	return correctedEntityID(entityType, entityID, fieldErrors)
+}
+
+// validateAnnotation records in fieldErrors why an annotation's tag, note or visibility isn't
+// valid
+func validateAnnotation(a models.Annotation, fieldErrors map[string]string) {
+	if a.Tag == "" && a.Note == "" {
+		fieldErrors["tag"] = "a tag or a note is required"
+	}
+	if len(a.Tag) > maxTagLength {
+		fieldErrors["tag"] = "must be at most 100 characters"
+	}
+	if len(a.Note) > maxNoteLength {
+		fieldErrors["note"] = "must be at most 4000 characters"
+	}
+	if !slices.Contains(annotationVisibilities, a.Visibility) {
+		fieldErrors["visibility"] = "must be private, team or public"
+	}
+}
+
+// fetchOwnAnnotation loads the :id annotation when the caller wrote it or is an admin; others'
+// annotations are reported as not found. On failure it writes the response and returns false.
+func fetchOwnAnnotation(c *gin.Context, start time.Time) (models.Annotation, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid annotation id",
+			Time:    time.Since(start).String(),
+		})
+		return models.Annotation{}, false
+	}
+
+	annotation, err := database.GetAnnotation(id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !ownedBy(c, annotation.Author)) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Annotation not found",
+			Time:    time.Since(start).String(),
+		})
+		return models.Annotation{}, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch annotation: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return models.Annotation{}, false
+	}
+	return annotation, true
+}
+
+// tagNetwork narrows a network to the tagged politicians and companies, their neighbours and the
+// links touching a tagged node. Stats stay those of the whole network.
+func tagNetwork(network *models.NetworkResponse, politicians []int, companies []string) *models.NetworkResponse {
+	tagged := map[string]bool{}
+	for _, id := range politicians {
+		tagged[string(models.NodeTypePolitician)+"_"+strconv.Itoa(id)] = true
+	}
+	for _, cnpj := range companies {
+		tagged[string(models.NodeTypeCompany)+"_"+cnpj] = true
+	}
+
+	narrowed := *network
+	narrowed.Links = []models.Connection{}
+	keep := map[string]bool{}
+	for _, link := range network.Links {
+		if tagged[link.SourceID] || tagged[link.TargetID] {
+			narrowed.Links = append(narrowed.Links, link)
+			keep[link.SourceID], keep[link.TargetID] = true, true
+		}
+	}
+	narrowed.Nodes = []models.NetworkNode{}
+	for _, node := range network.Nodes {
+		if tagged[node.ID] || keep[node.ID] {
+			narrowed.Nodes = append(narrowed.Nodes, node)
+		}
+	}
+	return &narrowed
+}
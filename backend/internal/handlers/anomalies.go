@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAnomalies handles GET /api/anomalies - suspicious expense patterns per politician and
+// vendor with their evidence, largest amount first (?type=geo_mismatch, ?politician_id=)
+func GetAnomalies(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.Anomaly](c, params.Fields) {
+		return
+	}
+
+	var filter database.AnomalyFilter
+	errs := map[string]string{}
+	if v := c.Query("type"); v != "" {
+		if !slices.Contains(database.AnomalyTypes, v) {
+			errs["type"] = "must be one of " + strings.Join(database.AnomalyTypes, ", ")
+		}
+		filter.Type = v
+	}
+	if v := c.Query("politician_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil || id < 1 {
+			errs["politician_id"] = "must be a positive integer"
+		}
+		filter.PoliticianID = id
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	cacheKey := utils.CacheKey("anomalies", filter.Type, filter.PoliticianID, params.Limit, params.Offset)
+
+	var anomalies []models.Anomaly
+	if cached, found := utils.GetCache(cacheKey); found {
+		anomalies = cached.([]models.Anomaly)
+	} else {
+		var err error
+		anomalies, err = database.GetAnomalies(filter, params.Limit, params.Offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch anomalies: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, anomalies, config.CacheTTL("anomalies"))
+	}
+
+	respondList(c, start, protectItems(c, "anomalies", anomalies), params.Fields)
+}
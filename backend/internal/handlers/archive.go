@@ -0,0 +1,362 @@
+package handlers
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/export"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"political-network-api/internal/privacy"
+	"political-network-api/internal/progress"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	archivePrefix = "open-data-gov-full-"
+	// archiveMaxAge is how long a generated archive is reused before rebuilding
+	archiveMaxAge = 24 * time.Hour
+)
+
+// archiveMu serializes archive builds; each one reads every table in full
+var archiveMu sync.Mutex
+
+// exportDir returns where dataset archives are written
+func exportDir() string {
+	return config.Get().Export.Dir
+}
+
+// ExportFull handles GET /api/export/full - returns a download URL for the full dataset archive.
+// With async=true a needed build runs in the background and the response is 202 with a progress
+// task to follow. refresh=true rebuilds a fresh archive, which reads every table, so it takes an
+// admin key.
+func ExportFull(c *gin.Context) {
+	start := time.Now()
+
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "jsonl" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Unsupported archive format: " + format + " (use csv or jsonl)",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	refresh := c.Query("refresh") == "true"
+	if refresh {
+		if middleware.RequireRole(models.RoleAdmin)(c); c.IsAborted() {
+			return
+		}
+	}
+
+	if c.Query("async") == "true" {
+		exportFullAsync(c, start, format, refresh)
+		return
+	}
+
+	archiveMu.Lock()
+	defer archiveMu.Unlock()
+
+	name, found := latestArchive(format)
+	if !found || refresh {
+		var err error
+		name, err = buildFullArchive(format, nil)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to build dataset archive: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+	}
+
+	info, err := describeArchive(name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to read dataset archive: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    info,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// exportFullAsync answers from a fresh archive when there is one and otherwise starts, or joins,
+// a background build
+func exportFullAsync(c *gin.Context, start time.Time, format string, refresh bool) {
+	if name, found := latestArchive(format); found && !refresh {
+		if info, err := describeArchive(name); err == nil {
+			c.JSON(http.StatusOK, models.APIResponse{
+				Success: true,
+				Data:    info,
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+	}
+
+	task, started, err := progress.StartOrJoin("export_full_"+format, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to start archive build: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if started {
+		go func() {
+			archiveMu.Lock()
+			defer archiveMu.Unlock()
+
+			name, err := buildFullArchive(format, task)
+			if err != nil {
+				log.Printf("❌ Dataset archive build failed: %v", err)
+				task.Finish(nil, err)
+				return
+			}
+			info, err := describeArchive(name)
+			task.Finish(info, err)
+		}()
+	}
+
+	acceptedTask(c, start, task)
+}
+
+// DownloadExport handles GET /api/export/files/:name
+func DownloadExport(c *gin.Context) {
+	name := c.Param("name")
+	if !isArchiveName(name) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Export not found",
+			Time:    "0ms",
+		})
+		return
+	}
+
+	path := filepath.Join(exportDir(), name)
+	if _, err := os.Stat(path); err != nil {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Export not found",
+			Time:    "0ms",
+		})
+		return
+	}
+
+	c.FileAttachment(path, name)
+}
+
+// buildFullArchive writes a new archive atomically, deletes the older ones of the format and
+// returns its file name. Rows written are counted on task when one is given.
+func buildFullArchive(format string, task *progress.Task) (string, error) {
+	dir := exportDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	version := time.Now().UTC().Format("20060102T150405Z")
+	name := fmt.Sprintf("%s%s.%s.zip", archivePrefix, version, format)
+
+	tmp, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	task.Stage("connections")
+	connections, err := publicConnections(repos.Network.GetAllConnections)()
+	if err != nil {
+		return "", err
+	}
+	if task != nil {
+		total, err := archiveRowEstimate(len(connections))
+		if err != nil {
+			return "", err
+		}
+		task.SetTotal(total)
+	}
+
+	// Archives are public files, so personal data is always masked
+	a := export.NewArchive(tmp, version, format)
+	if format == "csv" {
+		err = firstError(
+			func() error {
+				return export.AddCSV(a, "politicians", export.PoliticianColumns, counted(task, "politicians", shapeEach(privacy.Public, database.EachPolitician)))
+			},
+			func() error {
+				return export.AddCSV(a, "parties", export.PartyColumns, counted(task, "parties", database.EachParty))
+			},
+			func() error {
+				return export.AddCSV(a, "companies", export.CompanyColumns, counted(task, "companies", database.EachCompany))
+			},
+			func() error {
+				return export.AddCSV(a, "sanctions", export.SanctionColumns, counted(task, "sanctions", shapeEach(privacy.Public, database.EachSanction)))
+			},
+			func() error {
+				return export.AddCSV(a, "connections", export.ConnectionColumns, counted(task, "connections", export.EachOf(connections)))
+			},
+		)
+	} else {
+		err = firstError(
+			func() error {
+				return export.AddJSONL(a, "politicians", counted(task, "politicians", shapeEach(privacy.Public, database.EachPolitician)))
+			},
+			func() error { return export.AddJSONL(a, "parties", counted(task, "parties", database.EachParty)) },
+			func() error { return export.AddJSONL(a, "companies", counted(task, "companies", database.EachCompany)) },
+			func() error {
+				return export.AddJSONL(a, "sanctions", counted(task, "sanctions", shapeEach(privacy.Public, database.EachSanction)))
+			},
+			func() error {
+				return export.AddJSONL(a, "connections", counted(task, "connections", export.EachOf(connections)))
+			},
+		)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := a.Close(); err != nil {
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, name)); err != nil {
+		return "", err
+	}
+	pruneArchives(format, name)
+	return name, nil
+}
+
+// pruneArchives deletes the archives of the format other than keep. Downloads already reading
+// one finish from the open file.
+func pruneArchives(format, keep string) {
+	matches, err := filepath.Glob(filepath.Join(exportDir(), archivePrefix+"*."+format+".zip"))
+	if err != nil {
+		return
+	}
+	for _, path := range matches {
+		if filepath.Base(path) == keep {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			log.Printf("⚠️ Failed to delete old dataset archive %s: %v", filepath.Base(path), err)
+		}
+	}
+}
+
+// archiveRowEstimate is the number of rows an archive will hold, from table counts taken before
+// streaming starts
+func archiveRowEstimate(connections int) (int64, error) {
+	stats, err := repos.Network.GetNetworkStats(false)
+	if err != nil {
+		return 0, err
+	}
+	return int64(stats.Politicians + stats.Parties + stats.Companies + stats.Sanctions + connections), nil
+}
+
+// counted reports each item from each to task under stage
+func counted[T any](task *progress.Task, stage string, each export.Each[T]) export.Each[T] {
+	if task == nil {
+		return each
+	}
+	return func(fn func(T) error) error {
+		task.Stage(stage)
+		return each(func(item T) error {
+			task.AddRows(1)
+			return fn(item)
+		})
+	}
+}
+
+// latestArchive finds the newest archive of the format that is still fresh
+func latestArchive(format string) (string, bool) {
+	matches, err := filepath.Glob(filepath.Join(exportDir(), archivePrefix+"*."+format+".zip"))
+	if err != nil || len(matches) == 0 {
+		return "", false
+	}
+
+	// Versions are UTC timestamps, so lexical order is chronological
+	sort.Strings(matches)
+	newest := matches[len(matches)-1]
+
+	info, err := os.Stat(newest)
+	if err != nil || time.Since(info.ModTime()) > archiveMaxAge {
+		return "", false
+	}
+	return filepath.Base(newest), true
+}
+
+// describeArchive reads the manifest of an archive for the API response
+func describeArchive(name string) (*models.DatasetArchive, error) {
+	path := filepath.Join(exportDir(), name)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var manifest export.ArchiveManifest
+	f, err := zr.Open("manifest.json")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := json.NewDecoder(f).Decode(&manifest); err != nil {
+		return nil, err
+	}
+
+	return &models.DatasetArchive{
+		Version:     manifest.Version,
+		Format:      manifest.Format,
+		FileName:    name,
+		DownloadURL: "/api/export/files/" + name,
+		SizeBytes:   info.Size(),
+		Rows:        manifest.Rows,
+		GeneratedAt: manifest.GeneratedAt,
+	}, nil
+}
+
+func isArchiveName(name string) bool {
+	return name == filepath.Base(name) &&
+		strings.HasPrefix(name, archivePrefix) &&
+		strings.HasSuffix(name, ".zip")
+}
+
+// firstError runs steps in order and returns the first failure
+func firstError(steps ...func() error) error {
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/inflation"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Asset growth between two consecutive declarations is unusual when the total at least doubles
+// and grows by R$ 500k or more. cli4 post-process --enhanced uses the same thresholds when it
+// adds unusual growth to the corruption risk score.
+const (
+	unusualGrowthPct   = 100.0
+	unusualGrowthValue = models.Money(500000_00)
+)
+
+// assetIncludes are the collections GET /api/politicians/:id/assets can embed
+var assetIncludes = map[string]relationLimit{
+	"items": {Default: 50, Max: 500}, // per declaration, most valuable first
+}
+
+// cachedAssets is what GetPoliticianAssets caches: the declarations as stored, before growth
+// is computed for the requested adjust_to
+type cachedAssets struct {
+	declarations []models.AssetDeclaration
+	dates        []string
+}
+
+// GetPoliticianAssets handles GET /api/politicians/:id/assets - TSE asset declarations per
+// election with election-over-election growth (?include=items, ?adjust_to= for real growth)
+func GetPoliticianAssets(c *gin.Context) {
+	start := time.Now()
+
+	id, ok := politicianID(c, start, repos.Live)
+	if !ok {
+		return
+	}
+
+	includes, ok := parseIncludes(c, assetIncludes)
+	if !ok {
+		return
+	}
+	target, ok := adjustTarget(c, start)
+	if !ok {
+		return
+	}
+
+	cacheKey := utils.CacheKey("politician_assets", id, includesKey(includes))
+
+	var assets cachedAssets
+	if cached, found := utils.GetCache(cacheKey); found {
+		assets = cached.(cachedAssets)
+	} else {
+		var err error
+		assets, err = loadPoliticianAssets(id, includes)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   "Politician not found",
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch asset declarations: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, assets, config.CacheTTL("politician_assets"))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    assetGrowth(id, assets, target),
+		Count:   len(assets.declarations),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// loadPoliticianAssets reads the declarations of an existing politician and, when requested,
+// their items
+func loadPoliticianAssets(id int, includes map[string]int) (cachedAssets, error) {
+	if _, err := repos.Live.GetPolitician(id); err != nil {
+		return cachedAssets{}, err
+	}
+
+	declarations, dates, err := database.GetPoliticianAssetDeclarations(id)
+	if err != nil {
+		return cachedAssets{}, err
+	}
+
+	if limit, ok := includes["items"]; ok {
+		items, err := database.GetPoliticianDeclaredAssets(id, limit)
+		if err != nil {
+			return cachedAssets{}, err
+		}
+		for i := range declarations {
+			declarations[i].Items = items[declarations[i].Year]
+		}
+	}
+
+	return cachedAssets{declarations: declarations, dates: dates}, nil
+}
+
+// assetGrowth computes the growth of each declaration over the previous one, in prices of
+// target when one is given (real growth) and nominal otherwise. The cached declarations are
+// copied, never modified.
+func assetGrowth(id int, assets cachedAssets, target *inflation.Target) models.PoliticianAssets {
+	result := models.PoliticianAssets{
+		PoliticianID: id,
+		Declarations: make([]models.AssetDeclaration, len(assets.declarations)),
+	}
+
+	totals := make([]models.Money, len(assets.declarations))
+	for i, d := range assets.declarations {
+		totals[i] = d.TotalDeclared
+		if target != nil {
+			adjusted := target.Adjust(d.TotalDeclared, assets.dates[i])
+			d.TotalAdjusted = &adjusted
+			totals[i] = adjusted
+		}
+
+		if i > 0 {
+			prev := assets.declarations[i-1]
+			growth := totals[i] - totals[i-1]
+			d.PreviousYear = prev.Year
+			d.GrowthValue = &growth
+			if pct, ok := growthPct(totals[i-1], totals[i]); ok {
+				d.GrowthPct = &pct
+				d.UnusualGrowth = pct >= unusualGrowthPct && growth >= unusualGrowthValue
+				result.UnusualGrowth = result.UnusualGrowth || d.UnusualGrowth
+				if years := d.Year - prev.Year; years > 0 {
+					annual := round2((math.Pow(totals[i].Float64()/totals[i-1].Float64(), 1/float64(years)) - 1) * 100)
+					d.AnnualizedGrowthPct = &annual
+				}
+			}
+		}
+
+		result.Declarations[i] = d
+	}
+
+	if n := len(totals); n > 1 {
+		if pct, ok := growthPct(totals[0], totals[n-1]); ok {
+			result.TotalGrowthPct = &pct
+		}
+	}
+
+	return result
+}
+
+// growthPct is the percentage change from before to after, undefined when before isn't positive
+func growthPct(before, after models.Money) (float64, bool) {
+	if before <= 0 {
+		return 0, false
+	}
+	return round2(float64(after-before) / float64(before) * 100), true
+}
+
+// round2 rounds to two decimal places
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
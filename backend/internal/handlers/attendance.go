@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parsePoliticianFilter reads the attendance filters and sort order of politician lists:
+// min_absence_rate and max_absence_rate (percent of deliberative sessions missed), the
+// latest-election filters of parseElectionFilter and sort=id|absence_rate|votes|spending
+// (prefixed with - for descending). On failure it writes the response and returns false.
+func parsePoliticianFilter(c *gin.Context, start time.Time) (database.PoliticianFilter, bool) {
+	filter := database.PoliticianFilter{Sort: c.Query("sort")}
+	fieldErrors := map[string]string{}
+
+	for name, dst := range map[string]**float64{
+		"min_absence_rate": &filter.MinAbsenceRate,
+		"max_absence_rate": &filter.MaxAbsenceRate,
+	} {
+		raw, ok := c.GetQuery(name)
+		if !ok {
+			continue
+		}
+		rate, err := strconv.ParseFloat(raw, 64)
+		if err != nil || rate < 0 || rate > 100 {
+			fieldErrors[name] = "must be a percentage between 0 and 100"
+			continue
+		}
+		*dst = &rate
+	}
+	if filter.MinAbsenceRate != nil && filter.MaxAbsenceRate != nil && *filter.MinAbsenceRate > *filter.MaxAbsenceRate {
+		fieldErrors["min_absence_rate"] = "must not exceed max_absence_rate"
+	}
+	parseElectionFilter(c, &filter, fieldErrors)
+	if !database.ValidPoliticianSort(filter.Sort) {
+		fieldErrors["sort"] = "must be id, absence_rate, votes or spending, optionally prefixed with -"
+	}
+
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return filter, false
+	}
+	return filter, true
+}
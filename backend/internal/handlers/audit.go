@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// audit records an action by the current caller. The insert runs in the background so
+// auditing never adds database latency to the response; failures are logged.
+func audit(c *gin.Context, action, resource string, payload map[string]interface{}) {
+	entry := models.AuditEntry{
+		Actor:    middleware.Actor(c),
+		Role:     middleware.Role(c),
+		Action:   action,
+		Resource: resource,
+		Payload:  payload,
+	}
+
+	go func() {
+		if err := database.RecordAudit(entry); err != nil {
+			log.Printf("⚠️ Audit entry %s on %s not recorded: %v", entry.Action, entry.Resource, err)
+		}
+	}()
+}
+
+// GetAuditLog handles GET /api/admin/audit?action=&actor=
+func GetAuditLog(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+
+	entries, err := database.GetAuditLog(c.Query("action"), c.Query("actor"), params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch audit log: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    entries,
+		Count:   len(entries),
+		Time:    time.Since(start).String(),
+	})
+}
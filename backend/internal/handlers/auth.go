@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	mailer "political-network-api/internal/mail"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// magicLinkTTL is how long an emailed sign-in link stays valid
+const magicLinkTTL = 15 * time.Minute
+
+// RequestMagicLink handles POST /api/auth/magic-link - emails a one-time sign-in link. The
+// response is the same whether or not the address has an account.
+func RequestMagicLink(c *gin.Context) {
+	start := time.Now()
+
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	addr, err := mail.ParseAddress(req.Email)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request",
+			Errors:  map[string]string{"email": "must be an email address"},
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	email := strings.ToLower(addr.Address)
+
+	if !mailer.Enabled() {
+		c.JSON(http.StatusServiceUnavailable, models.APIResponse{
+			Success: false,
+			Error:   "Email sign-in is not configured on this server",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to generate sign-in token: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	throttled, err := database.CreateLoginToken(email, token, magicLinkTTL)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	if !throttled {
+		body := fmt.Sprintf("Use this link to sign in to Political Network. It expires in %d minutes and works once:\n\n%s\n\n"+
+			"If you didn't ask to sign in, ignore this email.\n", int(magicLinkTTL.Minutes()), magicLink(token))
+		if err := mailer.Send(email, "Your sign-in link", body); err != nil {
+			c.JSON(http.StatusBadGateway, models.APIResponse{
+				Success: false,
+				Error:   "Failed to send sign-in email",
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"sent": true, "expires_in": magicLinkTTL.String()},
+		Time:    time.Since(start).String(),
+	})
+}
+
+// magicLink points at auth.magic_link_url, or the verify endpoint under server.public_url, with
+// the token appended. It is never built from the request, whose Host the caller controls.
+func magicLink(token string) string {
+	link := config.Get().Auth.MagicLinkURL
+	if link == "" {
+		link = publicURL() + "/api/auth/verify"
+	}
+
+	separator := "?"
+	if strings.Contains(link, "?") {
+		separator = "&"
+	}
+	return link + separator + "token=" + url.QueryEscape(token)
+}
+
+// VerifyMagicLink handles GET /api/auth/verify?token= - exchanges a sign-in link's token for a
+// session token, sent as Authorization: Bearer on later requests
+func VerifyMagicLink(c *gin.Context) {
+	start := time.Now()
+
+	sessionToken, err := randomToken(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to generate session token: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	session, err := database.ConsumeLoginToken(c.Query("token"), middleware.SessionPrefix+sessionToken,
+		config.Get().Auth.SessionTTL)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Error:   "Sign-in link is invalid, expired or already used",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    session,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// GetCurrentUser handles GET /api/auth/me
+func GetCurrentUser(c *gin.Context) {
+	start := time.Now()
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    middleware.User(c),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// Logout handles POST /api/auth/logout - ends the current session
+func Logout(c *gin.Context) {
+	start := time.Now()
+
+	if err := database.DeleteSession(middleware.SessionToken(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"signed_out": true},
+		Time:    time.Since(start).String(),
+	})
+}
+
+// randomToken is n random bytes, hex encoded
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"political-network-api/internal/models"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxBatchRequests caps the sub-requests in one batch
+	maxBatchRequests = 20
+	// batchConcurrency is how many sub-requests of one batch run at a time
+	batchConcurrency = 6
+	// maxBatchBytes caps a batch request body
+	maxBatchBytes = 64 << 10
+)
+
+// batchHeaders are copied onto every sub-request, so it runs as the caller with the caller's
+// rate limit and language
+var batchHeaders = []string{"X-API-Key", "Authorization", "X-Forwarded-For", "X-Real-IP", "Accept-Language"}
+
+// Batch returns the handler for POST /api/batch - runs an array of GET sub-requests through
+// router concurrently and answers with every status and body in request order
+func Batch(router http.Handler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBytes)
+
+		var reqs []models.BatchRequest
+		if err := c.ShouldBindJSON(&reqs); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   "Invalid batch: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		fieldErrors := map[string]string{}
+		if len(reqs) == 0 {
+			fieldErrors["requests"] = "must not be empty"
+		}
+		if len(reqs) > maxBatchRequests {
+			fieldErrors["requests"] = fmt.Sprintf("at most %d sub-requests", maxBatchRequests)
+		}
+		targets := make([]string, len(reqs))
+		for i, r := range reqs {
+			target, err := batchTarget(r)
+			if err != nil {
+				fieldErrors[fmt.Sprintf("%d.path", i)] = err.Error()
+				continue
+			}
+			targets[i] = target
+		}
+		if len(fieldErrors) > 0 {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   "Invalid batch",
+				Errors:  fieldErrors,
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		results := make([]models.BatchResult, len(reqs))
+		sem := make(chan struct{}, batchConcurrency)
+		var wg sync.WaitGroup
+		for i, r := range reqs {
+			wg.Add(1)
+			go func(i int, r models.BatchRequest) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				status, body := runBatchRequest(c, router, targets[i])
+				results[i] = models.BatchResult{ID: r.ID, Path: r.Path, Status: status, Body: body}
+			}(i, r)
+		}
+		wg.Wait()
+
+		c.JSON(http.StatusOK, models.APIResponse{
+			Success: true,
+			Data:    results,
+			Count:   len(results),
+			Time:    time.Since(start).String(),
+		})
+	}
+}
+
+// batchTarget builds the URL of a sub-request from its path and params. Only API reads that
+// answer with one response are allowed: not batches themselves, and not streams.
+func batchTarget(r models.BatchRequest) (string, error) {
+	u, err := url.Parse(r.Path)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return "", fmt.Errorf("must be a path such as /api/politicians")
+	}
+
+	u.Path = path.Clean(u.Path)
+	switch {
+	case !strings.HasPrefix(u.Path, "/api/"):
+		return "", fmt.Errorf("must start with /api/")
+	case u.Path == "/api/batch",
+		strings.HasPrefix(u.Path, "/api/stream/"),
+		strings.HasPrefix(u.Path, "/api/progress/") && strings.HasSuffix(u.Path, "/events"):
+		return "", fmt.Errorf("%s can't be batched", u.Path)
+	}
+
+	query := u.Query()
+	for k, v := range r.Params {
+		query.Set(k, v)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// runBatchRequest serves one GET sub-request in-process as the caller
+func runBatchRequest(c *gin.Context, router http.Handler, target string) (int, json.RawMessage) {
+	req, err := http.NewRequestWithContext(c.Request.Context(), http.MethodGet, target, nil)
+	if err != nil {
+		body, _ := json.Marshal(models.APIResponse{Success: false, Error: err.Error()})
+		return http.StatusBadRequest, body
+	}
+	req.RemoteAddr = c.Request.RemoteAddr
+	for _, h := range batchHeaders {
+		if v := c.GetHeader(h); v != "" {
+			req.Header.Set(h, v)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	body := rec.Body.Bytes()
+	switch {
+	case len(body) == 0:
+		body = []byte("null")
+	case !strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json"):
+		body, _ = json.Marshal(string(body))
+	}
+	return rec.Code, body
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetCacheInfo handles GET /api/admin/cache - counters plus per-key size and TTL (?keys=false to skip)
+func GetCacheInfo(c *gin.Context) {
+	start := time.Now()
+
+	stats := utils.GetCacheStats(c.Query("keys") != "false")
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    stats,
+		Count:   len(stats.Keys),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// PurgeCacheKey handles DELETE /api/admin/cache?key= - removes a single entry
+func PurgeCacheKey(c *gin.Context) {
+	start := time.Now()
+
+	key := c.Query("key")
+	if key == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  map[string]string{"key": "is required"},
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	if !utils.DeleteCache(key) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Cache key not found: " + key,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	audit(c, "cache_purge", key, nil)
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    "Cache key purged: " + key,
+		Time:    time.Since(start).String(),
+	})
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/privacy"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetChanges handles GET /api/changes?entity=politicians|sanctions&since=&after= - the records
+// created, updated or deleted since a dataset version was published (since=N) or a time
+// (RFC 3339 or YYYY-MM-DD), oldest first. Mirrors page with after=next_after until has_more is
+// false, and keep next_after for their next sync.
+func GetChanges(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 500, 5000)
+	if !ok {
+		return
+	}
+
+	filter := database.ChangeFilter{Entity: c.Query("entity")}
+	errs := map[string]string{}
+	if !slices.Contains(models.ChangeEntities, filter.Entity) {
+		errs["entity"] = "must be one of " + strings.Join(models.ChangeEntities, ", ")
+	}
+	if v := c.Query("after"); v != "" {
+		after, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || after < 0 {
+			errs["after"] = "must be a change id"
+		}
+		filter.After = after
+	}
+	since := c.Query("since")
+	version, versionErr := strconv.Atoi(since)
+	if since != "" && versionErr != nil {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			t, err = time.Parse("2006-01-02", since)
+		}
+		if err != nil {
+			errs["since"] = "must be a dataset version, an RFC 3339 time or a YYYY-MM-DD date"
+		}
+		filter.Since = t
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	if since != "" && versionErr == nil {
+		publishedAt, err := database.DatasetVersionPublishedAt(version)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   "Dataset version " + since + " is not published (see /api/dataset-versions)",
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch dataset version: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+		filter.Since = publishedAt
+	}
+
+	changes, err := database.GetChanges(filter, params.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch changes: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	feed := models.ChangeFeed{
+		Entity:    filter.Entity,
+		Changes:   protectChanges(c, changes),
+		NextAfter: filter.After,
+		HasMore:   len(changes) == params.Limit,
+	}
+	if len(changes) > 0 {
+		feed.NextAfter = changes[len(changes)-1].ID
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    feed,
+		Count:   len(changes),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// protectChanges shapes the records embedded in changes for the caller's role
+func protectChanges(c *gin.Context, changes []models.Change) []models.Change {
+	for i, ch := range changes {
+		switch record := ch.Record.(type) {
+		case models.Politician:
+			changes[i].Record = privacy.Apply(privacyPolicy(c, "politicians"), record)
+		case models.Sanction:
+			changes[i].Record = privacy.Apply(privacyPolicy(c, "sanctions"), record)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var clusterSignals = []string{database.ClusterSignalParty, database.ClusterSignalState, database.ClusterSignalSplit}
+
+// GetVendorClusters handles GET /api/analysis/vendor-clusters - CEAP vendors serving mostly one
+// party or state delegation, or splitting invoices just under procurement limits, with the
+// clients and invoices behind each signal; largest total first
+// (?signal=party|state|split_invoices, ?party=SIGLA, ?uf=SP for the dominant party or state)
+func GetVendorClusters(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.VendorCluster](c, params.Fields) {
+		return
+	}
+
+	signal := c.Query("signal")
+	party := strings.ToUpper(c.Query("party"))
+	uf := strings.ToUpper(c.Query("uf"))
+	errs := map[string]string{}
+	if signal != "" && !slices.Contains(clusterSignals, signal) {
+		errs["signal"] = "must be one of " + strings.Join(clusterSignals, ", ")
+	}
+	if party != "" && !partySiglaPattern.MatchString(party) {
+		errs["party"] = "must be a party sigla such as PT"
+	}
+	if uf != "" && !ufPattern.MatchString(uf) {
+		errs["uf"] = "must be a state abbreviation such as SP"
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	// The analysis runs over every vendor at once, so it is cached whole and filtered here
+	cacheKey := utils.CacheKey("vendor_clusters")
+
+	var clusters []models.VendorCluster
+	if cached, found := utils.GetCache(cacheKey); found {
+		clusters = cached.([]models.VendorCluster)
+	} else {
+		var err error
+		clusters, err = database.GetVendorClusters()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to analyze vendor clusters: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, clusters, config.CacheTTL("vendor_clusters"))
+	}
+
+	var matched []models.VendorCluster
+	for _, v := range clusters {
+		if signal != "" && !slices.Contains(v.Signals, signal) {
+			continue
+		}
+		if party != "" && (v.Party == nil || v.Party.Key != party) {
+			continue
+		}
+		if uf != "" && (v.State == nil || v.State.Key != uf) {
+			continue
+		}
+		matched = append(matched, v)
+	}
+
+	respondList(c, start, paginate(matched, params.Limit, params.Offset), params.Fields)
+}
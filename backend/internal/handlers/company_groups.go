@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	cnpjRootPattern = regexp.MustCompile(`^\d{8}$`)
+	cnpjPattern     = regexp.MustCompile(`^\d{14}$`)
+)
+
+// normalizeCNPJ strips the punctuation of a formatted CNPJ (12.345.678/0001-90)
+func normalizeCNPJ(cnpj string) string {
+	return strings.NewReplacer(".", "", "-", "", "/", "", " ", "").Replace(strings.TrimSpace(cnpj))
+}
+
+// GetCompanyGroups handles GET /api/companies/groups - corporate groups (matriz + filiais)
+// with totals aggregated across branches
+func GetCompanyGroups(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.CompanyGroup](c, params.Fields) {
+		return
+	}
+
+	cacheKey := utils.CacheKey("company_groups", params.Limit, params.Offset)
+
+	var groups []models.CompanyGroup
+	if cached, found := utils.GetCache(cacheKey); found {
+		groups = cached.([]models.CompanyGroup)
+	} else {
+		var err error
+		groups, err = database.GetCompanyGroups(params.Limit, params.Offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch company groups: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, groups, config.CacheTTL("company_groups"))
+	}
+
+	respondList(c, start, groups, params.Fields)
+}
+
+// GetCompanyGroup handles GET /api/companies/groups/:root - one corporate group and its branches
+func GetCompanyGroup(c *gin.Context) {
+	start := time.Now()
+
+	root := c.Param("root")
+	if !cnpjRootPattern.MatchString(root) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid CNPJ root (expected the first 8 digits of a CNPJ)",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	cacheKey := utils.CacheKey("company_group", root)
+
+	var group models.CompanyGroup
+	if cached, found := utils.GetCache(cacheKey); found {
+		group = cached.(models.CompanyGroup)
+	} else {
+		var err error
+		group, err = database.GetCompanyGroup(root)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   "Company group not found",
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch company group: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, group, config.CacheTTL("company_groups"))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    group,
+		Time:    time.Since(start).String(),
+	})
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetConfig handles GET /api/admin/config - the effective configuration, secrets redacted
+func GetConfig(c *gin.Context) {
+	start := time.Now()
+
+	data, err := config.Get().Redacted().Map()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to render configuration: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    data,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// ApplyConfigReload updates runtime state after a configuration reload. Cache TTLs are read
+// on every write, so only entries cached from now on use new values; the network caches
+// are dropped when their connection limits change.
+func ApplyConfigReload(old, next *config.Config) {
+	middleware.LoadAPIKeys(next.Auth.APIKeys)
+
+	if old.Network != next.Network {
+		utils.DeleteCache("connections_all")
+		utils.DeleteCache("network_complete")
+	}
+
+	go func() {
+		err := database.RecordAudit(models.AuditEntry{
+			Actor:    "signal:SIGHUP",
+			Role:     models.RoleAdmin,
+			Action:   "config_reload",
+			Resource: "config",
+		})
+		if err != nil {
+			log.Printf("⚠️ Audit entry config_reload not recorded: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,389 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"political-network-api/internal/database"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxCorrectionBytes caps a correction request body
+	maxCorrectionBytes = 16 << 10
+	// maxCorrectionEvidence caps the evidence URLs of one correction
+	maxCorrectionEvidence = 10
+)
+
+var cnpjCPFPattern = regexp.MustCompile(`^(\d{11}|\d{14})$`)
+
+// CreateCorrection handles POST /api/corrections - anyone can report a wrong politician,
+// company or expense, with the value they believe is right and links to their evidence. The
+// correction waits in the moderation queue until an admin reviews it.
+func CreateCorrection(c *gin.Context) {
+	start := time.Now()
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxCorrectionBytes)
+
+	var req models.CorrectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid correction: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	correction := models.Correction{
+		EntityType:  req.EntityType,
+		Field:       req.Field,
+		Description: strings.TrimSpace(req.Description),
+		Evidence:    []string{},
+		SubmittedBy: middleware.Actor(c),
+	}
+	fieldErrors := map[string]string{}
+	correction.EntityID = correctedEntityID(req.EntityType, req.EntityID, fieldErrors)
+	if fields, ok := models.CorrectableFields[req.EntityType]; ok && req.Field != "" {
+		if !slices.Contains(fields, req.Field) {
+			fieldErrors["field"] = "must be one of " + strings.Join(fields, ", ")
+		} else if value, msg := correctionValue(req.Field, req.ProposedValue); msg != "" {
+			fieldErrors["proposed_value"] = msg
+		} else {
+			correction.ProposedValue = value
+		}
+	} else if req.ProposedValue != "" && fieldErrors["entity_type"] == "" {
+		fieldErrors["field"] = "is required with a proposed value"
+	}
+	if correction.Description == "" || len(correction.Description) > 4000 {
+		fieldErrors["description"] = "is required and must be at most 4000 characters"
+	}
+	if len(req.Evidence) > maxCorrectionEvidence {
+		fieldErrors["evidence"] = fmt.Sprintf("at most %d links", maxCorrectionEvidence)
+	}
+	for i, link := range req.Evidence {
+		u, err := url.Parse(strings.TrimSpace(link))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(link) > 2048 {
+			fieldErrors[fmt.Sprintf("evidence[%d]", i)] = "must be an http(s) URL"
+			continue
+		}
+		correction.Evidence = append(correction.Evidence, u.String())
+	}
+	if req.Contact != "" {
+		if addr, err := mail.ParseAddress(req.Contact); err != nil {
+			fieldErrors["contact"] = "must be an email address"
+		} else {
+			correction.Contact = addr.Address
+		}
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid correction",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	current, ok := entityFieldValue(c, start, "Invalid correction", correction.EntityType, correction.EntityID, correction.Field)
+	if !ok {
+		return
+	}
+	if correction.Field != "" {
+		correction.CurrentValue = current
+	}
+
+	if err := database.CreateCorrection(&correction); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	// Admins reviewing the correction see the current value whole; the submitter sees it as any
+	// other read of the entity shows it to them, so a vendor's CPF stays masked for the public
+	if correction.CurrentValue != "" {
+		correction.CurrentValue = privacyPolicy(c, "corrections").Document(correction.CurrentValue)
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    correction,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// correctedEntityID normalizes the ID of a politician, expense or company, recording why it or
+// the type is invalid in fieldErrors
+func correctedEntityID(entityType, entityID string, fieldErrors map[string]string) string {
+	switch entityType {
+	case models.CorrectionPolitician, models.CorrectionExpense:
+		id, err := strconv.Atoi(strings.TrimSpace(entityID))
+		if err != nil || id < 1 {
+			fieldErrors["entity_id"] = "must be a positive integer"
+		}
+		return strconv.Itoa(id)
+	case models.CorrectionCompany:
+		entityID = normalizeCNPJ(entityID)
+		if !cnpjPattern.MatchString(entityID) {
+			fieldErrors["entity_id"] = "must be a 14-digit CNPJ"
+		}
+		return entityID
+	default:
+		fieldErrors["entity_type"] = "must be politician, company or expense"
+		return entityID
+	}
+}
+
+// entityFieldValue reads the field of an entity a correction or override is about, writing a
+// 400 (with message as the error) when the entity doesn't exist. On failure it writes the
+// response and returns false.
+func entityFieldValue(c *gin.Context, start time.Time, message, entityType, entityID, field string) (string, bool) {
+	value, err := database.EntityFieldValue(entityType, entityID, field)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   message,
+			Errors:  map[string]string{"entity_id": "no " + entityType + " has this id"},
+			Time:    time.Since(start).String(),
+		})
+		return "", false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return "", false
+	}
+	return value, true
+}
+
+// correctionValue normalizes a value for a correctable field, or says why it isn't valid
+func correctionValue(field, value string) (string, string) {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		return "", "is required with a field"
+	case field == "current_state":
+		value = strings.ToUpper(value)
+		if !ufPattern.MatchString(value) {
+			return "", "must be a two-letter state (UF)"
+		}
+	case field == "current_party":
+		value = strings.ToUpper(value)
+		if !partySiglaPattern.MatchString(value) {
+			return "", "must be a party acronym such as PT or PSDB"
+		}
+	case field == "counterpart_cnpj_cpf":
+		value = normalizeCNPJ(value)
+		if !cnpjCPFPattern.MatchString(value) || (len(value) == 14 && !utils.ValidCNPJ(value)) {
+			return "", "must be an 11-digit CPF or a 14-digit CNPJ with valid check digits"
+		}
+	case field == "cnae_code":
+		value = cnaeSeparators.Replace(value)
+		if len(value) != 7 || !cnaeCodePattern.MatchString(value) {
+			return "", "must be a 7-digit CNAE code such as 4731-8/00"
+		}
+	case len(value) > 255:
+		return "", "must be at most 255 characters"
+	}
+	return value, ""
+}
+
+// GetCorrections handles GET /api/admin/corrections - the moderation queue, oldest first
+// (?status=pending|accepted|rejected, ?entity_type=, ?entity_id=)
+func GetCorrections(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.Correction](c, params.Fields) {
+		return
+	}
+
+	filter := database.CorrectionFilter{
+		Status:     c.Query("status"),
+		EntityType: c.Query("entity_type"),
+		EntityID:   c.Query("entity_id"),
+	}
+	errs := map[string]string{}
+	if filter.Status != "" && filter.Status != models.CorrectionPending &&
+		filter.Status != models.CorrectionAccepted && filter.Status != models.CorrectionRejected {
+		errs["status"] = "must be pending, accepted or rejected"
+	}
+	if _, ok := models.CorrectableFields[filter.EntityType]; filter.EntityType != "" && !ok {
+		errs["entity_type"] = "must be politician, company or expense"
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	corrections, err := database.GetCorrections(filter, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch corrections: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	respondList(c, start, corrections, params.Fields)
+}
+
+// GetCorrection handles GET /api/admin/corrections/:id
+func GetCorrection(c *gin.Context) {
+	start := time.Now()
+
+	correction, ok := fetchCorrection(c, start)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    correction,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// ReviewCorrection handles PATCH /api/admin/corrections/:id - accepts or rejects a correction.
+// Accepting one that names a field overrides it with the proposed value, or with "value" when
+// given. Rejecting an accepted correction removes its override; the next ETL run restores the
+// source's value.
+func ReviewCorrection(c *gin.Context) {
+	start := time.Now()
+
+	correction, ok := fetchCorrection(c, start)
+	if !ok {
+		return
+	}
+
+	var req models.CorrectionReview
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid review: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	fieldErrors := map[string]string{}
+	if req.Status != models.CorrectionAccepted && req.Status != models.CorrectionRejected {
+		fieldErrors["status"] = "must be accepted or rejected"
+	}
+	if len(req.Note) > 1000 {
+		fieldErrors["note"] = "must be at most 1000 characters"
+	}
+	value := ""
+	if req.Status == models.CorrectionAccepted && correction.Field != "" {
+		value = correction.ProposedValue
+		if req.Value != nil {
+			value = *req.Value
+		}
+		var msg string
+		if value, msg = correctionValue(correction.Field, value); msg != "" {
+			fieldErrors["value"] = msg
+		}
+	} else if req.Value != nil {
+		fieldErrors["value"] = "only applies when accepting a correction of a field"
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid review",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	if err := database.ReviewCorrection(correction.ID, req.Status, value, strings.TrimSpace(req.Note), middleware.Actor(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	audit(c, "correction_review", "corrections/"+strconv.FormatInt(correction.ID, 10), map[string]interface{}{
+		"status":   req.Status,
+		"previous": correction.Status,
+		"entity":   correction.EntityType + ":" + correction.EntityID,
+		"field":    correction.Field,
+		"value":    value,
+	})
+	// A corrected name or party shows anywhere, as after an ETL run
+	if value != "" {
+		utils.FlushCache()
+	}
+
+	reviewed, err := database.GetCorrection(correction.ID)
+	if err != nil {
+		reviewed = correction
+	}
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    reviewed,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// fetchCorrection loads the :id correction. On failure it writes the response and returns false.
+func fetchCorrection(c *gin.Context, start time.Time) (models.Correction, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid correction id",
+			Time:    time.Since(start).String(),
+		})
+		return models.Correction{}, false
+	}
+
+	correction, err := database.GetCorrection(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Correction not found",
+			Time:    time.Since(start).String(),
+		})
+		return models.Correction{}, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch correction: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return models.Correction{}, false
+	}
+	return correction, true
+}
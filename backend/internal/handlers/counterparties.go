@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/models"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPoliticianTopVendors handles GET /api/politicians/:id/top-vendors - the vendors the
+// politician paid the most, with share of their spending and trend (?limit=, default 10, max 100)
+func GetPoliticianTopVendors(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 10, 100)
+	if !ok {
+		return
+	}
+	if !validateFields[models.TopVendor](c, params.Fields) {
+		return
+	}
+	rd, release, ok := datasetReader(c, start)
+	if !ok {
+		return
+	}
+	defer release()
+	id, ok := politicianID(c, start, rd)
+	if !ok {
+		return
+	}
+
+	cacheKey := versionedCacheKey(rd, "top_vendors", id, params.Limit)
+
+	vendors, err := loadCached(c, cacheKey, config.CacheTTL("counterparties"), func() ([]models.TopVendor, error) {
+		if _, err := rd.GetPolitician(id); err != nil {
+			return nil, err
+		}
+		return rd.GetTopVendors(id, params.Limit)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Politician not found",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch top vendors: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	respondList(c, start, protectItems(c, "top_vendors", vendors), params.Fields)
+}
+
+// GetCompanyTopPoliticians handles GET /api/companies/:cnpj/top-politicians - the politicians who
+// paid the company the most, with share of what politicians paid it and trend (?limit=, default
+// 10, max 100)
+func GetCompanyTopPoliticians(c *gin.Context) {
+	start := time.Now()
+
+	cnpj := normalizeCNPJ(c.Param("cnpj"))
+	if !cnpjPattern.MatchString(cnpj) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid CNPJ (expected 14 digits)",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	params, ok := bindQueryParams(c, 10, 100)
+	if !ok {
+		return
+	}
+	if !validateFields[models.TopPolitician](c, params.Fields) {
+		return
+	}
+	rd, release, ok := datasetReader(c, start)
+	if !ok {
+		return
+	}
+	defer release()
+
+	cacheKey := versionedCacheKey(rd, "top_politicians", cnpj, params.Limit)
+
+	politicians, err := loadCached(c, cacheKey, config.CacheTTL("counterparties"), func() ([]models.TopPolitician, error) {
+		if _, err := rd.GetCompany(cnpj); err != nil {
+			return nil, err
+		}
+		return rd.GetTopPoliticians(cnpj, params.Limit)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Company not found",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch top politicians: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	respondList(c, start, politicians, params.Fields)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/cursor"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// nextCursorKey holds the ?cursor= of the page after c's response
+const nextCursorKey = "next_cursor"
+
+// markNextCursor gives c's response the cursor of the next page: "next_cursor" in the JSON body
+// and a Link header with rel="next"
+func markNextCursor(c *gin.Context, token string) {
+	c.Set(nextCursorKey, token)
+	query := c.Request.URL.Query()
+	query.Del("offset")
+	query.Set("cursor", token)
+	c.Header("Link", "<"+requestBaseURL(c)+c.Request.URL.Path+"?"+query.Encode()+`>; rel="next"`)
+}
+
+// nextCursor is the cursor of the page after c's response, empty on the last page
+func nextCursor(c *gin.Context) string {
+	return c.GetString(nextCursorKey)
+}
+
+// decodeCursor reads ?cursor= into position, a position in list, reporting whether there was
+// one. It writes the 400 response and returns ok false for a cursor that isn't one of list's or
+// that comes with ?offset=.
+func decodeCursor(c *gin.Context, start time.Time, list string, position interface{}) (found, ok bool) {
+	token := c.Query("cursor")
+	if token == "" {
+		return false, true
+	}
+
+	fieldErrors := map[string]string{}
+	if err := cursor.Decode(list, token, position); err != nil {
+		fieldErrors["cursor"] = "must be a next_cursor returned by this list"
+	}
+	if c.Query("offset") != "" {
+		fieldErrors["offset"] = "can't be combined with cursor"
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return true, false
+	}
+	return true, true
+}
+
+// politicianCursor continues filter from ?cursor=, in the cursor's sort
+func politicianCursor(c *gin.Context, start time.Time, filter *database.PoliticianFilter) bool {
+	var position database.PoliticianCursor
+	found, ok := decodeCursor(c, start, "politicians", &position)
+	if found && ok {
+		filter.Sort, filter.After = position.Sort, &position
+	}
+	return ok
+}
+
+// markNextPoliticians sets the cursor of the page after politicians, a full page of limit
+// politicians in sort
+func markNextPoliticians(c *gin.Context, politicians []models.Politician, limit int, sort string) {
+	if len(politicians) < limit || len(politicians) == 0 {
+		return
+	}
+	token, err := cursor.Encode("politicians", database.NextPoliticianCursor(politicians[len(politicians)-1], sort))
+	if err != nil {
+		return
+	}
+	markNextCursor(c, token)
+}
+
+// listCursor reads ?cursor= of list, one of the lists paged by database.ListCursor; the
+// position is nil without one
+func listCursor(c *gin.Context, start time.Time, list string) (*database.ListCursor, bool) {
+	var position database.ListCursor
+	found, ok := decodeCursor(c, start, list, &position)
+	if !found || !ok {
+		return nil, ok
+	}
+	return &position, true
+}
+
+// markNextPage sets the cursor of the page after items, a full page of limit items of list, from
+// the position of its last item
+func markNextPage[T any](c *gin.Context, list string, items []T, limit int, position func(T) database.ListCursor) {
+	if len(items) < limit || len(items) == 0 {
+		return
+	}
+	token, err := cursor.Encode(list, position(items[len(items)-1]))
+	if err != nil {
+		return
+	}
+	markNextCursor(c, token)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	// dataQuality is the latest integrity report, nil until the checks first run
+	dataQuality atomic.Pointer[models.DataQualityReport]
+	// verifying makes concurrent runs wait for one another rather than scan the tables twice
+	verifying sync.Mutex
+)
+
+// VerifyData runs the data integrity checks, logs what they found and keeps the report for
+// GET /api/admin/data-quality
+func VerifyData() models.DataQualityReport {
+	verifying.Lock()
+	defer verifying.Unlock()
+
+	report := database.CheckDataQuality()
+	dataQuality.Store(&report)
+
+	for _, check := range report.Checks {
+		switch {
+		case check.Error != "":
+			log.Printf("❌ Data check %s failed to run: %s", check.Name, check.Error)
+		case check.Violations > 0:
+			icon := "❌"
+			if check.Severity == models.SeverityWarning {
+				icon = "⚠️"
+			}
+			log.Printf("%s Data check %s: %d rows (%s)", icon, check.Name, check.Violations, strings.Join(check.Examples, ", "))
+		}
+	}
+	if report.Passed {
+		log.Printf("✅ Data checks passed in %s with %d warnings", report.Duration, report.Warnings)
+	} else {
+		log.Printf("❌ Data checks failed in %s: %d errors, %d warnings", report.Duration, report.Errors, report.Warnings)
+	}
+	return report
+}
+
+// GetDataQuality handles GET /api/admin/data-quality - the latest integrity report, from
+// startup unless ?refresh=true runs the checks again
+func GetDataQuality(c *gin.Context) {
+	start := time.Now()
+
+	report := dataQuality.Load()
+	if report == nil || c.Query("refresh") == "true" {
+		fresh := VerifyData()
+		report = &fresh
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    report,
+		Count:   len(report.Checks),
+		Time:    time.Since(start).String(),
+	})
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"log"
+	"political-network-api/internal/database"
+	"political-network-api/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// degradedKey marks a request answered from stale cached data
+const degradedKey = "degraded"
+
+// loadCached returns the value cached under key, or loads it and caches it for ttl. When the
+// load fails because the database is unavailable, the last value cached under key is returned
+// instead, however old, and c's response is marked degraded. Callers without a request (cache
+// warm-up, gRPC) pass a nil c and get the error. A value missing rows that failed to scan is
+// returned, not cached, with the rows counted in c's response.
+func loadCached[T any](c *gin.Context, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	return loadCachedAs(c, key, ttl, false, load)
+}
+
+// loadCachedCompressed is loadCached for large values, cached as gzipped JSON (see
+// utils.SetCacheCompressed). T must survive a JSON round trip; every call returns a new copy.
+func loadCachedCompressed[T any](c *gin.Context, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	return loadCachedAs(c, key, ttl, true, load)
+}
+
+func loadCachedAs[T any](c *gin.Context, key string, ttl time.Duration, compressed bool, load func() (T, error)) (T, error) {
+	if cached, found := utils.GetCache(key); found {
+		value, err := utils.CacheValue[T](cached)
+		if err == nil {
+			return value, nil
+		}
+		log.Printf("⚠️ Dropping unreadable cache entry %s: %v", key, err)
+		utils.DeleteCache(key)
+	}
+
+	value, err := load()
+	if err == nil {
+		if !compressed {
+			utils.SetCache(key, value, ttl)
+		} else if err := utils.SetCacheCompressed(key, value, ttl); err != nil {
+			log.Printf("⚠️ Not caching %s: %v", key, err)
+		}
+		return value, nil
+	}
+	if dropped, usable := database.DroppedRows(err); usable {
+		if c != nil {
+			markDropped(c, dropped)
+		}
+		return value, nil
+	}
+	if c != nil && database.Unavailable(err) {
+		if stale, storedAt, found := utils.GetStaleCache(key); found {
+			if value, err := utils.CacheValue[T](stale); err == nil {
+				markDegraded(c, storedAt)
+				return value, nil
+			}
+		}
+	}
+	return value, err
+}
+
+// markDegraded flags a response built from data cached at storedAt: X-Degraded, Age and a
+// Warning 110 header, plus "degraded": true in the JSON body
+func markDegraded(c *gin.Context, storedAt time.Time) {
+	c.Set(degradedKey, true)
+	c.Header("X-Degraded", "true")
+	c.Header("Age", strconv.Itoa(int(time.Since(storedAt).Seconds())))
+	c.Header("Warning", `110 - "Response is Stale"`)
+}
+
+// degraded reports whether the response is built from stale cached data
+func degraded(c *gin.Context) bool {
+	return c.GetBool(degradedKey)
+}
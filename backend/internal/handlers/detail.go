@@ -0,0 +1,378 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// relationLimit bounds how many rows of an embedded collection are returned
+type relationLimit struct {
+	Default int
+	Max     int
+}
+
+// politicianIncludes are the collections GET /api/politicians/:id can embed
+var politicianIncludes = map[string]relationLimit{
+	"expenses":    {Default: 100, Max: 1000},
+	"sanctions":   {Default: 50, Max: 500},
+	"memberships": {Default: 50, Max: 200},
+	"wikidata":    {Default: 50, Max: 200}, // limits previous offices
+	"fronts":      {Default: 50, Max: 500},
+	"front_peers": {Default: 20, Max: 100},
+	"relatives":   {Default: 50, Max: 200},
+	"tcu_rulings": {Default: 20, Max: 200},
+}
+
+// parseIncludes reads ?include= and the per-relation <name>_limit parameters, writing a 400 on error
+func parseIncludes(c *gin.Context, relations map[string]relationLimit) (map[string]int, bool) {
+	includes := map[string]int{}
+	fieldErrors := map[string]string{}
+
+	for _, name := range splitFields(c.QueryArray("include")) {
+		relation, ok := relations[name]
+		if !ok {
+			fieldErrors["include"] = "unknown relation: " + name
+			continue
+		}
+
+		limit := relation.Default
+		if v, ok := c.GetQuery(name + "_limit"); ok {
+			n, err := strconv.Atoi(v)
+			switch {
+			case err != nil:
+				fieldErrors[name+"_limit"] = "must be an integer"
+			case n < 1:
+				fieldErrors[name+"_limit"] = "must be at least 1"
+			default:
+				limit = min(n, relation.Max)
+			}
+		}
+		includes[name] = limit
+	}
+
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  fieldErrors,
+			Time:    "0ms",
+		})
+		return nil, false
+	}
+	return includes, true
+}
+
+// includesKey renders includes in a stable order for cache keys
+func includesKey(includes map[string]int) string {
+	parts := make([]string, 0, len(includes))
+	for name, limit := range includes {
+		parts = append(parts, fmt.Sprintf("%s:%d", name, limit))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// GetPolitician handles GET /api/politicians/:id
+func GetPolitician(c *gin.Context) {
+	start := time.Now()
+
+	includes, ok := parseIncludes(c, politicianIncludes)
+	if !ok {
+		return
+	}
+	target, ok := adjustTarget(c, start)
+	if !ok {
+		return
+	}
+	rd, release, ok := datasetReader(c, start)
+	if !ok {
+		return
+	}
+	defer release()
+	id, ok := politicianID(c, start, rd)
+	if !ok {
+		return
+	}
+
+	cacheKey := versionedCacheKey(rd, "politician_detail", id, includesKey(includes))
+
+	detail, err := loadCached(c, cacheKey, config.CacheTTL("politician_detail"), func() (models.PoliticianDetail, error) {
+		return buildPoliticianDetail(rd, id, includes)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Politician not found",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch politician: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	detail = privacyPolicy(c, "politician").PoliticianDetail(detail)
+	detail.Expenses = adjustExpenses(target, detail.Expenses)
+	if index, err := loadPoliticianIndex(c, rd); err == nil {
+		index.identifyPolitician(&detail.Politician)
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success:     true,
+		Data:        detail,
+		Degraded:    degraded(c),
+		DroppedRows: droppedRows(c),
+		Time:        time.Since(start).String(),
+	})
+}
+
+// buildPoliticianDetail loads a politician and the requested related collections
+func buildPoliticianDetail(rd database.Repository, id int, includes map[string]int) (models.PoliticianDetail, error) {
+	politician, err := rd.GetPolitician(id)
+	if err != nil {
+		return models.PoliticianDetail{}, err
+	}
+	detail := models.PoliticianDetail{Politician: politician}
+	var partial partialReads
+
+	if limit, ok := includes["expenses"]; ok {
+		if detail.Expenses, err = rd.GetFinancialRecords(id, limit, 0, nil); partial.failed(err) {
+			return detail, err
+		}
+	}
+	if limit, ok := includes["sanctions"]; ok {
+		if detail.Sanctions, err = rd.GetPoliticianSanctions(id, limit); partial.failed(err) {
+			return detail, err
+		}
+	}
+	if limit, ok := includes["memberships"]; ok {
+		if detail.Memberships, err = rd.GetPoliticianMemberships(id, limit); partial.failed(err) {
+			return detail, err
+		}
+	}
+	if limit, ok := includes["wikidata"]; ok {
+		if detail.Wikidata, err = rd.GetPoliticianWikidata(id, limit); partial.failed(err) {
+			return detail, err
+		}
+	}
+	if limit, ok := includes["fronts"]; ok {
+		if detail.Fronts, err = rd.GetPoliticianFronts(id, limit); partial.failed(err) {
+			return detail, err
+		}
+	}
+	if limit, ok := includes["front_peers"]; ok {
+		if detail.FrontPeers, err = rd.GetFrontPeers(id, limit); partial.failed(err) {
+			return detail, err
+		}
+	}
+	if limit, ok := includes["relatives"]; ok {
+		if detail.Relatives, err = rd.GetPoliticianRelatives(id, limit); partial.failed(err) {
+			return detail, err
+		}
+	}
+	if limit, ok := includes["tcu_rulings"]; ok {
+		filter := database.TCURulingFilter{PoliticianID: id}
+		if detail.TCURulings, err = rd.GetTCURulings(filter, limit, 0); partial.failed(err) {
+			return detail, err
+		}
+	}
+	detail.Provenance = detailProvenance(rd, models.ProvenancePolitician, strconv.Itoa(id))
+
+	return detail, partial.err()
+}
+
+// partyIncludes are the collections GET /api/parties/:id can embed
+var partyIncludes = map[string]relationLimit{
+	"members":        {Default: 100, Max: 1000},
+	"former_members": {Default: 100, Max: 1000},
+	"funds":          {Default: 50, Max: 500},
+}
+
+// GetParty handles GET /api/parties/:id
+func GetParty(c *gin.Context) {
+	start := time.Now()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid party id",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	includes, ok := parseIncludes(c, partyIncludes)
+	if !ok {
+		return
+	}
+	rd, release, ok := datasetReader(c, start)
+	if !ok {
+		return
+	}
+	defer release()
+
+	cacheKey := versionedCacheKey(rd, "party_detail", id, includesKey(includes))
+
+	detail, err := loadCached(c, cacheKey, config.CacheTTL("party_detail"), func() (models.PartyDetail, error) {
+		return buildPartyDetail(rd, id, includes)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Party not found",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch party: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success:     true,
+		Data:        detail,
+		Degraded:    degraded(c),
+		DroppedRows: droppedRows(c),
+		Time:        time.Since(start).String(),
+	})
+}
+
+// buildPartyDetail loads a party, its member summary and the requested collections
+func buildPartyDetail(rd database.Repository, id int, includes map[string]int) (models.PartyDetail, error) {
+	party, err := rd.GetParty(id)
+	if err != nil {
+		return models.PartyDetail{}, err
+	}
+	detail := models.PartyDetail{Party: party}
+	var partial partialReads
+
+	if detail.Summary, err = rd.GetPartySummary(party); partial.failed(err) {
+		return detail, err
+	}
+	if limit, ok := includes["members"]; ok {
+		if detail.Members, err = rd.GetPartyMembers(id, true, limit); partial.failed(err) {
+			return detail, err
+		}
+	}
+	if limit, ok := includes["former_members"]; ok {
+		if detail.FormerMembers, err = rd.GetPartyMembers(id, false, limit); partial.failed(err) {
+			return detail, err
+		}
+	}
+	if limit, ok := includes["funds"]; ok {
+		if detail.Funds, err = rd.GetPartyFunds(party.Sigla, limit); partial.failed(err) {
+			return detail, err
+		}
+	}
+	detail.Provenance = detailProvenance(rd, models.ProvenanceParty, strconv.Itoa(id))
+
+	return detail, partial.err()
+}
+
+// GetCompany handles GET /api/companies/:cnpj - the company dossier: profile, every politician
+// who paid it, all its sanctions (expired ones included) and its owners when QSA data exists
+func GetCompany(c *gin.Context) {
+	start := time.Now()
+
+	cnpj := normalizeCNPJ(c.Param("cnpj"))
+	if !cnpjPattern.MatchString(cnpj) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid CNPJ (expected 14 digits)",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	rd, release, ok := datasetReader(c, start)
+	if !ok {
+		return
+	}
+	defer release()
+
+	cacheKey := versionedCacheKey(rd, "company_detail", cnpj)
+
+	detail, err := loadCached(c, cacheKey, config.CacheTTL("company_detail"), func() (models.CompanyDetail, error) {
+		return buildCompanyDetail(rd, cnpj)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Company not found",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch company: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	detail = privacyPolicy(c, "company").CompanyDetail(detail)
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success:     true,
+		Data:        detail,
+		Degraded:    degraded(c),
+		DroppedRows: droppedRows(c),
+		Time:        time.Since(start).String(),
+	})
+}
+
+// buildCompanyDetail loads a company and every collection of its dossier
+func buildCompanyDetail(rd database.Repository, cnpj string) (models.CompanyDetail, error) {
+	company, err := rd.GetCompany(cnpj)
+	if err != nil {
+		return models.CompanyDetail{}, err
+	}
+	detail := models.CompanyDetail{Company: company}
+	var partial partialReads
+
+	if detail.Payers, err = rd.GetCompanyPayers(cnpj); partial.failed(err) {
+		return detail, err
+	}
+	if detail.Sanctions, err = rd.GetCompanySanctions(cnpj); partial.failed(err) {
+		return detail, err
+	}
+	if detail.TCURulings, err = rd.GetTCURulings(database.TCURulingFilter{CNPJ: cnpj}, 100, 0); partial.failed(err) {
+		return detail, err
+	}
+	if detail.PublicLoans, err = rd.GetCompanyPublicLoans(cnpj, 100); partial.failed(err) {
+		return detail, err
+	}
+	if detail.LoanTotals, err = rd.GetCompanyLoanTotals(cnpj); partial.failed(err) {
+		return detail, err
+	}
+	if detail.Owners, err = rd.GetCompanyOwners(cnpj); partial.failed(err) {
+		return detail, err
+	}
+	detail.Provenance = detailProvenance(rd, models.ProvenanceCompany, cnpj)
+
+	return detail, partial.err()
+}
@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SubscribeDigest handles POST /api/watchlists/:id/digests - subscribes an email address, or the
+// watchlist's own, to a weekly digest of new sanctions, score changes and unusual expenses
+func SubscribeDigest(c *gin.Context) {
+	start := time.Now()
+
+	watchlist, ok := ownedWatchlist(c, start)
+	if !ok {
+		return
+	}
+
+	var req models.DigestRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   "Invalid digest subscription: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+	}
+	if req.Email == "" {
+		req.Email = watchlist.Email
+	}
+	addr, err := mail.ParseAddress(req.Email)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid digest subscription",
+			Errors:  map[string]string{"email": "must be an email address (the watchlist has none)"},
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	// The digest's links are built on the configured address, not the request's Host
+	baseURL := publicURL()
+	if baseURL == "" {
+		c.JSON(http.StatusServiceUnavailable, models.APIResponse{
+			Success: false,
+			Error:   "Digests are not configured on this server (server.public_url)",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to generate unsubscribe token: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	digest := models.WatchlistDigest{WatchlistID: watchlist.ID, Email: addr.Address}
+	if err := database.SubscribeDigest(&digest, token, baseURL); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	audit(c, "digest_subscribe", "watchlists/"+strconv.FormatInt(watchlist.ID, 10), map[string]interface{}{
+		"digest": digest.ID,
+	})
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    digest,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// GetDigests handles GET /api/watchlists/:id/digests - the watchlist's active digest subscriptions
+func GetDigests(c *gin.Context) {
+	start := time.Now()
+
+	watchlist, ok := ownedWatchlist(c, start)
+	if !ok {
+		return
+	}
+
+	digests, err := database.GetDigests(watchlist.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    digests,
+		Count:   len(digests),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// DeleteDigest handles DELETE /api/watchlists/:id/digests/:digest
+func DeleteDigest(c *gin.Context) {
+	start := time.Now()
+
+	watchlist, ok := ownedWatchlist(c, start)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("digest"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid digest id",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	deleted, err := database.DeleteDigest(watchlist.ID, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Digest not found",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	audit(c, "digest_delete", "watchlists/"+strconv.FormatInt(watchlist.ID, 10), map[string]interface{}{
+		"digest": id,
+	})
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"deleted": id},
+		Time:    time.Since(start).String(),
+	})
+}
+
+// unsubscribePage asks to confirm an unsubscribe link, whose GET mustn't act since link scanners
+// and prefetchers follow it, and shows the result of the form's POST
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>Weekly digest of {{.Watchlist}}</title>
+</head>
+<body>
+{{if .Done}}
+<p>{{.Email}} no longer gets the weekly digest of {{.Watchlist}}.</p>
+{{else}}
+<p>Stop sending the weekly digest of {{.Watchlist}} to {{.Email}}?</p>
+<form method="post" action="{{.Action}}">
+<button type="submit">Unsubscribe</button>
+</form>
+{{end}}
+</body>
+</html>
+`))
+
+// renderUnsubscribePage writes unsubscribePage for the digest of watchlist to email
+func renderUnsubscribePage(c *gin.Context, start time.Time, watchlist, email string, done bool) {
+	var page bytes.Buffer
+	err := unsubscribePage.Execute(&page, struct {
+		Watchlist, Email, Action string
+		Done                     bool
+	}{watchlist, email, c.Request.URL.Path + "?token=" + url.QueryEscape(c.Query("token")), done})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to render unsubscribe page: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
+
+// UnsubscribeDigestPage handles GET /api/digests/unsubscribe?token= - the link in every digest,
+// a page confirming the unsubscribe with a POST
+func UnsubscribeDigestPage(c *gin.Context) {
+	start := time.Now()
+
+	watchlist, email, err := database.GetDigestByToken(c.Query("token"))
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Unknown unsubscribe link",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	renderUnsubscribePage(c, start, watchlist, email, false)
+}
+
+// UnsubscribeDigest handles POST /api/digests/unsubscribe?token= - the one-click unsubscribe mail
+// clients send for List-Unsubscribe-Post (RFC 8058), and the confirmation page's form, which
+// gets the page back
+func UnsubscribeDigest(c *gin.Context) {
+	start := time.Now()
+
+	watchlist, email, err := database.UnsubscribeDigest(c.Query("token"))
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Unknown unsubscribe link",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	if c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) == gin.MIMEHTML {
+		renderUnsubscribePage(c, start, watchlist, email, true)
+		return
+	}
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"unsubscribed": true, "watchlist": watchlist, "email": email},
+		Time:    time.Since(start).String(),
+	})
+}
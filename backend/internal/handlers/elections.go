@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"political-network-api/internal/database"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseElectionFilter reads the latest-election filters of politician lists into filter,
+// recording invalid values in fieldErrors: election_year, min_votes and max_votes (nominal
+// votes), elected=true|false and min_spending_percentile (campaign spending percentile among
+// politicians of the same election, e.g. 90 for the top decile)
+func parseElectionFilter(c *gin.Context, filter *database.PoliticianFilter, fieldErrors map[string]string) {
+	if raw, ok := c.GetQuery("election_year"); ok {
+		year, err := strconv.Atoi(raw)
+		if err != nil || year < 1945 || year > 2100 {
+			fieldErrors["election_year"] = "must be a year such as 2022"
+		}
+		filter.ElectionYear = year
+	}
+
+	for name, dst := range map[string]**int{
+		"min_votes": &filter.MinVotes,
+		"max_votes": &filter.MaxVotes,
+	} {
+		raw, ok := c.GetQuery(name)
+		if !ok {
+			continue
+		}
+		votes, err := strconv.Atoi(raw)
+		if err != nil || votes < 0 {
+			fieldErrors[name] = "must be a non-negative integer"
+			continue
+		}
+		*dst = &votes
+	}
+	if filter.MinVotes != nil && filter.MaxVotes != nil && *filter.MinVotes > *filter.MaxVotes {
+		fieldErrors["min_votes"] = "must not exceed max_votes"
+	}
+
+	if raw, ok := c.GetQuery("elected"); ok {
+		elected, err := strconv.ParseBool(raw)
+		if err != nil {
+			fieldErrors["elected"] = "must be true or false"
+		} else {
+			filter.Elected = &elected
+		}
+	}
+
+	if raw, ok := c.GetQuery("min_spending_percentile"); ok {
+		pct, err := strconv.ParseFloat(raw, 64)
+		if err != nil || pct < 0 || pct > 100 {
+			fieldErrors["min_spending_percentile"] = "must be a percentile between 0 and 100"
+		} else {
+			filter.MinSpendingPercentile = &pct
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetEntityLinks handles GET /api/admin/entity-links - cross-source links found by
+// resolve-entities (?status=suggested for the review queue, ?source=, ?entity_type=,
+// ?entity_id=, ?min_confidence=). Not cached: reviewers need to see their own changes.
+func GetEntityLinks(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.EntityLink](c, params.Fields) {
+		return
+	}
+
+	filter := database.EntityLinkFilter{
+		Status:     c.Query("status"),
+		Source:     strings.ToUpper(c.Query("source")),
+		EntityType: c.Query("entity_type"),
+		EntityID:   c.Query("entity_id"),
+	}
+	errs := map[string]string{}
+	if filter.Status != "" && filter.Status != models.LinkSuggested &&
+		filter.Status != models.LinkConfirmed && filter.Status != models.LinkRejected {
+		errs["status"] = "must be suggested, confirmed or rejected"
+	}
+	if filter.Source != "" && !slices.Contains(models.LinkSources, filter.Source) {
+		errs["source"] = "must be one of " + strings.Join(models.LinkSources, ", ")
+	}
+	if filter.EntityType != "" && filter.EntityType != models.LinkPolitician && filter.EntityType != models.LinkCompany {
+		errs["entity_type"] = "must be politician or company"
+	}
+	if v := c.Query("min_confidence"); v != "" {
+		confidence, err := strconv.ParseFloat(v, 64)
+		if err != nil || confidence < 0 || confidence > 1 {
+			errs["min_confidence"] = "must be a number between 0 and 1"
+		}
+		filter.MinConfidence = confidence
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	links, err := database.GetEntityLinks(filter, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch entity links: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	respondList(c, start, links, params.Fields)
+}
+
+// ReviewEntityLink handles PATCH /api/admin/entity-links/:id - confirms or rejects a link.
+// Confirming rejects the record's other open candidates.
+func ReviewEntityLink(c *gin.Context) {
+	start := time.Now()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid link id",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	var req models.EntityLinkReview
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid review: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	fieldErrors := map[string]string{}
+	if req.Status != models.LinkConfirmed && req.Status != models.LinkRejected {
+		fieldErrors["status"] = "must be confirmed or rejected"
+	}
+	if len(req.Note) > 1000 {
+		fieldErrors["note"] = "must be at most 1000 characters"
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid review",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	link, err := database.GetEntityLink(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Entity link not found",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch entity link: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	if err := database.ReviewEntityLink(id, req.Status, strings.TrimSpace(req.Note), middleware.Actor(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	audit(c, "entity_link_review", "entity-links/"+strconv.FormatInt(id, 10), map[string]interface{}{
+		"status":     req.Status,
+		"previous":   link.Status,
+		"source":     link.Source,
+		"entity":     link.EntityType + ":" + link.EntityID,
+		"confidence": link.Confidence,
+	})
+	// Confirmed Receita partners are shown as politicians among a company's owners
+	if link.Source == "RECEITA" {
+		utils.DeleteCachePrefix("company_detail")
+	}
+
+	reviewed, err := database.GetEntityLink(id)
+	if err != nil {
+		reviewed = link
+	}
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    reviewed,
+		Time:    time.Since(start).String(),
+	})
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/export"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// msgPackMIME is the MessagePack media type
+const msgPackMIME = "application/msgpack"
+
+// utf8BOM makes spreadsheet applications detect UTF-8 (accented names)
+const utf8BOM = "\ufeff"
+
+// wantsCSV reports whether the client asked for CSV via ?format=csv or the Accept header
+func wantsCSV(c *gin.Context) bool {
+	if format := c.Query("format"); format != "" {
+		return format == "csv"
+	}
+	return c.NegotiateFormat(gin.MIMEJSON, "text/csv") == "text/csv"
+}
+
+// wantsMsgPack reports whether the client asked for MessagePack via ?format=msgpack or the
+// Accept header
+func wantsMsgPack(c *gin.Context) bool {
+	if format := c.Query("format"); format != "" {
+		return format == "msgpack"
+	}
+	negotiated := c.NegotiateFormat(gin.MIMEJSON, msgPackMIME, "application/x-msgpack")
+	return negotiated == msgPackMIME || negotiated == "application/x-msgpack"
+}
+
+// cachedMsgPack is a network encoded as MessagePack, valid while its source network is the
+// cached one
+type cachedMsgPack struct {
+	source *models.NetworkResponse
+	data   []byte
+}
+
+// writeNetworkMsgPack sends the network in the JSON response's envelope, encoded as
+// MessagePack. Encoding a multi-MB graph is costly, so the encoded data is kept for as long
+// as the network it came from stays cached. A tag-filtered network is encoded apart from the
+// whole one.
+func writeNetworkMsgPack(c *gin.Context, start time.Time, source, network *models.NetworkResponse, fields []string, tag string) {
+	cacheKey := utils.CacheKey("network_msgpack", strings.Join(fields, ","), tag)
+
+	var data []byte
+	if cached, found := utils.GetCache(cacheKey); found && cached.(cachedMsgPack).source == source {
+		data = cached.(cachedMsgPack).data
+	} else {
+		encoded, err := json.Marshal(network)
+		if err == nil {
+			data, err = export.MsgPackFromJSON(encoded)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to encode network data: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+		utils.SetCache(cacheKey, cachedMsgPack{source: source, data: data}, config.CacheTTL("network"))
+	}
+
+	var body bytes.Buffer
+	body.Grow(len(data) + 64)
+	export.MsgPackMapHeader(&body, 3)
+	export.MsgPackString(&body, "success")
+	export.MsgPackBool(&body, true)
+	export.MsgPackString(&body, "data")
+	body.Write(data)
+	export.MsgPackString(&body, "processing_time")
+	export.MsgPackString(&body, time.Since(start).String())
+
+	c.Data(http.StatusOK, msgPackMIME, body.Bytes())
+}
+
+// writeCSV streams items as a CSV attachment named after the resource
+func writeCSV[T any](c *gin.Context, name string, columns []export.CSVColumn[T], items []T) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", "attachment; filename="+name+".csv")
+	c.Status(http.StatusOK)
+
+	if _, err := io.WriteString(c.Writer, utf8BOM); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := export.WriteCSV(c.Writer, columns, items); err != nil {
+		c.Error(err)
+	}
+}
+
+// graphExporters maps supported ?format= values to their writers and file metadata
+var graphExporters = map[string]struct {
+	write       func(io.Writer, *models.NetworkResponse) error
+	contentType string
+	extension   string
+}{
+	"graphml": {export.WriteGraphML, "application/graphml+xml", "graphml"},
+	"gexf":    {export.WriteGEXF, "application/gexf+xml", "gexf"},
+}
+
+// ExportNetwork handles GET /api/network/export?format=graphml|gexf
+func ExportNetwork(c *gin.Context) {
+	start := time.Now()
+
+	format := c.DefaultQuery("format", "graphml")
+	exporter, ok := graphExporters[format]
+	if !ok {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Unsupported export format: " + format + " (use graphml or gexf)",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	networkData, err := getNetworkData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to build network data: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", exporter.contentType)
+	c.Header("Content-Disposition", "attachment; filename=political-network."+exporter.extension)
+	c.Status(http.StatusOK)
+
+	if err := exporter.write(c.Writer, networkData); err != nil {
+		c.Error(err)
+	}
+}
+
+// ExportNeo4j handles GET /api/admin/export/neo4j - streams the full entity/edge model as Cypher
+func ExportNeo4j(c *gin.Context) {
+	start := time.Now()
+
+	// Connections are built before streaming so failures can still be reported as JSON
+	connections, err := publicConnections(repos.Network.GetAllConnections)()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to build connections: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Header("Content-Disposition", "attachment; filename=political-network.cypher")
+	c.Status(http.StatusOK)
+
+	cw := export.NewCypherWriter(c.Writer, 1000)
+	cw.WriteSchema()
+
+	err = firstError(
+		func() error {
+			return protectEach(c, "politicians", database.EachPolitician)(func(p models.Politician) error { cw.WritePolitician(p); return cw.Err() })
+		},
+		func() error {
+			return database.EachParty(func(p models.Party) error { cw.WriteParty(p); return cw.Err() })
+		},
+		func() error {
+			return database.EachCompany(func(co models.Company) error { cw.WriteCompany(co); return cw.Err() })
+		},
+		func() error {
+			return database.EachCompanyGroup(func(g models.CompanyGroup) error { cw.WriteCompanyGroup(g); return cw.Err() })
+		},
+		func() error {
+			return protectEach(c, "sanctions", database.EachSanction)(func(s models.Sanction) error { cw.WriteSanction(s); return cw.Err() })
+		},
+		func() error {
+			return database.EachTopic(func(t models.Topic) error { cw.WriteTopic(t); return cw.Err() })
+		},
+		func() error {
+			return database.EachFront(func(f models.Front) error { cw.WriteFront(f); return cw.Err() })
+		},
+		func() error {
+			return database.EachTCURuling(func(r models.TCURuling) error { cw.WriteTCURuling(r); return cw.Err() })
+		},
+		func() error {
+			return database.EachPublicBank(func(b models.PublicBank) error { cw.WritePublicBank(b); return cw.Err() })
+		},
+	)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	for _, conn := range connections {
+		cw.WriteConnection(conn)
+	}
+
+	if err := cw.Close(); err != nil {
+		c.Error(err)
+	}
+}
+
+// ExportParquet handles GET /api/export/parquet/:dataset - streams financial_records or connections as parquet
+func ExportParquet(c *gin.Context) {
+	start := time.Now()
+	dataset := c.Param("dataset")
+
+	var write func(io.Writer) error
+	switch dataset {
+	case "financial_records":
+		write = func(w io.Writer) error {
+			return export.WriteFinancialRecordsParquet(w, protectEach(c, "financial_records", database.EachFinancialRecord))
+		}
+	case "connections":
+		connections, err := publicConnections(repos.Network.GetAllConnections)()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to build connections: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+		write = func(w io.Writer) error {
+			return export.WriteConnectionsParquet(w, export.EachOf(connections))
+		}
+	default:
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Unknown parquet dataset: " + dataset + " (use financial_records or connections)",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", "application/vnd.apache.parquet")
+	c.Header("Content-Disposition", "attachment; filename="+dataset+".parquet")
+	c.Status(http.StatusOK)
+
+	if err := write(c.Writer); err != nil {
+		c.Error(err)
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/export"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// feedEntries is how many entries each feed carries
+const feedEntries = 50
+
+// GetSanctionFeed handles GET /feeds/sanctions.atom - newly imported sanctions against
+// companies that sitting politicians have paid
+func GetSanctionFeed(c *gin.Context) {
+	serveFeed(c, export.AtomFeed{
+		ID:       "urn:open-data-gov:feeds:sanctions",
+		Title:    "New sanctions against companies paid by sitting politicians",
+		Subtitle: "CEIS, CNEP and CEPIM sanctions from the Portal da Transparência",
+		Path:     "/feeds/sanctions.atom",
+	}, database.GetSanctionFeed)
+}
+
+// GetAlertFeed handles GET /feeds/alerts.atom - high-risk events involving sitting politicians
+func GetAlertFeed(c *gin.Context) {
+	serveFeed(c, export.AtomFeed{
+		ID:       "urn:open-data-gov:feeds:alerts",
+		Title:    "High-risk events involving sitting politicians",
+		Subtitle: "Payments to sanctioned companies and TCU disqualifications",
+		Path:     "/feeds/alerts.atom",
+	}, database.GetAlertFeed)
+}
+
+// serveFeed fills feed from the cache or fetch and writes it as Atom. Entries only change when
+// the ETL pipeline loads new data, so they are cached per feed.
+func serveFeed(c *gin.Context, feed export.AtomFeed, fetch func(limit int) ([]models.FeedEntry, error)) {
+	start := time.Now()
+
+	cacheKey := utils.CacheKey("feeds", feed.Path)
+
+	var entries []models.FeedEntry
+	if cached, found := utils.GetCache(cacheKey); found {
+		entries = cached.([]models.FeedEntry)
+	} else {
+		var err error
+		entries, err = fetch(feedEntries)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to build feed: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, entries, config.CacheTTL("feeds"))
+	}
+
+	feed.BaseURL = requestBaseURL(c)
+	feed.Entries = entries
+
+	c.Header("Content-Type", "application/atom+xml; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := export.WriteAtom(c.Writer, feed); err != nil {
+		c.Error(err)
+	}
+}
+
+// publicURL is server.public_url, the base of links that leave the response, in emails: unlike
+// requestBaseURL it can't be steered by a request's Host or X-Forwarded-Proto
+func publicURL() string {
+	return strings.TrimSuffix(config.Get().Server.PublicURL, "/")
+}
+
+// requestBaseURL is the scheme and host the client used, honoring a TLS-terminating proxy
+func requestBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"political-network-api/internal/export"
+	"political-network-api/internal/models"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// splitFields accepts both ?fields=a,b and repeated ?fields=a&fields=b
+func splitFields(values []string) []string {
+	var fields []string
+	for _, v := range values {
+		for _, f := range strings.Split(v, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields
+}
+
+// jsonFieldNames lists the JSON keys a struct type serializes to
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if tag != "" && tag != "-" {
+			names[tag] = true
+		}
+	}
+	return names
+}
+
+// validateFields rejects ?fields= entries that T does not have, writing a 400
+func validateFields[T any](c *gin.Context, fields []string) bool {
+	if len(fields) == 0 {
+		return true
+	}
+
+	known := jsonFieldNames(reflect.TypeOf((*T)(nil)).Elem())
+	var unknown []string
+	for _, f := range fields {
+		if !known[f] {
+			unknown = append(unknown, f)
+		}
+	}
+	if len(unknown) == 0 {
+		return true
+	}
+
+	c.JSON(http.StatusBadRequest, models.APIResponse{
+		Success: false,
+		Error:   "Invalid query parameters",
+		Errors:  map[string]string{"fields": "unknown field(s): " + strings.Join(unknown, ", ")},
+		Time:    "0ms",
+	})
+	return false
+}
+
+// projectFields keeps only the requested JSON keys of v
+func projectFields(v interface{}, fields []string) (map[string]json.RawMessage, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return nil, err
+	}
+
+	projected := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		if value, ok := all[f]; ok {
+			projected[f] = value
+		}
+	}
+	return projected, nil
+}
+
+// listData returns items unchanged, or projected to fields when a sparse fieldset was requested
+func listData[T any](items []T, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return items, nil
+	}
+
+	projected := make([]map[string]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		p, err := projectFields(item, fields)
+		if err != nil {
+			return nil, err
+		}
+		projected = append(projected, p)
+	}
+	return projected, nil
+}
+
+// paginate applies ?limit= and ?offset= to a list computed in memory
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+	items = items[offset:]
+	if limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// respondList writes a successful list response honoring ?fields=
+func respondList[T any](c *gin.Context, start time.Time, items []T, fields []string) {
+	data, err := listData(items, fields)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to select fields: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success:     true,
+		Data:        data,
+		Count:       len(items),
+		Degraded:    degraded(c),
+		DroppedRows: droppedRows(c),
+		NextCursor:  nextCursor(c),
+		Time:        time.Since(start).String(),
+	})
+}
+
+// selectColumns restricts CSV columns to fields, preserving the requested order
+func selectColumns[T any](columns []export.CSVColumn[T], fields []string) []export.CSVColumn[T] {
+	if len(fields) == 0 {
+		return columns
+	}
+
+	byName := make(map[string]export.CSVColumn[T], len(columns))
+	for _, col := range columns {
+		byName[col.Name] = col
+	}
+
+	var selected []export.CSVColumn[T]
+	for _, f := range fields {
+		if col, ok := byName[f]; ok {
+			selected = append(selected, col)
+		}
+	}
+	return selected
+}
+
+// projectedNodeData is node data reduced to a sparse fieldset
+type projectedNodeData struct {
+	nodeType models.NodeType
+	fields   map[string]json.RawMessage
+}
+
+// NodeType implements models.NodeData
+func (p projectedNodeData) NodeType() models.NodeType { return p.nodeType }
+
+// MarshalJSON renders only the selected fields
+func (p projectedNodeData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.fields)
+}
+
+// projectNetwork returns a copy of the network whose node data keeps only fields.
+// Field names are matched per node type, so mixed lists like id,nome,nome_empresa work.
+func projectNetwork(network *models.NetworkResponse, fields []string) (*models.NetworkResponse, error) {
+	if len(fields) == 0 {
+		return network, nil
+	}
+
+	projected := *network
+	projected.Nodes = make([]models.NetworkNode, len(network.Nodes))
+	for i, node := range network.Nodes {
+		data, err := projectFields(node.Data, fields)
+		if err != nil {
+			return nil, err
+		}
+		node.Data = projectedNodeData{nodeType: node.Type, fields: data}
+		projected.Nodes[i] = node
+	}
+	return &projected, nil
+}
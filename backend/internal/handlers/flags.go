@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetFlags handles GET /api/flags - the feature flags on for the caller, so clients can show
+// the experimental features they may use
+func GetFlags(c *gin.Context) {
+	start := time.Now()
+
+	flags := []string{}
+	for name := range config.Get().Flags {
+		if middleware.FlagOn(c, name) {
+			flags = append(flags, name)
+		}
+	}
+	sort.Strings(flags)
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    flags,
+		Count:   len(flags),
+		Time:    time.Since(start).String(),
+	})
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetFlows handles GET /api/analysis/flows - expenses paid to companies aggregated through
+// tiers for Sankey/chord diagrams (?group_by=party,sector,company from party, uf, politician,
+// sector and company; ?top= nodes per tier, default 20; ?source=deputados|tse; ?year=)
+func GetFlows(c *gin.Context) {
+	start := time.Now()
+
+	filter := database.FlowFilter{Top: 20}
+	errs := map[string]string{}
+	for _, tier := range strings.Split(c.DefaultQuery("group_by", "party,sector,company"), ",") {
+		tier = strings.ToLower(strings.TrimSpace(tier))
+		if !database.ValidFlowTier(tier) || slices.Contains(filter.Tiers, tier) {
+			errs["group_by"] = "must list 2 to 4 distinct tiers of party, uf, politician, sector and company"
+			break
+		}
+		filter.Tiers = append(filter.Tiers, tier)
+	}
+	if len(filter.Tiers) < 2 || len(filter.Tiers) > 4 {
+		errs["group_by"] = "must list 2 to 4 distinct tiers of party, uf, politician, sector and company"
+	}
+	if v := c.Query("top"); v != "" {
+		top, err := strconv.Atoi(v)
+		if err != nil || top < 1 || top > 100 {
+			errs["top"] = "must be an integer from 1 to 100"
+		}
+		filter.Top = top
+	}
+	if v := c.Query("source"); v != "" {
+		source, ok := sectorSourceNames[strings.ToLower(v)]
+		if !ok {
+			errs["source"] = "must be deputados or tse"
+		}
+		filter.Source = source
+	}
+	if v := c.Query("year"); v != "" {
+		year, err := strconv.Atoi(v)
+		if err != nil || year < 1945 || year > 2100 {
+			errs["year"] = "must be a year such as 2022"
+		}
+		filter.Year = year
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	cacheKey := utils.CacheKey("flows", filter)
+
+	var diagram models.FlowDiagram
+	if cached, found := utils.GetCache(cacheKey); found {
+		diagram = cached.(models.FlowDiagram)
+	} else {
+		var err error
+		diagram, err = database.GetFlows(filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to aggregate flows: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, diagram, config.CacheTTL("flows"))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    diagram,
+		Count:   len(diagram.Links),
+		Time:    time.Since(start).String(),
+	})
+}
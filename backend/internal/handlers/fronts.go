@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// frontIncludes are the collections GET /api/fronts/:id can embed
+var frontIncludes = map[string]relationLimit{
+	"members": {Default: 100, Max: 1000},
+}
+
+// GetFronts handles GET /api/fronts - frentes parlamentares, largest first (?legislatura=)
+func GetFronts(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.Front](c, params.Fields) {
+		return
+	}
+
+	legislatura := 0
+	if v := c.Query("legislatura"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   "Invalid query parameters",
+				Errors:  map[string]string{"legislatura": "must be a legislature number such as 57"},
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+		legislatura = n
+	}
+
+	cacheKey := utils.CacheKey("fronts", legislatura, params.Limit, params.Offset)
+
+	var fronts []models.Front
+	if cached, found := utils.GetCache(cacheKey); found {
+		fronts = cached.([]models.Front)
+	} else {
+		var err error
+		fronts, err = database.GetFronts(legislatura, params.Limit, params.Offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch fronts: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, fronts, config.CacheTTL("fronts"))
+	}
+
+	respondList(c, start, fronts, params.Fields)
+}
+
+// GetFront handles GET /api/fronts/:id - one frente parlamentar (?include=members)
+func GetFront(c *gin.Context) {
+	start := time.Now()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid front id",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	includes, ok := parseIncludes(c, frontIncludes)
+	if !ok {
+		return
+	}
+
+	cacheKey := utils.CacheKey("front_detail", id, includesKey(includes))
+
+	var detail models.FrontDetail
+	if cached, found := utils.GetCache(cacheKey); found {
+		detail = cached.(models.FrontDetail)
+	} else {
+		detail, err = buildFrontDetail(id, includes)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   "Front not found",
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch front: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, detail, config.CacheTTL("fronts"))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    detail,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// buildFrontDetail loads a front and the requested collections
+func buildFrontDetail(id int, includes map[string]int) (models.FrontDetail, error) {
+	front, err := database.GetFront(id)
+	if err != nil {
+		return models.FrontDetail{}, err
+	}
+	detail := models.FrontDetail{Front: front}
+
+	if limit, ok := includes["members"]; ok {
+		if detail.Members, err = database.GetFrontMembers(id, limit); err != nil {
+			return detail, err
+		}
+	}
+
+	return detail, nil
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var ufPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// GetGeoSpending handles GET /api/geo/spending - a GeoJSON FeatureCollection of every state
+// or municipality (?level=state|municipality) keyed by IBGE code, with the expenses paid to
+// vendors registered there and the amendments paid to it, for choropleth maps. ?uf=, ?year=
+// and ?politician_id= narrow the areas and spending. Errors keep the usual JSON envelope.
+func GetGeoSpending(c *gin.Context) {
+	start := time.Now()
+
+	filter := database.GeoSpendingFilter{
+		Level: c.DefaultQuery("level", "state"),
+		UF:    strings.ToUpper(c.Query("uf")),
+	}
+	errs := map[string]string{}
+	if !database.ValidGeoLevel(filter.Level) {
+		errs["level"] = "must be state or municipality"
+	}
+	if filter.UF != "" && !ufPattern.MatchString(filter.UF) {
+		errs["uf"] = "must be a state abbreviation such as SP"
+	}
+	if raw, ok := c.GetQuery("year"); ok {
+		year, err := strconv.Atoi(raw)
+		if err != nil || year < 1945 || year > 2100 {
+			errs["year"] = "must be a year such as 2022"
+		}
+		filter.Year = year
+	}
+	if raw, ok := c.GetQuery("politician_id"); ok {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id < 1 {
+			errs["politician_id"] = "must be a positive integer"
+		}
+		filter.PoliticianID = id
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	cacheKey := utils.CacheKey("geo_spending", filter)
+
+	var features []models.Feature
+	if cached, found := utils.GetCache(cacheKey); found {
+		features = cached.([]models.Feature)
+	} else {
+		var err error
+		features, err = database.GetGeoSpending(filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to get geo spending: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, features, config.CacheTTL("geo_spending"))
+	}
+
+	// gin keeps a Content-Type that is already set
+	c.Header("Content-Type", "application/geo+json; charset=utf-8")
+	c.JSON(http.StatusOK, models.FeatureCollection{
+		Type:     "FeatureCollection",
+		Features: features,
+	})
+}
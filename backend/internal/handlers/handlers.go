@@ -2,8 +2,12 @@ package handlers
 
 import (
 	"net/http"
+	"political-network-api/internal/config"
 	"political-network-api/internal/database"
+	"political-network-api/internal/export"
 	"political-network-api/internal/models"
+	"political-network-api/internal/privacy"
+	"political-network-api/internal/progress"
 	"political-network-api/internal/utils"
 	"strconv"
 	"time"
@@ -15,31 +19,31 @@ import (
 func GetPoliticians(c *gin.Context) {
 	start := time.Now()
 
-	// Parse query parameters
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "500"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
-	// Validate limits
-	if limit > 1000 {
-		limit = 1000
+	// Parse and validate query parameters
+	params, ok := bindQueryParams(c, 500, 1000)
+	if !ok {
+		return
 	}
-
-	// Cache key
-	cacheKey := utils.CacheKey("politicians", limit, offset)
-
-	// Try cache first
-	if cached, found := utils.GetCache(cacheKey); found {
-		c.JSON(http.StatusOK, models.APIResponse{
-			Success: true,
-			Data:    cached,
-			Count:   len(cached.([]models.Politician)),
-			Time:    time.Since(start).String(),
-		})
+	if !validateFields[models.Politician](c, params.Fields) {
 		return
 	}
+	filter, ok := parsePoliticianFilter(c, start)
+	if !ok {
+		return
+	}
+	if !politicianCursor(c, start, &filter) {
+		return
+	}
+	if filter.IDs, _, ok = taggedEntities(c, start); !ok {
+		return
+	}
+	rd, release, ok := datasetReader(c, start)
+	if !ok {
+		return
+	}
+	defer release()
 
-	// Query database
-	politicians, err := database.GetPoliticians(limit, offset)
+	politicians, err := loadPoliticians(c, rd, params, filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
@@ -48,42 +52,40 @@ func GetPoliticians(c *gin.Context) {
 		})
 		return
 	}
+	markNextPoliticians(c, politicians, params.Limit, filter.Sort)
+	politicians = protectItems(c, "politicians", politicians)
+	politicians = identifyPoliticians(c, rd, politicians)
 
-	// Cache result for 15 minutes
-	utils.SetCache(cacheKey, politicians, 15*time.Minute)
+	if wantsCSV(c) {
+		writeCSV(c, "politicians", selectColumns(export.PoliticianColumns, params.Fields), politicians)
+		return
+	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
-		Success: true,
-		Data:    politicians,
-		Count:   len(politicians),
-		Time:    time.Since(start).String(),
-	})
+	respondList(c, start, politicians, params.Fields)
 }
 
 // GetParties handles GET /api/parties
 func GetParties(c *gin.Context) {
 	start := time.Now()
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
-	if limit > 1000 {
-		limit = 1000
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
 	}
-
-	cacheKey := utils.CacheKey("parties", limit, offset)
-
-	if cached, found := utils.GetCache(cacheKey); found {
-		c.JSON(http.StatusOK, models.APIResponse{
-			Success: true,
-			Data:    cached,
-			Count:   len(cached.([]models.Party)),
-			Time:    time.Since(start).String(),
-		})
+	if !validateFields[models.Party](c, params.Fields) {
 		return
 	}
+	after, ok := listCursor(c, start, "parties")
+	if !ok {
+		return
+	}
+	rd, release, ok := datasetReader(c, start)
+	if !ok {
+		return
+	}
+	defer release()
 
-	parties, err := database.GetParties(limit, offset)
+	parties, err := loadParties(c, rd, params, after)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
@@ -92,120 +94,205 @@ func GetParties(c *gin.Context) {
 		})
 		return
 	}
+	markNextPage(c, "parties", parties, params.Limit, database.PartyCursor)
 
-	utils.SetCache(cacheKey, parties, 20*time.Minute)
-
-	c.JSON(http.StatusOK, models.APIResponse{
-		Success: true,
-		Data:    parties,
-		Count:   len(parties),
-		Time:    time.Since(start).String(),
-	})
+	respondList(c, start, parties, params.Fields)
 }
 
 // GetCompanies handles GET /api/companies
 func GetCompanies(c *gin.Context) {
 	start := time.Now()
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "500"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
-	if limit > 1000 {
-		limit = 1000
+	params, ok := bindQueryParams(c, 500, 1000)
+	if !ok {
+		return
 	}
+	if !validateFields[models.Company](c, params.Fields) {
+		return
+	}
+	target, ok := adjustTarget(c, start)
+	if !ok {
+		return
+	}
+	sector, ok := parseSector(c, start)
+	if !ok {
+		return
+	}
+	_, tagged, ok := taggedEntities(c, start)
+	if !ok {
+		return
+	}
+	filter := database.CompanyFilter{Sector: sector, CNPJs: tagged}
+	if filter.After, ok = listCursor(c, start, "companies"); !ok {
+		return
+	}
+	rd, release, ok := datasetReader(c, start)
+	if !ok {
+		return
+	}
+	defer release()
 
-	cacheKey := utils.CacheKey("companies", limit, offset)
+	cacheKey := versionedCacheKey(rd, "companies", params.Limit, params.Offset, filter)
 
-	if cached, found := utils.GetCache(cacheKey); found {
-		c.JSON(http.StatusOK, models.APIResponse{
-			Success: true,
-			Data:    cached,
-			Count:   len(cached.([]models.Company)),
+	companies, err := loadCached(c, cacheKey, config.CacheTTL("companies"), func() ([]models.Company, error) {
+		return rd.GetCompanies(params.Limit, params.Offset, filter)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch companies: " + err.Error(),
 			Time:    time.Since(start).String(),
 		})
 		return
 	}
+	markNextPage(c, "companies", companies, params.Limit, database.CompanyCursor)
 
-	companies, err := database.GetCompanies(limit, offset)
+	companies, err = adjustCompanies(target, companies)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
-			Error:   "Failed to fetch companies: " + err.Error(),
+			Error:   "Failed to adjust company totals: " + err.Error(),
 			Time:    time.Since(start).String(),
 		})
 		return
 	}
 
-	utils.SetCache(cacheKey, companies, 25*time.Minute)
+	if wantsCSV(c) {
+		columns := export.CompanyColumns
+		if target != nil {
+			columns = export.AdjustedCompanyColumns
+		}
+		writeCSV(c, "companies", selectColumns(columns, params.Fields), companies)
+		return
+	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
-		Success: true,
-		Data:    companies,
-		Count:   len(companies),
-		Time:    time.Since(start).String(),
-	})
+	respondList(c, start, companies, params.Fields)
 }
 
 // GetSanctions handles GET /api/sanctions
 func GetSanctions(c *gin.Context) {
 	start := time.Now()
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "1000"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	params, ok := bindQueryParams(c, 1000, 2000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.Sanction](c, params.Fields) {
+		return
+	}
+	after, ok := listCursor(c, start, "sanctions")
+	if !ok {
+		return
+	}
+	rd, release, ok := datasetReader(c, start)
+	if !ok {
+		return
+	}
+	defer release()
+
+	cacheKey := versionedCacheKey(rd, "sanctions", params.Limit, params.Offset, after)
+
+	sanctions, err := loadCached(c, cacheKey, config.CacheTTL("sanctions"), func() ([]models.Sanction, error) {
+		return rd.GetSanctions(params.Limit, params.Offset, after)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch sanctions: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	markNextPage(c, "sanctions", sanctions, params.Limit, database.SanctionCursor)
+	sanctions = protectItems(c, "sanctions", sanctions)
 
-	if limit > 2000 {
-		limit = 2000
+	if wantsCSV(c) {
+		writeCSV(c, "sanctions", selectColumns(export.SanctionColumns, params.Fields), sanctions)
+		return
 	}
 
-	cacheKey := utils.CacheKey("sanctions", limit, offset)
+	respondList(c, start, sanctions, params.Fields)
+}
+
+// GetExpenses handles GET /api/expenses
+func GetExpenses(c *gin.Context) {
+	start := time.Now()
 
-	if cached, found := utils.GetCache(cacheKey); found {
-		c.JSON(http.StatusOK, models.APIResponse{
-			Success: true,
-			Data:    cached,
-			Count:   len(cached.([]models.Sanction)),
+	params, ok := bindQueryParams(c, 500, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.FinancialRecord](c, params.Fields) {
+		return
+	}
+
+	politicianID, err := strconv.Atoi(c.DefaultQuery("politician_id", "0"))
+	if err != nil || politicianID < 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  map[string]string{"politician_id": "must be a positive integer"},
 			Time:    time.Since(start).String(),
 		})
 		return
 	}
 
-	sanctions, err := database.GetSanctions(limit, offset)
+	target, ok := adjustTarget(c, start)
+	if !ok {
+		return
+	}
+	after, ok := listCursor(c, start, "expenses")
+	if !ok {
+		return
+	}
+	rd, release, ok := datasetReader(c, start)
+	if !ok {
+		return
+	}
+	defer release()
+
+	cacheKey := versionedCacheKey(rd, "expenses", politicianID, params.Limit, params.Offset, after)
+
+	expenses, err := loadCached(c, cacheKey, config.CacheTTL("expenses"), func() ([]models.FinancialRecord, error) {
+		return rd.GetFinancialRecords(politicianID, params.Limit, params.Offset, after)
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
-			Error:   "Failed to fetch sanctions: " + err.Error(),
+			Error:   "Failed to fetch expenses: " + err.Error(),
 			Time:    time.Since(start).String(),
 		})
 		return
 	}
+	markNextPage(c, "expenses", expenses, params.Limit, database.ExpenseCursor)
+	expenses = adjustExpenses(target, protectItems(c, "expenses", expenses))
 
-	utils.SetCache(cacheKey, sanctions, 30*time.Minute)
+	if wantsCSV(c) {
+		columns := export.FinancialRecordColumns
+		if target != nil {
+			columns = export.AdjustedFinancialRecordColumns
+		}
+		writeCSV(c, "expenses", selectColumns(columns, params.Fields), expenses)
+		return
+	}
 
-	c.JSON(http.StatusOK, models.APIResponse{
-		Success: true,
-		Data:    sanctions,
-		Count:   len(sanctions),
-		Time:    time.Since(start).String(),
-	})
+	respondList(c, start, expenses, params.Fields)
 }
 
-// GetConnections handles GET /api/connections
+// GetConnections handles GET /api/connections (?sector= keeps financial connections to
+// companies in a CNAE sector)
 func GetConnections(c *gin.Context) {
 	start := time.Now()
 
-	cacheKey := "connections_all"
-
-	if cached, found := utils.GetCache(cacheKey); found {
-		c.JSON(http.StatusOK, models.APIResponse{
-			Success: true,
-			Data:    cached,
-			Count:   len(cached.([]models.Connection)),
-			Time:    time.Since(start).String(),
-		})
+	sector, ok := parseSector(c, start)
+	if !ok {
 		return
 	}
 
-	connections, err := database.GetConnections()
+	connections, err := getConnections(c)
+	if err == nil && sector != "" {
+		connections, err = filterConnectionsBySector(connections, sector)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
@@ -215,14 +302,13 @@ func GetConnections(c *gin.Context) {
 		return
 	}
 
-	// Cache connections for 20 minutes (they're expensive to compute)
-	utils.SetCache(cacheKey, connections, 20*time.Minute)
-
 	c.JSON(http.StatusOK, models.APIResponse{
-		Success: true,
-		Data:    connections,
-		Count:   len(connections),
-		Time:    time.Since(start).String(),
+		Success:     true,
+		Data:        connections,
+		Count:       len(connections),
+		Degraded:    degraded(c),
+		DroppedRows: droppedRows(c),
+		Time:        time.Since(start).String(),
 	})
 }
 
@@ -230,20 +316,34 @@ func GetConnections(c *gin.Context) {
 func GetNetworkData(c *gin.Context) {
 	start := time.Now()
 
-	cacheKey := "network_complete"
-
-	// Check cache first (this is an expensive operation)
-	if cached, found := utils.GetCache(cacheKey); found {
-		c.JSON(http.StatusOK, models.APIResponse{
-			Success: true,
-			Data:    cached,
+	// The network isn't paged, so only ?fields= (and ?tag=, below) apply
+	var params struct {
+		Fields []string `form:"fields"`
+	}
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  queryFieldErrors(c, err),
 			Time:    time.Since(start).String(),
 		})
 		return
 	}
 
-	// Build complete network data
-	networkData, err := buildNetworkData()
+	politicians, companies, ok := taggedEntities(c, start)
+	if !ok {
+		return
+	}
+
+	fields := splitFields(params.Fields)
+	source, err := getNetworkData(c)
+	var networkData *models.NetworkResponse
+	if err == nil {
+		if politicians != nil {
+			source = tagNetwork(source, politicians, companies)
+		}
+		networkData, err = projectNetwork(source, fields)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
@@ -253,116 +353,297 @@ func GetNetworkData(c *gin.Context) {
 		return
 	}
 
-	// Cache for 10 minutes (balance between performance and freshness)
-	utils.SetCache(cacheKey, networkData, 10*time.Minute)
+	if wantsMsgPack(c) {
+		writeNetworkMsgPack(c, start, source, networkData, fields, c.Query("tag"))
+		return
+	}
 
 	c.JSON(http.StatusOK, models.APIResponse{
-		Success: true,
-		Data:    networkData,
-		Time:    time.Since(start).String(),
+		Success:     true,
+		Data:        networkData,
+		Degraded:    degraded(c),
+		DroppedRows: droppedRows(c),
+		Time:        time.Since(start).String(),
+	})
+}
+
+// loadPoliticians returns a page of politicians from cache or the database. c is nil outside a
+// request (see loadCached).
+func loadPoliticians(c *gin.Context, rd database.Repository, params models.QueryParams, filter database.PoliticianFilter) ([]models.Politician, error) {
+	cacheKey := versionedCacheKey(rd, "politicians", params.Limit, params.Offset, params.MinScore, filter)
+
+	return loadCached(c, cacheKey, config.CacheTTL("politicians"), func() ([]models.Politician, error) {
+		return rd.GetPoliticians(params.Limit, params.Offset, params.MinScore, filter)
 	})
 }
 
-// buildNetworkData assembles complete network for 3D visualization
-func buildNetworkData() (*models.NetworkResponse, error) {
-	var nodes []interface{}
+// loadParties returns a page of parties from cache or the database
+func loadParties(c *gin.Context, rd database.Repository, params models.QueryParams, after *database.ListCursor) ([]models.Party, error) {
+	cacheKey := versionedCacheKey(rd, "parties", params.Limit, params.Offset, after)
+
+	return loadCached(c, cacheKey, config.CacheTTL("parties"), func() ([]models.Party, error) {
+		return rd.GetParties(params.Limit, params.Offset, after)
+	})
+}
+
+// CachedConnections returns the connections served by /api/connections, for the gRPC service
+func CachedConnections() ([]models.Connection, error) {
+	return getConnections(nil)
+}
+
+// CachedNetwork returns the graph served by /api/network, for the gRPC service
+func CachedNetwork() (*models.NetworkResponse, error) {
+	return getNetworkData(nil)
+}
+
+// getConnections returns the network connections from cache or builds them (they're expensive
+// to compute)
+func getConnections(c *gin.Context) ([]models.Connection, error) {
+	return loadCachedCompressed(c, "connections_all", config.CacheTTL("connections"), publicConnections(repos.Network.GetConnections))
+}
+
+// publicConnections wraps a connection loader to mask the CPFs of any individual endpoints:
+// connections are cached, streamed and archived for every caller, so they're shaped once, for
+// the public
+func publicConnections(load func() ([]models.Connection, error)) func() ([]models.Connection, error) {
+	return func() ([]models.Connection, error) {
+		connections, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return privacy.Slice(privacy.Public, connections), nil
+	}
+}
+
+// getNetworkData returns the cached network or builds and caches it. The TTL is short by default
+// (balance between performance and freshness).
+func getNetworkData(c *gin.Context) (*models.NetworkResponse, error) {
+	return loadCachedCompressed(c, "network_complete", config.CacheTTL("network"), func() (*models.NetworkResponse, error) {
+		return buildNetworkData(nil)
+	})
+}
+
+// networkBuildStages is the number of steps buildNetworkData reports to its task
+const networkBuildStages = 11
+
+// buildNetworkData assembles complete network for 3D visualization, reporting each stage to
+// task when one is given. The result is cached for every caller, so node data is always
+// PII-masked.
+func buildNetworkData(task *progress.Task) (*models.NetworkResponse, error) {
+	var nodes []models.NetworkNode
+	var partial partialReads
 
 	// Get politicians (limit to active ones for performance)
-	politicians, err := database.GetPoliticians(500, 0)
-	if err != nil {
+	task.Stage("politicians")
+	politicians, err := repos.Live.GetPoliticians(500, 0, 0, database.PoliticianFilter{})
+	if partial.failed(err) {
 		return nil, err
 	}
 
 	// Transform politicians to network nodes
 	for _, p := range politicians {
-		node := models.NetworkNode{
-			ID:              "politician_" + strconv.Itoa(p.ID),
-			Type:            "politician",
-			Name:            p.Nome,
-			Size:            8.0 + float64(p.FinancialRecordsCount)*0.1,
-			Color:           getPoliticianColor(p.CorruptionScore),
-			CorruptionScore: p.CorruptionScore,
-			Data:            p,
-		}
+		node := models.NewNetworkNode(
+			strconv.Itoa(p.ID),
+			p.Nome,
+			8.0+float64(p.FinancialRecordsCount)*0.1,
+			getPoliticianColor(p.CorruptionScore),
+			privacy.Public.Politician(p),
+		)
+		node.CorruptionScore = p.CorruptionScore
+		node.ImageURL = imagePath("politicians", p.ID, p.URLFoto)
 		nodes = append(nodes, node)
 	}
+	task.Step(int64(len(politicians)))
 
 	// Get parties
-	parties, err := database.GetParties(50, 0)
-	if err != nil {
+	task.Stage("parties")
+	parties, err := repos.Live.GetParties(50, 0, nil)
+	if partial.failed(err) {
 		return nil, err
 	}
 
 	for _, p := range parties {
-		node := models.NetworkNode{
-			ID:   "party_" + strconv.Itoa(p.ID),
-			Type: "party",
-			Name: p.Nome,
-			Size: 12.0 + float64(p.TotalMembros)*0.2,
-			Color: "#4ecdc4",
-			Data:  p,
-		}
+		node := models.NewNetworkNode(
+			strconv.Itoa(p.ID),
+			p.Nome,
+			12.0+float64(p.TotalMembros)*0.2,
+			"#4ecdc4",
+			p,
+		)
+		node.ImageURL = imagePath("parties", p.ID, p.LogoURL)
 		nodes = append(nodes, node)
 	}
+	task.Step(int64(len(parties)))
 
 	// Get top companies (limit for performance)
-	companies, err := database.GetCompanies(200, 0)
+	task.Stage("companies")
+	companies, err := repos.Live.GetCompanies(200, 0, database.CompanyFilter{})
+	if partial.failed(err) {
+		return nil, err
+	}
+
+	for _, c := range companies {
+		nodes = append(nodes, models.NewNetworkNode(
+			c.CNPJ,
+			c.NomeEmpresa,
+			6.0+(c.TotalValue.Float64()/1000000)*2, // Scale by millions
+			"#ffe66d",
+			c,
+		))
+	}
+	task.Step(int64(len(companies)))
+
+	// Corporate groups (matriz + filiais) and the branch links of companies in the graph
+	task.Stage("company_groups")
+	groups, err := repos.Network.GetCompanyGroups(100, 0)
 	if err != nil {
 		return nil, err
 	}
 
+	groupRoots := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		groupRoots[g.CNPJRoot] = true
+		nodes = append(nodes, models.NewNetworkNode(
+			g.CNPJRoot,
+			g.NomeEmpresa,
+			8.0+(g.TotalValue.Float64()/1000000)*2, // Scale by millions
+			"#f4a261",
+			g,
+		))
+	}
+
+	var branchLinks []models.Connection
 	for _, c := range companies {
-		node := models.NetworkNode{
-			ID:   "company_" + c.CNPJ,
-			Type: "company",
-			Name: c.NomeEmpresa,
-			Size: 6.0 + (c.TotalValue/1000000)*2, // Scale by millions
-			Color: "#ffe66d",
-			Data:  c,
+		if groupRoots[c.CNPJRoot] {
+			branchLinks = append(branchLinks, models.Connection{
+				SourceID: "company_" + c.CNPJ,
+				TargetID: string(models.NodeTypeCompanyGroup) + "_" + c.CNPJRoot,
+				Type:     "branch_of",
+				Value:    c.TotalValue,
+				Strength: 1.0,
+			})
 		}
-		nodes = append(nodes, node)
 	}
+	task.Step(int64(len(groups)))
 
 	// Get sanctions (limited set)
-	sanctions, err := database.GetSanctions(300, 0)
-	if err != nil {
+	task.Stage("sanctions")
+	sanctions, err := repos.Live.GetSanctions(300, 0, nil)
+	if partial.failed(err) {
 		return nil, err
 	}
 
 	for _, s := range sanctions {
-		node := models.NetworkNode{
-			ID:   "sanction_" + strconv.Itoa(s.ID),
-			Type: "sanction",
-			Name: "Sanção: " + s.TipoSancao,
-			Size: 4.0 + (s.ValorMulta/100000)*1, // Scale by value
-			Color: "#ff8b94",
-			Data:  s,
-		}
-		nodes = append(nodes, node)
+		nodes = append(nodes, models.NewNetworkNode(
+			strconv.Itoa(s.ID),
+			"Sanção: "+s.TipoSancao,
+			4.0+(s.ValorMulta.Float64()/100000)*1, // Scale by value
+			"#ff8b94",
+			privacy.Public.Sanction(s),
+		))
+	}
+	task.Step(int64(len(sanctions)))
+
+	// Speech topics, the same ones GetConnections links politicians to
+	task.Stage("topics")
+	topics, err := repos.Network.GetTopics(database.TopicNodeLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range topics {
+		nodes = append(nodes, models.NewNetworkNode(
+			t.Slug,
+			t.Topic,
+			5.0+float64(t.Politicians)*0.1, // Scale by politicians talking about it
+			"#b39ddb",
+			t,
+		))
 	}
+	task.Step(int64(len(topics)))
+
+	// Frentes parlamentares, the same ones GetConnections links their members to
+	task.Stage("fronts")
+	fronts, err := repos.Network.GetFronts(0, database.FrontNodeLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fronts {
+		nodes = append(nodes, models.NewNetworkNode(
+			strconv.Itoa(f.ID),
+			f.Titulo,
+			6.0+float64(f.MemberCount)*0.02, // Scale by members
+			"#81c784",
+			f,
+		))
+	}
+	task.Step(int64(len(fronts)))
+
+	// TCU rulings, the same ones GetConnections links named companies and politicians to
+	task.Stage("tcu_rulings")
+	rulings, err := repos.Live.GetTCURulings(database.TCURulingFilter{}, database.TCURulingNodeLimit, 0)
+	if partial.failed(err) {
+		return nil, err
+	}
+
+	for _, r := range rulings {
+		nodes = append(nodes, models.NewNetworkNode(
+			strconv.Itoa(r.ID),
+			r.Titulo,
+			5.0+float64(r.PartyCount)*0.5, // Scale by parties named
+			"#ce93d8",
+			r,
+		))
+	}
+	task.Step(int64(len(rulings)))
+
+	// Public banks, the lenders GetConnections links the companies they finance to
+	task.Stage("public_banks")
+	banks, err := repos.Network.GetPublicBanks()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range banks {
+		nodes = append(nodes, models.NewNetworkNode(
+			b.Slug,
+			b.Nome,
+			10.0+float64(b.CompanyCount)*0.1, // Scale by companies financed
+			"#64b5f6",
+			b,
+		))
+	}
+	task.Step(int64(len(banks)))
 
 	// Get connections
-	connections, err := database.GetConnections()
+	task.Stage("connections")
+	connections, err := publicConnections(repos.Network.GetConnections)()
 	if err != nil {
 		return nil, err
 	}
+	task.Step(int64(len(connections)))
 
 	// Get network stats
-	stats, err := database.GetNetworkStats()
+	task.Stage("stats")
+	stats, err := repos.Network.GetNetworkStats(false)
 	if err != nil {
 		return nil, err
 	}
+	task.Step(0)
+
+	links := append(connections[:len(connections):len(connections)], branchLinks...)
 
 	stats.TotalNodes = len(nodes)
-	stats.TotalLinks = len(connections)
+	stats.TotalLinks = len(links)
 
 	response := &models.NetworkResponse{
 		Nodes: nodes,
-		Links: connections,
+		Links: links,
 		Stats: stats,
 	}
 
-	return response, nil
+	return response, partial.err()
 }
 
 // getPoliticianColor returns color based on corruption score
@@ -375,40 +656,12 @@ func getPoliticianColor(score int) string {
 	return "#ff6b6b" // Low corruption - light red
 }
 
-// HealthCheck handles GET /health
-func HealthCheck(c *gin.Context) {
-	start := time.Now()
-
-	// Check database
-	dbStatus := "healthy"
-	if err := database.Health(); err != nil {
-		dbStatus = "unhealthy: " + err.Error()
-	}
-
-	// Check cache
-	cacheStatus := "healthy"
-	cacheStats := utils.GetCacheStats()
-
-	health := models.HealthCheck{
-		Status:    "healthy",
-		Database:  dbStatus,
-		Cache:     cacheStatus,
-		Uptime:    time.Since(start).String(),
-		Version:   "1.0.0",
-		Timestamp: time.Now(),
-	}
-
-	// Add cache stats
-	if stats, ok := cacheStats["items"].(int); ok {
-		health.Cache = "healthy (" + strconv.Itoa(stats) + " items)"
-	}
-
-	c.JSON(http.StatusOK, health)
-}
-
-// ClearCache handles POST /api/cache/clear
+// ClearCache handles POST /api/admin/cache/clear
 func ClearCache(c *gin.Context) {
+	items := utils.Cache.ItemCount()
 	utils.FlushCache()
+	audit(c, "cache_clear", "cache", map[string]interface{}{"items": items})
+
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
 		Data:    "Cache cleared successfully",
@@ -416,22 +669,21 @@ func ClearCache(c *gin.Context) {
 	})
 }
 
-// GetStats handles GET /api/stats
+// GetStats handles GET /api/stats (?exact=true counts large tables instead of estimating them,
+// which takes seconds)
 func GetStats(c *gin.Context) {
 	start := time.Now()
 
+	exact := c.Query("exact") == "true"
 	cacheKey := "stats_network"
-
-	if cached, found := utils.GetCache(cacheKey); found {
-		c.JSON(http.StatusOK, models.APIResponse{
-			Success: true,
-			Data:    cached,
-			Time:    time.Since(start).String(),
-		})
-		return
+	if exact {
+		cacheKey = "stats_network_exact"
 	}
 
-	stats, err := database.GetNetworkStats()
+	// Cache stats briefly; counts change with every ETL run
+	stats, err := loadCached(c, cacheKey, config.CacheTTL("stats"), func() (models.NetworkStats, error) {
+		return repos.Network.GetNetworkStats(exact)
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
@@ -441,12 +693,11 @@ func GetStats(c *gin.Context) {
 		return
 	}
 
-	// Cache stats for 5 minutes
-	utils.SetCache(cacheKey, stats, 5*time.Minute)
-
 	c.JSON(http.StatusOK, models.APIResponse{
-		Success: true,
-		Data:    stats,
-		Time:    time.Since(start).String(),
+		Success:     true,
+		Data:        stats,
+		Degraded:    degraded(c),
+		DroppedRows: droppedRows(c),
+		Time:        time.Since(start).String(),
 	})
-}
\ No newline at end of file
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// startedAt is when the process started, for the uptime report
+var startedAt = time.Now()
+
+const (
+	// poolSaturationWarn marks the pool degraded when this share of connections is in use
+	poolSaturationWarn = 0.9
+	// externalProbeTimeout bounds each ETL source probe
+	externalProbeTimeout = 3 * time.Second
+	// sourceProbeTTL is how long ETL source probe results are reused, so polling /health?deep=true
+	// doesn't turn into requests to the government APIs
+	sourceProbeTTL = time.Minute
+)
+
+// sourceProbes are the last ETL source probe results and when they were taken
+var sourceProbes struct {
+	sync.Mutex
+	at      time.Time
+	results map[string]models.DependencyCheck
+}
+
+// etlSources are the upstream APIs the CLI4 populators read from
+var etlSources = map[string]string{
+	"camara":               "https://dadosabertos.camara.leg.br/api/v2/partidos?itens=1",
+	"tse":                  "https://dadosabertos.tse.jus.br/api/3/action/status_show",
+	"senado":               "https://legis.senado.leg.br/dadosabertos/",
+	"portal_transparencia": "https://api.portaldatransparencia.gov.br/",
+	"tcu":                  "https://contas.tcu.gov.br/ords/condenacao/consulta/inabilitados",
+}
+
+// HealthCheck handles GET /health. Database and cache round trips, pool usage and the database
+// circuit breaker are always reported; ?deep=true also reports the ETL sources, probed at most
+// once a minute, which takes up to a few seconds.
+func HealthCheck(c *gin.Context) {
+	checks := map[string]models.DependencyCheck{
+		"database": timeCheck(repos.Health),
+		"cache":    timeCheck(probeCache),
+	}
+	if c.Query("deep") == "true" {
+		for name, check := range cachedSourceProbes() {
+			checks["source_"+name] = check
+		}
+	}
+
+	pool := poolStats()
+	breaker := database.Breaker()
+
+	health := models.HealthCheck{
+		Status:    "healthy",
+		Database:  "healthy",
+		Cache:     "healthy (" + strconv.Itoa(utils.Cache.ItemCount()) + " items)",
+		Uptime:    time.Since(startedAt).Round(time.Second).String(),
+		Version:   "1.0.0",
+		Timestamp: time.Now(),
+		Checks:    checks,
+		Pool:      pool,
+		Breaker:   &breaker,
+	}
+
+	for _, check := range checks {
+		if check.Status != "healthy" {
+			health.Status = "degraded"
+		}
+	}
+	if pool.Saturation >= poolSaturationWarn || breaker.State != database.BreakerClosed {
+		health.Status = "degraded"
+	}
+
+	status := http.StatusOK
+	if db := checks["database"]; db.Status != "healthy" {
+		health.Status = "unhealthy"
+		health.Database = "unhealthy: " + db.Error
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, health)
+}
+
+// timeCheck runs fn and reports its latency
+func timeCheck(fn func() error) models.DependencyCheck {
+	start := time.Now()
+	err := fn()
+
+	check := models.DependencyCheck{
+		Status:    "healthy",
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		check.Status = "unhealthy"
+		check.Error = err.Error()
+	}
+	return check
+}
+
+// probeCache does a set/get round trip on a scratch key
+func probeCache() error {
+	const key = "health_probe"
+	// Bypass GetCache/DeleteCache so health polling doesn't skew the cache metrics;
+	// the key is simply overwritten by the next probe
+	utils.Cache.Set(key, time.Now(), time.Minute)
+
+	if _, found := utils.Cache.Get(key); !found {
+		return errors.New("cache probe key not found after set")
+	}
+	return nil
+}
+
+// cachedSourceProbes returns the ETL source probe results, probing again once they are
+// sourceProbeTTL old. Concurrent callers wait for one probe rather than starting their own, which
+// is also why it isn't tied to any one request's context.
+func cachedSourceProbes() map[string]models.DependencyCheck {
+	sourceProbes.Lock()
+	defer sourceProbes.Unlock()
+
+	if sourceProbes.results == nil || time.Since(sourceProbes.at) >= sourceProbeTTL {
+		sourceProbes.results = probeSources(context.Background())
+		sourceProbes.at = time.Now()
+	}
+	return sourceProbes.results
+}
+
+// probeSources checks every ETL source concurrently. Any HTTP response below 500 counts as
+// reachable; several sources reject unauthenticated requests but are still up.
+func probeSources(ctx context.Context) map[string]models.DependencyCheck {
+	client := &http.Client{Timeout: externalProbeTimeout}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]models.DependencyCheck, len(etlSources))
+
+	for name, url := range etlSources {
+		wg.Add(1)
+		go func(name, url string) {
+			defer wg.Done()
+			check := timeCheck(func() error { return probeURL(ctx, client, url) })
+
+			mu.Lock()
+			results[name] = check
+			mu.Unlock()
+		}(name, url)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// probeURL issues a GET and fails on transport errors and 5xx responses
+func probeURL(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// poolStats reports connection pool usage; saturation is in-use connections over the maximum
+func poolStats() *models.PoolStats {
+	stats := database.GetStats()
+
+	pool := &models.PoolStats{
+		MaxOpen:      stats.MaxOpenConnections,
+		Open:         stats.OpenConnections,
+		InUse:        stats.InUse,
+		Idle:         stats.Idle,
+		WaitCount:    stats.WaitCount,
+		WaitDuration: stats.WaitDuration.String(),
+	}
+	if stats.MaxOpenConnections > 0 {
+		pool.Saturation = float64(stats.InUse) / float64(stats.MaxOpenConnections)
+	}
+	return pool
+}
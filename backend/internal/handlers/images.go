@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/imagecache"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// imageWidths are the sizes /api/images serves; a fixed set keeps the disk cache bounded
+var imageWidths = []int{48, 96, 128, 256, 512}
+
+// networkImageWidth is the thumbnail size linked from network nodes
+const networkImageWidth = 96
+
+var (
+	imageStoreOnce sync.Once
+	imageStore     *imagecache.Store
+)
+
+func images() *imagecache.Store {
+	imageStoreOnce.Do(func() {
+		cfg := config.Get().Images
+		imageStore = imagecache.NewStore(cfg.CacheDir, cfg.MaxAge)
+	})
+	return imageStore
+}
+
+// imagePath returns the proxy URL for an entity's image, or "" when it has none
+func imagePath(entity string, id int, sourceURL string) string {
+	if sourceURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("/api/images/%s/%d?w=%d", entity, id, networkImageWidth)
+}
+
+// GetImage handles GET /api/images/:entity/:id - politician photos and party logos served
+// through a resizing disk cache instead of hotlinking the Câmara CDN
+func GetImage(c *gin.Context) {
+	start := time.Now()
+
+	entity := c.Param("entity")
+	if entity != "politicians" && entity != "parties" {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Unknown image entity (expected politicians or parties)",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid " + strings.TrimSuffix(entity, "s") + " id",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	width := 0
+	if w := c.Query("w"); w != "" {
+		width, _ = strconv.Atoi(w)
+		valid := false
+		for _, allowed := range imageWidths {
+			valid = valid || width == allowed
+		}
+		if !valid {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   "Invalid query parameters",
+				Errors:  map[string]string{"w": fmt.Sprintf("must be one of %v", imageWidths)},
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+	}
+
+	sourceURL, err := imageSource(entity, id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && sourceURL == "") {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Image not found",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch image source: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	img, err := images().Get(c.Request.Context(), sourceURL, width)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, imagecache.ErrUpstream) {
+			status = http.StatusBadGateway
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch image: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(images().MaxAge().Seconds())))
+	c.Header("ETag", img.ETag)
+	c.Header("Last-Modified", img.ModTime.UTC().Format(http.TimeFormat))
+	// Logos may be SVG; never let one run script on the API origin
+	c.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	c.Header("X-Content-Type-Options", "nosniff")
+
+	if match := c.GetHeader("If-None-Match"); match != "" && etagMatches(match, img.ETag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, img.ContentType, img.Data)
+}
+
+// imageSource looks up the external URL of an entity's image, from cache when possible
+func imageSource(entity string, id int) (string, error) {
+	cacheKey := utils.CacheKey("image_source", entity, id)
+	if cached, found := utils.GetCache(cacheKey); found {
+		return cached.(string), nil
+	}
+
+	var sourceURL string
+	switch entity {
+	case "politicians":
+		p, err := repos.Live.GetPolitician(id)
+		if err != nil {
+			return "", err
+		}
+		sourceURL = p.URLFoto
+	case "parties":
+		p, err := repos.Live.GetParty(id)
+		if err != nil {
+			return "", err
+		}
+		sourceURL = p.LogoURL
+	}
+
+	utils.SetCache(cacheKey, sourceURL, config.CacheTTL("images"))
+	return sourceURL, nil
+}
+
+// etagMatches reports whether an If-None-Match header lists etag
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/inflation"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// adjustTarget reads ?adjust_to= (a year or YYYY-MM). It returns nil when the parameter is
+// absent; on an invalid or unpublished period it writes a 400 and returns false. The
+// resolved month is echoed in X-IPCA-Adjusted-To, since a year means its latest month.
+func adjustTarget(c *gin.Context, start time.Time) (*inflation.Target, bool) {
+	adjustTo := c.Query("adjust_to")
+	if adjustTo == "" {
+		return nil, true
+	}
+
+	table, err := loadIPCATable()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to load IPCA index: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return nil, false
+	}
+
+	target, err := table.Target(adjustTo)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  map[string]string{"adjust_to": err.Error()},
+			Time:    time.Since(start).String(),
+		})
+		return nil, false
+	}
+
+	c.Header("X-IPCA-Adjusted-To", target.Month)
+	return &target, true
+}
+
+// loadIPCATable returns the IPCA index table, from cache when possible
+func loadIPCATable() (*inflation.Table, error) {
+	if cached, found := utils.GetCache("ipca_index"); found {
+		return cached.(*inflation.Table), nil
+	}
+
+	months, err := database.GetIPCAIndex()
+	if err != nil {
+		return nil, err
+	}
+	table, err := inflation.NewTable(months)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.SetCache("ipca_index", table, config.CacheTTL("ipca"))
+	return table, nil
+}
+
+// adjustExpenses returns copies of records with valor_ajustado set (records may be cached)
+func adjustExpenses(target *inflation.Target, records []models.FinancialRecord) []models.FinancialRecord {
+	if target == nil || len(records) == 0 {
+		return records
+	}
+
+	adjusted := make([]models.FinancialRecord, len(records))
+	for i, f := range records {
+		v := target.Adjust(f.Valor, f.DataDoc)
+		f.ValorAjustado = &v
+		adjusted[i] = f
+	}
+	return adjusted
+}
+
+// adjustCompanies returns copies of companies with total_value_adjusted set. Totals span
+// many months, so they are re-summed per record in SQL.
+func adjustCompanies(target *inflation.Target, companies []models.Company) ([]models.Company, error) {
+	if target == nil || len(companies) == 0 {
+		return companies, nil
+	}
+
+	cnpjs := make([]string, len(companies))
+	for i, company := range companies {
+		cnpjs[i] = company.CNPJ
+	}
+
+	cacheKey := utils.CacheKey("companies_adjusted", target.Month, strings.Join(cnpjs, ","))
+
+	var totals map[string]models.Money
+	if cached, found := utils.GetCache(cacheKey); found {
+		totals = cached.(map[string]models.Money)
+	} else {
+		var err error
+		totals, err = database.GetAdjustedCompanyTotals(cnpjs, target.IndexNumber())
+		if err != nil {
+			return nil, err
+		}
+		utils.SetCache(cacheKey, totals, config.CacheTTL("companies"))
+	}
+
+	adjusted := make([]models.Company, len(companies))
+	for i, company := range companies {
+		total, ok := totals[company.CNPJ]
+		if !ok {
+			// No dated records to adjust
+			total = company.TotalValue
+		}
+		company.TotalValueAdjusted = &total
+		adjusted[i] = company
+	}
+	return adjusted, nil
+}
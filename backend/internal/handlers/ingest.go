@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxIngestBytes caps a bulk ingest payload
+	maxIngestBytes = 32 << 20
+	// maxIngestRows caps the rows of one bulk ingest
+	maxIngestRows = 50000
+)
+
+// ingestCachePrefixes are the cache entries built from each ingestible entity
+var ingestCachePrefixes = map[string][]string{
+	"sanctions":   {"sanctions", "sanction_detail", "company_detail", "politician_detail", "connections", "network", "stats"},
+	"companies":   {"companies", "company_detail", "company_group", "connections", "network"},
+	"expenses":    {"expenses", "politician_detail", "companies", "company_detail", "connections", "network", "stats"},
+	"tcu_rulings": {"tcu_rulings", "tcu_ruling_detail", "company_detail", "politician_detail", "connections", "network"},
+}
+
+// IngestEntity handles POST /api/admin/ingest/:entity?dry_run= - upserts a JSONL or CSV payload
+// (Content-Type text/csv, or ?format=csv|jsonl) into sanctions, companies, expenses or
+// tcu_rulings, matching existing records on the entity's unique key. Rows that fail are listed
+// with their line and skipped; a dry run reports what would happen and writes nothing.
+func IngestEntity(c *gin.Context) {
+	start := time.Now()
+
+	entity := c.Param("entity")
+	errs := map[string]string{}
+	if entities := database.IngestEntities(); !slices.Contains(entities, entity) {
+		errs["entity"] = "must be one of " + strings.Join(entities, ", ")
+	}
+	dryRun := false
+	if v := c.Query("dry_run"); v != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			errs["dry_run"] = "must be true or false"
+		}
+	}
+	format := c.Query("format")
+	if format == "" {
+		format = "jsonl"
+		if strings.HasPrefix(c.ContentType(), "text/csv") {
+			format = "csv"
+		}
+	}
+	if format != "jsonl" && format != "csv" {
+		errs["format"] = "must be jsonl or csv"
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxIngestBytes)
+	parse := parseJSONLRows
+	if format == "csv" {
+		parse = parseCSVRows
+	}
+	rows, err := parse(c.Request.Body)
+	if err == nil && len(rows) == 0 {
+		err = errors.New("no rows")
+	}
+	if err == nil && len(rows) > maxIngestRows {
+		err = fmt.Errorf("at most %d rows per request", maxIngestRows)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid payload: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	result, err := database.Ingest(entity, rows, dryRun)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to ingest " + entity + ": " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	if !dryRun {
+		audit(c, "bulk_ingest", "ingest/"+entity, map[string]interface{}{
+			"format":  format,
+			"rows":    result.Rows,
+			"created": result.Created,
+			"updated": result.Updated,
+			"failed":  result.Failed,
+		})
+		if result.Created+result.Updated > 0 {
+			// Overrides outlast the rows the ingest replaced
+			if _, err := database.ApplyOverrides(); err != nil {
+				log.Printf("⚠️ Overrides not applied after ingesting %s: %v", entity, err)
+			}
+			utils.DeleteCachePrefix(ingestCachePrefixes[entity]...)
+		}
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    result,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// parseJSONLRows reads one JSON object per line; blank lines are skipped and a line that is not
+// an object of scalars becomes a row error
+func parseJSONLRows(r io.Reader) ([]models.IngestRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+
+	var rows []models.IngestRow
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		row := models.IngestRow{Line: line, Values: map[string]*string{}}
+
+		var object map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.UseNumber()
+		if err := decoder.Decode(&object); err != nil {
+			row.Error = "invalid JSON: " + err.Error()
+			rows = append(rows, row)
+			continue
+		}
+		for key, value := range object {
+			column := strings.ToLower(strings.TrimSpace(key))
+			switch v := value.(type) {
+			case nil:
+				row.Values[column] = nil
+			case string:
+				row.Values[column] = &v
+			case json.Number:
+				s := v.String()
+				row.Values[column] = &s
+			case bool:
+				s := strconv.FormatBool(v)
+				row.Values[column] = &s
+			default:
+				row.Error = column + " must be a string, number, boolean or null"
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, scanner.Err()
+}
+
+// parseCSVRows reads a CSV with a header of column names; empty cells are NULL
+func parseCSVRows(r io.Reader) ([]models.IngestRow, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing CSV header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	var rows []models.IngestRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, models.IngestRow{Line: parseErr.StartLine, Error: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := models.IngestRow{Line: line, Values: make(map[string]*string, len(header))}
+		for i, column := range header {
+			if record[i] == "" {
+				row.Values[column] = nil
+				continue
+			}
+			value := record[i]
+			row.Values[column] = &value
+		}
+		rows = append(rows, row)
+	}
+}
@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/jobs"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"political-network-api/internal/progress"
+	"political-network-api/internal/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxJobAttempts caps the attempts a queued job may ask for
+const maxJobAttempts = 10
+
+// RegisterJobs adds the queued jobs built on handler code: dataset exports and network layout
+// rebuilds. Call it before jobs.StartQueue.
+func RegisterJobs() {
+	jobs.RegisterJob(models.JobExport, jobs.JobRunner{Check: checkExportJob, Run: runExportJob})
+	jobs.RegisterJob(models.JobNetworkLayout, jobs.JobRunner{Run: runNetworkLayoutJob})
+}
+
+// exportJob is the payload of an export job: the archive format, csv by default
+type exportJob struct {
+	Format string `json:"format"`
+}
+
+func (p *exportJob) decode(payload json.RawMessage) error {
+	if err := json.Unmarshal(payload, p); err != nil {
+		return fmt.Errorf("must be {\"format\": \"csv\" | \"jsonl\"}: %v", err)
+	}
+	if p.Format == "" {
+		p.Format = "csv"
+	}
+	if p.Format != "csv" && p.Format != "jsonl" {
+		return errors.New("format must be csv or jsonl")
+	}
+	return nil
+}
+
+func checkExportJob(payload json.RawMessage) error {
+	var p exportJob
+	return p.decode(payload)
+}
+
+// runExportJob builds a fresh full dataset archive, as GET /api/export/full?refresh=true does
+func runExportJob(_ context.Context, payload json.RawMessage, task *progress.Task) (interface{}, error) {
+	var p exportJob
+	if err := p.decode(payload); err != nil {
+		return nil, err
+	}
+
+	archiveMu.Lock()
+	defer archiveMu.Unlock()
+
+	name, err := buildFullArchive(p.Format, task)
+	if err != nil {
+		return nil, err
+	}
+	return describeArchive(name)
+}
+
+// runNetworkLayoutJob rebuilds the cached network the 3D layout is drawn from, as
+// POST /api/network/rebuild does
+func runNetworkLayoutJob(_ context.Context, _ json.RawMessage, task *progress.Task) (interface{}, error) {
+	task.SetTotal(networkBuildStages)
+	network, err := buildNetworkData(task)
+	if dropped, usable := database.DroppedRows(err); usable && err != nil {
+		log.Printf("⚠️ Network rebuilt without %d rows that failed to scan", dropped)
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.SetCacheCompressed("network_complete", network, config.CacheTTL("network")); err != nil {
+		return nil, err
+	}
+	return network.Stats, nil
+}
+
+// GetJobs handles GET /api/admin/jobs?status=&kind= - queued, running and finished jobs, newest
+// first, with their progress, durations and latest error
+func GetJobs(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.Job](c, params.Fields) {
+		return
+	}
+
+	filter := database.JobFilter{Status: c.Query("status"), Kind: c.Query("kind")}
+	errs := map[string]string{}
+	if filter.Status != "" && !slices.Contains(models.JobStatuses, filter.Status) {
+		errs["status"] = "must be one of " + strings.Join(models.JobStatuses, ", ")
+	}
+	if kinds := jobs.JobKinds(); filter.Kind != "" && !slices.Contains(kinds, filter.Kind) {
+		errs["kind"] = "must be one of " + strings.Join(kinds, ", ")
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	list, err := database.GetJobs(filter, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch jobs: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	for i := range list {
+		list[i] = withLiveProgress(list[i])
+	}
+
+	respondList(c, start, list, params.Fields)
+}
+
+// GetJob handles GET /api/admin/jobs/:id
+func GetJob(c *gin.Context) {
+	start := time.Now()
+
+	id, ok := jobID(c, start)
+	if !ok {
+		return
+	}
+	job, ok := fetchJob(c, start, id)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    withLiveProgress(job),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// CreateJob handles POST /api/admin/jobs - queues an etl, score_recompute, export,
+// network_layout or search_reindex job. The response is 202 with the queued job.
+func CreateJob(c *gin.Context) {
+	start := time.Now()
+
+	var req models.JobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid job: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	fieldErrors := map[string]string{}
+	if kinds := jobs.JobKinds(); !slices.Contains(kinds, req.Kind) {
+		fieldErrors["kind"] = "must be one of " + strings.Join(kinds, ", ")
+	} else if err := jobs.CheckJob(req.Kind, req.Payload); err != nil {
+		fieldErrors["payload"] = err.Error()
+	}
+	if req.MaxAttempts == 0 {
+		req.MaxAttempts = models.DefaultJobAttempts
+	}
+	if req.MaxAttempts < 1 || req.MaxAttempts > maxJobAttempts {
+		fieldErrors["max_attempts"] = "must be between 1 and " + strconv.Itoa(maxJobAttempts)
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid job",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	job, err := jobs.Enqueue(req.Kind, req.Payload, req.MaxAttempts, middleware.Actor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to queue job: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	audit(c, "job_enqueue", "jobs/"+strconv.FormatInt(job.ID, 10), map[string]interface{}{
+		"kind":    job.Kind,
+		"payload": job.Payload,
+	})
+
+	c.Header("Location", "/api/admin/jobs/"+strconv.FormatInt(job.ID, 10))
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Data:    job,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// RetryJob handles POST /api/admin/jobs/:id/retry - runs a failed or cancelled job again with
+// a fresh set of attempts; a queued job waiting out a retry delay runs at once
+func RetryJob(c *gin.Context) {
+	changeJob(c, "job_retry", "retried", []string{models.JobQueued, models.JobFailed, models.JobCancelled}, database.RetryJob)
+}
+
+// CancelJob handles POST /api/admin/jobs/:id/cancel - cancels a queued job, or stops a running
+// one at its next heartbeat
+func CancelJob(c *gin.Context) {
+	changeJob(c, "job_cancel", "cancelled", []string{models.JobQueued, models.JobRunning}, database.CancelJob)
+}
+
+// changeJob applies a retry or cancel to the :id job, answering 409 when the job is not in one
+// of the from statuses
+func changeJob(c *gin.Context, action, verb string, from []string, change func(int64, []string) (models.Job, error)) {
+	start := time.Now()
+
+	id, ok := jobID(c, start)
+	if !ok {
+		return
+	}
+	if _, ok := fetchJob(c, start, id); !ok {
+		return
+	}
+
+	job, err := change(id, from)
+	if errors.Is(err, sql.ErrNoRows) {
+		current, _ := database.GetJob(id)
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Job is %s and cannot be %s", current.Status, verb),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to update job: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	audit(c, action, "jobs/"+strconv.FormatInt(id, 10), map[string]interface{}{"kind": job.Kind})
+	if action == "job_retry" {
+		jobs.Wake()
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    job,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// jobID parses the :id parameter. On failure it writes the response and returns false.
+func jobID(c *gin.Context, start time.Time) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid job id",
+			Time:    time.Since(start).String(),
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// fetchJob loads a job. On failure it writes the response and returns false.
+func fetchJob(c *gin.Context, start time.Time, id int64) (models.Job, bool) {
+	job, err := database.GetJob(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Job not found",
+			Time:    time.Since(start).String(),
+		})
+		return job, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch job: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return job, false
+	}
+	return job, true
+}
+
+// withLiveProgress replaces a running job's last heartbeat with its task's current progress
+// when it runs on this instance
+func withLiveProgress(job models.Job) models.Job {
+	if job.Status != models.JobRunning || job.TaskID == "" {
+		return job
+	}
+	task, ok := progress.Get(job.TaskID)
+	if !ok {
+		return job
+	}
+	p := task.Snapshot()
+	job.Stage, job.Done, job.Total, job.Percent = p.Stage, p.Done, p.Total, p.Percent
+	return job
+}
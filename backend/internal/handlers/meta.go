@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/labels"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetLabels handles GET /api/meta/labels - display labels and notes for the API's field names,
+// in the language Accept-Language prefers (pt-BR or en); ?lang= overrides it
+func GetLabels(c *gin.Context) {
+	start := time.Now()
+
+	lang := labels.Negotiate(c.Query("lang"), c.GetHeader("Accept-Language"))
+	c.Header("Content-Language", lang)
+	c.Writer.Header().Add("Vary", "Accept-Language")
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    labels.For(lang),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// GetEnums handles GET /api/meta/enums - the valid node types, connection types, sanction types,
+// expense categories, UFs and parties, with counts, for filter dropdowns
+func GetEnums(c *gin.Context) {
+	start := time.Now()
+
+	enums, err := loadCached(c, utils.CacheKey("enums"), config.CacheTTL("enums"), database.GetEnums)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to list enums: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    enums,
+		Time:    time.Since(start).String(),
+	})
+}
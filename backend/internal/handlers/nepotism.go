@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetNepotism handles GET /api/analysis/nepotism - cabinet staff sharing uncommon family names
+// with the politician who hired them or another politician of the same state, strongest first
+// (?politician_id=, ?match_type=same_office|cross_office, ?min_score=0..1)
+func GetNepotism(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.NepotismFlag](c, params.Fields) {
+		return
+	}
+
+	var filter database.NepotismFilter
+	errs := map[string]string{}
+	if v := c.Query("politician_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil || id < 1 {
+			errs["politician_id"] = "must be a positive integer"
+		}
+		filter.PoliticianID = id
+	}
+	if v := c.Query("match_type"); v != "" {
+		if v != database.NepotismSameOffice && v != database.NepotismCrossOffice {
+			errs["match_type"] = "must be same_office or cross_office"
+		}
+		filter.MatchType = v
+	}
+	if v := c.Query("min_score"); v != "" {
+		score, err := strconv.ParseFloat(v, 64)
+		if err != nil || score < 0 || score > 1 {
+			errs["min_score"] = "must be a number from 0 to 1"
+		}
+		filter.MinScore = score
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	cacheKey := utils.CacheKey("nepotism", filter.PoliticianID, filter.MatchType, filter.MinScore, params.Limit, params.Offset)
+
+	var flags []models.NepotismFlag
+	if cached, found := utils.GetCache(cacheKey); found {
+		flags = cached.([]models.NepotismFlag)
+	} else {
+		var err error
+		flags, err = database.GetNepotismFlags(filter, params.Limit, params.Offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch nepotism flags: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, flags, config.CacheTTL("nepotism"))
+	}
+
+	respondList(c, start, flags, params.Fields)
+}
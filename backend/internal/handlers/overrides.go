@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetOverrides handles GET /api/admin/overrides - curated field values, most recently changed
+// first (?entity_type=, ?entity_id=)
+func GetOverrides(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.Override](c, params.Fields) {
+		return
+	}
+
+	filter := database.OverrideFilter{EntityType: c.Query("entity_type"), EntityID: c.Query("entity_id")}
+	if _, ok := models.CorrectableFields[filter.EntityType]; filter.EntityType != "" && !ok {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  map[string]string{"entity_type": "must be politician, company or expense"},
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	overrides, err := database.GetOverrides(filter, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch overrides: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	respondList(c, start, overrides, params.Fields)
+}
+
+// CreateOverride handles POST /api/admin/overrides - sets a field of a politician, company or
+// expense to a curated value, replacing the field's previous override, and sets it again after
+// every ETL run
+func CreateOverride(c *gin.Context) {
+	start := time.Now()
+
+	var req models.OverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid override: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	override := models.Override{
+		EntityType: req.EntityType,
+		Field:      req.Field,
+		Reason:     strings.TrimSpace(req.Reason),
+		Author:     middleware.Actor(c),
+	}
+	fieldErrors := map[string]string{}
+	override.EntityID = correctedEntityID(req.EntityType, req.EntityID, fieldErrors)
+	if fields, ok := models.CorrectableFields[req.EntityType]; ok {
+		if !slices.Contains(fields, req.Field) {
+			fieldErrors["field"] = "must be one of " + strings.Join(fields, ", ")
+		} else if value, msg := correctionValue(req.Field, req.Value); msg != "" {
+			fieldErrors["value"] = msg
+		} else {
+			override.Value = value
+		}
+	}
+	if override.Reason == "" || len(override.Reason) > 1000 {
+		fieldErrors["reason"] = "is required and must be at most 1000 characters"
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid override",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	previous, ok := entityFieldValue(c, start, "Invalid override", override.EntityType, override.EntityID, override.Field)
+	if !ok {
+		return
+	}
+
+	if err := database.SetOverride(&override); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	audit(c, "override_set", "overrides/"+strconv.FormatInt(override.ID, 10), map[string]interface{}{
+		"entity":   override.EntityType + ":" + override.EntityID,
+		"field":    override.Field,
+		"value":    override.Value,
+		"previous": previous,
+	})
+	// An overridden name or party shows anywhere, as after an ETL run
+	utils.FlushCache()
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    override,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// DeleteOverride handles DELETE /api/admin/overrides/:id - stops overriding the field, which
+// keeps its value until the next ETL run restores the source's
+func DeleteOverride(c *gin.Context) {
+	start := time.Now()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid override id",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	override, err := database.GetOverride(id)
+	if err == nil {
+		_, err = database.DeleteOverride(id)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Override not found",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	audit(c, "override_delete", "overrides/"+strconv.FormatInt(id, 10), map[string]interface{}{
+		"entity": override.EntityType + ":" + override.EntityID,
+		"field":  override.Field,
+		"value":  override.Value,
+	})
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"deleted": override.ID},
+		Time:    time.Since(start).String(),
+	})
+}
+
+// ApplyOverrides handles POST /api/admin/overrides/apply - sets the overridden fields again, for
+// ETL runs made from a shell rather than queued through /api/admin/jobs
+func ApplyOverrides(c *gin.Context) {
+	start := time.Now()
+
+	changed, err := database.ApplyOverrides()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to apply overrides: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	audit(c, "overrides_apply", "overrides", map[string]interface{}{"changed": changed})
+	if changed > 0 {
+		utils.FlushCache()
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    map[string]int{"changed": changed},
+		Time:    time.Since(start).String(),
+	})
+}
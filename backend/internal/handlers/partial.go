@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"political-network-api/internal/database"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// droppedRowsKey counts the rows left out of a response because they failed to scan
+const droppedRowsKey = "dropped_rows"
+
+// partialReads collects the reads of one response that dropped rows (see database.PartialError)
+type partialReads []error
+
+// failed reports whether err fails the response. Reads that only dropped rows returned usable
+// data: they are kept for err and the response goes on.
+func (p *partialReads) failed(err error) bool {
+	if _, usable := database.DroppedRows(err); !usable {
+		return true
+	}
+	if err != nil {
+		*p = append(*p, err)
+	}
+	return false
+}
+
+// err sums the dropped rows of the reads, nil when none dropped any
+func (p partialReads) err() error {
+	return database.JoinPartial(p...)
+}
+
+// markDropped adds n rows to those left out of c's response: an X-Dropped-Rows header and
+// "dropped_rows" in the JSON body
+func markDropped(c *gin.Context, n int) {
+	n += c.GetInt(droppedRowsKey)
+	c.Set(droppedRowsKey, n)
+	c.Header("X-Dropped-Rows", strconv.Itoa(n))
+}
+
+// droppedRows is how many rows were left out of c's response
+func droppedRows(c *gin.Context) int {
+	return c.GetInt(droppedRowsKey)
+}
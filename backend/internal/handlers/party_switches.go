@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var partySiglaPattern = regexp.MustCompile(`^[\p{Lu}0-9 ]{1,20}$`)
+
+// GetPartySwitches handles GET /api/analysis/party-switches - politicians who changed
+// parties, when, and whether it was during the janela partidária
+// (?politician_id=, ?party=SIGLA, ?year=)
+func GetPartySwitches(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.PartySwitch](c, params.Fields) {
+		return
+	}
+
+	var filter database.PartySwitchFilter
+	errs := map[string]string{}
+	if v := c.Query("politician_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil || id < 1 {
+			errs["politician_id"] = "must be a positive integer"
+		}
+		filter.PoliticianID = id
+	}
+	if v := c.Query("party"); v != "" {
+		filter.Party = strings.ToUpper(strings.TrimSpace(v))
+		if !partySiglaPattern.MatchString(filter.Party) {
+			errs["party"] = "must be a party sigla such as PT or UNIÃO"
+		}
+	}
+	if v := c.Query("year"); v != "" {
+		year, err := strconv.Atoi(v)
+		if err != nil || year < 1946 || year > 2100 {
+			errs["year"] = "must be a year such as 2022"
+		}
+		filter.Year = year
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	cacheKey := utils.CacheKey("party_switches", filter.PoliticianID, filter.Party, filter.Year, params.Limit, params.Offset)
+
+	var switches []models.PartySwitch
+	if cached, found := utils.GetCache(cacheKey); found {
+		switches = cached.([]models.PartySwitch)
+	} else {
+		var err error
+		switches, err = database.GetPartySwitches(filter, params.Limit, params.Offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch party switches: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, switches, config.CacheTTL("party_switches"))
+	}
+
+	respondList(c, start, switches, params.Fields)
+}
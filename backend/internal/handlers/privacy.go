@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"political-network-api/internal/export"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/privacy"
+
+	"github.com/gin-gonic/gin"
+)
+
+// privacyPolicy returns how personal data is shaped for the caller's role, auditing any
+// access that reveals unmasked values. Public callers always get masked CPFs and emails (LGPD).
+func privacyPolicy(c *gin.Context, resource string) privacy.Policy {
+	policy := privacy.ForRole(middleware.Role(c))
+	if policy.Reveals() {
+		audit(c, "pii_access", resource, map[string]interface{}{
+			"path": c.Request.URL.RequestURI(),
+		})
+	}
+	return policy
+}
+
+// protectItems returns items as the caller may see them
+func protectItems[T any](c *gin.Context, resource string, items []T) []T {
+	if !privacy.HasPII[T]() {
+		return items
+	}
+	return privacy.Slice(privacyPolicy(c, resource), items)
+}
+
+// protectEach wraps a row source so each row is shaped for the caller's role
+func protectEach[T any](c *gin.Context, resource string, each export.Each[T]) export.Each[T] {
+	if !privacy.HasPII[T]() {
+		return each
+	}
+	return shapeEach(privacyPolicy(c, resource), each)
+}
+
+// shapeEach wraps a row source so each row is shaped by policy
+func shapeEach[T any](policy privacy.Policy, each export.Each[T]) export.Each[T] {
+	return func(fn func(T) error) error {
+		return each(func(item T) error { return fn(privacy.Apply(policy, item)) })
+	}
+}
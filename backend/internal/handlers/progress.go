@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/progress"
+	"political-network-api/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// progressEventInterval is the shortest gap between two progress events on one stream,
+	// so row-by-row updates are sent as a few events per second
+	progressEventInterval = 250 * time.Millisecond
+	// progressKeepAlive is how often an idle stream sends a comment to keep proxies from
+	// closing it
+	progressKeepAlive = 15 * time.Second
+)
+
+// RebuildNetwork handles POST /api/network/rebuild - rebuilds the cached network in the background.
+// A rebuild already in progress is joined rather than started again. Each one reads every
+// table, so the route takes a researcher or admin key.
+func RebuildNetwork(c *gin.Context) {
+	start := time.Now()
+
+	task, started, err := progress.StartOrJoin("network_rebuild", networkBuildStages)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to start network rebuild: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	if started {
+		audit(c, "network_rebuild", "network", map[string]interface{}{"task_id": task.ID()})
+		go func() {
+			network, err := buildNetworkData(task)
+			if dropped, usable := database.DroppedRows(err); usable && err != nil {
+				log.Printf("⚠️ Network rebuilt without %d rows that failed to scan", dropped)
+				err = nil
+			}
+			if err != nil {
+				log.Printf("❌ Network rebuild failed: %v", err)
+				task.Finish(nil, err)
+				return
+			}
+			if err := utils.SetCacheCompressed("network_complete", network, config.CacheTTL("network")); err != nil {
+				log.Printf("⚠️ Rebuilt network not cached: %v", err)
+			}
+			task.Finish(network.Stats, nil)
+		}()
+	}
+
+	acceptedTask(c, start, task)
+}
+
+// GetProgress handles GET /api/progress/:id - a snapshot of a background build
+func GetProgress(c *gin.Context) {
+	start := time.Now()
+
+	task, ok := progressTask(c, start)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    withEventsURL(c, task.Snapshot()),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// GetProgressEvents handles GET /api/progress/:id/events - a Server-Sent Events stream of
+// "progress" events, ending with one "done" or "error" event carrying the final snapshot
+func GetProgressEvents(c *gin.Context) {
+	start := time.Now()
+
+	task, ok := progressTask(c, start)
+	if !ok {
+		return
+	}
+
+	changed, stop := task.Watch()
+	defer stop()
+	keepAlive := time.NewTicker(progressKeepAlive)
+	defer keepAlive.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+
+	first := true
+	c.Stream(func(w io.Writer) bool {
+		if !first {
+			select {
+			case <-changed:
+				// Let a burst of updates settle into one event
+				time.Sleep(progressEventInterval)
+			case <-keepAlive.C:
+				_, err := io.WriteString(w, ": keep-alive\n\n")
+				return err == nil
+			case <-c.Request.Context().Done():
+				return false
+			}
+		}
+		first = false
+
+		p := task.Snapshot()
+		switch p.Status {
+		case models.ProgressDone:
+			c.SSEvent("done", p)
+		case models.ProgressFailed:
+			c.SSEvent("error", p)
+		default:
+			c.SSEvent("progress", p)
+		}
+		return p.Status == models.ProgressRunning
+	})
+}
+
+// acceptedTask answers 202 with the task's snapshot and where to follow it
+func acceptedTask(c *gin.Context, start time.Time, task *progress.Task) {
+	c.Header("Location", "/api/progress/"+task.ID())
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Data:    withEventsURL(c, task.Snapshot()),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// progressTask looks up the :id task. On failure it writes the response and returns false.
+func progressTask(c *gin.Context, start time.Time) (*progress.Task, bool) {
+	task, found := progress.Get(c.Param("id"))
+	if !found {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Task not found or expired",
+			Time:    time.Since(start).String(),
+		})
+		return nil, false
+	}
+	return task, true
+}
+
+func withEventsURL(c *gin.Context, p models.Progress) models.Progress {
+	p.EventsURL = requestBaseURL(c) + "/api/progress/" + p.TaskID + "/events"
+	return p
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetProvenance handles GET /api/provenance?resource=&id= - the source system, source record
+// ID, link and fetch time of the rows behind a politician, party, company (id is the CNPJ),
+// sanction or TCU ruling, with per-source row counts of its related datasets
+func GetProvenance(c *gin.Context) {
+	start := time.Now()
+
+	resource := c.Query("resource")
+	id := strings.TrimSpace(c.Query("id"))
+	errs := map[string]string{}
+	if !slices.Contains(models.ProvenanceResources, resource) {
+		errs["resource"] = "must be one of " + strings.Join(models.ProvenanceResources, ", ")
+	} else if resource == models.ProvenanceCompany {
+		id = normalizeCNPJ(id)
+		if !cnpjPattern.MatchString(id) {
+			errs["id"] = "must be a CNPJ (14 digits)"
+		}
+	} else if n, err := strconv.Atoi(id); err != nil || n < 1 {
+		errs["id"] = "must be a positive integer"
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	rd, release, ok := datasetReader(c, start)
+	if !ok {
+		return
+	}
+	defer release()
+
+	cacheKey := versionedCacheKey(rd, "provenance", resource, id)
+
+	var provenance models.Provenance
+	if cached, found := utils.GetCache(cacheKey); found {
+		provenance = cached.(models.Provenance)
+	} else {
+		var err error
+		provenance, err = rd.GetProvenance(resource, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   "No " + resource + " " + id + " found",
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch provenance: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, provenance, config.CacheTTL("provenance"))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    provenance,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// detailProvenance loads the provenance embedded in detail responses. It is supporting data:
+// a database that predates the provenance columns gets the detail without it.
+func detailProvenance(rd database.Repository, resource, id string) *models.Provenance {
+	provenance, err := rd.GetProvenance(resource, id)
+	if err != nil {
+		log.Printf("⚠️ Provenance of %s %s not loaded: %v", resource, id, err)
+		return nil
+	}
+	return &provenance
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// querySorts are the ?sort= orders of GET /api/admin/queries, each highest first
+var querySorts = map[string]func(models.QueryStats) float64{
+	"total":  func(q models.QueryStats) float64 { return q.TotalMs },
+	"avg":    func(q models.QueryStats) float64 { return q.AvgMs },
+	"p95":    func(q models.QueryStats) float64 { return q.P95Ms },
+	"max":    func(q models.QueryStats) float64 { return q.MaxMs },
+	"calls":  func(q models.QueryStats) float64 { return float64(q.Calls) },
+	"errors": func(q models.QueryStats) float64 { return float64(q.Errors) },
+	"slow":   func(q models.QueryStats) float64 { return float64(q.Slow) },
+}
+
+// GetQueryStats handles GET /api/admin/queries?sort=total&top=50 - calls, errors, slow runs and
+// latencies of each database query since startup, named after the function that runs it
+func GetQueryStats(c *gin.Context) {
+	start := time.Now()
+
+	fieldErrors := map[string]string{}
+	by, ok := querySorts[c.DefaultQuery("sort", "total")]
+	if !ok {
+		fieldErrors["sort"] = "must be one of total, avg, p95, max, calls, errors, slow"
+	}
+	top, err := strconv.Atoi(c.DefaultQuery("top", "50"))
+	if err != nil || top < 1 || top > 1000 {
+		fieldErrors["top"] = "must be between 1 and 1000"
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	report := database.QueryStats()
+	sort.SliceStable(report.Queries, func(i, j int) bool {
+		return by(report.Queries[i]) > by(report.Queries[j])
+	})
+	if len(report.Queries) > top {
+		report.Queries = report.Queries[:top]
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    report,
+		Count:   len(report.Queries),
+		Time:    time.Since(start).String(),
+	})
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetFamilyRelations handles GET /api/relations - the family relation review queue and record
+// (?status=suggested|confirmed|rejected, ?politician_id=). Not cached: reviewers need to see
+// their own changes.
+func GetFamilyRelations(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.FamilyRelation](c, params.Fields) {
+		return
+	}
+
+	var filter database.RelationFilter
+	errs := map[string]string{}
+	if v := c.Query("politician_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil || id < 1 {
+			errs["politician_id"] = "must be a positive integer"
+		}
+		filter.PoliticianID = id
+	}
+	if v := c.Query("status"); v != "" {
+		if v != models.RelationSuggested && v != models.RelationConfirmed && v != models.RelationRejected {
+			errs["status"] = "must be suggested, confirmed or rejected"
+		}
+		filter.Status = v
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	relations, err := database.GetFamilyRelations(filter, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch family relations: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	respondList(c, start, relations, params.Fields)
+}
+
+// CreateFamilyRelation handles POST /api/relations - records a relation a researcher knows of
+// as confirmed, replacing any suggestion for the same pair
+func CreateFamilyRelation(c *gin.Context) {
+	start := time.Now()
+
+	var req models.FamilyRelationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid family relation: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	req.Relationship = strings.TrimSpace(req.Relationship)
+	if req.Relationship == "" {
+		req.Relationship = "relative"
+	}
+	fieldErrors := map[string]string{}
+	if req.PoliticianID < 1 {
+		fieldErrors["politician_id"] = "must be a positive integer"
+	}
+	if req.RelativeID < 1 || req.RelativeID == req.PoliticianID {
+		fieldErrors["relative_id"] = "must be a positive integer other than politician_id"
+	}
+	if _, ok := models.Relationships[req.Relationship]; !ok {
+		fieldErrors["relationship"] = "must be one of " + relationshipNames()
+	}
+	if len(req.Note) > 1000 {
+		fieldErrors["note"] = "must be at most 1000 characters"
+	}
+	if len(fieldErrors) == 0 {
+		missing, err := database.MissingPoliticians([]int{req.PoliticianID, req.RelativeID})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+		if len(missing) > 0 {
+			fieldErrors["politician_id"] = fmt.Sprintf("unknown politician id(s): %v", missing)
+		}
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid family relation",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	relation := models.FamilyRelation{
+		PoliticianID: req.PoliticianID,
+		RelativeID:   req.RelativeID,
+		Relationship: req.Relationship,
+		Status:       models.RelationConfirmed,
+		Source:       models.RelationSourceManual,
+		Note:         strings.TrimSpace(req.Note),
+		ReviewedBy:   middleware.Actor(c),
+	}
+	if err := database.SaveFamilyRelation(&relation); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to save family relation: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	audit(c, "relation_create", "relations/"+strconv.FormatInt(relation.ID, 10), map[string]interface{}{
+		"politician_id": relation.PoliticianID,
+		"relative_id":   relation.RelativeID,
+		"relationship":  relation.Relationship,
+	})
+	invalidateFamilyCaches()
+
+	created, err := database.GetFamilyRelation(relation.ID)
+	if err != nil {
+		created = relation
+	}
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    database.OrientRelation(created, req.PoliticianID),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// ReviewFamilyRelation handles PATCH /api/relations/:id - confirms or rejects a relation,
+// optionally correcting its relationship (as what relative_id is to politician_id)
+func ReviewFamilyRelation(c *gin.Context) {
+	start := time.Now()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid relation id",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	var req models.FamilyRelationReview
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid review: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	relation, err := database.GetFamilyRelation(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Family relation not found",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch family relation: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	req.Relationship = strings.TrimSpace(req.Relationship)
+	if req.Relationship == "" {
+		req.Relationship = relation.Relationship
+	}
+	fieldErrors := map[string]string{}
+	if req.Status != models.RelationConfirmed && req.Status != models.RelationRejected {
+		fieldErrors["status"] = "must be confirmed or rejected"
+	}
+	if _, ok := models.Relationships[req.Relationship]; !ok {
+		fieldErrors["relationship"] = "must be one of " + relationshipNames()
+	}
+	if len(req.Note) > 1000 {
+		fieldErrors["note"] = "must be at most 1000 characters"
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid review",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	err = database.ReviewFamilyRelation(id, req.Status, req.Relationship, strings.TrimSpace(req.Note), middleware.Actor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	audit(c, "relation_review", "relations/"+strconv.FormatInt(id, 10), map[string]interface{}{
+		"status":       req.Status,
+		"relationship": req.Relationship,
+		"previous":     relation.Status,
+	})
+	invalidateFamilyCaches()
+
+	reviewed, err := database.GetFamilyRelation(id)
+	if err != nil {
+		reviewed = relation
+	}
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    reviewed,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// relationshipNames lists the accepted relationships for validation messages
+func relationshipNames() string {
+	names := make([]string, 0, len(models.Relationships))
+	for name := range models.Relationships {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// invalidateFamilyCaches drops cached data that embeds confirmed relations: the family edges
+// of the network and its exports, and the relatives of politician detail
+func invalidateFamilyCaches() {
+	utils.DeleteCachePrefix("connections_all", "network", "politician_detail")
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/export"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// reportTopVendors is how many vendors a politician report lists
+const reportTopVendors = 20
+
+// reportFormats maps the ?format= values of reports other than json to their writers and
+// content types
+var reportFormats = map[string]struct {
+	write       func(io.Writer, models.PoliticianReport) error
+	contentType string
+	disposition string
+}{
+	"html": {export.WritePoliticianReportHTML, "text/html; charset=utf-8", "inline"},
+	"pdf":  {export.WritePoliticianReportPDF, "application/pdf", "attachment"},
+}
+
+// GetPoliticianReport handles GET /api/reports/politician/:id - the politician's dossier:
+// profile, spending, payments to sanctioned vendors, risk score breakdown and connection counts
+// (?format=json|html|pdf)
+func GetPoliticianReport(c *gin.Context) {
+	start := time.Now()
+
+	id, ok := politicianID(c, start, repos.Live)
+	if !ok {
+		return
+	}
+	format := c.DefaultQuery("format", "json")
+	if _, ok := reportFormats[format]; !ok && format != "json" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Unsupported report format: " + format + " (use json, html or pdf)",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	report, err := loadCached(c, utils.CacheKey("politician_report", id), config.CacheTTL("politician_report"), func() (models.PoliticianReport, error) {
+		return buildPoliticianReport(id)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Politician not found",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to build report: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	policy := privacyPolicy(c, "politician_report")
+	report.Politician = policy.Politician(report.Politician)
+	if index, err := loadPoliticianIndex(c, repos.Live); err == nil {
+		index.identifyPolitician(&report.Politician)
+	}
+	vendors := make([]models.ReportVendor, len(report.Spending.TopVendors))
+	for i, v := range report.Spending.TopVendors {
+		v.CNPJ = policy.Document(v.CNPJ)
+		vendors[i] = v
+	}
+	report.Spending.TopVendors = vendors
+
+	writer, ok := reportFormats[format]
+	if !ok {
+		c.JSON(http.StatusOK, models.APIResponse{
+			Success: true,
+			Data:    report,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", writer.contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("%s; filename=dossier-politician-%d.%s", writer.disposition, id, format))
+	c.Status(http.StatusOK)
+
+	if err := writer.write(c.Writer, report); err != nil {
+		c.Error(err)
+	}
+}
+
+// buildPoliticianReport reads everything a politician report holds from the live data
+func buildPoliticianReport(id int) (models.PoliticianReport, error) {
+	politician, err := repos.Live.GetPolitician(id)
+	if err != nil {
+		return models.PoliticianReport{}, err
+	}
+	report := models.PoliticianReport{Politician: politician, GeneratedAt: time.Now().UTC()}
+
+	if report.Spending, err = database.GetPoliticianSpending(id, reportTopVendors); err != nil {
+		return report, err
+	}
+	if report.SanctionedVendorPayments, err = database.GetSanctionedVendorPayments(id); err != nil {
+		return report, err
+	}
+	if report.Connections, err = database.GetConnectionSummary(id); err != nil {
+		return report, err
+	}
+
+	signals, err := database.GetScoreSignals(id)
+	if err != nil {
+		return report, err
+	}
+	declarations, dates, err := database.GetPoliticianAssetDeclarations(id)
+	if err != nil {
+		return report, err
+	}
+	assets := assetGrowth(id, cachedAssets{declarations: declarations, dates: dates}, nil)
+	report.Score = scoreBreakdown(politician.CorruptionScore, signals, assets.UnusualGrowth)
+
+	return report, nil
+}
+
+// scoreBreakdown explains a risk score with the weights of cli4 post-process --enhanced, which
+// computes the stored score: TCU disqualifications 40 (+20 while one is in force), sanctioned
+// vendors 30 (+10 for more than two), unusual wealth growth 20 and far-away vendors of local
+// expenses 15, capped at 100
+func scoreBreakdown(score int, s database.ScoreSignals, unusualGrowth bool) models.ScoreBreakdown {
+	points := func(applies bool, weight int) int {
+		if applies {
+			return weight
+		}
+		return 0
+	}
+
+	factors := []models.ScoreFactor{
+		{
+			Factor:    "tcu_disqualification",
+			Points:    points(s.TCUDisqualifications > 0, 40),
+			MaxPoints: 40,
+			Detail:    fmt.Sprintf("%d TCU disqualification(s)", s.TCUDisqualifications),
+		},
+		{
+			Factor:    "tcu_disqualification_in_force",
+			Points:    points(s.TCUDisqualifications > 0 && s.TCUActive > 0, 20),
+			MaxPoints: 20,
+			Detail:    fmt.Sprintf("%d in force", s.TCUActive),
+		},
+		{
+			Factor:    "sanctioned_vendors",
+			Points:    points(s.SanctionedVendors > 0, 30),
+			MaxPoints: 30,
+			Detail:    fmt.Sprintf("%d vendor(s) with an active sanction paid R$ %s", s.SanctionedVendors, s.SanctionedAmount),
+		},
+		{
+			Factor:    "several_sanctioned_vendors",
+			Points:    points(s.SanctionedVendors > 2, 10),
+			MaxPoints: 10,
+			Detail:    "more than two sanctioned vendors",
+		},
+		{
+			Factor:    "unusual_wealth_growth",
+			Points:    points(unusualGrowth, 20),
+			MaxPoints: 20,
+			Detail:    "declared assets at least doubled, by R$ 500000.00 or more, between elections",
+		},
+		{
+			Factor:    "geo_mismatch",
+			Points:    points(s.GeoMismatchVendors > 0, 15),
+			MaxPoints: 15,
+			Detail:    fmt.Sprintf("%d far-away vendor(s) of local expenses, R$ %s", s.GeoMismatchVendors, s.GeoMismatchAmount),
+		},
+	}
+
+	computed := 0
+	for _, f := range factors {
+		computed += f.Points
+	}
+	return models.ScoreBreakdown{Score: score, Computed: min(computed, 100), Factors: factors}
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/demo"
+	"political-network-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Repositories are the data sources the core read handlers go through. Main wires the Postgres
+// ones with UseRepositories once the database is open; tests and alternate backends supply
+// their own.
+type Repositories struct {
+	// Live reads the current data
+	Live database.Repository
+	// Pin returns a repository of a published dataset version and release to call when done
+	// with it; sql.ErrNoRows means the version is not published
+	Pin func(version int) (database.Repository, func(), error)
+	// Network builds the connections and statistics behind /api/network
+	Network database.ConnectionRepo
+	// Health checks the data source for /health
+	Health func() error
+}
+
+// repos is set by UseRepositories
+var repos Repositories
+
+// PostgresRepositories returns the repositories backed by the open database
+func PostgresRepositories() Repositories {
+	return Repositories{
+		Live: database.Live(),
+		Pin: func(version int) (database.Repository, func(), error) {
+			return database.Pin(version)
+		},
+		Network: database.Network{},
+		Health:  database.Health,
+	}
+}
+
+// SQLiteRepositories returns the repositories backed by a SQLite file opened with
+// database.OpenSQLite: the same reads, no dataset versions, and a network of the connections its
+// tables hold
+func SQLiteRepositories() Repositories {
+	r := PostgresRepositories()
+	r.Network = database.SQLiteNetwork{}
+	return r
+}
+
+// DemoRepositories returns the repositories of the in-memory demo dataset, which has no
+// dataset versions and is always healthy
+func DemoRepositories(data *demo.Dataset) Repositories {
+	return Repositories{
+		Live: data.Repository(),
+		Pin: func(version int) (database.Repository, func(), error) {
+			return nil, nil, sql.ErrNoRows
+		},
+		Network: data.Network(),
+		Health:  func() error { return nil },
+	}
+}
+
+// UseRepositories sets the repositories the handlers read through. Call it before serving.
+func UseRepositories(r Repositories) {
+	repos = r
+}
+
+// sqliteRoutes are the routes a SQLite file can serve: the ones reading through repositories or
+// its portable tables, and those that don't read the database
+var sqliteRoutes = map[string]bool{
+	"/health":                              true,
+	"/api/politicians":                     true,
+	"/api/politicians/:id":                 true,
+	"/api/politicians/:id/top-vendors":     true,
+	"/api/parties":                         true,
+	"/api/parties/:id":                     true,
+	"/api/companies":                       true,
+	"/api/companies/groups":                true,
+	"/api/companies/groups/:root":          true,
+	"/api/companies/:cnpj":                 true,
+	"/api/companies/:cnpj/top-politicians": true,
+	"/api/sanctions":                       true,
+	"/api/sanctions/:id":                   true,
+	"/api/tcu/rulings":                     true,
+	"/api/tcu/rulings/:id":                 true,
+	"/api/expenses":                        true,
+	"/api/connections":                     true,
+	"/api/provenance":                      true,
+	"/api/network":                         true,
+	"/api/images/:entity/:id":              true,
+	"/api/stats":                           true,
+	"/api/flags":                           true,
+	"/api/meta/labels":                     true,
+	"/api/batch":                           true,
+	"/api/admin/cache":                     true,
+	"/api/admin/cache/clear":               true,
+	"/api/admin/usage":                     true,
+	"/api/admin/queries":                   true,
+	"/api/admin/config":                    true,
+	"/api/admin/runtime":                   true,
+	"/api/admin/data-quality":              true,
+	"/debug/pprof/":                        true,
+	"/debug/pprof/cmdline":                 true,
+	"/debug/pprof/profile":                 true,
+	"/debug/pprof/symbol":                  true,
+	"/debug/pprof/trace":                   true,
+	"/debug/pprof/:name":                   true,
+}
+
+// demoRoutes are the routes the demo dataset can serve: the ones reading only through
+// repositories, and those that don't read the database
+var demoRoutes = map[string]bool{
+	"/health":                              true,
+	"/api/politicians":                     true,
+	"/api/politicians/:id":                 true,
+	"/api/politicians/:id/top-vendors":     true,
+	"/api/parties":                         true,
+	"/api/parties/:id":                     true,
+	"/api/companies":                       true,
+	"/api/companies/:cnpj":                 true,
+	"/api/companies/:cnpj/top-politicians": true,
+	"/api/sanctions":                       true,
+	"/api/sanctions/:id":                   true,
+	"/api/tcu/rulings":                     true,
+	"/api/tcu/rulings/:id":                 true,
+	"/api/expenses":                        true,
+	"/api/connections":                     true,
+	"/api/provenance":                      true,
+	"/api/network":                         true,
+	"/api/images/:entity/:id":              true,
+	"/api/stats":                           true,
+	"/api/flags":                           true,
+	"/api/meta/labels":                     true,
+	"/api/batch":                           true,
+	"/api/admin/cache":                     true,
+	"/api/admin/cache/clear":               true,
+	"/api/admin/usage":                     true,
+	"/api/admin/config":                    true,
+	"/api/admin/runtime":                   true,
+	"/debug/pprof/":                        true,
+	"/debug/pprof/cmdline":                 true,
+	"/debug/pprof/profile":                 true,
+	"/debug/pprof/symbol":                  true,
+	"/debug/pprof/trace":                   true,
+	"/debug/pprof/:name":                   true,
+}
+
+// SQLiteRoutesOnly answers 501 on the routes that need Postgres while the API runs on a SQLite
+// file (database.driver: sqlite): their tables or queries have no SQLite counterpart
+func SQLiteRoutesOnly() gin.HandlerFunc {
+	return onlyRoutes(sqliteRoutes, "Not available on the SQLite database; run the API on Postgres for this endpoint")
+}
+
+// DemoRoutesOnly answers 501 on the routes the demo dataset (server.demo) cannot serve
+func DemoRoutesOnly() gin.HandlerFunc {
+	return onlyRoutes(demoRoutes, "Not available in demo mode; run the API on a database for this endpoint")
+}
+
+// onlyRoutes answers 501 with message on the routes not in routes. Unmatched paths still get
+// the router's 404.
+func onlyRoutes(routes map[string]bool, message string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if route := c.FullPath(); route != "" && !routes[route] {
+			c.AbortWithStatusJSON(http.StatusNotImplemented, models.APIResponse{
+				Success: false,
+				Error:   message,
+				Time:    "0ms",
+			})
+			return
+		}
+		c.Next()
+	}
+}
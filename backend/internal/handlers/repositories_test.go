@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"political-network-api/internal/database"
+	"political-network-api/internal/demo"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeRepository serves fixed politicians; reads it doesn't override panic on the nil embed
+type fakeRepository struct {
+	database.Repository
+	politicians []models.Politician
+	// err is returned with the politicians
+	err error
+}
+
+func (f fakeRepository) GetPoliticians(limit, offset, minScore int, filter database.PoliticianFilter) ([]models.Politician, error) {
+	return f.politicians, f.err
+}
+
+func (f fakeRepository) GetPoliticianKeys() ([]models.PoliticianKey, error) {
+	keys := make([]models.PoliticianKey, len(f.politicians))
+	for i, p := range f.politicians {
+		keys[i] = models.PoliticianKey{ID: p.ID, Nome: p.Nome, SiglaPartido: p.SiglaPartido, UF: p.UF}
+	}
+	return keys, nil
+}
+
+func (f fakeRepository) DatasetVersion() int { return 0 }
+
+// fakeNetwork returns stats until the database goes down
+type fakeNetwork struct {
+	database.ConnectionRepo
+	down *bool
+}
+
+func (f fakeNetwork) GetNetworkStats(exact bool) (models.NetworkStats, error) {
+	if *f.down {
+		return models.NetworkStats{}, database.ErrUnavailable
+	}
+	return models.NetworkStats{Politicians: 513}, nil
+}
+
+// useDemo serves the handlers from a fresh cache and the bundled demo dataset, which it returns
+func useDemo(t *testing.T) *demo.Dataset {
+	t.Helper()
+	utils.InitializeCache()
+	data, err := demo.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	UseRepositories(DemoRepositories(data))
+	return data
+}
+
+// serve routes a GET of target to handler, registered under pattern, and decodes the response
+func serve(t *testing.T, pattern string, handler gin.HandlerFunc, target string) (*httptest.ResponseRecorder, models.APIResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET(pattern, handler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+	var resp models.APIResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decoding %s: %v", w.Body.String(), err)
+	}
+	return w, resp
+}
+
+func TestGetPoliticiansReadsRepository(t *testing.T) {
+	utils.InitializeCache()
+	UseRepositories(Repositories{Live: fakeRepository{politicians: []models.Politician{
+		{ID: 1, Nome: "Fulano", UF: "SP"},
+		{ID: 2, Nome: "Beltrana", UF: "RJ"},
+	}}})
+
+	w, resp := serve(t, "/", GetPoliticians, "/?limit=10")
+	if w.Code != http.StatusOK || !resp.Success {
+		t.Fatalf("status %d, response %+v", w.Code, resp)
+	}
+	if resp.Count != 2 {
+		t.Errorf("count = %d, want 2", resp.Count)
+	}
+	if first, _ := resp.Data.([]interface{})[0].(map[string]interface{}); first["slug"] != "fulano-sp" {
+		t.Errorf("slug = %v, want fulano-sp", first["slug"])
+	}
+}
+
+func TestPoliticianIndexSlugs(t *testing.T) {
+	index := buildPoliticianIndex([]models.PoliticianKey{
+		{ID: 1, Nome: "João Silva", SiglaPartido: "PT", UF: "SP", PublicID: "pol_aaaa", Slug: "joao-silva-pt-sp"},
+		{ID: 2, Nome: "João Silva", SiglaPartido: "PT", UF: "SP"},
+		{ID: 3, Nome: "Maria Souza", SiglaPartido: "PL", UF: "MG"},
+	})
+
+	for key, want := range map[string]int{
+		"pol_aaaa":           1,
+		"joao-silva-pt-sp":   1,
+		"joao-silva-pt-sp-2": 2,
+		"maria-souza-pl-mg":  3,
+	} {
+		if got := index.IDs[key]; got != want {
+			t.Errorf("%s resolves to %d, want %d", key, got, want)
+		}
+	}
+}
+
+func TestGetPoliticiansReportsDroppedRows(t *testing.T) {
+	utils.InitializeCache()
+	UseRepositories(Repositories{Live: fakeRepository{
+		politicians: []models.Politician{{ID: 1, Nome: "Fulano", UF: "SP"}},
+		err:         &database.PartialError{Dropped: 1, Read: 2, Err: errors.New("bad row")},
+	}})
+
+	w, resp := serve(t, "/", GetPoliticians, "/?limit=10")
+	if w.Code != http.StatusOK || resp.Count != 1 {
+		t.Fatalf("status %d, response %+v", w.Code, resp)
+	}
+	if resp.DroppedRows != 1 || w.Header().Get("X-Dropped-Rows") != "1" {
+		t.Errorf("dropped_rows = %d, X-Dropped-Rows = %q, want 1", resp.DroppedRows, w.Header().Get("X-Dropped-Rows"))
+	}
+	if _, cached := utils.GetCache(utils.CacheKey("politicians", 10, 0, 0, database.PoliticianFilter{})); cached {
+		t.Error("a list missing rows was cached")
+	}
+}
+
+func TestGetStatsServesStaleWhenDatabaseDown(t *testing.T) {
+	utils.InitializeCache()
+	down := false
+	UseRepositories(Repositories{Network: fakeNetwork{down: &down}})
+
+	if w, resp := serve(t, "/", GetStats, "/"); w.Code != http.StatusOK || resp.Degraded {
+		t.Fatalf("status %d, response %+v", w.Code, resp)
+	}
+
+	// Expire the fresh entry only, as its TTL would
+	utils.Cache.Delete("stats_network")
+	down = true
+
+	w, resp := serve(t, "/", GetStats, "/")
+	if w.Code != http.StatusOK || !resp.Degraded {
+		t.Fatalf("status %d, response %+v, want degraded 200", w.Code, resp)
+	}
+	if w.Header().Get("X-Degraded") == "" {
+		t.Error("missing X-Degraded header")
+	}
+}
+
+func TestDemoRepositoriesServeDataset(t *testing.T) {
+	useDemo(t)
+
+	w, resp := serve(t, "/", GetPoliticians, "/?limit=100&min_score=50")
+	if w.Code != http.StatusOK || resp.Count == 0 {
+		t.Fatalf("status %d, response %+v", w.Code, resp)
+	}
+	for _, p := range resp.Data.([]interface{}) {
+		if score := p.(map[string]interface{})["corruption_score"].(float64); score < 50 {
+			t.Errorf("politician with score %v listed with min_score=50", score)
+		}
+	}
+
+	if w, resp := serve(t, "/", GetStats, "/"); w.Code != http.StatusOK || resp.Degraded {
+		t.Fatalf("status %d, response %+v", w.Code, resp)
+	}
+}
+
+func TestGetPoliticiansPagesByCursor(t *testing.T) {
+	useDemo(t)
+
+	ids := func(resp models.APIResponse) []float64 {
+		var ids []float64
+		for _, p := range resp.Data.([]interface{}) {
+			ids = append(ids, p.(map[string]interface{})["id"].(float64))
+		}
+		return ids
+	}
+
+	_, all := serve(t, "/", GetPoliticians, "/?limit=6&sort=-votes")
+	w, first := serve(t, "/", GetPoliticians, "/?limit=3&sort=-votes")
+	if w.Code != http.StatusOK || first.NextCursor == "" || w.Header().Get("Link") == "" {
+		t.Fatalf("status %d, response %+v, Link %q", w.Code, first, w.Header().Get("Link"))
+	}
+	w, second := serve(t, "/", GetPoliticians, "/?limit=3&cursor="+first.NextCursor)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d, response %+v", w.Code, second)
+	}
+	if got, want := append(ids(first), ids(second)...), ids(all); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("pages = %v, want %v", got, want)
+	}
+
+	if w, _ := serve(t, "/", GetPoliticians, "/?limit=3&cursor="+first.NextCursor+"x"); w.Code != http.StatusBadRequest {
+		t.Errorf("altered cursor: status %d, want 400", w.Code)
+	}
+}
+
+func TestListsPageByCursor(t *testing.T) {
+	useDemo(t)
+
+	for _, list := range []struct {
+		name    string
+		handler gin.HandlerFunc
+		key     string
+	}{
+		{"parties", GetParties, "id"},
+		{"companies", GetCompanies, "cnpj"},
+		{"sanctions", GetSanctions, "id"},
+		{"expenses", GetExpenses, "id"},
+	} {
+		keys := func(resp models.APIResponse) []interface{} {
+			var keys []interface{}
+			for _, item := range resp.Data.([]interface{}) {
+				keys = append(keys, item.(map[string]interface{})[list.key])
+			}
+			return keys
+		}
+
+		_, all := serve(t, "/", list.handler, "/?limit=4")
+		w, first := serve(t, "/", list.handler, "/?limit=2")
+		if w.Code != http.StatusOK || first.NextCursor == "" || w.Header().Get("Link") == "" {
+			t.Fatalf("%s: status %d, response %+v, Link %q", list.name, w.Code, first, w.Header().Get("Link"))
+		}
+		w, second := serve(t, "/", list.handler, "/?limit=2&cursor="+first.NextCursor)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status %d, response %+v", list.name, w.Code, second)
+		}
+		if got, want := append(keys(first), keys(second)...), keys(all); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s: pages = %v, want %v", list.name, got, want)
+		}
+
+		if w, _ := serve(t, "/", list.handler, "/?limit=2&offset=2&cursor="+first.NextCursor); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: cursor with offset: status %d, want 400", list.name, w.Code)
+		}
+		if list.name != "parties" {
+			_, parties := serve(t, "/", GetParties, "/?limit=2")
+			if w, _ := serve(t, "/", list.handler, "/?limit=2&cursor="+parties.NextCursor); w.Code != http.StatusBadRequest {
+				t.Errorf("%s: parties cursor: status %d, want 400", list.name, w.Code)
+			}
+		}
+	}
+}
+
+func TestGetNetworkDataWithoutParameters(t *testing.T) {
+	useDemo(t)
+
+	if w, resp := serve(t, "/", GetNetworkData, "/"); w.Code != http.StatusOK || !resp.Success {
+		t.Fatalf("status %d, response %+v", w.Code, resp)
+	}
+}
+
+func TestGetCompanyTopPoliticians(t *testing.T) {
+	data := useDemo(t)
+	companies, err := data.Repository().GetCompanies(1, 0, database.CompanyFilter{})
+	if err != nil || len(companies) == 0 {
+		t.Fatalf("companies %v, err %v", companies, err)
+	}
+
+	if w, resp := serve(t, "/:cnpj", GetCompanyTopPoliticians, "/"+companies[0].CNPJ); w.Code != http.StatusOK || resp.Count == 0 {
+		t.Errorf("known company: status %d, response %+v", w.Code, resp)
+	}
+	if w, resp := serve(t, "/:cnpj", GetCompanyTopPoliticians, "/00000000000000"); w.Code != http.StatusNotFound {
+		t.Errorf("unknown company: status %d, response %+v", w.Code, resp)
+	}
+}
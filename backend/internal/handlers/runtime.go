@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/models"
+	"runtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// recentGCPauses is how many of the latest GC pauses GET /api/admin/runtime lists
+const recentGCPauses = 10
+
+// GetRuntimeStats handles GET /api/admin/runtime - goroutine count, heap and GC statistics of
+// the process. Heap profiles and the rest of pprof are under /debug/pprof.
+func GetRuntimeStats(c *gin.Context) {
+	start := time.Now()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := models.RuntimeStats{
+		GoVersion:    runtime.Version(),
+		CPUs:         runtime.NumCPU(),
+		Goroutines:   runtime.NumGoroutine(),
+		Uptime:       time.Since(startedAt).Round(time.Second).String(),
+		HeapAlloc:    mem.HeapAlloc,
+		HeapInuse:    mem.HeapInuse,
+		HeapIdle:     mem.HeapIdle,
+		HeapReleased: mem.HeapReleased,
+		HeapObjects:  mem.HeapObjects,
+		Sys:          mem.Sys,
+		TotalAlloc:   mem.TotalAlloc,
+		Mallocs:      mem.Mallocs,
+		Frees:        mem.Frees,
+		GC: models.RuntimeGC{
+			Cycles:         mem.NumGC,
+			Forced:         mem.NumForcedGC,
+			NextTarget:     mem.NextGC,
+			PauseTotalMs:   float64(mem.PauseTotalNs) / 1e6,
+			RecentPausesMs: []float64{},
+			CPUFraction:    mem.GCCPUFraction,
+		},
+	}
+	if mem.LastGC != 0 {
+		last := time.Unix(0, int64(mem.LastGC))
+		stats.GC.LastRun = &last
+	}
+	// PauseNs is a circular buffer; the latest pause is at (NumGC+255)%256
+	for i := uint32(0); i < min(mem.NumGC, recentGCPauses); i++ {
+		pause := mem.PauseNs[(mem.NumGC-1-i)%uint32(len(mem.PauseNs))]
+		stats.GC.RecentPausesMs = append(stats.GC.RecentPausesMs, float64(pause)/1e6)
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    stats,
+		Time:    time.Since(start).String(),
+	})
+}
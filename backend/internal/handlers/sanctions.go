@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetSanction handles GET /api/sanctions/:id - the full sanction record, expired ones included
+func GetSanction(c *gin.Context) {
+	start := time.Now()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid sanction id",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	rd, release, ok := datasetReader(c, start)
+	if !ok {
+		return
+	}
+	defer release()
+
+	cacheKey := versionedCacheKey(rd, "sanction_detail", id)
+
+	var sanction models.SanctionDetail
+	if cached, found := utils.GetCache(cacheKey); found {
+		sanction = cached.(models.SanctionDetail)
+	} else {
+		sanction, err = rd.GetSanction(id)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   "Sanction not found",
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch sanction: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+		sanction.Provenance = detailProvenance(rd, models.ProvenanceSanction, strconv.Itoa(id))
+
+		utils.SetCache(cacheKey, sanction, config.CacheTTL("sanctions"))
+	}
+
+	sanction = privacyPolicy(c, "sanction").SanctionDetail(sanction)
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    sanction,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// GetSanctionSourceStats handles GET /api/stats/sanctions/by-source - sanction counts, active vs
+// expired, sanctioned entities and fines per registry (CEIS, CNEP, CEPIM)
+func GetSanctionSourceStats(c *gin.Context) {
+	start := time.Now()
+
+	cacheKey := utils.CacheKey("stats_sanction_sources")
+
+	var stats []models.SanctionSourceStats
+	if cached, found := utils.GetCache(cacheKey); found {
+		stats = cached.([]models.SanctionSourceStats)
+	} else {
+		var err error
+		stats, err = database.GetSanctionSourceStats()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to get sanction source stats: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, stats, config.CacheTTL("stats"))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    stats,
+		Count:   len(stats),
+		Time:    time.Since(start).String(),
+	})
+}
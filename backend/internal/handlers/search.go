@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/jobs"
+	"political-network-api/internal/models"
+	"political-network-api/internal/progress"
+	"political-network-api/internal/search"
+	"political-network-api/internal/utils"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxSearchQuery caps ?q= in characters
+	maxSearchQuery = 200
+	// maxSuggestions is how many type-ahead suggestions are returned at most
+	maxSuggestions = 10
+)
+
+// GetSearch handles GET /api/search - fuzzy, accent-insensitive search across politician,
+// party and company names (?q=, ?type=politician,party,company), best match first
+func GetSearch(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 20, 100)
+	if !ok {
+		return
+	}
+	if !validateFields[models.SearchHit](c, params.Fields) {
+		return
+	}
+
+	q, types, ok := bindSearchQuery(c, start)
+	if !ok {
+		return
+	}
+
+	cacheKey := utils.CacheKey("search", utils.NormalizeText(q), types, params.Limit, params.Offset)
+
+	var hits []models.SearchHit
+	if cached, found := utils.GetCache(cacheKey); found {
+		hits = cached.([]models.SearchHit)
+	} else {
+		var err error
+		hits, _, err = search.Search(q, types, params.Limit, params.Offset)
+		if errors.Is(err, search.ErrDisabled) {
+			c.JSON(http.StatusServiceUnavailable, models.APIResponse{
+				Success: false,
+				Error:   "Full-text search is not enabled on this server",
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to search: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, hits, config.CacheTTL("search"))
+	}
+
+	respondList(c, start, hits, params.Fields)
+}
+
+// GetSearchSuggestions handles GET /api/search/suggest - type-ahead for the search box: the
+// politicians, parties and companies whose names start with the words of ?q= (?type=), up to
+// 10. Served from the search index, or from the database when search is disabled.
+func GetSearchSuggestions(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, maxSuggestions, maxSuggestions)
+	if !ok {
+		return
+	}
+	q, types, ok := bindSearchQuery(c, start)
+	if !ok {
+		return
+	}
+
+	cacheKey := utils.CacheKey("search_suggest", utils.NormalizeText(q), types, params.Limit)
+
+	var hits []models.SearchHit
+	if cached, found := utils.GetCache(cacheKey); found {
+		hits = cached.([]models.SearchHit)
+	} else {
+		var err error
+		hits, err = search.Suggest(q, types, params.Limit)
+		if errors.Is(err, search.ErrDisabled) {
+			if len(types) == 0 {
+				types = models.SearchTypes
+			}
+			hits, err = database.SuggestSearch(q, types, params.Limit)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch suggestions: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, hits, config.CacheTTL("search"))
+	}
+
+	respondList(c, start, hits, nil)
+}
+
+// bindSearchQuery reads the required ?q= and the optional ?type= list, sorted so equivalent
+// requests share a cache entry. On failure it writes a 400 and returns false.
+func bindSearchQuery(c *gin.Context, start time.Time) (string, []string, bool) {
+	errs := map[string]string{}
+	q := strings.TrimSpace(c.Query("q"))
+	switch n := utf8.RuneCountInString(q); {
+	case n == 0:
+		errs["q"] = "is required"
+	case n > maxSearchQuery:
+		errs["q"] = fmt.Sprintf("must be at most %d characters", maxSearchQuery)
+	}
+
+	var types []string
+	for _, t := range splitFields(c.QueryArray("type")) {
+		if !slices.Contains(models.SearchTypes, t) {
+			errs["type"] = "must be one of " + strings.Join(models.SearchTypes, ", ")
+			break
+		}
+		if !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return "", nil, false
+	}
+	slices.Sort(types)
+	return q, types, true
+}
+
+// ReindexSearch handles POST /api/admin/search/reindex - rewrites every politician, party and
+// company in the search index in the background and drops entities no longer in the
+// database. The scheduled sync only picks up changed rows, so run this after deletions.
+func ReindexSearch(c *gin.Context) {
+	start := time.Now()
+
+	if !search.Enabled() {
+		c.JSON(http.StatusServiceUnavailable, models.APIResponse{
+			Success: false,
+			Error:   "Full-text search is not enabled on this server",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	task, started, err := progress.StartOrJoin("search_reindex", 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to start search reindex: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	if started {
+		audit(c, "search_reindex", "search", map[string]interface{}{"task_id": task.ID()})
+		go func() {
+			written, err := jobs.SyncSearch(true, task)
+			if err != nil {
+				log.Printf("❌ Search reindex failed: %v", err)
+				task.Finish(nil, err)
+				return
+			}
+			task.Finish(map[string]int{"documents": written}, nil)
+		}()
+	}
+
+	acceptedTask(c, start, task)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetSeasonality handles GET /api/analysis/seasonality - each politician's CEAP spending by
+// month, election years against the others, with end-of-year spikes and pre-election surges;
+// politicians with the most flagged years first (?politician_id=, ?flag=end_of_year|pre_election)
+func GetSeasonality(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.SpendingSeasonality](c, params.Fields) {
+		return
+	}
+
+	politicianID := 0
+	flag := c.Query("flag")
+	errs := map[string]string{}
+	if v := c.Query("politician_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil || id < 1 {
+			errs["politician_id"] = "must be a positive integer"
+		}
+		politicianID = id
+	}
+	if flag != "" && flag != database.SeasonalityEndOfYear && flag != database.SeasonalityPreElection {
+		errs["flag"] = "must be end_of_year or pre_election"
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	// Flags are computed, not stored, so the whole analysis is cached and filtered here
+	cacheKey := utils.CacheKey("seasonality", politicianID)
+
+	var patterns []models.SpendingSeasonality
+	if cached, found := utils.GetCache(cacheKey); found {
+		patterns = cached.([]models.SpendingSeasonality)
+	} else {
+		var err error
+		patterns, err = database.GetSpendingSeasonality(politicianID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to analyze spending seasonality: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, patterns, config.CacheTTL("seasonality"))
+	}
+
+	var matched []models.SpendingSeasonality
+	for _, p := range patterns {
+		switch {
+		case flag == database.SeasonalityEndOfYear && len(p.EndOfYearSpikeYears) == 0,
+			flag == database.SeasonalityPreElection && len(p.PreElectionSurgeYears) == 0:
+			continue
+		}
+		matched = append(matched, p)
+	}
+
+	respondList(c, start, paginate(matched, params.Limit, params.Offset), params.Fields)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	cnaeCodePattern   = regexp.MustCompile(`^\d{2,7}$`)
+	cnaeSeparators    = strings.NewReplacer(".", "", "-", "", "/", "", " ", "")
+	sectorSourceNames = map[string]string{"deputados": "DEPUTADOS", "tse": "TSE"}
+)
+
+// parseSector reads ?sector= as a CNAE section letter (G) or code prefix (47, 4731-8);
+// it returns "" when absent and writes a 400 on invalid values
+func parseSector(c *gin.Context, start time.Time) (string, bool) {
+	raw := c.Query("sector")
+	if raw == "" {
+		return "", true
+	}
+
+	sector := strings.ToUpper(cnaeSeparators.Replace(raw))
+	if _, ok := models.CNAESections[sector]; ok || cnaeCodePattern.MatchString(sector) {
+		return sector, true
+	}
+
+	c.JSON(http.StatusBadRequest, models.APIResponse{
+		Success: false,
+		Error:   "Invalid query parameters",
+		Errors:  map[string]string{"sector": "must be a CNAE section letter (A-U) or a CNAE code prefix such as 47 or 4731-8"},
+		Time:    time.Since(start).String(),
+	})
+	return "", false
+}
+
+// GetSectorStats handles GET /api/stats/by-sector - financial totals by counterpart CNAE
+// sector (?level=section|division|group|class|subclass, ?source=deputados|tse)
+func GetSectorStats(c *gin.Context) {
+	start := time.Now()
+
+	level := c.DefaultQuery("level", "section")
+	errs := map[string]string{}
+	if !database.ValidSectorLevel(level) {
+		errs["level"] = "must be section, division, group, class or subclass"
+	}
+	source, ok := sectorSourceNames[strings.ToLower(c.Query("source"))]
+	if !ok && c.Query("source") != "" {
+		errs["source"] = "must be deputados or tse"
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	cacheKey := utils.CacheKey("stats_sector", level, source)
+
+	var stats []models.SectorStats
+	if cached, found := utils.GetCache(cacheKey); found {
+		stats = cached.([]models.SectorStats)
+	} else {
+		var err error
+		stats, err = database.GetSectorStats(level, source)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to get sector stats: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, stats, config.CacheTTL("stats"))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    stats,
+		Count:   len(stats),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// filterConnectionsBySector keeps the financial connections whose company is in sector
+func filterConnectionsBySector(connections []models.Connection, sector string) ([]models.Connection, error) {
+	cacheKey := utils.CacheKey("sector_companies", sector)
+
+	var cnpjs map[string]bool
+	if cached, found := utils.GetCache(cacheKey); found {
+		cnpjs = cached.(map[string]bool)
+	} else {
+		var err error
+		cnpjs, err = database.GetSectorCompanies(sector)
+		if err != nil {
+			return nil, err
+		}
+		utils.SetCache(cacheKey, cnpjs, config.CacheTTL("connections"))
+	}
+
+	var filtered []models.Connection
+	for _, conn := range connections {
+		cnpj, isCompany := strings.CutPrefix(conn.TargetID, string(models.NodeTypeCompany)+"_")
+		if conn.Type == "financial" && isCompany && cnpjs[cnpj] {
+			filtered = append(filtered, conn)
+		}
+	}
+	return filtered, nil
+}
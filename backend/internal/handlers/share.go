@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/export"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxShareNodes caps the subgraph captured by one snapshot
+	maxShareNodes = 500
+	// maxShareBytes caps a share request body
+	maxShareBytes = 64 << 10
+)
+
+// CreateShare handles POST /api/share - freezes a filter set and the selected network nodes,
+// with the links between them, under a short-lived ID
+func CreateShare(c *gin.Context) {
+	start := time.Now()
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxShareBytes)
+
+	var req models.ShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid snapshot: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		req.Title = "Political network snapshot"
+	}
+	fieldErrors := map[string]string{}
+	if len(req.Title) > 200 {
+		fieldErrors["title"] = "must be at most 200 characters"
+	}
+	if f := bytes.TrimSpace(req.Filters); len(f) > 0 && f[0] != '{' && string(f) != "null" {
+		fieldErrors["filters"] = "must be an object"
+	}
+	if len(req.Nodes) > maxShareNodes {
+		fieldErrors["nodes"] = fmt.Sprintf("at most %d nodes", maxShareNodes)
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid snapshot",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	network, err := getNetworkData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to build network data: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	nodes, links, unknown := captureSubgraph(network, req.Nodes)
+	if len(unknown) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid snapshot",
+			Errors:  map[string]string{"nodes": "not in the network: " + strings.Join(unknown, ", ")},
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	id, err := randomToken(6)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to generate snapshot id: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	snapshot := models.ShareSnapshot{
+		ID:          id,
+		Title:       req.Title,
+		Description: describeSubgraph(nodes, links),
+		Filters:     req.Filters,
+		Nodes:       nodes,
+		Links:       links,
+		ExpiresAt:   time.Now().Add(config.Get().Share.TTL),
+	}
+	if err := database.CreateShareSnapshot(&snapshot, middleware.Actor(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	withShareURLs(c, &snapshot)
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    snapshot,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// GetShare handles GET /api/share/:id - the snapshot for the frontend to restore
+func GetShare(c *gin.Context) {
+	start := time.Now()
+
+	snapshot, ok := shareSnapshot(c, start)
+	if !ok {
+		return
+	}
+
+	withShareURLs(c, snapshot)
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    snapshot,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// sharePage carries OpenGraph and Twitter card tags for link unfurlers and sends browsers on
+// to the frontend
+var sharePage = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<meta name="description" content="{{.Description}}">
+<meta property="og:type" content="website">
+<meta property="og:site_name" content="Political Network">
+<meta property="og:title" content="{{.Title}}">
+<meta property="og:description" content="{{.Description}}">
+<meta property="og:url" content="{{.URL}}">
+<meta property="og:image" content="{{.ImageURL}}">
+<meta property="og:image:width" content="1200">
+<meta property="og:image:height" content="630">
+<meta name="twitter:card" content="summary_large_image">
+<meta name="twitter:title" content="{{.Title}}">
+<meta name="twitter:description" content="{{.Description}}">
+<meta name="twitter:image" content="{{.ImageURL}}">
+<meta http-equiv="refresh" content="0; url={{.AppURL}}">
+</head>
+<body>
+<p><a href="{{.AppURL}}">{{.Title}}</a></p>
+<p>{{.Description}}</p>
+</body>
+</html>
+`))
+
+// SharePage handles GET /share/:id - the page a shared link opens
+func SharePage(c *gin.Context) {
+	start := time.Now()
+
+	snapshot, ok := shareSnapshot(c, start)
+	if !ok {
+		return
+	}
+	withShareURLs(c, snapshot)
+
+	appURL, err := url.Parse(config.Get().Share.FrontendURL)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Invalid share.frontend_url: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	query := appURL.Query()
+	query.Set("share", snapshot.ID)
+	appURL.RawQuery = query.Encode()
+
+	var page bytes.Buffer
+	err = sharePage.Execute(&page, struct {
+		*models.ShareSnapshot
+		AppURL string
+	}{snapshot, appURL.String()})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to render share page: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
+
+// ShareImage handles GET /share/:id/image.png - the snapshot's subgraph drawn for link previews
+func ShareImage(c *gin.Context) {
+	start := time.Now()
+
+	snapshot, ok := shareSnapshot(c, start)
+	if !ok {
+		return
+	}
+
+	var img bytes.Buffer
+	if err := export.WritePreviewPNG(&img, snapshot.Nodes, snapshot.Links); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to render preview: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	// Snapshots never change, so the image can be kept until the snapshot expires
+	maxAge := int(time.Until(snapshot.ExpiresAt).Seconds())
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", max(maxAge, 0)))
+	c.Data(http.StatusOK, "image/png", img.Bytes())
+}
+
+// shareSnapshot loads the :id snapshot. On failure it writes the response and returns false.
+func shareSnapshot(c *gin.Context, start time.Time) (*models.ShareSnapshot, bool) {
+	snapshot, err := database.GetShareSnapshot(c.Param("id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Snapshot not found or expired",
+			Time:    time.Since(start).String(),
+		})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch snapshot: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return nil, false
+	}
+	return snapshot, true
+}
+
+// withShareURLs fills in the snapshot's public page and preview image URLs
+func withShareURLs(c *gin.Context, s *models.ShareSnapshot) {
+	base := requestBaseURL(c) + "/share/" + s.ID
+	s.URL = base
+	s.ImageURL = base + "/image.png"
+}
+
+// captureSubgraph copies the requested nodes, in request order and without duplicates, and the
+// links among them out of the network. IDs missing from the network are returned as unknown.
+func captureSubgraph(network *models.NetworkResponse, ids []string) ([]models.ShareNode, []models.ShareLink, []string) {
+	byID := make(map[string]models.NetworkNode, len(network.Nodes))
+	for _, n := range network.Nodes {
+		byID[n.ID] = n
+	}
+
+	selected := map[string]bool{}
+	nodes := []models.ShareNode{}
+	var unknown []string
+	for _, id := range ids {
+		n, ok := byID[id]
+		if !ok {
+			unknown = append(unknown, id)
+			continue
+		}
+		if !selected[id] {
+			selected[id] = true
+			nodes = append(nodes, models.ShareNode{ID: n.ID, Type: n.Type, Name: n.Name, Color: n.Color})
+		}
+	}
+
+	links := []models.ShareLink{}
+	for _, l := range network.Links {
+		if selected[l.SourceID] && selected[l.TargetID] {
+			links = append(links, models.ShareLink{Source: l.SourceID, Target: l.TargetID, Type: l.Type})
+		}
+	}
+	return nodes, links, unknown
+}
+
+// shareNodeLabels names node types in snapshot descriptions, singular and plural
+var shareNodeLabels = []struct {
+	nodeType         models.NodeType
+	singular, plural string
+}{
+	{models.NodeTypePolitician, "politician", "politicians"},
+	{models.NodeTypeParty, "party", "parties"},
+	{models.NodeTypeCompany, "company", "companies"},
+	{models.NodeTypeCompanyGroup, "corporate group", "corporate groups"},
+	{models.NodeTypeSanction, "sanction", "sanctions"},
+	{models.NodeTypeTopic, "topic", "topics"},
+	{models.NodeTypeFront, "parliamentary front", "parliamentary fronts"},
+	{models.NodeTypeTCURuling, "TCU ruling", "TCU rulings"},
+	{models.NodeTypePublicBank, "public bank", "public banks"},
+}
+
+// describeSubgraph summarizes a snapshot for link previews: "2 politicians, 5 companies and
+// 7 connections: Name A, Name B, Name C and 4 more"
+func describeSubgraph(nodes []models.ShareNode, links []models.ShareLink) string {
+	if len(nodes) == 0 {
+		return "A filtered view of the political transparency network."
+	}
+
+	counts := map[models.NodeType]int{}
+	for _, n := range nodes {
+		counts[n.Type]++
+	}
+
+	var parts []string
+	for _, l := range shareNodeLabels {
+		switch counts[l.nodeType] {
+		case 0:
+		case 1:
+			parts = append(parts, "1 "+l.singular)
+		default:
+			parts = append(parts, fmt.Sprintf("%d %s", counts[l.nodeType], l.plural))
+		}
+	}
+	parts = append(parts, fmt.Sprintf("%d connection(s)", len(links)))
+
+	var names []string
+	for _, n := range nodes {
+		if len(names) == 3 {
+			break
+		}
+		names = append(names, n.Name)
+	}
+	summary := strings.Join(names, ", ")
+	if more := len(nodes) - len(names); more > 0 {
+		summary += fmt.Sprintf(" and %d more", more)
+	}
+
+	counted := strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1]
+	return counted + ": " + summary + "."
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// politicianIndex resolves the public IDs and slugs politician routes accept in place of the
+// numeric ID, and gives each politician theirs
+type politicianIndex struct {
+	IDs  map[string]int               `json:"ids"`
+	Keys map[int]models.PoliticianKey `json:"keys"`
+}
+
+// buildPoliticianIndex indexes keys. Politicians without an assigned slug (on SQLite, in demo
+// mode, or loaded by an ETL run from a shell since the last assignment) get one made the same
+// way, with their ID appended when another politician has it.
+func buildPoliticianIndex(keys []models.PoliticianKey) politicianIndex {
+	index := politicianIndex{
+		IDs:  make(map[string]int, 2*len(keys)),
+		Keys: make(map[int]models.PoliticianKey, len(keys)),
+	}
+	for _, k := range keys {
+		if k.PublicID != "" {
+			index.IDs[k.PublicID] = k.ID
+		}
+		if k.Slug != "" {
+			index.IDs[k.Slug] = k.ID
+		}
+	}
+	for _, k := range keys {
+		if k.Slug == "" {
+			k.Slug = utils.Slug(k.Nome, k.SiglaPartido, k.UF)
+			if _, taken := index.IDs[k.Slug]; taken || k.Slug == "" {
+				k.Slug = utils.Slug(k.Slug, strconv.Itoa(k.ID))
+			}
+			index.IDs[k.Slug] = k.ID
+		}
+		index.Keys[k.ID] = k
+	}
+	return index
+}
+
+// loadPoliticianIndex returns the politician index of rd from cache or builds it
+func loadPoliticianIndex(c *gin.Context, rd database.Repository) (politicianIndex, error) {
+	return loadCached(c, versionedCacheKey(rd, "politician_index"), config.CacheTTL("politicians"), func() (politicianIndex, error) {
+		keys, err := rd.GetPoliticianKeys()
+		if err != nil {
+			return politicianIndex{}, err
+		}
+		return buildPoliticianIndex(keys), nil
+	})
+}
+
+// politicianID resolves the :id of a politician route, a numeric ID, public ID or slug, writing
+// the error response when it names no politician. Numeric IDs are not checked here.
+func politicianID(c *gin.Context, start time.Time, rd database.Repository) (int, bool) {
+	param := c.Param("id")
+	if id, err := strconv.Atoi(param); err == nil {
+		if id < 1 {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   "Invalid politician id",
+				Time:    time.Since(start).String(),
+			})
+			return 0, false
+		}
+		return id, true
+	}
+
+	index, err := loadPoliticianIndex(c, rd)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to resolve politician: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return 0, false
+	}
+	id, found := index.IDs[strings.ToLower(param)]
+	if !found {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Politician not found",
+			Time:    time.Since(start).String(),
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// identifyPolitician sets a politician's public ID and slug
+func (index politicianIndex) identifyPolitician(p *models.Politician) {
+	k := index.Keys[p.ID]
+	p.PublicID, p.Slug = k.PublicID, k.Slug
+}
+
+// identifyPoliticians returns a copy of politicians with their public IDs and slugs; without
+// the index they are returned as they are
+func identifyPoliticians(c *gin.Context, rd database.Repository, politicians []models.Politician) []models.Politician {
+	index, err := loadPoliticianIndex(c, rd)
+	if err != nil {
+		log.Printf("⚠️ Politicians listed without slugs: %v", err)
+		return politicians
+	}
+	identified := make([]models.Politician, len(politicians))
+	for i, p := range politicians {
+		index.identifyPolitician(&p)
+		identified[i] = p
+	}
+	return identified
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTopics handles GET /api/topics - subjects of plenary speeches, the ones discussed by the
+// most politicians first
+func GetTopics(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.Topic](c, params.Fields) {
+		return
+	}
+
+	cacheKey := utils.CacheKey("topics", params.Limit, params.Offset)
+
+	var topics []models.Topic
+	if cached, found := utils.GetCache(cacheKey); found {
+		topics = cached.([]models.Topic)
+	} else {
+		var err error
+		topics, err = database.GetTopics(params.Limit, params.Offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch topics: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, topics, config.CacheTTL("topics"))
+	}
+
+	respondList(c, start, topics, params.Fields)
+}
+
+// GetPoliticianTopics handles GET /api/politicians/:id/topics - what a politician talks about
+// in plenary speeches, most frequent first (?limit=, default 20, max 100)
+func GetPoliticianTopics(c *gin.Context) {
+	start := time.Now()
+
+	id, ok := politicianID(c, start, repos.Live)
+	if !ok {
+		return
+	}
+
+	params, ok := bindQueryParams(c, 20, 100)
+	if !ok {
+		return
+	}
+	if !validateFields[models.PoliticianTopic](c, params.Fields) {
+		return
+	}
+
+	cacheKey := utils.CacheKey("politician_topics", id, params.Limit)
+
+	var topics []models.PoliticianTopic
+	if cached, found := utils.GetCache(cacheKey); found {
+		topics = cached.([]models.PoliticianTopic)
+	} else {
+		var err error
+		if _, err = repos.Live.GetPolitician(id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusNotFound, models.APIResponse{
+					Success: false,
+					Error:   "Politician not found",
+					Time:    time.Since(start).String(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch politician: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		topics, err = database.GetPoliticianTopics(id, params.Limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch politician topics: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, topics, config.CacheTTL("topics"))
+	}
+
+	respondList(c, start, topics, params.Fields)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/export"
+	"political-network-api/internal/models"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// streamFlushEvery is how many rows are buffered before flushing to the client
+const streamFlushEvery = 500
+
+// StreamEntities handles GET /api/stream/:entity - newline-delimited JSON of a full table
+func StreamEntities(c *gin.Context) {
+	switch entity := c.Param("entity"); entity {
+	case "politicians":
+		streamJSONL(c, protectEach(c, "politicians", database.EachPolitician))
+	case "companies":
+		streamJSONL(c, database.EachCompany)
+	case "financial_records":
+		streamJSONL(c, protectEach(c, "financial_records", database.EachFinancialRecord))
+	default:
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Unknown stream: " + entity + " (use politicians, companies or financial_records)",
+			Time:    "0ms",
+		})
+	}
+}
+
+// streamJSONL writes one JSON document per line, flushing periodically. Rows are read
+// from a database cursor as the client consumes them, so a slow reader holds back the
+// query instead of buffering the table in memory.
+func streamJSONL[T any](c *gin.Context, each export.Each[T]) {
+	start := time.Now()
+	enc := json.NewEncoder(c.Writer)
+	rows := 0
+
+	err := each(func(item T) error {
+		if rows == 0 {
+			c.Header("Content-Type", "application/x-ndjson")
+			c.Status(http.StatusOK)
+		}
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+
+		rows++
+		if rows%streamFlushEvery == 0 {
+			c.Writer.Flush()
+		}
+		return c.Request.Context().Err()
+	})
+
+	if err != nil && rows == 0 {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to stream data: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		// Headers are already sent; the truncated body is the only signal left
+		c.Error(err)
+		return
+	}
+
+	if rows == 0 {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+	}
+	c.Writer.Flush()
+}
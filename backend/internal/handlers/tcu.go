@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTCURulings handles GET /api/tcu/rulings - TCU acórdãos naming tracked companies or
+// politicians, most recent first (?cnpj=, ?politician_id=)
+func GetTCURulings(c *gin.Context) {
+	start := time.Now()
+
+	params, ok := bindQueryParams(c, 100, 1000)
+	if !ok {
+		return
+	}
+	if !validateFields[models.TCURuling](c, params.Fields) {
+		return
+	}
+
+	var filter database.TCURulingFilter
+	errs := map[string]string{}
+	if v := c.Query("cnpj"); v != "" {
+		filter.CNPJ = normalizeCNPJ(v)
+		if !cnpjPattern.MatchString(filter.CNPJ) {
+			errs["cnpj"] = "must be a 14-digit CNPJ"
+		}
+	}
+	if v := c.Query("politician_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil || id < 1 {
+			errs["politician_id"] = "must be a positive integer"
+		}
+		filter.PoliticianID = id
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  errs,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	rd, release, ok := datasetReader(c, start)
+	if !ok {
+		return
+	}
+	defer release()
+
+	cacheKey := versionedCacheKey(rd, "tcu_rulings", filter.CNPJ, filter.PoliticianID, params.Limit, params.Offset)
+
+	rulings, err := loadCached(c, cacheKey, config.CacheTTL("tcu_rulings"), func() ([]models.TCURuling, error) {
+		return rd.GetTCURulings(filter, params.Limit, params.Offset)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch TCU rulings: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	respondList(c, start, rulings, params.Fields)
+}
+
+// GetTCURuling handles GET /api/tcu/rulings/:id - one ruling with the companies and politicians
+// it names and links to the original documents
+func GetTCURuling(c *gin.Context) {
+	start := time.Now()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid ruling id",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	rd, release, ok := datasetReader(c, start)
+	if !ok {
+		return
+	}
+	defer release()
+
+	cacheKey := versionedCacheKey(rd, "tcu_ruling_detail", id)
+
+	detail, err := loadCached(c, cacheKey, config.CacheTTL("tcu_rulings"), func() (models.TCURulingDetail, error) {
+		return buildTCURulingDetail(rd, id)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "TCU ruling not found",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch TCU ruling: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success:     true,
+		Data:        detail,
+		Degraded:    degraded(c),
+		DroppedRows: droppedRows(c),
+		Time:        time.Since(start).String(),
+	})
+}
+
+// buildTCURulingDetail loads a ruling and its parties
+func buildTCURulingDetail(rd database.Repository, id int) (models.TCURulingDetail, error) {
+	ruling, err := rd.GetTCURuling(id)
+	if err != nil {
+		return models.TCURulingDetail{}, err
+	}
+	detail := models.TCURulingDetail{TCURuling: ruling}
+
+	var partial partialReads
+	if detail.Parties, err = rd.GetTCURulingParties(id); partial.failed(err) {
+		return detail, err
+	}
+	detail.Provenance = detailProvenance(rd, models.ProvenanceTCURuling, strconv.Itoa(id))
+
+	return detail, partial.err()
+}
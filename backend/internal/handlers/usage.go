@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+	"political-network-api/internal/models"
+	"political-network-api/internal/usage"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetUsage handles GET /api/admin/usage?since=1h&bucket=5m&route=&top=10 - request counts,
+// errors and latencies since startup (up to 24 hours back), in time buckets and by route,
+// with the busiest consumers
+func GetUsage(c *gin.Context) {
+	start := time.Now()
+
+	fieldErrors := map[string]string{}
+	since, err := time.ParseDuration(c.DefaultQuery("since", "1h"))
+	if err != nil || since <= 0 || since > usage.Retention {
+		fieldErrors["since"] = "must be a duration up to " + usage.Retention.String()
+	}
+	bucket, err := time.ParseDuration(c.DefaultQuery("bucket", "5m"))
+	if err != nil || bucket < time.Minute || bucket%time.Minute != 0 {
+		fieldErrors["bucket"] = "must be a whole number of minutes"
+	}
+	top, err := strconv.Atoi(c.DefaultQuery("top", "10"))
+	if err != nil || top < 1 || top > 100 {
+		fieldErrors["top"] = "must be between 1 and 100"
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	summary := usage.Summarize(start.Add(-since), bucket, c.Query("route"), top, start)
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    summary,
+		Count:   len(summary.Routes),
+		Time:    time.Since(start).String(),
+	})
+}
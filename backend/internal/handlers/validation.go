@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"political-network-api/internal/models"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Report validation failures by query parameter name instead of Go field name
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name := strings.Split(field.Tag.Get("form"), ",")[0]
+			if name == "" || name == "-" {
+				return field.Name
+			}
+			return name
+		})
+	}
+}
+
+// bindQueryParams binds and validates the common query parameters. Limit defaults to
+// defaultLimit and is capped at maxLimit. On failure it writes a 400 with per-field
+// errors and returns false.
+func bindQueryParams(c *gin.Context, defaultLimit, maxLimit int) (models.QueryParams, bool) {
+	start := time.Now()
+	params := models.QueryParams{Limit: defaultLimit}
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  queryFieldErrors(c, err),
+			Time:    time.Since(start).String(),
+		})
+		return params, false
+	}
+
+	params.Fields = splitFields(params.Fields)
+	if params.Limit > maxLimit {
+		params.Limit = maxLimit
+	}
+	return params, true
+}
+
+// queryFieldErrors maps a binding error to query parameter names and messages
+func queryFieldErrors(c *gin.Context, err error) map[string]string {
+	fieldErrors := map[string]string{}
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		for _, fe := range validationErrors {
+			fieldErrors[fe.Field()] = validationMessage(fe)
+		}
+		return fieldErrors
+	}
+
+	// Type conversion errors don't carry the field name, so find the offending parameter
+	for _, name := range []string{"limit", "offset", "min_score"} {
+		if v, ok := c.GetQuery(name); ok {
+			if _, convErr := strconv.Atoi(v); convErr != nil {
+				fieldErrors[name] = "must be an integer"
+			}
+		}
+	}
+	if v, ok := c.GetQuery("include_stats"); ok {
+		if _, convErr := strconv.ParseBool(v); convErr != nil {
+			fieldErrors["include_stats"] = "must be a boolean"
+		}
+	}
+
+	if len(fieldErrors) == 0 {
+		fieldErrors["query"] = err.Error()
+	}
+	return fieldErrors
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "min":
+		return "must be at least " + fe.Param()
+	case "max":
+		return "must be at most " + fe.Param()
+	default:
+		return "failed " + fe.Tag() + " validation"
+	}
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// datasetReader resolves ?dataset_version= on the read endpoints: empty or "latest" reads the
+// live tables, a number pins the request to that published version. Callers defer release. On
+// false the error response has been written.
+func datasetReader(c *gin.Context, start time.Time) (database.Repository, func(), bool) {
+	param := c.Query("dataset_version")
+	if param == "" || param == "latest" {
+		c.Header("X-Dataset-Version", "latest")
+		return repos.Live, func() {}, true
+	}
+
+	version, err := strconv.Atoi(param)
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  map[string]string{"dataset_version": "must be latest or a positive integer"},
+			Time:    time.Since(start).String(),
+		})
+		return nil, nil, false
+	}
+
+	rd, release, err := repos.Pin(version)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Dataset version " + param + " is not published (see /api/dataset-versions)",
+			Time:    time.Since(start).String(),
+		})
+		return nil, nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to open dataset version: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return nil, nil, false
+	}
+
+	c.Header("X-Dataset-Version", param)
+	return rd, release, true
+}
+
+// versionedCacheKey is utils.CacheKey for data read through rd. Pinned versions get their own
+// entries right after the prefix, so purging a prefix still drops every version's.
+func versionedCacheKey(rd database.Repository, prefix string, params ...interface{}) string {
+	version := rd.DatasetVersion()
+	if version == 0 {
+		return utils.CacheKey(prefix, params...)
+	}
+	return utils.CacheKey(prefix, append([]interface{}{"v" + strconv.Itoa(version)}, params...)...)
+}
+
+// GetDatasetVersions handles GET /api/dataset-versions - the dataset versions stamped by the ETL
+// runs: the one being built, the published ones that ?dataset_version= can pin and the pruned ones
+func GetDatasetVersions(c *gin.Context) {
+	start := time.Now()
+
+	cacheKey := utils.CacheKey("dataset_versions")
+
+	var versions []models.DatasetVersion
+	if cached, found := utils.GetCache(cacheKey); found {
+		versions = cached.([]models.DatasetVersion)
+	} else {
+		var err error
+		versions, err = database.GetDatasetVersions()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch dataset versions: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+
+		utils.SetCache(cacheKey, versions, config.CacheTTL("dataset_versions"))
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    versions,
+		Count:   len(versions),
+		Time:    time.Since(start).String(),
+	})
+}
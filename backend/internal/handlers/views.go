@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxViewBytes caps a saved view's request body
+	maxViewBytes = 256 << 10
+	// maxSelectedNodes caps the nodes a view can select
+	maxSelectedNodes = 5000
+)
+
+// CreateSavedView handles POST /api/views - saves the signed-in user's network view under a
+// short random ID that can be shared
+func CreateSavedView(c *gin.Context) {
+	start := time.Now()
+
+	view, ok := bindSavedView(c, start)
+	if !ok {
+		return
+	}
+
+	id, err := randomToken(8)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to generate view id: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	view.ID = id
+	view.UserID = middleware.User(c).ID
+
+	if err := database.CreateSavedView(&view); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    view,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// GetSavedViews handles GET /api/views - the signed-in user's views, most recently updated first
+func GetSavedViews(c *gin.Context) {
+	start := time.Now()
+
+	views, err := database.GetSavedViews(middleware.User(c).ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch views: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    views,
+		Count:   len(views),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// GetSavedView handles GET /api/views/:id - anyone with the ID can load a view
+func GetSavedView(c *gin.Context) {
+	start := time.Now()
+
+	view, ok := savedView(c, start)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    view,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// UpdateSavedView handles PUT /api/views/:id - replaces a view the signed-in user owns; the ID,
+// and so any shared link, stays the same
+func UpdateSavedView(c *gin.Context) {
+	start := time.Now()
+
+	existing, ok := ownedSavedView(c, start)
+	if !ok {
+		return
+	}
+
+	view, ok := bindSavedView(c, start)
+	if !ok {
+		return
+	}
+	view.ID = existing.ID
+	view.UserID = existing.UserID
+	view.CreatedAt = existing.CreatedAt
+
+	if err := database.UpdateSavedView(&view); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    view,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// DeleteSavedView handles DELETE /api/views/:id
+func DeleteSavedView(c *gin.Context) {
+	start := time.Now()
+
+	view, ok := ownedSavedView(c, start)
+	if !ok {
+		return
+	}
+
+	if err := database.DeleteSavedView(view.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"deleted": view.ID},
+		Time:    time.Since(start).String(),
+	})
+}
+
+// bindSavedView reads and validates a view from the request body. On failure it writes a 400
+// and returns false.
+func bindSavedView(c *gin.Context, start time.Time) (models.SavedView, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxViewBytes)
+
+	var req models.SavedViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid view: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return models.SavedView{}, false
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	fieldErrors := map[string]string{}
+	if req.Name == "" || len(req.Name) > 200 {
+		fieldErrors["name"] = "is required and must be at most 200 characters"
+	}
+	if f := bytes.TrimSpace(req.Filters); len(f) > 0 && f[0] != '{' && string(f) != "null" {
+		fieldErrors["filters"] = "must be an object"
+	}
+	if len(req.SelectedNodes) > maxSelectedNodes {
+		fieldErrors["selected_nodes"] = fmt.Sprintf("at most %d nodes", maxSelectedNodes)
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid view",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return models.SavedView{}, false
+	}
+
+	if req.SelectedNodes == nil {
+		req.SelectedNodes = []string{}
+	}
+	return models.SavedView{
+		Name:          req.Name,
+		Filters:       req.Filters,
+		SelectedNodes: req.SelectedNodes,
+		Camera:        req.Camera,
+	}, true
+}
+
+// savedView loads the :id view. On failure it writes the response and returns false.
+func savedView(c *gin.Context, start time.Time) (*models.SavedView, bool) {
+	view, err := database.GetSavedView(c.Param("id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "View not found",
+			Time:    time.Since(start).String(),
+		})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch view: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return nil, false
+	}
+	return view, true
+}
+
+// ownedSavedView loads the :id view if the signed-in user owns it; other users get a 403
+func ownedSavedView(c *gin.Context, start time.Time) (*models.SavedView, bool) {
+	view, ok := savedView(c, start)
+	if !ok {
+		return nil, false
+	}
+	if view.UserID != middleware.User(c).ID {
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   "Only the view's owner can change it",
+			Time:    time.Since(start).String(),
+		})
+		return nil, false
+	}
+	return view, true
+}
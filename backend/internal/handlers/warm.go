@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"log"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"time"
+)
+
+// snapshotKeys are the cache entries kept across restarts (cache.snapshot_file): the slowest to
+// rebuild
+var snapshotKeys = []string{"network_complete", "connections_all"}
+
+// WarmCache fills the most requested cache keys in the background, so the first visitor
+// after a deploy doesn't wait for a cold /api/network build. Keys match the default
+// (unparameterized) requests of each endpoint.
+func WarmCache() {
+	steps := []struct {
+		name string
+		warm func() error
+	}{
+		{"politicians", func() error {
+			_, err := loadPoliticians(nil, repos.Live, models.QueryParams{Limit: 500}, database.PoliticianFilter{})
+			return err
+		}},
+		{"parties", func() error {
+			_, err := loadParties(nil, repos.Live, models.QueryParams{Limit: 100}, nil)
+			return err
+		}},
+		{"connections", func() error {
+			_, err := getConnections(nil)
+			return err
+		}},
+		{"network", func() error {
+			_, err := getNetworkData(nil)
+			return err
+		}},
+	}
+
+	go func() {
+		start := time.Now()
+		for _, step := range steps {
+			stepStart := time.Now()
+			if err := step.warm(); err != nil {
+				log.Printf("⚠️ Cache warm-up of %s failed: %v", step.name, err)
+				continue
+			}
+			log.Printf("🔥 Warmed %s cache in %s", step.name, time.Since(stepStart).Round(time.Millisecond))
+		}
+		log.Printf("✅ Cache warm-up finished in %s", time.Since(start).Round(time.Millisecond))
+	}()
+}
+
+// SaveCacheSnapshot saves the network and connections caches to cache.snapshot_file, if set, for
+// RestoreCacheSnapshot to load on the next start
+func SaveCacheSnapshot() {
+	path := config.Get().Cache.SnapshotFile
+	if path == "" {
+		return
+	}
+	saved, err := utils.SaveCacheSnapshot(path, snapshotKeys)
+	if err != nil {
+		log.Printf("⚠️ Cache snapshot not saved: %v", err)
+		return
+	}
+	log.Printf("💾 Saved %d cache entries to %s", saved, path)
+}
+
+// RestoreCacheSnapshot loads the entries SaveCacheSnapshot saved, those not yet expired back
+// into the cache and all of them as stale copies. Warm-up then finds them cached.
+func RestoreCacheSnapshot() {
+	path := config.Get().Cache.SnapshotFile
+	if path == "" {
+		return
+	}
+	restored, err := utils.LoadCacheSnapshot(path)
+	if err != nil {
+		log.Printf("⚠️ Cache snapshot not restored: %v", err)
+		return
+	}
+	if restored > 0 {
+		log.Printf("♻️ Restored %d cache entries from %s", restored, path)
+	}
+}
@@ -0,0 +1,397 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"political-network-api/internal/database"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxWatchlistItems caps the entities followed by one watchlist
+const maxWatchlistItems = 500
+
+// CreateWatchlist handles POST /api/watchlists - follows politicians and companies, with alerts
+// sent to one of the caller's webhooks and/or an email address
+func CreateWatchlist(c *gin.Context) {
+	start := time.Now()
+
+	var req models.WatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid watchlist: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	fieldErrors := map[string]string{}
+	if req.Name == "" || len(req.Name) > 200 {
+		fieldErrors["name"] = "is required and must be at most 200 characters"
+	}
+	if req.Email != "" {
+		if addr, err := mail.ParseAddress(req.Email); err != nil {
+			fieldErrors["email"] = "must be an email address"
+		} else {
+			req.Email = addr.Address
+		}
+	}
+	if req.WebhookID != nil {
+		webhook, err := database.GetWebhook(*req.WebhookID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Failed to fetch webhook: " + err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return
+		}
+		if err != nil || !ownedBy(c, webhook.Owner) {
+			fieldErrors["webhook_id"] = "must be one of your webhooks"
+		}
+	}
+	items, ok := validWatchlistItems(c, start, req.Items, fieldErrors)
+	if !ok {
+		return
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid watchlist",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	watchlist := models.Watchlist{
+		Owner:     middleware.Actor(c),
+		Name:      req.Name,
+		WebhookID: req.WebhookID,
+		Email:     req.Email,
+		Items:     items,
+	}
+	if err := database.CreateWatchlist(&watchlist); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	audit(c, "watchlist_create", "watchlists/"+strconv.FormatInt(watchlist.ID, 10), map[string]interface{}{
+		"name":  watchlist.Name,
+		"items": len(watchlist.Items),
+	})
+
+	created, err := database.GetWatchlist(watchlist.ID)
+	if err != nil {
+		created = &watchlist
+	}
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    created,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// GetWatchlists handles GET /api/watchlists - the caller's watchlists; admins see everyone's
+func GetWatchlists(c *gin.Context) {
+	start := time.Now()
+
+	watchlists, err := database.GetWatchlists(ownerFilter(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch watchlists: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    watchlists,
+		Count:   len(watchlists),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// GetWatchlist handles GET /api/watchlists/:id
+func GetWatchlist(c *gin.Context) {
+	start := time.Now()
+
+	watchlist, ok := ownedWatchlist(c, start)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    watchlist,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// DeleteWatchlist handles DELETE /api/watchlists/:id - removes the watchlist and its alerts
+func DeleteWatchlist(c *gin.Context) {
+	start := time.Now()
+
+	watchlist, ok := ownedWatchlist(c, start)
+	if !ok {
+		return
+	}
+
+	if err := database.DeleteWatchlist(watchlist.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	audit(c, "watchlist_delete", "watchlists/"+strconv.FormatInt(watchlist.ID, 10), nil)
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"deleted": watchlist.ID},
+		Time:    time.Since(start).String(),
+	})
+}
+
+// AddWatchlistItems handles POST /api/watchlists/:id/items - body is a list of items
+func AddWatchlistItems(c *gin.Context) {
+	start := time.Now()
+
+	watchlist, ok := ownedWatchlist(c, start)
+	if !ok {
+		return
+	}
+
+	var req []models.WatchlistItem
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid watchlist items: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	fieldErrors := map[string]string{}
+	items, ok := validWatchlistItems(c, start, req, fieldErrors)
+	if !ok {
+		return
+	}
+	if len(items)+len(watchlist.Items) > maxWatchlistItems {
+		fieldErrors["items"] = fmt.Sprintf("a watchlist follows at most %d entities", maxWatchlistItems)
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid watchlist items",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	if err := database.AddWatchlistItems(watchlist.ID, items); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	updated, err := database.GetWatchlist(watchlist.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch watchlist: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    updated,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// RemoveWatchlistItem handles DELETE /api/watchlists/:id/items/:type/:entity
+func RemoveWatchlistItem(c *gin.Context) {
+	start := time.Now()
+
+	watchlist, ok := ownedWatchlist(c, start)
+	if !ok {
+		return
+	}
+
+	entityID := c.Param("entity")
+	if c.Param("type") == models.WatchCompany {
+		entityID = normalizeCNPJ(entityID)
+	}
+
+	removed, err := database.RemoveWatchlistItem(watchlist.ID, c.Param("type"), entityID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+	if !removed {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Entity is not on this watchlist",
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"removed": gin.H{"type": c.Param("type"), "id": entityID}},
+		Time:    time.Since(start).String(),
+	})
+}
+
+// GetWatchlistAlerts handles GET /api/watchlists/:id/alerts - alerts newest first
+func GetWatchlistAlerts(c *gin.Context) {
+	start := time.Now()
+
+	watchlist, ok := ownedWatchlist(c, start)
+	if !ok {
+		return
+	}
+
+	params, ok := bindQueryParams(c, 50, 500)
+	if !ok {
+		return
+	}
+
+	alerts, err := database.GetWatchlistAlerts(watchlist.ID, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch watchlist alerts: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    alerts,
+		Count:   len(alerts),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// validWatchlistItems normalizes items (politician IDs, 14-digit CNPJs without punctuation)
+// and drops duplicates. Problems are added to fieldErrors; a database failure is written as a
+// 500 and returns false.
+func validWatchlistItems(c *gin.Context, start time.Time, items []models.WatchlistItem, fieldErrors map[string]string) ([]models.WatchlistItem, bool) {
+	if len(items) > maxWatchlistItems {
+		fieldErrors["items"] = fmt.Sprintf("a watchlist follows at most %d entities", maxWatchlistItems)
+		return nil, true
+	}
+
+	seen := map[models.WatchlistItem]bool{}
+	valid := []models.WatchlistItem{}
+	var politicianIDs []int
+	for i, item := range items {
+		field := fmt.Sprintf("items[%d]", i)
+		switch item.Type {
+		case models.WatchPolitician:
+			id, err := strconv.Atoi(item.ID)
+			if err != nil || id < 1 {
+				fieldErrors[field] = "politician id must be a positive integer"
+				continue
+			}
+			politicianIDs = append(politicianIDs, id)
+			item.ID = strconv.Itoa(id)
+		case models.WatchCompany:
+			item.ID = normalizeCNPJ(item.ID)
+			if !cnpjPattern.MatchString(item.ID) {
+				fieldErrors[field] = "company id must be a 14-digit CNPJ"
+				continue
+			}
+		default:
+			fieldErrors[field] = "type must be politician or company"
+			continue
+		}
+
+		key := models.WatchlistItem{Type: item.Type, ID: item.ID}
+		if !seen[key] {
+			seen[key] = true
+			valid = append(valid, key)
+		}
+	}
+
+	if len(politicianIDs) > 0 {
+		missing, err := database.MissingPoliticians(politicianIDs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   err.Error(),
+				Time:    time.Since(start).String(),
+			})
+			return nil, false
+		}
+		if len(missing) > 0 {
+			fieldErrors["items"] = fmt.Sprintf("unknown politician id(s): %v", missing)
+		}
+	}
+	return valid, true
+}
+
+// ownedWatchlist loads the :id watchlist if the caller owns it or is an admin. Other callers get
+// a 404, as if it did not exist. On failure it writes the response and returns false.
+func ownedWatchlist(c *gin.Context, start time.Time) (*models.Watchlist, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid watchlist id",
+			Time:    time.Since(start).String(),
+		})
+		return nil, false
+	}
+
+	watchlist, err := database.GetWatchlist(id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !ownedBy(c, watchlist.Owner)) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Watchlist not found",
+			Time:    time.Since(start).String(),
+		})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch watchlist: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return nil, false
+	}
+	return watchlist, true
+}
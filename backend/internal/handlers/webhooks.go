@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/url"
+	"political-network-api/internal/database"
+	"political-network-api/internal/middleware"
+	"political-network-api/internal/models"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateWebhook handles POST /api/webhooks - subscribes an endpoint to change events. The
+// response carries the signing secret, which is not shown again.
+func CreateWebhook(c *gin.Context) {
+	start := time.Now()
+
+	var req models.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid webhook: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	fieldErrors := map[string]string{}
+	if u, err := url.Parse(req.URL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		fieldErrors["url"] = "must be an absolute http or https URL"
+	}
+	if len(req.Events) == 0 {
+		fieldErrors["events"] = "must list at least one event"
+	}
+	for _, event := range req.Events {
+		if !slices.Contains(models.WebhookEvents, event) {
+			fieldErrors["events"] = "unknown event " + strconv.Quote(event)
+		}
+	}
+	if req.ScoreThreshold == 0 {
+		req.ScoreThreshold = models.DefaultScoreTrigger
+	}
+	if req.ScoreThreshold < 1 || req.ScoreThreshold > 100 {
+		fieldErrors["score_threshold"] = "must be between 1 and 100"
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid webhook",
+			Errors:  fieldErrors,
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	secret, err := randomToken(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to generate webhook secret: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	events := slices.Clone(req.Events)
+	slices.Sort(events)
+
+	webhook := models.Webhook{
+		URL:            req.URL,
+		Events:         slices.Compact(events),
+		ScoreThreshold: req.ScoreThreshold,
+		Owner:          middleware.Actor(c),
+		Secret:         "whsec_" + secret,
+	}
+	if err := database.CreateWebhook(&webhook); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	audit(c, "webhook_create", "webhooks/"+strconv.FormatInt(webhook.ID, 10), map[string]interface{}{
+		"url":    webhook.URL,
+		"events": webhook.Events,
+	})
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    webhook,
+		Time:    time.Since(start).String(),
+	})
+}
+
+// GetWebhooks handles GET /api/webhooks - the caller's webhooks; admins see everyone's
+func GetWebhooks(c *gin.Context) {
+	start := time.Now()
+
+	webhooks, err := database.GetWebhooks(ownerFilter(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch webhooks: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    webhooks,
+		Count:   len(webhooks),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// DeleteWebhook handles DELETE /api/webhooks/:id - unsubscribes and drops the delivery log
+func DeleteWebhook(c *gin.Context) {
+	start := time.Now()
+
+	webhook, ok := ownedWebhook(c, start)
+	if !ok {
+		return
+	}
+
+	if err := database.DeleteWebhook(webhook.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	audit(c, "webhook_delete", "webhooks/"+strconv.FormatInt(webhook.ID, 10), map[string]interface{}{
+		"url": webhook.URL,
+	})
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"deleted": webhook.ID},
+		Time:    time.Since(start).String(),
+	})
+}
+
+// GetWebhookDeliveries handles GET /api/webhooks/:id/deliveries?status= - the delivery log,
+// newest first, with attempts, response status and the next retry
+func GetWebhookDeliveries(c *gin.Context) {
+	start := time.Now()
+
+	webhook, ok := ownedWebhook(c, start)
+	if !ok {
+		return
+	}
+
+	params, ok := bindQueryParams(c, 50, 500)
+	if !ok {
+		return
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", models.DeliveryPending, models.DeliveryDelivered, models.DeliveryFailed:
+	default:
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid query parameters",
+			Errors:  map[string]string{"status": "must be pending, delivered or failed"},
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	deliveries, err := database.GetWebhookDeliveries(webhook.ID, status, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch webhook deliveries: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    deliveries,
+		Count:   len(deliveries),
+		Time:    time.Since(start).String(),
+	})
+}
+
+// ownedWebhook loads the :id webhook if the caller owns it or is an admin. Other callers get a
+// 404, as if it did not exist. On failure it writes the response and returns false.
+func ownedWebhook(c *gin.Context, start time.Time) (*models.Webhook, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid webhook id",
+			Time:    time.Since(start).String(),
+		})
+		return nil, false
+	}
+
+	webhook, err := database.GetWebhook(id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !ownedBy(c, webhook.Owner)) {
+		c.JSON(http.StatusNotFound, models.APIResponse{
+			Success: false,
+			Error:   "Webhook not found",
+			Time:    time.Since(start).String(),
+		})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to fetch webhook: " + err.Error(),
+			Time:    time.Since(start).String(),
+		})
+		return nil, false
+	}
+	return webhook, true
+}
+
+// ownedBy reports whether the caller may manage a resource created by owner: its creator's
+// API key, or any admin
+func ownedBy(c *gin.Context, owner string) bool {
+	return owner == middleware.Actor(c) || middleware.Role(c) == models.RoleAdmin
+}
+
+// ownerFilter limits listings to the caller's own resources; admins list everyone's
+func ownerFilter(c *gin.Context) string {
+	if middleware.Role(c) == models.RoleAdmin {
+		return ""
+	}
+	return middleware.Actor(c)
+}
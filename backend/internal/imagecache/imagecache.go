@@ -0,0 +1,265 @@
+package imagecache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // registers the GIF decoder for image.Decode
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxSourceBytes caps upstream downloads; Câmara photos are ~30KB and party logos ~200KB
+	maxSourceBytes = 10 << 20
+	// maxSourcePixels caps the images transform decodes, since a few KB of compressed data can
+	// declare a size whose pixels take gigabytes; it allows 40 megapixels (~160MB decoded)
+	maxSourcePixels = 40_000_000
+)
+
+// ErrUpstream wraps failures fetching the source image
+var ErrUpstream = errors.New("upstream image unavailable")
+
+// Image is an encoded image ready to serve
+type Image struct {
+	Data        []byte
+	ContentType string
+	ETag        string
+	ModTime     time.Time
+}
+
+// Store fetches external images, resizes them and keeps the results on disk. Files are
+// named after the source URL, so a changed photo URL is fetched again.
+type Store struct {
+	dir    string
+	maxAge time.Duration
+	client *http.Client
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// NewStore returns a store caching under dir for maxAge
+func NewStore(dir string, maxAge time.Duration) *Store {
+	return &Store{
+		dir:    dir,
+		maxAge: maxAge,
+		client: &http.Client{Timeout: 15 * time.Second},
+		locks:  make(map[string]*sync.Mutex),
+	}
+}
+
+// MaxAge returns how long cached images are reused
+func (s *Store) MaxAge() time.Duration {
+	return s.maxAge
+}
+
+// Get returns sourceURL scaled down to width pixels (0 keeps the original size). Fresh
+// files are served from disk; when the upstream fails a stale file is served instead.
+func (s *Store) Get(ctx context.Context, sourceURL string, width int) (Image, error) {
+	sum := sha256.Sum256([]byte(sourceURL))
+	name := fmt.Sprintf("%s_%d", hex.EncodeToString(sum[:12]), width)
+
+	// One fetch per image at a time; concurrent requests wait and read the file
+	lock := s.lock(name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	cached, cacheErr := s.read(name)
+	if cacheErr == nil && time.Since(cached.ModTime) < s.maxAge {
+		return cached, nil
+	}
+
+	data, contentType, err := s.fetch(ctx, sourceURL)
+	if err == nil {
+		data, contentType, err = transform(data, contentType, width)
+	}
+	if err != nil {
+		if cacheErr == nil {
+			log.Printf("⚠️ Serving stale image for %s: %v", sourceURL, err)
+			return cached, nil
+		}
+		return Image{}, err
+	}
+
+	img, err := s.write(name, data, contentType)
+	if err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+	return img, nil
+}
+
+func (s *Store) lock(name string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.locks[name]
+	if !ok {
+		l = &sync.Mutex{}
+		s.locks[name] = l
+	}
+	return l
+}
+
+// fetch downloads sourceURL, accepting only image responses
+func (s *Store) fetch(ctx context.Context, sourceURL string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrUpstream, err)
+	}
+	req.Header.Set("User-Agent", "open-data-gov image proxy")
+	req.Header.Set("Accept", "image/*")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrUpstream, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("%w: %s returned %s", ErrUpstream, sourceURL, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSourceBytes+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrUpstream, err)
+	}
+	if len(data) > maxSourceBytes {
+		return nil, "", fmt.Errorf("%w: %s is larger than %d bytes", ErrUpstream, sourceURL, maxSourceBytes)
+	}
+
+	contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if !strings.HasPrefix(contentType, "image/") {
+		contentType, _, _ = mime.ParseMediaType(http.DetectContentType(data))
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, "", fmt.Errorf("%w: %s is not an image (%s)", ErrUpstream, sourceURL, contentType)
+	}
+	return data, contentType, nil
+}
+
+// transform scales raster images wider than width down to it. Formats the standard
+// library can't decode (SVG, WebP) and images already small enough pass through.
+func transform(data []byte, contentType string, width int) ([]byte, string, error) {
+	if width == 0 {
+		return data, contentType, nil
+	}
+
+	// The header's size is checked before decoding anything
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) {
+		return data, contentType, nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: failed to decode image: %v", ErrUpstream, err)
+	}
+	if cfg.Width <= width {
+		return data, contentType, nil
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > maxSourcePixels {
+		return nil, "", fmt.Errorf("%w: image of %dx%d pixels is too large to resize", ErrUpstream, cfg.Width, cfg.Height)
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: failed to decode image: %v", ErrUpstream, err)
+	}
+
+	dst := Resize(src, width)
+
+	// Photos stay JPEG; logos keep their transparency as PNG
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+		contentType = "image/jpeg"
+	} else {
+		err = png.Encode(&buf, dst)
+		contentType = "image/png"
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), contentType, nil
+}
+
+// extensions maps served content types to file extensions; the extension records the
+// content type on disk
+var extensions = map[string]string{
+	"image/jpeg":    ".jpg",
+	"image/png":     ".png",
+	"image/gif":     ".gif",
+	"image/webp":    ".webp",
+	"image/svg+xml": ".svg",
+}
+
+func (s *Store) read(name string) (Image, error) {
+	for contentType, ext := range extensions {
+		path := filepath.Join(s.dir, name+ext)
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Image{}, err
+		}
+		return newImage(data, contentType, info.ModTime()), nil
+	}
+	return Image{}, os.ErrNotExist
+}
+
+func (s *Store) write(name string, data []byte, contentType string) (Image, error) {
+	img := newImage(data, contentType, time.Now())
+
+	ext, ok := extensions[contentType]
+	if !ok {
+		// Served but not cached; the next request fetches it again
+		return img, nil
+	}
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return img, fmt.Errorf("failed to create image cache directory: %w", err)
+	}
+	for _, other := range extensions {
+		os.Remove(filepath.Join(s.dir, name+other))
+	}
+
+	// Write then rename so readers never see a partial file
+	tmp, err := os.CreateTemp(s.dir, name+".*.tmp")
+	if err != nil {
+		return img, fmt.Errorf("failed to cache image: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return img, fmt.Errorf("failed to cache image: %w", err)
+	}
+	tmp.Close()
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, name+ext)); err != nil {
+		os.Remove(tmp.Name())
+		return img, fmt.Errorf("failed to cache image: %w", err)
+	}
+	return img, nil
+}
+
+func newImage(data []byte, contentType string, modTime time.Time) Image {
+	sum := sha256.Sum256(data)
+	return Image{
+		Data:        data,
+		ContentType: contentType,
+		ETag:        `"` + hex.EncodeToString(sum[:16]) + `"`,
+		ModTime:     modTime,
+	}
+}
@@ -0,0 +1,50 @@
+package imagecache
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Resize scales src down to width pixels wide, keeping its aspect ratio. Each output
+// pixel averages the source pixels it covers (a box filter), which is sharp enough for
+// thumbnails and needs nothing beyond the standard library.
+func Resize(src image.Image, width int) *image.NRGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	height := sh * width / sw
+	if height < 1 {
+		height = 1
+	}
+
+	// Work on a premultiplied copy so transparent pixels don't bleed their color
+	rgba := image.NewRGBA(image.Rect(0, 0, sw, sh))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := y * sh / height
+		y1 := max((y+1)*sh/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := x * sw / width
+			x1 := max((x+1)*sw/width, x0+1)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint64(p[0])
+					g += uint64(p[1])
+					bl += uint64(p[2])
+					a += uint64(p[3])
+					n++
+				}
+			}
+
+			c := color.RGBA{uint8(r / n), uint8(g / n), uint8(bl / n), uint8(a / n)}
+			dst.Set(x, y, c)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,102 @@
+package inflation
+
+import (
+	"fmt"
+	"math/big"
+	"political-network-api/internal/models"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Table holds the IPCA index by month ("2006-01")
+type Table struct {
+	index  map[string]*big.Rat
+	months []string // ascending
+}
+
+// Target is the month whose prices amounts are adjusted to
+type Target struct {
+	Month string
+	table *Table
+	index *big.Rat
+}
+
+var adjustToPattern = regexp.MustCompile(`^\d{4}(-\d{2})?$`)
+
+// NewTable builds a lookup table from the ipca_index rows
+func NewTable(months []models.IPCAIndex) (*Table, error) {
+	t := &Table{index: make(map[string]*big.Rat, len(months))}
+	for _, m := range months {
+		r, ok := new(big.Rat).SetString(m.IndexNumber)
+		if !ok || r.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid IPCA index %q for %s", m.IndexNumber, m.Month)
+		}
+		t.index[m.Month] = r
+		t.months = append(t.months, m.Month)
+	}
+	return t, nil
+}
+
+// Latest returns the most recent month with a published index, or "" when empty
+func (t *Table) Latest() string {
+	if len(t.months) == 0 {
+		return ""
+	}
+	return t.months[len(t.months)-1]
+}
+
+// Target resolves ?adjust_to=. A year ("2024") means its latest published month, so
+// the current year adjusts to the newest index; a month ("2024-06") must be published.
+func (t *Table) Target(adjustTo string) (Target, error) {
+	if !adjustToPattern.MatchString(adjustTo) {
+		return Target{}, fmt.Errorf("must be a year (2024) or month (2024-06)")
+	}
+
+	month := adjustTo
+	if len(adjustTo) == 4 {
+		month = ""
+		for _, m := range t.months {
+			if strings.HasPrefix(m, adjustTo+"-") {
+				month = m
+			}
+		}
+	}
+
+	index, ok := t.index[month]
+	if !ok {
+		if latest := t.Latest(); latest != "" {
+			return Target{}, fmt.Errorf("no IPCA index for %s (latest is %s)", adjustTo, latest)
+		}
+		return Target{}, fmt.Errorf("no IPCA index loaded (run cli4 populate-ipca)")
+	}
+	return Target{Month: month, table: t, index: index}, nil
+}
+
+// IndexNumber returns the target month's index as a decimal string, for SQL parameters
+func (t Target) IndexNumber() string {
+	return t.index.FloatString(10)
+}
+
+// Adjust converts an amount dated "YYYY-MM-DD" to the target month's prices, rounded to
+// the centavo. Amounts without a published index for their month are returned unchanged.
+func (t Target) Adjust(amount models.Money, date string) models.Money {
+	if len(date) < 7 {
+		return amount
+	}
+	base, ok := t.table.index[date[:7]]
+	if !ok {
+		return amount
+	}
+
+	r := new(big.Rat).SetInt64(int64(amount))
+	r.Mul(r, t.index)
+	r.Quo(r, base)
+
+	// FloatString rounds half away from zero, matching PostgreSQL ROUND on numerics
+	v, err := strconv.ParseInt(r.FloatString(0), 10, 64)
+	if err != nil {
+		return amount
+	}
+	return models.Money(v)
+}
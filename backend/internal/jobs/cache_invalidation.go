@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"encoding/json"
+	"log"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/utils"
+)
+
+// cacheInvalidationChannel is the NOTIFY channel replicas announce cache invalidations on
+const cacheInvalidationChannel = "cache_invalidation"
+
+// cacheInvalidation is a flush, or a purge of prefixes, sent by the instance Origin
+type cacheInvalidation struct {
+	Origin   string   `json:"origin"`
+	Flush    bool     `json:"flush,omitempty"`
+	Prefixes []string `json:"prefixes,omitempty"`
+}
+
+// StartCacheInvalidation makes replicas drop cache entries together (cache.invalidation): every
+// cache flush, such as after an ETL run or POST /api/admin/cache/clear, and every prefix purge, such as
+// after an ingest, is announced to the others, which apply it to their own caches. A replica
+// that loses its listener connection flushes its cache once reconnected, since it may have missed
+// announcements.
+func StartCacheInvalidation(cfg config.CacheConfig) {
+	if cfg.Invalidation != config.InvalidationPostgres {
+		return
+	}
+
+	err := database.Listen(cfg.InvalidationURL, cacheInvalidationChannel, receiveCacheInvalidation, func() {
+		log.Println("🔄 Cache invalidation listener reconnected; flushing announcements it may have missed")
+		utils.ApplyCacheInvalidation(nil)
+	})
+	if err != nil {
+		log.Printf("❌ Cache invalidation disabled: %v", err)
+		return
+	}
+	utils.OnCacheInvalidation(publishCacheInvalidation)
+	log.Println("📡 Broadcasting cache invalidations to other replicas")
+}
+
+// publishCacheInvalidation announces a flush (nil prefixes) or purge in the background, so a
+// slow or unavailable database never holds up the request that invalidated
+func publishCacheInvalidation(prefixes []string) {
+	payload, _ := json.Marshal(cacheInvalidation{Origin: instanceName, Flush: prefixes == nil, Prefixes: prefixes})
+	go func() {
+		if err := database.Notify(cacheInvalidationChannel, string(payload)); err != nil {
+			log.Printf("⚠️ Cache invalidation not broadcast: %v", err)
+		}
+	}()
+}
+
+// receiveCacheInvalidation applies another replica's announcement
+func receiveCacheInvalidation(payload string) {
+	var msg cacheInvalidation
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		log.Printf("⚠️ Ignoring malformed cache invalidation %q: %v", payload, err)
+		return
+	}
+	if msg.Origin == instanceName {
+		return
+	}
+	if msg.Flush {
+		utils.ApplyCacheInvalidation(nil)
+		return
+	}
+	if len(msg.Prefixes) > 0 {
+		utils.ApplyCacheInvalidation(msg.Prefixes)
+		log.Printf("🧹 Purged cache prefixes %v for %s", msg.Prefixes, msg.Origin)
+	}
+}
@@ -0,0 +1,117 @@
+package jobs
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"political-network-api/internal/database"
+	"political-network-api/internal/mail"
+	"political-network-api/internal/models"
+	"strings"
+	"time"
+)
+
+// digestPeriod is how often each subscriber gets a watchlist digest
+const digestPeriod = 7 * 24 * time.Hour
+
+// digestBatch caps the digests sent per run; the rest go out on the next runs
+const digestBatch = 200
+
+// StartDigests sends the weekly watchlist digests that are due now and then every interval, on
+// one instance at a time. An interval of 0 disables the job.
+func StartDigests(interval time.Duration) {
+	if interval <= 0 {
+		log.Println("⏸️ Digest job disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := runScheduled("digests", interval, RunDigests); err != nil {
+				log.Printf("⚠️ Digest job failed: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+	log.Printf("⏰ Digest job scheduled every %s", interval)
+}
+
+// RunDigests emails every digest not sent in the last week. Digests with nothing new are not
+// sent but count as sent, so the next one covers the following week. A digest the provider
+// rejects stays due and is tried again on the next run; digests wait while email is not
+// configured.
+func RunDigests() error {
+	if !mail.Enabled() {
+		return nil
+	}
+
+	due, err := database.GetDueDigests(digestPeriod, digestBatch)
+	if err != nil {
+		return err
+	}
+
+	sent := 0
+	for _, d := range due {
+		if !d.Empty() {
+			if err := mail.SendMessage(digestMessage(d)); err != nil {
+				log.Printf("⚠️ Digest %d not sent: %v", d.ID, err)
+				continue
+			}
+			sent++
+		}
+		if err := database.MarkDigestSent(d.ID, d.AnomalyKeys); err != nil {
+			return err
+		}
+	}
+	if sent > 0 {
+		log.Printf("✉️ Sent %d watchlist digest(s)", sent)
+	}
+	return nil
+}
+
+// digestMessage writes a digest as an email with one-click unsubscribe (RFC 8058)
+func digestMessage(d models.DueDigest) mail.Message {
+	unsubscribe := d.BaseURL + "/api/digests/unsubscribe?token=" + url.QueryEscape(d.Token)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Weekly digest of your watchlist %q since %s.\n", d.Watchlist, d.Since.Format("2006-01-02"))
+
+	section := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(&body, "\n%s\n\n", title)
+		for _, line := range lines {
+			fmt.Fprintf(&body, "- %s\n", line)
+		}
+	}
+	section("New sanctions", d.Sanctions)
+	section("Risk score changes", d.ScoreChanges)
+
+	anomalies := make([]string, len(d.Anomalies))
+	for i, a := range d.Anomalies {
+		vendor := a.CounterpartName
+		if vendor == "" {
+			vendor = a.CNPJCPF
+		}
+		anomalies[i] = fmt.Sprintf("%s: %s paid %s R$ %s in %d expense(s), %s to %s",
+			a.Type, a.PoliticianName, vendor, a.TotalAmount, a.RecordCount, a.FirstDate, a.LastDate)
+	}
+	section("Unusual expenses", anomalies)
+
+	fmt.Fprintf(&body, "\nFull alerts: %s/api/watchlists/%d/alerts\n", d.BaseURL, d.WatchlistID)
+	fmt.Fprintf(&body, "Stop these emails: %s\n", unsubscribe)
+
+	return mail.Message{
+		To:      d.Email,
+		Subject: fmt.Sprintf("[%s] Weekly digest", d.Watchlist),
+		Body:    body.String(),
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + unsubscribe + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}
+}
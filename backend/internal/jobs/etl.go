@@ -0,0 +1,157 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/progress"
+	"political-network-api/internal/utils"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// etlOutputLines is how much of an ETL command's output is kept on the job
+	etlOutputLines = 50
+	// etlStageLength caps the output line shown as the job's stage
+	etlStageLength = 100
+)
+
+// etlConfig holds the cli4 entry point ETL jobs run, set by StartQueue
+var etlConfig config.JobsConfig
+
+var etlCommandPattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// etlRefused are cli4 commands that reset the schema; they are run from a shell, not queued
+var etlRefused = []string{"init-db", "clear-db"}
+
+// etlPayload is the payload of an etl job: a cli4 command and its arguments, e.g.
+// {"command": "populate-financial", "args": ["--phase", "records"]}
+type etlPayload struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+}
+
+func checkETL(payload json.RawMessage) error {
+	var p etlPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("must be {\"command\": ..., \"args\": [...]}: %v", err)
+	}
+	if !etlCommandPattern.MatchString(p.Command) {
+		return errors.New("command must be a cli4 command such as populate-sanctions")
+	}
+	if slices.Contains(etlRefused, p.Command) {
+		return fmt.Errorf("%s cannot be queued, run it from a shell", p.Command)
+	}
+	return nil
+}
+
+// runETL runs a cli4 command, showing its latest output line as the stage, then sets the
+// overridden fields again and drops every cached response since the command may have changed
+// any table
+func runETL(ctx context.Context, payload json.RawMessage, task *progress.Task) (interface{}, error) {
+	var p etlPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+	return runCLI(ctx, task, p.Command, p.Args...)
+}
+
+// runScoreRecompute recalculates aggregate fields and corruption risk scores with cli4
+// post-process
+func runScoreRecompute(ctx context.Context, _ json.RawMessage, task *progress.Task) (interface{}, error) {
+	return runCLI(ctx, task, "post-process")
+}
+
+func runCLI(ctx context.Context, task *progress.Task, command string, args ...string) (interface{}, error) {
+	entry := strings.Fields(etlConfig.ETLCommand)
+	if len(entry) == 0 {
+		return nil, errors.New("jobs.etl_command is not configured")
+	}
+
+	output := &etlOutput{task: task}
+	cmd := exec.CommandContext(ctx, entry[0], append(append(entry[1:], command), args...)...)
+	cmd.Dir = etlConfig.ETLDir
+	cmd.Env = append(os.Environ(), "PYTHONUNBUFFERED=1")
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	task.Stage(command)
+	err := cmd.Run()
+	lines := output.tail()
+	if err != nil {
+		if len(lines) > 0 {
+			return nil, fmt.Errorf("%s: %w: %s", command, err, lines[len(lines)-1])
+		}
+		return nil, fmt.Errorf("%s: %w", command, err)
+	}
+
+	// Overrides outlast the rows the command replaced
+	if _, err := database.ApplyOverrides(); err != nil {
+		log.Printf("⚠️ Overrides not applied after %s: %v", command, err)
+	}
+	// New politicians get their public IDs and slugs
+	if _, err := database.AssignPublicIDs(); err != nil {
+		log.Printf("⚠️ Public IDs not assigned after %s: %v", command, err)
+	}
+	utils.FlushCache()
+	return map[string]interface{}{"command": command, "args": args, "output": lines}, nil
+}
+
+// etlOutput keeps the last lines a command writes and shows the latest as the task's stage
+type etlOutput struct {
+	mu      sync.Mutex
+	task    *progress.Task
+	partial []byte
+	lines   []string
+}
+
+func (o *etlOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.partial = append(o.partial, p...)
+	for {
+		i := bytes.IndexByte(o.partial, '\n')
+		if i < 0 {
+			break
+		}
+		o.add(string(o.partial[:i]))
+		o.partial = o.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+func (o *etlOutput) add(line string) {
+	line = strings.TrimSpace(strings.TrimRight(line, "\r"))
+	if line == "" {
+		return
+	}
+	o.lines = append(o.lines, line)
+	if len(o.lines) > etlOutputLines {
+		o.lines = o.lines[len(o.lines)-etlOutputLines:]
+	}
+	if stage := []rune(line); len(stage) > etlStageLength {
+		line = string(stage[:etlStageLength])
+	}
+	o.task.Stage(line)
+}
+
+func (o *etlOutput) tail() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.partial) > 0 {
+		o.add(string(o.partial))
+		o.partial = nil
+	}
+	return slices.Clone(o.lines)
+}
@@ -0,0 +1,231 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"political-network-api/internal/config"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/progress"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// jobHeartbeat is how often a running job saves its progress and checks it was not cancelled
+	jobHeartbeat = 10 * time.Second
+	// jobStaleAfter is how long a running job can go without a heartbeat before its worker is
+	// presumed dead and the job is claimed again
+	jobStaleAfter = 2 * time.Minute
+)
+
+// jobRetries is the wait before each retry of a failed job; the last one repeats for jobs
+// allowed more attempts
+var jobRetries = []time.Duration{time.Minute, 10 * time.Minute, time.Hour}
+
+// JobRunner runs one kind of queued job. Check validates a payload before it is queued (nil
+// accepts any); Run does the work, reporting progress to task, and should stop when ctx is
+// cancelled. Its result is saved on the job as JSON.
+type JobRunner struct {
+	Check func(payload json.RawMessage) error
+	Run   func(ctx context.Context, payload json.RawMessage, task *progress.Task) (interface{}, error)
+}
+
+var (
+	runners = map[string]JobRunner{
+		models.JobETL:            {Check: checkETL, Run: runETL},
+		models.JobScoreRecompute: {Check: noPayload, Run: runScoreRecompute},
+		models.JobSearchReindex:  {Check: checkSearchReindex, Run: runSearchReindex},
+	}
+	// queueWake lets a newly queued job start without waiting for the next poll
+	queueWake = make(chan struct{}, 1)
+)
+
+// RegisterJob adds a kind of job the queue runs, for work that lives outside this package.
+// Call it before StartQueue.
+func RegisterJob(kind string, runner JobRunner) {
+	runners[kind] = runner
+}
+
+// JobKinds lists the kinds of job that can be queued
+func JobKinds() []string {
+	kinds := make([]string, 0, len(runners))
+	for kind := range runners {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// CheckJob validates a job before it is queued
+func CheckJob(kind string, payload json.RawMessage) error {
+	runner, ok := runners[kind]
+	if !ok {
+		return fmt.Errorf("unknown job kind %q", kind)
+	}
+	if runner.Check == nil {
+		return nil
+	}
+	return runner.Check(payload)
+}
+
+// Enqueue queues a checked job and wakes an idle worker
+func Enqueue(kind string, payload json.RawMessage, maxAttempts int, requestedBy string) (models.Job, error) {
+	if len(payload) == 0 {
+		payload = json.RawMessage("{}")
+	}
+	job, err := database.EnqueueJob(kind, payload, maxAttempts, requestedBy)
+	if err != nil {
+		return job, err
+	}
+	Wake()
+	return job, nil
+}
+
+// Wake tells an idle worker to look for due jobs now instead of at its next poll
+func Wake() {
+	select {
+	case queueWake <- struct{}{}:
+	default:
+	}
+}
+
+// StartQueue starts cfg.QueueWorkers workers running queued jobs, each polling every
+// cfg.QueuePollInterval. Workers on several instances share the queue. With no workers, jobs
+// stay queued until an instance that has them picks them up.
+func StartQueue(cfg config.JobsConfig) {
+	if cfg.QueueWorkers <= 0 {
+		log.Println("⏸️ Job queue workers disabled, queued jobs wait for another instance")
+		return
+	}
+	etlConfig = cfg
+
+	for i := 1; i <= cfg.QueueWorkers; i++ {
+		worker := fmt.Sprintf("%s:%d", instanceName, i)
+		go runQueue(worker, cfg.QueuePollInterval)
+	}
+	log.Printf("⏰ Job queue: %d workers polling every %s", cfg.QueueWorkers, cfg.QueuePollInterval)
+}
+
+func runQueue(worker string, poll time.Duration) {
+	for {
+		job, err := database.ClaimJob(worker, jobStaleAfter)
+		if err == nil {
+			runJob(worker, job)
+			continue
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("⚠️ Job queue worker %s failed to claim a job: %v", worker, err)
+		}
+
+		select {
+		case <-queueWake:
+		case <-time.After(poll):
+		}
+	}
+}
+
+// runJob runs a claimed job, saving its progress while it runs, and records the outcome:
+// done, failed, or queued again for a retry while it has attempts left
+func runJob(worker string, job models.Job) {
+	task, err := progress.Start(job.Kind, 0)
+	if err != nil {
+		log.Printf("⚠️ Job %d (%s) could not start: %v", job.ID, job.Kind, err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		heartbeatJob(job, worker, task, cancel, stop)
+	}()
+
+	log.Printf("▶️ Job %d (%s) started, attempt %d of %d", job.ID, job.Kind, job.Attempts, job.MaxAttempts)
+	result, runErr := callRunner(ctx, job, task)
+	close(stop)
+	wg.Wait()
+	task.Finish(result, runErr)
+
+	var encoded []byte
+	if runErr == nil {
+		if encoded, err = json.Marshal(result); err != nil {
+			runErr = fmt.Errorf("failed to encode result: %w", err)
+		}
+	}
+
+	var retryAt *time.Time
+	errText := ""
+	if runErr != nil {
+		errText = runErr.Error()
+		if job.Attempts < job.MaxAttempts && ctx.Err() == nil {
+			next := time.Now().Add(jobRetries[min(job.Attempts, len(jobRetries))-1])
+			retryAt = &next
+		}
+		log.Printf("❌ Job %d (%s) failed: %v", job.ID, job.Kind, runErr)
+	} else {
+		log.Printf("✅ Job %d (%s) done", job.ID, job.Kind)
+	}
+
+	if err := database.FinishJob(job.ID, worker, task.Snapshot(), encoded, errText, retryAt); err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+}
+
+// callRunner runs the job's runner, turning a panic into an error so one bad job cannot stop
+// its worker
+func callRunner(ctx context.Context, job models.Job, task *progress.Task) (result interface{}, err error) {
+	runner, ok := runners[job.Kind]
+	if !ok {
+		return nil, fmt.Errorf("no runner for job kind %q on this server", job.Kind)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return runner.Run(ctx, job.Payload, task)
+}
+
+// heartbeatJob saves the task's progress every jobHeartbeat until stop is closed, cancelling
+// the run once the job is no longer running on this worker
+func heartbeatJob(job models.Job, worker string, task *progress.Task, cancel context.CancelFunc, stop <-chan struct{}) {
+	ticker := time.NewTicker(jobHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		running, err := database.HeartbeatJob(job.ID, worker, task.Snapshot())
+		if err != nil {
+			log.Printf("⚠️ %v", err)
+		} else if !running {
+			log.Printf("⏹️ Job %d (%s) was cancelled, stopping it", job.ID, job.Kind)
+			cancel()
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// noPayload accepts an empty payload only
+func noPayload(payload json.RawMessage) error {
+	var fields map[string]interface{}
+	if len(payload) > 0 && string(payload) != "null" {
+		if err := json.Unmarshal(payload, &fields); err != nil || len(fields) > 0 {
+			return errors.New("takes no payload")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Package jobs runs the API's scheduled maintenance tasks
+package jobs
+
+import (
+	"log"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/utils"
+	"time"
+)
+
+// sanctionCachePrefixes are the cache entries built from sanctions' active flags
+var sanctionCachePrefixes = []string{
+	"sanctions", "sanction_detail", "company_detail", "politician_detail",
+	"connections", "network", "stats",
+}
+
+// StartSanctionExpiry recalculates sanction status now and then every interval, so sanctions
+// stop counting as active the day their end date passes instead of at the next import. With
+// several instances, one of them runs each round. An interval of 0 disables the job.
+func StartSanctionExpiry(interval time.Duration) {
+	if interval <= 0 {
+		log.Println("⏸️ Sanction expiry job disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := runScheduled("sanction_expiry", interval, RecalculateSanctions); err != nil {
+				log.Printf("⚠️ Sanction expiry job failed: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+	log.Printf("⏰ Sanction expiry job scheduled every %s", interval)
+}
+
+// RecalculateSanctions flips is_active on sanctions whose dates say otherwise, then drops the
+// cached responses built from the old flags and records the run in the audit log
+func RecalculateSanctions() error {
+	expired, reactivated, err := database.RecalculateSanctionStatus()
+	if err != nil {
+		return err
+	}
+	if expired+reactivated == 0 {
+		return nil
+	}
+
+	purged := utils.DeleteCachePrefix(sanctionCachePrefixes...)
+	log.Printf("🔁 Sanction status recalculated: %d expired, %d reactivated, %d cache entries purged",
+		expired, reactivated, purged)
+
+	return database.RecordAudit(models.AuditEntry{
+		Actor:    "job:sanction_expiry",
+		Role:     models.RoleAdmin,
+		Action:   "sanction_status_recalc",
+		Resource: "vendor_sanctions",
+		Payload: map[string]interface{}{
+			"expired":     expired,
+			"reactivated": reactivated,
+		},
+	})
+}
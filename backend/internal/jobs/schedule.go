@@ -0,0 +1,22 @@
+package jobs
+
+import (
+	"fmt"
+	"os"
+	"political-network-api/internal/database"
+	"time"
+)
+
+// instanceName identifies this API instance in scheduler_runs and as a queue worker
+var instanceName = func() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}()
+
+// runScheduled runs one round of a scheduled job, unless another replica is running it or
+// already ran it this interval. Every instance keeps its ticker; whichever takes the job's
+// advisory lock first runs the round, so a replica going away costs at most one interval.
+func runScheduled(name string, interval time.Duration, fn func() error) error {
+	_, err := database.RunScheduled(name, instanceName, interval, fn)
+	return err
+}
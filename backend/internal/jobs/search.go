@@ -0,0 +1,155 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"political-network-api/internal/progress"
+	"political-network-api/internal/search"
+	"political-network-api/internal/utils"
+	"sync"
+	"time"
+)
+
+const (
+	// searchBatch is how many documents are sent to the index at a time
+	searchBatch = 1000
+	// searchSyncOverlap re-reads rows updated just before the last sync, covering clock skew
+	// between the API and the database; rewriting a document is harmless
+	searchSyncOverlap = time.Minute
+)
+
+// searchMu keeps the scheduled sync and an admin reindex from writing at the same time
+var searchMu sync.Mutex
+
+// StartSearchSync builds the search index if it has never been synced, then every interval
+// pushes the politicians, parties and companies the ETL pipeline changed since the last sync.
+// An interval of 0 only builds the index. A shared Elasticsearch index is synced by one
+// instance at a time; each instance syncs its own Bleve index.
+func StartSearchSync(interval time.Duration) {
+	if !search.Enabled() {
+		return
+	}
+
+	go func() {
+		if err := searchScheduled("search_build", 0, buildSearchIfEmpty); err != nil {
+			log.Printf("⚠️ Search index build failed: %v", err)
+		}
+		if interval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			err := searchScheduled("search_sync", interval, func() error {
+				_, err := SyncSearch(false, nil)
+				return err
+			})
+			if err != nil {
+				log.Printf("⚠️ Search sync job failed: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+
+	if interval > 0 {
+		log.Printf("⏰ Search sync job scheduled every %s", interval)
+	} else {
+		log.Println("⏸️ Search sync job disabled, the index is only built at startup")
+	}
+}
+
+// buildSearchIfEmpty builds the whole index when it has never been synced
+func buildSearchIfEmpty() error {
+	last, err := search.LastSync()
+	if err != nil {
+		return fmt.Errorf("failed to read the last sync: %w", err)
+	}
+	if last.IsZero() {
+		_, err = SyncSearch(true, nil)
+	}
+	return err
+}
+
+// searchScheduled runs a scheduled search job, on one instance at a time when the index is shared
+func searchScheduled(name string, interval time.Duration, fn func() error) error {
+	if !search.Shared() {
+		return fn()
+	}
+	return runScheduled(name, interval, fn)
+}
+
+// SyncSearch writes entities updated since the last sync into the search index and returns how
+// many it wrote. A full sync rewrites every entity and then removes the ones deleted from the
+// database. Cached search results are dropped when anything changed. Progress is reported to
+// task, which may be nil.
+func SyncSearch(full bool, task *progress.Task) (int, error) {
+	searchMu.Lock()
+	defer searchMu.Unlock()
+
+	// Index timestamps have second resolution; stamping with the truncated start keeps this
+	// run's documents out of the stale range
+	started := time.Now().Truncate(time.Second)
+
+	var since time.Time
+	if !full {
+		last, err := search.LastSync()
+		if err != nil {
+			return 0, err
+		}
+		if !last.IsZero() {
+			since = last.Add(-searchSyncOverlap)
+		}
+	}
+
+	task.Stage("index")
+	written, err := database.EachSearchDocument(since, searchBatch, func(docs []models.SearchDocument) error {
+		if err := search.Upsert(docs, started); err != nil {
+			return err
+		}
+		task.AddRows(int64(len(docs)))
+		return nil
+	})
+	if err != nil {
+		return written, err
+	}
+
+	deleted := 0
+	if since.IsZero() {
+		task.Stage("delete_stale")
+		if deleted, err = search.DeleteStale(started); err != nil {
+			return written, err
+		}
+	}
+
+	if err := search.SetLastSync(started); err != nil {
+		return written, err
+	}
+	if written+deleted > 0 {
+		purged := utils.DeleteCachePrefix("search")
+		log.Printf("🔎 Search index synced: %d documents written, %d removed, %d cache entries purged",
+			written, deleted, purged)
+	}
+	return written, nil
+}
+
+func checkSearchReindex(payload json.RawMessage) error {
+	if !search.Enabled() {
+		return errors.New("full-text search is not enabled on this server")
+	}
+	return noPayload(payload)
+}
+
+// runSearchReindex rebuilds the whole search index, as POST /api/admin/search/reindex does
+func runSearchReindex(_ context.Context, _ json.RawMessage, task *progress.Task) (interface{}, error) {
+	written, err := SyncSearch(true, task)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int{"documents": written}, nil
+}
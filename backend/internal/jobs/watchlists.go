@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"fmt"
+	"log"
+	"political-network-api/internal/database"
+	"political-network-api/internal/mail"
+	"strings"
+)
+
+// alertEmailBatch caps the alerts emailed per run; the rest go out on the next runs
+const alertEmailBatch = 500
+
+// EmailWatchlistAlerts sends each watchlist with an email address one message listing its new
+// alerts. Alerts stay pending while email is not configured or the relay fails, so they go out
+// once it works.
+func EmailWatchlistAlerts() error {
+	if !mail.Enabled() {
+		return nil
+	}
+
+	pending, err := database.GetPendingAlertEmails(alertEmailBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range pending {
+		subject := fmt.Sprintf("[%s] %d new alert(s)", p.Name, len(p.AlertIDs))
+
+		var body strings.Builder
+		fmt.Fprintf(&body, "New activity on your watchlist %q:\n\n", p.Name)
+		for _, summary := range p.Summaries {
+			fmt.Fprintf(&body, "- %s\n", summary)
+		}
+		fmt.Fprintf(&body, "\nFull alerts: /api/watchlists/%d/alerts\n", p.WatchlistID)
+
+		if err := mail.Send(p.Email, subject, body.String()); err != nil {
+			return err
+		}
+		if err := database.MarkAlertsEmailed(p.AlertIDs); err != nil {
+			return err
+		}
+		log.Printf("✉️ Emailed %d alert(s) for watchlist %d", len(p.AlertIDs), p.WatchlistID)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"political-network-api/internal/database"
+	"political-network-api/internal/models"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// webhookRetries is the wait before each retry of a failed delivery; once they are used up
+// the delivery is marked failed
+var webhookRetries = []time.Duration{
+	time.Minute, 5 * time.Minute, 30 * time.Minute, 2 * time.Hour, 12 * time.Hour,
+}
+
+// webhookBatch caps the deliveries attempted per run
+const webhookBatch = 200
+
+// webhookClient posts deliveries. It refuses to connect to loopback, private and link-local
+// addresses, checked on the resolved IP so DNS cannot point a webhook at internal services,
+// and does not follow redirects.
+var webhookClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: publicAddressesOnly,
+		}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+func publicAddressesOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("webhook address %s is not public", host)
+	}
+	return nil
+}
+
+// StartWebhooks detects changes and delivers queued webhook events and watchlist alerts now and
+// then every interval, on one instance at a time so a delivery is never sent twice. An interval
+// of 0 disables the job; deliveries stay queued until it is enabled again.
+func StartWebhooks(interval time.Duration) {
+	if interval <= 0 {
+		log.Println("⏸️ Webhook job disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := runScheduled("webhooks", interval, RunWebhooks); err != nil {
+				log.Printf("⚠️ Webhook job failed: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+	log.Printf("⏰ Webhook job scheduled every %s", interval)
+}
+
+// RunWebhooks queues deliveries and watchlist alerts for changes since the previous run,
+// attempts every delivery that is due, including retries, and emails pending watchlist alerts
+func RunWebhooks() error {
+	queued, alerts, err := database.QueueWebhookEvents()
+	if err != nil {
+		return err
+	}
+	if queued > 0 || alerts > 0 {
+		log.Printf("📬 Queued %d webhook deliveries, %d watchlist alerts", queued, alerts)
+	}
+
+	if err := EmailWatchlistAlerts(); err != nil {
+		log.Printf("⚠️ Watchlist alert emails failed: %v", err)
+	}
+
+	due, err := database.GetDueDeliveries(webhookBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range due {
+		status, err := deliver(d)
+
+		var retryAt *time.Time
+		attemptErr := ""
+		if err != nil {
+			attemptErr = err.Error()
+			if d.Attempts < len(webhookRetries) {
+				next := time.Now().Add(webhookRetries[d.Attempts])
+				retryAt = &next
+			}
+		}
+
+		if err := database.RecordDeliveryAttempt(d.ID, attemptErr == "", status, attemptErr, retryAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver posts one delivery and returns the response status; any non-2xx response is an error
+func deliver(d models.PendingDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookClient.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "political-network-api-webhooks")
+	req.Header.Set("X-Webhook-Event", d.Event)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(d.ID, 10))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+SignWebhook(d.Secret, timestamp, d.Payload))
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhook is the hex HMAC-SHA256 of "<timestamp>.<body>" under the webhook's secret.
+// Signing the timestamp lets receivers reject replayed deliveries.
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,165 @@
+// Package labels maps the API's field names, many of them Portuguese as published by the
+// Câmara, TSE and CGU, to display labels in Portuguese and English, with notes on the fields
+// whose meaning isn't obvious to researchers outside Brazil
+package labels
+
+import "golang.org/x/text/language"
+
+// Supported languages; Portuguese is the default
+const (
+	Portuguese = "pt-BR"
+	English    = "en"
+)
+
+var matcher = language.NewMatcher([]language.Tag{language.BrazilianPortuguese, language.English})
+
+// Label is one field's label and note in each language
+type Label struct {
+	PT     string
+	EN     string
+	NotePT string
+	NoteEN string
+}
+
+// fields are the labels by JSON field name; a field means the same in every response it
+// appears in. English notes explain Brazilian terms; Portuguese notes are only given where the
+// field holds more than its name says.
+var fields = map[string]Label{
+	// Politicians
+	"nome":                    {PT: "Nome", EN: "Name"},
+	"cpf":                     {PT: "CPF", EN: "CPF (taxpayer ID)", NoteEN: "Brazilian individual taxpayer number, 11 digits; masked for public callers"},
+	"uf":                      {PT: "UF", EN: "State", NotePT: "Sigla da unidade da federação", NoteEN: "Two-letter abbreviation of the Brazilian state (unidade federativa)"},
+	"sigla_partido":           {PT: "Partido", EN: "Party", NotePT: "Sigla do partido atual", NoteEN: "Abbreviation of the politician's current party, e.g. PT, PL, MDB"},
+	"ultimo_status_situacao":  {PT: "Situação", EN: "Status", NotePT: "Situação do mandato segundo a Câmara", NoteEN: "Mandate status as reported by the Chamber of Deputies: Exercício (in office), Licença (on leave), Fim de Mandato (term ended)"},
+	"ultimo_status_email":     {PT: "E-mail do gabinete", EN: "Office email"},
+	"url_foto":                {PT: "Foto", EN: "Photo URL"},
+	"data_nascimento":         {PT: "Data de nascimento", EN: "Date of birth"},
+	"escolaridade":            {PT: "Escolaridade", EN: "Education", NoteEN: "Highest education level declared to the TSE"},
+	"profissao":               {PT: "Profissão", EN: "Occupation", NoteEN: "Occupation declared to the TSE"},
+	"sessoes_plenario":        {PT: "Sessões deliberativas", EN: "Plenary sessions", NoteEN: "Deliberative plenary sessions held while in office"},
+	"presencas_plenario":      {PT: "Presenças", EN: "Sessions attended"},
+	"taxa_ausencia":           {PT: "Taxa de ausência", EN: "Absence rate", NotePT: "% das sessões deliberativas sem presença", NoteEN: "Percentage of deliberative sessions missed; null until attendance is loaded"},
+	"ano_eleicao":             {PT: "Ano da eleição", EN: "Election year", NoteEN: "Year of the politician's latest election"},
+	"votos":                   {PT: "Votos", EN: "Votes", NoteEN: "Nominal votes in the latest election"},
+	"eleito":                  {PT: "Eleito", EN: "Elected"},
+	"coligacao":               {PT: "Coligação", EN: "Coalition", NoteEN: "Party coalition the politician ran in"},
+	"gasto_campanha":          {PT: "Gasto de campanha", EN: "Campaign spending", NoteEN: "Campaign expenses paid in the latest election, in BRL"},
+	"corruption_score":        {PT: "Índice de risco", EN: "Risk score", NotePT: "0 a 100, a partir de sanções, inabilitações do TCU, fornecedores sancionados e evolução patrimonial", NoteEN: "0 to 100, from sanctions, TCU disqualifications, sanctioned vendors and wealth growth; a lead, not an accusation"},
+	"financial_records_count": {PT: "Registros financeiros", EN: "Financial records"},
+	"politician_id":           {PT: "ID do político", EN: "Politician ID"},
+	"politician_nome":         {PT: "Político", EN: "Politician"},
+	"deputy_id":               {PT: "ID do deputado", EN: "Deputy ID", NoteEN: "ID in the Chamber of Deputies open data API"},
+	"deputy_name":             {PT: "Deputado", EN: "Deputy"},
+	"legislatura_id":          {PT: "Legislatura", EN: "Legislature", NoteEN: "Four-year term of the Chamber of Deputies; 57 began in 2023"},
+
+	// Parties
+	"sigla":            {PT: "Sigla", EN: "Abbreviation"},
+	"numero_eleitoral": {PT: "Número eleitoral", EN: "Ballot number", NoteEN: "Two-digit number voters type for the party"},
+	"lider_atual":      {PT: "Líder atual", EN: "Current leader"},
+	"lider_id":         {PT: "ID do líder", EN: "Leader ID"},
+	"total_membros":    {PT: "Total de membros", EN: "Members"},
+	"total_efetivos":   {PT: "Membros em exercício", EN: "Sitting members", NoteEN: "Members currently in office, excluding those on leave"},
+	"data_inicio":      {PT: "Início", EN: "Start date"},
+	"data_fim":         {PT: "Fim", EN: "End date"},
+
+	// Companies and payments
+	"cnpj":                 {PT: "CNPJ", EN: "CNPJ (company ID)", NoteEN: "Brazilian company registration number, 14 digits; the first 8 identify the company, the rest the branch"},
+	"cnpj_cpf":             {PT: "CNPJ/CPF", EN: "Company or taxpayer ID", NoteEN: "CNPJ of a company or CPF of an individual vendor"},
+	"cnpj_root":            {PT: "Raiz do CNPJ", EN: "CNPJ root", NoteEN: "First 8 digits of the CNPJ, shared by a company's headquarters (matriz) and branches (filiais)"},
+	"cnae":                 {PT: "CNAE", EN: "Activity code", NoteEN: "Main economic activity code (CNAE) of the company"},
+	"nome_empresa":         {PT: "Empresa", EN: "Company"},
+	"counterpart_name":     {PT: "Fornecedor", EN: "Vendor"},
+	"transaction_count":    {PT: "Transações", EN: "Transactions"},
+	"total_value":          {PT: "Valor total", EN: "Total amount", NoteEN: "In BRL"},
+	"total_value_adjusted": {PT: "Valor total corrigido", EN: "Total amount adjusted", NoteEN: "In BRL adjusted for inflation by the IPCA index"},
+	"valor":                {PT: "Valor", EN: "Amount", NoteEN: "In BRL"},
+	"valor_ajustado":       {PT: "Valor corrigido", EN: "Adjusted amount", NoteEN: "In BRL adjusted for inflation by the IPCA index"},
+	"data_doc":             {PT: "Data do documento", EN: "Document date", NoteEN: "Date of the invoice or receipt behind the expense"},
+	"documento":            {PT: "Documento", EN: "Document"},
+
+	// Sanctions
+	"tipo_sancao":          {PT: "Tipo de sanção", EN: "Sanction type", NoteEN: "e.g. Inidoneidade (barred from public contracts), Suspensão, Impedimento, Multa (fine)"},
+	"valor_multa":          {PT: "Valor da multa", EN: "Fine amount", NoteEN: "Fine imposed with the sanction, in BRL; 0 when the sanction carries no fine"},
+	"data_inicio_sancao":   {PT: "Início da sanção", EN: "Sanction start"},
+	"data_fim_sancao":      {PT: "Fim da sanção", EN: "Sanction end", NoteEN: "Empty when the sanction has no end date"},
+	"ativa":                {PT: "Ativa", EN: "Active", NoteEN: "Whether the sanction is in force today"},
+	"nome_sancionado":      {PT: "Sancionado", EN: "Sanctioned party"},
+	"descricao":            {PT: "Descrição", EN: "Description"},
+	"fundamentacao_legal":  {PT: "Fundamentação legal", EN: "Legal basis", NoteEN: "Law and article the sanction is based on"},
+	"orgao_sancionador":    {PT: "Órgão sancionador", EN: "Sanctioning body"},
+	"uf_orgao_sancionador": {PT: "UF do órgão sancionador", EN: "Sanctioning body's state"},
+	"numero_processo":      {PT: "Número do processo", EN: "Case number"},
+	"cadastro":             {PT: "Cadastro", EN: "Registry", NoteEN: "CGU registry the sanction was published in: CEIS (barred or suspended companies), CNEP (Anti-Corruption Law) or CEPIM (barred non-profits)"},
+	"verificado_em":        {PT: "Verificado em", EN: "Checked at"},
+	"data_source":          {PT: "Fonte", EN: "Data source"},
+
+	// TCU rulings
+	"numero":      {PT: "Número", EN: "Number"},
+	"ano":         {PT: "Ano", EN: "Year"},
+	"colegiado":   {PT: "Colegiado", EN: "Panel", NoteEN: "TCU body that issued the ruling: Plenário or the first or second chamber"},
+	"relator":     {PT: "Relator", EN: "Rapporteur", NoteEN: "Minister in charge of the case"},
+	"data_sessao": {PT: "Data da sessão", EN: "Session date"},
+	"titulo":      {PT: "Título", EN: "Title"},
+	"sumario":     {PT: "Sumário", EN: "Summary"},
+
+	// Public loans
+	"cliente":            {PT: "Cliente", EN: "Borrower"},
+	"municipio":          {PT: "Município", EN: "Municipality"},
+	"numero_contrato":    {PT: "Número do contrato", EN: "Contract number"},
+	"data_contratacao":   {PT: "Data da contratação", EN: "Contract date"},
+	"valor_contratado":   {PT: "Valor contratado", EN: "Contracted amount", NoteEN: "Loan amount agreed, in BRL"},
+	"valor_desembolsado": {PT: "Valor desembolsado", EN: "Disbursed amount", NoteEN: "Amount actually paid out, in BRL"},
+	"produto":            {PT: "Produto", EN: "Product", NoteEN: "BNDES financing line"},
+	"instrumento":        {PT: "Instrumento", EN: "Instrument"},
+	"forma_apoio":        {PT: "Forma de apoio", EN: "Support type", NoteEN: "direta (lent by BNDES) or indireta (through a partner bank, the agente financeiro)"},
+	"agente_financeiro":  {PT: "Agente financeiro", EN: "Intermediary bank"},
+	"situacao":           {PT: "Situação", EN: "Status"},
+
+	// Fronts, relations, staff
+	"employer_nome":   {PT: "Empregador", EN: "Employer"},
+	"related_nome":    {PT: "Político relacionado", EN: "Related politician"},
+	"relative_nome":   {PT: "Parente", EN: "Relative"},
+	"shared_fronts":   {PT: "Frentes em comum", EN: "Shared fronts", NoteEN: "Parliamentary fronts (frentes parlamentares, cross-party caucuses) both politicians belong to"},
+	"faixa_etaria":    {PT: "Faixa etária", EN: "Age group"},
+	"qualificacao":    {PT: "Qualificação", EN: "Role", NoteEN: "Partner's role in the company, as registered with the Receita Federal"},
+	"in_party_window": {PT: "Janela partidária", EN: "Party window", NoteEN: "Whether the switch happened in the janela partidária, when deputies may change party without losing the mandate"},
+}
+
+// Map is the label map of one language
+type Map struct {
+	Lang   string            `json:"lang"`
+	Labels map[string]string `json:"labels"`
+	Notes  map[string]string `json:"notes"`
+}
+
+// Negotiate picks the supported language closest to an Accept-Language header; lang, when not
+// empty, takes precedence
+func Negotiate(lang, acceptLanguage string) string {
+	tags, _, _ := language.ParseAcceptLanguage(acceptLanguage)
+	if lang != "" {
+		if tag, err := language.Parse(lang); err == nil {
+			tags = []language.Tag{tag}
+		}
+	}
+	_, index, _ := matcher.Match(tags...)
+	if index == 1 {
+		return English
+	}
+	return Portuguese
+}
+
+// For returns the labels and notes of lang, Portuguese or English
+func For(lang string) Map {
+	m := Map{Lang: lang, Labels: make(map[string]string, len(fields)), Notes: map[string]string{}}
+	for field, l := range fields {
+		label, note := l.PT, l.NotePT
+		if lang == English {
+			label, note = l.EN, l.NoteEN
+		}
+		m.Labels[field] = label
+		if note != "" {
+			m.Notes[field] = note
+		}
+	}
+	return m
+}
@@ -0,0 +1,103 @@
+// Package mail sends notification emails through the configured provider: an SMTP relay or
+// Amazon SES
+package mail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"political-network-api/internal/config"
+	"sort"
+	"time"
+)
+
+// ErrDisabled is returned by Send when no email provider is configured
+var ErrDisabled = errors.New("email is not configured")
+
+// Message is a plain-text email. Headers are added to the standard ones, e.g. List-Unsubscribe.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+	Headers map[string]string
+}
+
+// Mailer delivers messages through one provider
+type Mailer interface {
+	Send(from string, m Message) error
+}
+
+// providers builds the mailer of each email.provider, or nil while it is not configured
+var providers = map[string]func(config.EmailConfig) Mailer{
+	config.EmailSMTP: func(cfg config.EmailConfig) Mailer {
+		if cfg.SMTPHost == "" {
+			return nil
+		}
+		return smtpMailer{cfg}
+	},
+	config.EmailSES: func(cfg config.EmailConfig) Mailer {
+		if cfg.SESRegion == "" {
+			return nil
+		}
+		return sesMailer{cfg}
+	},
+}
+
+// current is the configured mailer, or nil. The settings are read on every call, so a
+// configuration reload takes effect for the next email.
+func current() (Mailer, string) {
+	cfg := config.Get().Email
+	build, ok := providers[cfg.Provider]
+	if !ok {
+		return nil, ""
+	}
+	return build(cfg), cfg.From
+}
+
+// Enabled reports whether an email provider is configured
+func Enabled() bool {
+	m, _ := current()
+	return m != nil
+}
+
+// Send delivers a plain-text email
+func Send(to, subject, body string) error {
+	return SendMessage(Message{To: to, Subject: subject, Body: body})
+}
+
+// SendMessage delivers m through the configured provider
+func SendMessage(m Message) error {
+	mailer, from := current()
+	if mailer == nil {
+		return ErrDisabled
+	}
+	if err := mailer.Send(from, m); err != nil {
+		return fmt.Errorf("failed to send email to %s: %w", m.To, err)
+	}
+	return nil
+}
+
+// encode renders m as a MIME message from from
+func (m Message) encode(from string) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", m.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+
+	names := make([]string, 0, len(m.Headers))
+	for name := range m.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&msg, "%s: %s\r\n", name, m.Headers[name])
+	}
+
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(m.Body)
+	return msg.Bytes()
+}
@@ -0,0 +1,85 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"political-network-api/internal/config"
+	"time"
+)
+
+// sesClient calls the SES API
+var sesClient = &http.Client{Timeout: 15 * time.Second}
+
+// sesMailer sends raw messages through the SES v2 API, signing requests with AWS Signature
+// Version 4 so the AWS SDK isn't needed for one call
+type sesMailer struct {
+	cfg config.EmailConfig
+}
+
+func (s sesMailer) Send(from string, m Message) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"FromEmailAddress": from,
+		"Destination":      map[string][]string{"ToAddresses": {m.To}},
+		"Content":          map[string]interface{}{"Raw": map[string][]byte{"Data": m.encode(from)}},
+	})
+	if err != nil {
+		return err
+	}
+
+	host := "email." + s.cfg.SESRegion + ".amazonaws.com"
+	req, err := http.NewRequest(http.MethodPost, "https://"+host+"/v2/email/outbound-emails", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.sign(req, host, body, time.Now().UTC())
+
+	resp, err := sesClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return fmt.Errorf("SES responded %s: %s", resp.Status, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+// sign adds the Signature Version 4 headers for the ses service to req
+func (s sesMailer) sign(req *http.Request, host string, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	payloadHash := sha256.Sum256(body)
+	signedHeaders := "content-type;host;x-amz-date"
+	canonical := fmt.Sprintf("%s\n%s\n\ncontent-type:%s\nhost:%s\nx-amz-date:%s\n\n%s\n%s",
+		req.Method, req.URL.EscapedPath(), req.Header.Get("Content-Type"), host, amzDate,
+		signedHeaders, hex.EncodeToString(payloadHash[:]))
+
+	scope := date + "/" + s.cfg.SESRegion + "/ses/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonical))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := []byte("AWS4" + s.cfg.SESSecretAccessKey)
+	for _, part := range []string{date, s.cfg.SESRegion, "ses", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.SESAccessKeyID, scope, signedHeaders, hex.EncodeToString(hmacSHA256(key, stringToSign))))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
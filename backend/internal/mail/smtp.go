@@ -0,0 +1,24 @@
+package mail
+
+import (
+	"net"
+	"net/smtp"
+	"political-network-api/internal/config"
+	"strconv"
+)
+
+// smtpMailer sends through an SMTP relay. net/smtp upgrades to STARTTLS when the relay offers
+// it and only sends credentials over TLS or to localhost.
+type smtpMailer struct {
+	cfg config.EmailConfig
+}
+
+func (s smtpMailer) Send(from string, m Message) error {
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.SMTPHost)
+	}
+
+	addr := net.JoinHostPort(s.cfg.SMTPHost, strconv.Itoa(s.cfg.SMTPPort))
+	return smtp.SendMail(addr, auth, from, []string{m.To}, m.encode(from))
+}
@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"political-network-api/internal/models"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Context keys set by Authenticate
+const (
+	roleKey  = "auth_role"
+	actorKey = "auth_actor"
+	userKey  = "auth_user"
+)
+
+// SessionPrefix marks user session tokens, telling them apart from API keys
+const SessionPrefix = "sess_"
+
+// SessionResolver looks up a user session token, returning an error for unknown or expired
+// sessions. It is installed at startup so this package stays independent of the database.
+type SessionResolver func(token string) (*models.User, error)
+
+var resolveSession SessionResolver
+
+// SetSessionResolver enables user sessions
+func SetSessionResolver(resolver SessionResolver) {
+	resolveSession = resolver
+}
+
+// apiKey is a configured key's identity; the label is what audit entries record
+type apiKey struct {
+	Label string
+	Role  models.Role
+}
+
+// apiKeys holds the configured keys by secret; LoadAPIKeys swaps in a new map on SIGHUP while
+// requests read the current one
+var apiKeys atomic.Pointer[map[string]apiKey]
+
+// lookupAPIKey returns the identity of a configured API key
+func lookupAPIKey(key string) (apiKey, bool) {
+	keys := apiKeys.Load()
+	if keys == nil {
+		return apiKey{}, false
+	}
+	identity, ok := (*keys)[key]
+	return identity, ok
+}
+
+// LoadAPIKeys installs label:role:key entries (validated by config.Validate)
+func LoadAPIKeys(entries []string) {
+	keys := make(map[string]apiKey, len(entries))
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		keys[parts[2]] = apiKey{Label: parts[0], Role: models.Role(parts[1])}
+	}
+
+	apiKeys.Store(&keys)
+	log.Printf("🔑 Loaded %d API keys", len(keys))
+}
+
+// ResolveAPIKey returns the label and role of a configured API key, for transports other than
+// HTTP
+func ResolveAPIKey(key string) (label string, role models.Role, ok bool) {
+	identity, ok := lookupAPIKey(key)
+	return identity.Label, identity.Role, ok
+}
+
+// requestKey reads the API key from X-API-Key or an Authorization: Bearer header
+func requestKey(c *gin.Context) string {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
+
+// Authenticate resolves the caller's API key to a role, or a session token to a user.
+// Requests without either are public; an unknown key is rejected rather than silently
+// downgraded.
+func Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := requestKey(c)
+		if key == "" {
+			c.Set(roleKey, models.RolePublic)
+			c.Set(actorKey, "anonymous")
+			c.Next()
+			return
+		}
+
+		// Signed-in users browse with public access; their session only identifies them
+		if strings.HasPrefix(key, SessionPrefix) && resolveSession != nil {
+			user, err := resolveSession(key)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, models.APIResponse{
+					Success: false,
+					Error:   "Invalid or expired session",
+					Time:    "0ms",
+				})
+				return
+			}
+
+			c.Set(roleKey, models.RolePublic)
+			c.Set(actorKey, "user:"+strconv.FormatInt(user.ID, 10))
+			c.Set(userKey, user)
+			c.Next()
+			return
+		}
+
+		identity, ok := lookupAPIKey(key)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, models.APIResponse{
+				Success: false,
+				Error:   "Invalid API key",
+				Time:    "0ms",
+			})
+			return
+		}
+
+		c.Set(roleKey, identity.Role)
+		c.Set(actorKey, identity.Label)
+		c.Next()
+	}
+}
+
+// RequireRole rejects callers whose role is not one of roles
+func RequireRole(roles ...models.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := Role(c)
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		status := http.StatusForbidden
+		if role == models.RolePublic {
+			status = http.StatusUnauthorized
+		}
+		c.AbortWithStatusJSON(status, models.APIResponse{
+			Success: false,
+			Error:   "Insufficient role for this endpoint",
+			Time:    "0ms",
+		})
+	}
+}
+
+// RequireUser rejects callers without a user session
+func RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if User(c) == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, models.APIResponse{
+				Success: false,
+				Error:   "Sign in to use this endpoint",
+				Time:    "0ms",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// User returns the signed-in user, or nil
+func User(c *gin.Context) *models.User {
+	if user, ok := c.Get(userKey); ok {
+		return user.(*models.User)
+	}
+	return nil
+}
+
+// SessionToken returns the caller's session token, if they sent one
+func SessionToken(c *gin.Context) string {
+	if key := requestKey(c); strings.HasPrefix(key, SessionPrefix) {
+		return key
+	}
+	return ""
+}
+
+// Role returns the caller's role, models.RolePublic when unauthenticated
+func Role(c *gin.Context) models.Role {
+	if role, ok := c.Get(roleKey); ok {
+		return role.(models.Role)
+	}
+	return models.RolePublic
+}
+
+// Actor returns the label of the caller's API key, "user:<id>" for a signed-in user, or "anonymous"
+func Actor(c *gin.Context) string {
+	if actor := c.GetString(actorKey); actor != "" {
+		return actor
+	}
+	return "anonymous"
+}
@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"political-network-api/internal/config"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+// brotliLevel trades ratio for speed; responses are compressed on every request
+const brotliLevel = 5
+
+var (
+	gzipWriters   = sync.Pool{New: func() interface{} { return gzip.NewWriter(io.Discard) }}
+	brotliWriters = sync.Pool{New: func() interface{} { return brotli.NewWriterLevel(io.Discard, brotliLevel) }}
+)
+
+// encoder is a pooled gzip or brotli writer
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+// Compress encodes responses with brotli or gzip, whichever the client prefers in
+// Accept-Encoding. Only bodies of an allowed content type that reach compression.min_size are
+// compressed; smaller ones are sent as they are. A handler's Flush ends the buffering, so
+// streamed responses keep flowing. Settings are read per request, so a SIGHUP reload applies
+// immediately.
+func Compress() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cfg := config.Get().Compression
+		if !cfg.Enabled || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		w := &compressWriter{ResponseWriter: c.Writer, encoding: encoding, cfg: cfg}
+		c.Writer = w
+		defer w.finish()
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks br or gzip by the client's q-values, preferring br on a tie;
+// "" means the response is sent uncompressed
+func negotiateEncoding(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "br" && name != "gzip" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > bestQ || (q == bestQ && q > 0 && name == "br") {
+			best, bestQ = name, q
+		}
+	}
+	return best
+}
+
+// compressWriter holds the body back until it knows whether to compress: when it reaches the
+// size threshold, when the handler flushes, or when the request ends
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	cfg      config.CompressionConfig
+
+	buf     bytes.Buffer
+	decided bool
+	enc     encoder
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		if w.enc != nil {
+			return w.enc.Write(data)
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buf.Write(data)
+	if w.buf.Len() >= w.cfg.MinSize {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Written reports buffered bytes as written, so handlers see the response as started
+func (w *compressWriter) Written() bool {
+	return w.buf.Len() > 0 || w.ResponseWriter.Written()
+}
+
+// Flush sends everything written so far; a stream that flushes is worth compressing even
+// before it reaches the size threshold
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide(true)
+	}
+	if w.enc != nil {
+		w.enc.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide settles compression for the response and writes out the buffered body. large is
+// false at the end of a response that never reached the threshold.
+func (w *compressWriter) decide(large bool) error {
+	w.decided = true
+	if large && w.compressible() {
+		header := w.Header()
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		// The compressed body is a different byte sequence, so a strong validator becomes weak
+		if etag := header.Get("ETag"); strings.HasPrefix(etag, `"`) {
+			header.Set("ETag", "W/"+etag)
+		}
+
+		if w.encoding == "br" {
+			w.enc = brotliWriters.Get().(*brotli.Writer)
+		} else {
+			w.enc = gzipWriters.Get().(*gzip.Writer)
+		}
+		w.enc.Reset(w.ResponseWriter)
+	}
+
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	var err error
+	if w.enc != nil {
+		_, err = w.enc.Write(w.buf.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+	w.buf.Reset()
+	return err
+}
+
+// compressible reports whether the response may be compressed: a success or error with a body,
+// not already encoded, not a byte range, and of an allowed content type
+func (w *compressWriter) compressible() bool {
+	status := w.Status()
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified ||
+		status == http.StatusPartialContent {
+		return false
+	}
+
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return w.cfg.AllowsType(mediaType)
+}
+
+// finish writes out a body that never reached the threshold and closes the encoder
+func (w *compressWriter) finish() {
+	if !w.decided {
+		w.decide(false)
+	}
+	if w.enc == nil {
+		return
+	}
+
+	w.enc.Close()
+	w.enc.Reset(io.Discard)
+	if w.encoding == "br" {
+		brotliWriters.Put(w.enc)
+	} else {
+		gzipWriters.Put(w.enc)
+	}
+}
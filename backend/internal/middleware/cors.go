@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"political-network-api/internal/config"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// CORS allows the frontend origins from configuration. Origins are checked against the
+// active configuration on every request, so a SIGHUP reload applies immediately.
+func CORS() gin.HandlerFunc {
+	corsConfig := cors.DefaultConfig()
+	corsConfig.AllowOriginFunc = func(origin string) bool {
+		return config.Get().CORS.AllowsOrigin(origin)
+	}
+	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Key"}
+	corsConfig.ExposeHeaders = []string{"Content-Length", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After"}
+	corsConfig.AllowCredentials = true
+
+	return cors.New(corsConfig)
+}
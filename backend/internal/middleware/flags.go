@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireFlag hides an experimental endpoint behind the feature flag name (see config.Flag):
+// callers it is not on for get a 404, as if the endpoint didn't exist yet. Flags are evaluated
+// per request, so a reload changes who gets through at once.
+func RequireFlag(name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !FlagOn(c, name) {
+			c.AbortWithStatusJSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   "Endpoint not found",
+				Time:    "0ms",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// FlagOn reports whether the feature flag name is on for the caller
+func FlagOn(c *gin.Context, name string) bool {
+	return config.Get().FlagOn(name, Actor(c), Role(c))
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/models"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateWindow counts one client's requests in the current fixed window
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// rateLimiter keeps per-client counters for windows aligned to the window size, so every
+// counter from an earlier window can be dropped at once
+type rateLimiter struct {
+	mu      sync.Mutex
+	windows map[string]*rateWindow
+	current time.Time
+}
+
+var limiter = &rateLimiter{windows: map[string]*rateWindow{}}
+
+// take counts a request for key, reporting the requests left and when the window resets.
+// ok is false, and nothing is counted, once the limit is used up.
+func (l *rateLimiter) take(key string, limit int, window time.Duration, now time.Time) (remaining int, reset time.Time, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	start := now.Truncate(window)
+	if !l.current.Equal(start) {
+		for k, w := range l.windows {
+			if !w.start.Equal(start) {
+				delete(l.windows, k)
+			}
+		}
+		l.current = start
+	}
+
+	w, exists := l.windows[key]
+	if !exists {
+		w = &rateWindow{start: start}
+		l.windows[key] = w
+	}
+
+	reset = start.Add(window)
+	if w.count >= limit {
+		return 0, reset, false
+	}
+	w.count++
+	return limit - w.count, reset, true
+}
+
+// RateLimit caps requests per client and window by role (rate_limit in configuration). API
+// keys and signed-in users are counted by identity, anonymous callers by IP. Every limited
+// response carries X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset (Unix
+// seconds); rejected requests get a 429 with Retry-After and the same values in the body.
+// Limits are read per request, so a SIGHUP reload applies immediately.
+func RateLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cfg := config.Get().RateLimit
+		limit := cfg.Limit(Role(c))
+		if limit == 0 {
+			c.Next()
+			return
+		}
+
+		key := Actor(c)
+		if key == "anonymous" {
+			key = "ip:" + c.ClientIP()
+		}
+
+		now := time.Now()
+		remaining, reset, ok := limiter.take(key, limit, cfg.Window, now)
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		if ok {
+			c.Next()
+			return
+		}
+
+		// Round up so a client waiting retry_after seconds lands in the next window
+		retryAfter := int((reset.Sub(now) + time.Second - 1) / time.Second)
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, models.APIResponse{
+			Success: false,
+			Error:   "Rate limit exceeded",
+			Data: models.RateLimitStatus{
+				Limit:      limit,
+				Remaining:  0,
+				Reset:      reset.Unix(),
+				RetryAfter: retryAfter,
+			},
+			Time: "0ms",
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"political-network-api/internal/models"
+	"political-network-api/internal/reporting"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery turns a panic in a handler into a 500 carrying an error_id, and logs and reports it
+// (reporting.dsn) with the request and stack under that ID. A client that hung up mid-response
+// is not an error: those panics only end the request.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			if err, ok := recovered.(error); ok && (errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)) {
+				c.Abort()
+				return
+			}
+
+			errorID := reporting.Panic(recovered, reporting.Request{
+				Method: c.Request.Method,
+				URL:    c.Request.URL,
+				Header: c.Request.Header,
+				Route:  c.FullPath(),
+				Actor:  Actor(c),
+			})
+			log.Printf("❌ Panic %s in %s %s: %v\n%s", errorID, c.Request.Method, c.Request.URL.Path, recovered, debug.Stack())
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, models.APIResponse{
+				Success: false,
+				Error:   "Internal server error",
+				ErrorID: errorID,
+				Time:    "0ms",
+			})
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"political-network-api/internal/usage"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Usage records every request's route pattern, caller, status and latency for
+// /api/admin/usage. It goes before Authenticate, so rejected keys are counted too; the caller
+// is read once the request is done, after Authenticate has identified it.
+func Usage() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "(unmatched)"
+		}
+		usage.Record(c.Request.Method+" "+route, Actor(c), c.Writer.Status(), time.Since(start), start)
+	}
+}
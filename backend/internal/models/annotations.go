@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Annotation visibilities: private to its author, shared with the team (every researcher and
+// admin key), or public. Admins see all of them.
+const (
+	VisibilityPrivate = "private"
+	VisibilityTeam    = "team"
+	VisibilityPublic  = "public"
+)
+
+// Annotated entity types: a politician (ID is unified_politicians.id) or a company (ID is its CNPJ)
+const (
+	AnnotatePolitician = "politician"
+	AnnotateCompany    = "company"
+)
+
+// Annotation is a researcher's tag ("Lava Jato", "under investigation") and/or note on a
+// politician or company. Tags are matched case-insensitively.
+type Annotation struct {
+	ID         int64     `json:"id"`
+	EntityType string    `json:"entity_type"`
+	EntityID   string    `json:"entity_id"`
+	Tag        string    `json:"tag,omitempty"`
+	Note       string    `json:"note,omitempty"`
+	Visibility string    `json:"visibility"`
+	Author     string    `json:"author"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// AnnotationRequest is the body of POST /api/annotations; a tag, a note or both are required.
+// Visibility defaults to private.
+type AnnotationRequest struct {
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+	Tag        string `json:"tag"`
+	Note       string `json:"note"`
+	Visibility string `json:"visibility"`
+}
+
+// AnnotationUpdate is the body of PATCH /api/annotations/:id; omitted fields are kept
+type AnnotationUpdate struct {
+	Tag        *string `json:"tag"`
+	Note       *string `json:"note"`
+	Visibility *string `json:"visibility"`
+}
+
+// TagCount is a tag with the number of politicians and companies carrying it
+type TagCount struct {
+	Tag         string `json:"tag"`
+	Politicians int    `json:"politicians"`
+	Companies   int    `json:"companies"`
+}
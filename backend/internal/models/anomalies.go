@@ -0,0 +1,22 @@
+package models
+
+import "encoding/json"
+
+// Anomaly is a suspicious expense pattern between a politician and a vendor, found by cli4
+// detect-anomalies. Details holds the evidence, whose fields depend on Type: for geo_mismatch,
+// category, politician_uf, vendor_uf, vendor_municipality, distance_km and months; for
+// duplicate, scope, amount, date, category, same_document, politician_ids and the records with
+// their document URLs.
+type Anomaly struct {
+	ID              int             `json:"id"`
+	Type            string          `json:"type"`
+	PoliticianID    int             `json:"politician_id"`
+	PoliticianName  string          `json:"politician_nome"`
+	CNPJCPF         string          `json:"cnpj_cpf,omitempty"`
+	CounterpartName string          `json:"counterpart_name,omitempty"`
+	RecordCount     int             `json:"record_count"`
+	TotalAmount     Money           `json:"total_amount"`
+	FirstDate       string          `json:"first_date,omitempty"`
+	LastDate        string          `json:"last_date,omitempty"`
+	Details         json.RawMessage `json:"details"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "encoding/json"
+
+// BatchRequest is one GET sub-request of POST /api/batch
+type BatchRequest struct {
+	ID     string            `json:"id,omitempty"`
+	Path   string            `json:"path"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// BatchResult is a sub-request's status and body. Body is the sub-response's JSON, or a string
+// for other content types.
+type BatchResult struct {
+	ID     string          `json:"id,omitempty"`
+	Path   string          `json:"path"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
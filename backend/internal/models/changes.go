@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Change feed entities: the tables whose row changes /api/changes serves
+const (
+	ChangePoliticians = "politicians"
+	ChangeSanctions   = "sanctions"
+)
+
+// ChangeEntities lists the change feed entities in the order the API documents them
+var ChangeEntities = []string{ChangePoliticians, ChangeSanctions}
+
+// Change operations
+const (
+	ChangeCreated = "created"
+	ChangeUpdated = "updated"
+	ChangeDeleted = "deleted"
+)
+
+// Change is one row change recorded by the change triggers. ID orders the feed; Record is the
+// record as it is now (a Politician or a Sanction), absent for deletions and for records that
+// no longer exist or are no longer listed.
+type Change struct {
+	ID        int64       `json:"id"`
+	Entity    string      `json:"entity"`
+	RecordID  int         `json:"record_id"`
+	Operation string      `json:"operation"`
+	ChangedAt time.Time   `json:"changed_at"`
+	Record    interface{} `json:"record,omitempty"`
+}
+
+// ChangeFeed is a page of the change feed; NextAfter is the after= of the next page
+type ChangeFeed struct {
+	Entity    string   `json:"entity"`
+	Changes   []Change `json:"changes"`
+	NextAfter int64    `json:"next_after"`
+	HasMore   bool     `json:"has_more"`
+}
@@ -0,0 +1,46 @@
+package models
+
+// VendorCluster is a vendor whose clients or invoices suggest coordination: politicians of one
+// party or one state delegation make up most of its CEAP clients, or it bills a politician
+// several times in a month just under a dispensa de licitação limit. It is a lead for review,
+// not a finding.
+type VendorCluster struct {
+	CNPJ          string               `json:"cnpj"`
+	Nome          string               `json:"nome"`
+	Signals       []string             `json:"signals"` // party, state, split_invoices
+	Politicians   int                  `json:"politicians"`
+	TotalAmount   Money                `json:"total_amount"`
+	Party         *ClientConcentration `json:"party,omitempty"`
+	State         *ClientConcentration `json:"state,omitempty"`
+	SplitInvoices []SplitInvoices      `json:"split_invoices,omitempty"`
+	Clients       []VendorClient       `json:"clients"`
+}
+
+// ClientConcentration is the party or state with the most politicians among a vendor's clients
+type ClientConcentration struct {
+	Key         string  `json:"key"` // party sigla or UF
+	Politicians int     `json:"politicians"`
+	Share       float64 `json:"share"` // of the vendor's politicians, 0-1
+	Amount      Money   `json:"amount"`
+}
+
+// VendorClient is a politician paying a vendor through the CEAP
+type VendorClient struct {
+	PoliticianID int    `json:"politician_id"`
+	Nome         string `json:"nome"`
+	SiglaPartido string `json:"sigla_partido,omitempty"`
+	UF           string `json:"uf,omitempty"`
+	Amount       Money  `json:"amount"`
+}
+
+// SplitInvoices are the invoices of one month from a vendor to a politician that each fall just
+// under Threshold but together exceed it
+type SplitInvoices struct {
+	PoliticianID int      `json:"politician_id"`
+	Nome         string   `json:"nome"`
+	Month        string   `json:"month"` // YYYY-MM
+	Threshold    Money    `json:"threshold"`
+	Invoices     int      `json:"invoices"`
+	Total        Money    `json:"total"`
+	DocumentURLs []string `json:"document_urls,omitempty"`
+}
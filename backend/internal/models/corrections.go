@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// Correction moderation states. Anyone submits; an admin accepts or rejects.
+const (
+	CorrectionPending  = "pending"
+	CorrectionAccepted = "accepted"
+	CorrectionRejected = "rejected"
+)
+
+// Corrected entity types: a politician (ID is unified_politicians.id), a company (ID is its
+// CNPJ/CPF) or an expense (ID is unified_financial_records.id)
+const (
+	CorrectionPolitician = "politician"
+	CorrectionCompany    = "company"
+	CorrectionExpense    = "expense"
+)
+
+// CorrectableFields are the fields of each entity type corrections and overrides can set. A
+// correction naming none of them is a tip, accepted or rejected without changing data.
+var CorrectableFields = map[string][]string{
+	CorrectionPolitician: {"nome_civil", "nome_eleitoral", "current_party", "current_state", "situacao"},
+	CorrectionCompany:    {"name", "cnae_code"},
+	CorrectionExpense:    {"counterpart_cnpj_cpf", "counterpart_name"},
+}
+
+// Correction is a report that a politician, company or expense is wrong, such as an expense
+// matched to the wrong CNPJ or an outdated party. Value is the value an admin accepted, which
+// became an Override of the field.
+type Correction struct {
+	ID            int64      `json:"id"`
+	EntityType    string     `json:"entity_type"`
+	EntityID      string     `json:"entity_id"`
+	Field         string     `json:"field,omitempty"`
+	CurrentValue  string     `json:"current_value,omitempty"`
+	ProposedValue string     `json:"proposed_value,omitempty"`
+	Description   string     `json:"description"`
+	Evidence      []string   `json:"evidence"`
+	Contact       string     `json:"contact,omitempty"`
+	SubmittedBy   string     `json:"submitted_by"`
+	Status        string     `json:"status"`
+	Value         string     `json:"value,omitempty"`
+	Note          string     `json:"note,omitempty"`
+	ReviewedBy    string     `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// CorrectionRequest is the body of POST /api/corrections. Field and ProposedValue are optional:
+// without them the correction is a tip described in Description. Evidence holds source URLs.
+type CorrectionRequest struct {
+	EntityType    string   `json:"entity_type"`
+	EntityID      string   `json:"entity_id"`
+	Field         string   `json:"field"`
+	ProposedValue string   `json:"proposed_value"`
+	Description   string   `json:"description"`
+	Evidence      []string `json:"evidence"`
+	Contact       string   `json:"contact"`
+}
+
+// CorrectionReview is the body of PATCH /api/admin/corrections/:id. Value replaces the proposed
+// value when the admin accepts a different one.
+type CorrectionReview struct {
+	Status string  `json:"status"`
+	Value  *string `json:"value"`
+	Note   string  `json:"note"`
+}
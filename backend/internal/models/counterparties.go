@@ -0,0 +1,83 @@
+package models
+
+// trendStable is the change in percent within which a counterparty's trend is stable
+const trendStable = 10
+
+// Trend directions of a counterparty
+const (
+	TrendRising   = "rising"
+	TrendFalling  = "falling"
+	TrendStable   = "stable"
+	TrendNew      = "new"      // paid only in the last 12 months
+	TrendEnded    = "ended"    // paid in the 12 months before, not in the last
+	TrendInactive = "inactive" // paid in neither
+)
+
+// CounterpartyTrend compares the payments of the last 12 months with those of the 12 before.
+// The months end at the latest payment of the politician or company rather than today, so
+// trends of data that stops earlier still compare full years.
+type CounterpartyTrend struct {
+	Recent    Money    `json:"recent"`
+	Previous  Money    `json:"previous"`
+	Change    *float64 `json:"change"` // percent; null when nothing was paid in the 12 months before
+	Direction string   `json:"direction"`
+}
+
+// NewCounterpartyTrend compares the recent and previous payments of a counterparty
+func NewCounterpartyTrend(recent, previous Money) CounterpartyTrend {
+	t := CounterpartyTrend{Recent: recent, Previous: previous}
+	switch {
+	case recent <= 0 && previous <= 0:
+		t.Direction = TrendInactive
+	case previous <= 0:
+		t.Direction = TrendNew
+	case recent <= 0:
+		t.Direction = TrendEnded
+	default:
+		change := float64((recent-previous)*10000/previous) / 100
+		t.Change = &change
+		switch {
+		case change > trendStable:
+			t.Direction = TrendRising
+		case change < -trendStable:
+			t.Direction = TrendFalling
+		default:
+			t.Direction = TrendStable
+		}
+	}
+	return t
+}
+
+// Share is part as a percentage of total, to two decimals
+func Share(part, total Money) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(part*10000/total) / 100
+}
+
+// TopVendor is a vendor with what a politician paid it, ranked by total
+type TopVendor struct {
+	CNPJCPF   string            `json:"cnpj_cpf"`
+	Name      string            `json:"name"`
+	Total     Money             `json:"total"`
+	Payments  int               `json:"payments"`
+	Share     float64           `json:"share"` // percent of the politician's spending
+	FirstDate string            `json:"first_date,omitempty"`
+	LastDate  string            `json:"last_date,omitempty"`
+	Trend     CounterpartyTrend `json:"trend"`
+}
+
+// TopPolitician is a politician with what they paid a company, ranked by total
+type TopPolitician struct {
+	PoliticianID int               `json:"politician_id"`
+	Nome         string            `json:"nome"`
+	SiglaPartido string            `json:"sigla_partido,omitempty"`
+	UF           string            `json:"uf,omitempty"`
+	Total        Money             `json:"total"`
+	Payments     int               `json:"payments"`
+	Share        float64           `json:"share"` // percent of what politicians paid the company
+	FirstDate    string            `json:"first_date,omitempty"`
+	LastDate     string            `json:"last_date,omitempty"`
+	Trend        CounterpartyTrend `json:"trend"`
+}
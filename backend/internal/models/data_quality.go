@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Data quality check severities: errors break the network or the figures shown, warnings are
+// worth a look but can be legitimate (a refund is a negative expense)
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// DataQualityReport is the result of the integrity checks, run on startup, by the verify
+// command and by GET /api/admin/data-quality. Passed is false when an error check found rows.
+type DataQualityReport struct {
+	CheckedAt time.Time          `json:"checked_at"`
+	Duration  string             `json:"duration"`
+	Passed    bool               `json:"passed"`
+	Errors    int                `json:"errors"`
+	Warnings  int                `json:"warnings"`
+	Checks    []DataQualityCheck `json:"checks"`
+}
+
+// DataQualityCheck is one invariant with the rows breaking it: how many, and a few of their
+// keys to start looking from. Error is set instead when the check could not run.
+type DataQualityCheck struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Severity    string   `json:"severity"`
+	Violations  int      `json:"violations"`
+	Examples    []string `json:"examples,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Entity link review states. The matcher confirms links it is sure of and suggests the rest;
+// an admin confirms or rejects suggestions.
+const (
+	LinkSuggested = "suggested"
+	LinkConfirmed = "confirmed"
+	LinkRejected  = "rejected"
+)
+
+// Linked entity types: a politician (ID is unified_politicians.id) or a company (ID is its CNPJ)
+const (
+	LinkPolitician = "politician"
+	LinkCompany    = "company"
+)
+
+// LinkSources are the datasets resolve-entities reads, as stored in entity_links.source
+var LinkSources = []string{"CAMARA", "TSE", "PORTAL_TRANSPARENCIA", "TCU", "SENADO", "RECEITA"}
+
+// EntityLink says that a record of one dataset names a known politician or company, with the
+// matcher's confidence and how it got there (document, masked_document, cnpj_root,
+// name_birth_date or name)
+type EntityLink struct {
+	ID             int64      `json:"id"`
+	Source         string     `json:"source"`
+	SourceKey      string     `json:"source_key"`
+	SourceName     string     `json:"source_name,omitempty"`
+	SourceDocument string     `json:"source_document,omitempty"`
+	EntityType     string     `json:"entity_type"`
+	EntityID       string     `json:"entity_id"`
+	EntityName     string     `json:"entity_name"`
+	Method         string     `json:"method"`
+	Confidence     float64    `json:"confidence"`
+	Evidence       string     `json:"evidence,omitempty"`
+	Status         string     `json:"status"`
+	Note           string     `json:"note,omitempty"`
+	ReviewedBy     string     `json:"reviewed_by,omitempty"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// EntityLinkReview is the body of PATCH /api/admin/entity-links/:id
+type EntityLinkReview struct {
+	Status string `json:"status"`
+	Note   string `json:"note"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// FeedEntry is one item of a syndication feed. Key identifies the underlying record and stays
+// stable across ETL runs; Path is the API resource with the details.
+type FeedEntry struct {
+	Kind     string
+	Key      string
+	Title    string
+	Summary  string
+	Path     string
+	Category string
+	Updated  time.Time
+}
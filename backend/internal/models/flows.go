@@ -0,0 +1,27 @@
+package models
+
+// FlowDiagram is money flowing through tiers of entities (e.g. party → sector → company), in
+// the nodes/links shape of Sankey and chord diagram libraries such as d3-sankey. Links join
+// nodes of consecutive tiers by ID.
+type FlowDiagram struct {
+	Tiers []string   `json:"tiers"`
+	Nodes []FlowNode `json:"nodes"`
+	Links []FlowLink `json:"links"`
+	Total Money      `json:"total"`
+}
+
+// FlowNode is an entity of one tier. Entities outside the largest of their tier are merged
+// into a single "Outros" node whose ID ends in _other.
+type FlowNode struct {
+	ID    string `json:"id"` // tier:key, e.g. party:PT, sector:G, company:12345678000199
+	Name  string `json:"name"`
+	Tier  int    `json:"tier"`
+	Value Money  `json:"value"`
+}
+
+// FlowLink is the amount flowing from a node to one in the next tier
+type FlowLink struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Value  Money  `json:"value"`
+}
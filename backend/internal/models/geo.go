@@ -0,0 +1,31 @@
+package models
+
+import "encoding/json"
+
+// FeatureCollection is a GeoJSON (RFC 7946) feature collection
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Feature is one IBGE area. Geometry is the stored GeoJSON geometry, or null when the IBGE
+// malhas API had none for the area.
+type Feature struct {
+	Type       string          `json:"type"`
+	ID         string          `json:"id"`
+	Geometry   json.RawMessage `json:"geometry"`
+	Properties AreaSpending    `json:"properties"`
+}
+
+// AreaSpending is what was spent in an area: expenses paid to vendors registered there and
+// amendments paid to it, in reais
+type AreaSpending struct {
+	IBGECode       string `json:"ibge_code"`
+	Name           string `json:"nome"`
+	UF             string `json:"uf"`
+	Expenses       Money  `json:"despesas"`
+	ExpenseCount   int    `json:"despesas_count"`
+	Amendments     Money  `json:"emendas"`
+	AmendmentCount int    `json:"emendas_count"`
+	Total          Money  `json:"total"`
+}
@@ -0,0 +1,38 @@
+package models
+
+// IngestRow is one row of a bulk ingest payload: the payload line it starts on and its column
+// values, nil for NULL. Error is set instead when the line could not be parsed.
+type IngestRow struct {
+	Line   int
+	Values map[string]*string
+	Error  string
+}
+
+// IngestRowError is why one payload row was not ingested
+type IngestRowError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// IngestResult reports a bulk ingest: rows created and updated (or that would be, on a dry
+// run) and the ones that failed. Errors lists the first MaxIngestErrors failures.
+type IngestResult struct {
+	Entity  string           `json:"entity"`
+	DryRun  bool             `json:"dry_run"`
+	Rows    int              `json:"rows"`
+	Created int              `json:"created"`
+	Updated int              `json:"updated"`
+	Failed  int              `json:"failed"`
+	Errors  []IngestRowError `json:"errors,omitempty"`
+}
+
+// MaxIngestErrors caps the row errors an ingest response lists
+const MaxIngestErrors = 1000
+
+// AddError records a failed row
+func (r *IngestResult) AddError(line int, err string) {
+	r.Failed++
+	if len(r.Errors) < MaxIngestErrors {
+		r.Errors = append(r.Errors, IngestRowError{Line: line, Error: err})
+	}
+}
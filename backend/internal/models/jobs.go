@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Queued job kinds
+const (
+	JobETL            = "etl"
+	JobScoreRecompute = "score_recompute"
+	JobExport         = "export"
+	JobNetworkLayout  = "network_layout"
+	JobSearchReindex  = "search_reindex"
+)
+
+// Queued job statuses
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobDone      = "done"
+	JobFailed    = "failed"
+	JobCancelled = "cancelled"
+)
+
+// JobStatuses are the states a queued job moves through
+var JobStatuses = []string{JobQueued, JobRunning, JobDone, JobFailed, JobCancelled}
+
+// DefaultJobAttempts is how many times a job runs before it is marked failed
+const DefaultJobAttempts = 3
+
+// Job is one entry of the job queue. Stage, Done, Total and Percent are the progress of the
+// current run as of its last heartbeat; while it runs, TaskID follows it live under
+// /api/progress. StartedAt, FinishedAt and DurationSeconds describe the latest run; Error is
+// the latest failure, kept while a retry is pending.
+type Job struct {
+	ID              int64           `json:"id"`
+	Kind            string          `json:"kind"`
+	Payload         json.RawMessage `json:"payload,omitempty"`
+	Status          string          `json:"status"`
+	Attempts        int             `json:"attempts"`
+	MaxAttempts     int             `json:"max_attempts"`
+	RequestedBy     string          `json:"requested_by,omitempty"`
+	TaskID          string          `json:"task_id,omitempty"`
+	Stage           string          `json:"stage,omitempty"`
+	Done            int64           `json:"done"`
+	Total           int64           `json:"total"`
+	Percent         float64         `json:"percent"`
+	Error           string          `json:"error,omitempty"`
+	Result          json.RawMessage `json:"result,omitempty"`
+	RunAfter        time.Time       `json:"run_after"`
+	CreatedAt       time.Time       `json:"created_at"`
+	StartedAt       *time.Time      `json:"started_at,omitempty"`
+	FinishedAt      *time.Time      `json:"finished_at,omitempty"`
+	DurationSeconds *float64        `json:"duration_seconds,omitempty"`
+}
+
+// JobRequest is the body of POST /api/admin/jobs
+type JobRequest struct {
+	Kind        string          `json:"kind"`
+	Payload     json.RawMessage `json:"payload"`
+	MaxAttempts int             `json:"max_attempts"`
+}
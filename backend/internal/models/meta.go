@@ -0,0 +1,21 @@
+package models
+
+// EnumValue is one valid value of a filter. Count is how many records have it, when counted:
+// politicians for UFs and parties, sanctions for sanction types, CEAP expenses for expense
+// categories and entities for node types.
+type EnumValue struct {
+	Value     string `json:"value"`
+	Label     string `json:"label,omitempty"`
+	Count     *int   `json:"count,omitempty"`
+	Estimated bool   `json:"estimated,omitempty"`
+}
+
+// Enums are the values frontends offer in filter dropdowns
+type Enums struct {
+	NodeTypes         []EnumValue `json:"node_types"`
+	ConnectionTypes   []EnumValue `json:"connection_types"`
+	SanctionTypes     []EnumValue `json:"sanction_types"`
+	ExpenseCategories []EnumValue `json:"expense_categories"`
+	UFs               []EnumValue `json:"ufs"`
+	Parties           []EnumValue `json:"parties"`
+}
@@ -1,22 +1,49 @@
 package models
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
 // Politician represents a politician entity
 type Politician struct {
-	ID                       int       `json:"id" db:"id"`
-	Nome                     string    `json:"nome" db:"nome"`
-	CPF                      string    `json:"cpf" db:"cpf"`
-	UF                       string    `json:"uf" db:"uf"`
-	SiglaPartido             string    `json:"sigla_partido" db:"sigla_partido"`
-	UltimoStatusSituacao     string    `json:"ultimo_status_situacao" db:"ultimo_status_situacao"`
-	UltimoStatusEmail        string    `json:"ultimo_status_email" db:"ultimo_status_email"`
-	CorruptionScore          int       `json:"corruption_score"`
-	FinancialRecordsCount    int       `json:"financial_records_count"`
-	CreatedAt                time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt                time.Time `json:"updated_at" db:"updated_at"`
+	ID                    int       `json:"id" db:"id"`
+	PublicID              string    `json:"public_id,omitempty"` // stable across reloads, unlike id
+	Slug                  string    `json:"slug,omitempty"`
+	Nome                  string    `json:"nome" db:"nome"`
+	CPF                   string    `json:"cpf" db:"cpf"`
+	UF                    string    `json:"uf" db:"uf"`
+	SiglaPartido          string    `json:"sigla_partido" db:"sigla_partido"`
+	UltimoStatusSituacao  string    `json:"ultimo_status_situacao" db:"ultimo_status_situacao"`
+	UltimoStatusEmail     string    `json:"ultimo_status_email" db:"ultimo_status_email"`
+	CorruptionScore       int       `json:"corruption_score"`
+	FinancialRecordsCount int       `json:"financial_records_count"`
+	URLFoto               string    `json:"url_foto,omitempty" db:"url_foto"`
+	DataNascimento        string    `json:"data_nascimento,omitempty" db:"birth_date"`
+	Escolaridade          string    `json:"escolaridade,omitempty" db:"education_level"`
+	Profissao             string    `json:"profissao,omitempty" db:"occupation"`
+	SessoesPlenario       int       `json:"sessoes_plenario" db:"plenary_sessions_total"`
+	PresencasPlenario     int       `json:"presencas_plenario" db:"plenary_sessions_present"`
+	TaxaAusencia          *float64  `json:"taxa_ausencia" db:"plenary_absence_rate"` // % of deliberative sessions missed; null until attendance is loaded
+	AnoEleicao            int       `json:"ano_eleicao,omitempty" db:"last_election_year"`
+	Votos                 *int      `json:"votos" db:"last_election_votes"` // nominal votes in the latest election; null until results are loaded
+	Eleito                *bool     `json:"eleito" db:"last_election_elected"`
+	Coligacao             string    `json:"coligacao,omitempty" db:"last_election_coalition"`
+	GastoCampanha         *Money    `json:"gasto_campanha" db:"last_election_spending"` // campaign expenses paid in the latest election
+	CreatedAt             time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// PoliticianKey is what politician routes accept in place of the numeric ID: the public ID
+// and slug assigned to the politician's CPF, and the name, party and state slugs are made of
+type PoliticianKey struct {
+	ID           int    `json:"id"`
+	Nome         string `json:"nome"`
+	SiglaPartido string `json:"sigla_partido"`
+	UF           string `json:"uf"`
+	PublicID     string `json:"public_id"`
+	Slug         string `json:"slug"`
 }
 
 // Party represents a political party
@@ -38,13 +65,74 @@ type Party struct {
 
 // Company represents a company/vendor entity
 type Company struct {
-	ID               string  `json:"id" db:"cnpj_cpf"`
-	CNPJ             string  `json:"cnpj" db:"cnpj_cpf"`
-	NomeEmpresa      string  `json:"nome_empresa" db:"nome_empresa"`
-	TransactionCount int     `json:"transaction_count"`
-	TotalValue       float64 `json:"total_value"`
-	CreatedAt        time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+	ID                 string    `json:"id" db:"cnpj_cpf"`
+	CNPJ               string    `json:"cnpj" db:"cnpj_cpf"`
+	NomeEmpresa        string    `json:"nome_empresa" db:"nome_empresa"`
+	TransactionCount   int       `json:"transaction_count"`
+	TotalValue         Money     `json:"total_value"`
+	TotalValueAdjusted *Money    `json:"total_value_adjusted,omitempty"`
+	CNPJRoot           string    `json:"cnpj_root,omitempty"`
+	CNAE               string    `json:"cnae,omitempty"`
+	CNAEDescription    string    `json:"cnae_description,omitempty"`
+	Sector             string    `json:"sector,omitempty"`
+	SectorName         string    `json:"sector_name,omitempty"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CompanyGroup is a corporate entity: the matriz and filiais sharing an 8-digit CNPJ root
+type CompanyGroup struct {
+	ID               string    `json:"id"`
+	CNPJRoot         string    `json:"cnpj_root"`
+	NomeEmpresa      string    `json:"nome_empresa"`
+	BranchCount      int       `json:"branch_count"`
+	TransactionCount int       `json:"transaction_count"`
+	TotalValue       Money     `json:"total_value"`
+	Branches         []Company `json:"branches,omitempty"`
+}
+
+// Topic is a subject politicians talk about in plenary speeches, from the Câmara's keywords or
+// extracted from the speech summary
+type Topic struct {
+	Slug        string `json:"slug"`
+	Topic       string `json:"topic"`
+	Speeches    int    `json:"speeches"`
+	Politicians int    `json:"politicians"`
+}
+
+// PoliticianTopic is how often one politician spoke about a topic
+type PoliticianTopic struct {
+	Slug          string `json:"slug"`
+	Topic         string `json:"topic"`
+	Speeches      int    `json:"speeches"`
+	FirstSpokenAt string `json:"first_spoken_at"`
+	LastSpokenAt  string `json:"last_spoken_at"`
+}
+
+// Front is a frente parlamentar: a cross-party caucus on a policy area, per legislature
+type Front struct {
+	ID            int    `json:"id"`
+	Titulo        string `json:"titulo"`
+	LegislaturaID int    `json:"legislatura_id"`
+	MemberCount   int    `json:"member_count"`
+}
+
+// FrontMember is a politician in a frente parlamentar
+type FrontMember struct {
+	PoliticianID    int    `json:"politician_id"`
+	Nome            string `json:"nome"`
+	SiglaPartido    string `json:"sigla_partido"`
+	UF              string `json:"uf"`
+	CorruptionScore int    `json:"corruption_score"`
+}
+
+// FrontPeer is a politician sharing frentes parlamentares with another, a soft tie that
+// crosses party lines
+type FrontPeer struct {
+	PoliticianID int    `json:"politician_id"`
+	Nome         string `json:"nome"`
+	SiglaPartido string `json:"sigla_partido"`
+	SharedFronts int    `json:"shared_fronts"`
 }
 
 // Sanction represents a government sanction
@@ -53,90 +141,571 @@ type Sanction struct {
 	TipoSancao       string    `json:"tipo_sancao" db:"tipo_sancao"`
 	CNPJ             string    `json:"cnpj" db:"cnpj"`
 	CPF              string    `json:"cpf" db:"cpf"`
-	ValorMulta       float64   `json:"valor_multa" db:"valor_multa"`
+	ValorMulta       Money     `json:"valor_multa" db:"valor_multa"`
 	DataInicioSancao string    `json:"data_inicio_sancao" db:"data_inicio_sancao"`
+	DataFimSancao    string    `json:"data_fim_sancao,omitempty" db:"data_fim_sancao"`
+	Ativa            bool      `json:"ativa" db:"ativa"`
 	CreatedAt        time.Time `json:"created_at" db:"created_at"`
 }
 
+// AssetDeclaration is a politician's TSE declaration of assets (bens) for one election, by
+// category, with the change since their previous declaration
+type AssetDeclaration struct {
+	Year                int             `json:"year"`
+	ElectionYear        int             `json:"election_year,omitempty"`
+	AssetCount          int             `json:"asset_count"`
+	TotalDeclared       Money           `json:"total_declared"`
+	TotalAdjusted       *Money          `json:"total_declared_adjusted,omitempty"`
+	RealEstate          Money           `json:"real_estate"`
+	Vehicles            Money           `json:"vehicles"`
+	Investments         Money           `json:"investments"`
+	Business            Money           `json:"business"`
+	CashDeposits        Money           `json:"cash_deposits"`
+	Other               Money           `json:"other"`
+	PreviousYear        int             `json:"previous_year,omitempty"`
+	GrowthValue         *Money          `json:"growth_value,omitempty"`
+	GrowthPct           *float64        `json:"growth_pct,omitempty"`
+	AnnualizedGrowthPct *float64        `json:"annualized_growth_pct,omitempty"`
+	UnusualGrowth       bool            `json:"unusual_growth"`
+	Items               []DeclaredAsset `json:"items,omitempty"`
+}
+
+// DeclaredAsset is one item of an asset declaration
+type DeclaredAsset struct {
+	Sequence    int    `json:"sequence"`
+	TypeCode    int    `json:"type_code"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Value       Money  `json:"value"`
+}
+
+// PoliticianAssets is a politician's asset declarations, oldest first, with growth from the
+// first to the latest
+type PoliticianAssets struct {
+	PoliticianID   int                `json:"politician_id"`
+	Declarations   []AssetDeclaration `json:"declarations"`
+	TotalGrowthPct *float64           `json:"total_growth_pct,omitempty"`
+	UnusualGrowth  bool               `json:"unusual_growth"`
+}
+
+// TCURuling is a TCU acórdão naming at least one company or politician in the dataset
+type TCURuling struct {
+	ID          int    `json:"id"`
+	Key         string `json:"key"`
+	Numero      int    `json:"numero"`
+	Ano         int    `json:"ano"`
+	Colegiado   string `json:"colegiado"`
+	Relator     string `json:"relator,omitempty"`
+	DataSessao  string `json:"data_sessao,omitempty"`
+	Titulo      string `json:"titulo"`
+	Sumario     string `json:"sumario,omitempty"`
+	URL         string `json:"url,omitempty"`
+	DocumentURL string `json:"document_url,omitempty"`
+	PartyCount  int    `json:"party_count"`
+}
+
+// TCURulingParty is a company or politician named in a ruling. Politicians are identified by
+// ID, never by the CPF the ruling was matched on.
+type TCURulingParty struct {
+	EntityType   string `json:"entity_type"` // company or politician
+	CNPJ         string `json:"cnpj,omitempty"`
+	PoliticianID int    `json:"politician_id,omitempty"`
+	Nome         string `json:"nome"`
+}
+
+// TCURulingDetail is a ruling with the parties it names
+type TCURulingDetail struct {
+	TCURuling
+	Parties    []TCURulingParty `json:"parties"`
+	Provenance *Provenance      `json:"provenance,omitempty"`
+}
+
+// PublicLoan is a financing operation of a public bank (BNDES) with a company in the dataset
+type PublicLoan struct {
+	ID                int64  `json:"id"`
+	Lender            string `json:"lender"`
+	CNPJ              string `json:"cnpj"`
+	Cliente           string `json:"cliente"`
+	UF                string `json:"uf,omitempty"`
+	Municipio         string `json:"municipio,omitempty"`
+	NumeroContrato    string `json:"numero_contrato,omitempty"`
+	DataContratacao   string `json:"data_contratacao,omitempty"`
+	ValorContratado   Money  `json:"valor_contratado"`
+	ValorDesembolsado Money  `json:"valor_desembolsado"`
+	Produto           string `json:"produto,omitempty"`
+	Instrumento       string `json:"instrumento,omitempty"`
+	FormaApoio        string `json:"forma_apoio,omitempty"` // direta, or indireta through AgenteFinanceiro
+	AgenteFinanceiro  string `json:"agente_financeiro,omitempty"`
+	Situacao          string `json:"situacao,omitempty"`
+}
+
+// PublicLoanTotals sums a company's public loans
+type PublicLoanTotals struct {
+	Count             int    `json:"count"`
+	ValorContratado   Money  `json:"valor_contratado"`
+	ValorDesembolsado Money  `json:"valor_desembolsado"`
+	FirstDate         string `json:"first_date,omitempty"`
+	LastDate          string `json:"last_date,omitempty"`
+}
+
+// PublicBank is a public lender with its loans to companies in the dataset
+type PublicBank struct {
+	Slug              string `json:"slug"`
+	Nome              string `json:"nome"`
+	LoanCount         int    `json:"loan_count"`
+	CompanyCount      int    `json:"company_count"`
+	ValorDesembolsado Money  `json:"valor_desembolsado"`
+}
+
 // Connection represents a network connection between entities
 type Connection struct {
-	SourceID   string  `json:"source_id"`
-	TargetID   string  `json:"target_id"`
-	Type       string  `json:"type"`
-	Value      float64 `json:"value"`
-	Strength   float64 `json:"strength"`
-	Data       interface{} `json:"data,omitempty"`
+	SourceID  string      `json:"source_id"`
+	TargetID  string      `json:"target_id"`
+	Type      string      `json:"type"`
+	Value     Money       `json:"value"`
+	Strength  float64     `json:"strength"`
+	StartDate string      `json:"start_date,omitempty"` // time-ranged edges (party_switch)
+	EndDate   string      `json:"end_date,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
 }
 
 // NetworkResponse represents the complete network data
 type NetworkResponse struct {
-	Nodes []interface{} `json:"nodes"`
+	Nodes []NetworkNode `json:"nodes"`
 	Links []Connection  `json:"links"`
 	Stats NetworkStats  `json:"stats"`
 }
 
-// NetworkStats represents network statistics
+// NetworkStats represents network statistics. Estimated names the counts taken from planner
+// statistics rather than counted (see database.CountRows).
 type NetworkStats struct {
-	TotalNodes      int `json:"total_nodes"`
-	TotalLinks      int `json:"total_links"`
-	Politicians     int `json:"politicians"`
-	Parties         int `json:"parties"`
-	Companies       int `json:"companies"`
-	Sanctions       int `json:"sanctions"`
-	LastUpdated     time.Time `json:"last_updated"`
-	ProcessingTime  string    `json:"processing_time"`
+	TotalNodes       int       `json:"total_nodes"`
+	TotalLinks       int       `json:"total_links"`
+	Politicians      int       `json:"politicians"`
+	Parties          int       `json:"parties"`
+	Companies        int       `json:"companies"`
+	CompanyGroups    int       `json:"company_groups"`
+	Sanctions        int       `json:"sanctions"`
+	FinancialRecords int       `json:"financial_records"`
+	Estimated        []string  `json:"estimated,omitempty"`
+	LastUpdated      time.Time `json:"last_updated"`
+	ProcessingTime   string    `json:"processing_time"`
 }
 
 // FinancialRecord represents a financial transaction
 type FinancialRecord struct {
-	ID           int     `json:"id" db:"id"`
-	PoliticianID int     `json:"politician_id" db:"politician_id"`
-	CNPJ         string  `json:"cnpj" db:"cnpj_cpf"`
-	Valor        float64 `json:"valor" db:"valor"`
-	DataDoc      string  `json:"data_doc" db:"data_doc"`
-	NomeEmpresa  string  `json:"nome_empresa" db:"nome_empresa"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	ID            int       `json:"id" db:"id"`
+	PoliticianID  int       `json:"politician_id" db:"politician_id"`
+	CNPJ          string    `json:"cnpj" db:"cnpj_cpf"`
+	Valor         Money     `json:"valor" db:"valor"`
+	ValorAjustado *Money    `json:"valor_ajustado,omitempty"`
+	DataDoc       string    `json:"data_doc" db:"data_doc"`
+	NomeEmpresa   string    `json:"nome_empresa" db:"nome_empresa"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// IPCAIndex is one month of the IPCA index (IBGE, December 1993 = 100)
+type IPCAIndex struct {
+	Month       string `json:"month"` // YYYY-MM
+	IndexNumber string `json:"index_number"`
 }
 
 // PartyMembership represents party membership relationship
 type PartyMembership struct {
-	ID            int    `json:"id" db:"id"`
-	PartyID       int    `json:"party_id" db:"party_id"`
-	DeputyID      int    `json:"deputy_id" db:"deputy_id"`
-	DeputyName    string `json:"deputy_name" db:"deputy_name"`
-	LegislaturaID int    `json:"legislatura_id" db:"legislatura_id"`
-	Status        string `json:"status" db:"status"`
+	ID            int       `json:"id" db:"id"`
+	PartyID       int       `json:"party_id" db:"party_id"`
+	DeputyID      int       `json:"deputy_id" db:"deputy_id"`
+	DeputyName    string    `json:"deputy_name" db:"deputy_name"`
+	LegislaturaID int       `json:"legislatura_id" db:"legislatura_id"`
+	Status        string    `json:"status" db:"status"`
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
 }
 
-// NetworkNode represents a generic network node
+// PoliticianDetail is a politician with optionally embedded related collections
+type PoliticianDetail struct {
+	Politician
+	Expenses    []FinancialRecord `json:"expenses,omitempty"`
+	Sanctions   []Sanction        `json:"sanctions,omitempty"`
+	Memberships []PartyMembership `json:"memberships,omitempty"`
+	Wikidata    *WikidataLink     `json:"wikidata,omitempty"`
+	Fronts      []Front           `json:"fronts,omitempty"`
+	FrontPeers  []FrontPeer       `json:"front_peers,omitempty"`
+	Relatives   []FamilyRelation  `json:"relatives,omitempty"`
+	TCURulings  []TCURuling       `json:"tcu_rulings,omitempty"`
+	Provenance  *Provenance       `json:"provenance,omitempty"`
+}
+
+// FrontDetail is a frente parlamentar with its members
+type FrontDetail struct {
+	Front
+	Members []FrontMember `json:"members,omitempty"`
+}
+
+// PartyDetail is a party with member aggregates and optionally embedded collections
+type PartyDetail struct {
+	Party
+	Summary       PartySummary  `json:"summary"`
+	Members       []PartyMember `json:"members,omitempty"`
+	FormerMembers []PartyMember `json:"former_members,omitempty"`
+	Funds         []PartyFund   `json:"funds,omitempty"`
+	Provenance    *Provenance   `json:"provenance,omitempty"`
+}
+
+// CompanyDetail is the company dossier: who paid the company, its sanctions, the TCU rulings
+// naming it, its public loans and its owners
+type CompanyDetail struct {
+	Company
+	Payers      []CompanyPayer   `json:"payers"`
+	Sanctions   []Sanction       `json:"sanctions"`
+	TCURulings  []TCURuling      `json:"tcu_rulings"`
+	PublicLoans []PublicLoan     `json:"public_loans"`
+	LoanTotals  PublicLoanTotals `json:"loan_totals"`
+	Owners      []CompanyOwner   `json:"owners,omitempty"`
+	Provenance  *Provenance      `json:"provenance,omitempty"`
+}
+
+// CompanyPayer is a politician's payments to a company
+type CompanyPayer struct {
+	PoliticianID     int    `json:"politician_id"`
+	Nome             string `json:"nome"`
+	SiglaPartido     string `json:"sigla_partido,omitempty"`
+	UF               string `json:"uf,omitempty"`
+	TransactionCount int    `json:"transaction_count"`
+	TotalValue       Money  `json:"total_value"`
+	FirstPayment     string `json:"first_payment,omitempty"`
+	LastPayment      string `json:"last_payment,omitempty"`
+}
+
+// CompanyOwner is a partner or administrator from the Receita QSA. Documents of individuals
+// come masked by the Receita itself (***123456**). PoliticianID is set when entity resolution
+// confirmed the partner is a tracked politician.
+type CompanyOwner struct {
+	Nome         string `json:"nome"`
+	Tipo         string `json:"tipo,omitempty"`
+	Documento    string `json:"documento,omitempty"`
+	Qualificacao string `json:"qualificacao,omitempty"`
+	DataEntrada  string `json:"data_entrada,omitempty"`
+	FaixaEtaria  string `json:"faixa_etaria,omitempty"`
+	PoliticianID *int   `json:"politician_id,omitempty"`
+}
+
+// PartySummary aggregates a party's membership. Spending and scores cover current members;
+// spending is their CEAP expenses.
+type PartySummary struct {
+	CurrentMembers          int     `json:"current_members"`
+	FormerMembers           int     `json:"former_members"`
+	MemberExpenseCount      int     `json:"member_expense_count"`
+	MemberSpending          Money   `json:"member_spending"`
+	AverageCorruptionScore  float64 `json:"average_corruption_score"`
+	FundoPartidarioReceived Money   `json:"fundo_partidario_received"`
+	FEFCReceived            Money   `json:"fefc_received"`
+}
+
+// PartyMember is a deputy's latest membership in a party
+type PartyMember struct {
+	PoliticianID    int    `json:"politician_id"`
+	DeputyID        int    `json:"deputy_id"`
+	Nome            string `json:"nome"`
+	LegislaturaID   int    `json:"legislatura_id"`
+	Status          string `json:"status"`
+	DataInicio      string `json:"data_inicio,omitempty"`
+	DataFim         string `json:"data_fim,omitempty"`
+	CorruptionScore int    `json:"corruption_score"`
+}
+
+// PartyFund is a Fundo Partidário or FEFC distribution; Month 0 is a yearly total
+type PartyFund struct {
+	Year       int    `json:"year"`
+	Month      int    `json:"month,omitempty"`
+	FundType   string `json:"fund_type"`
+	Amount     Money  `json:"amount"`
+	DataSource string `json:"data_source"`
+}
+
+// PartySwitch is a politician leaving one party for another. InPartyWindow marks switches
+// during the "janela partidária", when deputies may change party without losing the mandate.
+type PartySwitch struct {
+	PoliticianID  int    `json:"politician_id"`
+	Nome          string `json:"nome"`
+	FromParty     string `json:"from_party"`
+	ToParty       string `json:"to_party"`
+	FromPartyID   int    `json:"from_party_id,omitempty"`
+	ToPartyID     int    `json:"to_party_id,omitempty"`
+	SwitchDate    string `json:"switch_date"`
+	LegislaturaID int    `json:"legislatura_id,omitempty"`
+	Event         string `json:"event,omitempty"`
+	ElectionYear  int    `json:"election_year"`
+	InPartyWindow bool   `json:"in_party_window"`
+}
+
+// NepotismFlag is a staff member sharing uncommon family names with the politician who hired
+// them (same_office) or with another politician of that state (cross_office). It is a lead for
+// review, not a finding: surnames are the only evidence, as staff CPFs aren't published.
+type NepotismFlag struct {
+	StaffID        int      `json:"staff_id"`
+	StaffName      string   `json:"staff_name"`
+	StaffPosition  string   `json:"staff_position,omitempty"`
+	StartDate      string   `json:"start_date"`
+	EndDate        string   `json:"end_date,omitempty"`
+	EmployerID     int      `json:"employer_politician_id"`
+	EmployerName   string   `json:"employer_nome"`
+	RelatedID      int      `json:"related_politician_id"`
+	RelatedName    string   `json:"related_nome"`
+	MatchType      string   `json:"match_type"`
+	SharedSurnames []string `json:"shared_surnames"`
+	Score          float64  `json:"score"`
+}
+
+// NodeType discriminates the entity carried by a NetworkNode
+type NodeType string
+
+const (
+	NodeTypePolitician   NodeType = "politician"
+	NodeTypeParty        NodeType = "party"
+	NodeTypeCompany      NodeType = "company"
+	NodeTypeCompanyGroup NodeType = "company_group"
+	NodeTypeSanction     NodeType = "sanction"
+	NodeTypeTopic        NodeType = "topic"
+	NodeTypeFront        NodeType = "front"
+	NodeTypeTCURuling    NodeType = "tcu_ruling"
+	NodeTypePublicBank   NodeType = "public_bank"
+)
+
+// NodeTypes lists every node type, for /api/meta/enums
+var NodeTypes = []NodeType{
+	NodeTypePolitician, NodeTypeParty, NodeTypeCompany, NodeTypeCompanyGroup, NodeTypeSanction,
+	NodeTypeTopic, NodeTypeFront, NodeTypeTCURuling, NodeTypePublicBank,
+}
+
+// ConnectionTypes lists the Type of every connection the network builds, for /api/meta/enums
+var ConnectionTypes = []string{
+	"party_membership", "party_switch", "financial", "financial_group", "branch_of", "sanction",
+	"family", "possible_nepotism", "front_member", "speaks_about", "tcu_ruling", "public_loan",
+}
+
+// NodeData is implemented by every entity that can back a network node
+type NodeData interface {
+	NodeType() NodeType
+}
+
+// NodeType implements NodeData
+func (Politician) NodeType() NodeType { return NodeTypePolitician }
+
+// NodeType implements NodeData
+func (Party) NodeType() NodeType { return NodeTypeParty }
+
+// NodeType implements NodeData
+func (Company) NodeType() NodeType { return NodeTypeCompany }
+
+// NodeType implements NodeData
+func (CompanyGroup) NodeType() NodeType { return NodeTypeCompanyGroup }
+
+// NodeType implements NodeData
+func (Sanction) NodeType() NodeType { return NodeTypeSanction }
+
+// NodeType implements NodeData
+func (Topic) NodeType() NodeType { return NodeTypeTopic }
+
+// NodeType implements NodeData
+func (Front) NodeType() NodeType { return NodeTypeFront }
+
+// NodeType implements NodeData
+func (TCURuling) NodeType() NodeType { return NodeTypeTCURuling }
+
+// NodeType implements NodeData
+func (PublicBank) NodeType() NodeType { return NodeTypePublicBank }
+
+// NetworkNode represents a typed network node
 type NetworkNode struct {
-	ID              string      `json:"id"`
-	Type            string      `json:"type"`
-	Name            string      `json:"name"`
-	Size            float64     `json:"size"`
-	Color           string      `json:"color"`
-	CorruptionScore int         `json:"corruption_score,omitempty"`
-	Data            interface{} `json:"data"`
+	ID              string   `json:"id"`
+	Type            NodeType `json:"type"`
+	Name            string   `json:"name"`
+	Size            float64  `json:"size"`
+	Color           string   `json:"color"`
+	CorruptionScore int      `json:"corruption_score,omitempty"`
+	ImageURL        string   `json:"image_url,omitempty"`
+	Data            NodeData `json:"data"`
+}
+
+// NewNetworkNode builds a node whose ID and Type are derived from its data
+func NewNetworkNode(key string, name string, size float64, color string, data NodeData) NetworkNode {
+	return NetworkNode{
+		ID:    string(data.NodeType()) + "_" + key,
+		Type:  data.NodeType(),
+		Name:  name,
+		Size:  size,
+		Color: color,
+		Data:  data,
+	}
 }
 
-// APIResponse represents a standard API response
+// UnmarshalJSON decodes a node with its data as the entity its type names, so networks survive
+// a JSON round trip (the cache keeps them encoded)
+func (n *NetworkNode) UnmarshalJSON(data []byte) error {
+	type plain NetworkNode
+	var raw struct {
+		plain
+		Data json.RawMessage `json:"data"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	*n = NetworkNode(raw.plain)
+
+	switch n.Type {
+	case NodeTypePolitician:
+		n.Data, err = decodeNodeData[Politician](raw.Data)
+	case NodeTypeParty:
+		n.Data, err = decodeNodeData[Party](raw.Data)
+	case NodeTypeCompany:
+		n.Data, err = decodeNodeData[Company](raw.Data)
+	case NodeTypeCompanyGroup:
+		n.Data, err = decodeNodeData[CompanyGroup](raw.Data)
+	case NodeTypeSanction:
+		n.Data, err = decodeNodeData[Sanction](raw.Data)
+	case NodeTypeTopic:
+		n.Data, err = decodeNodeData[Topic](raw.Data)
+	case NodeTypeFront:
+		n.Data, err = decodeNodeData[Front](raw.Data)
+	case NodeTypeTCURuling:
+		n.Data, err = decodeNodeData[TCURuling](raw.Data)
+	case NodeTypePublicBank:
+		n.Data, err = decodeNodeData[PublicBank](raw.Data)
+	default:
+		return fmt.Errorf("unknown node type %q", n.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("node %s: %w", n.ID, err)
+	}
+	return nil
+}
+
+func decodeNodeData[T NodeData](data json.RawMessage) (NodeData, error) {
+	var value T
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// APIResponse represents a standard API response. Degraded marks data served from a stale cache
+// copy while the database is unavailable; DroppedRows counts rows left out of Data because they
+// failed to scan. ErrorID identifies an internal error in the logs and the error tracker.
 type APIResponse struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
-	Count   int         `json:"count,omitempty"`
-	Time    string      `json:"processing_time"`
+	Success     bool              `json:"success"`
+	Data        interface{}       `json:"data,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	ErrorID     string            `json:"error_id,omitempty"`
+	Errors      map[string]string `json:"errors,omitempty"`
+	Count       int               `json:"count,omitempty"`
+	Degraded    bool              `json:"degraded,omitempty"`
+	DroppedRows int               `json:"dropped_rows,omitempty"`
+	NextCursor  string            `json:"next_cursor,omitempty"` // ?cursor= of the next page, on lists that page by cursor
+	Time        string            `json:"processing_time"`
 }
 
 // HealthCheck represents health check response
 type HealthCheck struct {
-	Status      string    `json:"status"`
-	Database    string    `json:"database"`
-	Cache       string    `json:"cache"`
-	Uptime      string    `json:"uptime"`
-	Version     string    `json:"version"`
-	Timestamp   time.Time `json:"timestamp"`
+	Status    string                     `json:"status"`
+	Database  string                     `json:"database"`
+	Cache     string                     `json:"cache"`
+	Uptime    string                     `json:"uptime"`
+	Version   string                     `json:"version"`
+	Timestamp time.Time                  `json:"timestamp"`
+	Checks    map[string]DependencyCheck `json:"checks,omitempty"`
+	Pool      *PoolStats                 `json:"pool,omitempty"`
+	Breaker   *BreakerState              `json:"circuit_breaker,omitempty"`
+}
+
+// BreakerState reports the database circuit breaker: closed, open (connects fail fast) or
+// half_open (the next connect probes the database). Failures counts consecutive failed connects.
+type BreakerState struct {
+	State    string     `json:"state"`
+	Failures int        `json:"failures"`
+	OpenedAt *time.Time `json:"opened_at,omitempty"`
+}
+
+// DependencyCheck is the result of one round trip to a dependency
+type DependencyCheck struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// PoolStats summarizes database connection pool usage
+type PoolStats struct {
+	MaxOpen      int     `json:"max_open"`
+	Open         int     `json:"open"`
+	InUse        int     `json:"in_use"`
+	Idle         int     `json:"idle"`
+	WaitCount    int64   `json:"wait_count"`
+	WaitDuration string  `json:"wait_duration"`
+	Saturation   float64 `json:"saturation"`
+}
+
+// CacheStats reports cache effectiveness since startup. Compressed entries (large values kept as
+// gzipped JSON) take CompressedBytes in memory for UncompressedBytes of JSON.
+type CacheStats struct {
+	Items             int            `json:"items"`
+	Hits              int64          `json:"hits"`
+	Misses            int64          `json:"misses"`
+	HitRatio          float64        `json:"hit_ratio"`
+	Evictions         int64          `json:"evictions"`
+	Deletes           int64          `json:"deletes"`
+	CompressedItems   int            `json:"compressed_items"`
+	CompressedBytes   int            `json:"compressed_bytes"`
+	UncompressedBytes int            `json:"uncompressed_bytes"`
+	Keys              []CacheKeyInfo `json:"keys,omitempty"`
+}
+
+// CacheKeyInfo describes one cached entry. SizeBytes is its JSON size, or for a compressed entry
+// its size in memory, with the JSON size in UncompressedBytes.
+type CacheKeyInfo struct {
+	Key               string     `json:"key"`
+	SizeBytes         int        `json:"size_bytes"`
+	Compressed        bool       `json:"compressed,omitempty"`
+	UncompressedBytes int        `json:"uncompressed_bytes,omitempty"`
+	TTL               string     `json:"ttl,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+}
+
+// DatasetArchive describes a downloadable full-dataset export
+type DatasetArchive struct {
+	Version     string         `json:"version"`
+	Format      string         `json:"format"`
+	FileName    string         `json:"file_name"`
+	DownloadURL string         `json:"download_url"`
+	SizeBytes   int64          `json:"size_bytes"`
+	Rows        map[string]int `json:"rows"`
+	GeneratedAt time.Time      `json:"generated_at"`
+}
+
+// Role is the access level granted by an API key
+type Role string
+
+const (
+	RolePublic     Role = "public"
+	RoleResearcher Role = "researcher"
+	RoleAdmin      Role = "admin"
+)
+
+// RateLimitStatus is the body of a 429: the client's limit, when its window resets (Unix
+// seconds) and how many seconds to wait before retrying
+type RateLimitStatus struct {
+	Limit      int   `json:"limit"`
+	Remaining  int   `json:"remaining"`
+	Reset      int64 `json:"reset"`
+	RetryAfter int   `json:"retry_after"`
+}
+
+// AuditEntry is one row of the audit log
+type AuditEntry struct {
+	ID        int64                  `json:"id"`
+	Actor     string                 `json:"actor"`
+	Role      Role                   `json:"role"`
+	Action    string                 `json:"action"`
+	Resource  string                 `json:"resource"`
+	Payload   map[string]interface{} `json:"payload,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
 }
 
 // QueryParams represents common query parameters
@@ -146,4 +715,5 @@ type QueryParams struct {
 	IncludeStats bool     `form:"include_stats"`
 	NodeTypes    []string `form:"node_types"`
 	MinScore     int      `form:"min_score" binding:"min=0,max=100"`
-}
\ No newline at end of file
+	Fields       []string `form:"fields"`
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is a monetary amount in centavos. The database stores DECIMAL(15,2), so every
+// amount is exact in int64 and sums never accumulate float rounding errors.
+type Money int64
+
+// MoneyFromFloat converts reais to Money, rounding to the nearest centavo
+func MoneyFromFloat(v float64) Money {
+	return Money(math.Round(v * 100))
+}
+
+// ParseMoney parses a decimal string such as "1234.56" or "-0.5". Digits past the
+// second decimal place are rounded half away from zero.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	neg := strings.HasPrefix(s, "-")
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+
+	whole, frac, _ := strings.Cut(digits, ".")
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("invalid money value %q", s)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	reais, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || strings.ContainsAny(whole, "+-") {
+		return 0, fmt.Errorf("invalid money value %q", s)
+	}
+	for _, r := range frac {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid money value %q", s)
+		}
+	}
+
+	frac += "000"
+	centavos, _ := strconv.ParseInt(frac[:2], 10, 64)
+	if frac[2] >= '5' {
+		centavos++
+	}
+	if reais > (math.MaxInt64-centavos)/100 {
+		return 0, fmt.Errorf("money value %q out of range", s)
+	}
+
+	m := Money(reais*100 + centavos)
+	if neg {
+		m = -m
+	}
+	return m, nil
+}
+
+// Float64 returns the amount in reais, for scaling and charting only
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+// String renders the amount in reais with two decimals, e.g. "1234.56"
+func (m Money) String() string {
+	sign := ""
+	v := int64(m)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/100, v%100)
+}
+
+// MarshalJSON renders a JSON number with two decimals, keeping the float-era shape
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a quoted decimal string
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		*m = 0
+		return nil
+	}
+	v, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// Scan reads NUMERIC columns (returned as text by lib/pq); NULL becomes zero
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	case int64:
+		*m = Money(v * 100)
+	case float64:
+		*m = MoneyFromFloat(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+	return nil
+}
+
+func (m *Money) scanString(s string) error {
+	v, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// Value writes the amount as a decimal string so NUMERIC columns stay exact
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
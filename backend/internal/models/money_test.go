@@ -0,0 +1,104 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want Money
+	}{
+		{"1234.56", 123456},
+		{"0.995", 100}, // half-up carries into the reais
+		{"0.994", 99},
+		{"1.005", 101},
+		{"-0.5", -50},
+		{"-1.005", -101}, // half away from zero
+		{"+7.25", 725},
+		{"12", 1200},
+		{"12.", 1200},
+		{".5", 50},
+		{" 3.10 ", 310},
+		{"0", 0},
+		{"92233720368547758.07", math.MaxInt64},
+	} {
+		got, err := ParseMoney(tc.in)
+		if err != nil || got != tc.want {
+			t.Errorf("ParseMoney(%q) = %d, %v, want %d", tc.in, got, err, tc.want)
+		}
+	}
+
+	for _, in := range []string{
+		"", "-", "+", ".", "abc", "1.2x", "1e3", "1,50", "--1", "+-1", "1-2", "0.-5",
+		"92233720368547758.08", // one centavo past MaxInt64
+		"92233720368547758.075",
+		"100000000000000000000",
+	} {
+		if got, err := ParseMoney(in); err == nil {
+			t.Errorf("ParseMoney(%q) = %d, want an error", in, got)
+		}
+	}
+}
+
+func TestMoneyScan(t *testing.T) {
+	for _, tc := range []struct {
+		src  interface{}
+		want Money
+	}{
+		{nil, 0},
+		{[]byte("1234.56"), 123456},
+		{"-0.995", -100},
+		{int64(42), 4200},
+		{2.5, 250},
+	} {
+		m := Money(-1)
+		if err := m.Scan(tc.src); err != nil || m != tc.want {
+			t.Errorf("Scan(%#v) = %d, %v, want %d", tc.src, m, err, tc.want)
+		}
+	}
+
+	for _, src := range []interface{}{"1.2x", []byte(""), true} {
+		var m Money
+		if err := m.Scan(src); err == nil {
+			t.Errorf("Scan(%#v) = %d, want an error", src, m)
+		}
+	}
+}
+
+func TestMoneyJSONRoundTrip(t *testing.T) {
+	for _, m := range []Money{0, 5, -5, 100, -123456, math.MaxInt64} {
+		data, err := json.Marshal(m)
+		if err != nil {
+			t.Fatalf("Marshal(%d): %v", m, err)
+		}
+		var back Money
+		if err := json.Unmarshal(data, &back); err != nil || back != m {
+			t.Errorf("round trip of %d through %s = %d, %v", m, data, back, err)
+		}
+	}
+
+	if data, _ := json.Marshal(Money(-5)); string(data) != "-0.05" {
+		t.Errorf("Marshal(-5) = %s, want -0.05", data)
+	}
+
+	for _, tc := range []struct {
+		in   string
+		want Money
+	}{
+		{`"12.30"`, 1230},
+		{`12.3`, 1230},
+		{`null`, 0},
+	} {
+		m := Money(-1)
+		if err := json.Unmarshal([]byte(tc.in), &m); err != nil || m != tc.want {
+			t.Errorf("Unmarshal(%s) = %d, %v, want %d", tc.in, m, err, tc.want)
+		}
+	}
+	var m Money
+	if err := json.Unmarshal([]byte(`"abc"`), &m); err == nil {
+		t.Errorf(`Unmarshal("abc") = %d, want an error`, m)
+	}
+}
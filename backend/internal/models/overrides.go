@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Override is a curated value for a field of a politician, company or expense. It is set on top
+// of the ingested data, and set again after every ETL run so the next sync doesn't undo it.
+// CorrectionID is the accepted correction it came from, if any.
+type Override struct {
+	ID           int64     `json:"id"`
+	EntityType   string    `json:"entity_type"`
+	EntityID     string    `json:"entity_id"`
+	Field        string    `json:"field"`
+	Value        string    `json:"value"`
+	Reason       string    `json:"reason"`
+	Author       string    `json:"author"`
+	CorrectionID *int64    `json:"correction_id,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// OverrideRequest is the body of POST /api/admin/overrides
+type OverrideRequest struct {
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+	Field      string `json:"field"`
+	Value      string `json:"value"`
+	Reason     string `json:"reason"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Progress task states
+const (
+	ProgressRunning = "running"
+	ProgressDone    = "done"
+	ProgressFailed  = "failed"
+)
+
+// Progress is a snapshot of a long-running build. Done and Total count rows for exports and
+// build stages for network rebuilds; Total is an estimate taken when the task starts.
+type Progress struct {
+	TaskID     string      `json:"task_id"`
+	Kind       string      `json:"kind"`
+	Status     string      `json:"status"`
+	Stage      string      `json:"stage,omitempty"`
+	Rows       int64       `json:"rows"`
+	Done       int64       `json:"done"`
+	Total      int64       `json:"total"`
+	Percent    float64     `json:"percent"`
+	ETASeconds *float64    `json:"eta_seconds"`
+	StartedAt  time.Time   `json:"started_at"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty"`
+	Error      string      `json:"error,omitempty"`
+	Result     interface{} `json:"result,omitempty"`
+	EventsURL  string      `json:"events_url,omitempty"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Provenance resources: what /api/provenance and the detail endpoints can cite sources for
+const (
+	ProvenancePolitician = "politician"
+	ProvenanceParty      = "party"
+	ProvenanceCompany    = "company"
+	ProvenanceSanction   = "sanction"
+	ProvenanceTCURuling  = "tcu_ruling"
+)
+
+// ProvenanceResources lists the resources in the order the API documents them
+var ProvenanceResources = []string{
+	ProvenancePolitician, ProvenanceParty, ProvenanceCompany, ProvenanceSanction, ProvenanceTCURuling,
+}
+
+// Provenance says where a resource's data came from: the stored rows describing the resource
+// itself, and per source how many rows of each related dataset (expenses, assets, sanctions...)
+// back the rest of what the API shows about it
+type Provenance struct {
+	Resource string             `json:"resource"`
+	ID       string             `json:"id"`
+	Records  []ProvenanceRecord `json:"records"`
+	Related  []ProvenanceSource `json:"related,omitempty"`
+}
+
+// ProvenanceRecord is one stored row with the system it came from, its ID there, a link to it
+// when the source publishes one, and when the ETL last fetched it
+type ProvenanceRecord struct {
+	Table          string     `json:"table"`
+	SourceSystem   string     `json:"source_system"`
+	SourceRecordID string     `json:"source_record_id,omitempty"`
+	SourceURL      string     `json:"source_url,omitempty"`
+	FetchedAt      *time.Time `json:"fetched_at,omitempty"`
+}
+
+// ProvenanceSource summarizes the rows of a related dataset that came from one source system
+type ProvenanceSource struct {
+	Table          string     `json:"table"`
+	SourceSystem   string     `json:"source_system"`
+	Rows           int        `json:"rows"`
+	FirstFetchedAt *time.Time `json:"first_fetched_at,omitempty"`
+	LastFetchedAt  *time.Time `json:"last_fetched_at,omitempty"`
+}
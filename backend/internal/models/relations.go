@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// Family relation review states. The matcher suggests; a researcher confirms or rejects.
+const (
+	RelationSuggested = "suggested"
+	RelationConfirmed = "confirmed"
+	RelationRejected  = "rejected"
+)
+
+// Relation sources
+const (
+	RelationSourceHeuristic = "heuristic"
+	RelationSourceManual    = "manual"
+)
+
+// Relationships say what the relative is to the politician. Directed ones have an inverse, so
+// a relation can be stored from either side; "relative" is unspecified kinship.
+var Relationships = map[string]string{
+	"parent":       "child",
+	"child":        "parent",
+	"grandparent":  "grandchild",
+	"grandchild":   "grandparent",
+	"uncle_aunt":   "nephew_niece",
+	"nephew_niece": "uncle_aunt",
+	"sibling":      "sibling",
+	"spouse":       "spouse",
+	"cousin":       "cousin",
+	"in_law":       "in_law",
+	"relative":     "relative",
+}
+
+// FamilyRelation links two politicians who are, or may be, relatives
+type FamilyRelation struct {
+	ID           int64      `json:"id"`
+	PoliticianID int        `json:"politician_id"`
+	Nome         string     `json:"nome"`
+	RelativeID   int        `json:"relative_id"`
+	RelativeNome string     `json:"relative_nome"`
+	Relationship string     `json:"relationship"`
+	Status       string     `json:"status"`
+	Source       string     `json:"source"`
+	Score        *float64   `json:"score,omitempty"`
+	Evidence     string     `json:"evidence,omitempty"`
+	Note         string     `json:"note,omitempty"`
+	ReviewedBy   string     `json:"reviewed_by,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// FamilyRelationRequest is the body of POST /api/relations - a relation known to a researcher,
+// stored as confirmed
+type FamilyRelationRequest struct {
+	PoliticianID int    `json:"politician_id"`
+	RelativeID   int    `json:"relative_id"`
+	Relationship string `json:"relationship"`
+	Note         string `json:"note"`
+}
+
+// FamilyRelationReview is the body of PATCH /api/relations/:id
+type FamilyRelationReview struct {
+	Status       string `json:"status"`
+	Relationship string `json:"relationship"`
+	Note         string `json:"note"`
+}
@@ -0,0 +1,86 @@
+package models
+
+import "time"
+
+// PoliticianReport is the dossier of GET /api/reports/politician/:id: everything a journalist
+// needs about one politician in a single document, served as JSON, HTML or PDF
+type PoliticianReport struct {
+	Politician               Politician              `json:"politician"`
+	Spending                 ReportSpending          `json:"spending"`
+	SanctionedVendorPayments []SanctionedPayment     `json:"sanctioned_vendor_payments"`
+	Score                    ScoreBreakdown          `json:"score"`
+	Connections              ReportConnectionSummary `json:"connections"`
+	DatasetVersion           int                     `json:"dataset_version,omitempty"`
+	GeneratedAt              time.Time               `json:"generated_at"`
+}
+
+// ReportSpending sums a politician's expenses, per year and for the vendors paid the most
+type ReportSpending struct {
+	Total      Money          `json:"total"`
+	Records    int            `json:"records"`
+	Vendors    int            `json:"vendors"`
+	FirstDate  string         `json:"first_date,omitempty"`
+	LastDate   string         `json:"last_date,omitempty"`
+	ByYear     []YearAmount   `json:"by_year"`
+	TopVendors []ReportVendor `json:"top_vendors"`
+}
+
+// YearAmount is a total of one year
+type YearAmount struct {
+	Year    int   `json:"year"`
+	Amount  Money `json:"amount"`
+	Records int   `json:"records"`
+}
+
+// ReportVendor is a vendor with what a politician paid it
+type ReportVendor struct {
+	CNPJ     string  `json:"cnpj_cpf"`
+	Name     string  `json:"name"`
+	Total    Money   `json:"total"`
+	Payments int     `json:"payments"`
+	Share    float64 `json:"share"` // percent of the politician's spending
+}
+
+// SanctionedPayment groups a politician's payments to a company made while one of its
+// sanctions was in force
+type SanctionedPayment struct {
+	CNPJ         string `json:"cnpj"`
+	Company      string `json:"company"`
+	SanctionID   int    `json:"sanction_id"`
+	SanctionType string `json:"sanction_type,omitempty"`
+	Registry     string `json:"registry,omitempty"`
+	SanctionFrom string `json:"sanction_from,omitempty"`
+	SanctionTo   string `json:"sanction_to,omitempty"`
+	Payments     int    `json:"payments"`
+	Total        Money  `json:"total"`
+	FirstPayment string `json:"first_payment"`
+	LastPayment  string `json:"last_payment"`
+}
+
+// ScoreBreakdown explains a corruption risk score by the factors cli4 post-process --enhanced
+// adds up. Score is the stored score; Computed is the sum of the factors on the current data,
+// which differs from it until the score is recomputed.
+type ScoreBreakdown struct {
+	Score    int           `json:"score"`
+	Computed int           `json:"computed"`
+	Factors  []ScoreFactor `json:"factors"`
+}
+
+// ScoreFactor is one factor of a risk score with the points it adds (0 when it doesn't apply)
+type ScoreFactor struct {
+	Factor    string `json:"factor"`
+	Points    int    `json:"points"`
+	MaxPoints int    `json:"max_points"`
+	Detail    string `json:"detail"`
+}
+
+// ReportConnectionSummary counts a politician's links in the network
+type ReportConnectionSummary struct {
+	Vendors           int `json:"vendors"`
+	SanctionedVendors int `json:"sanctioned_vendors"`
+	Memberships       int `json:"party_memberships"`
+	Fronts            int `json:"fronts"`
+	Relatives         int `json:"relatives"`
+	TCURulings        int `json:"tcu_rulings"`
+	Sanctions         int `json:"sanctions"` // against the politician's own CPF
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// SanctionLists names the Portal da Transparência registries a sanction can come from,
+// keyed by vendor_sanctions.data_source
+var SanctionLists = map[string]string{
+	"PORTAL_TRANSPARENCIA":       "CEIS",
+	"PORTAL_TRANSPARENCIA_CNEP":  "CNEP",
+	"PORTAL_TRANSPARENCIA_CEPIM": "CEPIM",
+}
+
+// SanctionDetail is the full sanction record. Cadastro is the registry it was published in:
+// CEIS (inidôneas e suspensas), CNEP (Lei Anticorrupção) or CEPIM (entidades impedidas).
+type SanctionDetail struct {
+	Sanction
+	NomeSancionado     string      `json:"nome_sancionado,omitempty"`
+	Descricao          string      `json:"descricao,omitempty"`
+	FundamentacaoLegal string      `json:"fundamentacao_legal,omitempty"`
+	OrgaoSancionador   string      `json:"orgao_sancionador,omitempty"`
+	UFOrgaoSancionador string      `json:"uf_orgao_sancionador,omitempty"`
+	NumeroProcesso     string      `json:"numero_processo,omitempty"`
+	Cadastro           string      `json:"cadastro"`
+	DataSource         string      `json:"data_source"`
+	PortalID           string      `json:"portal_id,omitempty"`
+	VerificadoEm       time.Time   `json:"verificado_em"`
+	UpdatedAt          time.Time   `json:"updated_at"`
+	Provenance         *Provenance `json:"provenance,omitempty"`
+}
+
+// SanctionSourceStats aggregates sanctions by the registry they were published in.
+// LinkedEntities counts sanctioned CNPJs/CPFs that appear in politicians' financial records.
+type SanctionSourceStats struct {
+	Cadastro       string `json:"cadastro"`
+	DataSource     string `json:"data_source"`
+	Total          int    `json:"total"`
+	Active         int    `json:"active"`
+	Expired        int    `json:"expired"`
+	Entities       int    `json:"entities"`
+	LinkedEntities int    `json:"linked_entities"`
+	TotalPenalty   Money  `json:"total_penalty"`
+	FirstStart     string `json:"first_start,omitempty"`
+	LastStart      string `json:"last_start,omitempty"`
+}
@@ -0,0 +1,31 @@
+package models
+
+// Entity types held in the full-text search index
+const (
+	SearchPolitician = "politician"
+	SearchParty      = "party"
+	SearchCompany    = "company"
+)
+
+// SearchTypes lists every type ?type= accepts on /api/search
+var SearchTypes = []string{SearchPolitician, SearchParty, SearchCompany}
+
+// SearchDocument is one politician, party or company as stored in the search index. ID is
+// what the entity's detail endpoint takes: the politician or party ID, or the company CNPJ.
+type SearchDocument struct {
+	Type    string   `json:"type"`
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"` // civil name, party acronym, trade name
+	Detail  string   `json:"detail,omitempty"`  // party and state, or municipality and state
+}
+
+// SearchHit is a search result, best match first. Suggestions served from the database
+// when the index is disabled have no score.
+type SearchHit struct {
+	Type   string  `json:"type"`
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Detail string  `json:"detail,omitempty"`
+	Score  float64 `json:"score,omitempty"`
+}
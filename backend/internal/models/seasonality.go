@@ -0,0 +1,36 @@
+package models
+
+// SpendingSeasonality is a politician's CEAP spending by calendar month, comparing general
+// election years (the ones deputies and senators run in) with the other years, and the years
+// with an end-of-year spike or a pre-election surge
+type SpendingSeasonality struct {
+	PoliticianID            int            `json:"politician_id"`
+	Nome                    string         `json:"nome"`
+	UF                      string         `json:"uf,omitempty"`
+	ElectionYearsMonthlyAvg Money          `json:"election_years_monthly_avg"`
+	OtherYearsMonthlyAvg    Money          `json:"other_years_monthly_avg"`
+	ElectionRatio           *float64       `json:"election_ratio,omitempty"` // election over other years' monthly average
+	EndOfYearSpikeYears     []int          `json:"end_of_year_spike_years"`
+	PreElectionSurgeYears   []int          `json:"pre_election_surge_years"`
+	Months                  []MonthProfile `json:"months"`
+	Years                   []YearSpending `json:"years"`
+}
+
+// MonthProfile is the average spending of one calendar month in election and other years
+type MonthProfile struct {
+	Month            int   `json:"month"`
+	ElectionYearsAvg Money `json:"election_years_avg"`
+	OtherYearsAvg    Money `json:"other_years_avg"`
+}
+
+// YearSpending is one year of a politician's spending. DecemberRatio compares December with
+// the average month before it and PreElectionRatio July-September of an election year with
+// the average month of its first half; both are missing when the year has too few months.
+type YearSpending struct {
+	Year             int       `json:"year"`
+	Election         bool      `json:"election"`
+	Total            Money     `json:"total"`
+	Monthly          [12]Money `json:"monthly"`
+	DecemberRatio    *float64  `json:"december_ratio,omitempty"`
+	PreElectionRatio *float64  `json:"pre_election_ratio,omitempty"`
+}
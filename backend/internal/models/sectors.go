@@ -0,0 +1,36 @@
+package models
+
+// CNAESections names the CNAE 2.0 sections companies are grouped into
+var CNAESections = map[string]string{
+	"A": "Agricultura, pecuária, produção florestal, pesca e aquicultura",
+	"B": "Indústrias extrativas",
+	"C": "Indústrias de transformação",
+	"D": "Eletricidade e gás",
+	"E": "Água, esgoto, atividades de gestão de resíduos e descontaminação",
+	"F": "Construção",
+	"G": "Comércio; reparação de veículos automotores e motocicletas",
+	"H": "Transporte, armazenagem e correio",
+	"I": "Alojamento e alimentação",
+	"J": "Informação e comunicação",
+	"K": "Atividades financeiras, de seguros e serviços relacionados",
+	"L": "Atividades imobiliárias",
+	"M": "Atividades profissionais, científicas e técnicas",
+	"N": "Atividades administrativas e serviços complementares",
+	"O": "Administração pública, defesa e seguridade social",
+	"P": "Educação",
+	"Q": "Saúde humana e serviços sociais",
+	"R": "Artes, cultura, esporte e recreação",
+	"S": "Outras atividades de serviços",
+	"T": "Serviços domésticos",
+	"U": "Organismos internacionais e outras instituições extraterritoriais",
+}
+
+// SectorStats aggregates financial records by CNAE sector at one level of the hierarchy
+type SectorStats struct {
+	Sector           string `json:"sector"`
+	Name             string `json:"name,omitempty"`
+	TransactionCount int    `json:"transaction_count"`
+	TotalValue       Money  `json:"total_value"`
+	Companies        int    `json:"companies"`
+	Politicians      int    `json:"politicians"`
+}
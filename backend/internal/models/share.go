@@ -0,0 +1,43 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ShareSnapshot freezes a filter set and subgraph of the network under a short-lived ID, so a
+// shared link shows what the sender saw even after the data changes
+type ShareSnapshot struct {
+	ID          string          `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Filters     json.RawMessage `json:"filters,omitempty"`
+	Nodes       []ShareNode     `json:"nodes"`
+	Links       []ShareLink     `json:"links"`
+	CreatedAt   time.Time       `json:"created_at"`
+	ExpiresAt   time.Time       `json:"expires_at"`
+	URL         string          `json:"url,omitempty"`
+	ImageURL    string          `json:"image_url,omitempty"`
+}
+
+// ShareNode is a network node as captured in a snapshot
+type ShareNode struct {
+	ID    string   `json:"id"`
+	Type  NodeType `json:"type"`
+	Name  string   `json:"name"`
+	Color string   `json:"color"`
+}
+
+// ShareLink is a connection between two captured nodes
+type ShareLink struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+// ShareRequest is the body of POST /api/share. Nodes are network node IDs (politician_123).
+type ShareRequest struct {
+	Title   string          `json:"title"`
+	Filters json.RawMessage `json:"filters"`
+	Nodes   []string        `json:"nodes"`
+}
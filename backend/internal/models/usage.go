@@ -0,0 +1,97 @@
+package models
+
+import "time"
+
+// UsageStats summarizes a set of requests. Latency percentiles are estimated from a
+// histogram, so p95_ms is the upper edge of the bucket holding the 95th percentile.
+type UsageStats struct {
+	Requests     int64   `json:"requests"`
+	ClientErrors int64   `json:"client_errors"`
+	ServerErrors int64   `json:"server_errors"`
+	RateLimited  int64   `json:"rate_limited"`
+	AvgMs        float64 `json:"avg_ms"`
+	P95Ms        float64 `json:"p95_ms"`
+	MaxMs        float64 `json:"max_ms"`
+}
+
+// UsageBucket is the traffic in one time bucket
+type UsageBucket struct {
+	Start time.Time `json:"start"`
+	UsageStats
+}
+
+// RouteUsage is the traffic to one route, such as "GET /api/politicians/:id"
+type RouteUsage struct {
+	Route string `json:"route"`
+	UsageStats
+}
+
+// ConsumerUsage is the traffic from one API key label, "user:<id>" or "anonymous"
+type ConsumerUsage struct {
+	Actor string `json:"actor"`
+	UsageStats
+}
+
+// UsageSummary is the response of GET /api/admin/usage
+type UsageSummary struct {
+	From      time.Time       `json:"from"`
+	To        time.Time       `json:"to"`
+	Bucket    string          `json:"bucket"`
+	Route     string          `json:"route,omitempty"`
+	Totals    UsageStats      `json:"totals"`
+	Buckets   []UsageBucket   `json:"buckets"`
+	Routes    []RouteUsage    `json:"routes"`
+	Consumers []ConsumerUsage `json:"consumers"`
+}
+
+// QueryStats summarizes the runs of one database query, named after the database package
+// function that issues it. Latency runs from sending the query until its rows are closed; p95_ms
+// is estimated from a histogram like UsageStats.
+type QueryStats struct {
+	Name    string  `json:"name"`
+	Calls   int64   `json:"calls"`
+	Errors  int64   `json:"errors"`
+	Slow    int64   `json:"slow"`
+	TotalMs float64 `json:"total_ms"`
+	AvgMs   float64 `json:"avg_ms"`
+	P95Ms   float64 `json:"p95_ms"`
+	MaxMs   float64 `json:"max_ms"`
+}
+
+// QueryReport is the response of GET /api/admin/queries
+type QueryReport struct {
+	Since           time.Time    `json:"since"`
+	SlowThresholdMs float64      `json:"slow_threshold_ms"`
+	Queries         []QueryStats `json:"queries"`
+}
+
+// RuntimeStats is the response of GET /api/admin/runtime: the process's goroutines, heap and
+// garbage collector, for diagnosing memory spikes. Byte counts are current unless named total.
+type RuntimeStats struct {
+	GoVersion    string    `json:"go_version"`
+	CPUs         int       `json:"cpus"`
+	Goroutines   int       `json:"goroutines"`
+	Uptime       string    `json:"uptime"`
+	HeapAlloc    uint64    `json:"heap_alloc_bytes"`
+	HeapInuse    uint64    `json:"heap_inuse_bytes"`
+	HeapIdle     uint64    `json:"heap_idle_bytes"`
+	HeapReleased uint64    `json:"heap_released_bytes"`
+	HeapObjects  uint64    `json:"heap_objects"`
+	Sys          uint64    `json:"sys_bytes"`
+	TotalAlloc   uint64    `json:"total_alloc_bytes"`
+	Mallocs      uint64    `json:"mallocs"`
+	Frees        uint64    `json:"frees"`
+	GC           RuntimeGC `json:"gc"`
+}
+
+// RuntimeGC summarizes garbage collection since startup; RecentPausesMs lists the latest pauses,
+// newest first
+type RuntimeGC struct {
+	Cycles         uint32     `json:"cycles"`
+	Forced         uint32     `json:"forced"`
+	NextTarget     uint64     `json:"next_target_bytes"`
+	LastRun        *time.Time `json:"last_run,omitempty"`
+	PauseTotalMs   float64    `json:"pause_total_ms"`
+	RecentPausesMs []float64  `json:"recent_pauses_ms"`
+	CPUFraction    float64    `json:"cpu_fraction"`
+}
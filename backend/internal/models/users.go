@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// User is an account signed in by email magic link
+type User struct {
+	ID          int64      `json:"id"`
+	Email       string     `json:"email"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+// Session is the bearer token issued when a magic link is verified
+type Session struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	User      User      `json:"user"`
+}
+
+// SavedView is a named state of the network visualization. Filters and Camera are stored as
+// the frontend sends them; anyone with the ID can load the view.
+type SavedView struct {
+	ID            string          `json:"id"`
+	Name          string          `json:"name"`
+	Filters       json.RawMessage `json:"filters,omitempty"`
+	SelectedNodes []string        `json:"selected_nodes"`
+	Camera        json.RawMessage `json:"camera,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+	UserID        int64           `json:"-"`
+}
+
+// SavedViewRequest is the body of POST and PUT /api/views
+type SavedViewRequest struct {
+	Name          string          `json:"name"`
+	Filters       json.RawMessage `json:"filters"`
+	SelectedNodes []string        `json:"selected_nodes"`
+	Camera        json.RawMessage `json:"camera"`
+}
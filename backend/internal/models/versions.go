@@ -0,0 +1,27 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Dataset version states. ETL runs stamp the building version; cli4 publish-version snapshots
+// it, making it pinnable with ?dataset_version=, and prunes published versions past --keep.
+const (
+	VersionBuilding  = "building"
+	VersionPublished = "published"
+	VersionPruned    = "pruned"
+)
+
+// DatasetVersion is a numbered state of the ETL data. Runs lists the cli4 commands that went
+// into it; TableRows counts each table's rows when it was published.
+type DatasetVersion struct {
+	Version     int             `json:"version"`
+	Status      string          `json:"status"`
+	Runs        json.RawMessage `json:"runs"`
+	TableRows   json.RawMessage `json:"table_rows"`
+	Note        string          `json:"note,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	PublishedAt *time.Time      `json:"published_at,omitempty"`
+	PrunedAt    *time.Time      `json:"pruned_at,omitempty"`
+}
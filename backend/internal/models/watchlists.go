@@ -0,0 +1,104 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Watchlist entity types
+const (
+	WatchPolitician = "politician"
+	WatchCompany    = "company"
+)
+
+// EventWatchlistAlert is the webhook event carrying a watchlist alert
+const EventWatchlistAlert = "watchlist_alert"
+
+// Watchlist is a set of politicians and companies a consumer follows. Alerts are delivered to
+// WebhookID (one of the owner's webhooks) and/or emailed to Email.
+type Watchlist struct {
+	ID        int64           `json:"id"`
+	Owner     string          `json:"owner"`
+	Name      string          `json:"name"`
+	WebhookID *int64          `json:"webhook_id,omitempty"`
+	Email     string          `json:"email,omitempty"`
+	Items     []WatchlistItem `json:"items"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// WatchlistItem is a followed entity: a politician by ID or a company by 14-digit CNPJ.
+// Label is the politician's or company's name when known.
+type WatchlistItem struct {
+	Type    string     `json:"type"`
+	ID      string     `json:"id"`
+	Label   string     `json:"label,omitempty"`
+	AddedAt *time.Time `json:"added_at,omitempty"`
+}
+
+// WatchlistRequest is the body of POST /api/watchlists
+type WatchlistRequest struct {
+	Name      string          `json:"name"`
+	Items     []WatchlistItem `json:"items"`
+	WebhookID *int64          `json:"webhook_id"`
+	Email     string          `json:"email"`
+}
+
+// WatchlistAlert records a change that touched a followed entity
+type WatchlistAlert struct {
+	ID          int64           `json:"id"`
+	WatchlistID int64           `json:"watchlist_id"`
+	EntityType  string          `json:"entity_type"`
+	EntityID    string          `json:"entity_id"`
+	Event       string          `json:"event"`
+	Summary     string          `json:"summary"`
+	Data        json.RawMessage `json:"data,omitempty"`
+	EmailedAt   *time.Time      `json:"emailed_at,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// PendingAlertEmail collects the alerts of one watchlist not yet emailed
+type PendingAlertEmail struct {
+	WatchlistID int64
+	Name        string
+	Email       string
+	AlertIDs    []int64
+	Summaries   []string
+}
+
+// WatchlistDigest subscribes an email address to a weekly digest of a watchlist
+type WatchlistDigest struct {
+	ID          int64      `json:"id"`
+	WatchlistID int64      `json:"watchlist_id"`
+	Email       string     `json:"email"`
+	LastSentAt  *time.Time `json:"last_sent_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// DigestRequest is the body of POST /api/watchlists/:id/digests; an empty email subscribes the
+// watchlist's own address
+type DigestRequest struct {
+	Email string `json:"email"`
+}
+
+// DueDigest is a digest to send: new sanctions and score changes alerted since Since, and
+// unusual expenses flagged on followed entities that no earlier digest reported. AnomalyKeys are
+// the flagged anomalies reported by now, remembered once the digest is sent; those over the
+// section cap wait for the next digest.
+type DueDigest struct {
+	ID           int64
+	WatchlistID  int64
+	Watchlist    string
+	Email        string
+	Token        string
+	BaseURL      string
+	Since        time.Time
+	Sanctions    []string
+	ScoreChanges []string
+	Anomalies    []Anomaly
+	AnomalyKeys  []string
+}
+
+// Empty reports whether nothing happened since the previous digest
+func (d DueDigest) Empty() bool {
+	return len(d.Sanctions) == 0 && len(d.ScoreChanges) == 0 && len(d.Anomalies) == 0
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook event types
+const (
+	EventNewSanction    = "new_sanction"
+	EventScoreChange    = "score_change_above_threshold"
+	EventNewConnection  = "new_connection"
+	DefaultScoreTrigger = 10
+)
+
+// WebhookEvents are the event types consumers can subscribe to
+var WebhookEvents = []string{EventNewSanction, EventScoreChange, EventNewConnection}
+
+// Webhook delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// Webhook is a consumer's subscription. Secret signs the deliveries and is only returned when
+// the webhook is created; ScoreThreshold is the smallest score change, in points, that
+// triggers score_change_above_threshold.
+type Webhook struct {
+	ID             int64     `json:"id"`
+	URL            string    `json:"url"`
+	Events         []string  `json:"events"`
+	ScoreThreshold int       `json:"score_threshold"`
+	Owner          string    `json:"owner"`
+	Active         bool      `json:"active"`
+	Secret         string    `json:"secret,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// WebhookRequest is the body of POST /api/webhooks
+type WebhookRequest struct {
+	URL            string   `json:"url"`
+	Events         []string `json:"events"`
+	ScoreThreshold int      `json:"score_threshold"`
+}
+
+// WebhookEvent is a change detected in the data, before it is queued for each subscriber.
+// Magnitude is the score change in points; other events leave it at zero. PoliticianID and
+// CNPJ name the entities involved, for matching against watchlists, and Summary describes the
+// change in one line for alerts.
+type WebhookEvent struct {
+	Event        string                 `json:"event"`
+	OccurredAt   time.Time              `json:"occurred_at"`
+	Data         map[string]interface{} `json:"data"`
+	Magnitude    int                    `json:"-"`
+	PoliticianID int                    `json:"-"`
+	CNPJ         string                 `json:"-"`
+	Summary      string                 `json:"-"`
+}
+
+// WebhookDelivery is one entry of a webhook's delivery log
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhook_id"`
+	Event          string          `json:"event"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+// PendingDelivery is a queued delivery joined with its webhook's endpoint and secret
+type PendingDelivery struct {
+	ID       int64
+	Event    string
+	Payload  []byte
+	Attempts int
+	URL      string
+	Secret   string
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// WikidataLink is a politician's matched Wikidata item with the enrichment pulled from it.
+// MatchMethod, RevisionID and RetrievedAt record where the data came from.
+type WikidataLink struct {
+	QID             string           `json:"qid"`
+	WikidataURL     string           `json:"wikidata_url"`
+	WikipediaPT     string           `json:"wikipedia_pt,omitempty"`
+	WikipediaEN     string           `json:"wikipedia_en,omitempty"`
+	Aliases         []string         `json:"aliases,omitempty"`
+	PreviousOffices []WikidataOffice `json:"previous_offices,omitempty"`
+	MatchMethod     string           `json:"match_method"`
+	RevisionID      int64            `json:"revision_id,omitempty"`
+	DataSource      string           `json:"data_source"`
+	RetrievedAt     time.Time        `json:"retrieved_at"`
+}
+
+// WikidataOffice is a "position held" (P39) statement; dates keep Wikidata's precision
+// (YYYY, YYYY-MM or YYYY-MM-DD)
+type WikidataOffice struct {
+	QID      string `json:"qid"`
+	Position string `json:"position"`
+	Of       string `json:"of,omitempty"`
+	Start    string `json:"start,omitempty"`
+	End      string `json:"end,omitempty"`
+}
@@ -0,0 +1,234 @@
+package privacy
+
+import (
+	"political-network-api/internal/models"
+	"strings"
+)
+
+// Action is how a sensitive field is shaped in a response
+type Action int
+
+const (
+	// Reveal returns the stored value
+	Reveal Action = iota
+	// Mask keeps only a non-identifying part of the value
+	Mask
+	// Strip removes the value entirely
+	Strip
+)
+
+// Policy decides the shape of each sensitive field for one role
+type Policy struct {
+	CPF   Action
+	Email Action
+}
+
+// policies per role. Researchers get full CPFs for record linkage against TSE/CGU
+// datasets but no contact data; only admins see everything.
+var policies = map[models.Role]Policy{
+	models.RolePublic:     {CPF: Mask, Email: Mask},
+	models.RoleResearcher: {CPF: Reveal, Email: Strip},
+	models.RoleAdmin:      {CPF: Reveal, Email: Reveal},
+}
+
+// Public is the policy for anonymous callers and for data shared between callers (caches, archives)
+var Public = policies[models.RolePublic]
+
+// ForRole returns the policy for role, falling back to Public for unknown roles
+func ForRole(role models.Role) Policy {
+	if p, ok := policies[role]; ok {
+		return p
+	}
+	return Public
+}
+
+// Reveals reports whether the policy exposes any sensitive value in full
+func (p Policy) Reveals() bool {
+	return p.CPF == Reveal || p.Email == Reveal
+}
+
+// apply shapes value according to action using mask for Mask
+func apply(action Action, value string, mask func(string) string) string {
+	switch action {
+	case Reveal:
+		return value
+	case Strip:
+		return ""
+	default:
+		return mask(value)
+	}
+}
+
+// digits strips punctuation from a CPF/CNPJ
+func digits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// IsCPF reports whether a document number is a CPF (11 digits) rather than a CNPJ
+func IsCPF(doc string) bool {
+	return len(digits(doc)) == 11
+}
+
+// MaskCPF keeps the middle six digits, the format recommended for LGPD publication: ***.456.789-**
+func MaskCPF(cpf string) string {
+	d := digits(cpf)
+	if len(d) != 11 {
+		if cpf == "" {
+			return ""
+		}
+		return "***"
+	}
+	return "***." + d[3:6] + "." + d[6:9] + "-**"
+}
+
+// MaskEmail keeps the first character of the local part and the domain: j***@camara.leg.br
+func MaskEmail(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" {
+		if email == "" {
+			return ""
+		}
+		return "***"
+	}
+	return local[:1] + "***@" + domain
+}
+
+// Document shapes a CPF/CNPJ field; CNPJs identify companies and are public
+func (p Policy) Document(doc string) string {
+	if IsCPF(doc) {
+		return apply(p.CPF, doc, MaskCPF)
+	}
+	return doc
+}
+
+// Politician returns v with CPF and email shaped by the policy
+func (p Policy) Politician(v models.Politician) models.Politician {
+	v.CPF = apply(p.CPF, v.CPF, MaskCPF)
+	v.UltimoStatusEmail = apply(p.Email, v.UltimoStatusEmail, MaskEmail)
+	return v
+}
+
+// Sanction returns v with any personal document shaped by the policy
+func (p Policy) Sanction(v models.Sanction) models.Sanction {
+	v.CNPJ = p.Document(v.CNPJ)
+	v.CPF = apply(p.CPF, v.CPF, MaskCPF)
+	return v
+}
+
+// SanctionDetail returns v with the sanctioned document shaped like Sanction
+func (p Policy) SanctionDetail(v models.SanctionDetail) models.SanctionDetail {
+	v.Sanction = p.Sanction(v.Sanction)
+	return v
+}
+
+// FinancialRecord returns v with an individual counterpart's CPF shaped by the policy
+func (p Policy) FinancialRecord(v models.FinancialRecord) models.FinancialRecord {
+	v.CNPJ = p.Document(v.CNPJ)
+	return v
+}
+
+// Anomaly returns v with an individual vendor's CPF shaped by the policy
+func (p Policy) Anomaly(v models.Anomaly) models.Anomaly {
+	v.CNPJCPF = p.Document(v.CNPJCPF)
+	return v
+}
+
+// TopVendor returns v with an individual vendor's CPF shaped by the policy
+func (p Policy) TopVendor(v models.TopVendor) models.TopVendor {
+	v.CNPJCPF = p.Document(v.CNPJCPF)
+	return v
+}
+
+// Connection returns v with a CPF embedded in an endpoint's node ID ("company_<doc>") shaped
+// by the policy
+func (p Policy) Connection(v models.Connection) models.Connection {
+	v.SourceID = p.nodeID(v.SourceID)
+	v.TargetID = p.nodeID(v.TargetID)
+	return v
+}
+
+// nodeID shapes the document after the last "_" of a network node ID
+func (p Policy) nodeID(id string) string {
+	i := strings.LastIndexByte(id, '_')
+	if i < 0 {
+		return id
+	}
+	return id[:i+1] + p.Document(id[i+1:])
+}
+
+// PoliticianDetail shapes the politician and every embedded collection
+func (p Policy) PoliticianDetail(v models.PoliticianDetail) models.PoliticianDetail {
+	v.Politician = p.Politician(v.Politician)
+	v.Expenses = Slice(p, v.Expenses)
+	v.Sanctions = Slice(p, v.Sanctions)
+	return v
+}
+
+// CompanyDetail shapes the sanctions and owner documents of a company dossier
+func (p Policy) CompanyDetail(v models.CompanyDetail) models.CompanyDetail {
+	v.Sanctions = Slice(p, v.Sanctions)
+	owners := make([]models.CompanyOwner, len(v.Owners))
+	for i, o := range v.Owners {
+		o.Documento = p.Document(o.Documento)
+		owners[i] = o
+	}
+	v.Owners = owners
+	return v
+}
+
+// Apply returns item shaped by the policy; types without PII are returned unchanged
+func Apply[T any](p Policy, item T) T {
+	var shaped interface{}
+	switch v := any(item).(type) {
+	case models.Politician:
+		shaped = p.Politician(v)
+	case models.Sanction:
+		shaped = p.Sanction(v)
+	case models.SanctionDetail:
+		shaped = p.SanctionDetail(v)
+	case models.FinancialRecord:
+		shaped = p.FinancialRecord(v)
+	case models.Anomaly:
+		shaped = p.Anomaly(v)
+	case models.TopVendor:
+		shaped = p.TopVendor(v)
+	case models.Connection:
+		shaped = p.Connection(v)
+	case models.PoliticianDetail:
+		shaped = p.PoliticianDetail(v)
+	case models.CompanyDetail:
+		shaped = p.CompanyDetail(v)
+	default:
+		return item
+	}
+	return shaped.(T)
+}
+
+// HasPII reports whether values of type T carry personal data that Apply would change
+func HasPII[T any]() bool {
+	var zero T
+	switch any(zero).(type) {
+	case models.Politician, models.Sanction, models.SanctionDetail, models.FinancialRecord,
+		models.Anomaly, models.TopVendor, models.Connection, models.PoliticianDetail, models.CompanyDetail:
+		return true
+	}
+	return false
+}
+
+// Slice returns a shaped copy of items, leaving the (possibly cached) original untouched
+func Slice[T any](p Policy, items []T) []T {
+	if items == nil || !HasPII[T]() || (p.CPF == Reveal && p.Email == Reveal) {
+		return items
+	}
+	shaped := make([]T, len(items))
+	for i, item := range items {
+		shaped[i] = Apply(p, item)
+	}
+	return shaped
+}
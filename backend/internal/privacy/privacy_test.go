@@ -0,0 +1,56 @@
+package privacy
+
+import (
+	"political-network-api/internal/models"
+	"strings"
+	"testing"
+)
+
+func TestSliceMasksAnomalyCPFs(t *testing.T) {
+	anomalies := []models.Anomaly{
+		{ID: 1, Type: "geo_mismatch", CNPJCPF: "12345678901"},
+		{ID: 2, Type: "geo_mismatch", CNPJCPF: "11222333000181"},
+	}
+
+	public := Slice(Public, anomalies)
+	if got := public[0].CNPJCPF; got != "***.456.789-**" {
+		t.Errorf("public CPF = %q, want ***.456.789-**", got)
+	}
+	if got := public[1].CNPJCPF; got != "11222333000181" {
+		t.Errorf("public CNPJ = %q, want it unmasked", got)
+	}
+	if anomalies[0].CNPJCPF != "12345678901" {
+		t.Error("Slice changed the (cached) original")
+	}
+
+	if got := Slice(ForRole(models.RoleResearcher), anomalies)[0].CNPJCPF; got != "12345678901" {
+		t.Errorf("researcher CPF = %q, want it whole", got)
+	}
+}
+
+func TestConnectionNeverExposesCPFsToPublic(t *testing.T) {
+	connections := []models.Connection{
+		{SourceID: "politician_1", TargetID: "company_12345678901", Type: "financial"},
+		{SourceID: "company_12345678901", TargetID: "politician_1", Type: "tcu_irregular"},
+		{SourceID: "politician_1", TargetID: "company_11222333000181", Type: "financial"},
+	}
+
+	public := Slice(Public, connections)
+	for i, c := range public[:2] {
+		if strings.Contains(c.SourceID+c.TargetID, "12345678901") {
+			t.Errorf("public connection %d = %s -> %s, want the CPF masked", i, c.SourceID, c.TargetID)
+		}
+	}
+	if got := public[0].TargetID; got != "company_***.456.789-**" {
+		t.Errorf("public CPF target = %q, want company_***.456.789-**", got)
+	}
+	if got := public[2].TargetID; got != "company_11222333000181" {
+		t.Errorf("public CNPJ target = %q, want it unmasked", got)
+	}
+	if public[0].SourceID != "politician_1" {
+		t.Errorf("public source = %q, want politician_1", public[0].SourceID)
+	}
+	if connections[0].TargetID != "company_12345678901" {
+		t.Error("Slice changed the (cached) original")
+	}
+}
@@ -0,0 +1,241 @@
+package progress
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"political-network-api/internal/models"
+	"sync"
+	"time"
+)
+
+// Retention is how long a finished task can still be polled
+const Retention = time.Hour
+
+var (
+	mu    sync.Mutex
+	tasks = map[string]*Task{}
+)
+
+// Task tracks one long-running build. A nil *Task accepts every call and does nothing, so
+// builders can report progress without caring whether anyone is watching.
+type Task struct {
+	mu       sync.Mutex
+	id, kind string
+	stage    string
+	rows     int64
+	done     int64
+	total    int64
+	started  time.Time
+	finished time.Time
+	status   string
+	err      string
+	result   interface{}
+	watchers map[chan struct{}]struct{}
+}
+
+// StartOrJoin returns the running task of kind if there is one, so callers share a build
+// instead of starting a duplicate. Otherwise it registers a new task with an estimated total and
+// reports started as true; the caller must then run the build and Finish the task.
+func StartOrJoin(kind string, total int64) (t *Task, started bool, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, existing := range tasks {
+		if existing.kind == kind && existing.running() {
+			return existing, false, nil
+		}
+	}
+
+	t, err = register(kind, total)
+	return t, err == nil, err
+}
+
+// Start registers a new task of kind even when one is already running, for callers that
+// serialize their own work such as the job queue. The caller must Finish the task.
+func Start(kind string, total int64) (*Task, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return register(kind, total)
+}
+
+// register adds a running task. mu must be held.
+func register(kind string, total int64) (*Task, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	t := &Task{
+		id:       hex.EncodeToString(buf),
+		kind:     kind,
+		total:    total,
+		started:  time.Now(),
+		status:   models.ProgressRunning,
+		watchers: map[chan struct{}]struct{}{},
+	}
+
+	sweep()
+	tasks[t.id] = t
+	return t, nil
+}
+
+// Get finds a running or recently finished task
+func Get(id string) (*Task, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	t, ok := tasks[id]
+	return t, ok
+}
+
+// sweep drops tasks that finished more than Retention ago. mu must be held.
+func sweep() {
+	for id, t := range tasks {
+		t.mu.Lock()
+		expired := !t.finished.IsZero() && time.Since(t.finished) > Retention
+		t.mu.Unlock()
+		if expired {
+			delete(tasks, id)
+		}
+	}
+}
+
+// ID returns the task ID
+func (t *Task) ID() string {
+	if t == nil {
+		return ""
+	}
+	return t.id
+}
+
+func (t *Task) running() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.status == models.ProgressRunning
+}
+
+// SetTotal replaces the estimated total once the task knows its size
+func (t *Task) SetTotal(total int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.total = total
+	t.mu.Unlock()
+	t.notify()
+}
+
+// Stage names the step the task is on
+func (t *Task) Stage(name string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.stage = name
+	t.mu.Unlock()
+	t.notify()
+}
+
+// AddRows counts rows processed toward the total
+func (t *Task) AddRows(n int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.rows += n
+	t.done += n
+	t.mu.Unlock()
+	t.notify()
+}
+
+// Step marks one build stage complete, having produced rows
+func (t *Task) Step(rows int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.rows += rows
+	t.done++
+	t.mu.Unlock()
+	t.notify()
+}
+
+// Finish ends the task with its result, or with err if it failed
+func (t *Task) Finish(result interface{}, err error) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.finished = time.Now()
+	if err != nil {
+		t.status = models.ProgressFailed
+		t.err = err.Error()
+	} else {
+		t.status = models.ProgressDone
+		t.result = result
+		t.done = max(t.done, t.total)
+		t.total = t.done
+	}
+	t.mu.Unlock()
+	t.notify()
+}
+
+// Watch returns a channel signalled whenever the task changes, and a function to stop watching.
+// Signals coalesce: a slow reader sees one pending signal, then reads the latest Snapshot.
+func (t *Task) Watch() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	t.mu.Lock()
+	t.watchers[ch] = struct{}{}
+	t.mu.Unlock()
+	return ch, func() {
+		t.mu.Lock()
+		delete(t.watchers, ch)
+		t.mu.Unlock()
+	}
+}
+
+func (t *Task) notify() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for ch := range t.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Snapshot reports the task's progress, with the ETA extrapolated from the rate so far
+func (t *Task) Snapshot() models.Progress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := models.Progress{
+		TaskID:    t.id,
+		Kind:      t.kind,
+		Status:    t.status,
+		Stage:     t.stage,
+		Rows:      t.rows,
+		Done:      t.done,
+		Total:     t.total,
+		StartedAt: t.started,
+		Error:     t.err,
+		Result:    t.result,
+	}
+	if t.total > 0 {
+		p.Percent = min(100, float64(t.done)*100/float64(t.total))
+	}
+	if !t.finished.IsZero() {
+		finished := t.finished
+		p.FinishedAt = &finished
+	}
+
+	switch {
+	case t.status == models.ProgressDone:
+		eta := 0.0
+		p.ETASeconds = &eta
+	case t.status == models.ProgressRunning && t.done > 0 && t.total > t.done:
+		elapsed := time.Since(t.started).Seconds()
+		eta := elapsed / float64(t.done) * float64(t.total-t.done)
+		p.ETASeconds = &eta
+	}
+	return p
+}
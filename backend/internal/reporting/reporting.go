@@ -0,0 +1,289 @@
+// Package reporting sends panics to a Sentry-compatible error tracker (Sentry, GlitchTip)
+// through its envelope API, with the request and stack that led to them. Events are queued and
+// sent in the background; when the queue is full or no DSN is configured, they are only logged.
+package reporting
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"political-network-api/internal/config"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// queueSize bounds the events waiting to be sent; a panic storm beyond it is only logged
+const queueSize = 64
+
+// sensitiveHeaders and sensitiveParams are left out of reported requests
+var (
+	sensitiveHeaders = []string{"Authorization", "Cookie", "X-Api-Key"}
+	sensitiveParams  = []string{"token", "key", "api_key"}
+)
+
+// Request is what an event records of the request that panicked
+type Request struct {
+	Method string
+	URL    *url.URL
+	Header http.Header
+	Route  string
+	Actor  string
+}
+
+// event is a Sentry event with the fields this package fills in
+type event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       string            `json:"level"`
+	Logger      string            `json:"logger"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Transaction string            `json:"transaction,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	User        *eventUser        `json:"user,omitempty"`
+	Request     *eventRequest     `json:"request,omitempty"`
+	Exception   eventExceptions   `json:"exception"`
+}
+
+type eventUser struct {
+	ID string `json:"id"`
+}
+
+type eventRequest struct {
+	URL         string            `json:"url"`
+	Method      string            `json:"method"`
+	QueryString string            `json:"query_string,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+}
+
+type eventExceptions struct {
+	Values []eventException `json:"values"`
+}
+
+type eventException struct {
+	Type       string          `json:"type"`
+	Value      string          `json:"value"`
+	Stacktrace eventStacktrace `json:"stacktrace"`
+}
+
+type eventStacktrace struct {
+	Frames []eventFrame `json:"frames"`
+}
+
+type eventFrame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	Filename string `json:"filename"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+// queued is an event waiting to be sent with the DSN it goes to
+type queued struct {
+	dsn   string
+	event event
+}
+
+var (
+	queue     = make(chan queued, queueSize)
+	startOnce sync.Once
+	client    = &http.Client{Timeout: 10 * time.Second}
+	hostname  string
+)
+
+// Panic reports a recovered panic value, with the stack of the goroutine that panicked, and
+// returns the event ID quoted in the logs and the response. Call it from the deferred function
+// that recovered, so the stack still shows where the panic happened.
+func Panic(recovered interface{}, req Request) string {
+	id := newEventID()
+
+	cfg := config.Get().Reporting
+	if cfg.DSN == "" {
+		return id
+	}
+	startOnce.Do(start)
+
+	ev := event{
+		EventID:     id,
+		Timestamp:   time.Now().UTC().Format(time.RFC3339Nano),
+		Platform:    "go",
+		Level:       "fatal",
+		Logger:      "recovery",
+		ServerName:  hostname,
+		Environment: cfg.Environment,
+		Release:     cfg.Release,
+		Transaction: req.Route,
+		Exception: eventExceptions{Values: []eventException{{
+			Type:       panicType(recovered),
+			Value:      fmt.Sprint(recovered),
+			Stacktrace: eventStacktrace{Frames: stackFrames()},
+		}}},
+	}
+	if req.Route != "" {
+		ev.Tags = map[string]string{"route": req.Route}
+	}
+	if req.Actor != "" {
+		ev.User = &eventUser{ID: req.Actor}
+	}
+	if req.URL != nil {
+		ev.Request = reportedRequest(req)
+	}
+
+	select {
+	case queue <- queued{dsn: cfg.DSN, event: ev}:
+	default:
+		log.Printf("⚠️ Error report queue full, dropping event %s", id)
+	}
+	return id
+}
+
+// start runs the sender
+func start() {
+	hostname, _ = os.Hostname()
+	go func() {
+		for q := range queue {
+			if err := send(q.dsn, q.event); err != nil {
+				log.Printf("⚠️ Failed to report event %s: %v", q.event.EventID, err)
+			}
+		}
+	}()
+}
+
+// send posts an event as a one-item envelope to the project's envelope endpoint
+func send(dsn string, ev event) error {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return fmt.Errorf("invalid DSN: %w", err)
+	}
+	key := u.User.Username()
+	project := path.Base(u.Path)
+	endpoint := url.URL{Scheme: u.Scheme, Host: u.Host, Path: path.Join(path.Dir(u.Path), "api", project, "envelope") + "/"}
+
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	var body bytes.Buffer
+	header, _ := json.Marshal(map[string]string{"event_id": ev.EventID, "sent_at": time.Now().UTC().Format(time.RFC3339)})
+	item, _ := json.Marshal(map[string]interface{}{"type": "event", "length": len(payload)})
+	body.Write(header)
+	body.WriteByte('\n')
+	body.Write(item)
+	body.WriteByte('\n')
+	body.Write(payload)
+	body.WriteByte('\n')
+
+	req, err := http.NewRequest(http.MethodPost, endpoint.String(), &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", "Sentry sentry_version=7, sentry_client=political-network-api/1.0, sentry_key="+key)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("error tracker answered %s", resp.Status)
+	}
+	return nil
+}
+
+// reportedRequest is the request without credentials
+func reportedRequest(req Request) *eventRequest {
+	query := req.URL.Query()
+	for _, param := range sensitiveParams {
+		if query.Has(param) {
+			query.Set(param, "[Filtered]")
+		}
+	}
+	headers := make(map[string]string, len(req.Header))
+	for name, values := range req.Header {
+		headers[name] = strings.Join(values, ", ")
+	}
+	for _, name := range sensitiveHeaders {
+		if _, ok := headers[name]; ok {
+			headers[name] = "[Filtered]"
+		}
+	}
+	return &eventRequest{
+		URL:         req.URL.Path,
+		Method:      req.Method,
+		QueryString: query.Encode(),
+		Headers:     headers,
+	}
+}
+
+// panicType names the type of the panic value, such as runtime.boundsError
+func panicType(recovered interface{}) string {
+	if recovered == nil {
+		return "panic"
+	}
+	return reflect.TypeOf(recovered).String()
+}
+
+// stackFrames is the panicking goroutine's stack up to the panic, outermost call first as Sentry
+// expects, without the runtime or the recovering function
+func stackFrames() []eventFrame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []eventFrame
+	for {
+		frame, more := frames.Next()
+		if frame.Function == "runtime.gopanic" {
+			// Everything so far is the deferred function that recovered
+			stack = stack[:0]
+		} else if !strings.HasPrefix(frame.Function, "runtime.") {
+			module, function := splitFunction(frame.Function)
+			stack = append(stack, eventFrame{
+				Function: function,
+				Module:   module,
+				Filename: path.Base(frame.File),
+				AbsPath:  frame.File,
+				Lineno:   frame.Line,
+				InApp:    strings.HasPrefix(frame.Function, "political-network-api/"),
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	for i, j := 0, len(stack)-1; i < j; i, j = i+1, j-1 {
+		stack[i], stack[j] = stack[j], stack[i]
+	}
+	return stack
+}
+
+// splitFunction splits political-network-api/internal/handlers.GetPolitician into its package
+// path and function name
+func splitFunction(name string) (module, function string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	return name[:slash+1+dot], name[slash+1+dot+1:]
+}
+
+// newEventID returns a random 32-hex-digit ID, the format Sentry expects
+func newEventID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,79 @@
+package reporting
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"political-network-api/internal/config"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPanicSendsEnvelope(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	bodies := make(chan []string, 1)
+	tracker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var lines []string
+		scanner := bufio.NewScanner(r.Body)
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		received <- r
+		bodies <- lines
+	}))
+	defer tracker.Close()
+
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("SENTRY_DSN", strings.Replace(tracker.URL, "://", "://publickey@", 1)+"/42")
+	if _, err := config.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	var id string
+	func() {
+		defer func() {
+			id = Panic(recover(), Request{
+				Method: http.MethodGet,
+				URL:    &url.URL{Path: "/api/politicians/7", RawQuery: "token=secret&fields=name"},
+				Header: http.Header{"X-Api-Key": {"secret"}, "Accept": {"application/json"}},
+				Route:  "/api/politicians/:id",
+			})
+		}()
+		var politicians []int
+		_ = politicians[7]
+	}()
+
+	var r *http.Request
+	var lines []string
+	select {
+	case r = <-received:
+		lines = <-bodies
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event reached the tracker")
+	}
+	if r.URL.Path != "/api/42/envelope/" || !strings.Contains(r.Header.Get("X-Sentry-Auth"), "sentry_key=publickey") {
+		t.Fatalf("posted to %s with auth %q", r.URL.Path, r.Header.Get("X-Sentry-Auth"))
+	}
+	if len(lines) != 3 {
+		t.Fatalf("envelope has %d lines, want header, item header and event", len(lines))
+	}
+
+	var ev event
+	if err := json.Unmarshal([]byte(lines[2]), &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev.EventID != id || ev.Transaction != "/api/politicians/:id" {
+		t.Errorf("event %s for %q, want %s for the route", ev.EventID, ev.Transaction, id)
+	}
+	if strings.Contains(lines[2], "secret") {
+		t.Errorf("event leaks credentials: %s", lines[2])
+	}
+	frames := ev.Exception.Values[0].Stacktrace.Frames
+	if last := frames[len(frames)-1]; !last.InApp || !strings.HasPrefix(last.Function, "TestPanicSendsEnvelope") {
+		t.Errorf("innermost frame is %s, want the function that panicked", last.Function)
+	}
+}
@@ -0,0 +1,295 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"political-network-api/internal/models"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/char/asciifolding"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+// foldedAnalyzer splits names into words, strips accents and lowercases them, so "JOAO" finds
+// "João"
+const foldedAnalyzer = "folded"
+
+// lastSyncKey holds the sync watermark in Bleve's internal storage
+var lastSyncKey = []byte("last_sync")
+
+// searchFields are matched by every word, with their boosts
+var searchFields = []struct {
+	name  string
+	boost float64
+}{{"name", 2}, {"aliases", 1}}
+
+// staleDeleteBatch is how many stale documents are looked up and deleted at a time
+const staleDeleteBatch = 10000
+
+type bleveIndex struct {
+	index bleve.Index
+}
+
+// openBleve opens the index at path, creating it on first use
+func openBleve(path string) (*bleveIndex, error) {
+	idx, err := bleve.Open(path)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		var m *mapping.IndexMappingImpl
+		if m, err = bleveMapping(); err != nil {
+			return nil, err
+		}
+		idx, err = bleve.New(path, m)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &bleveIndex{index: idx}, nil
+}
+
+func bleveMapping() (*mapping.IndexMappingImpl, error) {
+	m := bleve.NewIndexMapping()
+	err := m.AddCustomAnalyzer(foldedAnalyzer, map[string]interface{}{
+		"type":          custom.Name,
+		"char_filters":  []string{asciifolding.Name},
+		"tokenizer":     unicode.Name,
+		"token_filters": []string{lowercase.Name},
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.DefaultAnalyzer = foldedAnalyzer
+
+	text := func(store bool) *mapping.FieldMapping {
+		f := bleve.NewTextFieldMapping()
+		f.Analyzer = foldedAnalyzer
+		f.Store = store
+		return f
+	}
+	keyword := bleve.NewKeywordFieldMapping()
+	detail := bleve.NewTextFieldMapping()
+	detail.Index = false
+
+	doc := bleve.NewDocumentStaticMapping()
+	doc.AddFieldMappingsAt("type", keyword)
+	doc.AddFieldMappingsAt("id", keyword)
+	doc.AddFieldMappingsAt("name", text(true))
+	doc.AddFieldMappingsAt("aliases", text(false))
+	doc.AddFieldMappingsAt("detail", detail)
+	doc.AddFieldMappingsAt("synced", bleve.NewNumericFieldMapping())
+	m.DefaultMapping = doc
+
+	return m, nil
+}
+
+func (b *bleveIndex) Upsert(docs []models.SearchDocument, synced time.Time) error {
+	batch := b.index.NewBatch()
+	for _, doc := range docs {
+		err := batch.Index(docID(doc), map[string]interface{}{
+			"type":    doc.Type,
+			"id":      doc.ID,
+			"name":    doc.Name,
+			"aliases": doc.Aliases,
+			"detail":  doc.Detail,
+			"synced":  float64(synced.Unix()),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return b.index.Batch(batch)
+}
+
+func (b *bleveIndex) DeleteStale(before time.Time) (int, error) {
+	max := float64(before.Unix())
+	stale := bleve.NewNumericRangeQuery(nil, &max)
+	stale.SetField("synced")
+
+	deleted := 0
+	for {
+		res, err := b.index.Search(bleve.NewSearchRequestOptions(stale, staleDeleteBatch, 0, false))
+		if err != nil {
+			return deleted, err
+		}
+		if len(res.Hits) == 0 {
+			return deleted, nil
+		}
+
+		batch := b.index.NewBatch()
+		for _, hit := range res.Hits {
+			batch.Delete(hit.ID)
+		}
+		if err := b.index.Batch(batch); err != nil {
+			return deleted, err
+		}
+		deleted += len(res.Hits)
+	}
+}
+
+// Search requires every word of q to match name or an alias, allowing typos in proportion to
+// the word's length and treating the last word as a prefix while the user is still typing.
+// An exact phrase match on the name ranks first.
+func (b *bleveIndex) Search(q string, types []string, limit, offset int) ([]models.SearchHit, int, error) {
+	words, err := b.analyze(q)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(words) == 0 {
+		return []models.SearchHit{}, 0, nil
+	}
+
+	must := make([]query.Query, 0, len(words)+1)
+	for i, word := range words {
+		var alternatives []query.Query
+		for _, field := range searchFields {
+			alternatives = append(alternatives, wordQuery(word, field.name, field.boost))
+
+			if i == len(words)-1 {
+				prefix := bleve.NewPrefixQuery(word)
+				prefix.SetField(field.name)
+				prefix.SetBoost(field.boost)
+				alternatives = append(alternatives, prefix)
+			}
+		}
+		must = append(must, bleve.NewDisjunctionQuery(alternatives...))
+	}
+
+	must = append(must, typeFilter(types))
+
+	phrase := bleve.NewMatchPhraseQuery(q)
+	phrase.SetField("name")
+	phrase.SetBoost(4)
+
+	return b.run(query.NewBooleanQuery(must, []query.Query{phrase}, nil), limit, offset)
+}
+
+// Suggest requires every word of prefix to start a word of the name or an alias
+func (b *bleveIndex) Suggest(prefix string, types []string, limit int) ([]models.SearchHit, error) {
+	words, err := b.analyze(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return []models.SearchHit{}, nil
+	}
+
+	must := make([]query.Query, 0, len(words)+1)
+	for _, word := range words {
+		var alternatives []query.Query
+		for _, field := range searchFields {
+			q := bleve.NewPrefixQuery(word)
+			q.SetField(field.name)
+			q.SetBoost(field.boost)
+			alternatives = append(alternatives, q)
+		}
+		must = append(must, bleve.NewDisjunctionQuery(alternatives...))
+	}
+	must = append(must, typeFilter(types))
+
+	hits, _, err := b.run(bleve.NewConjunctionQuery(must...), limit, 0)
+	return hits, err
+}
+
+// typeFilter matches documents of any of types
+func typeFilter(types []string) query.Query {
+	queries := make([]query.Query, len(types))
+	for i, t := range types {
+		q := bleve.NewTermQuery(t)
+		q.SetField("type")
+		queries[i] = q
+	}
+	return bleve.NewDisjunctionQuery(queries...)
+}
+
+// run executes q and reads the stored fields of each hit
+func (b *bleveIndex) run(q query.Query, limit, offset int) ([]models.SearchHit, int, error) {
+	req := bleve.NewSearchRequestOptions(q, limit, offset, false)
+	req.Fields = []string{"type", "id", "name", "detail"}
+	res, err := b.index.Search(req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]models.SearchHit, 0, len(res.Hits))
+	for _, h := range res.Hits {
+		hit := models.SearchHit{Score: h.Score}
+		hit.Type, _ = h.Fields["type"].(string)
+		hit.ID, _ = h.Fields["id"].(string)
+		hit.Name, _ = h.Fields["name"].(string)
+		hit.Detail, _ = h.Fields["detail"].(string)
+		hits = append(hits, hit)
+	}
+	return hits, int(res.Total), nil
+}
+
+// wordQuery matches word in field with the typos its length allows. Bleve rejects fuzzy
+// queries without edits, so short words match exactly.
+func wordQuery(word, field string, boost float64) query.Query {
+	if n := fuzziness(word); n > 0 {
+		q := bleve.NewFuzzyQuery(word)
+		q.SetField(field)
+		q.SetFuzziness(n)
+		q.SetBoost(boost)
+		return q
+	}
+	q := bleve.NewTermQuery(word)
+	q.SetField(field)
+	q.SetBoost(boost)
+	return q
+}
+
+// analyze folds q the way names were indexed; fuzzy and prefix queries are not analyzed
+func (b *bleveIndex) analyze(q string) ([]string, error) {
+	analyzer := b.index.Mapping().AnalyzerNamed(foldedAnalyzer)
+	if analyzer == nil {
+		return nil, fmt.Errorf("analyzer %q missing from index mapping", foldedAnalyzer)
+	}
+
+	var words []string
+	for _, token := range analyzer.Analyze([]byte(q)) {
+		words = append(words, string(token.Term))
+	}
+	return words, nil
+}
+
+// fuzziness allows one typo in medium words and two in long ones, like Elasticsearch's AUTO
+func fuzziness(word string) int {
+	switch n := len([]rune(word)); {
+	case n <= 2:
+		return 0
+	case n <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (b *bleveIndex) Count() (int, error) {
+	n, err := b.index.DocCount()
+	return int(n), err
+}
+
+func (b *bleveIndex) LastSync() (time.Time, error) {
+	raw, err := b.index.GetInternal(lastSyncKey)
+	if err != nil || raw == nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339Nano, string(raw))
+}
+
+func (b *bleveIndex) SetLastSync(t time.Time) error {
+	return b.index.SetInternal(lastSyncKey, []byte(t.UTC().Format(time.RFC3339Nano)))
+}
+
+func (b *bleveIndex) Close() error {
+	return b.index.Close()
+}
@@ -0,0 +1,301 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"political-network-api/internal/models"
+	"strings"
+	"time"
+)
+
+// elasticSyncDoc holds the sync watermark; its type keeps it out of searches and counts
+const elasticSyncDoc = "sync-state"
+
+// elasticIndexSettings fold accents and case the way the Bleve backend does
+var elasticIndexSettings = map[string]interface{}{
+	"settings": map[string]interface{}{
+		"analysis": map[string]interface{}{
+			"analyzer": map[string]interface{}{
+				foldedAnalyzer: map[string]interface{}{
+					"type":      "custom",
+					"tokenizer": "standard",
+					"filter":    []string{"lowercase", "asciifolding"},
+				},
+			},
+		},
+	},
+	"mappings": map[string]interface{}{
+		"properties": map[string]interface{}{
+			"type":    map[string]string{"type": "keyword"},
+			"id":      map[string]string{"type": "keyword"},
+			"name":    map[string]string{"type": "text", "analyzer": foldedAnalyzer},
+			"aliases": map[string]string{"type": "text", "analyzer": foldedAnalyzer},
+			"detail":  map[string]interface{}{"type": "text", "index": false},
+			"synced":  map[string]string{"type": "date"},
+		},
+	},
+}
+
+// elasticIndex talks to Elasticsearch's REST API. Credentials in the URL are sent as basic
+// auth.
+type elasticIndex struct {
+	base   string
+	index  string
+	client *http.Client
+}
+
+// openElastic connects to the cluster at rawURL, creating index on first use
+func openElastic(rawURL, index string) (*elasticIndex, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	e := &elasticIndex{
+		base:   strings.TrimSuffix(u.String(), "/"),
+		index:  url.PathEscape(index),
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+
+	status, err := e.do(http.MethodHead, "/"+e.index, nil, nil)
+	if err != nil && status != http.StatusNotFound {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		if _, err := e.do(http.MethodPut, "/"+e.index, elasticIndexSettings, nil); err != nil {
+			return nil, fmt.Errorf("failed to create index %s: %w", index, err)
+		}
+	}
+	return e, nil
+}
+
+// do sends a JSON request and decodes the response into out when it is not nil. Non-2xx
+// responses are returned as errors along with their status.
+func (e *elasticIndex) do(method, path string, body, out interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	return e.send(method, path, "application/json", reader, out)
+}
+
+func (e *elasticIndex) send(method, path, contentType string, body io.Reader, out interface{}) (int, error) {
+	req, err := http.NewRequest(method, e.base+path, body)
+	if err != nil {
+		return 0, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return resp.StatusCode, fmt.Errorf("elasticsearch %s %s: %s: %s", method, path, resp.Status, msg)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, err
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+func (e *elasticIndex) Upsert(docs []models.SearchDocument, synced time.Time) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, doc := range docs {
+		action := map[string]interface{}{"index": map[string]string{"_id": docID(doc)}}
+		source := map[string]interface{}{
+			"type":    doc.Type,
+			"id":      doc.ID,
+			"name":    doc.Name,
+			"aliases": doc.Aliases,
+			"detail":  doc.Detail,
+			"synced":  synced.UTC().Format(time.RFC3339),
+		}
+		if err := enc.Encode(action); err != nil {
+			return err
+		}
+		if err := enc.Encode(source); err != nil {
+			return err
+		}
+	}
+
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Error json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if _, err := e.send(http.MethodPost, "/"+e.index+"/_bulk", "application/x-ndjson", &body, &result); err != nil {
+		return err
+	}
+	if result.Errors {
+		for _, item := range result.Items {
+			for _, op := range item {
+				if len(op.Error) > 0 {
+					return fmt.Errorf("elasticsearch bulk index failed: %s", op.Error)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// entityFilter limits a query to indexed entities, leaving out the sync watermark
+func entityFilter(types []string) map[string]interface{} {
+	return map[string]interface{}{"terms": map[string]interface{}{"type": types}}
+}
+
+func (e *elasticIndex) DeleteStale(before time.Time) (int, error) {
+	body := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": []interface{}{
+					entityFilter(models.SearchTypes),
+					map[string]interface{}{"range": map[string]interface{}{
+						"synced": map[string]string{"lt": before.UTC().Format(time.RFC3339)},
+					}},
+				},
+			},
+		},
+	}
+
+	var result struct {
+		Deleted int `json:"deleted"`
+	}
+	_, err := e.do(http.MethodPost, "/"+e.index+"/_delete_by_query?conflicts=proceed", body, &result)
+	return result.Deleted, err
+}
+
+// Search requires every word of q to match name or an alias with AUTO fuzziness; names
+// starting with the phrase as typed rank first
+func (e *elasticIndex) Search(q string, types []string, limit, offset int) ([]models.SearchHit, int, error) {
+	body := map[string]interface{}{
+		"from":             offset,
+		"size":             limit,
+		"track_total_hits": true,
+		"_source":          []string{"type", "id", "name", "detail"},
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": map[string]interface{}{
+					"multi_match": map[string]interface{}{
+						"query":     q,
+						"fields":    []string{"name^2", "aliases"},
+						"fuzziness": "AUTO",
+						"operator":  "and",
+					},
+				},
+				"should": map[string]interface{}{
+					"match_phrase_prefix": map[string]interface{}{
+						"name": map[string]interface{}{"query": q, "boost": 4},
+					},
+				},
+				"filter": entityFilter(types),
+			},
+		},
+	}
+
+	return e.run(body)
+}
+
+// Suggest requires every word of prefix to start a word of the name or an alias
+func (e *elasticIndex) Suggest(prefix string, types []string, limit int) ([]models.SearchHit, error) {
+	body := map[string]interface{}{
+		"size":             limit,
+		"track_total_hits": false,
+		"_source":          []string{"type", "id", "name", "detail"},
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": map[string]interface{}{
+					"multi_match": map[string]interface{}{
+						"query":    prefix,
+						"type":     "bool_prefix",
+						"fields":   []string{"name^2", "aliases"},
+						"operator": "and",
+					},
+				},
+				"filter": entityFilter(types),
+			},
+		},
+	}
+
+	hits, _, err := e.run(body)
+	return hits, err
+}
+
+// run posts a _search body and reads its hits and total
+func (e *elasticIndex) run(body map[string]interface{}) ([]models.SearchHit, int, error) {
+	var result struct {
+		Hits struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Score  float64          `json:"_score"`
+				Source models.SearchHit `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if _, err := e.do(http.MethodPost, "/"+e.index+"/_search", body, &result); err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]models.SearchHit, 0, len(result.Hits.Hits))
+	for _, h := range result.Hits.Hits {
+		hit := h.Source
+		hit.Score = h.Score
+		hits = append(hits, hit)
+	}
+	return hits, result.Hits.Total.Value, nil
+}
+
+func (e *elasticIndex) Count() (int, error) {
+	var result struct {
+		Count int `json:"count"`
+	}
+	body := map[string]interface{}{"query": entityFilter(models.SearchTypes)}
+	_, err := e.do(http.MethodPost, "/"+e.index+"/_count", body, &result)
+	return result.Count, err
+}
+
+func (e *elasticIndex) LastSync() (time.Time, error) {
+	var result struct {
+		Source struct {
+			Synced time.Time `json:"synced"`
+		} `json:"_source"`
+	}
+	status, err := e.do(http.MethodGet, "/"+e.index+"/_doc/"+elasticSyncDoc, nil, &result)
+	if status == http.StatusNotFound {
+		return time.Time{}, nil
+	}
+	return result.Source.Synced, err
+}
+
+func (e *elasticIndex) SetLastSync(t time.Time) error {
+	body := map[string]interface{}{"type": "meta", "synced": t.UTC().Format(time.RFC3339Nano)}
+	_, err := e.do(http.MethodPut, "/"+e.index+"/_doc/"+elasticSyncDoc, body, nil)
+	return err
+}
+
+func (e *elasticIndex) Close() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,141 @@
+// Package search keeps the full-text index behind /api/search: fuzzy, accent- and
+// case-insensitive matching of politician, party and company names, in an embedded Bleve
+// index or an external Elasticsearch cluster
+package search
+
+import (
+	"errors"
+	"log"
+	"political-network-api/internal/config"
+	"political-network-api/internal/models"
+	"time"
+)
+
+// ErrDisabled is returned when search.backend is none or the index failed to open
+var ErrDisabled = errors.New("search is not enabled")
+
+// index is a search backend. Documents are keyed by type and ID and stamped with the sync
+// that last wrote them, so a full reindex can drop entities deleted from the database.
+type index interface {
+	Upsert(docs []models.SearchDocument, synced time.Time) error
+	DeleteStale(before time.Time) (int, error)
+	Search(q string, types []string, limit, offset int) ([]models.SearchHit, int, error)
+	Suggest(prefix string, types []string, limit int) ([]models.SearchHit, error)
+	Count() (int, error)
+	LastSync() (time.Time, error)
+	SetLastSync(t time.Time) error
+	Close() error
+}
+
+// active is set once by Open, before the server starts
+var active index
+
+// Open connects the configured backend. A backend that fails to open is logged and search
+// stays disabled, so the rest of the API still starts.
+func Open(cfg config.SearchConfig) {
+	var err error
+	switch cfg.Backend {
+	case config.SearchBleve:
+		active, err = openBleve(cfg.Path)
+	case config.SearchElasticsearch:
+		active, err = openElastic(cfg.ElasticsearchURL, cfg.Index)
+	default:
+		log.Println("⏸️ Full-text search disabled")
+		return
+	}
+
+	if err != nil {
+		active = nil
+		log.Printf("⚠️ Full-text search disabled, %s index failed to open: %v", cfg.Backend, err)
+		return
+	}
+	log.Printf("🔎 Full-text search using %s", cfg.Backend)
+}
+
+// Close releases the index
+func Close() error {
+	if active == nil {
+		return nil
+	}
+	return active.Close()
+}
+
+// Enabled reports whether an index is open
+func Enabled() bool {
+	return active != nil
+}
+
+// Shared reports whether the index is shared by every API instance (Elasticsearch), so one
+// instance syncing it is enough; an embedded Bleve index belongs to its instance
+func Shared() bool {
+	_, ok := active.(*elasticIndex)
+	return ok
+}
+
+// Search returns the best matches for q among types (all when empty), and how many matched
+func Search(q string, types []string, limit, offset int) ([]models.SearchHit, int, error) {
+	if active == nil {
+		return nil, 0, ErrDisabled
+	}
+	if len(types) == 0 {
+		types = models.SearchTypes
+	}
+	return active.Search(q, types, limit, offset)
+}
+
+// Suggest returns the entities whose names start with the words of prefix, for type-ahead.
+// Unlike Search it allows no typos, which keeps it fast enough to run on every keystroke.
+func Suggest(prefix string, types []string, limit int) ([]models.SearchHit, error) {
+	if active == nil {
+		return nil, ErrDisabled
+	}
+	if len(types) == 0 {
+		types = models.SearchTypes
+	}
+	return active.Suggest(prefix, types, limit)
+}
+
+// Upsert adds or replaces docs, stamped with the sync writing them
+func Upsert(docs []models.SearchDocument, synced time.Time) error {
+	if active == nil {
+		return ErrDisabled
+	}
+	return active.Upsert(docs, synced)
+}
+
+// DeleteStale removes documents no sync has written since before
+func DeleteStale(before time.Time) (int, error) {
+	if active == nil {
+		return 0, ErrDisabled
+	}
+	return active.DeleteStale(before)
+}
+
+// Count returns how many entities are indexed
+func Count() (int, error) {
+	if active == nil {
+		return 0, ErrDisabled
+	}
+	return active.Count()
+}
+
+// LastSync returns when the index was last brought up to date, zero if it never was
+func LastSync() (time.Time, error) {
+	if active == nil {
+		return time.Time{}, ErrDisabled
+	}
+	return active.LastSync()
+}
+
+// SetLastSync records that the index holds every change up to t
+func SetLastSync(t time.Time) error {
+	if active == nil {
+		return ErrDisabled
+	}
+	return active.SetLastSync(t)
+}
+
+// docID keys a document by entity type, since party and politician IDs overlap
+func docID(doc models.SearchDocument) string {
+	return doc.Type + ":" + doc.ID
+}
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"political-network-api/internal/config"
+	"political-network-api/internal/models"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// saturatedRetryAfter is how long clients turned away by a full route are told to wait
+const saturatedRetryAfter = 5 * time.Second
+
+// inFlight counts the running requests per requests.max_in_flight pattern
+var inFlight = struct {
+	sync.Mutex
+	running map[string]int
+}{running: map[string]int{}}
+
+// guard bounds every request by the requests settings, read per request so a SIGHUP reload
+// applies immediately. Handlers don't take contexts down to the database, so a timed-out handler
+// can't be stopped: it runs on in its own goroutine, its late writes are dropped, and it holds its
+// max_in_flight slot until it returns, so a pileup can't grow past the cap.
+func guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cfg := config.Get().Requests
+
+		release := func() {}
+		if pattern, ok := config.MatchRoute(r.URL.Path, cfg.MaxInFlight); ok {
+			if !acquire(pattern, cfg.MaxInFlight[pattern]) {
+				w.Header().Set("Retry-After", strconv.Itoa(int(saturatedRetryAfter/time.Second)))
+				writeError(w, http.StatusServiceUnavailable, "Too many requests in progress for this endpoint; retry shortly")
+				return
+			}
+			release = func() { releaseSlot(pattern) }
+		}
+
+		timeout := cfg.Timeout(r.URL.Path)
+		if timeout == 0 {
+			defer release()
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		gw := &guardedWriter{w: w, header: http.Header{}}
+		done := make(chan struct{})
+		panicked := make(chan interface{}, 1)
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					panicked <- p
+				}
+				release()
+				close(done)
+			}()
+			next.ServeHTTP(gw, r.WithContext(ctx))
+		}()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			// A client that went away, or a response already under way, is left to finish
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) && r.Context().Err() == nil && gw.timeOut() {
+				log.Printf("⏱️ %s %s timed out after %s", r.Method, r.URL.Path, timeout)
+				writeError(w, http.StatusGatewayTimeout, "Request timed out after "+timeout.String())
+				return
+			}
+			<-done
+		}
+		select {
+		case p := <-panicked:
+			panic(p)
+		default:
+		}
+	})
+}
+
+// acquire takes one of max slots for pattern, reporting false when all are in use
+func acquire(pattern string, max int) bool {
+	inFlight.Lock()
+	defer inFlight.Unlock()
+	if inFlight.running[pattern] >= max {
+		return false
+	}
+	inFlight.running[pattern]++
+	return true
+}
+
+func releaseSlot(pattern string) {
+	inFlight.Lock()
+	defer inFlight.Unlock()
+	if inFlight.running[pattern]--; inFlight.running[pattern] <= 0 {
+		delete(inFlight.running, pattern)
+	}
+}
+
+// guardedWriter passes a handler's response through unless the request has timed out first.
+// Headers are kept apart until the response starts, so the handler and the timeout response
+// never share them.
+type guardedWriter struct {
+	w      http.ResponseWriter
+	header http.Header
+
+	mu       sync.Mutex
+	started  bool
+	timedOut bool
+}
+
+func (g *guardedWriter) Header() http.Header {
+	return g.header
+}
+
+func (g *guardedWriter) WriteHeader(status int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.timedOut {
+		return
+	}
+	g.start()
+	g.w.WriteHeader(status)
+}
+
+func (g *guardedWriter) Write(data []byte) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	g.start()
+	return g.w.Write(data)
+}
+
+func (g *guardedWriter) Flush() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if flusher, ok := g.w.(http.Flusher); ok && !g.timedOut {
+		g.start()
+		flusher.Flush()
+	}
+}
+
+// CloseNotify is used by gin's Context.Stream
+func (g *guardedWriter) CloseNotify() <-chan bool {
+	return g.w.(http.CloseNotifier).CloseNotify()
+}
+
+// start copies the handler's headers out as its response begins; g.mu is held
+func (g *guardedWriter) start() {
+	if g.started {
+		return
+	}
+	g.started = true
+	header := g.w.Header()
+	for name, values := range g.header {
+		header[name] = values
+	}
+}
+
+// timeOut drops the handler's response from now on, unless it has already started
+func (g *guardedWriter) timeOut() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.started {
+		return false
+	}
+	g.timedOut = true
+	return true
+}
+
+// writeError answers in the APIResponse shape the handlers use
+func writeError(w http.ResponseWriter, status int, message string) {
+	body, _ := json.Marshal(models.APIResponse{Success: false, Error: message, Time: "0ms"})
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	w.Write(body)
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"political-network-api/internal/config"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGuardTimesOutAndCapsInFlight(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("REQUEST_TIMEOUT", "50ms")
+	if _, err := config.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	unblock := make(chan struct{})
+	handler := guard(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+		w.Header().Set("X-Late", "1")
+		w.Write([]byte("late"))
+	}))
+
+	// A slow handler past the default timeout gets a 504, and its late response is dropped
+	start := time.Now()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/stats", nil))
+	if w.Code != http.StatusGatewayTimeout || time.Since(start) > time.Second {
+		t.Fatalf("got %d after %s, want 504 after about 50ms", w.Code, time.Since(start))
+	}
+
+	// /api/connections allows 8 requests at once; the ninth is turned away with Retry-After
+	max := config.Get().Requests.MaxInFlight["/api/connections"]
+	var wg sync.WaitGroup
+	for i := 0; i < max; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/connections", nil))
+		}()
+	}
+	for deadline := time.Now().Add(time.Second); ; {
+		inFlight.Lock()
+		running := inFlight.running["/api/connections"]
+		inFlight.Unlock()
+		if running == max || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/connections", nil))
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") == "" {
+		t.Errorf("got %d with Retry-After %q, want 503 with Retry-After", w.Code, w.Header().Get("Retry-After"))
+	}
+
+	close(unblock)
+	wg.Wait()
+}
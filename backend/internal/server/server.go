@@ -0,0 +1,122 @@
+// Package server runs the API's HTTP listener: plain HTTP, or HTTPS with certificates from an
+// ACME CA when tls.enabled is set, with every request bounded by the requests settings.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"political-network-api/internal/config"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const (
+	// readHeaderTimeout drops connections that are slow to send a request's headers
+	readHeaderTimeout = 10 * time.Second
+	// shutdownTimeout is how long in-flight requests get to finish once ctx is done
+	shutdownTimeout = 30 * time.Second
+)
+
+// Run serves handler on server.host:server.port until ctx is done or the listener fails. With
+// tls.enabled the listener speaks HTTPS, and a second one on tls.http_port answers ACME
+// challenges and redirects to it. Once ctx is done it stops accepting connections and waits up
+// to shutdownTimeout for in-flight requests before returning nil.
+func Run(ctx context.Context, handler http.Handler, cfg *config.Config) error {
+	srv := &http.Server{
+		Addr:              fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
+		Handler:           guard(handler),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	servers := []*http.Server{srv}
+	if !cfg.TLS.Enabled {
+		return serveUntil(ctx, servers, srv.ListenAndServe)
+	}
+
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(cfg.TLS.Domains...),
+		Cache:      autocert.DirCache(cfg.TLS.CacheDir),
+		Email:      cfg.TLS.Email,
+	}
+	if cfg.TLS.ACMEDirectory != "" {
+		manager.Client = &acme.Client{DirectoryURL: cfg.TLS.ACMEDirectory}
+	}
+	srv.TLSConfig = manager.TLSConfig()
+
+	if cfg.TLS.HTTPPort != 0 {
+		redirect := &http.Server{
+			Addr:              fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.TLS.HTTPPort),
+			Handler:           manager.HTTPHandler(redirectToHTTPS(cfg.TLS.Domains, cfg.Server.Port)),
+			ReadHeaderTimeout: readHeaderTimeout,
+		}
+		servers = append(servers, redirect)
+		go func() {
+			log.Printf("🔀 Redirecting HTTP on %s to HTTPS", redirect.Addr)
+			if err := redirect.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatalf("❌ Failed to start HTTP redirect: %v", err)
+			}
+		}()
+	}
+
+	log.Printf("🔒 Serving HTTPS for %s, certificates cached in %s", strings.Join(cfg.TLS.Domains, ", "), cfg.TLS.CacheDir)
+	return serveUntil(ctx, servers, func() error { return srv.ListenAndServeTLS("", "") })
+}
+
+// serveUntil runs serve, which listens with servers[0], until it fails or ctx is done, and then
+// shuts servers down gracefully
+func serveUntil(ctx context.Context, servers []*http.Server, serve func() error) error {
+	failed := make(chan error, 1)
+	go func() {
+		failed <- serve()
+	}()
+
+	select {
+	case err := <-failed:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("⏳ Waiting up to %s for in-flight requests", shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	var errs []error
+	for _, s := range servers {
+		if err := s.Shutdown(shutdownCtx); err != nil {
+			errs = append(errs, fmt.Errorf("shutting down %s: %w", s.Addr, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// redirectToHTTPS permanently redirects requests to the same URL over HTTPS on port. Hosts other
+// than domains, which certificates can't be issued for, go to the first domain.
+func redirectToHTTPS(domains []string, port int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if !slices.ContainsFunc(domains, func(domain string) bool { return strings.EqualFold(domain, host) }) {
+			host = domains[0]
+		}
+		if port != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(port))
+		}
+
+		// 308 keeps the method and body of a POST, which clients may turn into a GET on a 301
+		status := http.StatusMovedPermanently
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			status = http.StatusPermanentRedirect
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), status)
+	})
+}
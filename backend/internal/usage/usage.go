@@ -0,0 +1,218 @@
+package usage
+
+import (
+	"net/http"
+	"political-network-api/internal/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Retention is how much per-minute history is kept in memory
+const Retention = 24 * time.Hour
+
+// latencyBounds are the histogram bucket edges, in milliseconds; slower requests land in a
+// final overflow bucket
+var latencyBounds = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// counter aggregates requests for one route or consumer in one minute
+type counter struct {
+	requests, clientErrors, serverErrors, rateLimited int64
+	total, max                                        time.Duration
+	histogram                                         [12]int64 // len(latencyBounds) + overflow
+}
+
+func (c *counter) add(status int, latency time.Duration) {
+	c.requests++
+	switch {
+	case status == http.StatusTooManyRequests:
+		c.rateLimited++
+		c.clientErrors++
+	case status >= 500:
+		c.serverErrors++
+	case status >= 400:
+		c.clientErrors++
+	}
+
+	c.total += latency
+	if latency > c.max {
+		c.max = latency
+	}
+	ms := float64(latency) / float64(time.Millisecond)
+	i := sort.SearchFloat64s(latencyBounds, ms)
+	c.histogram[i]++
+}
+
+func (c *counter) merge(o *counter) {
+	c.requests += o.requests
+	c.clientErrors += o.clientErrors
+	c.serverErrors += o.serverErrors
+	c.rateLimited += o.rateLimited
+	c.total += o.total
+	if o.max > c.max {
+		c.max = o.max
+	}
+	for i := range c.histogram {
+		c.histogram[i] += o.histogram[i]
+	}
+}
+
+func (c *counter) stats() models.UsageStats {
+	s := models.UsageStats{
+		Requests:     c.requests,
+		ClientErrors: c.clientErrors,
+		ServerErrors: c.serverErrors,
+		RateLimited:  c.rateLimited,
+		MaxMs:        milliseconds(c.max),
+	}
+	if c.requests == 0 {
+		return s
+	}
+	s.AvgMs = milliseconds(c.total / time.Duration(c.requests))
+
+	// The 95th percentile falls in the first bucket whose running count reaches 95%
+	target, seen := (c.requests*95+99)/100, int64(0)
+	s.P95Ms = s.MaxMs
+	for i, n := range c.histogram[:len(latencyBounds)] {
+		if seen += n; seen >= target {
+			s.P95Ms = min(latencyBounds[i], s.MaxMs)
+			break
+		}
+	}
+	return s
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// key identifies who called which route
+type key struct {
+	route, actor string
+}
+
+// store keeps per-minute counters for Retention
+type store struct {
+	mu      sync.Mutex
+	minutes map[int64]map[key]*counter // keyed by Unix minute
+}
+
+var requests = &store{minutes: map[int64]map[key]*counter{}}
+
+// Record counts one finished request. route is the method and route pattern, actor who made it.
+func Record(route, actor string, status int, latency time.Duration, at time.Time) {
+	requests.mu.Lock()
+	defer requests.mu.Unlock()
+
+	minute := at.Unix() / 60
+	counters, ok := requests.minutes[minute]
+	if !ok {
+		counters = map[key]*counter{}
+		requests.minutes[minute] = counters
+
+		// A new minute is the only time history grows, so prune then
+		oldest := at.Add(-Retention).Unix() / 60
+		for m := range requests.minutes {
+			if m < oldest {
+				delete(requests.minutes, m)
+			}
+		}
+	}
+
+	k := key{route, actor}
+	c, ok := counters[k]
+	if !ok {
+		c = &counter{}
+		counters[k] = c
+	}
+	c.add(status, latency)
+}
+
+// Summarize aggregates the traffic since the given time into buckets of the given size (whole
+// minutes), with per-route totals and the top consumers. A non-empty route restricts the
+// summary to that route.
+func Summarize(since time.Time, bucket time.Duration, route string, top int, now time.Time) models.UsageSummary {
+	requests.mu.Lock()
+	defer requests.mu.Unlock()
+
+	from := since.Truncate(time.Minute)
+	summary := models.UsageSummary{
+		From:      from,
+		To:        now,
+		Bucket:    bucket.String(),
+		Route:     route,
+		Buckets:   []models.UsageBucket{},
+		Routes:    []models.RouteUsage{},
+		Consumers: []models.ConsumerUsage{},
+	}
+
+	bucketMinutes := int64(bucket / time.Minute)
+	buckets := map[int64]*counter{}
+	routes := map[string]*counter{}
+	consumers := map[string]*counter{}
+	totals := &counter{}
+	merge := func(into map[string]*counter, name string, c *counter) {
+		if into[name] == nil {
+			into[name] = &counter{}
+		}
+		into[name].merge(c)
+	}
+
+	first, last := from.Unix()/60, now.Unix()/60
+	for minute, counters := range requests.minutes {
+		if minute < first || minute > last {
+			continue
+		}
+
+		b := first + (minute-first)/bucketMinutes*bucketMinutes
+		if buckets[b] == nil {
+			buckets[b] = &counter{}
+		}
+		for k, c := range counters {
+			if route != "" && k.route != route {
+				continue
+			}
+			buckets[b].merge(c)
+			totals.merge(c)
+			merge(routes, k.route, c)
+			merge(consumers, k.actor, c)
+		}
+	}
+
+	// Every bucket is listed, empty ones included, so the series can be charted directly
+	for b := first; b <= last; b += bucketMinutes {
+		c := buckets[b]
+		if c == nil {
+			c = &counter{}
+		}
+		summary.Buckets = append(summary.Buckets, models.UsageBucket{Start: time.Unix(b*60, 0).UTC(), UsageStats: c.stats()})
+	}
+
+	summary.Totals = totals.stats()
+	for name, c := range routes {
+		summary.Routes = append(summary.Routes, models.RouteUsage{Route: name, UsageStats: c.stats()})
+	}
+	sort.Slice(summary.Routes, func(i, j int) bool {
+		a, b := summary.Routes[i], summary.Routes[j]
+		if a.Requests != b.Requests {
+			return a.Requests > b.Requests
+		}
+		return a.Route < b.Route
+	})
+
+	for actor, c := range consumers {
+		summary.Consumers = append(summary.Consumers, models.ConsumerUsage{Actor: actor, UsageStats: c.stats()})
+	}
+	sort.Slice(summary.Consumers, func(i, j int) bool {
+		a, b := summary.Consumers[i], summary.Consumers[j]
+		if a.Requests != b.Requests {
+			return a.Requests > b.Requests
+		}
+		return a.Actor < b.Actor
+	})
+	if len(summary.Consumers) > top {
+		summary.Consumers = summary.Consumers[:top]
+	}
+
+	return summary
+}
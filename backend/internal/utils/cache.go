@@ -1,8 +1,20 @@
 package utils
 
 import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"log"
+	"political-network-api/internal/config"
+	"political-network-api/internal/models"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/patrickmn/go-cache"
@@ -10,27 +22,150 @@ import (
 
 var Cache *cache.Cache
 
+// staleCache keeps a copy of the values SetCache stores for cache.stale_ttl, well past their
+// TTL, to answer reads while the database is unavailable. It holds at most
+// cache.stale_max_entries copies: parameterized keys are unbounded, and each copy outlives its
+// entry by a day.
+var staleCache *cache.Cache
+
+// staleMu serializes stale copy inserts, so the cap holds under concurrent SetCache calls
+var staleMu sync.Mutex
+
+// staleItem is a stale copy and when it was cached
+type staleItem struct {
+	value    interface{}
+	storedAt time.Time
+}
+
+// compressedValue is a value SetCacheCompressed stored as gzipped JSON, and its JSON size
+type compressedValue struct {
+	data []byte
+	size int
+}
+
+// Cache counters since startup
+var (
+	cacheHits    atomic.Int64
+	cacheMisses  atomic.Int64
+	cacheRemoved atomic.Int64 // every removal reported by go-cache, expired or deleted
+	cacheDeletes atomic.Int64 // explicit DeleteCache calls
+)
+
 // InitializeCache sets up in-memory cache for high performance
 func InitializeCache() {
-	// Cache with 30-minute default expiration and 5-minute cleanup interval
-	Cache = cache.New(30*time.Minute, 5*time.Minute)
+	// Configured default expiration (30 minutes unless overridden) and 5-minute cleanup interval
+	Cache = cache.New(config.Get().Cache.DefaultTTL, 5*time.Minute)
+	Cache.OnEvicted(func(string, interface{}) { cacheRemoved.Add(1) })
+	staleCache = cache.New(cache.NoExpiration, 10*time.Minute)
 	log.Println("✅ Cache initialized")
 }
 
 // Get retrieves data from cache
 func GetCache(key string) (interface{}, bool) {
-	return Cache.Get(key)
+	value, found := Cache.Get(key)
+	if found {
+		cacheHits.Add(1)
+	} else {
+		cacheMisses.Add(1)
+	}
+	return value, found
 }
 
-// Set stores data in cache
+// Set stores data in cache, and a stale copy of it
 func SetCache(key string, data interface{}, duration time.Duration) {
 	Cache.Set(key, data, duration)
+	if cfg := config.Get().Cache; cfg.StaleTTL > 0 {
+		setStale(key, staleItem{value: data, storedAt: time.Now()}, cfg.StaleTTL, cfg.StaleMaxEntries)
+	}
+}
+
+// setStale stores a stale copy under key, first dropping the oldest copies when a new key would
+// take the stale cache past max entries
+func setStale(key string, item staleItem, ttl time.Duration, max int) {
+	staleMu.Lock()
+	defer staleMu.Unlock()
+
+	if _, found := staleCache.Get(key); !found {
+		items := staleCache.Items()
+		if excess := len(items) - max + 1; excess > 0 {
+			keys := make([]string, 0, len(items))
+			for k := range items {
+				keys = append(keys, k)
+			}
+			sort.Slice(keys, func(i, j int) bool {
+				return items[keys[i]].Object.(staleItem).storedAt.Before(items[keys[j]].Object.(staleItem).storedAt)
+			})
+			for _, k := range keys[:excess] {
+				staleCache.Delete(k)
+			}
+		}
+	}
+	staleCache.Set(key, item, ttl)
+}
+
+// SetCacheCompressed stores data like SetCache, but as gzipped JSON when cache.compress is on:
+// for large object graphs that is a fraction of the memory, paid for by a decode on every hit.
+// Read values back with CacheValue.
+func SetCacheCompressed(key string, data interface{}, duration time.Duration) error {
+	if !config.Get().Cache.Compress {
+		SetCache(key, data, duration)
+		return nil
+	}
+	compressed, err := compress(data)
+	if err != nil {
+		return fmt.Errorf("caching %s: %w", key, err)
+	}
+	SetCache(key, compressed, duration)
+	return nil
+}
+
+// compress encodes value as gzipped JSON
+func compress(value interface{}) (compressedValue, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return compressedValue{}, err
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(encoded)
+	if err := zw.Close(); err != nil {
+		return compressedValue{}, err
+	}
+	return compressedValue{data: buf.Bytes(), size: len(encoded)}, nil
+}
+
+// CacheValue returns a value read from the cache as a T, decoding it if SetCacheCompressed
+// compressed it. Each call decodes a fresh copy, which callers may modify.
+func CacheValue[T any](cached interface{}) (T, error) {
+	var value T
+	compressed, ok := cached.(compressedValue)
+	if !ok {
+		return cached.(T), nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed.data))
+	if err != nil {
+		return value, fmt.Errorf("decompressing cached value: %w", err)
+	}
+	if err := json.NewDecoder(zr).Decode(&value); err != nil {
+		return value, fmt.Errorf("decoding cached value: %w", err)
+	}
+	return value, nil
+}
+
+// GetStaleCache returns the last value SetCache stored under key, even when it has expired from
+// the cache, and when it was stored
+func GetStaleCache(key string) (interface{}, time.Time, bool) {
+	item, found := staleCache.Get(key)
+	if !found || config.Get().Cache.StaleTTL == 0 {
+		return nil, time.Time{}, false
+	}
+	return item.(staleItem).value, item.(staleItem).storedAt, true
 }
 
 // GetOrSet retrieves from cache or executes function and caches result
 func GetOrSet(key string, duration time.Duration, fn func() (interface{}, error)) (interface{}, error) {
 	// Try to get from cache first
-	if cached, found := Cache.Get(key); found {
+	if cached, found := GetCache(key); found {
 		return cached, nil
 	}
 
@@ -41,42 +176,184 @@ func GetOrSet(key string, duration time.Duration, fn func() (interface{}, error)
 	}
 
 	// Store in cache
-	Cache.Set(key, result, duration)
+	SetCache(key, result, duration)
 	return result, nil
 }
 
-// Delete removes item from cache
-func DeleteCache(key string) {
+// Delete removes item from cache, reporting whether it was present. Its stale copy goes too:
+// explicit deletes mean the data must not be served again.
+func DeleteCache(key string) bool {
+	staleCache.Delete(key)
+	if _, found := Cache.Get(key); !found {
+		return false
+	}
+	cacheDeletes.Add(1)
 	Cache.Delete(key)
+	return true
 }
 
-// FlushCache clears all cache
+// DeleteCachePrefix removes every key built by CacheKey from one of prefixes, plus keys equal
+// to a prefix, and returns how many were removed. Other replicas are told to do the same (see
+// OnCacheInvalidation).
+func DeleteCachePrefix(prefixes ...string) int {
+	removed := deleteCachePrefix(prefixes)
+	if hook := invalidationHook.Load(); hook != nil && len(prefixes) > 0 {
+		(*hook)(prefixes)
+	}
+	return removed
+}
+
+func deleteCachePrefix(prefixes []string) int {
+	removed := 0
+	for key := range Cache.Items() {
+		if hasCachePrefix(key, prefixes) && DeleteCache(key) {
+			removed++
+		}
+	}
+	for key := range staleCache.Items() {
+		if hasCachePrefix(key, prefixes) {
+			staleCache.Delete(key)
+		}
+	}
+	return removed
+}
+
+func hasCachePrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if key == prefix || strings.HasPrefix(key, prefix+"_") || strings.HasPrefix(key, prefix+"#") {
+			return true
+		}
+	}
+	return false
+}
+
+// FlushCache clears all cache, stale copies included, here and on other replicas (see
+// OnCacheInvalidation)
 func FlushCache() {
+	flushCache()
+	if hook := invalidationHook.Load(); hook != nil {
+		(*hook)(nil)
+	}
+}
+
+func flushCache() {
 	Cache.Flush()
+	staleCache.Flush()
 	log.Println("🧹 Cache flushed")
 }
 
-// GetCacheStats returns cache statistics
-func GetCacheStats() map[string]interface{} {
-	return map[string]interface{}{
-		"items": Cache.ItemCount(),
+// invalidationHook passes flushes (nil prefixes) and prefix deletes on to other replicas
+var invalidationHook atomic.Pointer[func(prefixes []string)]
+
+// OnCacheInvalidation registers publish to be called with the prefixes of every
+// DeleteCachePrefix, or nil for every FlushCache, so replicas sharing no cache can drop the
+// same entries. They apply what they receive with ApplyCacheInvalidation.
+func OnCacheInvalidation(publish func(prefixes []string)) {
+	invalidationHook.Store(&publish)
+}
+
+// ApplyCacheInvalidation drops the entries another replica invalidated: those under prefixes,
+// or all of them when prefixes is empty. It is not published again.
+func ApplyCacheInvalidation(prefixes []string) {
+	if len(prefixes) == 0 {
+		flushCache()
+		return
 	}
+	deleteCachePrefix(prefixes)
 }
 
-// CacheKey generates consistent cache keys
+// GetCacheStats returns hit/miss counters, the sizes of compressed entries and, when withKeys is
+// set, every key with its remaining TTL and approximate JSON-encoded size (encoding large values
+// is not free; compressed entries report their size in memory and their recorded JSON size)
+func GetCacheStats(withKeys bool) models.CacheStats {
+	hits, misses := cacheHits.Load(), cacheMisses.Load()
+	stats := models.CacheStats{
+		Items:     Cache.ItemCount(),
+		Hits:      hits,
+		Misses:    misses,
+		Evictions: cacheRemoved.Load() - cacheDeletes.Load(),
+		Deletes:   cacheDeletes.Load(),
+	}
+	if hits+misses > 0 {
+		stats.HitRatio = float64(hits) / float64(hits+misses)
+	}
+	items := Cache.Items()
+	for _, item := range items {
+		if compressed, ok := item.Object.(compressedValue); ok {
+			stats.CompressedItems++
+			stats.CompressedBytes += len(compressed.data)
+			stats.UncompressedBytes += compressed.size
+		}
+	}
+	if !withKeys {
+		return stats
+	}
+
+	now := time.Now()
+	for key, item := range items {
+		info := models.CacheKeyInfo{Key: key, SizeBytes: -1}
+		if compressed, ok := item.Object.(compressedValue); ok {
+			info.SizeBytes = len(compressed.data)
+			info.Compressed = true
+			info.UncompressedBytes = compressed.size
+		} else if b, err := json.Marshal(item.Object); err == nil {
+			info.SizeBytes = len(b)
+		}
+		if item.Expiration > 0 {
+			expiresAt := time.Unix(0, item.Expiration)
+			info.ExpiresAt = &expiresAt
+			info.TTL = expiresAt.Sub(now).Round(time.Second).String()
+		}
+		stats.Keys = append(stats.Keys, info)
+	}
+	sort.Slice(stats.Keys, func(i, j int) bool { return stats.Keys[i].SizeBytes > stats.Keys[j].SizeBytes })
+	return stats
+}
+
+// maxCacheKeyLength is the longest key kept readable; longer keys are hashed
+const maxCacheKeyLength = 200
+
+// CacheKey generates consistent cache keys: prefix and params joined by "_". Underscores and
+// backslashes inside string params are escaped so ("a_b") and ("a", "b") differ. Keys longer
+// than maxCacheKeyLength become prefix#<sha256> so they stay bounded but identifiable.
 func CacheKey(prefix string, params ...interface{}) string {
-	key := prefix
+	var b strings.Builder
+	b.WriteString(prefix)
+
 	for _, param := range params {
+		b.WriteByte('_')
 		switch v := param.(type) {
 		case string:
-			key += "_" + v
+			b.WriteString(cacheKeyEscaper.Replace(v))
 		case int:
-			key += "_" + string(rune(v))
+			b.WriteString(strconv.Itoa(v))
+		case int64:
+			b.WriteString(strconv.FormatInt(v, 10))
+		case bool:
+			b.WriteString(strconv.FormatBool(v))
+		case float64:
+			b.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
 		default:
-			if b, err := json.Marshal(v); err == nil {
-				key += "_" + string(b)
+			if data, err := json.Marshal(v); err == nil {
+				b.WriteString(cacheKeyEscaper.Replace(string(data)))
+			} else {
+				b.WriteString(cacheKeyEscaper.Replace(fmt.Sprintf("%v", v)))
 			}
 		}
 	}
+
+	key := b.String()
+	if len(key) > maxCacheKeyLength {
+		return HashedCacheKey(prefix, key)
+	}
 	return key
-}
\ No newline at end of file
+}
+
+// HashedCacheKey returns prefix#<sha256 of key>, for keys built from unbounded input
+func HashedCacheKey(prefix, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return prefix + "#" + hex.EncodeToString(sum[:])
+}
+
+// cacheKeyEscaper keeps the "_" separator unambiguous inside string params
+var cacheKeyEscaper = strings.NewReplacer(`\`, `\\`, "_", `\_`)
@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"political-network-api/internal/config"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+)
+
+// snapshotEntry is a cached value as SaveCacheSnapshot writes it: gzipped JSON with its JSON
+// size, when it was cached and, while it hasn't expired (Fresh), when it will (zero: never)
+type snapshotEntry struct {
+	Key       string
+	Data      []byte
+	Size      int
+	StoredAt  time.Time
+	Fresh     bool
+	ExpiresAt time.Time
+}
+
+// SaveCacheSnapshot writes the values cached under keys to path, compressed as
+// SetCacheCompressed does, and returns how many it saved. Expired values still held as stale
+// copies are saved too, so they can answer reads during an outage after the restart. The file is
+// replaced atomically.
+func SaveCacheSnapshot(path string, keys []string) (int, error) {
+	var entries []snapshotEntry
+	for _, key := range keys {
+		entry := snapshotEntry{Key: key, StoredAt: time.Now()}
+		value, expiresAt, fresh := Cache.GetWithExpiration(key)
+		if fresh {
+			entry.Fresh = true
+			entry.ExpiresAt = expiresAt
+		}
+		if item, found := staleCache.Get(key); found {
+			entry.StoredAt = item.(staleItem).storedAt
+			if !fresh {
+				value = item.(staleItem).value
+			}
+		} else if !fresh {
+			continue
+		}
+
+		compressed, ok := value.(compressedValue)
+		if !ok {
+			var err error
+			if compressed, err = compress(value); err != nil {
+				return 0, fmt.Errorf("%s: %w", key, err)
+			}
+		}
+		entry.Data, entry.Size = compressed.data, compressed.size
+		entries = append(entries, entry)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return 0, err
+	}
+	// Write then rename so a crash mid-write leaves the previous snapshot
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	if err := gob.NewEncoder(tmp).Encode(entries); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// LoadCacheSnapshot restores the values SaveCacheSnapshot wrote to path, each for what was left
+// of its TTL, and its stale copy for what is left of cache.stale_ttl. It returns how many values
+// were restored to the cache; a missing file restores none.
+func LoadCacheSnapshot(path string) (int, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var entries []snapshotEntry
+	if err := gob.NewDecoder(f).Decode(&entries); err != nil {
+		return 0, fmt.Errorf("invalid cache snapshot: %w", err)
+	}
+
+	now := time.Now()
+	staleTTL, staleMax := config.Get().Cache.StaleTTL, config.Get().Cache.StaleMaxEntries
+	restored := 0
+	for _, entry := range entries {
+		value := compressedValue{data: entry.Data, size: entry.Size}
+		if staleTTL > 0 && entry.StoredAt.Add(staleTTL).After(now) {
+			setStale(entry.Key, staleItem{value: value, storedAt: entry.StoredAt}, entry.StoredAt.Add(staleTTL).Sub(now), staleMax)
+		}
+		switch {
+		case entry.Fresh && entry.ExpiresAt.IsZero():
+			Cache.Set(entry.Key, value, cache.NoExpiration)
+			restored++
+		case entry.Fresh && entry.ExpiresAt.After(now):
+			Cache.Set(entry.Key, value, entry.ExpiresAt.Sub(now))
+			restored++
+		}
+	}
+	return restored, nil
+}
@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"path/filepath"
+	"political-network-api/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheKeyEncodesIntegers(t *testing.T) {
+	tests := []struct {
+		params []interface{}
+		want   string
+	}{
+		{[]interface{}{500, 0}, "politicians_500_0"},
+		{[]interface{}{1000, 250, 0.5}, "politicians_1000_250_0.5"},
+		{[]interface{}{-1}, "politicians_-1"},
+		{[]interface{}{int64(9007199254740993)}, "politicians_9007199254740993"},
+		{[]interface{}{true}, "politicians_true"},
+	}
+
+	for _, tt := range tests {
+		if got := CacheKey("politicians", tt.params...); got != tt.want {
+			t.Errorf("CacheKey(%v) = %q, want %q", tt.params, got, tt.want)
+		}
+	}
+}
+
+func TestCacheKeyNoCollisions(t *testing.T) {
+	// string(rune(v)) mapped every invalid code point (negatives, surrogates) to U+FFFD
+	cases := [][]interface{}{
+		{-1}, {-2}, {0xD800}, {0xD801}, {0x110000}, {0x110001},
+		{50, 0}, {500}, {5, 0, 0},
+		{"a_b"}, {"a", "b"}, {`a\`, "b"}, {`a\_b`},
+	}
+
+	seen := make(map[string][]interface{})
+	for _, params := range cases {
+		key := CacheKey("sanctions", params...)
+		if prev, ok := seen[key]; ok {
+			t.Errorf("CacheKey(%v) collides with CacheKey(%v): %q", params, prev, key)
+		}
+		seen[key] = params
+	}
+}
+
+func TestCacheKeyHashesLongKeys(t *testing.T) {
+	long := strings.Repeat("x", maxCacheKeyLength)
+
+	key := CacheKey("expenses", long)
+	if !strings.HasPrefix(key, "expenses#") {
+		t.Fatalf("long key not hashed: %q", key)
+	}
+	if len(key) != len("expenses#")+64 {
+		t.Errorf("hashed key length = %d, want %d", len(key), len("expenses#")+64)
+	}
+	if again := CacheKey("expenses", long); again != key {
+		t.Errorf("hashed key not stable: %q != %q", again, key)
+	}
+	if other := CacheKey("expenses", long+"y"); other == key {
+		t.Errorf("distinct long keys hashed to the same value: %q", key)
+	}
+
+	short := CacheKey("expenses", 100, 0)
+	if short != "expenses_100_0" {
+		t.Errorf("short key hashed unexpectedly: %q", short)
+	}
+}
+
+func TestDeleteCachePrefix(t *testing.T) {
+	InitializeCache()
+	keys := []string{
+		CacheKey("sanctions", 1000, 0),
+		CacheKey("sanction_detail", 7),
+		CacheKey("sanctions", strings.Repeat("x", maxCacheKeyLength)),
+		"sanctions",
+		CacheKey("sanctions2", 1),
+		CacheKey("company_detail", "12345678000190"),
+	}
+	for _, key := range keys {
+		SetCache(key, true, time.Minute)
+	}
+
+	if removed := DeleteCachePrefix("sanctions", "sanction_detail"); removed != 4 {
+		t.Errorf("DeleteCachePrefix removed %d keys, want 4", removed)
+	}
+	for _, key := range keys[4:] {
+		if _, found := GetCache(key); !found {
+			t.Errorf("key %q removed, want kept", key)
+		}
+	}
+}
+
+func TestStaleCacheOutlivesTTL(t *testing.T) {
+	InitializeCache()
+	SetCache("stats_network", 42, time.Millisecond)
+	SetCache("sanctions_1000_0", 7, time.Minute)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, found := GetCache("stats_network"); found {
+		t.Fatal("expired key still cached")
+	}
+	value, storedAt, found := GetStaleCache("stats_network")
+	if !found || value != 42 || storedAt.IsZero() {
+		t.Errorf("GetStaleCache = %v, %v, %v, want 42 with its store time", value, storedAt, found)
+	}
+
+	DeleteCachePrefix("stats")
+	if _, _, found := GetStaleCache("stats_network"); found {
+		t.Error("stale copy kept after DeleteCachePrefix")
+	}
+	FlushCache()
+	if _, _, found := GetStaleCache("sanctions_1000_0"); found {
+		t.Error("stale copy kept after FlushCache")
+	}
+}
+
+func TestStaleCacheDropsOldestPastCap(t *testing.T) {
+	InitializeCache()
+	now := time.Now()
+	setStale("politicians_a", staleItem{value: 1, storedAt: now.Add(-3 * time.Minute)}, time.Hour, 2)
+	setStale("politicians_b", staleItem{value: 2, storedAt: now.Add(-2 * time.Minute)}, time.Hour, 2)
+	setStale("politicians_a", staleItem{value: 3, storedAt: now.Add(-time.Minute)}, time.Hour, 2)
+	if got := len(staleCache.Items()); got != 2 {
+		t.Fatalf("stale copies after replacing a key = %d, want 2", got)
+	}
+
+	setStale("politicians_c", staleItem{value: 4, storedAt: now}, time.Hour, 2)
+	if _, _, found := GetStaleCache("politicians_b"); found {
+		t.Error("oldest stale copy kept past the cap")
+	}
+	for _, key := range []string{"politicians_a", "politicians_c"} {
+		if _, _, found := GetStaleCache(key); !found {
+			t.Errorf("stale copy %q dropped, want kept", key)
+		}
+	}
+}
+
+func TestCompressedCacheRoundTrip(t *testing.T) {
+	InitializeCache()
+	network := &models.NetworkResponse{
+		Nodes: []models.NetworkNode{
+			models.NewNetworkNode("1", "Fulano", 8, "#fff", models.Politician{ID: 1, Nome: "Fulano", SiglaPartido: "ABC"}),
+			models.NewNetworkNode("2", "Partido ABC", 12, "#000", models.Party{ID: 2, Sigla: "ABC"}),
+		},
+		Links: []models.Connection{{SourceID: "politician_1", TargetID: "party_2", Type: "member", Strength: 1}},
+	}
+	if err := SetCacheCompressed("network_complete", network, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	cached, found := GetCache("network_complete")
+	if !found {
+		t.Fatal("compressed value not cached")
+	}
+	got, err := CacheValue[*models.NetworkResponse](cached)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, network) {
+		t.Errorf("CacheValue = %+v, want %+v", got, network)
+	}
+
+	stats := GetCacheStats(true)
+	if stats.CompressedItems != 1 || stats.CompressedBytes == 0 || stats.UncompressedBytes == 0 {
+		t.Errorf("stats = %+v, want one compressed item with its sizes", stats)
+	}
+	if len(stats.Keys) != 1 || !stats.Keys[0].Compressed || stats.Keys[0].SizeBytes != stats.CompressedBytes {
+		t.Errorf("keys = %+v, want network_complete reported compressed", stats.Keys)
+	}
+}
+
+func TestCacheSnapshotRestoresUnexpiredEntries(t *testing.T) {
+	InitializeCache()
+	path := filepath.Join(t.TempDir(), "cache.snapshot")
+	SetCache("connections_all", []models.Connection{{SourceID: "politician_1", TargetID: "party_2"}}, time.Minute)
+	SetCache("network_complete", &models.NetworkResponse{}, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if saved, err := SaveCacheSnapshot(path, []string{"connections_all", "network_complete", "missing"}); err != nil || saved != 2 {
+		t.Fatalf("SaveCacheSnapshot = %d, %v, want 2 entries saved", saved, err)
+	}
+	FlushCache()
+	if restored, err := LoadCacheSnapshot(path); err != nil || restored != 1 {
+		t.Fatalf("LoadCacheSnapshot = %d, %v, want 1 entry restored", restored, err)
+	}
+
+	cached, found := GetCache("connections_all")
+	if !found {
+		t.Fatal("connections_all not restored")
+	}
+	if links, err := CacheValue[[]models.Connection](cached); err != nil || len(links) != 1 || links[0].TargetID != "party_2" {
+		t.Errorf("restored connections = %+v, %v", links, err)
+	}
+	if _, found := GetCache("network_complete"); found {
+		t.Error("expired network_complete restored to the cache")
+	}
+	if _, _, found := GetStaleCache("network_complete"); !found {
+		t.Error("expired network_complete not restored as a stale copy")
+	}
+}
+
+func TestCacheInvalidationHook(t *testing.T) {
+	InitializeCache()
+	var published [][]string
+	OnCacheInvalidation(func(prefixes []string) { published = append(published, prefixes) })
+	defer invalidationHook.Store(nil)
+
+	SetCache(CacheKey("sanctions", 1000, 0), true, time.Minute)
+	SetCache(CacheKey("parties", 100, 0), true, time.Minute)
+	DeleteCachePrefix("sanctions")
+	FlushCache()
+	if len(published) != 2 || len(published[0]) != 1 || published[0][0] != "sanctions" || published[1] != nil {
+		t.Errorf("published %v, want [[sanctions] []]", published)
+	}
+
+	// Invalidations received from other replicas apply here without being announced again
+	SetCache(CacheKey("parties", 100, 0), true, time.Minute)
+	ApplyCacheInvalidation([]string{"parties"})
+	if _, found := GetCache(CacheKey("parties", 100, 0)); found {
+		t.Error("parties still cached after ApplyCacheInvalidation")
+	}
+	if len(published) != 2 {
+		t.Errorf("ApplyCacheInvalidation published %v", published[2:])
+	}
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeText returns the canonical form names are compared in: accents removed, uppercase,
+// whitespace squashed to single spaces. It matches normalize_text in the ETL
+// (src/validation/normalization.py) and the normalize_text() SQL function the setup scripts
+// create, so "José  da Silva" and "JOSE DA SILVA" are the same entity everywhere.
+func NormalizeText(s string) string {
+	unaccented, _, err := transform.String(transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn))), s)
+	if err != nil {
+		unaccented = s
+	}
+	return strings.Join(strings.Fields(strings.ToUpper(unaccented)), " ")
+}
+
+// ValidCNPJ reports whether cnpj is 14 digits whose last two are the check digits of the first
+// twelve, as the Receita Federal computes them (weights 5..2,9..2 then 6..2,9..2, mod 11)
+func ValidCNPJ(cnpj string) bool {
+	if len(cnpj) != 14 || strings.Count(cnpj, string(cnpj[0])) == 14 {
+		return false
+	}
+	digits := make([]int, 14)
+	for i, r := range cnpj {
+		if r < '0' || r > '9' {
+			return false
+		}
+		digits[i] = int(r - '0')
+	}
+
+	for _, n := range []int{12, 13} {
+		sum, weight := 0, n-7
+		for i := 0; i < n; i++ {
+			sum += digits[i] * weight
+			if weight--; weight < 2 {
+				weight = 9
+			}
+		}
+		check := 11 - sum%11
+		if check >= 10 {
+			check = 0
+		}
+		if digits[n] != check {
+			return false
+		}
+	}
+	return true
+}
+
+// Slug joins parts into a URL path segment: NormalizeText'd, lowercase, with every run of other
+// characters than letters and digits as one hyphen, so "João da Silva", "PT", "SP" is
+// "joao-da-silva-pt-sp". Empty parts are left out.
+func Slug(parts ...string) string {
+	var b strings.Builder
+	for _, part := range parts {
+		for _, word := range strings.FieldsFunc(strings.ToLower(NormalizeText(part)), func(r rune) bool {
+			return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+		}) {
+			if b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteString(word)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package utils
+
+import "testing"
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"José da Silva", "JOSE DA SILVA"},
+		{"JOSE DA SILVA", "JOSE DA SILVA"},
+		{"  joão \t cleber\n", "JOAO CLEBER"},
+		{"São Paulo", "SAO PAULO"},
+		{"CONCEIÇÃO DO ARAGUAIA", "CONCEICAO DO ARAGUAIA"},
+		{"Müller & Cia. Ltda", "MULLER & CIA. LTDA"},
+		{"D'Ávila", "D'AVILA"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeText(tt.in); got != tt.want {
+			t.Errorf("NormalizeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeTextComposedAndDecomposed(t *testing.T) {
+	// "é" as one code point and as "e" plus a combining acute accent
+	if a, b := NormalizeText("José"), NormalizeText("José"); a != b || a != "JOSE" {
+		t.Errorf("NormalizeText gave %q and %q, want JOSE for both", a, b)
+	}
+}
+
+func TestValidCNPJ(t *testing.T) {
+	tests := []struct {
+		cnpj string
+		want bool
+	}{
+		{"11222333000181", true},
+		{"00000000000191", true}, // Banco do Brasil
+		{"33000167000101", true}, // Petrobras
+		{"11222333000182", false},
+		{"11222333000191", false},
+		{"11111111111111", false},
+		{"1122233300018", false},
+		{"11.222.333/0001-81", false},
+		{"12345678901", false}, // a CPF
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidCNPJ(tt.cnpj); got != tt.want {
+			t.Errorf("ValidCNPJ(%q) = %v, want %v", tt.cnpj, got, tt.want)
+		}
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		parts []string
+		want  string
+	}{
+		{[]string{"João da Silva", "PT", "SP"}, "joao-da-silva-pt-sp"},
+		{[]string{"D'Ávila", "PC do B", "RJ"}, "d-avila-pc-do-b-rj"},
+		{[]string{"  Tiririca ", "", "SP"}, "tiririca-sp"},
+		{[]string{"", ""}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := Slug(tt.parts...); got != tt.want {
+			t.Errorf("Slug(%q) = %q, want %q", tt.parts, got, tt.want)
+		}
+	}
+}
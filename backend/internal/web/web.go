@@ -0,0 +1,177 @@
+// Package web serves the frontend (../frontend) from the binary. `make frontend` copies it
+// into dist, which is embedded at build time, so one binary serves both the UI and the API.
+package web
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"political-network-api/internal/models"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed all:dist
+var embedded embed.FS
+
+const (
+	// assetCacheControl lets browsers reuse the frontend's assets for an hour. Their names
+	// carry no content hash, so they can't be cached for longer; the ETag makes revalidation
+	// cheap.
+	assetCacheControl = "public, max-age=3600"
+	// indexCacheControl has index.html revalidated on every load, so a deploy shows up at once
+	indexCacheControl = "no-cache"
+)
+
+// indexHead is added to index.html's <head>: relative asset URLs resolve from the root under
+// client-side routes too, and the frontend calls the API of the server it was loaded from
+const indexHead = `<base href="/"><script>window.API_URL = window.API_URL || '/api';</script>`
+
+// Frontend serves the frontend's files, and index.html for the client-side routes
+type Frontend struct {
+	files fs.FS
+	// immutable is set for the embedded build, whose files are read and hashed once
+	immutable bool
+	cache     sync.Map // name -> file
+}
+
+// file is a frontend file with the ETag of its content
+type file struct {
+	content []byte
+	etag    string
+}
+
+// New returns the frontend in dir, read on every request so edits show without a rebuild, or
+// the embedded build when dir is ""
+func New(dir string) (*Frontend, error) {
+	if dir == "" {
+		files, err := fs.Sub(embedded, "dist")
+		if err != nil {
+			return nil, err
+		}
+		return newFrontend(files, true), nil
+	}
+
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("frontend directory %s not found", dir)
+	}
+	return newFrontend(os.DirFS(dir), false), nil
+}
+
+func newFrontend(files fs.FS, immutable bool) *Frontend {
+	f := &Frontend{files: files, immutable: immutable}
+	if _, err := f.read("index.html"); err != nil {
+		log.Printf("⚠️ Frontend has no index.html (run make frontend); only the API is served")
+	}
+	return f
+}
+
+// Handler serves GET and HEAD requests the router has no route for: a frontend file when the
+// path names one, otherwise index.html, so client-side routes resolve in the browser. Paths
+// under /api/ and missing files with an extension (a stale asset URL) get a JSON 404 instead.
+func (f *Frontend) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		urlPath := c.Request.URL.Path
+		if (c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead) ||
+			urlPath == "/api" || strings.HasPrefix(urlPath, "/api/") {
+			notFound(c, "Endpoint not found")
+			return
+		}
+
+		name := strings.TrimPrefix(path.Clean(urlPath), "/")
+		if name != "" && name != "index.html" {
+			asset, err := f.read(name)
+			if err == nil {
+				serve(c, name, asset, assetCacheControl)
+				return
+			}
+			if !errors.Is(err, fs.ErrNotExist) {
+				c.JSON(http.StatusInternalServerError, models.APIResponse{
+					Success: false,
+					Error:   "Failed to read " + name + ": " + err.Error(),
+					Time:    "0ms",
+				})
+				return
+			}
+			if path.Ext(name) != "" {
+				notFound(c, "File not found")
+				return
+			}
+		}
+
+		index, err := f.read("index.html")
+		if err != nil {
+			notFound(c, "Frontend not built; run make frontend")
+			return
+		}
+		serve(c, "index.html", index, indexCacheControl)
+	}
+}
+
+// read returns a file of the frontend, with indexHead added to index.html. Hidden files and
+// directories are never served.
+func (f *Frontend) read(name string) (file, error) {
+	if cached, ok := f.cache.Load(name); ok {
+		return cached.(file), nil
+	}
+
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") {
+			return file{}, fs.ErrNotExist
+		}
+	}
+	content, err := fs.ReadFile(f.files, name)
+	if err != nil {
+		if info, statErr := fs.Stat(f.files, name); statErr == nil && info.IsDir() {
+			return file{}, fs.ErrNotExist
+		}
+		return file{}, err
+	}
+	if name == "index.html" {
+		content = withHead(content)
+	}
+
+	sum := sha256.Sum256(content)
+	read := file{content: content, etag: `"` + hex.EncodeToString(sum[:8]) + `"`}
+	if f.immutable {
+		f.cache.Store(name, read)
+	}
+	return read, nil
+}
+
+// withHead adds indexHead at the start of an HTML document's <head>
+func withHead(html []byte) []byte {
+	i := bytes.Index(bytes.ToLower(html), []byte("<head>"))
+	if i < 0 {
+		return html
+	}
+	i += len("<head>")
+	return append(append(append([]byte{}, html[:i]...), indexHead...), html[i:]...)
+}
+
+// serve writes a file with its ETag and cache policy; http.ServeContent answers conditional
+// and range requests and sets the content type from the name
+func serve(c *gin.Context, name string, f file, cacheControl string) {
+	c.Header("ETag", f.etag)
+	c.Header("Cache-Control", cacheControl)
+	http.ServeContent(c.Writer, c.Request, name, time.Time{}, bytes.NewReader(f.content))
+}
+
+func notFound(c *gin.Context, message string) {
+	c.JSON(http.StatusNotFound, models.APIResponse{
+		Success: false,
+		Error:   message,
+		Time:    "0ms",
+	})
+}
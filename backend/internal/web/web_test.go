@@ -0,0 +1,54 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHandlerServesAssetsAndFallsBackToIndex(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	frontend := newFrontend(fstest.MapFS{
+		"index.html":  {Data: []byte("<html><head><title>t</title></head></html>")},
+		"src/main.js": {Data: []byte("console.log(1)")},
+		".env":        {Data: []byte("SECRET=1")},
+	}, true)
+	router := gin.New()
+	router.NoRoute(frontend.Handler())
+
+	get := func(path, etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	asset := get("/src/main.js", "")
+	if asset.Code != http.StatusOK || asset.Header().Get("Cache-Control") != assetCacheControl {
+		t.Fatalf("asset: got %d, Cache-Control %q", asset.Code, asset.Header().Get("Cache-Control"))
+	}
+	if w := get("/src/main.js", asset.Header().Get("ETag")); w.Code != http.StatusNotModified {
+		t.Errorf("asset revalidation: got %d, want 304", w.Code)
+	}
+
+	index := get("/politicians/42", "")
+	if index.Code != http.StatusOK || index.Header().Get("Cache-Control") != indexCacheControl {
+		t.Fatalf("client route: got %d, Cache-Control %q", index.Code, index.Header().Get("Cache-Control"))
+	}
+	if !strings.Contains(index.Body.String(), "<head>"+indexHead+"<title>") {
+		t.Errorf("index.html lacks the injected head: %s", index.Body.String())
+	}
+
+	for _, path := range []string{"/api/nope", "/src/missing.js", "/.env"} {
+		if w := get(path, ""); w.Code != http.StatusNotFound {
+			t.Errorf("%s: got %d, want 404", path, w.Code)
+		}
+	}
+}
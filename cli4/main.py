@@ -8,6 +8,7 @@ import argparse
 import sys
 import os
 import time
+from datetime import datetime
 from pathlib import Path
 from dotenv import load_dotenv
 
@@ -19,11 +20,13 @@ sys.path.insert(0, str(project_root))
 load_dotenv(project_root / '.env')
 
 from cli4.modules import database
+from cli4.modules.provenance import ensure_provenance_columns
+from cli4.modules import versions
 from cli4.modules.logger import CLI4Logger
 from cli4.modules.rate_limiter import CLI4RateLimiter
 from cli4.populators import CLI4PoliticianPopulator, CLI4PoliticianValidator
 from cli4.populators.financial import CLI4CounterpartsPopulator, CLI4RecordsPopulator, CLI4FinancialValidator
-from cli4.populators.electoral import ElectoralRecordsPopulator, ElectoralRecordsValidator
+from cli4.populators.electoral import ElectoralRecordsPopulator, ElectoralRecordsValidator, ElectoralResultsPopulator
 from cli4.populators.parties import CLI4PartiesPopulator, CLI4PartiesValidator
 from cli4.populators.wealth import CLI4WealthPopulator, CLI4WealthValidator
 from cli4.populators.career import CareerPopulator, CareerValidator
@@ -31,8 +34,23 @@ from cli4.populators.assets import AssetsPopulator, AssetsValidator
 from cli4.populators.professional import ProfessionalPopulator, ProfessionalValidator
 from cli4.populators.events import EventsPopulator, EventsValidator
 from cli4.populators.sanctions import SanctionsPopulator, SanctionsValidator
-from cli4.populators.tcu import TCUPopulator, TCUValidator
+from cli4.populators.tcu import TCUPopulator, TCUValidator, TCURulingsPopulator
+from cli4.populators.bndes import BNDESPopulator
+from cli4.populators.geo import GeoPopulator
+from cli4.populators.amendments import AmendmentsPopulator
+from cli4.populators.anomalies import AnomaliesPopulator
 from cli4.populators.senado import SenadoPopulator, SenadoValidator
+from cli4.populators.ipca import IPCAPopulator
+from cli4.populators.cnae import CNAEPopulator
+from cli4.populators.wikidata import WikidataPopulator
+from cli4.populators.party_funds import PartyFundsPopulator
+from cli4.populators.party_history import PartyHistoryPopulator
+from cli4.populators.qsa import QSAPopulator
+from cli4.populators.attendance import AttendancePopulator
+from cli4.populators.speeches import SpeechesPopulator
+from cli4.populators.staff import StaffPopulator
+from cli4.populators.family import FamilyPopulator
+from cli4.populators.entities import EntityResolutionPopulator
 
 
 def setup_cli():
@@ -61,10 +79,14 @@ Examples:
 
   # Populate financial records
   python cli4/main.py populate-financial
+  python cli4/main.py populate-financial --phase records --full-reload
 
   # Populate electoral records (NEW!)
   python cli4/main.py populate-electoral
 
+  # Load vote totals and summarize each politician's latest election
+  python cli4/main.py populate-electoral-results --election-years 2018 2022
+
   # Populate political parties (NEW!)
   python cli4/main.py populate-parties --limit 10
   python cli4/main.py populate-parties --legislatura-id 57
@@ -95,10 +117,62 @@ Examples:
   python cli4/main.py populate-tcu --max-pages 50
   python cli4/main.py populate-tcu --update-existing
 
+  # TCU rulings (acórdãos) naming companies and politicians we track
+  python cli4/main.py populate-tcu-rulings --since 2020-01-01
+
+  # BNDES loans to companies paid by politicians
+  python cli4/main.py populate-bndes --since 2015-01-01
+
   # Populate Senado politicians (NEW!)
   python cli4/main.py populate-senado
   python cli4/main.py populate-senado --update-existing
 
+  # Populate IPCA inflation index (for ?adjust_to= on the API)
+  python cli4/main.py populate-ipca
+
+  # Tag companies with CNAE sectors from the Receita CNPJ files (Estabelecimentos*.zip, Cnaes.zip)
+  python cli4/main.py populate-cnae --receita-dir ./data/receita
+
+  # IBGE states/municipalities with boundaries; locate vendors from the Receita files (for /api/geo/spending)
+  python cli4/main.py populate-geo --receita-dir ./data/receita
+
+  # Parliamentary amendments and where their money goes (run populate-geo first)
+  python cli4/main.py populate-amendments --years 2022 2023 2024
+
+  # Flag suspicious expense patterns (for /api/anomalies and post-process --enhanced)
+  python cli4/main.py detect-anomalies --types geo_mismatch duplicate
+
+  # Link politicians to Wikidata (Wikipedia links, aliases, previous offices)
+  python cli4/main.py populate-wikidata --limit 50
+
+  # Load Fundo Partidário / FEFC distributions (CSV: ano, mes, sigla_partido, tipo, valor)
+  python cli4/main.py populate-party-funds --csv ./data/tse/fundo_partidario_2024.csv
+
+  # Time-ranged party memberships and switches from the Câmara deputy history
+  python cli4/main.py populate-party-history --limit 50
+
+  # Company owners (QSA) from the Receita CNPJ files (Socios*.zip, Qualificacoes.zip)
+  python cli4/main.py populate-qsa --receita-dir ./data/receita
+
+  # Plenary attendance and absence rates (deliberative sessions; default: this year)
+  python cli4/main.py populate-attendance --start-date 2023-02-01
+
+  # Plenary speeches (discursos) with their keywords/topics
+  python cli4/main.py populate-speeches --days-back 365 --limit 50
+
+  # Cabinet staff rosters and the possible-nepotism cross-check
+  python cli4/main.py populate-staff --year 2024
+
+  # Suggested family relations between politicians, reviewed through /api/relations
+  python cli4/main.py populate-family --min-score 0.6
+
+  # Link people and companies across Câmara, TSE, Portal da Transparência, TCU, Senado and Receita,
+  # reviewed through /api/admin/entity-links
+  python cli4/main.py resolve-entities --sources sanctions receita --min-confidence 0.6
+
+  # Freeze the runs since the last publish as a dataset version the API can pin (?dataset_version=)
+  python cli4/main.py publish-version --keep 5 --note "October TSE and CEIS refresh"
+
   # Post-process: Calculate aggregate career fields (NEW!)
   python cli4/main.py post-process --fields electoral
   python cli4/main.py post-process --enhanced --fields corruption
@@ -148,6 +222,8 @@ Examples:
     financial_parser.add_argument('--politician-ids', type=int, nargs='+', help='Specific politician IDs to process')
     financial_parser.add_argument('--start-year', type=int, help='Start year for financial data')
     financial_parser.add_argument('--end-year', type=int, help='End year for financial data')
+    financial_parser.add_argument('--full-reload', action='store_true',
+                                 help='Rebuild unified_financial_records in a staging table and swap it in (records phase, all politicians)')
 
     # Electoral population commands (NEW)
     electoral_parser = subparsers.add_parser('populate-electoral', help='Populate electoral records table')
@@ -157,6 +233,10 @@ Examples:
     electoral_parser.add_argument('--force-refresh', action='store_true',
                                  help='Refresh existing records (skip duplicate check)')
 
+    electoral_results_parser = subparsers.add_parser('populate-electoral-results', help='Load TSE vote totals and each politician\'s latest election')
+    electoral_results_parser.add_argument('--election-years', type=int, nargs='+',
+                                          help='Election years to load (default: every year with electoral records)')
+
     # Parties population commands (NEW)
     parties_parser = subparsers.add_parser('populate-parties', help='Populate political parties and memberships tables')
     parties_parser.add_argument('--limit', type=int, help='Limit number of parties to process')
@@ -222,10 +302,94 @@ Examples:
     tcu_parser.add_argument('--max-pages', type=int, default=100, help='Maximum pages to fetch (default: 100, reasonable limit)')
     tcu_parser.add_argument('--update-existing', action='store_true', help='Update existing records instead of skipping')
 
+    # TCU rulings population
+    tcu_rulings_parser = subparsers.add_parser('populate-tcu-rulings', help='Populate TCU rulings (acórdãos) naming tracked companies/politicians')
+    tcu_rulings_parser.add_argument('--max-pages', type=int, default=20, help='Maximum pages of acórdãos to scan (default: 20)')
+    tcu_rulings_parser.add_argument('--page-size', type=int, default=100, help='Acórdãos per page (default: 100)')
+    tcu_rulings_parser.add_argument('--since', type=str, help='Stop at rulings before this session date (YYYY-MM-DD)')
+
+    # BNDES public loans population
+    bndes_parser = subparsers.add_parser('populate-bndes', help='Populate BNDES loans to tracked companies')
+    bndes_parser.add_argument('--since', type=str, help='Skip contracts signed before this date (YYYY-MM-DD)')
+
     # Senado politicians population
     senado_parser = subparsers.add_parser('populate-senado', help='Populate Senado politicians table (family network detection)')
     senado_parser.add_argument('--update-existing', action='store_true', help='Update existing records instead of skipping')
 
+    # IPCA inflation index population
+    subparsers.add_parser('populate-ipca', help='Populate IPCA index table (inflation-adjusted amounts)')
+
+    # CNAE sector classification
+    cnae_parser = subparsers.add_parser('populate-cnae', help='Tag companies with CNAE sectors from Receita Federal CNPJ files')
+    cnae_parser.add_argument('--receita-dir', required=True, help='Directory with Estabelecimentos and Cnaes files (zip or csv)')
+    cnae_parser.add_argument('--update-existing', action='store_true', help='Reclassify companies that already have a CNAE')
+
+    # IBGE areas and vendor geolocation
+    geo_parser = subparsers.add_parser('populate-geo', help='Populate IBGE areas with boundaries and geolocate companies')
+    geo_parser.add_argument('--receita-dir', help='Directory with Estabelecimentos and Municipios files (zip or csv); skips geolocation when omitted')
+    geo_parser.add_argument('--update-existing', action='store_true', help='Geolocate companies that already have a municipality')
+
+    # Parliamentary amendments
+    amendments_parser = subparsers.add_parser('populate-amendments', help='Populate parliamentary amendments from the Portal da Transparência')
+    amendments_parser.add_argument('--years', type=int, nargs='+', default=[2022, 2023, 2024],
+                                   help='Budget years to load (default: 2022 2023 2024)')
+
+    # Expense anomalies detection
+    anomalies_parser = subparsers.add_parser('detect-anomalies', help='Detect suspicious expense patterns (run populate-geo first)')
+    anomalies_parser.add_argument('--types', nargs='+', choices=['geo_mismatch', 'duplicate'],
+                                  help='Anomaly types to detect (default: all)')
+
+    # Wikidata enrichment
+    wikidata_parser = subparsers.add_parser('populate-wikidata', help='Link politicians to Wikidata items by name + birth date')
+    wikidata_parser.add_argument('--limit', type=int, help='Limit number of politicians to process')
+    wikidata_parser.add_argument('--update-existing', action='store_true', help='Refresh politicians already linked')
+
+    # Party fund distributions
+    party_funds_parser = subparsers.add_parser('populate-party-funds', help='Load Fundo Partidário and FEFC distributions per party from CSV')
+    party_funds_parser.add_argument('--csv', required=True, help='CSV with ano, mes, sigla_partido, tipo (fundo_partidario|fefc), valor')
+
+    # Party membership history
+    party_history_parser = subparsers.add_parser('populate-party-history', help='Populate time-ranged party memberships and switches')
+    party_history_parser.add_argument('--politician-ids', type=int, nargs='+', help='Specific politician IDs to process')
+    party_history_parser.add_argument('--limit', type=int, help='Limit number of politicians to process')
+
+    # Company owners (QSA)
+    qsa_parser = subparsers.add_parser('populate-qsa', help='Load company partners (QSA) from Receita Federal CNPJ files')
+    qsa_parser.add_argument('--receita-dir', required=True, help='Directory with Socios and Qualificacoes files (zip or csv)')
+
+    # Plenary attendance
+    attendance_parser = subparsers.add_parser('populate-attendance', help='Populate plenary attendance and absence rates')
+    attendance_parser.add_argument('--start-date', help='First session date, YYYY-MM-DD (default: January 1st of this year)')
+    attendance_parser.add_argument('--end-date', help='Last session date, YYYY-MM-DD (default: today)')
+
+    # Speeches and topics
+    speeches_parser = subparsers.add_parser('populate-speeches', help='Populate plenary speeches and their topics')
+    speeches_parser.add_argument('--politician-ids', type=int, nargs='+', help='Specific politician IDs to process')
+    speeches_parser.add_argument('--limit', type=int, help='Limit number of politicians to process')
+    speeches_parser.add_argument('--days-back', type=int, default=365, help='Days back to fetch speeches (default: 365)')
+
+    # Cabinet staff and nepotism flags
+    staff_parser = subparsers.add_parser('populate-staff', help='Populate cabinet staff and possible nepotism flags')
+    staff_parser.add_argument('--politician-ids', type=int, nargs='+', help='Specific politician IDs to process')
+    staff_parser.add_argument('--limit', type=int, help='Limit number of politicians to process')
+    staff_parser.add_argument('--year', type=int, help='Roster year (default: current year)')
+
+    # Family relation suggestions
+    family_parser = subparsers.add_parser('populate-family', help='Suggest family relations between politicians')
+    family_parser.add_argument('--min-score', type=float, default=0.5, help='Minimum match score to suggest (default: 0.5)')
+
+    # Cross-source entity resolution
+    entities_parser = subparsers.add_parser('resolve-entities', help='Link people and companies across datasets by CPF/CNPJ, name and birth date')
+    entities_parser.add_argument('--sources', nargs='+', choices=['camara', 'tse', 'sanctions', 'tcu', 'senado', 'receita'],
+                                 help='Datasets to resolve (default: all)')
+    entities_parser.add_argument('--min-confidence', type=float, default=0.5, help='Minimum confidence to store a link (default: 0.5)')
+
+    # Dataset versions
+    publish_parser = subparsers.add_parser('publish-version', help='Publish the ETL runs since the last publish as a pinnable dataset version')
+    publish_parser.add_argument('--keep', type=int, default=int(os.getenv('DATASET_VERSIONS_KEEP', versions.DEFAULT_KEEP)),
+                                help=f'Published versions to keep (default: DATASET_VERSIONS_KEEP or {versions.DEFAULT_KEEP})')
+    publish_parser.add_argument('--note', help='What this version contains')
+
     # Status commands
     status_parser = subparsers.add_parser('status', help='Show database status')
     status_parser.add_argument('--detailed', action='store_true', help='Show detailed statistics')
@@ -252,6 +416,21 @@ Examples:
     return parser
 
 
+def audit_command(args):
+    """Record data-mutating commands in the audit log"""
+    if args.command == 'post-process':
+        action = 'score_recompute'
+    elif args.command.startswith('populate'):
+        action = 'etl_run'
+    elif args.command == 'clear-db':
+        action = 'data_clear'
+    else:
+        return
+
+    payload = {key: value for key, value in vars(args).items() if key != 'command'}
+    database.record_audit(action, args.command, payload)
+
+
 def main():
     """Main CLI4 entry point"""
     parser = setup_cli()
@@ -261,6 +440,8 @@ def main():
         parser.print_help()
         return 1
 
+    started_at = datetime.now()
+
     try:
         # Initialize core infrastructure
         print("🔧 Initializing CLI4 infrastructure...")
@@ -274,7 +455,11 @@ def main():
 
         if args.command == 'init-db':
             print("\n🏗️  Checking database...")
-            database.check_database()
+            if database.check_database():
+                with database.get_connection() as conn:
+                    ensure_provenance_columns(conn.cursor())
+                    conn.commit()
+                print("✅ Provenance columns (source, source_record_id, fetched_at) in place")
 
         elif args.command == 'clear-db':
             if not args.confirm:
@@ -338,7 +523,8 @@ def main():
                 records_count = records_populator.populate(
                     politician_ids=args.politician_ids,
                     start_year=args.start_year,
-                    end_year=args.end_year
+                    end_year=args.end_year,
+                    full_reload=args.full_reload
                 )
                 print(f"✅ Records phase completed: {records_count} records")
 
@@ -361,6 +547,14 @@ def main():
             print(f"\n🏆 Electoral population completed: {electoral_count} records")
             print(f"   Election years processed: {', '.join(map(str, args.election_years))}")
 
+        elif args.command == 'populate-electoral-results':
+            electoral_results_populator = ElectoralResultsPopulator(logger, rate_limiter)
+            results_count = electoral_results_populator.populate(
+                election_years=args.election_years
+            )
+
+            print(f"\n🏆 Electoral results population completed: {results_count} candidacies updated")
+
         elif args.command == 'populate-parties':
             print("🏛️ POLITICAL PARTIES POPULATION")
             print("Political parties and membership relationships from Câmara")
@@ -596,6 +790,22 @@ def main():
 
             print(f"\n🏆 TCU population completed: {tcu_count} records")
 
+        elif args.command == 'populate-tcu-rulings':
+            tcu_rulings_populator = TCURulingsPopulator(logger, rate_limiter)
+            rulings_count = tcu_rulings_populator.populate(
+                max_pages=args.max_pages,
+                page_size=args.page_size,
+                since=args.since
+            )
+
+            print(f"\n🏆 TCU rulings population completed: {rulings_count} rulings")
+
+        elif args.command == 'populate-bndes':
+            bndes_populator = BNDESPopulator(logger, rate_limiter)
+            loans_count = bndes_populator.populate(since=args.since)
+
+            print(f"\n🏆 BNDES population completed: {loans_count} loans")
+
         elif args.command == 'populate-senado':
             print("🏛️  SENADO POLITICIANS POPULATION")
             print("Senate Federal politicians for family network detection")
@@ -611,6 +821,124 @@ def main():
 
             print(f"\n🏆 Senado population completed: {senado_count} records")
 
+        elif args.command == 'populate-ipca':
+            ipca_populator = IPCAPopulator(logger, rate_limiter)
+            ipca_count = ipca_populator.populate()
+
+            print(f"\n🏆 IPCA population completed: {ipca_count} months")
+
+        elif args.command == 'populate-cnae':
+            cnae_populator = CNAEPopulator(logger, rate_limiter)
+            cnae_count = cnae_populator.populate(
+                receita_dir=args.receita_dir,
+                update_existing=args.update_existing
+            )
+
+            print(f"\n🏆 CNAE population completed: {cnae_count} companies")
+
+        elif args.command == 'populate-geo':
+            geo_populator = GeoPopulator(logger, rate_limiter)
+            areas_count = geo_populator.populate(
+                receita_dir=args.receita_dir,
+                update_existing=args.update_existing
+            )
+
+            print(f"\n🏆 Geo population completed: {areas_count} areas")
+
+        elif args.command == 'populate-amendments':
+            amendments_populator = AmendmentsPopulator(logger, rate_limiter)
+            amendments_count = amendments_populator.populate(years=args.years)
+
+            print(f"\n🏆 Amendments population completed: {amendments_count} amendments")
+
+        elif args.command == 'detect-anomalies':
+            anomalies_populator = AnomaliesPopulator(logger, rate_limiter)
+            anomalies_count = anomalies_populator.populate(types=args.types)
+
+            print(f"\n🏆 Anomalies detection completed: {anomalies_count} anomalies")
+
+        elif args.command == 'populate-wikidata':
+            wikidata_populator = WikidataPopulator(logger, rate_limiter)
+            wikidata_count = wikidata_populator.populate(
+                limit=args.limit,
+                update_existing=args.update_existing
+            )
+
+            print(f"\n🏆 Wikidata enrichment completed: {wikidata_count} politicians linked")
+
+        elif args.command == 'populate-party-funds':
+            party_funds_populator = PartyFundsPopulator(logger, rate_limiter)
+            party_funds_count = party_funds_populator.populate(csv_path=args.csv)
+
+            print(f"\n🏆 Party funds population completed: {party_funds_count} distributions")
+
+        elif args.command == 'populate-party-history':
+            party_history_populator = PartyHistoryPopulator(logger, rate_limiter)
+            party_history_count = party_history_populator.populate(
+                politician_ids=args.politician_ids,
+                limit=args.limit
+            )
+
+            print(f"\n🏆 Party history population completed: {party_history_count} memberships")
+
+        elif args.command == 'populate-qsa':
+            qsa_populator = QSAPopulator(logger, rate_limiter)
+            qsa_count = qsa_populator.populate(receita_dir=args.receita_dir)
+
+            print(f"\n🏆 QSA population completed: {qsa_count} partners")
+
+        elif args.command == 'populate-attendance':
+            attendance_populator = AttendancePopulator(logger, rate_limiter)
+            attendance_count = attendance_populator.populate(
+                start_date=args.start_date,
+                end_date=args.end_date
+            )
+
+            print(f"\n🏆 Attendance population completed: {attendance_count} records")
+
+        elif args.command == 'populate-speeches':
+            speeches_populator = SpeechesPopulator(logger, rate_limiter)
+            speeches_count = speeches_populator.populate(
+                politician_ids=args.politician_ids,
+                limit=args.limit,
+                days_back=args.days_back
+            )
+
+            print(f"\n🏆 Speeches population completed: {speeches_count} speeches")
+
+        elif args.command == 'populate-staff':
+            staff_populator = StaffPopulator(logger, rate_limiter)
+            staff_count = staff_populator.populate(
+                politician_ids=args.politician_ids,
+                limit=args.limit,
+                year=args.year
+            )
+
+            print(f"\n🏆 Staff population completed: {staff_count} staff records")
+
+        elif args.command == 'populate-family':
+            family_populator = FamilyPopulator(logger, rate_limiter)
+            family_count = family_populator.populate(min_score=args.min_score)
+
+            print(f"\n🏆 Family matching completed: {family_count} suggestions")
+
+        elif args.command == 'resolve-entities':
+            entities_populator = EntityResolutionPopulator(logger, rate_limiter)
+            links_count = entities_populator.populate(
+                sources=args.sources,
+                min_confidence=args.min_confidence
+            )
+
+            print(f"\n🏆 Entity resolution completed: {links_count} links")
+
+        elif args.command == 'publish-version':
+            published = versions.publish(keep=args.keep, note=args.note)
+
+            print(f"\n🏆 Dataset version {published['version']} published: "
+                  f"{len(published['table_rows'])} tables, {sum(published['table_rows'].values())} rows")
+            if published['pruned']:
+                print(f"   Pruned versions: {', '.join(str(v) for v in published['pruned'])}")
+
         elif args.command == 'post-process':
             if args.enhanced:
                 print("📊 ENHANCED POST-PROCESSING: COMPREHENSIVE AGGREGATE FIELDS")
@@ -991,6 +1319,10 @@ def main():
             print(f"❌ Unknown command: {args.command}")
             return 1
 
+        audit_command(args)
+        if versions.is_data_command(args.command):
+            versions.stamp_run(args.command, started_at, 'completed')
+
         # Show session summary
         logger.print_summary()
 
@@ -1001,6 +1333,8 @@ def main():
         print("\n⏹️ Operation cancelled by user")
         return 1
     except Exception as e:
+        if versions.is_data_command(args.command):
+            versions.stamp_run(args.command, started_at, 'failed')
         print(f"\n❌ CLI4 Error: {e}")
         import traceback
         print(f"Traceback: {traceback.format_exc()}")
@@ -0,0 +1,235 @@
+"""
+CLI4 Bulk Load Module
+COPY-based loading for multi-million-row tables (financial records, Receita CNPJ files).
+Rows stream into a staging table with COPY instead of one INSERT per row, then reach the real
+table in a single statement:
+- copy_upsert / copy_update: a temporary staging table merged with INSERT ... ON CONFLICT or
+  UPDATE ... FROM, for incremental loads
+- StagingTable: a full reload built beside the live table, indexed once loaded and swapped in,
+  so readers see the old data until the swap commits
+"""
+
+import re
+import time
+from typing import Iterable, Iterator, List, Optional, Sequence
+
+from cli4.modules.database import get_connection
+
+
+def _csv_field(value) -> str:
+    # COPY ... CSV reads an unquoted empty field as NULL and a quoted one as ''
+    if value is None:
+        return ''
+    return '"' + str(value).replace('"', '""') + '"'
+
+
+class _CSVStream:
+    """File-like object COPY reads rows from, encoding them as CSV as it goes"""
+
+    def __init__(self, rows: Iterable[Sequence]):
+        self._rows: Iterator[Sequence] = iter(rows)
+        self._pending = ''
+        self.count = 0
+
+    def read(self, size: int = -1) -> str:
+        while size < 0 or len(self._pending) < size:
+            row = next(self._rows, None)
+            if row is None:
+                break
+            self._pending += ','.join(_csv_field(v) for v in row) + '\n'
+            self.count += 1
+        if size < 0:
+            data, self._pending = self._pending, ''
+        else:
+            data, self._pending = self._pending[:size], self._pending[size:]
+        return data
+
+    readline = read
+
+
+def copy_rows(cursor, table: str, columns: List[str], rows: Iterable[Sequence]) -> int:
+    """COPY rows (tuples in columns order) into table; returns how many were sent"""
+    stream = _CSVStream(rows)
+    cursor.copy_expert(f"COPY {table} ({', '.join(columns)}) FROM STDIN WITH (FORMAT csv)", stream)
+    return stream.count
+
+
+def _stage(cursor, table: str, columns: List[str], rows: Iterable[Sequence]) -> str:
+    """COPY rows into a temporary table shaped like table's columns, dropped at commit"""
+    staging = f"{table}_load"
+    cursor.execute(f"DROP TABLE IF EXISTS {staging}")
+    cursor.execute(f"""
+        CREATE TEMP TABLE {staging} ON COMMIT DROP AS
+        SELECT {', '.join(columns)} FROM {table} WITH NO DATA
+    """)
+    copy_rows(cursor, staging, columns, rows)
+    return staging
+
+
+def copy_upsert(cursor, table: str, columns: List[str], rows: Iterable[Sequence],
+                key: List[str], update_columns: Optional[List[str]] = None) -> int:
+    """Upsert rows through a COPY-loaded staging table: rows matching key update update_columns
+    (and updated_at/fetched_at), or are skipped when update_columns is None. Within the rows,
+    the last one of each key wins. Returns the rows inserted or updated; the caller commits."""
+    staging = _stage(cursor, table, columns, rows)
+    key_list = ', '.join(key)
+
+    if update_columns is None:
+        conflict = "DO NOTHING"
+    else:
+        updates = [f"{column} = EXCLUDED.{column}" for column in update_columns]
+        updates += [f"{column} = CURRENT_TIMESTAMP" for column in _refresh_columns(cursor, table)]
+        conflict = "DO UPDATE SET " + ', '.join(updates)
+
+    cursor.execute(f"""
+        INSERT INTO {table} ({', '.join(columns)})
+        SELECT DISTINCT ON ({key_list}) {', '.join(columns)}
+        FROM {staging}
+        ORDER BY {key_list}, ctid DESC
+        ON CONFLICT ({key_list}) {conflict}
+    """)
+    return cursor.rowcount
+
+
+def copy_update(cursor, table: str, columns: List[str], rows: Iterable[Sequence], key: List[str]) -> int:
+    """UPDATE table's rows matching key with the other columns of rows, through a COPY-loaded
+    staging table. Returns the rows updated; the caller commits."""
+    staging = _stage(cursor, table, columns, rows)
+    updates = [f"{column} = s.{column}" for column in columns if column not in key]
+    updates += [f"{column} = CURRENT_TIMESTAMP" for column in _refresh_columns(cursor, table)]
+    matches = ' AND '.join(f"t.{column} = s.{column}" for column in key)
+
+    cursor.execute(f"""
+        UPDATE {table} t SET {', '.join(updates)}
+        FROM {staging} s
+        WHERE {matches}
+    """)
+    return cursor.rowcount
+
+
+def _refresh_columns(cursor, table: str) -> List[str]:
+    """The bookkeeping timestamps a write bumps: updated_at and the provenance fetched_at"""
+    cursor.execute("""
+        SELECT column_name FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = %s
+          AND column_name IN ('updated_at', 'fetched_at')
+        ORDER BY column_name DESC
+    """, (table,))
+    return [row['column_name'] for row in cursor.fetchall()]
+
+
+class StagingTable:
+    """Full reload of a table: create(), copy() every row (each call commits, so the load can
+    run for hours without holding a transaction open), then swap(). The staging table gets the
+    live table's constraints, indexes and triggers after the load, which is much faster than
+    maintaining them row by row, and swap() replaces the live table in one transaction."""
+
+    SUFFIX = '__staging'
+
+    def __init__(self, table: str, key: Optional[List[str]] = None):
+        """key is the table's unique key: rows copied twice keep the last copy, as an upsert would"""
+        self.table = table
+        self.key = key
+        self.staging = table + self.SUFFIX
+        self.rows = 0
+
+    def create(self):
+        """Start an empty staging table, dropping any left by an interrupted reload"""
+        with get_connection() as conn:
+            cursor = conn.cursor()
+            cursor.execute(f"DROP TABLE IF EXISTS {self.staging}")
+            cursor.execute(f"""
+                CREATE TABLE {self.staging}
+                (LIKE {self.table} INCLUDING DEFAULTS INCLUDING GENERATED INCLUDING IDENTITY)
+            """)
+            conn.commit()
+        self.rows = 0
+
+    def copy(self, columns: List[str], rows: Iterable[Sequence]) -> int:
+        """COPY rows into the staging table and commit them"""
+        with get_connection() as conn:
+            count = copy_rows(conn.cursor(), self.staging, columns, rows)
+            conn.commit()
+        self.rows += count
+        return count
+
+    def swap(self) -> float:
+        """Index the staging table like the live one and replace it; returns the seconds the
+        live table was locked. Fails, leaving the live table untouched, when another table's
+        foreign key or a view depends on it."""
+        with get_connection() as conn:
+            cursor = conn.cursor()
+
+            if self.key:
+                matches = ' AND '.join(f"a.{column} = b.{column}" for column in self.key)
+                cursor.execute(f"""
+                    DELETE FROM {self.staging} a USING {self.staging} b
+                    WHERE {matches} AND a.ctid < b.ctid
+                """)
+
+            # Constraints first (their indexes included), validating the loaded rows
+            cursor.execute("""
+                SELECT conname, pg_get_constraintdef(oid) AS definition FROM pg_constraint
+                WHERE conrelid = %s::regclass AND contype IN ('p', 'u', 'c', 'f', 'x')
+                ORDER BY contype = 'f', conname
+            """, (self.table,))
+            constraints = cursor.fetchall()
+            for c in constraints:
+                cursor.execute(
+                    f"ALTER TABLE {self.staging} ADD CONSTRAINT {c['conname']}{self.SUFFIX} {c['definition']}"
+                )
+            constraint_names = {c['conname'] for c in constraints}
+
+            cursor.execute("""
+                SELECT indexname, indexdef FROM pg_indexes
+                WHERE schemaname = current_schema() AND tablename = %s
+            """, (self.table,))
+            indexes = [i for i in cursor.fetchall() if i['indexname'] not in constraint_names]
+            for i in indexes:
+                definition = re.sub(
+                    rf"INDEX {re.escape(i['indexname'])} ON (\w+\.)?{re.escape(self.table)} ",
+                    f"INDEX {i['indexname']}{self.SUFFIX} ON {self.staging} ",
+                    i['indexdef'], count=1,
+                )
+                cursor.execute(definition)
+
+            cursor.execute("""
+                SELECT tgname, pg_get_triggerdef(oid) AS definition FROM pg_trigger
+                WHERE tgrelid = %s::regclass AND NOT tgisinternal
+            """, (self.table,))
+            triggers = cursor.fetchall()
+
+            cursor.execute(f"ANALYZE {self.staging}")
+
+            locked_at = time.time()
+            cursor.execute(f"LOCK TABLE {self.table} IN ACCESS EXCLUSIVE MODE")
+
+            # Serial columns share the live table's sequence: hand it over before the drop
+            cursor.execute("""
+                SELECT a.attname, pg_get_serial_sequence(%s, a.attname) AS sequence
+                FROM pg_attribute a
+                WHERE a.attrelid = %s::regclass AND a.attnum > 0 AND NOT a.attisdropped
+            """, (self.table, self.table))
+            for column in cursor.fetchall():
+                if column['sequence']:
+                    cursor.execute(f"ALTER SEQUENCE {column['sequence']} OWNED BY {self.staging}.{column['attname']}")
+
+            cursor.execute(f"DROP TABLE {self.table}")
+            cursor.execute(f"ALTER TABLE {self.staging} RENAME TO {self.table}")
+            for c in constraints:
+                cursor.execute(
+                    f"ALTER TABLE {self.table} RENAME CONSTRAINT {c['conname']}{self.SUFFIX} TO {c['conname']}"
+                )
+            for i in indexes:
+                cursor.execute(f"ALTER INDEX {i['indexname']}{self.SUFFIX} RENAME TO {i['indexname']}")
+            for t in triggers:
+                cursor.execute(t['definition'])
+
+            conn.commit()
+            return time.time() - locked_at
+
+    def drop(self):
+        """Abandon the reload"""
+        with get_connection() as conn:
+            conn.cursor().execute(f"DROP TABLE IF EXISTS {self.staging}")
+            conn.commit()
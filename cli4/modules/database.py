@@ -4,6 +4,8 @@ Simple PostgreSQL database functions - no unnecessary "manager" class
 """
 
 import os
+import getpass
+import json
 import psycopg2
 import psycopg2.extras
 from typing import Dict, List, Any, Optional
@@ -106,6 +108,22 @@ def execute_batch_returning(query: str, values: List[tuple]) -> List[dict]:
         return results
 
 
+def record_audit(action: str, resource: str, payload: Optional[Dict[str, Any]] = None) -> bool:
+    """Record an ETL action in the API's audit_log table (created by the backend on startup)"""
+    try:
+        execute_update(
+            """
+            INSERT INTO audit_log (actor, role, action, resource, payload)
+            VALUES (%s, %s, %s, %s, %s)
+            """,
+            (f"cli4:{getpass.getuser()}", 'etl', action, resource, json.dumps(payload or {}, default=str))
+        )
+        return True
+    except Exception as e:
+        print(f"⚠️ Audit entry not recorded ({action} {resource}): {e}")
+        return False
+
+
 def get_table_count(table_name: str) -> int:
     """Get row count for table"""
     result = execute_query(f"SELECT COUNT(*) as count FROM {table_name}")
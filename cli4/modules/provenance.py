@@ -0,0 +1,100 @@
+"""
+CLI4 Provenance Module
+Every ingested row records where it came from: the source system, the record's ID in that
+source and when we last fetched it. Most tables were created with a source column; this adds
+the rest, derives source_record_id from each table's natural key where the source gives one,
+and adds fetched_at, which populators bump whenever an upsert refreshes a row.
+The API reads the same columns for /api/provenance (internal/database/provenance.go).
+"""
+
+from typing import Dict, Optional, Tuple
+
+# table -> (source column, default source when the column is added, source record ID expression)
+# The source column is the table's own when it has one; otherwise data_source is added with the
+# default. The expression becomes a generated source_record_id column on tables that lack one,
+# so it must be immutable (no date or timestamp casts); None where the table already keeps the
+# source's ID (source_record_id, api_reference_id) or the source has none. Keys never embed a
+# person's full CPF, since the API serves them to the public.
+PROVENANCE_TABLES: Dict[str, Tuple[str, Optional[str], Optional[str]]] = {
+    'unified_politicians': ('data_source', 'CAMARA', "deputy_id::text"),
+    'financial_counterparts': ('source_system', None, "cnpj_cpf"),
+    'unified_financial_records': ('source_system', None, None),
+    'unified_electoral_records': ('source_system', None, None),
+    'unified_political_networks': ('source_system', None, "network_type || ':' || network_id"),
+    'unified_wealth_tracking': ('data_source', 'TSE', "year::text"),
+    'politician_career_history': ('source_system', None, None),
+    'politician_events': ('data_source', 'CAMARA', "event_id"),
+    'politician_assets': ('data_source', 'TSE', "declaration_year::text || ':' || asset_sequence::text"),
+    'politician_professional_background': ('source_system', None, None),
+    'vendor_sanctions': ('data_source', None, None),
+    'tcu_disqualifications': ('data_source', None, None),
+    'senado_politicians': ('data_source', None, "codigo"),
+    'political_parties': ('data_source', 'CAMARA', "id::text"),
+    'party_memberships': ('data_source', 'CAMARA', "party_id::text || ':' || deputy_id::text"),
+    'ipca_index': ('data_source', None, None),
+    'politician_wikidata': ('data_source', None, "qid"),
+    'party_funds': ('data_source', None, "party_sigla || ':' || year::text || ':' || month::text || ':' || fund_type"),
+    'party_membership_history': ('data_source', None, None),
+    'company_partners': ('data_source', None, "cnpj_root || ':' || partner_document || ':' || partner_name"),
+    'plenary_attendance': ('data_source', None, "session_id::text || ':' || deputy_id::text"),
+    'politician_speeches': ('data_source', None, None),
+    'parliamentary_staff': ('data_source', None, None),
+    'tcu_rulings': ('data_source', None, "ruling_key"),
+    'public_loans': ('lender', None, "loan_key"),
+    'ibge_areas': ('data_source', 'IBGE', "ibge_code"),
+    'parliamentary_amendments': ('data_source', 'PORTAL_TRANSPARENCIA', "amendment_code"),
+}
+
+# When a table's existing rows were last fetched, for the fetched_at backfill
+_BACKFILL = {
+    'politician_wikidata': "retrieved_at",
+}
+
+# Tables created without updated_at (see scripts/setup/recreate_all_tables.py)
+_INSERT_ONLY = {
+    'unified_political_networks', 'politician_career_history', 'politician_events',
+    'politician_assets', 'politician_professional_background', 'party_memberships',
+    'party_membership_history', 'company_partners', 'plenary_attendance',
+    'politician_speeches', 'parliamentary_staff',
+}
+
+
+def provenance_sql(table: str) -> str:
+    """Idempotent DDL giving table its provenance columns; a no-op when the table doesn't exist"""
+    source_column, default_source, record_id = PROVENANCE_TABLES[table]
+    statements = []
+    if default_source:
+        statements.append(
+            f"ALTER TABLE {table} ADD COLUMN IF NOT EXISTS {source_column} VARCHAR(50) DEFAULT '{default_source}';"
+        )
+    if record_id:
+        statements.append(
+            f"ALTER TABLE {table} ADD COLUMN IF NOT EXISTS source_record_id TEXT "
+            f"GENERATED ALWAYS AS ({record_id}) STORED;"
+        )
+    backfill = _BACKFILL.get(table, "created_at" if table in _INSERT_ONLY else "COALESCE(updated_at, created_at)")
+    # fetched_at is added without a default first so existing rows keep their own dates
+    statements.append(f"""
+            IF NOT EXISTS (
+                SELECT 1 FROM information_schema.columns
+                WHERE table_name = '{table}' AND column_name = 'fetched_at'
+            ) THEN
+                ALTER TABLE {table} ADD COLUMN fetched_at TIMESTAMP;
+                UPDATE {table} SET fetched_at = {backfill};
+                ALTER TABLE {table} ALTER COLUMN fetched_at SET DEFAULT CURRENT_TIMESTAMP;
+            END IF;""")
+
+    body = '\n            '.join(statements)
+    return f"""
+        DO $$ BEGIN
+            IF to_regclass('{table}') IS NOT NULL THEN
+            {body}
+            END IF;
+        END $$
+    """
+
+
+def ensure_provenance_columns(cursor) -> None:
+    """Add the provenance columns to every ingested table that exists, for new and old databases"""
+    for table in PROVENANCE_TABLES:
+        cursor.execute(provenance_sql(table))
@@ -22,6 +22,8 @@ class CLI4RateLimiter:
             'portal': 1.0,    # 1 call/second max (has API key)
             'tcu': 1.5,       # Conservative for government site
             'datajud': 2.0,   # Very conservative for judicial data
+            'ibge': 1.0,      # 1 call/second max
+            'wikidata': 1.0,  # Wikimedia asks bots to avoid parallel bursts
             'default': 1.0    # Default for unknown APIs
         }
 
@@ -0,0 +1,112 @@
+"""
+CLI4 Dataset Versions Module
+Every data-writing command stamps its run on the dataset version being built. publish-version
+freezes that version: the ETL tables are copied into a dataset_v<N> schema the API can pin reads
+to (?dataset_version=N, internal/database/versions.go), and published versions beyond the ones
+kept are pruned. The dataset_versions table is created by the backend on startup.
+"""
+
+import json
+from datetime import datetime
+from typing import Dict, Optional
+
+from cli4.modules.database import get_connection
+from cli4.modules.provenance import PROVENANCE_TABLES
+
+# The ETL tables a version freezes: every ingested table plus the ones derived from them.
+# Tables the API curates itself (politician_relations, entity_links, users...) stay live.
+SNAPSHOT_TABLES = list(PROVENANCE_TABLES) + [
+    'tcu_ruling_parties', 'speech_topics', 'nepotism_flags', 'expense_anomalies',
+]
+
+DEFAULT_KEEP = 5
+
+
+def is_data_command(command: str) -> bool:
+    """Whether a cli4 command writes ETL data, and so belongs to a dataset version"""
+    return command.startswith('populate') or command in (
+        'detect-anomalies', 'resolve-entities', 'post-process', 'clear-db',
+    )
+
+
+def schema_name(version: int) -> str:
+    return f"dataset_v{version}"
+
+
+def stamp_run(command: str, started_at: datetime, status: str) -> Optional[int]:
+    """Record an ETL run on the version being built, opening one if none is; returns its number"""
+    run = {
+        'command': command,
+        'started_at': started_at.isoformat(timespec='seconds'),
+        'finished_at': datetime.now().isoformat(timespec='seconds'),
+        'status': status,
+    }
+    try:
+        with get_connection() as conn:
+            cursor = conn.cursor()
+            cursor.execute("""
+                INSERT INTO dataset_versions (status, runs)
+                VALUES ('building', %s::jsonb)
+                ON CONFLICT (status) WHERE status = 'building'
+                DO UPDATE SET runs = dataset_versions.runs || EXCLUDED.runs
+                RETURNING version
+            """, (json.dumps([run]),))
+            version = cursor.fetchone()['version']
+            conn.commit()
+        return version
+    except Exception as e:
+        print(f"⚠️ Run not stamped on a dataset version ({command}): {e}")
+        return None
+
+
+def publish(keep: int = DEFAULT_KEEP, note: Optional[str] = None) -> Dict:
+    """Freeze the version being built into its schema and prune published versions beyond keep.
+    Runs in one transaction, so the API never sees a half-copied version."""
+    if keep < 1:
+        raise ValueError("keep must be at least 1")
+
+    with get_connection() as conn:
+        cursor = conn.cursor()
+        cursor.execute("""
+            INSERT INTO dataset_versions (status) VALUES ('building')
+            ON CONFLICT (status) WHERE status = 'building' DO NOTHING
+        """)
+        cursor.execute("SELECT version FROM dataset_versions WHERE status = 'building' FOR UPDATE")
+        version = cursor.fetchone()['version']
+        schema = schema_name(version)
+
+        cursor.execute(f"CREATE SCHEMA {schema}")
+        table_rows = {}
+        for table in SNAPSHOT_TABLES:
+            cursor.execute("SELECT to_regclass(%s) IS NOT NULL AS present", (f"public.{table}",))
+            if not cursor.fetchone()['present']:
+                continue
+            # Without INCLUDING GENERATED, generated columns (source_record_id) become plain ones
+            cursor.execute(f"CREATE TABLE {schema}.{table} (LIKE public.{table} INCLUDING INDEXES)")
+            cursor.execute(f"INSERT INTO {schema}.{table} SELECT * FROM public.{table}")
+            table_rows[table] = cursor.rowcount
+
+        cursor.execute("""
+            UPDATE dataset_versions
+            SET status = 'published', published_at = CURRENT_TIMESTAMP,
+                table_rows = %s::jsonb, note = COALESCE(%s, note)
+            WHERE version = %s
+        """, (json.dumps(table_rows), note, version))
+
+        cursor.execute("""
+            SELECT version FROM dataset_versions
+            WHERE status = 'published'
+            ORDER BY version DESC
+            OFFSET %s
+        """, (keep,))
+        pruned = [row['version'] for row in cursor.fetchall()]
+        for old in pruned:
+            cursor.execute(f"DROP SCHEMA IF EXISTS {schema_name(old)} CASCADE")
+            cursor.execute("""
+                UPDATE dataset_versions SET status = 'pruned', pruned_at = CURRENT_TIMESTAMP
+                WHERE version = %s
+            """, (old,))
+
+        conn.commit()
+
+    return {'version': version, 'table_rows': table_rows, 'pruned': pruned}
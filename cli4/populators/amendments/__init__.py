@@ -0,0 +1,5 @@
+# Parliamentary Amendments Populator Module
+
+from .populator import AmendmentsPopulator
+
+__all__ = ['AmendmentsPopulator']
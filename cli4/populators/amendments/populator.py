@@ -0,0 +1,165 @@
+"""
+CLI4 Amendments Populator
+Populate parliamentary_amendments with the budget amendments (emendas parlamentares) from the
+Portal da Transparência, matching authors to tracked politicians and the place the money goes
+to its IBGE code, so the API can map amendment spending (/api/geo/spending).
+"""
+
+import re
+from decimal import Decimal, InvalidOperation
+from typing import Dict, List, Optional
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+from cli4.modules.dependency_checker import DependencyChecker
+from src.validation.normalization import normalize_name
+from src.clients.portal_transparencia_client import PortalTransparenciaClient
+
+# "CAMPINAS - SP" for a municipality, "SÃO PAULO (UF)" for a state
+MUNICIPALITY_LOCALITY = re.compile(r'^(.+?)\s*-\s*([A-Z]{2})$')
+STATE_LOCALITY = re.compile(r'^(.+?)\s*\(UF\)$')
+
+
+class AmendmentsPopulator:
+    """Populate parliamentary_amendments from the Portal da Transparência"""
+
+    MAX_PAGES = 2000
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+        self.portal_client = PortalTransparenciaClient()
+
+    def populate(self, years: List[int]) -> int:
+        """Load every amendment of the given budget years. Returns the amendments stored."""
+
+        print("📜 PARLIAMENTARY AMENDMENTS POPULATION")
+        print("=" * 60)
+        print("Budget amendments by author and destination")
+        print()
+
+        DependencyChecker.print_dependency_warning(
+            required_steps=["politicians"],
+            current_step="AMENDMENTS POPULATION"
+        )
+
+        authors = self._load_authors()
+        municipalities, states = self._load_areas()
+        print(f"👥 {len(authors):,} politician names, 🗺️ {len(municipalities):,} municipalities")
+
+        stored = 0
+        for year in years:
+            year_stored = 0
+            for page in range(1, self.MAX_PAGES + 1):
+                self.rate_limiter.wait_if_needed('portal')
+                amendments = self.portal_client.get_amendments(year, page=page)
+                self.logger.log_api_call('portal', f'emendas/{year}', 'success' if amendments else 'empty', 0)
+                if not amendments:
+                    break
+
+                for amendment in amendments:
+                    if self._store_amendment(amendment, year, authors, municipalities, states):
+                        year_stored += 1
+
+            stored += year_stored
+            print(f"   📅 {year}: {year_stored:,} amendments")
+            self.logger.log_processing('amendments', str(year), 'success', {'stored': year_stored})
+
+        print(f"\n✅ Amendments population completed")
+        print(f"📜 {stored:,} amendments stored")
+
+        return stored
+
+    def _load_authors(self) -> Dict[str, int]:
+        """Politicians by normalized ballot name, leaving out names shared by several"""
+        authors: Dict[str, Optional[int]] = {}
+        for row in database.execute_query(
+            "SELECT id, nome_eleitoral FROM unified_politicians WHERE nome_eleitoral IS NOT NULL"
+        ):
+            key = normalize_name(row['nome_eleitoral'])
+            authors[key] = None if key in authors else row['id']
+        return {k: v for k, v in authors.items() if v is not None}
+
+    def _load_areas(self):
+        """IBGE codes by (uf, normalized name) for municipalities and by normalized name for states"""
+        municipalities = {}
+        states = {}
+        if not database.execute_query(
+            "SELECT 1 FROM information_schema.tables WHERE table_name = 'ibge_areas'"
+        ):
+            return municipalities, states
+
+        for row in database.execute_query("SELECT ibge_code, level, normalized_name, uf FROM ibge_areas"):
+            if row['level'] == 'state':
+                states[row['normalized_name']] = row['ibge_code']
+            else:
+                municipalities[(row['uf'], row['normalized_name'])] = row['ibge_code']
+        return municipalities, states
+
+    def _locate(self, locality: str, municipalities, states) -> Optional[str]:
+        """IBGE code of the place an amendment funds; national or regional ones have none"""
+        locality = locality.strip().upper()
+        match = MUNICIPALITY_LOCALITY.match(locality)
+        if match:
+            return municipalities.get((match.group(2), normalize_name(match.group(1))))
+        match = STATE_LOCALITY.match(locality)
+        if match:
+            return states.get(normalize_name(match.group(1)))
+        return None
+
+    def _store_amendment(self, amendment: Dict, year: int, authors: Dict[str, int],
+                         municipalities, states) -> bool:
+        code = str(amendment.get('codigoEmenda') or '').strip()
+        if not code:
+            return False
+
+        author = (amendment.get('nomeAutor') or amendment.get('autor') or '').strip()
+        locality = (amendment.get('localidadeDoGasto') or '').strip()
+
+        database.execute_update(
+            """
+            INSERT INTO parliamentary_amendments (
+                amendment_code, year, amendment_type, amendment_number, author_name, politician_id,
+                locality, ibge_code, function_name, subfunction_name,
+                committed_value, liquidated_value, paid_value
+            )
+            VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+            ON CONFLICT (amendment_code) DO UPDATE SET
+                politician_id = COALESCE(EXCLUDED.politician_id, parliamentary_amendments.politician_id),
+                ibge_code = COALESCE(EXCLUDED.ibge_code, parliamentary_amendments.ibge_code),
+                committed_value = EXCLUDED.committed_value,
+                liquidated_value = EXCLUDED.liquidated_value,
+                paid_value = EXCLUDED.paid_value,
+                updated_at = CURRENT_TIMESTAMP,
+                fetched_at = CURRENT_TIMESTAMP
+            """,
+            (
+                code[:30],
+                amendment.get('ano') or year,
+                (amendment.get('tipoEmenda') or '')[:100] or None,
+                str(amendment.get('numeroEmenda') or '')[:20] or None,
+                author[:255] or None,
+                authors.get(normalize_name(author)) if author else None,
+                locality[:255] or None,
+                self._locate(locality, municipalities, states) if locality else None,
+                (amendment.get('funcao') or '')[:100] or None,
+                (amendment.get('subfuncao') or '')[:100] or None,
+                self._money(amendment.get('valorEmpenhado')),
+                self._money(amendment.get('valorLiquidado')),
+                self._money(amendment.get('valorPago')),
+            )
+        )
+        return True
+
+    @staticmethod
+    def _money(value) -> Optional[Decimal]:
+        """Brazilian (1.234,56) or plain (1234.56) amounts"""
+        if value is None or value == '':
+            return None
+        value = str(value)
+        if ',' in value:
+            value = value.replace('.', '').replace(',', '.')
+        try:
+            return Decimal(value).quantize(Decimal('0.01'))
+        except InvalidOperation:
+            return None
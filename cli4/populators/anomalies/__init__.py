@@ -0,0 +1,5 @@
+# Expense Anomalies Detection Module
+
+from .populator import AnomaliesPopulator
+
+__all__ = ['AnomaliesPopulator']
@@ -0,0 +1,29 @@
+"""
+Distances between Brazilian states, measured between their capitals
+"""
+
+import math
+from typing import Optional
+
+# (latitude, longitude) of each state capital
+UF_CAPITALS = {
+    'AC': (-9.975, -67.824), 'AL': (-9.666, -35.735), 'AM': (-3.119, -60.021), 'AP': (0.035, -51.069),
+    'BA': (-12.972, -38.501), 'CE': (-3.717, -38.542), 'DF': (-15.780, -47.929), 'ES': (-20.316, -40.313),
+    'GO': (-16.686, -49.264), 'MA': (-2.539, -44.283), 'MG': (-19.917, -43.935), 'MS': (-20.443, -54.646),
+    'MT': (-15.601, -56.097), 'PA': (-1.455, -48.502), 'PB': (-7.115, -34.864), 'PE': (-8.054, -34.881),
+    'PI': (-5.089, -42.802), 'PR': (-25.428, -49.273), 'RJ': (-22.907, -43.173), 'RN': (-5.794, -35.211),
+    'RO': (-8.761, -63.900), 'RR': (2.820, -60.673), 'RS': (-30.033, -51.230), 'SC': (-27.597, -48.550),
+    'SE': (-10.947, -37.073), 'SP': (-23.551, -46.633), 'TO': (-10.184, -48.334),
+}
+
+EARTH_RADIUS_KM = 6371.0
+
+
+def uf_distance_km(a: str, b: str) -> Optional[float]:
+    """Great-circle distance between the capitals of two states, None for unknown UFs"""
+    if a not in UF_CAPITALS or b not in UF_CAPITALS:
+        return None
+    lat1, lon1 = map(math.radians, UF_CAPITALS[a])
+    lat2, lon2 = map(math.radians, UF_CAPITALS[b])
+    h = math.sin((lat2 - lat1) / 2) ** 2 + math.cos(lat1) * math.cos(lat2) * math.sin((lon2 - lon1) / 2) ** 2
+    return 2 * EARTH_RADIUS_KM * math.asin(math.sqrt(h))
@@ -0,0 +1,242 @@
+"""
+CLI4 Anomalies Populator
+Detect suspicious expense patterns and store them in expense_anomalies, one row per politician and
+vendor, for GET /api/anomalies and the corruption risk score (post-process --enhanced).
+
+geo_mismatch: recurring CEAP expenses that are consumed where they are bought (fuel, car rental,
+meals, the support office) paid to a vendor registered in a state far from the politician's own,
+e.g. a Roraima deputy filling up in São Paulo month after month. Brasília is where deputies work,
+so vendors there never count. Vendor states come from cli4 populate-geo.
+
+duplicate: two or more expenses of the same kind with the same amount to the same CNPJ on the
+same date, by one politician or several, with the documents of each so they can be compared.
+"""
+
+import json
+import time
+from typing import Callable, Dict, List
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+from cli4.modules.dependency_checker import DependencyChecker
+from src.validation.normalization import normalize_name
+from cli4.populators.anomalies.geo import uf_distance_km
+
+# Expense categories (CEAP tipoDespesa, normalized) that only make sense near where the
+# politician lives or works
+LOCAL_CATEGORIES = (
+    'COMBUSTIVEIS',
+    'LOCACAO OU FRETAMENTO DE VEICULOS',
+    'FORNECIMENTO DE ALIMENTACAO',
+    'MANUTENCAO DE ESCRITORIO',
+)
+
+# A vendor is far at this distance between state capitals (Roraima-São Paulo is ~3,300 km,
+# neighbouring capitals are mostly under 1,000 km) and the pattern recurring at this many
+# distinct months
+GEO_MISMATCH_MIN_KM = 1000
+GEO_MISMATCH_MIN_MONTHS = 3
+
+WORKPLACE_UF = 'DF'
+
+# Duplicates below this amount (R$) are mostly legitimate repeat purchases such as two taxi rides,
+# and airfares repeat whenever colleagues fly together
+DUPLICATE_MIN_AMOUNT = 100
+DUPLICATE_IGNORED_CATEGORIES = ('PASSAGEM AEREA', 'PASSAGENS AEREAS')
+
+
+class AnomaliesPopulator:
+    """Populate expense_anomalies"""
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+        self.detectors: Dict[str, Callable[[], List[Dict]]] = {
+            'geo_mismatch': self._detect_geo_mismatch,
+            'duplicate': self._detect_duplicates,
+        }
+
+    def populate(self, types: List[str] = None) -> int:
+        """Replace the anomalies of each type with a fresh detection. Returns the anomalies stored."""
+
+        print("🔎 EXPENSE ANOMALIES DETECTION")
+        print("=" * 60)
+        print("Suspicious expense patterns per politician and vendor")
+        print()
+
+        DependencyChecker.print_dependency_warning(
+            required_steps=["politicians", "financial"],
+            current_step="ANOMALIES DETECTION"
+        )
+
+        types = types or list(self.detectors)
+        unknown = [t for t in types if t not in self.detectors]
+        if unknown:
+            raise ValueError(f"Unknown anomaly types: {', '.join(unknown)} (known: {', '.join(self.detectors)})")
+
+        start_time = time.time()
+        stored = 0
+        for anomaly_type in types:
+            print(f"🔍 {anomaly_type}...")
+            anomalies = self.detectors[anomaly_type]()
+            self._replace(anomaly_type, anomalies)
+            stored += len(anomalies)
+            print(f"   ⚠️ {len(anomalies):,} anomalies")
+            self.logger.log_processing('anomalies', anomaly_type, 'success', {'stored': len(anomalies)})
+
+        elapsed_time = time.time() - start_time
+        print(f"\n✅ Anomalies detection completed")
+        print(f"🔎 {stored:,} anomalies stored")
+        print(f"⏱️  Total time: {elapsed_time:.1f} seconds")
+
+        return stored
+
+    def _detect_geo_mismatch(self) -> List[Dict]:
+        """Vendors of locally consumed expenses registered far from the politician's state"""
+        groups = database.execute_query("""
+            SELECT
+                fr.politician_id,
+                p.current_state as politician_uf,
+                fc.state as vendor_uf,
+                MAX(fc.municipality) as vendor_municipality,
+                fr.counterpart_cnpj_cpf as cnpj_cpf,
+                MAX(COALESCE(fc.name, fr.counterpart_name)) as counterpart_name,
+                fr.transaction_category as category,
+                COUNT(*) as record_count,
+                SUM(fr.amount) as total_amount,
+                COUNT(DISTINCT (fr.year, fr.month)) as months,
+                MIN(fr.transaction_date) as first_date,
+                MAX(fr.transaction_date) as last_date
+            FROM unified_financial_records fr
+            JOIN unified_politicians p ON p.id = fr.politician_id
+            JOIN financial_counterparts fc ON fc.cnpj_cpf = fr.counterpart_cnpj_cpf
+            WHERE fr.transaction_type = 'PARLIAMENTARY_EXPENSE'
+              AND fc.state IS NOT NULL
+              AND p.current_state IS NOT NULL
+              AND fc.state <> p.current_state
+              AND fc.state <> %s
+            GROUP BY fr.politician_id, p.current_state, fc.state, fr.counterpart_cnpj_cpf, fr.transaction_category
+        """, (WORKPLACE_UF,))
+
+        anomalies = []
+        for group in groups:
+            if not normalize_name(group['category'] or '').startswith(LOCAL_CATEGORIES):
+                continue
+            if group['months'] < GEO_MISMATCH_MIN_MONTHS:
+                continue
+            distance = uf_distance_km(group['politician_uf'], group['vendor_uf'])
+            if distance is None or distance < GEO_MISMATCH_MIN_KM:
+                continue
+
+            anomalies.append({
+                'politician_id': group['politician_id'],
+                'cnpj_cpf': group['cnpj_cpf'],
+                'counterpart_name': group['counterpart_name'],
+                'record_count': group['record_count'],
+                'total_amount': group['total_amount'],
+                'first_date': group['first_date'],
+                'last_date': group['last_date'],
+                'details': {
+                    'category': group['category'],
+                    'politician_uf': group['politician_uf'],
+                    'vendor_uf': group['vendor_uf'],
+                    'vendor_municipality': group['vendor_municipality'],
+                    'distance_km': round(distance),
+                    'months': group['months'],
+                },
+            })
+
+        return anomalies
+
+    def _detect_duplicates(self) -> List[Dict]:
+        """Expenses sharing type, CNPJ, date and amount; one anomaly per politician involved"""
+        records = database.execute_query("""
+            WITH groups AS (
+                SELECT transaction_type, counterpart_cnpj_cpf, transaction_date, amount
+                FROM unified_financial_records
+                WHERE transaction_type IN ('PARLIAMENTARY_EXPENSE', 'CAMPAIGN_EXPENSE_PAID')
+                  AND LENGTH(counterpart_cnpj_cpf) = 14
+                  AND amount >= %s
+                GROUP BY 1, 2, 3, 4
+                HAVING COUNT(*) >= 2
+            )
+            SELECT fr.id, fr.politician_id, fr.transaction_type, fr.counterpart_cnpj_cpf as cnpj_cpf,
+                   fr.counterpart_name, fr.transaction_date, fr.amount, fr.transaction_category as category,
+                   fr.document_number, fr.document_url
+            FROM unified_financial_records fr
+            JOIN groups g ON g.transaction_type = fr.transaction_type
+                         AND g.counterpart_cnpj_cpf = fr.counterpart_cnpj_cpf
+                         AND g.transaction_date = fr.transaction_date
+                         AND g.amount = fr.amount
+            ORDER BY fr.counterpart_cnpj_cpf, fr.transaction_date, fr.amount, fr.id
+        """, (DUPLICATE_MIN_AMOUNT,))
+
+        groups: Dict[tuple, List[Dict]] = {}
+        for record in records:
+            if normalize_name(record['category'] or '').startswith(DUPLICATE_IGNORED_CATEGORIES):
+                continue
+            key = (record['transaction_type'], record['cnpj_cpf'], record['transaction_date'], record['amount'])
+            groups.setdefault(key, []).append(record)
+
+        anomalies = []
+        for group in groups.values():
+            if len(group) < 2:
+                continue
+
+            politicians = sorted({r['politician_id'] for r in group})
+            document_numbers = [r['document_number'] for r in group if r['document_number']]
+            details = {
+                'scope': 'same_politician' if len(politicians) == 1 else 'cross_politician',
+                'amount': float(group[0]['amount']),
+                'date': group[0]['transaction_date'].isoformat(),
+                'category': group[0]['category'],
+                'same_document': len(document_numbers) > len(set(document_numbers)),
+                'politician_ids': politicians,
+                'records': [
+                    {
+                        'record_id': r['id'],
+                        'politician_id': r['politician_id'],
+                        'document_number': r['document_number'],
+                        'document_url': r['document_url'],
+                    }
+                    for r in group
+                ],
+            }
+
+            for politician_id in politicians:
+                own = [r for r in group if r['politician_id'] == politician_id]
+                anomalies.append({
+                    'politician_id': politician_id,
+                    'cnpj_cpf': group[0]['cnpj_cpf'],
+                    'counterpart_name': group[0]['counterpart_name'],
+                    'record_count': len(own),
+                    'total_amount': sum(r['amount'] for r in own),
+                    'first_date': group[0]['transaction_date'],
+                    'last_date': group[0]['transaction_date'],
+                    'details': details,
+                })
+
+        return anomalies
+
+    def _replace(self, anomaly_type: str, anomalies: List[Dict]):
+        with database.get_connection() as conn:
+            cursor = conn.cursor()
+            cursor.execute("DELETE FROM expense_anomalies WHERE anomaly_type = %s", (anomaly_type,))
+            cursor.executemany(
+                """
+                INSERT INTO expense_anomalies (
+                    anomaly_type, politician_id, cnpj_cpf, counterpart_name, record_count,
+                    total_amount, first_date, last_date, details
+                )
+                VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+                """,
+                [
+                    (
+                        anomaly_type, a['politician_id'], a['cnpj_cpf'], (a['counterpart_name'] or '')[:255] or None,
+                        a['record_count'], a['total_amount'], a['first_date'], a['last_date'],
+                        json.dumps(a['details'], ensure_ascii=False),
+                    )
+                    for a in anomalies
+                ]
+            )
+            conn.commit()
@@ -278,7 +278,8 @@ class AssetsPopulator:
                             declared_value = EXCLUDED.declared_value,
                             asset_description = EXCLUDED.asset_description,
                             last_update_date = EXCLUDED.last_update_date,
-                            data_generation_date = EXCLUDED.data_generation_date
+                            data_generation_date = EXCLUDED.data_generation_date,
+                            fetched_at = CURRENT_TIMESTAMP
                     """
                 else:
                     # Skip duplicates
@@ -0,0 +1,5 @@
+# Plenary Attendance Populator Module
+
+from .populator import AttendancePopulator
+
+__all__ = ['AttendancePopulator']
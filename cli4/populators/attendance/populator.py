@@ -0,0 +1,195 @@
+"""
+CLI4 Attendance Populator
+Populate plenary_attendance with each deputy's presence at deliberative plenary sessions
+(/eventos?idOrgao=180 and /eventos/{id}/deputados) and roll it up into the
+plenary_sessions_total, plenary_sessions_present and plenary_absence_rate columns
+of unified_politicians, which the API exposes and sorts by
+"""
+
+import time
+from datetime import date
+from typing import Dict, List, Optional, Set
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+from cli4.modules.dependency_checker import DependencyChecker
+from src.clients.deputados_client import DeputadosClient
+
+
+class AttendancePopulator:
+    """Populate plenary_attendance and per-politician absence rates"""
+
+    # Only deliberative sessions count: attendance at debate-only sessions isn't required
+    SESSION_TYPE_PREFIX = 'Sessão Deliberativa'
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+        self.deputados_client = DeputadosClient()
+
+    def populate(self, start_date: Optional[str] = None, end_date: Optional[str] = None) -> int:
+        """Record attendance for every deliberative plenary session between the dates
+        (default: January 1st of the current year to today) and recompute absence rates"""
+
+        today = date.today()
+        start_date = start_date or date(today.year, 1, 1).isoformat()
+        end_date = end_date or today.isoformat()
+
+        print("🗳️ PLENARY ATTENDANCE POPULATION")
+        print("=" * 60)
+        print(f"Deliberative plenary sessions from {start_date} to {end_date}")
+        print()
+
+        DependencyChecker.print_dependency_warning(
+            required_steps=["politicians"],
+            current_step="ATTENDANCE POPULATION"
+        )
+
+        sessions = self._fetch_sessions(start_date, end_date)
+        print(f"📅 Found {len(sessions)} deliberative sessions")
+
+        politicians = database.execute_query(
+            "SELECT id, deputy_id, situacao FROM unified_politicians WHERE deputy_id IS NOT NULL"
+        )
+        by_deputy = {p['deputy_id']: p for p in politicians}
+
+        present_by_session: Dict[int, Set[int]] = {}
+        for i, session in enumerate(sessions, 1):
+            print(f"🏛️ [{i}/{len(sessions)}] {session['date']} {session['type']}")
+            try:
+                present_by_session[session['id']] = self._fetch_present(session['id'])
+                print(f"  ✅ {len(present_by_session[session['id']])} deputies present")
+            except Exception as e:
+                print(f"  ❌ Error: {e}")
+                self.logger.log_processing('attendance', str(session['id']), 'error', {'error': str(e)})
+
+        sessions = [s for s in sessions if s['id'] in present_by_session]
+        rows = self._build_rows(sessions, present_by_session, by_deputy)
+        self._replace_rows(sessions, rows)
+        updated = self._update_rates()
+
+        present = sum(1 for r in rows if r['present'])
+        print(f"\n✅ Attendance population completed")
+        print(f"📊 {len(rows)} attendance records ({present} present, {len(rows) - present} absent)")
+        print(f"👥 Absence rates updated for {updated} politicians")
+
+        return len(rows)
+
+    def _fetch_sessions(self, start_date: str, end_date: str) -> List[Dict]:
+        """Fetch finished deliberative plenary sessions, oldest first"""
+        self.rate_limiter.wait_if_needed('camara')
+
+        try:
+            start_time = time.time()
+            events = self.deputados_client.get_plenary_sessions(start_date, end_date)
+            self.logger.log_api_call('camara', 'eventos?idOrgao=180', 'success', time.time() - start_time)
+        except Exception:
+            self.logger.log_api_call('camara', 'eventos?idOrgao=180', 'error', 0)
+            raise
+
+        sessions = []
+        for event in events:
+            session_type = event.get('descricaoTipo') or ''
+            if not session_type.startswith(self.SESSION_TYPE_PREFIX):
+                continue
+            if event.get('situacao') != 'Encerrada' or not event.get('dataHoraInicio'):
+                continue
+            sessions.append({
+                'id': event['id'],
+                'date': event['dataHoraInicio'][:10],
+                'type': session_type[:100],
+            })
+
+        return sorted(sessions, key=lambda s: (s['date'], s['id']))
+
+    def _fetch_present(self, session_id: int) -> Set[int]:
+        """Deputy IDs registered as present at a session"""
+        self.rate_limiter.wait_if_needed('camara')
+
+        try:
+            start_time = time.time()
+            deputies = self.deputados_client.get_event_attendance(session_id)
+            self.logger.log_api_call('camara', f'eventos/{session_id}/deputados', 'success', time.time() - start_time)
+        except Exception:
+            self.logger.log_api_call('camara', f'eventos/{session_id}/deputados', 'error', 0)
+            raise
+
+        return {d['id'] for d in deputies if d.get('id')}
+
+    def _build_rows(self, sessions: List[Dict], present_by_session: Dict[int, Set[int]],
+                    by_deputy: Dict[int, Dict]) -> List[Dict]:
+        """One row per deputy per session they were expected at.
+        The API has no mandate dates per session, so a deputy is expected from their first
+        recorded presence in the period to their last one, or to the end of the period while
+        still in office; substitutes aren't counted absent before taking or after leaving a seat."""
+        first_seen: Dict[int, int] = {}
+        last_seen: Dict[int, int] = {}
+        for index, session in enumerate(sessions):
+            for deputy_id in present_by_session[session['id']]:
+                first_seen.setdefault(deputy_id, index)
+                last_seen[deputy_id] = index
+
+        rows = []
+        for deputy_id, first in first_seen.items():
+            politician = by_deputy.get(deputy_id)
+            if not politician:
+                continue
+
+            last = last_seen[deputy_id]
+            if politician.get('situacao') == 'Exercício':
+                last = len(sessions) - 1
+
+            for session in sessions[first:last + 1]:
+                rows.append({
+                    'politician_id': politician['id'],
+                    'deputy_id': deputy_id,
+                    'session_id': session['id'],
+                    'session_date': session['date'],
+                    'session_type': session['type'],
+                    'present': deputy_id in present_by_session[session['id']],
+                })
+
+        return rows
+
+    def _replace_rows(self, sessions: List[Dict], rows: List[Dict]):
+        """Replace the period's sessions so reruns pick up late attendance corrections"""
+        database.execute_update(
+            "DELETE FROM plenary_attendance WHERE session_id = ANY(%s)",
+            ([s['id'] for s in sessions],)
+        )
+
+        for row in rows:
+            database.execute_update(
+                """
+                INSERT INTO plenary_attendance (
+                    politician_id, deputy_id, session_id, session_date, session_type, present
+                )
+                VALUES (%s, %s, %s, %s, %s, %s)
+                ON CONFLICT (deputy_id, session_id) DO UPDATE SET
+                    present = EXCLUDED.present, fetched_at = CURRENT_TIMESTAMP
+                """,
+                (
+                    row['politician_id'], row['deputy_id'], row['session_id'],
+                    row['session_date'], row['session_type'], row['present'],
+                )
+            )
+
+    def _update_rates(self) -> int:
+        """Roll every politician's recorded sessions up into unified_politicians"""
+        return database.execute_update(
+            """
+            UPDATE unified_politicians p
+            SET plenary_sessions_total = a.total,
+                plenary_sessions_present = a.present,
+                plenary_absence_rate = ROUND(100.0 * (a.total - a.present) / a.total, 2),
+                updated_at = CURRENT_TIMESTAMP
+            FROM (
+                SELECT politician_id,
+                       COUNT(*) AS total,
+                       COUNT(*) FILTER (WHERE present) AS present
+                FROM plenary_attendance
+                GROUP BY politician_id
+            ) a
+            WHERE p.id = a.politician_id
+            """
+        )
@@ -0,0 +1,5 @@
+# BNDES Public Loans Populator Module
+
+from .populator import BNDESPopulator
+
+__all__ = ['BNDESPopulator']
@@ -0,0 +1,165 @@
+"""
+CLI4 BNDES Populator
+Populate public_loans with the BNDES financing operations of companies in financial_counterparts,
+so the API can show the loans on company dossiers and link state-financed vendors of politicians
+to the bank as public_loan connections.
+"""
+
+import hashlib
+import re
+from datetime import datetime, date
+from decimal import Decimal, InvalidOperation
+from typing import Dict, Optional, Set
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+from cli4.modules.dependency_checker import DependencyChecker
+from src.clients.bndes_client import BNDESClient
+
+LENDER = 'BNDES'
+
+
+class BNDESPopulator:
+    """Populate public_loans from the BNDES operations datasets"""
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+        self.bndes_client = BNDESClient()
+
+    def populate(self, since: Optional[str] = None) -> int:
+        """Scan every operations CSV, keeping the contracts of tracked companies signed on or
+        after since (YYYY-MM-DD). Returns the loans stored."""
+
+        print("🏦 BNDES PUBLIC LOANS POPULATION")
+        print("=" * 60)
+        print("BNDES financing operations of companies paid by politicians")
+        print()
+
+        DependencyChecker.print_dependency_warning(
+            required_steps=["financial"],
+            current_step="BNDES POPULATION"
+        )
+
+        since_date = datetime.strptime(since, '%Y-%m-%d').date() if since else None
+
+        companies = {row['cnpj_cpf'] for row in database.execute_query(
+            "SELECT cnpj_cpf FROM financial_counterparts WHERE entity_type = 'COMPANY' AND LENGTH(cnpj_cpf) = 14"
+        )}
+        print(f"🎯 Matching against {len(companies):,} company CNPJs")
+
+        try:
+            resources = self.bndes_client.list_operation_resources()
+            self.logger.log_api_call('bndes', 'package_show/operacoes-financiamento', 'success', 0)
+        except Exception as e:
+            self.logger.log_api_call('bndes', 'package_show/operacoes-financiamento', 'error', 0)
+            print(f"❌ Could not list BNDES operations: {e}")
+            return 0
+
+        print(f"📂 {len(resources)} operations datasets")
+        print()
+
+        stored = 0
+        for resource in resources:
+            print(f"📄 {resource['name']}")
+            self.rate_limiter.wait_if_needed('default')
+            try:
+                scanned, resource_stored = self._load_resource(resource['url'], companies, since_date)
+            except Exception as e:
+                print(f"   ❌ Error: {e}")
+                self.logger.log_processing('bndes', resource['name'], 'error', {'error': str(e)})
+                continue
+
+            stored += resource_stored
+            print(f"   ✅ {scanned:,} operations scanned, {resource_stored:,} loans to tracked companies")
+            self.logger.log_processing('bndes', resource['name'], 'success',
+                                       {'scanned': scanned, 'stored': resource_stored})
+
+        print(f"\n✅ BNDES population completed")
+        print(f"🏦 {stored:,} loans stored")
+
+        return stored
+
+    def _load_resource(self, url: str, companies: Set[str], since_date: Optional[date]):
+        """Store the loans of tracked companies in one operations CSV"""
+        scanned = 0
+        stored = 0
+        for row in self.bndes_client.iter_operations(url):
+            scanned += 1
+            cnpj = re.sub(r'\D', '', row.get('cpf_cnpj', ''))
+            if len(cnpj) != 14 or cnpj not in companies:
+                continue
+
+            contract_date = self._parse_date(row.get('data_da_contratacao'))
+            if since_date and contract_date and contract_date < since_date:
+                continue
+
+            self._store_loan(cnpj, contract_date, row)
+            stored += 1
+
+        return scanned, stored
+
+    def _store_loan(self, cnpj: str, contract_date: Optional[date], row: Dict[str, str]):
+        """Upsert one operation; the key identifies it across reruns since automatic
+        operations have no contract number"""
+        contract_number = row.get('numero_do_contrato') or None
+        contracted = self._money(row.get('valor_contratado_reais') or row.get('valor_da_operacao_em_reais'))
+        product = row.get('produto') or None
+        loan_key = hashlib.sha1(
+            '|'.join(str(v) for v in (cnpj, contract_number, contract_date, contracted, product)).encode()
+        ).hexdigest()
+
+        database.execute_update(
+            """
+            INSERT INTO public_loans (
+                lender, loan_key, cnpj, client_name, uf, municipality, contract_number, contract_date,
+                contracted_value, disbursed_value, product, instrument, support_type,
+                financial_agent, status
+            )
+            VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+            ON CONFLICT (lender, loan_key) DO UPDATE SET
+                disbursed_value = EXCLUDED.disbursed_value,
+                status = EXCLUDED.status,
+                updated_at = CURRENT_TIMESTAMP,
+                fetched_at = CURRENT_TIMESTAMP
+            """,
+            (
+                LENDER, loan_key, cnpj,
+                (row.get('cliente') or '')[:255] or None,
+                (row.get('uf') or '')[:2] or None,
+                (row.get('municipio') or '')[:100] or None,
+                (contract_number or '')[:50] or None,
+                contract_date,
+                contracted,
+                self._money(row.get('valor_desembolsado_reais')),
+                (product or '')[:100] or None,
+                (row.get('instrumento_financeiro') or '')[:255] or None,
+                (row.get('forma_de_apoio') or '')[:50] or None,
+                (row.get('instituicao_financeira_credenciada') or '')[:255] or None,
+                (row.get('situacao_do_contrato') or row.get('situacao_da_operacao') or '')[:50] or None,
+            )
+        )
+
+    @staticmethod
+    def _parse_date(value: Optional[str]) -> Optional[date]:
+        """ISO or dd/mm/yyyy dates"""
+        if not value:
+            return None
+        for fmt in ('%Y-%m-%d', '%d/%m/%Y'):
+            try:
+                return datetime.strptime(value[:10], fmt).date()
+            except ValueError:
+                continue
+        return None
+
+    @staticmethod
+    def _money(value: Optional[str]) -> Optional[Decimal]:
+        """Brazilian (1.234,56) or plain (1234.56) amounts"""
+        if not value:
+            return None
+        if ',' in value:
+            value = value.replace('.', '').replace(',', '.')
+        try:
+            return Decimal(value).quantize(Decimal('0.01'))
+        except InvalidOperation:
+            return None
@@ -0,0 +1,5 @@
+# CNAE Sector Classification Populator Module
+
+from .populator import CNAEPopulator
+
+__all__ = ['CNAEPopulator']
@@ -0,0 +1,176 @@
+"""
+CLI4 CNAE Sector Populator
+Tag financial_counterparts companies with their main CNAE activity from the Receita Federal
+open CNPJ dataset (Estabelecimentos + Cnaes files from dados.gov.br / arquivos.receitafederal.gov.br)
+Enables sector aggregation in the API (/api/stats/by-sector, ?sector= filters)
+"""
+
+import csv
+import io
+import time
+import zipfile
+from pathlib import Path
+from typing import Dict, Iterator, List, Set, Tuple
+from cli4.modules import database
+from cli4.modules.bulk_load import copy_update
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+
+
+# CNAE 2.0 sections by 2-digit division
+CNAE_SECTIONS = [
+    ('A', 1, 3), ('B', 5, 9), ('C', 10, 33), ('D', 35, 35), ('E', 36, 39), ('F', 41, 43),
+    ('G', 45, 47), ('H', 49, 53), ('I', 55, 56), ('J', 58, 63), ('K', 64, 66), ('L', 68, 68),
+    ('M', 69, 75), ('N', 77, 82), ('O', 84, 84), ('P', 85, 85), ('Q', 86, 88), ('R', 90, 93),
+    ('S', 94, 96), ('T', 97, 97), ('U', 99, 99),
+]
+
+# Estabelecimentos layout (no header, ';' separated, latin-1)
+COL_CNPJ_BASICO = 0
+COL_CNPJ_ORDEM = 1
+COL_CNPJ_DV = 2
+COL_CNAE_PRINCIPAL = 11
+
+
+def receita_files(directory: Path, marker: str) -> List[Path]:
+    """Receita files whose name contains marker, ignoring case: the zips are named
+    Estabelecimentos0.zip while the extracted files end in .ESTABELE"""
+    return sorted(p for p in directory.iterdir() if marker.upper() in p.name.upper())
+
+
+def read_receita_rows(path: Path) -> Iterator[List[str]]:
+    """Read a Receita CSV, either extracted or inside its original zip"""
+    if path.suffix.lower() == '.zip':
+        with zipfile.ZipFile(path) as archive:
+            for name in archive.namelist():
+                with archive.open(name) as raw:
+                    yield from csv.reader(io.TextIOWrapper(raw, encoding='latin-1'), delimiter=';')
+    else:
+        with open(path, encoding='latin-1', newline='') as f:
+            yield from csv.reader(f, delimiter=';')
+
+
+def cnae_section(cnae_code: str) -> str:
+    """Return the CNAE section letter for a 7-digit subclass code"""
+    try:
+        division = int(cnae_code[:2])
+    except ValueError:
+        return ''
+    for section, first, last in CNAE_SECTIONS:
+        if first <= division <= last:
+            return section
+    return ''
+
+
+class CNAEPopulator:
+    """Populate CNAE columns of financial_counterparts from Receita Federal files"""
+
+    BATCH_SIZE = 50000
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+
+    def populate(self, receita_dir: str, update_existing: bool = False) -> int:
+        """Match every company CNPJ against the Estabelecimentos files in receita_dir"""
+
+        print("🏭 CNAE SECTOR POPULATION")
+        print("=" * 60)
+        print("Receita Federal main activity (CNAE) for vendor/donor companies")
+        print()
+
+        start_time = time.time()
+        directory = Path(receita_dir)
+        if not directory.is_dir():
+            raise ValueError(f"Receita directory not found: {receita_dir}")
+
+        self._ensure_columns()
+
+        descriptions = self._load_descriptions(directory)
+        print(f"   📚 {len(descriptions):,} CNAE descriptions loaded")
+
+        targets = self._load_target_cnpjs(update_existing)
+        print(f"   🎯 {len(targets):,} companies to classify")
+        if not targets:
+            return 0
+
+        files = receita_files(directory, 'ESTABELE')
+        if not files:
+            raise ValueError(f"No Estabelecimentos files in {receita_dir}")
+
+        updated = 0
+        batch: List[Tuple[str, str, str, str]] = []
+        for path in files:
+            print(f"   📄 Scanning {path.name}...")
+            for cnpj, cnae in self._read_establishments(path):
+                if cnpj not in targets:
+                    continue
+                targets.discard(cnpj)
+                batch.append((cnae, descriptions.get(cnae, ''), cnae_section(cnae), cnpj))
+
+                if len(batch) >= self.BATCH_SIZE:
+                    updated += self._write_batch(batch)
+                    batch = []
+
+            if not targets:
+                break
+
+        updated += self._write_batch(batch)
+
+        elapsed_time = time.time() - start_time
+        print(f"\n✅ CNAE sector population completed")
+        print(f"📊 {updated:,} companies classified, {len(targets):,} not found in Receita files")
+        print(f"⏱️  Total time: {elapsed_time/60:.1f} minutes")
+
+        return updated
+
+    def _ensure_columns(self):
+        """Add the CNAE columns to databases created before they existed"""
+        database.execute_update("""
+            ALTER TABLE financial_counterparts
+                ADD COLUMN IF NOT EXISTS cnae_code VARCHAR(7),
+                ADD COLUMN IF NOT EXISTS cnae_description VARCHAR(255),
+                ADD COLUMN IF NOT EXISTS cnae_section CHAR(1)
+        """)
+        database.execute_update(
+            "CREATE INDEX IF NOT EXISTS idx_counterparts_cnae ON financial_counterparts(cnae_section, cnae_code)"
+        )
+
+    def _load_target_cnpjs(self, update_existing: bool) -> Set[str]:
+        query = """
+            SELECT cnpj_cpf FROM financial_counterparts
+            WHERE entity_type = 'COMPANY' AND LENGTH(cnpj_cpf) = 14
+        """
+        if not update_existing:
+            query += " AND cnae_code IS NULL"
+        return {row['cnpj_cpf'] for row in database.execute_query(query)}
+
+    def _load_descriptions(self, directory: Path) -> Dict[str, str]:
+        descriptions = {}
+        for path in receita_files(directory, 'CNAE'):
+            for row in read_receita_rows(path):
+                if len(row) >= 2:
+                    descriptions[row[0].strip().zfill(7)] = row[1].strip()[:255]
+        return descriptions
+
+    def _read_establishments(self, path: Path) -> Iterator[Tuple[str, str]]:
+        for row in read_receita_rows(path):
+            if len(row) <= COL_CNAE_PRINCIPAL:
+                continue
+            cnpj = row[COL_CNPJ_BASICO] + row[COL_CNPJ_ORDEM] + row[COL_CNPJ_DV]
+            cnae = row[COL_CNAE_PRINCIPAL].strip().zfill(7)
+            if len(cnpj) == 14 and cnae.isdigit():
+                yield cnpj, cnae
+
+    def _write_batch(self, batch: List[Tuple[str, str, str, str]]) -> int:
+        if not batch:
+            return 0
+        with database.get_connection() as conn:
+            cursor = conn.cursor()
+            copy_update(
+                cursor, 'financial_counterparts',
+                ['cnae_code', 'cnae_description', 'cnae_section', 'cnpj_cpf'], batch,
+                key=['cnpj_cpf']
+            )
+            conn.commit()
+        return len(batch)
@@ -5,5 +5,6 @@ Handles TSE electoral outcome data population following CLI4 architecture
 
 from .populator import ElectoralRecordsPopulator
 from .validator import ElectoralRecordsValidator
+from .results import ElectoralResultsPopulator
 
-__all__ = ['ElectoralRecordsPopulator', 'ElectoralRecordsValidator']
\ No newline at end of file
+__all__ = ['ElectoralRecordsPopulator', 'ElectoralRecordsValidator', 'ElectoralResultsPopulator']
\ No newline at end of file
@@ -0,0 +1,146 @@
+"""
+CLI4 Electoral Results Populator
+Fill unified_electoral_records.votes_received from the TSE election results (the candidate file
+has no vote counts) and summarize each politician's latest election on unified_politicians:
+votes, coalition, outcome and campaign spending. The API filters politician lists on them.
+"""
+
+from typing import Dict, List, Optional
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+from cli4.modules.dependency_checker import DependencyChecker
+from src.clients.tse_client import TSEClient
+
+
+class ElectoralResultsPopulator:
+    """Load vote totals into unified_electoral_records and the latest election into unified_politicians"""
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+        self.tse_client = TSEClient()
+
+    def populate(self, election_years: Optional[List[int]] = None) -> int:
+        """Load vote totals for the tracked candidacies of election_years, then refresh the
+        latest-election summary of every politician. Returns the candidacies updated."""
+
+        print("🗳️ ELECTORAL RESULTS POPULATION")
+        print("=" * 60)
+        print("TSE nominal vote totals per candidacy and each politician's latest election")
+        print()
+
+        DependencyChecker.print_dependency_warning(
+            required_steps=["politicians", "electoral", "financial"],
+            current_step="ELECTORAL RESULTS POPULATION"
+        )
+
+        if not election_years:
+            election_years = [row['election_year'] for row in database.execute_query(
+                "SELECT DISTINCT election_year FROM unified_electoral_records ORDER BY election_year"
+            )]
+
+        print(f"🗳️ Election years: {', '.join(map(str, election_years)) or 'none'}")
+        print()
+
+        updated = 0
+        for year in election_years:
+            print(f"📊 {year}")
+            try:
+                year_updated = self._load_year(year)
+            except Exception as e:
+                print(f"   ❌ Error loading {year} results: {e}")
+                self.logger.log_processing('electoral_results', str(year), 'error', {'error': str(e)})
+                continue
+
+            updated += year_updated
+            print(f"   ✅ Updated {year_updated} candidacies")
+            self.logger.log_processing('electoral_results', str(year), 'success', {'records_updated': year_updated})
+            self.rate_limiter.wait_if_needed('default')
+
+        summarized = self._summarize_latest_elections()
+
+        print(f"\n✅ ELECTORAL RESULTS POPULATION COMPLETED")
+        print(f"   Candidacies updated: {updated}")
+        print(f"   Politicians summarized: {summarized}")
+
+        return updated
+
+    def _load_year(self, year: int) -> int:
+        """Update the tracked candidacies of one election with their vote totals and outcome"""
+        records = database.execute_query("""
+            SELECT id, source_record_id, COALESCE(election_round, 1) as election_round
+            FROM unified_electoral_records
+            WHERE election_year = %s AND source_record_id IS NOT NULL
+        """, (year,))
+        if not records:
+            print("   ⏭️ No tracked candidacies")
+            return 0
+
+        candidate_ids = {str(r['source_record_id']).strip() for r in records}
+        print(f"   🎯 {len(candidate_ids)} tracked candidacies")
+
+        totals = self.tse_client.get_vote_totals(year, candidate_ids)
+        if not totals:
+            print("   ⚠️ No results found")
+            return 0
+
+        updated = 0
+        for record in records:
+            result = totals.get((str(record['source_record_id']).strip(), record['election_round']))
+            if not result:
+                continue
+
+            outcome = result['outcome']
+            updated += database.execute_update("""
+                UPDATE unified_electoral_records
+                SET votes_received = %s,
+                    electoral_outcome = COALESCE(%s, electoral_outcome),
+                    was_elected = CASE WHEN %s IS NULL THEN was_elected ELSE %s END,
+                    election_status_category = COALESCE(%s, election_status_category),
+                    updated_at = CURRENT_TIMESTAMP,
+                    fetched_at = CURRENT_TIMESTAMP
+                WHERE id = %s
+            """, (
+                result['votes'],
+                outcome,
+                outcome, self.tse_client._determine_election_success(outcome),
+                self.tse_client._categorize_election_status(outcome) if outcome else None,
+                record['id'],
+            ))
+
+        return updated
+
+    def _summarize_latest_elections(self) -> int:
+        """Copy each politician's latest candidacy (last round) onto unified_politicians, with the
+        campaign expenses paid in that election"""
+        print("\n📋 Summarizing latest elections...")
+        return database.execute_update("""
+            WITH latest AS (
+                SELECT DISTINCT ON (politician_id)
+                    politician_id,
+                    election_year,
+                    -- votes_received defaults to 0 until results are loaded
+                    NULLIF(votes_received, 0) as votes,
+                    was_elected,
+                    COALESCE(NULLIF(coalition_name, ''), NULLIF(federation_composition, '')) as coalition
+                FROM unified_electoral_records
+                ORDER BY politician_id, election_year DESC, election_round DESC
+            ),
+            spending AS (
+                SELECT politician_id, election_year, SUM(amount) as spending
+                FROM unified_financial_records
+                WHERE transaction_type = 'CAMPAIGN_EXPENSE_PAID'
+                GROUP BY politician_id, election_year
+            )
+            UPDATE unified_politicians p
+            SET last_election_year = l.election_year,
+                last_election_votes = l.votes,
+                last_election_elected = l.was_elected,
+                last_election_coalition = l.coalition,
+                last_election_spending = s.spending,
+                updated_at = CURRENT_TIMESTAMP
+            FROM latest l
+            LEFT JOIN spending s ON s.politician_id = l.politician_id AND s.election_year = l.election_year
+            WHERE p.id = l.politician_id
+        """)
@@ -0,0 +1,6 @@
+# Entity Resolution Populator Module
+
+from .populator import EntityResolutionPopulator
+from .matcher import AUTO_CONFIRM, Matcher, SourceRecord
+
+__all__ = ['EntityResolutionPopulator', 'AUTO_CONFIRM', 'Matcher', 'SourceRecord']
@@ -0,0 +1,224 @@
+"""
+Entity matching rules
+Score how likely a record from one dataset names a politician in unified_politicians or a company
+in financial_counterparts. Documents decide when they are there; otherwise names, normalized the
+same way everywhere, are confirmed or vetoed by birth dates (exact, or the Receita QSA age bracket)
+and narrowed by state.
+"""
+
+import re
+from dataclasses import dataclass, field
+from datetime import date
+from typing import Dict, List, Optional
+
+from src.validation.brazilian_validators import BrazilianValidator
+from src.validation.normalization import normalize_name
+
+# Links at or above this confidence are stored confirmed; the rest wait for review
+AUTO_CONFIRM = 0.95
+
+# Company name endings that vary between registries (LTDA, LTDA ME, S/A...)
+LEGAL_SUFFIXES = {'LTDA', 'ME', 'EPP', 'EIRELI', 'SA', 'S', 'A', 'CIA', 'MEI', 'SS', 'SLU'}
+
+_MASKED_CPF = re.compile(r'^\*{3}(\d{6})\*{2}$')
+
+
+@dataclass
+class SourceRecord:
+    """A person or company as named by one dataset"""
+    source: str                         # CAMARA, TSE, PORTAL_TRANSPARENCIA, TCU, SENADO, RECEITA
+    key: str                            # identifies the record within the source
+    kind: str                           # person or company
+    name: str
+    aliases: List[str] = field(default_factory=list)
+    document: str = ''                  # CPF, CNPJ or a Receita-masked CPF (***123456**)
+    birth_date: Optional[date] = None
+    age_range: Optional[str] = None     # Receita QSA bracket: '51-60', '80+'
+    state: Optional[str] = None
+
+
+@dataclass
+class Candidate:
+    """A possible link from a source record to a known entity"""
+    entity_type: str                    # politician or company
+    entity_id: str                      # politician id or CNPJ
+    method: str                         # document, masked_document, cnpj_root, name_birth_date, name
+    confidence: float
+    evidence: List[str]
+
+
+def company_name(name: str) -> str:
+    """normalize_name without legal-form suffixes, so "ACME LTDA - ME" matches "Acme Ltda" """
+    words = normalize_name(name).split()
+    while len(words) > 1 and words[-1] in LEGAL_SUFFIXES:
+        words.pop()
+    return ' '.join(words)
+
+
+def age_in_range(birth_date: date, age_range: str, today: date) -> bool:
+    """Whether someone born on birth_date is within a QSA age bracket, with a year of slack
+    because the Receita computes it when the file is generated"""
+    age = today.year - birth_date.year - ((today.month, today.day) < (birth_date.month, birth_date.day))
+    low, _, high = age_range.partition('-')
+    low = int(low.rstrip('+'))
+    high = int(high) if high else 200
+    return low - 1 <= age <= high + 1
+
+
+class Matcher:
+    """Indexes the known politicians and companies and scores source records against them"""
+
+    def __init__(self, politicians: List[Dict], companies: List[Dict], today: Optional[date] = None):
+        self.today = today or date.today()
+
+        self.politicians = {p['id']: p for p in politicians}
+        self.by_cpf: Dict[str, int] = {}
+        self.by_masked_cpf: Dict[str, List[int]] = {}
+        self.by_civil_name: Dict[str, List[int]] = {}
+        self.by_ballot_name: Dict[str, List[int]] = {}
+        for p in politicians:
+            cpf = p.get('cpf') or ''
+            if len(cpf) == 11:
+                self.by_cpf[cpf] = p['id']
+                self.by_masked_cpf.setdefault(cpf[3:9], []).append(p['id'])
+            if p.get('nome_civil'):
+                self.by_civil_name.setdefault(normalize_name(p['nome_civil'], drop_honorifics=True), []).append(p['id'])
+            for ballot in {normalize_name(n, drop_honorifics=True)
+                           for n in (p.get('nome_eleitoral'), p.get('nome_urna_candidato')) if n}:
+                self.by_ballot_name.setdefault(ballot, []).append(p['id'])
+
+        self.companies = {c['cnpj_cpf']: c for c in companies}
+        self.by_cnpj_root: Dict[str, List[str]] = {}
+        self.by_company_name: Dict[str, List[str]] = {}
+        for c in companies:
+            self.by_cnpj_root.setdefault(c['cnpj_cpf'][:8], []).append(c['cnpj_cpf'])
+            for name in {company_name(n) for n in (c.get('name'), c.get('trade_name')) if n}:
+                if name:
+                    self.by_company_name.setdefault(name, []).append(c['cnpj_cpf'])
+
+    def match(self, record: SourceRecord) -> List[Candidate]:
+        """Candidates for record, most confident first"""
+        if record.kind == 'company':
+            candidates = self._match_company(record)
+        else:
+            candidates = self._match_person(record)
+        return sorted(candidates, key=lambda c: -c.confidence)
+
+    # People
+
+    def _match_person(self, record: SourceRecord) -> List[Candidate]:
+        document = BrazilianValidator.clean_document(record.document) if record.document else ''
+
+        if len(document) == 11 and BrazilianValidator.validate_cpf(document):
+            # A valid CPF is the person; it either is a politician's or it isn't
+            politician_id = self.by_cpf.get(document)
+            if politician_id is None:
+                return []
+            return [self._score_document(record, self.politicians[politician_id])]
+
+        masked = _MASKED_CPF.match(record.document or '')
+        if masked:
+            # The Receita masks CPFs down to six digits, which only mean something with the name;
+            # a namesake whose digits differ is someone else
+            candidates = []
+            for politician_id in self.by_masked_cpf.get(masked.group(1), []):
+                politician = self.politicians[politician_id]
+                if self._names_of(politician) & self._record_names(record):
+                    candidate = self._score_name(record, politician, 0.9, 'masked_document')
+                    if candidate:
+                        candidate.evidence.insert(0, f"CPF digits {masked.group(1)}")
+                        candidates.append(candidate)
+            return candidates
+
+        return self._match_person_name(record)
+
+    def _score_document(self, record: SourceRecord, politician: Dict) -> Candidate:
+        evidence = ['CPF']
+        confidence = 1.0
+        if record.name and not self._names_of(politician) & self._record_names(record):
+            # Same CPF under another name: typos or a married name, worth a human look
+            confidence = 0.8
+            evidence.append(f"name differs: {record.name}")
+        if record.birth_date and politician.get('birth_date') and record.birth_date != politician['birth_date']:
+            confidence = min(confidence, 0.6)
+            evidence.append(f"birth date differs: {record.birth_date}")
+        return Candidate('politician', str(politician['id']), 'document', confidence, evidence)
+
+    def _match_person_name(self, record: SourceRecord) -> List[Candidate]:
+        scored: Dict[int, Candidate] = {}
+        for name in self._record_names(record):
+            for index, base in ((self.by_civil_name, 0.6), (self.by_ballot_name, 0.5)):
+                for politician_id in index.get(name, []):
+                    candidate = self._score_name(record, self.politicians[politician_id], base, 'name')
+                    if candidate and (politician_id not in scored or candidate.confidence > scored[politician_id].confidence):
+                        scored[politician_id] = candidate
+
+        candidates = list(scored.values())
+        if len(candidates) == 1:
+            candidates[0].confidence = round(min(candidates[0].confidence + 0.1, 1.0), 2)
+            candidates[0].evidence.append('only politician with this name')
+        return candidates
+
+    def _score_name(self, record: SourceRecord, politician: Dict, base: float, method: str) -> Optional[Candidate]:
+        """A name-based candidate, or None when the birth date or age bracket rules it out"""
+        confidence = base
+        evidence = [f"name: {record.name}"]
+        born = politician.get('birth_date')
+
+        if record.birth_date and born:
+            if record.birth_date != born:
+                return None
+            confidence += 0.35
+            evidence.append(f"birth date: {born}")
+            if method == 'name':
+                method = 'name_birth_date'
+        elif record.age_range and born:
+            if not age_in_range(born, record.age_range, self.today):
+                return None
+            confidence += 0.05
+            evidence.append(f"age range: {record.age_range}")
+
+        if record.state and record.state in (politician.get('birth_state'), politician.get('current_state')):
+            confidence += 0.1
+            evidence.append(f"state: {record.state}")
+
+        return Candidate('politician', str(politician['id']), method, round(min(confidence, 1.0), 2), evidence)
+
+    @staticmethod
+    def _names_of(politician: Dict) -> set:
+        return {normalize_name(n, drop_honorifics=True)
+                for n in (politician.get('nome_civil'), politician.get('nome_eleitoral'),
+                          politician.get('nome_urna_candidato')) if n}
+
+    @staticmethod
+    def _record_names(record: SourceRecord) -> set:
+        names = {normalize_name(n, drop_honorifics=True) for n in [record.name] + record.aliases if n}
+        names.discard('')
+        return names
+
+    # Companies
+
+    def _match_company(self, record: SourceRecord) -> List[Candidate]:
+        document = BrazilianValidator.clean_document(record.document) if record.document else ''
+
+        if len(document) == 14:
+            if document in self.companies:
+                return [Candidate('company', document, 'document', 1.0, ['CNPJ'])]
+            # Another branch of a known company: same CNPJ root, usually the same business
+            branches = sorted(self.by_cnpj_root.get(document[:8], []))
+            if branches:
+                return [Candidate('company', branches[0], 'cnpj_root', 0.9, [f"CNPJ root {document[:8]}"])]
+            if BrazilianValidator.validate_cnpj(document):
+                return []
+
+        name = company_name(record.name or '')
+        cnpjs = self.by_company_name.get(name, []) if name else []
+        candidates = []
+        for cnpj in cnpjs:
+            confidence = 0.6 if len(cnpjs) == 1 else 0.5
+            evidence = [f"name: {record.name}"]
+            if record.state and record.state == self.companies[cnpj].get('state'):
+                confidence += 0.1
+                evidence.append(f"state: {record.state}")
+            candidates.append(Candidate('company', cnpj, 'name', confidence, evidence))
+        return candidates
@@ -0,0 +1,242 @@
+"""
+CLI4 Entity Resolution Populator
+Link the people and companies named by other datasets - CEAP and campaign counterparts (Câmara,
+TSE), sanctions (Portal da Transparência), TCU disqualifications, senators and Receita QSA partners -
+to unified_politicians and financial_counterparts. Links go to entity_links, created by the backend
+on startup: confident ones are confirmed right away, the rest wait in the review queue at
+/api/admin/entity-links.
+"""
+
+import time
+from typing import Callable, Dict, Iterator, List, Optional
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+from cli4.modules.dependency_checker import DependencyChecker
+from .matcher import AUTO_CONFIRM, Matcher, SourceRecord
+
+
+class EntityResolutionPopulator:
+    """Populate entity_links from every ingested dataset that names people or companies"""
+
+    BATCH_SIZE = 1000
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+
+    @property
+    def sources(self) -> Dict[str, Callable[[], Iterator[SourceRecord]]]:
+        """Source name (the --sources value) to its record reader"""
+        return {
+            'camara': lambda: self._counterparts('DEPUTADOS', 'CAMARA'),
+            'tse': lambda: self._counterparts('TSE', 'TSE'),
+            'sanctions': self._sanctions,
+            'tcu': self._tcu,
+            'senado': self._senado,
+            'receita': self._partners,
+        }
+
+    def populate(self, sources: Optional[List[str]] = None, min_confidence: float = 0.5) -> int:
+        """Match the records of each source and store candidates scoring at least min_confidence.
+        Reviewed links are never touched; unreviewed links that no longer match are removed."""
+
+        print("🔗 ENTITY RESOLUTION")
+        print("=" * 60)
+        print(f"Cross-source people and companies (min confidence {min_confidence}, "
+              f"auto-confirm at {AUTO_CONFIRM})")
+        print()
+
+        DependencyChecker.print_dependency_warning(
+            required_steps=["politicians", "financial"],
+            current_step="ENTITY RESOLUTION"
+        )
+
+        start_time = time.time()
+        politicians = database.execute_query("""
+            SELECT id, cpf, nome_civil, nome_eleitoral, nome_urna_candidato,
+                   birth_date, birth_state, current_state
+            FROM unified_politicians
+        """)
+        companies = database.execute_query("""
+            SELECT cnpj_cpf, name, trade_name, state
+            FROM financial_counterparts
+            WHERE entity_type = 'COMPANY' AND LENGTH(cnpj_cpf) = 14
+        """)
+        matcher = Matcher(politicians, companies)
+        print(f"👥 {len(politicians):,} politicians and 🏢 {len(companies):,} companies indexed")
+
+        total = 0
+        for name in sources or list(self.sources):
+            if name not in self.sources:
+                raise ValueError(f"Unknown source: {name} (choose from {', '.join(self.sources)})")
+            try:
+                total += self._resolve(name, matcher, min_confidence)
+            except Exception as e:
+                print(f"  ❌ Error resolving {name}: {e}")
+                self.logger.log_processing('entities', name, 'error', {'error': str(e)})
+
+        elapsed_time = time.time() - start_time
+        print(f"\n✅ Entity resolution completed: {total:,} links")
+        print(f"⏱️  Total time: {elapsed_time/60:.1f} minutes")
+        print(f"👉 Review them at /api/admin/entity-links?status=suggested")
+
+        return total
+
+    def _resolve(self, name: str, matcher: Matcher, min_confidence: float) -> int:
+        print(f"\n📂 {name}")
+        links = []
+        source = None
+        records = 0
+        for record in self.sources[name]():
+            source = record.source
+            records += 1
+            for candidate in matcher.match(record):
+                if candidate.confidence < min_confidence:
+                    continue
+                links.append((
+                    record.source, record.key, record.name[:255], record.document[:14] or None,
+                    candidate.entity_type, candidate.entity_id, candidate.method,
+                    candidate.confidence, '; '.join(candidate.evidence),
+                    'confirmed' if candidate.confidence >= AUTO_CONFIRM else 'suggested',
+                ))
+
+        if source is None:
+            print("   ⏭️  No records (not ingested yet)")
+            return 0
+
+        removed = self._store(source, links)
+        confirmed = sum(1 for link in links if link[9] == 'confirmed')
+        print(f"   🔍 {records:,} records: {confirmed:,} confirmed and "
+              f"{len(links) - confirmed:,} suggested links, {removed:,} stale links removed")
+        self.logger.log_processing('entities', name, 'success', {
+            'records': records, 'links': len(links), 'confirmed': confirmed, 'removed': removed
+        })
+        return len(links)
+
+    def _store(self, source: str, links: List[tuple]) -> int:
+        """Upsert a source's links, leaving reviewed ones alone, and drop its unreviewed links
+        that were not found again. Returns how many were dropped."""
+        with database.get_connection() as conn:
+            cursor = conn.cursor()
+            for i in range(0, len(links), self.BATCH_SIZE):
+                cursor.executemany(
+                    """
+                    INSERT INTO entity_links (
+                        source, source_key, source_name, source_document, entity_type, entity_id,
+                        method, confidence, evidence, status
+                    )
+                    VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+                    ON CONFLICT (source, source_key, entity_type, entity_id) DO UPDATE SET
+                        source_name = EXCLUDED.source_name,
+                        source_document = EXCLUDED.source_document,
+                        method = EXCLUDED.method,
+                        confidence = EXCLUDED.confidence,
+                        evidence = EXCLUDED.evidence,
+                        status = EXCLUDED.status,
+                        updated_at = CURRENT_TIMESTAMP
+                    WHERE entity_links.reviewed_by IS NULL
+                    """,
+                    links[i:i + self.BATCH_SIZE]
+                )
+            cursor.execute(
+                """
+                DELETE FROM entity_links
+                WHERE source = %s AND reviewed_by IS NULL
+                  AND (source_key, entity_type, entity_id) NOT IN (
+                      SELECT * FROM unnest(%s::text[], %s::text[], %s::text[])
+                  )
+                """,
+                (source, [l[1] for l in links], [l[4] for l in links], [l[5] for l in links])
+            )
+            removed = cursor.rowcount
+            conn.commit()
+        return removed
+
+    # Source readers
+
+    @staticmethod
+    def _ingested(table: str) -> bool:
+        return bool(database.execute_query("SELECT to_regclass(%s) IS NOT NULL AS ok", (table,))[0]['ok'])
+
+    def _counterparts(self, source_system: str, source: str) -> Iterator[SourceRecord]:
+        """CEAP suppliers (DEPUTADOS) or campaign donors and suppliers (TSE)"""
+        rows = database.execute_query("""
+            SELECT counterpart_cnpj_cpf, MAX(counterpart_name) AS name, MAX(state) AS state
+            FROM unified_financial_records
+            WHERE source_system = %s AND counterpart_cnpj_cpf IS NOT NULL
+            GROUP BY counterpart_cnpj_cpf
+        """, (source_system,))
+        for row in rows:
+            document = row['counterpart_cnpj_cpf']
+            yield SourceRecord(
+                source=source, key=document, kind='person' if len(document) == 11 else 'company',
+                name=row['name'] or '', document=document, state=row['state'],
+            )
+
+    def _sanctions(self) -> Iterator[SourceRecord]:
+        """CEIS/CNEP/CEPIM sanctioned people and companies"""
+        if not self._ingested('vendor_sanctions'):
+            return
+        rows = database.execute_query("""
+            SELECT cnpj_cpf, MAX(entity_name) AS name, MAX(sanctioning_state) AS state
+            FROM vendor_sanctions
+            GROUP BY cnpj_cpf
+        """)
+        for row in rows:
+            document = row['cnpj_cpf']
+            yield SourceRecord(
+                source='PORTAL_TRANSPARENCIA', key=document,
+                kind='person' if len(document) == 11 else 'company',
+                name=row['name'] or '', document=document, state=row['state'],
+            )
+
+    def _tcu(self) -> Iterator[SourceRecord]:
+        """People the TCU disqualified from public office"""
+        if not self._ingested('tcu_disqualifications'):
+            return
+        rows = database.execute_query("""
+            SELECT cpf, MAX(nome) AS name, MAX(uf) AS state
+            FROM tcu_disqualifications
+            GROUP BY cpf
+        """)
+        for row in rows:
+            yield SourceRecord(
+                source='TCU', key=row['cpf'], kind='person',
+                name=row['name'] or '', document=row['cpf'], state=row['state'],
+            )
+
+    def _senado(self) -> Iterator[SourceRecord]:
+        """Senators, who have no CPF in the Senado API: full and parliamentary names with state"""
+        if not self._ingested('senado_politicians'):
+            return
+        rows = database.execute_query("""
+            SELECT codigo, nome, nome_completo, estado
+            FROM senado_politicians
+            WHERE codigo IS NOT NULL
+        """)
+        for row in rows:
+            yield SourceRecord(
+                source='SENADO', key=row['codigo'], kind='person',
+                name=row['nome_completo'] or row['nome'] or '',
+                aliases=[row['nome']] if row['nome'] else [], state=row['estado'],
+            )
+
+    def _partners(self) -> Iterator[SourceRecord]:
+        """Receita QSA partners: masked CPF and age bracket for people, CNPJ for companies.
+        The key matches a company_partners row even after populate-qsa replaces it."""
+        if not self._ingested('company_partners'):
+            return
+        rows = database.execute_query("""
+            SELECT DISTINCT cnpj_root, partner_type, partner_name, partner_document, age_range
+            FROM company_partners
+            WHERE partner_type IN ('PF', 'PJ')
+        """)
+        for row in rows:
+            yield SourceRecord(
+                source='RECEITA',
+                key=f"{row['cnpj_root']}:{row['partner_document']}:{row['partner_name']}",
+                kind='person' if row['partner_type'] == 'PF' else 'company',
+                name=row['partner_name'], document=row['partner_document'] or '',
+                age_range=row['age_range'],
+            )
@@ -0,0 +1,5 @@
+# Family Relations Populator Module
+
+from .populator import FamilyPopulator
+
+__all__ = ['FamilyPopulator']
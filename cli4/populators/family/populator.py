@@ -0,0 +1,173 @@
+"""
+CLI4 Family Populator
+Suggest family relations between politicians from shared uncommon surnames, generational suffixes
+(FILHO, JUNIOR, NETO) and a shared hometown. Suggestions go to politician_relations, created by
+the backend on startup, where researchers confirm or reject them through /api/relations; only
+confirmed relations become family edges in the network.
+"""
+
+from typing import Dict, List, Optional, Tuple
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+from cli4.modules.dependency_checker import DependencyChecker
+from src.validation.normalization import normalize_name
+from cli4.populators.staff.surnames import COMMON_SURNAMES, surnames, shared_surnames
+
+# Generational suffixes and what the unsuffixed namesake is to the suffixed politician
+GENERATIONS = {'FILHO': 'parent', 'FILHA': 'parent', 'JUNIOR': 'parent', 'JR': 'parent',
+               'NETO': 'grandparent', 'NETA': 'grandparent', 'SOBRINHO': 'uncle_aunt',
+               'SOBRINHA': 'uncle_aunt'}
+
+
+class FamilyPopulator:
+    """Populate politician_relations with heuristic suggestions"""
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+
+    def populate(self, min_score: float = 0.5) -> int:
+        """Suggest relations scoring at least min_score. Reviewed relations are never touched;
+        suggestions that no longer match are removed."""
+
+        print("👪 FAMILY RELATIONS MATCHING")
+        print("=" * 60)
+        print(f"Shared surnames and hometowns between politicians (min score {min_score})")
+        print()
+
+        DependencyChecker.print_dependency_warning(
+            required_steps=["politicians"],
+            current_step="FAMILY MATCHING"
+        )
+
+        politicians = database.execute_query(
+            """
+            SELECT id, nome_civil, birth_state, birth_municipality
+            FROM unified_politicians
+            WHERE nome_civil IS NOT NULL
+            ORDER BY id
+            """
+        )
+        print(f"👥 Comparing {len(politicians)} politicians")
+
+        # Candidates share an uncommon family name, or the same name before a generational suffix
+        groups: Dict[str, List[Dict]] = {}
+        for politician in politicians:
+            for surname in set(surnames(politician['nome_civil'])) - COMMON_SURNAMES:
+                groups.setdefault(surname, []).append(politician)
+            groups.setdefault('=' + self._base_name(politician['nome_civil']), []).append(politician)
+
+        seen = set()
+        suggestions = []
+        for group in groups.values():
+            for i, a in enumerate(group):
+                for b in group[i + 1:]:
+                    if (a['id'], b['id']) in seen:
+                        continue
+                    seen.add((a['id'], b['id']))
+
+                    suggestion = self._match(a, b)
+                    if suggestion and suggestion[3] >= min_score:
+                        suggestions.append(suggestion)
+
+        stored = 0
+        for suggestion in suggestions:
+            try:
+                stored += self._store(suggestion)
+            except Exception as e:
+                print(f"  ❌ Error storing {suggestion[0]}-{suggestion[1]}: {e}")
+                self.logger.log_processing('family', f"{suggestion[0]}-{suggestion[1]}", 'error', {'error': str(e)})
+
+        removed = database.execute_update(
+            """
+            DELETE FROM politician_relations
+            WHERE status = 'suggested' AND source = 'heuristic'
+              AND (politician_id, relative_id) NOT IN (
+                  SELECT * FROM unnest(%s::int[], %s::int[])
+              )
+            """,
+            ([s[0] for s in suggestions], [s[1] for s in suggestions])
+        )
+
+        print(f"\n✅ Family matching completed")
+        print(f"🔗 {len(suggestions)} candidate pairs, {stored} open suggestions updated")
+        print(f"🧹 {removed or 0} stale suggestions removed")
+        print(f"👉 Review them at /api/relations?status=suggested")
+
+        return stored
+
+    @staticmethod
+    def _match(a: Dict, b: Dict) -> Optional[Tuple[int, int, str, float, str]]:
+        """(politician_id, relative_id, relationship, score, evidence) for a pair with a.id < b.id,
+        or None when they share no uncommon family name.
+
+        0.4 per shared surname, 0.2 when both names end in the same one, 0.3 for the same birth
+        municipality (0.1 for the same birth state only), capped at 1. Namesakes told apart only by
+        a generational suffix (João Silva Costa / João Silva Costa Filho) score 1."""
+        shared = shared_surnames(a['nome_civil'], b['nome_civil'])
+        generation = FamilyPopulator._generation(a['nome_civil'], b['nome_civil'])
+        if not shared and not generation:
+            return None
+
+        evidence = [f"surnames: {' '.join(shared)}"] if shared else []
+        relationship = 'relative'
+
+        score = 0.4 * len(shared)
+        names_a, names_b = surnames(a['nome_civil']), surnames(b['nome_civil'])
+        if names_a and names_b and names_a[-1] == names_b[-1] and names_a[-1] in shared:
+            score += 0.2
+
+        if a['birth_state'] and a['birth_state'] == b['birth_state']:
+            if a['birth_municipality'] and normalize_name(a['birth_municipality']) == normalize_name(b['birth_municipality'] or ''):
+                score += 0.3
+                evidence.append(f"hometown: {a['birth_municipality']}/{a['birth_state']}")
+            else:
+                score += 0.1
+                evidence.append(f"birth state: {a['birth_state']}")
+
+        if generation:
+            relationship, suffix = generation
+            score = 1.0
+            evidence.append(f"namesake with {suffix}")
+
+        return a['id'], b['id'], relationship, round(min(score, 1.0), 2), '; '.join(evidence)
+
+    @staticmethod
+    def _base_name(name: str) -> str:
+        """Normalized name without a trailing generational suffix"""
+        words = normalize_name(name).split()
+        if len(words) > 2 and words[-1] in GENERATIONS:
+            words = words[:-1]
+        return ' '.join(words)
+
+    @staticmethod
+    def _generation(name_a: str, name_b: str) -> Optional[Tuple[str, str]]:
+        """What b is to a when one name is the other plus a generational suffix"""
+        words_a, words_b = normalize_name(name_a).split(), normalize_name(name_b).split()
+        if len(words_b) == len(words_a) + 1 and words_b[:-1] == words_a and words_b[-1] in GENERATIONS:
+            # b is the junior: a is b's parent (or grandparent), so b is a's child
+            inverse = {'parent': 'child', 'grandparent': 'grandchild', 'uncle_aunt': 'nephew_niece'}
+            return inverse[GENERATIONS[words_b[-1]]], words_b[-1]
+        if len(words_a) == len(words_b) + 1 and words_a[:-1] == words_b and words_a[-1] in GENERATIONS:
+            return GENERATIONS[words_a[-1]], words_a[-1]
+        return None
+
+    @staticmethod
+    def _store(suggestion: Tuple[int, int, str, float, str]) -> int:
+        """Upsert a suggestion unless the pair was already reviewed; returns 1 when written"""
+        result = database.execute_update(
+            """
+            INSERT INTO politician_relations (
+                politician_id, relative_id, relationship, status, source, score, evidence
+            )
+            VALUES (%s, %s, %s, 'suggested', 'heuristic', %s, %s)
+            ON CONFLICT (politician_id, relative_id) DO UPDATE SET
+                relationship = EXCLUDED.relationship,
+                score = EXCLUDED.score,
+                evidence = EXCLUDED.evidence
+            WHERE politician_relations.status = 'suggested'
+            """,
+            suggestion
+        )
+        return 1 if result else 0
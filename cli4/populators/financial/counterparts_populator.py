@@ -8,6 +8,7 @@ from cli4.modules import database
 from cli4.modules.logger import CLI4Logger
 from cli4.modules.rate_limiter import CLI4RateLimiter
 from src.clients.tse_client import TSEClient
+from src.validation.normalization import normalize_text
 
 
 class CLI4CounterpartsPopulator:
@@ -259,7 +260,7 @@ class CLI4CounterpartsPopulator:
                 entity_type = 'COMPANY' if len(cnpj_cpf) == 14 else 'INDIVIDUAL'
 
                 # Normalize name
-                normalized_name = name.upper().strip()
+                normalized_name = normalize_text(name)
 
                 values.append((cnpj_cpf, name, normalized_name, entity_type, source))
 
@@ -276,7 +277,8 @@ class CLI4CounterpartsPopulator:
                         name = EXCLUDED.name,
                         normalized_name = EXCLUDED.normalized_name,
                         source_system = EXCLUDED.source_system,
-                        updated_at = CURRENT_TIMESTAMP
+                        updated_at = CURRENT_TIMESTAMP,
+                        fetched_at = CURRENT_TIMESTAMP
                 """
 
                 try:
@@ -299,7 +301,8 @@ class CLI4CounterpartsPopulator:
                                     name = EXCLUDED.name,
                                     normalized_name = EXCLUDED.normalized_name,
                                     source_system = EXCLUDED.source_system,
-                                    updated_at = CURRENT_TIMESTAMP
+                                    updated_at = CURRENT_TIMESTAMP,
+                                    fetched_at = CURRENT_TIMESTAMP
                             """
                             database.execute_update(single_query, (cnpj_cpf, name, normalized_name, entity_type, source))
                             total_inserted += 1
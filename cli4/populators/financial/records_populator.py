@@ -8,6 +8,7 @@ import time
 from typing import Dict, List, Optional
 from datetime import datetime, date
 from cli4.modules import database
+from cli4.modules.bulk_load import StagingTable, copy_upsert
 from cli4.modules.logger import CLI4Logger
 from cli4.modules.rate_limiter import CLI4RateLimiter
 from cli4.modules.dependency_checker import DependencyChecker
@@ -17,11 +18,29 @@ from src.clients.tse_client import TSEClient
 class CLI4RecordsPopulator:
     """Populate unified_financial_records table with all transactions"""
 
+    # ALL SCHEMA FIELDS INCLUDED (WITH NEW TSE FIELDS)
+    FIELDS = [
+        'politician_id', 'source_system', 'source_record_id', 'source_url',
+        'transaction_type', 'transaction_category', 'amount', 'amount_net',
+        'amount_rejected', 'original_amount', 'transaction_date', 'year',
+        'month', 'counterpart_name', 'counterpart_cnpj_cpf', 'counterpart_type',
+        'counterpart_cnae', 'counterpart_business_type', 'state', 'municipality',
+        'document_number', 'document_code', 'document_type', 'document_type_code',
+        'document_url', 'lote_code', 'installment', 'reimbursement_number',
+        'election_year', 'election_round', 'election_date', 'cnpj_validated',
+        'sanctions_checked', 'external_validation_date'
+    ]
+    KEY = ['source_system', 'source_record_id']
+    # What a rerun refreshes on records already stored
+    UPDATE_FIELDS = ['amount', 'amount_net', 'transaction_date']
+
     def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
         self.logger = logger
         self.rate_limiter = rate_limiter
         self.camara_base = "https://dadosabertos.camara.leg.br/api/v2"
         self.tse_client = TSEClient()
+        # Set during a full reload: records are copied here and swapped in at the end
+        self.staging: Optional[StagingTable] = None
 
     def _parse_brazilian_float(self, value) -> float:
         """Parse Brazilian formatted numbers (comma as decimal separator)"""
@@ -75,14 +94,35 @@ class CLI4RecordsPopulator:
 
     def populate(self, politician_ids: Optional[List[int]] = None,
                  start_year: Optional[int] = None,
-                 end_year: Optional[int] = None) -> int:
-        """Populate unified financial records table"""
+                 end_year: Optional[int] = None,
+                 full_reload: bool = False) -> int:
+        """Populate unified financial records table. A full reload rebuilds the table from
+        scratch beside the live one and swaps it in, dropping records the sources no longer have."""
 
         print("📊 UNIFIED FINANCIAL RECORDS POPULATION")
         print("=" * 50)
         print("Phase 2b: All financial transactions")
         print()
 
+        if full_reload:
+            if politician_ids:
+                raise ValueError("A full reload covers every politician; drop --politician-ids")
+            self.staging = StagingTable('unified_financial_records', key=self.KEY)
+            self.staging.create()
+            print("🔁 Full reload: records are loaded into a staging table and swapped in at the end")
+            try:
+                return self._populate(None, start_year, end_year)
+            except BaseException:
+                self.staging.drop()
+                raise
+            finally:
+                self.staging = None
+
+        return self._populate(politician_ids, start_year, end_year)
+
+    def _populate(self, politician_ids: Optional[List[int]], start_year: Optional[int],
+                  end_year: Optional[int]) -> int:
+
         # Check dependencies
         DependencyChecker.print_dependency_warning(
             required_steps=["politicians"],
@@ -154,6 +194,11 @@ class CLI4RecordsPopulator:
                 )
                 continue
 
+        if self.staging:
+            print(f"\n🔁 Indexing {self.staging.rows:,} staged records and swapping them in...")
+            locked = self.staging.swap()
+            print(f"   ✅ unified_financial_records replaced (locked {locked:.1f}s)")
+
         print(f"\n✅ Financial records population completed: {total_records} total records")
         return total_records
 
@@ -322,7 +367,7 @@ class CLI4RecordsPopulator:
         finance_data_iterator = self.tse_client.get_finance_data_streaming(year, data_type, politician_cpf)
 
         batch_records = []
-        batch_size = 100  # Insert every 100 matching records
+        batch_size = 5000  # Insert every 5000 matching records (one COPY each)
         total_inserted = 0
 
         for record in finance_data_iterator:
@@ -628,92 +673,95 @@ class CLI4RecordsPopulator:
             'external_validation_date': None
         }
 
+    def _record_values(self, record: Dict) -> tuple:
+        """A record's values in FIELDS order"""
+        record_values = []
+        for field in self.FIELDS:
+            value = record.get(field)
+            # Handle None values and type conversions
+            if value is None:
+                record_values.append(None)
+            elif field in ['amount', 'amount_net', 'amount_rejected', 'original_amount']:
+                record_values.append(float(value) if value else 0.0)
+            elif field in ['year', 'month', 'document_code', 'document_type_code',
+                         'lote_code', 'installment', 'election_year', 'election_round']:
+                record_values.append(int(value) if value else None)
+            elif field in ['cnpj_validated', 'sanctions_checked']:
+                record_values.append(bool(value))
+            elif field in ['transaction_date', 'election_date', 'external_validation_date']:
+                record_values.append(value)  # Already DATE type
+            else:
+                record_values.append(value)
+        return tuple(record_values)
+
     def _bulk_insert_records(self, records: List[Dict]) -> int:
-        """Bulk insert financial records"""
+        """Bulk insert financial records with COPY: into the staging table on a full reload,
+        otherwise upserted through a temporary one"""
 
         if not records:
             return 0
 
+        values = [self._record_values(record) for record in records]
+        if self.staging:
+            return self.staging.copy(self.FIELDS, values)
+
+        try:
+            with database.get_connection() as conn:
+                count = copy_upsert(conn.cursor(), 'unified_financial_records', self.FIELDS, values,
+                                    key=self.KEY, update_columns=self.UPDATE_FIELDS)
+                conn.commit()
+            return count
+        except Exception as copy_error:
+            # One bad value fails the whole COPY: find it row by row
+            print(f"    ⚠️ COPY of {len(records)} records failed: {copy_error}")
+            print(f"    🔄 Retrying in batches...")
+            return self._insert_records_rowwise(records, values)
+
+    def _insert_records_rowwise(self, records: List[Dict], values: List[tuple]) -> int:
+        """Insert financial records in INSERT batches, then one by one, skipping bad records"""
+
         batch_size = 100
         inserted_count = 0
 
-        for i in range(0, len(records), batch_size):
+        placeholders = ', '.join(['%s'] * len(self.FIELDS))
+        fields_str = ', '.join(self.FIELDS)
+
+        query = f"""
+            INSERT INTO unified_financial_records ({fields_str})
+            VALUES ({placeholders})
+            ON CONFLICT (source_system, source_record_id) DO UPDATE SET
+                amount = EXCLUDED.amount,
+                amount_net = EXCLUDED.amount_net,
+                transaction_date = EXCLUDED.transaction_date,
+                updated_at = CURRENT_TIMESTAMP,
+                fetched_at = CURRENT_TIMESTAMP
+            RETURNING id
+        """
+
+        for i in range(0, len(values), batch_size):
             batch = records[i:i + batch_size]
+            batch_values = values[i:i + batch_size]
 
+            # Execute batch with retry logic for individual records
             try:
-                # Build bulk insert query - ALL SCHEMA FIELDS INCLUDED (WITH NEW TSE FIELDS)
-                fields = [
-                    'politician_id', 'source_system', 'source_record_id', 'source_url',
-                    'transaction_type', 'transaction_category', 'amount', 'amount_net',
-                    'amount_rejected', 'original_amount', 'transaction_date', 'year',
-                    'month', 'counterpart_name', 'counterpart_cnpj_cpf', 'counterpart_type',
-                    'counterpart_cnae', 'counterpart_business_type', 'state', 'municipality',
-                    'document_number', 'document_code', 'document_type', 'document_type_code',
-                    'document_url', 'lote_code', 'installment', 'reimbursement_number',
-                    'election_year', 'election_round', 'election_date', 'cnpj_validated',
-                    'sanctions_checked', 'external_validation_date'
-                ]
-
-                placeholders = ', '.join(['%s'] * len(fields))
-                fields_str = ', '.join(fields)
-
-                query = f"""
-                    INSERT INTO unified_financial_records ({fields_str})
-                    VALUES ({placeholders})
-                    ON CONFLICT (source_system, source_record_id) DO UPDATE SET
-                        amount = EXCLUDED.amount,
-                        amount_net = EXCLUDED.amount_net,
-                        transaction_date = EXCLUDED.transaction_date,
-                        updated_at = CURRENT_TIMESTAMP
-                    RETURNING id
-                """
-
-                # Prepare values
-                values = []
-                for record in batch:
-                    record_values = []
-                    for field in fields:
-                        value = record.get(field)
-                        # Handle None values and type conversions
-                        if value is None:
-                            record_values.append(None)
-                        elif field in ['amount', 'amount_net', 'amount_rejected', 'original_amount']:
-                            record_values.append(float(value) if value else 0.0)
-                        elif field in ['year', 'month', 'document_code', 'document_type_code',
-                                     'lote_code', 'installment', 'election_year', 'election_round']:
-                            record_values.append(int(value) if value else None)
-                        elif field in ['cnpj_validated', 'sanctions_checked']:
-                            record_values.append(bool(value))
-                        elif field in ['transaction_date', 'election_date', 'external_validation_date']:
-                            record_values.append(value)  # Already DATE type
-                        else:
-                            record_values.append(value)
-                    values.append(tuple(record_values))
-
-                # Execute batch with retry logic for individual records
-                try:
-                    results = database.execute_batch_returning(query, values)
-                    inserted_count += len(results)
-                except Exception as batch_error:
-                    print(f"    ⚠️ Batch {i//batch_size + 1} failed: {batch_error}")
-                    print(f"    🔄 Retrying individual records...")
-                    # Try inserting records one by one to identify problematic records
-                    for j, record_values in enumerate(values):
-                        try:
-                            single_result = database.execute_batch_returning(query, [record_values])
-                            inserted_count += len(single_result)
-                        except Exception as record_error:
-                            print(f"    ❌ Record {j+1} failed: {record_error}")
-                            # Log the problematic record data for debugging
-                            record_data = batch[j]
-                            state_val = record_data.get('state')
-                            source_id = record_data.get('source_record_id', 'unknown')
-                            print(f"       Source ID: {source_id}, State: '{state_val}'")
-                            continue  # Skip this record but continue with others
-
-            except Exception as e:
-                print(f"    ❌ Unexpected error in batch {i//batch_size + 1}: {e}")
-                continue
+                results = database.execute_batch_returning(query, batch_values)
+                inserted_count += len(results)
+            except Exception as batch_error:
+                print(f"    ⚠️ Batch {i//batch_size + 1} failed: {batch_error}")
+                print(f"    🔄 Retrying individual records...")
+                # Try inserting records one by one to identify problematic records
+                for j, record_values in enumerate(batch_values):
+                    try:
+                        single_result = database.execute_batch_returning(query, [record_values])
+                        inserted_count += len(single_result)
+                    except Exception as record_error:
+                        print(f"    ❌ Record {j+1} failed: {record_error}")
+                        # Log the problematic record data for debugging
+                        record_data = batch[j]
+                        state_val = record_data.get('state')
+                        source_id = record_data.get('source_record_id', 'unknown')
+                        print(f"       Source ID: {source_id}, State: '{state_val}'")
+                        continue  # Skip this record but continue with others
 
         return inserted_count
 
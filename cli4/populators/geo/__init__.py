@@ -0,0 +1,5 @@
+# IBGE Areas and Vendor Geolocation Populator Module
+
+from .populator import GeoPopulator
+
+__all__ = ['GeoPopulator']
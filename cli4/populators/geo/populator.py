@@ -0,0 +1,227 @@
+"""
+CLI4 Geo Populator
+Populate ibge_areas with the IBGE states and municipalities (codes, names and simplified GeoJSON
+boundaries from the IBGE malhas API) and geolocate financial_counterparts companies by the
+municipality of their Receita Federal registration. The API aggregates spending by IBGE code
+for choropleth maps (/api/geo/spending).
+"""
+
+import json
+import time
+import requests
+from pathlib import Path
+from typing import Dict, Iterator, List, Optional, Set, Tuple
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+from cli4.populators.cnae.populator import receita_files, read_receita_rows
+from src.validation.normalization import normalize_name
+
+# Estabelecimentos layout (no header, ';' separated, latin-1)
+COL_CNPJ_BASICO = 0
+COL_CNPJ_ORDEM = 1
+COL_CNPJ_DV = 2
+COL_UF = 19
+COL_MUNICIPIO = 20
+
+
+class GeoPopulator:
+    """Populate ibge_areas and the location columns of financial_counterparts"""
+
+    LOCALIDADES_URL = "https://servicodados.ibge.gov.br/api/v1/localidades/"
+    # intrarregiao=UF or municipio; qualidade=minima keeps the whole country in a few MB
+    MALHAS_URL = "https://servicodados.ibge.gov.br/api/v3/malhas/paises/BR"
+
+    BATCH_SIZE = 1000
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+
+    def populate(self, receita_dir: Optional[str] = None, update_existing: bool = False) -> int:
+        """Load the IBGE areas, then geolocate companies from the Estabelecimentos files in
+        receita_dir when given. Returns the areas stored."""
+
+        print("🗺️  IBGE AREAS AND VENDOR GEOLOCATION")
+        print("=" * 60)
+        print("IBGE states and municipalities with boundaries, and where vendors are registered")
+        print()
+
+        start_time = time.time()
+        self._ensure_columns()
+
+        areas = self._load_areas()
+
+        if receita_dir:
+            located = self._locate_companies(Path(receita_dir), update_existing)
+            print(f"📍 {located:,} companies geolocated")
+
+        elapsed_time = time.time() - start_time
+        print(f"\n✅ Geo population completed")
+        print(f"🗺️  {areas:,} areas stored")
+        print(f"⏱️  Total time: {elapsed_time/60:.1f} minutes")
+
+        return areas
+
+    def _ensure_columns(self):
+        """Add the IBGE code column to databases created before it existed"""
+        database.execute_update("""
+            ALTER TABLE financial_counterparts
+                ADD COLUMN IF NOT EXISTS municipality_ibge_code VARCHAR(7)
+        """)
+        database.execute_update(
+            "CREATE INDEX IF NOT EXISTS idx_counterparts_ibge ON financial_counterparts(municipality_ibge_code)"
+        )
+
+    def _load_areas(self) -> int:
+        """Upsert every state and municipality with its boundary"""
+        states = {str(s['id']): s for s in self._get(self.LOCALIDADES_URL + 'estados', 'localidades/estados')}
+        municipalities = self._get(self.LOCALIDADES_URL + 'municipios', 'localidades/municipios')
+        print(f"   📋 {len(states)} states, {len(municipalities):,} municipalities")
+
+        state_shapes = self._get_shapes('UF')
+        municipality_shapes = self._get_shapes('municipio')
+
+        rows = []
+        for code, state in states.items():
+            rows.append((code, 'state', state['nome'], state['sigla'], state_shapes.get(code)))
+        for municipality in municipalities:
+            code = str(municipality['id'])
+            # The first two digits of a municipality code are its state's code
+            state = states.get(code[:2])
+            rows.append((code, 'municipality', municipality['nome'], state['sigla'] if state else None,
+                         municipality_shapes.get(code)))
+
+        with database.get_connection() as conn:
+            cursor = conn.cursor()
+            for i in range(0, len(rows), self.BATCH_SIZE):
+                cursor.executemany(
+                    """
+                    INSERT INTO ibge_areas (ibge_code, level, name, normalized_name, uf, geometry)
+                    VALUES (%s, %s, %s, %s, %s, %s)
+                    ON CONFLICT (ibge_code) DO UPDATE SET
+                        name = EXCLUDED.name,
+                        normalized_name = EXCLUDED.normalized_name,
+                        uf = EXCLUDED.uf,
+                        geometry = COALESCE(EXCLUDED.geometry, ibge_areas.geometry),
+                        updated_at = CURRENT_TIMESTAMP,
+                        fetched_at = CURRENT_TIMESTAMP
+                    """,
+                    [(code, level, name, normalize_name(name), uf, shape) for code, level, name, uf, shape in rows[i:i + self.BATCH_SIZE]]
+                )
+            conn.commit()
+
+        return len(rows)
+
+    def _get_shapes(self, intrarregiao: str) -> Dict[str, str]:
+        """GeoJSON geometries by IBGE code; empty when the malhas API is unavailable"""
+        try:
+            collection = self._get(
+                self.MALHAS_URL, f'malhas/BR/{intrarregiao}',
+                params={'formato': 'application/vnd.geo+json', 'qualidade': 'minima', 'intrarregiao': intrarregiao},
+            )
+        except Exception as e:
+            print(f"   ⚠️ No {intrarregiao} boundaries: {e}")
+            return {}
+
+        shapes = {}
+        for feature in collection.get('features', []):
+            code = str((feature.get('properties') or {}).get('codarea') or '')
+            if code and feature.get('geometry'):
+                shapes[code] = json.dumps(feature['geometry'], separators=(',', ':'))
+        print(f"   🧭 {len(shapes):,} {intrarregiao} boundaries")
+        return shapes
+
+    def _get(self, url: str, endpoint: str, params: Optional[Dict] = None):
+        self.rate_limiter.wait_if_needed('ibge')
+        try:
+            api_start = time.time()
+            response = requests.get(url, params=params, timeout=300)
+            response.raise_for_status()
+            self.logger.log_api_call('ibge', endpoint, 'success', time.time() - api_start)
+            return response.json()
+        except Exception:
+            self.logger.log_api_call('ibge', endpoint, 'error', 0)
+            raise
+
+    def _locate_companies(self, directory: Path, update_existing: bool) -> int:
+        """Set state, municipality and municipality_ibge_code of companies from the
+        Estabelecimentos files. Receita codes its municipalities differently from IBGE, so they
+        are matched by name within the state."""
+        if not directory.is_dir():
+            raise ValueError(f"Receita directory not found: {directory}")
+
+        targets = self._load_target_cnpjs(update_existing)
+        print(f"   🎯 {len(targets):,} companies to geolocate")
+        if not targets:
+            return 0
+
+        receita_names = {}
+        for path in receita_files(directory, 'MUNIC'):
+            for row in read_receita_rows(path):
+                if len(row) >= 2:
+                    receita_names[row[0].strip()] = row[1].strip()
+
+        ibge_codes = {(row['uf'], row['normalized_name']): (row['ibge_code'], row['name']) for row in database.execute_query(
+            "SELECT ibge_code, name, normalized_name, uf FROM ibge_areas WHERE level = 'municipality'"
+        )}
+
+        files = receita_files(directory, 'ESTABELE')
+        if not files:
+            raise ValueError(f"No Estabelecimentos files in {directory}")
+
+        located = 0
+        batch: List[Tuple[str, str, Optional[str], str]] = []
+        for path in files:
+            print(f"   📄 Scanning {path.name}...")
+            for cnpj, uf, receita_code in self._read_establishments(path):
+                if cnpj not in targets:
+                    continue
+                targets.discard(cnpj)
+
+                receita_name = receita_names.get(receita_code, '')
+                ibge_code, name = ibge_codes.get((uf, normalize_name(receita_name)), (None, receita_name.title()))
+                batch.append((uf, name[:255] or None, ibge_code, cnpj))
+
+                if len(batch) >= self.BATCH_SIZE:
+                    located += self._write_batch(batch)
+                    batch = []
+
+            if not targets:
+                break
+
+        located += self._write_batch(batch)
+        return located
+
+    def _load_target_cnpjs(self, update_existing: bool) -> Set[str]:
+        query = """
+            SELECT cnpj_cpf FROM financial_counterparts
+            WHERE entity_type = 'COMPANY' AND LENGTH(cnpj_cpf) = 14
+        """
+        if not update_existing:
+            query += " AND municipality_ibge_code IS NULL"
+        return {row['cnpj_cpf'] for row in database.execute_query(query)}
+
+    def _read_establishments(self, path: Path) -> Iterator[Tuple[str, str, str]]:
+        for row in read_receita_rows(path):
+            if len(row) <= COL_MUNICIPIO:
+                continue
+            cnpj = row[COL_CNPJ_BASICO] + row[COL_CNPJ_ORDEM] + row[COL_CNPJ_DV]
+            if len(cnpj) == 14:
+                yield cnpj, row[COL_UF].strip(), row[COL_MUNICIPIO].strip()
+
+    def _write_batch(self, batch: List[Tuple[str, Optional[str], Optional[str], str]]) -> int:
+        if not batch:
+            return 0
+        with database.get_connection() as conn:
+            cursor = conn.cursor()
+            cursor.executemany(
+                """
+                UPDATE financial_counterparts
+                SET state = %s, municipality = %s, municipality_ibge_code = %s, updated_at = CURRENT_TIMESTAMP
+                WHERE cnpj_cpf = %s
+                """,
+                batch
+            )
+            conn.commit()
+        return len(batch)
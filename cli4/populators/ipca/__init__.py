@@ -0,0 +1,5 @@
+# IPCA Inflation Index Populator Module
+
+from .populator import IPCAPopulator
+
+__all__ = ['IPCAPopulator']
@@ -0,0 +1,104 @@
+"""
+CLI4 IPCA Index Populator
+Populate ipca_index table with the monthly IPCA index number from IBGE (SIDRA table 1737)
+Used by the API to return inflation-adjusted amounts (?adjust_to=2024)
+"""
+
+import time
+import requests
+from datetime import date
+from typing import Dict, List
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+
+
+class IPCAPopulator:
+    """Populate ipca_index with IPCA index numbers (base December 1993 = 100)"""
+
+    # Table 1737, variable 2266: IPCA - número-índice (base: dezembro de 1993 = 100)
+    SIDRA_URL = "https://apisidra.ibge.gov.br/values/t/1737/n1/all/v/2266/p/all"
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+
+    def populate(self) -> int:
+        """Fetch the full IPCA series and upsert every month"""
+
+        print("📈 IPCA INDEX POPULATION")
+        print("=" * 60)
+        print("Monthly IPCA index numbers for inflation-adjusted amounts")
+        print()
+
+        start_time = time.time()
+
+        wait_time = self.rate_limiter.wait_if_needed('ibge')
+        if wait_time > 0:
+            print(f"   ⏰ Rate limiting: waited {wait_time:.1f}s")
+
+        try:
+            api_start = time.time()
+            months = self._fetch_index()
+            self.logger.log_api_call('ibge', 'sidra/t/1737', 'success', time.time() - api_start)
+        except Exception as e:
+            print(f"   ❌ Error fetching IPCA series: {e}")
+            self.logger.log_api_call('ibge', 'sidra/t/1737', 'error', 0)
+            raise
+
+        if not months:
+            print("   ⚠️ No IPCA months found")
+            return 0
+
+        print(f"   📋 Found {len(months)} months ({months[0]['reference_month']:%Y-%m} to {months[-1]['reference_month']:%Y-%m})")
+
+        for month in months:
+            database.execute_update(
+                """
+                INSERT INTO ipca_index (reference_month, index_number)
+                VALUES (%s, %s)
+                ON CONFLICT (reference_month) DO UPDATE
+                SET index_number = EXCLUDED.index_number, updated_at = CURRENT_TIMESTAMP, fetched_at = CURRENT_TIMESTAMP
+                """,
+                (month['reference_month'], month['index_number'])
+            )
+
+        elapsed_time = time.time() - start_time
+        print(f"\n✅ IPCA index population completed")
+        print(f"📊 {len(months)} months stored")
+        print(f"⏱️  Total time: {elapsed_time:.1f} seconds")
+
+        return len(months)
+
+    def _fetch_index(self) -> List[Dict]:
+        """Fetch the series from SIDRA. The first row maps column keys to labels."""
+        response = requests.get(self.SIDRA_URL, timeout=60)
+        response.raise_for_status()
+
+        rows = response.json()
+        if not rows:
+            return []
+
+        header = rows[0]
+        month_key = next((k for k, label in header.items() if label == 'Mês (Código)'), None)
+        if month_key is None:
+            raise ValueError("SIDRA response has no 'Mês (Código)' column")
+
+        months = []
+        for row in rows[1:]:
+            code, value = row.get(month_key, ''), row.get('V', '')
+            # Unpublished months come back as '...' or '-'
+            if len(code) != 6 or not code.isdigit():
+                continue
+            try:
+                float(value)
+            except ValueError:
+                continue
+
+            months.append({
+                'reference_month': date(int(code[:4]), int(code[4:]), 1),
+                'index_number': value,
+            })
+
+        months.sort(key=lambda m: m['reference_month'])
+        return months
@@ -11,6 +11,11 @@ from cli4.modules.logger import CLI4Logger
 from cli4.modules.rate_limiter import CLI4RateLimiter
 from cli4.modules.dependency_checker import DependencyChecker
 
+# Declared wealth growth between consecutive elections is unusual when it at least doubles AND
+# grows by R$ 500k or more (the API's GET /api/politicians/:id/assets flags the same steps)
+UNUSUAL_GROWTH_PCT = 100.0
+UNUSUAL_GROWTH_MIN_VALUE = 500000.0
+
 
 class EnhancedCLI4PostProcessor:
     """Enhanced post-processor for 13-table architecture with corruption detection"""
@@ -180,6 +185,26 @@ class EnhancedCLI4PostProcessor:
             })
             print("      ✅ SANCTIONS: Clean vendor record")
 
+        # Recurring local expenses paid to vendors in far-away states (detect-anomalies)
+        geo_mismatch = database.execute_query("""
+            SELECT COUNT(DISTINCT cnpj_cpf) as vendors, COALESCE(SUM(total_amount), 0) as amount
+            FROM expense_anomalies
+            WHERE politician_id = %s AND anomaly_type = 'geo_mismatch'
+        """, (politician_id,))
+        geo_vendors = geo_mismatch[0]['vendors'] if geo_mismatch else 0
+        corruption_metrics.update({
+            'geo_mismatch_vendors': geo_vendors,
+            'geo_mismatch_amount': geo_mismatch[0]['amount'] if geo_mismatch else 0
+        })
+        if geo_vendors > 0:
+            print(f"      🚨 GEO: {geo_vendors} far-away vendors of local expenses, R$ {geo_mismatch[0]['amount']:,.2f}")
+
+        # Unusual declared wealth growth between elections
+        _, unusual_growth = self._wealth_growth_anomaly(politician_id)
+        corruption_metrics['wealth_unusual_growth'] = unusual_growth
+        if unusual_growth:
+            print("      🚨 WEALTH: Unusual growth between asset declarations")
+
         # Calculate corruption risk score
         risk_score = self._calculate_corruption_risk_score(corruption_metrics)
         corruption_metrics['corruption_risk_score'] = risk_score
@@ -332,6 +357,15 @@ class EnhancedCLI4PostProcessor:
             })
             print(f"      💎 WEALTH: {wealth['wealth_declarations']} declarations, R$ {wealth_growth:,.2f} growth")
 
+            max_growth_pct, unusual_growth = self._wealth_growth_anomaly(politician_id)
+            wealth_metrics.update({
+                'wealth_max_growth_pct': max_growth_pct,
+                'wealth_unusual_growth': unusual_growth
+            })
+            if max_growth_pct is not None:
+                flag = " ⚠️ unusual" if unusual_growth else ""
+                print(f"      📈 WEALTH: Max growth between declarations {max_growth_pct:,.2f}%{flag}")
+
         # Asset diversity analysis
         asset_diversity = database.execute_query("""
             SELECT COUNT(DISTINCT asset_type_description) as asset_types,
@@ -354,6 +388,31 @@ class EnhancedCLI4PostProcessor:
 
         return wealth_metrics
 
+    def _wealth_growth_anomaly(self, politician_id: int) -> Tuple[Optional[float], bool]:
+        """Largest growth between consecutive declarations (%), and whether any step is unusual"""
+        declarations = database.execute_query("""
+            SELECT year, total_declared_wealth
+            FROM unified_wealth_tracking
+            WHERE politician_id = %s
+            ORDER BY year
+        """, (politician_id,))
+
+        max_growth_pct = None
+        unusual = False
+        previous = None
+        for declaration in declarations or []:
+            current = float(declaration['total_declared_wealth'] or 0)
+            if previous is not None and previous > 0:
+                growth = current - previous
+                growth_pct = round(growth / previous * 100, 2)
+                if max_growth_pct is None or growth_pct > max_growth_pct:
+                    max_growth_pct = growth_pct
+                if growth_pct >= UNUSUAL_GROWTH_PCT and growth >= UNUSUAL_GROWTH_MIN_VALUE:
+                    unusual = True
+            previous = current
+
+        return max_growth_pct, unusual
+
     def _calculate_corruption_risk_score(self, corruption_metrics: Dict) -> float:
         """Calculate corruption risk score (0-100, higher = more risk)"""
         score = 0
@@ -371,6 +430,14 @@ class EnhancedCLI4PostProcessor:
             if corruption_metrics.get('sanctioned_vendors_count', 0) > 2:
                 score += 10
 
+        # Unusual growth of declared wealth between elections (medium weight)
+        if corruption_metrics.get('wealth_unusual_growth'):
+            score += 20
+
+        # Local expenses recurring at vendors in far-away states (medium weight)
+        if corruption_metrics.get('geo_mismatch_vendors', 0) > 0:
+            score += 15
+
         return min(score, 100)  # Cap at 100
 
     def _get_politicians_by_ids(self, politician_ids: List[int]) -> List[Dict]:
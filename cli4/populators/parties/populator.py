@@ -298,7 +298,7 @@ class CLI4PartiesPopulator:
                 SET nome = %s, sigla = %s, numero_eleitoral = %s, status = %s,
                     lider_atual = %s, lider_id = %s, lider_estado = %s, lider_legislatura = %s,
                     total_membros = %s, total_efetivos = %s, logo_url = %s, uri_membros = %s,
-                    updated_at = %s
+                    updated_at = %s, fetched_at = CURRENT_TIMESTAMP
                 WHERE id = %s AND legislatura_id = %s
             """
 
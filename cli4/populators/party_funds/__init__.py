@@ -0,0 +1,5 @@
+# Party Funds (Fundo Partidário / FEFC) Populator Module
+
+from .populator import PartyFundsPopulator
+
+__all__ = ['PartyFundsPopulator']
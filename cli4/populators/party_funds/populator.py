@@ -0,0 +1,117 @@
+"""
+CLI4 Party Funds Populator
+Load Fundo Partidário and FEFC (Fundo Especial de Financiamento de Campanha) distributions
+per party into party_funds from a CSV exported from the TSE distribution tables
+Shown on the API party detail (GET /api/parties/:id?include=funds)
+"""
+
+import csv
+import time
+from decimal import Decimal, InvalidOperation
+from typing import Dict, List, Optional
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+
+
+class PartyFundsPopulator:
+    """Populate party_funds from a CSV with ano, mes, sigla_partido, tipo, valor columns"""
+
+    FUND_TYPES = {
+        'fundo_partidario': 'FUNDO_PARTIDARIO',
+        'fundo partidário': 'FUNDO_PARTIDARIO',
+        'fp': 'FUNDO_PARTIDARIO',
+        'fefc': 'FEFC',
+        'fundo eleitoral': 'FEFC',
+    }
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+
+    def populate(self, csv_path: str) -> int:
+        """Upsert every row of csv_path. mes is optional (0 or empty means a yearly total)."""
+
+        print("💰 PARTY FUNDS POPULATION")
+        print("=" * 60)
+        print("Fundo Partidário and FEFC distributions per party")
+        print()
+
+        start_time = time.time()
+        rows = self._read_csv(csv_path)
+        print(f"   📋 Read {len(rows)} distributions from {csv_path}")
+
+        stored = 0
+        for row in rows:
+            database.execute_update(
+                """
+                INSERT INTO party_funds (party_sigla, year, month, fund_type, amount)
+                VALUES (%s, %s, %s, %s, %s)
+                ON CONFLICT (party_sigla, year, month, fund_type) DO UPDATE
+                SET amount = EXCLUDED.amount, updated_at = CURRENT_TIMESTAMP, fetched_at = CURRENT_TIMESTAMP
+                """,
+                (row['party_sigla'], row['year'], row['month'], row['fund_type'], row['amount'])
+            )
+            stored += 1
+
+        self.logger.log_processing('party_funds', csv_path, 'success', {'rows': stored})
+
+        elapsed_time = time.time() - start_time
+        print(f"\n✅ Party funds population completed")
+        print(f"📊 {stored} distributions stored")
+        print(f"⏱️  Total time: {elapsed_time:.1f} seconds")
+
+        return stored
+
+    def _read_csv(self, csv_path: str) -> List[Dict]:
+        """Parse the CSV (',' or ';' separated, UTF-8 or latin-1), skipping invalid rows"""
+        with open(csv_path, 'rb') as f:
+            raw = f.read()
+        try:
+            text = raw.decode('utf-8-sig')
+        except UnicodeDecodeError:
+            text = raw.decode('latin-1')
+
+        delimiter = ';' if text.split('\n', 1)[0].count(';') > text.split('\n', 1)[0].count(',') else ','
+        reader = csv.DictReader(text.splitlines(), delimiter=delimiter)
+        reader.fieldnames = [name.strip().lower() for name in reader.fieldnames or []]
+
+        missing = {'ano', 'sigla_partido', 'tipo', 'valor'} - set(reader.fieldnames)
+        if missing:
+            raise ValueError(f"{csv_path} is missing columns: {', '.join(sorted(missing))}")
+
+        rows = []
+        for line, record in enumerate(reader, start=2):
+            fund_type = self.FUND_TYPES.get((record.get('tipo') or '').strip().lower())
+            amount = self._parse_amount(record.get('valor'))
+            sigla = (record.get('sigla_partido') or '').strip().upper()
+            try:
+                year = int(record['ano'])
+                month = int(record.get('mes') or 0)
+            except ValueError:
+                year = month = None
+
+            if not fund_type or amount is None or not sigla or year is None or not 0 <= month <= 12:
+                print(f"   ⚠️ Skipping line {line}: {record}")
+                continue
+
+            rows.append({
+                'party_sigla': sigla,
+                'year': year,
+                'month': month,
+                'fund_type': fund_type,
+                'amount': amount,
+            })
+        return rows
+
+    def _parse_amount(self, value: Optional[str]) -> Optional[Decimal]:
+        """Parse '1234.56' or Brazilian '1.234,56' (optionally prefixed with R$)"""
+        if not value:
+            return None
+        value = value.replace('R$', '').strip()
+        if ',' in value:
+            value = value.replace('.', '').replace(',', '.')
+        try:
+            return Decimal(value).quantize(Decimal('0.01'))
+        except InvalidOperation:
+            return None
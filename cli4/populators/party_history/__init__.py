@@ -0,0 +1,5 @@
+# Party Membership History Populator Module
+
+from .populator import PartyHistoryPopulator
+
+__all__ = ['PartyHistoryPopulator']
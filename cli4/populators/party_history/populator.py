@@ -0,0 +1,156 @@
+"""
+CLI4 Party History Populator
+Populate party_membership_history with time-ranged party memberships built from the
+Câmara deputy status history (/deputados/{id}/historico)
+party_memberships only snapshots each legislature's active members, so switches within
+a legislature are lost there; the API builds party_switch connections from this table
+"""
+
+import time
+from typing import Dict, List, Optional
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+from cli4.modules.dependency_checker import DependencyChecker
+from src.clients.deputados_client import DeputadosClient
+
+
+class PartyHistoryPopulator:
+    """Populate party_membership_history from deputy status history"""
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+        self.deputados_client = DeputadosClient()
+
+    def populate(self, politician_ids: Optional[List[int]] = None, limit: Optional[int] = None) -> int:
+        """Rebuild each deputy's membership segments"""
+
+        print("🔀 PARTY MEMBERSHIP HISTORY POPULATION")
+        print("=" * 60)
+        print("Time-ranged party memberships and party switches")
+        print()
+
+        DependencyChecker.print_dependency_warning(
+            required_steps=["politicians", "parties"],
+            current_step="PARTY HISTORY POPULATION"
+        )
+
+        query = "SELECT id, deputy_id, nome_civil FROM unified_politicians WHERE deputy_id IS NOT NULL"
+        params = None
+        if politician_ids:
+            query += " AND id = ANY(%s)"
+            params = (politician_ids,)
+        query += " ORDER BY id"
+        if limit:
+            query += f" LIMIT {int(limit)}"
+
+        politicians = database.execute_query(query, params)
+        party_ids = self._party_ids_by_sigla()
+
+        print(f"👥 Processing {len(politicians)} politicians with deputy_id")
+        print()
+
+        total_segments = 0
+        total_switches = 0
+        processed = 0
+
+        for i, politician in enumerate(politicians, 1):
+            print(f"🏛️ [{i}/{len(politicians)}] {politician['nome_civil']}")
+            try:
+                history = self._fetch_history(politician['deputy_id'])
+                segments = self._build_segments(history)
+                self._replace_segments(politician, segments, party_ids)
+
+                switches = max(len(segments) - 1, 0)
+                total_segments += len(segments)
+                total_switches += switches
+                processed += 1
+
+                print(f"  ✅ {len(segments)} memberships, {switches} switches")
+                self.logger.log_processing(
+                    'party_history', str(politician['id']), 'success',
+                    {'segments': len(segments), 'switches': switches}
+                )
+
+            except Exception as e:
+                print(f"  ❌ Error: {e}")
+                self.logger.log_processing('party_history', str(politician['id']), 'error', {'error': str(e)})
+                continue
+
+        print(f"\n✅ Party history population completed")
+        print(f"📊 {total_segments} memberships, {total_switches} party switches")
+        print(f"👥 {processed}/{len(politicians)} politicians processed")
+
+        return total_segments
+
+    def _fetch_history(self, deputy_id: int) -> List[Dict]:
+        """Fetch the status history with rate limiting"""
+        self.rate_limiter.wait_if_needed('camara')
+
+        try:
+            start_time = time.time()
+            history = self.deputados_client.get_deputy_history(deputy_id)
+            self.logger.log_api_call('camara', f'historico/{deputy_id}', 'success', time.time() - start_time)
+            return history
+        except Exception:
+            self.logger.log_api_call('camara', f'historico/{deputy_id}', 'error', 0)
+            raise
+
+    def _build_segments(self, history: List[Dict]) -> List[Dict]:
+        """Collapse consecutive status records with the same party into one membership.
+        Each membership ends where the next one starts; the last one stays open."""
+        records = sorted(
+            (r for r in history if r.get('siglaPartido') and r.get('dataHora')),
+            key=lambda r: r['dataHora']
+        )
+
+        segments = []
+        for record in records:
+            sigla = record['siglaPartido'].strip().upper()
+            if segments and segments[-1]['party_sigla'] == sigla:
+                continue
+
+            start_date = record['dataHora'][:10]
+            if segments:
+                segments[-1]['end_date'] = start_date
+            segments.append({
+                'party_sigla': sigla,
+                'legislatura_id': record.get('idLegislatura'),
+                'start_date': start_date,
+                'end_date': None,
+                'start_event': (record.get('descricaoStatus') or record.get('situacao') or '')[:500] or None,
+            })
+
+        return segments
+
+    def _party_ids_by_sigla(self) -> Dict[str, int]:
+        """Map party sigla to political_parties.id, preferring the latest legislature"""
+        rows = database.execute_query(
+            "SELECT id, sigla FROM political_parties ORDER BY legislatura_id NULLS FIRST"
+        )
+        return {row['sigla'].upper(): row['id'] for row in rows}
+
+    def _replace_segments(self, politician: Dict, segments: List[Dict], party_ids: Dict[str, int]):
+        """Replace a deputy's memberships so reruns pick up corrected history"""
+        database.execute_update(
+            "DELETE FROM party_membership_history WHERE politician_id = %s",
+            (politician['id'],)
+        )
+
+        for segment in segments:
+            database.execute_update(
+                """
+                INSERT INTO party_membership_history (
+                    politician_id, deputy_id, party_sigla, party_id, legislatura_id,
+                    start_date, end_date, start_event
+                )
+                VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+                ON CONFLICT (deputy_id, party_sigla, start_date) DO NOTHING
+                """,
+                (
+                    politician['id'], politician['deputy_id'], segment['party_sigla'],
+                    party_ids.get(segment['party_sigla']), segment['legislatura_id'],
+                    segment['start_date'], segment['end_date'], segment['start_event'],
+                )
+            )
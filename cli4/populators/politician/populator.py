@@ -10,6 +10,7 @@ from cli4.modules import database
 from cli4.modules.logger import CLI4Logger
 from cli4.modules.rate_limiter import CLI4RateLimiter
 from src.clients.tse_client import TSEClient
+from src.validation.normalization import normalize_text
 
 
 class CLI4PoliticianPopulator:
@@ -366,6 +367,31 @@ class CLI4PoliticianPopulator:
             self.logger.log_api_call('camara', f'/deputados/{deputy_id}', 'error', response_time)
             return None
 
+    def _get_deputy_profession(self, deputy_id: Optional[int]) -> Optional[str]:
+        """Get the deputy's declared profession from the Câmara (fallback when there is no TSE occupation)"""
+        if not deputy_id:
+            return None
+
+        self.rate_limiter.wait_if_needed('camara')
+
+        start_time = time.time()
+        url = f"{self.camara_base}/deputados/{deputy_id}/profissoes"
+
+        try:
+            response = requests.get(url, timeout=30)
+        except requests.RequestException:
+            self.logger.log_api_call('camara', f'/deputados/{deputy_id}/profissoes', 'error', time.time() - start_time)
+            return None
+        response_time = time.time() - start_time
+
+        if response.status_code != 200:
+            self.logger.log_api_call('camara', f'/deputados/{deputy_id}/profissoes', 'error', response_time)
+            return None
+
+        self.logger.log_api_call('camara', f'/deputados/{deputy_id}/profissoes', 'success', response_time)
+        professions = [p.get('titulo') for p in response.json().get('dados', []) if p.get('titulo')]
+        return professions[0] if professions else None
+
     def _find_tse_candidate_by_cpf(self, cpf: str, state: Optional[str] = None) -> List[Dict]:
         """Find TSE candidacy records by CPF using cached TSE client"""
         if not cpf:
@@ -752,7 +778,7 @@ class CLI4PoliticianPopulator:
             'gender_code': most_recent_tse.get('cd_genero') if most_recent_tse else None,
             'education_level': deputy_detail.get('escolaridade') or (most_recent_tse.get('ds_grau_instrucao') if most_recent_tse else None),
             'education_code': most_recent_tse.get('cd_grau_instrucao') if most_recent_tse else None,
-            'occupation': most_recent_tse.get('ds_ocupacao') if most_recent_tse else None,
+            'occupation': (most_recent_tse.get('ds_ocupacao') if most_recent_tse else None) or self._get_deputy_profession(deputy_detail.get('id')),
             'occupation_code': most_recent_tse.get('cd_ocupacao') if most_recent_tse else None,
             'marital_status': most_recent_tse.get('ds_estado_civil') if most_recent_tse else None,
             'marital_status_code': most_recent_tse.get('cd_estado_civil') if most_recent_tse else None,
@@ -786,14 +812,7 @@ class CLI4PoliticianPopulator:
 
     def _normalize_name(self, name: str) -> str:
         """Normalize name for comparison"""
-        if not name:
-            return ''
-
-        # Remove accents and convert to uppercase
-        import unicodedata
-        normalized = unicodedata.normalize('NFD', name)
-        ascii_name = ''.join(c for c in normalized if unicodedata.category(c) != 'Mn')
-        return ascii_name.upper().strip()
+        return normalize_text(name)
 
     def _format_social_networks(self, social_data: Any) -> Optional[str]:
         """Format social networks data for JSONB storage"""
@@ -887,8 +906,9 @@ class CLI4PoliticianPopulator:
 
                 # Only execute update if there are actual changes
                 if update_fields:
-                    # Add updated_at timestamp
+                    # Add updated_at and fetched_at timestamps
                     update_fields.append("updated_at = NOW()")
+                    update_fields.append("fetched_at = NOW()")
 
                     query = f"""
                         UPDATE unified_politicians
@@ -0,0 +1,5 @@
+# QSA Populator Module
+
+from .populator import QSAPopulator
+
+__all__ = ['QSAPopulator']
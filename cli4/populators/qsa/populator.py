@@ -0,0 +1,156 @@
+"""
+CLI4 QSA Populator
+Load the ownership table (Quadro de Sócios e Administradores) of financial_counterparts
+companies from the Receita Federal open CNPJ dataset (Socios + Qualificacoes files)
+Feeds the owners section of the company dossier (/api/companies/:cnpj)
+"""
+
+import time
+from pathlib import Path
+from typing import Dict, Iterator, List, Optional, Set, Tuple
+from cli4.modules import database
+from cli4.modules.bulk_load import copy_upsert
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+from cli4.populators.cnae.populator import receita_files, read_receita_rows
+
+
+# Socios layout (no header, ';' separated, latin-1)
+COL_CNPJ_BASICO = 0
+COL_IDENTIFICADOR = 1
+COL_NOME = 2
+COL_DOCUMENTO = 3
+COL_QUALIFICACAO = 4
+COL_DATA_ENTRADA = 5
+COL_FAIXA_ETARIA = 10
+
+PARTNER_TYPES = {'1': 'PJ', '2': 'PF', '3': 'ESTRANGEIRO'}
+
+AGE_RANGES = {
+    '1': '0-12', '2': '13-20', '3': '21-30', '4': '31-40', '5': '41-50',
+    '6': '51-60', '7': '61-70', '8': '71-80', '9': '80+',
+}
+
+
+class QSAPopulator:
+    """Populate company_partners from Receita Federal Socios files"""
+
+    COLUMNS = [
+        'cnpj_root', 'partner_type', 'partner_name', 'partner_document',
+        'qualification_code', 'qualification', 'entry_date', 'age_range'
+    ]
+    KEY = ['cnpj_root', 'partner_name', 'partner_document', 'qualification_code']
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+
+    def populate(self, receita_dir: str) -> int:
+        """Replace the partners of every company CNPJ root found in the Socios files"""
+
+        print("🤝 QSA (COMPANY OWNERS) POPULATION")
+        print("=" * 60)
+        print("Receita Federal partners and administrators for vendor/donor companies")
+        print()
+
+        start_time = time.time()
+        directory = Path(receita_dir)
+        if not directory.is_dir():
+            raise ValueError(f"Receita directory not found: {receita_dir}")
+
+        qualifications = self._load_qualifications(directory)
+        print(f"   📚 {len(qualifications):,} qualification descriptions loaded")
+
+        roots = self._load_target_roots()
+        print(f"   🎯 {len(roots):,} company CNPJ roots to match")
+        if not roots:
+            return 0
+
+        files = receita_files(directory, 'SOCIO')
+        if not files:
+            raise ValueError(f"No Socios files in {receita_dir}")
+
+        partners: Dict[str, List[Tuple]] = {}
+        for path in files:
+            print(f"   📄 Scanning {path.name}...")
+            for root, partner in self._read_partners(path, qualifications):
+                if root in roots:
+                    partners.setdefault(root, []).append(partner)
+
+        inserted = self._replace_partners(partners)
+
+        elapsed_time = time.time() - start_time
+        print(f"\n✅ QSA population completed")
+        print(f"📊 {inserted:,} partners for {len(partners):,} companies, "
+              f"{len(roots) - len(partners):,} roots without QSA")
+        print(f"⏱️  Total time: {elapsed_time/60:.1f} minutes")
+
+        self.logger.log_processing('qsa', receita_dir, 'success', {
+            'partners': inserted, 'companies': len(partners)
+        })
+
+        return inserted
+
+    def _load_target_roots(self) -> Set[str]:
+        """The 8-digit CNPJ roots (cnpj_basico) of known companies"""
+        rows = database.execute_query("""
+            SELECT DISTINCT LEFT(cnpj_cpf, 8) as cnpj_root FROM financial_counterparts
+            WHERE entity_type = 'COMPANY' AND LENGTH(cnpj_cpf) = 14
+        """)
+        return {row['cnpj_root'] for row in rows}
+
+    def _load_qualifications(self, directory: Path) -> Dict[str, str]:
+        descriptions = {}
+        for path in receita_files(directory, 'QUAL'):
+            for row in read_receita_rows(path):
+                if len(row) >= 2:
+                    descriptions[row[0].strip().lstrip('0') or '0'] = row[1].strip()[:255]
+        return descriptions
+
+    def _read_partners(self, path: Path, qualifications: Dict[str, str]) -> Iterator[Tuple[str, Tuple]]:
+        for row in read_receita_rows(path):
+            if len(row) <= COL_FAIXA_ETARIA:
+                continue
+
+            root = row[COL_CNPJ_BASICO].strip()
+            name = row[COL_NOME].strip()
+            if len(root) != 8 or not name:
+                continue
+
+            qualification_code = row[COL_QUALIFICACAO].strip().lstrip('0') or '0'
+            yield root, (
+                root,
+                PARTNER_TYPES.get(row[COL_IDENTIFICADOR].strip()),
+                name[:255],
+                # CPFs are already masked by the Receita (***123456**)
+                row[COL_DOCUMENTO].strip()[:14],
+                qualification_code,
+                qualifications.get(qualification_code),
+                self._parse_date(row[COL_DATA_ENTRADA]),
+                AGE_RANGES.get(row[COL_FAIXA_ETARIA].strip()),
+            )
+
+    def _parse_date(self, value: str) -> Optional[str]:
+        """Receita dates are YYYYMMDD, with 0 or blank when unknown"""
+        value = value.strip()
+        if len(value) != 8 or not value.isdigit() or value == '00000000':
+            return None
+        return f"{value[:4]}-{value[4:6]}-{value[6:]}"
+
+    def _replace_partners(self, partners: Dict[str, List[Tuple]]) -> int:
+        """Replace each matched company's partners so reruns drop partners who left"""
+        if not partners:
+            return 0
+
+        rows = [partner for company in partners.values() for partner in company]
+        with database.get_connection() as conn:
+            cursor = conn.cursor()
+            cursor.execute(
+                "DELETE FROM company_partners WHERE cnpj_root = ANY(%s)",
+                (list(partners.keys()),)
+            )
+            # One COPY for the whole set instead of an INSERT per partner
+            copy_upsert(cursor, 'company_partners', self.COLUMNS, rows, key=self.KEY)
+            conn.commit()
+
+        return len(rows)
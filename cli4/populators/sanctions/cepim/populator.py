@@ -316,6 +316,7 @@ class CEPIMPopulator:
                 # ON CONFLICT UPDATE approach
                 update_fields = [f"{field} = EXCLUDED.{field}" for field in fields
                                if field not in ['cnpj_cpf', 'sanction_type', 'sanction_start_date', 'sanctioning_agency']]
+                update_fields.append("fetched_at = CURRENT_TIMESTAMP")
 
                 sql = f"""
                     INSERT INTO vendor_sanctions ({', '.join(fields)})
@@ -38,6 +38,8 @@ class CNEPPopulator:
             current_step="CNEP POPULATION"
         )
 
+        self._ensure_columns()
+
         print("🎯 APPROACH: Complete sanctions database for fast local lookups")
         print("   • Fetch ALL sanctions via pagination (API CNPJ filter is broken)")
         print("   • Store in local database with proper indexing")
@@ -220,6 +222,7 @@ class CNEPPopulator:
                 'sanction_description': self._normalize_text(
                     tipo_sancao.get('descricaoPortal'), 2000
                 ),
+                'legal_basis': self._legal_basis(sanction),
                 'sanction_start_date': start_date,
                 'sanction_end_date': end_date,
                 'sanctioning_agency': self._normalize_text(
@@ -244,6 +247,21 @@ class CNEPPopulator:
             print(f"        ⚠️ Error building sanction record: {e}")
             return None
 
+    def _ensure_columns(self):
+        """Add columns introduced after vendor_sanctions was first created"""
+        database.execute_update(
+            "ALTER TABLE vendor_sanctions ADD COLUMN IF NOT EXISTS legal_basis TEXT"
+        )
+
+    def _legal_basis(self, sanction: Dict) -> Optional[str]:
+        """Join the legal provisions (fundamentacao) cited by the sanction"""
+        provisions = [
+            (item.get('descricao') or item.get('codigo') or '').strip()
+            for item in sanction.get('fundamentacao') or []
+            if isinstance(item, dict)
+        ]
+        return self._normalize_text('; '.join(p for p in provisions if p), 2000)
+
     def _parse_date(self, date_str: str) -> Optional[str]:
         """Parse Brazilian date format to database-compatible format"""
         if not date_str or date_str.strip() == '' or 'Sem informação' in date_str:
@@ -319,6 +337,7 @@ class CNEPPopulator:
                 # ON CONFLICT UPDATE approach
                 update_fields = [f"{field} = EXCLUDED.{field}" for field in fields
                                if field not in ['cnpj_cpf', 'sanction_type', 'sanction_start_date', 'sanctioning_agency']]
+                update_fields.append("fetched_at = CURRENT_TIMESTAMP")
 
                 sql = f"""
                     INSERT INTO vendor_sanctions ({', '.join(fields)})
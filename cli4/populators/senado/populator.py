@@ -251,6 +251,7 @@ class SenadoPopulator:
                 # ON CONFLICT UPDATE approach
                 update_fields = [f"{field} = EXCLUDED.{field}" for field in fields
                                if field not in ['codigo']]
+                update_fields.append("fetched_at = CURRENT_TIMESTAMP")
 
                 sql = f"""
                     INSERT INTO senado_politicians ({', '.join(fields)})
@@ -0,0 +1,6 @@
+# Speeches (Discursos) Populator Module
+
+from .populator import SpeechesPopulator
+from .keywords import extract_topics
+
+__all__ = ['SpeechesPopulator', 'extract_topics']
@@ -0,0 +1,90 @@
+"""
+Topic extraction for plenary speeches
+The Câmara indexes most speeches with keywords; those are used as they are. Speeches without
+them get the most frequent meaningful words and word pairs of their summary and transcript.
+"""
+
+import re
+import unicodedata
+from collections import Counter
+from typing import Dict, List, Optional
+
+# Words that carry no topic: function words plus the procedural vocabulary of every speech
+STOPWORDS = set("""
+a à ao aos aquela aquelas aquele aqueles aquilo as às até com como contra da das de dela delas
+dele deles depois do dos e é ela elas ele eles em entre era essa essas esse esses esta está
+estamos estão estas este estes eu foi fomos for foram há isso isto já lhe lhes mais mas me
+mesmo meu minha muito na nas nem no nos nós nossa nossas nosso nossos num numa o os ou para
+pela pelas pelo pelos por porque qual quando que quem se sem ser seu seus sua suas são só
+também te tem têm ter toda todas todo todos tu um uma umas uns vai vamos você vocês
+aqui agora ainda então assim sobre sendo sido seja sejam pode podem deve devem fazer feito
+hoje dia dias ano anos vez vezes grande bem sim não obrigado obrigada
+senhor senhora senhores senhoras sr sra srs sras deputado deputada deputados deputadas
+presidente presidência casa plenário sessão discurso orador oradora palavra tempo minutos
+brasil brasileiro brasileira brasileiros brasileiras país povo nação governo federal
+v.exa exa excelência colegas colega pronunciamento registro quero gostaria
+""".split())
+
+WORD = re.compile(r"[a-zà-öø-ÿ]+(?:-[a-zà-öø-ÿ]+)*")
+
+
+def slugify(topic: str) -> str:
+    """Accent-free, lowercase, hyphenated key used to match a topic across speeches"""
+    ascii_topic = unicodedata.normalize('NFKD', topic).encode('ascii', 'ignore').decode('ascii')
+    return re.sub(r'[^a-z0-9]+', '-', ascii_topic.lower()).strip('-')
+
+
+def _from_keywords(keywords: str) -> List[Dict]:
+    topics = []
+    seen = set()
+    for raw in re.split(r'[,;]', keywords):
+        topic = ' '.join(raw.split()).strip(' .')
+        slug = slugify(topic)
+        if not slug or len(topic) > 100 or slug in seen:
+            continue
+        seen.add(slug)
+        topics.append({'topic': topic[:1].upper() + topic[1:], 'slug': slug, 'source': 'camara_keywords', 'weight': 1.0})
+    return topics
+
+
+def _from_text(text: str, limit: int) -> List[Dict]:
+    words = WORD.findall(text.lower())
+    meaningful = [w if len(w) >= 4 and w not in STOPWORDS else None for w in words]
+
+    counts = Counter(w for w in meaningful if w)
+    # Adjacent meaningful words that recur ("reforma tributária") beat either word alone
+    pairs = Counter(
+        f"{a} {b}" for a, b in zip(meaningful, meaningful[1:]) if a and b and a != b
+    )
+    for pair, n in pairs.items():
+        if n >= 2:
+            counts[pair] = n * 2
+
+    if not counts:
+        return []
+
+    top = counts.most_common()[0][1]
+    topics = []
+    seen = set()
+    covered = set()
+    for term, n in counts.most_common():
+        if len(topics) == limit:
+            break
+        slug = slugify(term)
+        # Skip single words already inside a chosen pair
+        if slug in seen or term in covered or n < 2:
+            continue
+        seen.add(slug)
+        covered.update(term.split())
+        topics.append({'topic': term[:1].upper() + term[1:], 'slug': slug, 'source': 'extracted', 'weight': round(n / top, 3)})
+    return topics
+
+
+def extract_topics(keywords: Optional[str], summary: Optional[str], transcript: Optional[str],
+                   limit: int = 5) -> List[Dict]:
+    """Topics of one speech as dicts with topic, slug, source and weight (0-1)"""
+    if keywords and keywords.strip():
+        topics = _from_keywords(keywords)
+        if topics:
+            return topics
+    return _from_text(' '.join(filter(None, [summary, transcript])), limit)
@@ -0,0 +1,146 @@
+"""
+CLI4 Speeches Populator
+Populate politician_speeches with plenary speeches from the Câmara (/deputados/{id}/discursos)
+and speech_topics with the keywords of each one, which the API serves as politician topics
+and links into the network as topic nodes
+"""
+
+import time
+from datetime import date, timedelta
+from typing import Dict, List, Optional
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+from cli4.modules.dependency_checker import DependencyChecker
+from src.clients.deputados_client import DeputadosClient
+from .keywords import extract_topics
+
+
+class SpeechesPopulator:
+    """Populate politician_speeches and speech_topics"""
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+        self.deputados_client = DeputadosClient()
+
+    def populate(self, politician_ids: Optional[List[int]] = None, limit: Optional[int] = None,
+                 days_back: int = 365) -> int:
+        """Load each deputy's speeches from the last days_back days with their topics"""
+
+        end_date = date.today()
+        start_date = end_date - timedelta(days=days_back)
+
+        print("🎤 SPEECHES POPULATION")
+        print("=" * 60)
+        print(f"Plenary speeches and topics from {start_date} to {end_date}")
+        print()
+
+        DependencyChecker.print_dependency_warning(
+            required_steps=["politicians"],
+            current_step="SPEECHES POPULATION"
+        )
+
+        query = "SELECT id, deputy_id, nome_civil FROM unified_politicians WHERE deputy_id IS NOT NULL"
+        params = None
+        if politician_ids:
+            query += " AND id = ANY(%s)"
+            params = (politician_ids,)
+        query += " ORDER BY id"
+        if limit:
+            query += f" LIMIT {int(limit)}"
+
+        politicians = database.execute_query(query, params)
+
+        print(f"👥 Processing {len(politicians)} politicians with deputy_id")
+        print()
+
+        total_speeches = 0
+        total_topics = 0
+        processed = 0
+
+        for i, politician in enumerate(politicians, 1):
+            print(f"🏛️ [{i}/{len(politicians)}] {politician['nome_civil']}")
+            try:
+                speeches = self._fetch_speeches(politician['deputy_id'], start_date, end_date)
+                topics = 0
+                for speech in speeches:
+                    topics += self._store_speech(politician, speech)
+
+                total_speeches += len(speeches)
+                total_topics += topics
+                processed += 1
+
+                print(f"  ✅ {len(speeches)} speeches, {topics} topics")
+                self.logger.log_processing(
+                    'speeches', str(politician['id']), 'success',
+                    {'speeches': len(speeches), 'topics': topics}
+                )
+
+            except Exception as e:
+                print(f"  ❌ Error: {e}")
+                self.logger.log_processing('speeches', str(politician['id']), 'error', {'error': str(e)})
+                continue
+
+        print(f"\n✅ Speeches population completed")
+        print(f"📊 {total_speeches} speeches, {total_topics} speech topics")
+        print(f"👥 {processed}/{len(politicians)} politicians processed")
+
+        return total_speeches
+
+    def _fetch_speeches(self, deputy_id: int, start_date: date, end_date: date) -> List[Dict]:
+        """Fetch every speech in the period with rate limiting"""
+        self.rate_limiter.wait_if_needed('camara')
+
+        try:
+            start_time = time.time()
+            speeches = self.deputados_client.get_deputy_discourses(
+                deputy_id, start_date.isoformat(), end_date.isoformat()
+            )
+            self.logger.log_api_call('camara', f'discursos/{deputy_id}', 'success', time.time() - start_time)
+            return [s for s in speeches if s.get('dataHoraInicio')]
+        except Exception:
+            self.logger.log_api_call('camara', f'discursos/{deputy_id}', 'error', 0)
+            raise
+
+    def _store_speech(self, politician: Dict, speech: Dict) -> int:
+        """Upsert one speech and replace its topics; returns the number of topics"""
+        phase = speech.get('faseEvento') or {}
+        rows = database.execute_insert_returning(
+            """
+            INSERT INTO politician_speeches (
+                politician_id, deputy_id, speech_datetime, event_phase, speech_type,
+                keywords, summary, transcript, text_url
+            )
+            VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+            ON CONFLICT (deputy_id, speech_datetime) DO UPDATE SET
+                event_phase = EXCLUDED.event_phase,
+                speech_type = EXCLUDED.speech_type,
+                keywords = EXCLUDED.keywords,
+                summary = EXCLUDED.summary,
+                transcript = EXCLUDED.transcript,
+                text_url = EXCLUDED.text_url,
+                fetched_at = CURRENT_TIMESTAMP
+            RETURNING id
+            """,
+            (
+                politician['id'], politician['deputy_id'], speech['dataHoraInicio'],
+                (phase.get('titulo') or '')[:255] or None, (speech.get('tipoDiscurso') or '')[:100] or None,
+                speech.get('keywords'), speech.get('sumario'), speech.get('transcricao'),
+                speech.get('urlTexto'),
+            )
+        )
+        speech_id = rows[0]['id']
+
+        topics = extract_topics(speech.get('keywords'), speech.get('sumario'), speech.get('transcricao'))
+        database.execute_update("DELETE FROM speech_topics WHERE speech_id = %s", (speech_id,))
+        for topic in topics:
+            database.execute_update(
+                """
+                INSERT INTO speech_topics (speech_id, topic_slug, topic, source, weight)
+                VALUES (%s, %s, %s, %s, %s)
+                ON CONFLICT (speech_id, topic_slug) DO NOTHING
+                """,
+                (speech_id, topic['slug'], topic['topic'][:100], topic['source'], topic['weight'])
+            )
+        return len(topics)
@@ -0,0 +1,6 @@
+# Parliamentary Staff Populator Module
+
+from .populator import StaffPopulator
+from .surnames import surnames, shared_surnames
+
+__all__ = ['StaffPopulator', 'surnames', 'shared_surnames']
@@ -0,0 +1,197 @@
+"""
+CLI4 Staff Populator
+Populate parliamentary_staff with each deputy's cabinet staff (secretários parlamentares) and
+nepotism_flags with staff who share uncommon family names with their employer or with another
+politician of the same state, which the API serves at /api/analysis/nepotism
+"""
+
+import re
+import time
+from datetime import datetime
+from typing import Dict, List, Optional
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+from cli4.modules.dependency_checker import DependencyChecker
+from src.clients.deputados_client import DeputadosClient
+from src.validation.normalization import normalize_name
+from .surnames import surnames, shared_surnames
+
+DATE = re.compile(r'(\d{2})/(\d{2})/(\d{4})')
+
+
+class StaffPopulator:
+    """Populate parliamentary_staff and nepotism_flags"""
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+        self.deputados_client = DeputadosClient()
+
+    def populate(self, politician_ids: Optional[List[int]] = None, limit: Optional[int] = None,
+                 year: Optional[int] = None) -> int:
+        """Load the staff roster of each deputy for year (default: current), then recompute
+        nepotism flags over every roster loaded so far"""
+
+        print("👔 PARLIAMENTARY STAFF POPULATION")
+        print("=" * 60)
+        print(f"Cabinet staff rosters for {year or datetime.now().year} and nepotism cross-check")
+        print()
+
+        DependencyChecker.print_dependency_warning(
+            required_steps=["politicians"],
+            current_step="STAFF POPULATION"
+        )
+
+        query = "SELECT id, deputy_id, nome_civil FROM unified_politicians WHERE deputy_id IS NOT NULL"
+        params = None
+        if politician_ids:
+            query += " AND id = ANY(%s)"
+            params = (politician_ids,)
+        query += " ORDER BY id"
+        if limit:
+            query += f" LIMIT {int(limit)}"
+
+        politicians = database.execute_query(query, params)
+
+        print(f"👥 Processing {len(politicians)} politicians with deputy_id")
+        print()
+
+        total_staff = 0
+        processed = 0
+
+        for i, politician in enumerate(politicians, 1):
+            print(f"🏛️ [{i}/{len(politicians)}] {politician['nome_civil']}")
+            try:
+                staff = self._fetch_staff(politician['deputy_id'], year)
+                stored = sum(self._store_staff(politician, member) for member in staff)
+
+                total_staff += stored
+                processed += 1
+
+                print(f"  ✅ {stored} staff members")
+                self.logger.log_processing('staff', str(politician['id']), 'success', {'staff': stored})
+
+            except Exception as e:
+                print(f"  ❌ Error: {e}")
+                self.logger.log_processing('staff', str(politician['id']), 'error', {'error': str(e)})
+                continue
+
+        flags = self._flag_nepotism()
+
+        print(f"\n✅ Staff population completed")
+        print(f"📊 {total_staff} staff records")
+        print(f"🚩 {flags} possible nepotism flags")
+        print(f"👥 {processed}/{len(politicians)} politicians processed")
+
+        return total_staff
+
+    def _fetch_staff(self, deputy_id: int, year: Optional[int]) -> List[Dict]:
+        """Fetch the deputy's staff roster with rate limiting"""
+        self.rate_limiter.wait_if_needed('camara')
+
+        try:
+            start_time = time.time()
+            staff = self.deputados_client.get_deputy_staff(deputy_id, year)
+            self.logger.log_api_call('camara', f'pessoal-gabinete/{deputy_id}', 'success', time.time() - start_time)
+            return [s for s in staff if s.get('nome')]
+        except Exception:
+            self.logger.log_api_call('camara', f'pessoal-gabinete/{deputy_id}', 'error', 0)
+            raise
+
+    def _store_staff(self, politician: Dict, member: Dict) -> int:
+        """Upsert one staff record; returns 0 when its period can't be read"""
+        dates = [f"{y}-{m}-{d}" for d, m, y in DATE.findall(member.get('periodo') or '')]
+        if not dates:
+            print(f"    ⚠️ Skipping {member['nome']}: unreadable period '{member.get('periodo')}'")
+            return 0
+
+        database.execute_update(
+            """
+            INSERT INTO parliamentary_staff (
+                politician_id, deputy_id, name, normalized_name, functional_group,
+                position, start_date, end_date
+            )
+            VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+            ON CONFLICT (deputy_id, normalized_name, start_date) DO UPDATE SET
+                functional_group = EXCLUDED.functional_group,
+                position = EXCLUDED.position,
+                end_date = EXCLUDED.end_date,
+                fetched_at = CURRENT_TIMESTAMP
+            """,
+            (
+                politician['id'], politician['deputy_id'], member['nome'][:255],
+                normalize_name(member['nome'])[:255], (member.get('grupo') or '')[:100] or None,
+                (member.get('cargo') or '')[:100] or None, dates[0],
+                dates[1] if len(dates) > 1 else None,
+            )
+        )
+        return 1
+
+    def _flag_nepotism(self) -> int:
+        """Replace nepotism_flags: staff sharing uncommon family names with the deputy who hired
+        them (same_office) or with another politician of that deputy's state (cross_office, the
+        "nepotismo cruzado" of swapping relatives between offices)"""
+        staff = database.execute_query(
+            """
+            SELECT s.id, s.name, s.politician_id, p.nome_civil, p.current_state
+            FROM parliamentary_staff s
+            JOIN unified_politicians p ON p.id = s.politician_id
+            """
+        )
+        politicians = database.execute_query(
+            "SELECT id, nome_civil, current_state FROM unified_politicians WHERE nome_civil IS NOT NULL"
+        )
+
+        # Only politicians with an uncommon family name can match; index them by those names
+        by_surname: Dict[str, List[Dict]] = {}
+        for politician in politicians:
+            for surname in set(surnames(politician['nome_civil'])):
+                by_surname.setdefault(surname, []).append(politician)
+
+        flags = []
+        for member in staff:
+            candidates = {}
+            for surname in surnames(member['name']):
+                for politician in by_surname.get(surname, []):
+                    candidates[politician['id']] = politician
+
+            for politician in candidates.values():
+                if politician['id'] == member['politician_id']:
+                    match_type = 'same_office'
+                elif politician['current_state'] and politician['current_state'] == member['current_state']:
+                    match_type = 'cross_office'
+                else:
+                    continue
+
+                shared = shared_surnames(member['name'], politician['nome_civil'])
+                if not shared:
+                    continue
+                flags.append((member['id'], member['politician_id'], politician['id'], match_type,
+                              ' '.join(shared), self._score(member['name'], politician['nome_civil'], shared)))
+
+        database.execute_update("DELETE FROM nepotism_flags")
+        for flag in flags:
+            database.execute_update(
+                """
+                INSERT INTO nepotism_flags (
+                    staff_id, employer_politician_id, related_politician_id, match_type,
+                    shared_surnames, score
+                )
+                VALUES (%s, %s, %s, %s, %s, %s)
+                ON CONFLICT (staff_id, related_politician_id) DO NOTHING
+                """,
+                flag
+            )
+
+        return len(flags)
+
+    @staticmethod
+    def _score(staff_name: str, politician_name: str, shared: List[str]) -> float:
+        """0.5 per shared uncommon family name, plus 0.25 when both end in the same one (the
+        paternal name, passed down most reliably), capped at 1"""
+        score = 0.5 * len(shared)
+        staff_names, politician_names = surnames(staff_name), surnames(politician_name)
+        if staff_names and politician_names and staff_names[-1] == politician_names[-1] and staff_names[-1] in shared:
+            score += 0.25
+        return round(min(score, 1.0), 2)
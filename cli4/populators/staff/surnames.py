@@ -0,0 +1,49 @@
+"""
+Surname matching for kinship heuristics
+Brazilian names carry the mother's and father's family names, usually last. Two people sharing
+an uncommon family name are worth a look; sharing SILVA or SANTOS says nothing.
+"""
+
+from typing import List, Set
+
+from src.validation.normalization import normalize_name
+
+# Particles and generational suffixes are not family names
+PARTICLES = {'DE', 'DA', 'DO', 'DAS', 'DOS', 'E', 'DI', 'DEL', 'VAN', 'VON'}
+SUFFIXES = {'FILHO', 'FILHA', 'JUNIOR', 'JR', 'NETO', 'NETA', 'SOBRINHO', 'SOBRINHA', 'SEGUNDO', 'II', 'III'}
+
+# The most frequent family names in Brazil, shared by millions of unrelated people
+COMMON_SURNAMES = set("""
+SILVA SANTOS OLIVEIRA SOUZA SOUSA RODRIGUES FERREIRA ALVES PEREIRA LIMA GOMES COSTA RIBEIRO
+MARTINS CARVALHO ALMEIDA LOPES SOARES FERNANDES VIEIRA BARBOSA ROCHA DIAS NASCIMENTO ANDRADE
+MOREIRA NUNES MARQUES MACHADO MENDES FREITAS CARDOSO RAMOS GONCALVES SANTANA TEIXEIRA ARAUJO
+MELO MELLO CAVALCANTI CAVALCANTE MOURA CASTRO PINTO CORREIA CORREA BATISTA MONTEIRO CAMPOS
+JESUS MIRANDA MATOS REIS BEZERRA SALES PAIVA BARROS FARIAS FARIA AZEVEDO MEDEIROS NOGUEIRA
+""".split())
+
+# Given names that often come second in compound first names (Ana Maria, José Carlos)
+GIVEN_NAMES = set("""
+MARIA JOSE JOAO ANA ANTONIO CARLOS PAULO LUIZ LUIS FRANCISCO PEDRO CESAR EDUARDO HENRIQUE
+AUGUSTO CRISTINA APARECIDA FERNANDO RICARDO ROBERTO ALBERTO LUCIA HELENA TERESA TEREZA
+RAIMUNDO MANOEL MANUEL MIGUEL RAFAEL GABRIEL VITOR VICTOR CLAUDIA PATRICIA FATIMA
+""".split())
+
+
+def surnames(name: str) -> List[str]:
+    """Family names of a full name: every word after the first, without particles, suffixes and
+    the given names of compound first names"""
+    words = normalize_name(name).split()[1:]
+    return [w for w in words
+            if w not in PARTICLES and w not in SUFFIXES and w not in GIVEN_NAMES and len(w) >= 3]
+
+
+def shared_surnames(name_a: str, name_b: str) -> List[str]:
+    """Uncommon family names two people share, in the order they appear in name_a"""
+    other: Set[str] = set(surnames(name_b))
+    seen: Set[str] = set()
+    shared = []
+    for surname in surnames(name_a):
+        if surname in other and surname not in COMMON_SURNAMES and surname not in seen:
+            seen.add(surname)
+            shared.append(surname)
+    return shared
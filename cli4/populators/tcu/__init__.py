@@ -1,6 +1,7 @@
-# TCU Disqualifications Populator Module
+# TCU Disqualifications and Rulings Populator Module
 
 from .populator import TCUPopulator
 from .validator import TCUValidator
+from .rulings import TCURulingsPopulator
 
-__all__ = ['TCUPopulator', 'TCUValidator']
\ No newline at end of file
+__all__ = ['TCUPopulator', 'TCUValidator', 'TCURulingsPopulator']
\ No newline at end of file
@@ -296,6 +296,7 @@ class TCUPopulator:
                 # ON CONFLICT UPDATE approach
                 update_fields = [f"{field} = EXCLUDED.{field}" for field in fields
                                if field not in ['cpf', 'processo', 'deliberacao']]
+                update_fields.append("fetched_at = CURRENT_TIMESTAMP")
 
                 sql = f"""
                     INSERT INTO tcu_disqualifications ({', '.join(fields)})
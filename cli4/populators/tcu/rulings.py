@@ -0,0 +1,197 @@
+"""
+CLI4 TCU Rulings Populator
+Populate tcu_rulings with TCU acórdãos that name a company or politician in our dataset, and
+tcu_ruling_parties with who they name. Rulings are matched by the CNPJs and CPFs in their full
+text; the API serves them at /api/tcu/rulings and as tcu_ruling nodes in the network.
+"""
+
+import re
+import time
+from datetime import datetime, date
+from typing import Dict, List, Optional, Set, Tuple
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+from cli4.modules.dependency_checker import DependencyChecker
+from src.clients.tcu_client import TCUClient
+
+# CNPJs appear formatted or not; CPFs only formatted, since any 11 digits would match otherwise
+CNPJ = re.compile(r'(?<![\d.])(\d{2}\.?\d{3}\.?\d{3}/?\d{4}-?\d{2})(?![\d])')
+CPF = re.compile(r'(?<![\d.])(\d{3}\.\d{3}\.\d{3}-\d{2})(?![\d])')
+
+
+class TCURulingsPopulator:
+    """Populate tcu_rulings and tcu_ruling_parties"""
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+        self.tcu_client = TCUClient()
+
+    def populate(self, max_pages: int = 20, page_size: int = 100, since: Optional[str] = None) -> int:
+        """Walk acórdãos from the most recent back to since (YYYY-MM-DD) or max_pages pages,
+        keeping those that name a company or politician we track"""
+
+        print("⚖️  TCU RULINGS POPULATION")
+        print("=" * 60)
+        print("Federal Audit Court acórdãos naming companies and politicians in our dataset")
+        print()
+
+        DependencyChecker.print_dependency_warning(
+            required_steps=["politicians", "financial"],
+            current_step="TCU RULINGS POPULATION"
+        )
+
+        since_date = datetime.strptime(since, '%Y-%m-%d').date() if since else None
+
+        companies = {row['cnpj_cpf'] for row in database.execute_query(
+            "SELECT cnpj_cpf FROM financial_counterparts WHERE entity_type = 'COMPANY' AND LENGTH(cnpj_cpf) = 14"
+        )}
+        politicians = {row['cpf']: row['id'] for row in database.execute_query(
+            "SELECT id, cpf FROM unified_politicians WHERE cpf IS NOT NULL AND LENGTH(cpf) = 11"
+        )}
+        print(f"🎯 Matching against {len(companies):,} company CNPJs and {len(politicians):,} politician CPFs")
+        print()
+
+        scanned = 0
+        stored = 0
+        done = False
+
+        for page in range(max_pages):
+            inicio = page * page_size
+            print(f"📄 Page {page + 1}/{max_pages} (inicio: {inicio})")
+
+            try:
+                acordaos = self._fetch_page(inicio, page_size)
+            except Exception as e:
+                print(f"   ❌ Error: {e}")
+                continue
+
+            if not acordaos:
+                break
+
+            for acordao in acordaos:
+                session_date = self._parse_date(acordao.get('dataSessao'))
+                if since_date and session_date and session_date < since_date:
+                    done = True
+                    break
+
+                scanned += 1
+                try:
+                    parties = self._match_parties(acordao, companies, politicians)
+                    if parties:
+                        self._store_ruling(acordao, session_date, parties)
+                        stored += 1
+                        print(f"   ✅ {acordao.get('titulo') or acordao.get('key')}: {len(parties)} parties")
+                except Exception as e:
+                    print(f"   ⚠️ Error processing {acordao.get('key')}: {e}")
+                    self.logger.log_processing('tcu_rulings', str(acordao.get('key')), 'error', {'error': str(e)})
+
+            if done:
+                print(f"   📅 Reached rulings before {since}")
+                break
+
+        print(f"\n✅ TCU rulings population completed")
+        print(f"📋 {scanned} acórdãos scanned")
+        print(f"⚖️  {stored} rulings naming tracked companies or politicians")
+
+        return stored
+
+    def _fetch_page(self, inicio: int, quantidade: int) -> List[Dict]:
+        """Fetch a page of acórdãos with rate limiting"""
+        self.rate_limiter.wait_if_needed('tcu')
+
+        try:
+            start_time = time.time()
+            acordaos = self.tcu_client.list_acordaos(inicio, quantidade)
+            self.logger.log_api_call('tcu', f'acordao/recupera-acordaos/{inicio}', 'success', time.time() - start_time)
+            return acordaos
+        except Exception:
+            self.logger.log_api_call('tcu', f'acordao/recupera-acordaos/{inicio}', 'error', 0)
+            raise
+
+    def _match_parties(self, acordao: Dict, companies: Set[str],
+                       politicians: Dict[str, int]) -> List[Tuple[str, Optional[int]]]:
+        """(cnpj_cpf, politician_id) of every tracked company or politician named in the ruling"""
+        text = ' '.join(str(acordao.get(field) or '') for field in ('titulo', 'sumario'))
+
+        document_url = acordao.get('urlArquivo')
+        if document_url:
+            self.rate_limiter.wait_if_needed('tcu')
+            text += ' ' + self.tcu_client.get_acordao_text(document_url)
+
+        parties = {}
+        for match in CNPJ.findall(text):
+            cnpj = re.sub(r'\D', '', match)
+            if cnpj in companies:
+                parties[cnpj] = None
+        for match in CPF.findall(text):
+            cpf = re.sub(r'\D', '', match)
+            if cpf in politicians:
+                parties[cpf] = politicians[cpf]
+
+        return list(parties.items())
+
+    def _store_ruling(self, acordao: Dict, session_date: Optional[date],
+                      parties: List[Tuple[str, Optional[int]]]):
+        """Upsert a ruling and the parties it names"""
+        rows = database.execute_insert_returning(
+            """
+            INSERT INTO tcu_rulings (
+                ruling_key, numero, ano, colegiado, relator, session_date, titulo, sumario,
+                url, document_url
+            )
+            VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+            ON CONFLICT (ruling_key) DO UPDATE SET
+                relator = EXCLUDED.relator,
+                titulo = EXCLUDED.titulo,
+                sumario = EXCLUDED.sumario,
+                url = EXCLUDED.url,
+                document_url = EXCLUDED.document_url,
+                updated_at = CURRENT_TIMESTAMP,
+                fetched_at = CURRENT_TIMESTAMP
+            RETURNING id
+            """,
+            (
+                str(acordao.get('key'))[:100],
+                self._int(acordao.get('numeroAcordao')),
+                self._int(acordao.get('anoAcordao')),
+                (acordao.get('colegiado') or '')[:50] or None,
+                (acordao.get('relator') or '')[:255] or None,
+                session_date,
+                acordao.get('titulo'),
+                acordao.get('sumario'),
+                acordao.get('urlAcordao'),
+                acordao.get('urlArquivoPdf') or acordao.get('urlArquivo'),
+            )
+        )
+        ruling_id = rows[0]['id']
+
+        for cnpj_cpf, politician_id in parties:
+            database.execute_update(
+                """
+                INSERT INTO tcu_ruling_parties (ruling_id, cnpj_cpf, entity_type, politician_id)
+                VALUES (%s, %s, %s, %s)
+                ON CONFLICT (ruling_id, cnpj_cpf) DO NOTHING
+                """,
+                (ruling_id, cnpj_cpf, 'POLITICIAN' if politician_id else 'COMPANY', politician_id)
+            )
+
+    @staticmethod
+    def _parse_date(value: Optional[str]) -> Optional[date]:
+        """dd/mm/yyyy or ISO dates"""
+        if not value:
+            return None
+        for fmt in ('%d/%m/%Y', '%Y-%m-%d'):
+            try:
+                return datetime.strptime(value[:10], fmt).date()
+            except ValueError:
+                continue
+        return None
+
+    @staticmethod
+    def _int(value) -> Optional[int]:
+        try:
+            return int(value)
+        except (TypeError, ValueError):
+            return None
@@ -439,7 +439,8 @@ class CLI4WealthPopulator:
                         business_value = EXCLUDED.business_value,
                         cash_deposits_value = EXCLUDED.cash_deposits_value,
                         other_assets_value = EXCLUDED.other_assets_value,
-                        updated_at = CURRENT_TIMESTAMP
+                        updated_at = CURRENT_TIMESTAMP,
+                        fetched_at = CURRENT_TIMESTAMP
                 """
 
                 result = database.execute_update(sql, tuple(values))
@@ -0,0 +1,5 @@
+# Wikidata Enrichment Populator Module
+
+from .populator import WikidataPopulator
+
+__all__ = ['WikidataPopulator']
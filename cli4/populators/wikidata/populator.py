@@ -0,0 +1,288 @@
+"""
+CLI4 Wikidata Populator
+Link politicians to Wikidata items (matched by name + birth date) and store their
+Wikipedia links, aliases and positions held in politician_wikidata
+"""
+
+import json
+import time
+import requests
+from datetime import datetime
+from typing import Dict, List, Optional
+from cli4.modules import database
+from cli4.modules.logger import CLI4Logger
+from cli4.modules.rate_limiter import CLI4RateLimiter
+from cli4.modules.dependency_checker import DependencyChecker
+
+
+class WikidataPopulator:
+    """Populate politician_wikidata with QIDs and enrichment from Wikidata"""
+
+    API_URL = "https://www.wikidata.org/w/api.php"
+    USER_AGENT = "open-data-gov/1.0 (https://github.com/fcavalcantirj/open-data-gov)"
+
+    HUMAN = 'Q5'
+    INSTANCE_OF = 'P31'
+    BIRTH_DATE = 'P569'
+    POSITION_HELD = 'P39'
+    START_TIME = 'P580'
+    END_TIME = 'P582'
+    OF = 'P642'
+    ELECTORAL_DISTRICT = 'P768'
+
+    def __init__(self, logger: CLI4Logger, rate_limiter: CLI4RateLimiter):
+        self.logger = logger
+        self.rate_limiter = rate_limiter
+        self.session = requests.Session()
+        self.session.headers['User-Agent'] = self.USER_AGENT
+        self._label_cache: Dict[str, str] = {}
+
+    def populate(self, limit: Optional[int] = None, update_existing: bool = False) -> int:
+        """Match politicians with a birth date and upsert their Wikidata enrichment"""
+
+        print("🌐 WIKIDATA ENRICHMENT")
+        print("=" * 60)
+        print("Wikipedia links, aliases and previous offices matched by name + birth date")
+        print()
+
+        DependencyChecker.print_dependency_warning(
+            required_steps=["politicians"],
+            current_step="WIKIDATA ENRICHMENT"
+        )
+
+        query = """
+            SELECT p.id, p.nome_civil, p.nome_eleitoral, p.birth_date
+            FROM unified_politicians p
+            WHERE p.birth_date IS NOT NULL
+        """
+        if not update_existing:
+            query += " AND NOT EXISTS (SELECT 1 FROM politician_wikidata w WHERE w.politician_id = p.id)"
+        query += " ORDER BY p.id"
+        if limit:
+            query += f" LIMIT {int(limit)}"
+
+        politicians = database.execute_query(query)
+        print(f"👥 Processing {len(politicians)} politicians with a birth date")
+        print()
+
+        start_time = time.time()
+        matched = 0
+        ambiguous = 0
+
+        for i, politician in enumerate(politicians, 1):
+            print(f"🔎 [{i}/{len(politicians)}] {politician['nome_civil']} ({politician['birth_date']})")
+            try:
+                match = self._match_politician(politician)
+                if match is None:
+                    print("  ⚠️ No Wikidata item with this name and birth date")
+                    self.logger.log_processing('wikidata', str(politician['id']), 'skipped', {'reason': 'no_match'})
+                    continue
+                if match == 'ambiguous':
+                    ambiguous += 1
+                    print("  ⚠️ Several Wikidata items match; skipped")
+                    self.logger.log_processing('wikidata', str(politician['id']), 'skipped', {'reason': 'ambiguous'})
+                    continue
+
+                entity, method = match
+                record = self._build_record(politician['id'], entity, method)
+                self._upsert(record)
+                matched += 1
+
+                print(f"  ✅ {record['qid']} - {len(record['previous_offices'])} positions, {len(record['aliases'])} aliases")
+                self.logger.log_processing('wikidata', str(politician['id']), 'success', {'qid': record['qid']})
+
+            except Exception as e:
+                print(f"  ❌ Error: {e}")
+                self.logger.log_processing('wikidata', str(politician['id']), 'error', {'error': str(e)})
+                continue
+
+        elapsed_time = time.time() - start_time
+        print(f"\n✅ Wikidata enrichment completed")
+        print(f"📊 {matched}/{len(politicians)} politicians linked ({ambiguous} ambiguous)")
+        print(f"⏱️  Total time: {elapsed_time:.1f} seconds")
+
+        return matched
+
+    def _match_politician(self, politician: Dict):
+        """Return (entity, match_method) for the single human item with the politician's
+        name and birth date, 'ambiguous' when several match, or None"""
+        birth_date = politician['birth_date'].strftime('%Y-%m-%d')
+
+        names = [('nome_civil', politician['nome_civil'])]
+        if politician.get('nome_eleitoral') and politician['nome_eleitoral'] != politician['nome_civil']:
+            names.append(('nome_eleitoral', politician['nome_eleitoral']))
+
+        for field, name in names:
+            candidates = self._search(name)
+            if not candidates:
+                continue
+
+            entities = self._get_entities(candidates, 'claims|sitelinks|aliases|info')
+            matches = [e for e in entities.values() if self._is_human(e) and self._birth_date(e) == birth_date]
+            if len(matches) == 1:
+                return matches[0], f"{field}+birth_date"
+            if len(matches) > 1:
+                return 'ambiguous'
+
+        return None
+
+    def _search(self, name: str) -> List[str]:
+        """Search items by label or alias"""
+        data = self._api({
+            'action': 'wbsearchentities',
+            'search': name,
+            'language': 'pt',
+            'type': 'item',
+            'limit': 10,
+        }, 'wbsearchentities')
+        return [result['id'] for result in data.get('search', [])]
+
+    def _get_entities(self, ids: List[str], props: str) -> Dict[str, Dict]:
+        """Fetch entities in batches of 50 (the API maximum)"""
+        entities = {}
+        for i in range(0, len(ids), 50):
+            data = self._api({
+                'action': 'wbgetentities',
+                'ids': '|'.join(ids[i:i + 50]),
+                'props': props,
+                'languages': 'pt|en',
+            }, 'wbgetentities')
+            entities.update({k: v for k, v in data.get('entities', {}).items() if 'missing' not in v})
+        return entities
+
+    def _api(self, params: Dict, endpoint: str) -> Dict:
+        """Call the Wikidata API with rate limiting"""
+        wait_time = self.rate_limiter.wait_if_needed('wikidata')
+        if wait_time > 0:
+            print(f"    ⏰ Rate limiting: waited {wait_time:.1f}s")
+
+        params = dict(params, format='json')
+        try:
+            api_start = time.time()
+            response = self.session.get(self.API_URL, params=params, timeout=30)
+            response.raise_for_status()
+            self.logger.log_api_call('wikidata', endpoint, 'success', time.time() - api_start)
+            return response.json()
+        except Exception:
+            self.logger.log_api_call('wikidata', endpoint, 'error', 0)
+            raise
+
+    def _claims(self, entity: Dict, prop: str) -> List[Dict]:
+        return [c for c in entity.get('claims', {}).get(prop, []) if c.get('rank') != 'deprecated']
+
+    def _item_value(self, snak: Dict) -> Optional[str]:
+        value = snak.get('datavalue', {}).get('value')
+        return value.get('id') if isinstance(value, dict) else None
+
+    def _time_value(self, snak: Dict) -> Optional[str]:
+        """Render a time snak as YYYY-MM-DD, YYYY-MM or YYYY depending on its precision"""
+        value = snak.get('datavalue', {}).get('value')
+        if not isinstance(value, dict) or 'time' not in value:
+            return None
+        date = value['time'].lstrip('+')[:10]
+        precision = value.get('precision', 11)
+        if precision >= 11:
+            return date
+        if precision == 10:
+            return date[:7]
+        return date[:4]
+
+    def _is_human(self, entity: Dict) -> bool:
+        return any(self._item_value(c['mainsnak']) == self.HUMAN for c in self._claims(entity, self.INSTANCE_OF))
+
+    def _birth_date(self, entity: Dict) -> Optional[str]:
+        for claim in self._claims(entity, self.BIRTH_DATE):
+            date = self._time_value(claim['mainsnak'])
+            if date and len(date) == 10:
+                return date
+        return None
+
+    def _build_record(self, politician_id: int, entity: Dict, method: str) -> Dict:
+        """Extract the stored fields from a matched entity"""
+        sitelinks = entity.get('sitelinks', {})
+
+        aliases = []
+        for lang in ('pt', 'en'):
+            for alias in entity.get('aliases', {}).get(lang, []):
+                if alias['value'] not in aliases:
+                    aliases.append(alias['value'])
+
+        positions = []
+        for claim in self._claims(entity, self.POSITION_HELD):
+            position_qid = self._item_value(claim['mainsnak'])
+            if not position_qid:
+                continue
+            qualifiers = claim.get('qualifiers', {})
+            first = lambda prop: (qualifiers.get(prop) or [{}])[0]
+            positions.append({
+                'position_qid': position_qid,
+                'start': self._time_value(first(self.START_TIME)),
+                'end': self._time_value(first(self.END_TIME)),
+                'of_qid': self._item_value(first(self.OF)) or self._item_value(first(self.ELECTORAL_DISTRICT)),
+            })
+
+        labels = self._labels([p['position_qid'] for p in positions] + [p['of_qid'] for p in positions if p['of_qid']])
+        offices = []
+        for p in positions:
+            offices.append({
+                'qid': p['position_qid'],
+                'position': labels.get(p['position_qid'], p['position_qid']),
+                'of': labels.get(p['of_qid']) if p['of_qid'] else None,
+                'start': p['start'],
+                'end': p['end'],
+            })
+        offices.sort(key=lambda o: o['start'] or '')
+
+        return {
+            'politician_id': politician_id,
+            'qid': entity['id'],
+            'wikipedia_pt': self._sitelink_url(sitelinks.get('ptwiki'), 'pt'),
+            'wikipedia_en': self._sitelink_url(sitelinks.get('enwiki'), 'en'),
+            'aliases': aliases,
+            'previous_offices': offices,
+            'match_method': method,
+            'revision_id': entity.get('lastrevid'),
+            'retrieved_at': datetime.now(),
+        }
+
+    def _labels(self, qids: List[str]) -> Dict[str, str]:
+        """Resolve item labels (Portuguese, falling back to English), cached across politicians"""
+        missing = sorted({q for q in qids if q not in self._label_cache})
+        if missing:
+            for qid, entity in self._get_entities(missing, 'labels').items():
+                labels = entity.get('labels', {})
+                label = labels.get('pt') or labels.get('en')
+                self._label_cache[qid] = label['value'] if label else qid
+        return {q: self._label_cache.get(q, q) for q in qids}
+
+    def _sitelink_url(self, sitelink: Optional[Dict], lang: str) -> Optional[str]:
+        if not sitelink:
+            return None
+        return f"https://{lang}.wikipedia.org/wiki/{sitelink['title'].replace(' ', '_')}"
+
+    def _upsert(self, record: Dict):
+        database.execute_update(
+            """
+            INSERT INTO politician_wikidata (
+                politician_id, qid, wikipedia_pt, wikipedia_en, aliases, previous_offices,
+                match_method, revision_id, retrieved_at
+            )
+            VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+            ON CONFLICT (politician_id) DO UPDATE SET
+                qid = EXCLUDED.qid,
+                wikipedia_pt = EXCLUDED.wikipedia_pt,
+                wikipedia_en = EXCLUDED.wikipedia_en,
+                aliases = EXCLUDED.aliases,
+                previous_offices = EXCLUDED.previous_offices,
+                match_method = EXCLUDED.match_method,
+                revision_id = EXCLUDED.revision_id,
+                retrieved_at = EXCLUDED.retrieved_at,
+                updated_at = CURRENT_TIMESTAMP,
+                fetched_at = CURRENT_TIMESTAMP
+            """,
+            (
+                record['politician_id'], record['qid'], record['wikipedia_pt'], record['wikipedia_en'],
+                record['aliases'], json.dumps(record['previous_offices'], ensure_ascii=False),
+                record['match_method'], record['revision_id'], record['retrieved_at'],
+            )
+        )
@@ -7,9 +7,14 @@ Complete reset for testing duplicate prevention
 import psycopg2
 import psycopg2.extras
 import os
+import sys
+from pathlib import Path
 from datetime import datetime
 from dotenv import load_dotenv
 
+sys.path.insert(0, str(Path(__file__).resolve().parents[2]))
+from cli4.modules.provenance import ensure_provenance_columns
+
 # Load environment variables from .env file
 load_dotenv()
 
@@ -28,6 +33,22 @@ def recreate_all_tables():
 
     # Drop all tables in correct order (dependencies first)
     drop_tables = [
+        "DROP TABLE IF EXISTS expense_anomalies CASCADE",
+        "DROP TABLE IF EXISTS parliamentary_amendments CASCADE",
+        "DROP TABLE IF EXISTS ibge_areas CASCADE",
+        "DROP TABLE IF EXISTS public_loans CASCADE",
+        "DROP TABLE IF EXISTS tcu_ruling_parties CASCADE",
+        "DROP TABLE IF EXISTS tcu_rulings CASCADE",
+        "DROP TABLE IF EXISTS nepotism_flags CASCADE",
+        "DROP TABLE IF EXISTS parliamentary_staff CASCADE",
+        "DROP TABLE IF EXISTS speech_topics CASCADE",
+        "DROP TABLE IF EXISTS politician_speeches CASCADE",
+        "DROP TABLE IF EXISTS plenary_attendance CASCADE",
+        "DROP TABLE IF EXISTS company_partners CASCADE",
+        "DROP TABLE IF EXISTS party_membership_history CASCADE",
+        "DROP TABLE IF EXISTS party_funds CASCADE",
+        "DROP TABLE IF EXISTS politician_wikidata CASCADE",
+        "DROP TABLE IF EXISTS ipca_index CASCADE",
         "DROP TABLE IF EXISTS party_memberships CASCADE",
         "DROP TABLE IF EXISTS politician_assets CASCADE",
         "DROP TABLE IF EXISTS politician_professional_background CASCADE",
@@ -137,6 +158,12 @@ def recreate_all_tables():
         total_elections INTEGER,
         first_mandate_year INTEGER,
 
+        -- LATEST ELECTION (populate-electoral-results)
+        last_election_votes INTEGER,
+        last_election_elected BOOLEAN,
+        last_election_coalition VARCHAR(255),
+        last_election_spending DECIMAL(15,2),
+
         -- ENHANCED AGGREGATE METRICS (calculated fields)
         number_of_elections INTEGER DEFAULT 0,
         electoral_success_rate DECIMAL(5,2) DEFAULT 0.0,
@@ -154,6 +181,8 @@ def recreate_all_tables():
         sanctioned_vendors_count INTEGER DEFAULT 0,
         sanctioned_vendors_total_sanctions INTEGER DEFAULT 0,
         sanctioned_vendors_amount DECIMAL(15,2) DEFAULT 0.0,
+        geo_mismatch_vendors INTEGER DEFAULT 0,
+        geo_mismatch_amount DECIMAL(15,2) DEFAULT 0.0,
         corruption_risk_score DECIMAL(5,2) DEFAULT 0.0,
 
         -- FAMILY NETWORK ANALYSIS
@@ -179,6 +208,11 @@ def recreate_all_tables():
         parliamentary_event_types INTEGER DEFAULT 0,
         parliamentary_attendance_rate DECIMAL(5,2) DEFAULT 0.0,
 
+        -- PLENARY ATTENDANCE (deliberative sessions)
+        plenary_sessions_total INTEGER DEFAULT 0,
+        plenary_sessions_present INTEGER DEFAULT 0,
+        plenary_absence_rate DECIMAL(5,2),
+
         -- WEALTH PROGRESSION METRICS
         wealth_declarations_count INTEGER DEFAULT 0,
         wealth_first_year INTEGER,
@@ -187,6 +221,8 @@ def recreate_all_tables():
         wealth_highest_declared DECIMAL(15,2),
         wealth_average_declared DECIMAL(15,2),
         wealth_total_growth DECIMAL(15,2) DEFAULT 0.0,
+        wealth_max_growth_pct DECIMAL(10,2),
+        wealth_unusual_growth BOOLEAN DEFAULT FALSE,
         asset_types_diversity INTEGER DEFAULT 0,
         total_individual_assets INTEGER DEFAULT 0,
         total_individual_asset_value DECIMAL(15,2) DEFAULT 0.0,
@@ -219,12 +255,16 @@ def recreate_all_tables():
         -- COMPANY DETAILS
         trade_name VARCHAR(255),
         business_sector VARCHAR(100),
+        cnae_code VARCHAR(7),                  -- main activity (Receita), 7-digit subclass
+        cnae_description VARCHAR(255),
+        cnae_section CHAR(1),                  -- CNAE section letter (A-U)
         company_size VARCHAR(20),
         registration_status VARCHAR(50),
 
         -- GEOGRAPHIC INFORMATION
         state VARCHAR(10),
         municipality VARCHAR(255),
+        municipality_ibge_code VARCHAR(7),     -- IBGE code of the Receita registration municipality
 
         -- TRANSACTION SUMMARY
         total_transaction_amount DECIMAL(15,2) DEFAULT 0,
@@ -545,6 +585,7 @@ def recreate_all_tables():
             entity_name VARCHAR(500),
             sanction_type VARCHAR(100),
             sanction_description TEXT,
+            legal_basis TEXT,
             sanction_start_date DATE,
             sanction_end_date DATE,
             sanctioning_agency VARCHAR(255),
@@ -635,6 +676,249 @@ def recreate_all_tables():
             FOREIGN KEY (deputy_id) REFERENCES unified_politicians(deputy_id),
             CONSTRAINT unique_party_membership UNIQUE (party_id, deputy_id, legislatura_id)
         )
+        '''),
+        ('ipca_index', '''
+        CREATE TABLE ipca_index (
+            reference_month DATE PRIMARY KEY,
+            index_number DECIMAL(20,10) NOT NULL,
+            data_source VARCHAR(50) DEFAULT 'IBGE_SIDRA_1737',
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+        )
+        '''),
+        ('politician_wikidata', '''
+        CREATE TABLE politician_wikidata (
+            politician_id INTEGER PRIMARY KEY REFERENCES unified_politicians(id) ON DELETE CASCADE,
+            qid VARCHAR(20) NOT NULL,
+            wikipedia_pt TEXT,
+            wikipedia_en TEXT,
+            aliases TEXT[],
+            previous_offices JSONB,
+            match_method VARCHAR(50) NOT NULL,
+            revision_id BIGINT,
+            data_source VARCHAR(50) DEFAULT 'WIKIDATA',
+            retrieved_at TIMESTAMP NOT NULL,
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+        )
+        '''),
+        ('party_funds', '''
+        CREATE TABLE party_funds (
+            id SERIAL PRIMARY KEY,
+            party_sigla VARCHAR(20) NOT NULL,
+            year INTEGER NOT NULL,
+            month INTEGER NOT NULL DEFAULT 0,
+            fund_type VARCHAR(30) NOT NULL,
+            amount DECIMAL(15,2) NOT NULL,
+            data_source VARCHAR(50) DEFAULT 'TSE',
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            CONSTRAINT unique_party_fund UNIQUE (party_sigla, year, month, fund_type)
+        )
+        '''),
+        ('party_membership_history', '''
+        CREATE TABLE party_membership_history (
+            id SERIAL PRIMARY KEY,
+            politician_id INTEGER NOT NULL REFERENCES unified_politicians(id) ON DELETE CASCADE,
+            deputy_id INTEGER NOT NULL,
+            party_sigla VARCHAR(20) NOT NULL,
+            party_id INTEGER,
+            legislatura_id INTEGER,
+            start_date DATE NOT NULL,
+            end_date DATE,
+            start_event VARCHAR(500),
+            data_source VARCHAR(50) DEFAULT 'CAMARA_HISTORICO',
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            CONSTRAINT unique_party_history UNIQUE (deputy_id, party_sigla, start_date)
+        )
+        '''),
+        ('company_partners', '''
+        CREATE TABLE company_partners (
+            id SERIAL PRIMARY KEY,
+            cnpj_root CHAR(8) NOT NULL,
+            partner_type VARCHAR(20),
+            partner_name VARCHAR(255) NOT NULL,
+            partner_document VARCHAR(14) NOT NULL DEFAULT '',
+            qualification_code VARCHAR(3),
+            qualification VARCHAR(255),
+            entry_date DATE,
+            age_range VARCHAR(10),
+            data_source VARCHAR(50) DEFAULT 'RECEITA_QSA',
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            CONSTRAINT unique_company_partner UNIQUE (cnpj_root, partner_name, partner_document, qualification_code)
+        )
+        '''),
+        ('plenary_attendance', '''
+        CREATE TABLE plenary_attendance (
+            id SERIAL PRIMARY KEY,
+            politician_id INTEGER NOT NULL REFERENCES unified_politicians(id) ON DELETE CASCADE,
+            deputy_id INTEGER NOT NULL,
+            session_id INTEGER NOT NULL,
+            session_date DATE NOT NULL,
+            session_type VARCHAR(100),
+            present BOOLEAN NOT NULL,
+            data_source VARCHAR(50) DEFAULT 'CAMARA_EVENTOS',
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            CONSTRAINT unique_plenary_attendance UNIQUE (deputy_id, session_id)
+        )
+        '''),
+        ('politician_speeches', '''
+        CREATE TABLE politician_speeches (
+            id SERIAL PRIMARY KEY,
+            politician_id INTEGER NOT NULL REFERENCES unified_politicians(id) ON DELETE CASCADE,
+            deputy_id INTEGER NOT NULL,
+            speech_datetime TIMESTAMP NOT NULL,
+            event_phase VARCHAR(255),
+            speech_type VARCHAR(100),
+            keywords TEXT,
+            summary TEXT,
+            transcript TEXT,
+            text_url TEXT,
+            data_source VARCHAR(50) DEFAULT 'CAMARA_DISCURSOS',
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            CONSTRAINT unique_politician_speech UNIQUE (deputy_id, speech_datetime)
+        )
+        '''),
+        ('speech_topics', '''
+        CREATE TABLE speech_topics (
+            id SERIAL PRIMARY KEY,
+            speech_id INTEGER NOT NULL REFERENCES politician_speeches(id) ON DELETE CASCADE,
+            topic_slug VARCHAR(120) NOT NULL,
+            topic VARCHAR(100) NOT NULL,
+            source VARCHAR(20) NOT NULL,
+            weight DECIMAL(4,3) DEFAULT 1.0,
+            CONSTRAINT unique_speech_topic UNIQUE (speech_id, topic_slug)
+        )
+        '''),
+        ('parliamentary_staff', '''
+        CREATE TABLE parliamentary_staff (
+            id SERIAL PRIMARY KEY,
+            politician_id INTEGER NOT NULL REFERENCES unified_politicians(id) ON DELETE CASCADE,
+            deputy_id INTEGER NOT NULL,
+            name VARCHAR(255) NOT NULL,
+            normalized_name VARCHAR(255) NOT NULL,
+            functional_group VARCHAR(100),
+            position VARCHAR(100),
+            start_date DATE NOT NULL,
+            end_date DATE,
+            data_source VARCHAR(50) DEFAULT 'CAMARA_PESSOAL_GABINETE',
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            CONSTRAINT unique_staff_member UNIQUE (deputy_id, normalized_name, start_date)
+        )
+        '''),
+        ('nepotism_flags', '''
+        CREATE TABLE nepotism_flags (
+            id SERIAL PRIMARY KEY,
+            staff_id INTEGER NOT NULL REFERENCES parliamentary_staff(id) ON DELETE CASCADE,
+            employer_politician_id INTEGER NOT NULL REFERENCES unified_politicians(id) ON DELETE CASCADE,
+            related_politician_id INTEGER NOT NULL REFERENCES unified_politicians(id) ON DELETE CASCADE,
+            match_type VARCHAR(20) NOT NULL,
+            shared_surnames VARCHAR(255) NOT NULL,
+            score DECIMAL(3,2) NOT NULL,
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            CONSTRAINT unique_nepotism_flag UNIQUE (staff_id, related_politician_id)
+        )
+        '''),
+        ('tcu_rulings', '''
+        CREATE TABLE tcu_rulings (
+            id SERIAL PRIMARY KEY,
+            ruling_key VARCHAR(100) NOT NULL,
+            numero INTEGER,
+            ano INTEGER,
+            colegiado VARCHAR(50),
+            relator VARCHAR(255),
+            session_date DATE,
+            titulo TEXT,
+            sumario TEXT,
+            url TEXT,
+            document_url TEXT,
+            data_source VARCHAR(50) DEFAULT 'TCU_DADOS_ABERTOS',
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            CONSTRAINT unique_tcu_ruling UNIQUE (ruling_key)
+        )
+        '''),
+        ('tcu_ruling_parties', '''
+        CREATE TABLE tcu_ruling_parties (
+            id SERIAL PRIMARY KEY,
+            ruling_id INTEGER NOT NULL REFERENCES tcu_rulings(id) ON DELETE CASCADE,
+            cnpj_cpf VARCHAR(14) NOT NULL,
+            entity_type VARCHAR(20) NOT NULL,
+            politician_id INTEGER REFERENCES unified_politicians(id) ON DELETE CASCADE,
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            CONSTRAINT unique_tcu_ruling_party UNIQUE (ruling_id, cnpj_cpf)
+        )
+        '''),
+        ('public_loans', '''
+        CREATE TABLE public_loans (
+            id SERIAL PRIMARY KEY,
+            lender VARCHAR(20) NOT NULL,
+            loan_key VARCHAR(64) NOT NULL,
+            cnpj VARCHAR(14) NOT NULL,
+            client_name VARCHAR(255),
+            uf VARCHAR(2),
+            municipality VARCHAR(100),
+            contract_number VARCHAR(50),
+            contract_date DATE,
+            contracted_value DECIMAL(15,2),
+            disbursed_value DECIMAL(15,2),
+            product VARCHAR(100),
+            instrument VARCHAR(255),
+            support_type VARCHAR(50),
+            financial_agent VARCHAR(255),
+            status VARCHAR(50),
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            CONSTRAINT unique_public_loan UNIQUE (lender, loan_key)
+        )
+        '''),
+        ('ibge_areas', '''
+        CREATE TABLE ibge_areas (
+            ibge_code VARCHAR(7) PRIMARY KEY,       -- 2 digits for states, 7 for municipalities
+            level VARCHAR(20) NOT NULL,             -- state, municipality
+            name VARCHAR(255) NOT NULL,
+            normalized_name VARCHAR(255),
+            uf VARCHAR(2),
+            geometry TEXT,                          -- GeoJSON geometry (IBGE malhas, minimal quality)
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+        )
+        '''),
+        ('parliamentary_amendments', '''
+        CREATE TABLE parliamentary_amendments (
+            id SERIAL PRIMARY KEY,
+            amendment_code VARCHAR(30) NOT NULL,
+            year INTEGER NOT NULL,
+            amendment_type VARCHAR(100),
+            amendment_number VARCHAR(20),
+            author_name VARCHAR(255),
+            politician_id INTEGER REFERENCES unified_politicians(id) ON DELETE SET NULL,
+            locality VARCHAR(255),
+            ibge_code VARCHAR(7),                   -- destination state or municipality, NULL if national
+            function_name VARCHAR(100),
+            subfunction_name VARCHAR(100),
+            committed_value DECIMAL(15,2),
+            liquidated_value DECIMAL(15,2),
+            paid_value DECIMAL(15,2),
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            CONSTRAINT unique_amendment_code UNIQUE (amendment_code)
+        )
+        '''),
+        ('expense_anomalies', '''
+        CREATE TABLE expense_anomalies (
+            id SERIAL PRIMARY KEY,
+            anomaly_type VARCHAR(30) NOT NULL,      -- geo_mismatch, duplicate
+            politician_id INTEGER NOT NULL REFERENCES unified_politicians(id) ON DELETE CASCADE,
+            cnpj_cpf VARCHAR(14),
+            counterpart_name VARCHAR(255),
+            record_count INTEGER NOT NULL,
+            total_amount DECIMAL(15,2),
+            first_date DATE,
+            last_date DATE,
+            details JSONB,                          -- evidence, specific to the type
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+        )
         ''')
     ]
 
@@ -650,7 +934,9 @@ def recreate_all_tables():
         "CREATE INDEX idx_financial_politician_year ON unified_financial_records(politician_id, year)",
         "CREATE INDEX idx_financial_counterpart_cnpj ON unified_financial_records(counterpart_cnpj_cpf)",
         "CREATE INDEX idx_counterparts_cnpj ON financial_counterparts(cnpj_cpf)",
+        "CREATE INDEX idx_counterparts_cnae ON financial_counterparts(cnae_section, cnae_code)",
         "CREATE INDEX idx_networks_politician ON unified_political_networks(politician_id, network_type)",
+        "CREATE INDEX idx_networks_type_id ON unified_political_networks(network_type, network_id)",
         "CREATE INDEX idx_wealth_politician_year ON unified_wealth_tracking(politician_id, year)",
         "CREATE INDEX idx_career_politician ON politician_career_history(politician_id)",
         "CREATE INDEX idx_events_politician ON politician_events(politician_id)",
@@ -669,6 +955,28 @@ def recreate_all_tables():
         "CREATE INDEX idx_party_memberships_party ON party_memberships(party_id)",
         "CREATE INDEX idx_party_memberships_deputy ON party_memberships(deputy_id)",
         "CREATE INDEX idx_party_memberships_legislatura ON party_memberships(legislatura_id)",
+        "CREATE INDEX idx_wikidata_qid ON politician_wikidata(qid)",
+        "CREATE INDEX idx_party_funds_sigla_year ON party_funds(party_sigla, year)",
+        "CREATE INDEX idx_party_history_politician ON party_membership_history(politician_id, start_date)",
+        "CREATE INDEX idx_party_history_party ON party_membership_history(party_sigla)",
+        "CREATE INDEX idx_company_partners_root ON company_partners(cnpj_root)",
+        "CREATE INDEX idx_plenary_attendance_politician ON plenary_attendance(politician_id, session_date)",
+        "CREATE INDEX idx_plenary_attendance_session ON plenary_attendance(session_id)",
+        "CREATE INDEX idx_politicians_absence_rate ON unified_politicians(plenary_absence_rate)",
+        "CREATE INDEX idx_speeches_politician ON politician_speeches(politician_id, speech_datetime)",
+        "CREATE INDEX idx_speech_topics_slug ON speech_topics(topic_slug)",
+        "CREATE INDEX idx_staff_politician ON parliamentary_staff(politician_id)",
+        "CREATE INDEX idx_nepotism_employer ON nepotism_flags(employer_politician_id)",
+        "CREATE INDEX idx_nepotism_related ON nepotism_flags(related_politician_id)",
+        "CREATE INDEX idx_tcu_rulings_session ON tcu_rulings(session_date)",
+        "CREATE INDEX idx_tcu_ruling_parties_document ON tcu_ruling_parties(cnpj_cpf)",
+        "CREATE INDEX idx_tcu_ruling_parties_politician ON tcu_ruling_parties(politician_id)",
+        "CREATE INDEX idx_public_loans_cnpj ON public_loans(cnpj, contract_date)",
+        "CREATE INDEX idx_counterparts_ibge ON financial_counterparts(municipality_ibge_code)",
+        "CREATE INDEX idx_ibge_areas_level ON ibge_areas(level, uf)",
+        "CREATE INDEX idx_amendments_ibge ON parliamentary_amendments(ibge_code, year)",
+        "CREATE INDEX idx_amendments_politician ON parliamentary_amendments(politician_id)",
+        "CREATE INDEX idx_expense_anomalies_type ON expense_anomalies(anomaly_type, politician_id)",
         # Enhanced field indexes
         "CREATE INDEX idx_politicians_corruption_risk ON unified_politicians(corruption_risk_score)",
         "CREATE INDEX idx_politicians_tcu_disqualifications ON unified_politicians(tcu_disqualifications_total)",
@@ -677,10 +985,27 @@ def recreate_all_tables():
         "CREATE INDEX idx_politicians_career_span ON unified_politicians(career_span_years)",
         "CREATE INDEX idx_politicians_wealth_growth ON unified_politicians(wealth_total_growth)",
         "CREATE INDEX idx_politicians_electoral_success ON unified_politicians(electoral_success_rate)",
+        "CREATE INDEX idx_politicians_last_election ON unified_politicians(last_election_year, last_election_votes)",
         "CREATE INDEX idx_politicians_corruption_composite ON unified_politicians(corruption_risk_score, tcu_disqualifications_total, sanctioned_vendors_count)",
-        "CREATE INDEX idx_politicians_family_network_composite ON unified_politicians(family_senators_count, family_deputies_count, total_political_networks)"
+        "CREATE INDEX idx_politicians_family_network_composite ON unified_politicians(family_senators_count, family_deputies_count, total_political_networks)",
+        # Trigram indexes on normalized names for prefix lookups (/api/search/suggest without a search index)
+        "CREATE INDEX idx_politicians_nome_eleitoral_trgm ON unified_politicians USING gin (normalize_text(nome_eleitoral) gin_trgm_ops)",
+        "CREATE INDEX idx_politicians_nome_civil_trgm ON unified_politicians USING gin (normalize_text(nome_civil) gin_trgm_ops)",
+        "CREATE INDEX idx_counterparts_name_trgm ON financial_counterparts USING gin (normalize_text(name) gin_trgm_ops)",
+        "CREATE INDEX idx_counterparts_trade_name_trgm ON financial_counterparts USING gin (normalize_text(trade_name) gin_trgm_ops)"
     ]
 
+    # normalize_text(): accent-free, uppercase, single-spaced, matching normalize_text in
+    # src/validation/normalization.py and utils.NormalizeText in the API. unaccent is wrapped
+    # with its dictionary spelled out so the function is immutable and can be indexed.
+    cursor.execute("CREATE EXTENSION IF NOT EXISTS pg_trgm")
+    cursor.execute("CREATE EXTENSION IF NOT EXISTS unaccent")
+    cursor.execute(r"""
+        CREATE OR REPLACE FUNCTION normalize_text(text) RETURNS text
+        LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT AS $$
+            SELECT btrim(regexp_replace(upper(public.unaccent('public.unaccent'::regdictionary, $1)), '\s+', ' ', 'g'))
+        $$
+    """)
     for index_sql in indexes:
         cursor.execute(index_sql)
 
@@ -706,6 +1031,10 @@ def recreate_all_tables():
     cursor.execute(enhanced_constraints_sql)
     print("✓ Applied enhanced validation constraints")
 
+    # Provenance: source, source_record_id and fetched_at on every ingested table
+    ensure_provenance_columns(cursor)
+    print("✓ Added provenance columns")
+
     # Commit changes and close connection
     conn.commit()
     cursor.close()
@@ -727,6 +1056,22 @@ def recreate_all_tables():
     print("12. ✅ senado_politicians - UNIQUE on (codigo)")
     print("13. ✅ political_parties - UNIQUE on (id, legislatura_id)")
     print("14. ✅ party_memberships - UNIQUE on (party_id, deputy_id, legislatura_id)")
+    print("15. ✅ ipca_index - PRIMARY KEY on (reference_month)")
+    print("16. ✅ politician_wikidata - PRIMARY KEY on (politician_id)")
+    print("17. ✅ party_funds - UNIQUE on (party_sigla, year, month, fund_type)")
+    print("18. ✅ party_membership_history - UNIQUE on (deputy_id, party_sigla, start_date)")
+    print("19. ✅ company_partners - UNIQUE on (cnpj_root, partner_name, partner_document, qualification_code)")
+    print("20. ✅ plenary_attendance - UNIQUE on (deputy_id, session_id)")
+    print("21. ✅ politician_speeches - UNIQUE on (deputy_id, speech_datetime)")
+    print("22. ✅ speech_topics - UNIQUE on (speech_id, topic_slug)")
+    print("23. ✅ parliamentary_staff - UNIQUE on (deputy_id, normalized_name, start_date)")
+    print("24. ✅ nepotism_flags - UNIQUE on (staff_id, related_politician_id)")
+    print("25. ✅ tcu_rulings - UNIQUE on (ruling_key)")
+    print("26. ✅ tcu_ruling_parties - UNIQUE on (ruling_id, cnpj_cpf)")
+    print("27. ✅ public_loans - UNIQUE on (lender, loan_key)")
+    print("28. ✅ ibge_areas - PRIMARY KEY on ibge_code")
+    print("29. ✅ parliamentary_amendments - UNIQUE on amendment_code")
+    print("30. ✅ expense_anomalies - replaced per anomaly_type by detect-anomalies")
     print("\n🚀 ENHANCED FEATURES READY:")
     print("✅ Corruption Detection: TCU disqualifications + vendor sanctions correlation")
     print("✅ Family Networks: Cross-chamber surname analysis (Senate + Chamber)")
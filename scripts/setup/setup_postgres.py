@@ -7,8 +7,13 @@ Creates all 13 unified schema tables for complete data integration
 import psycopg2
 import psycopg2.extras
 import os
+import sys
+from pathlib import Path
 from datetime import datetime
 
+sys.path.insert(0, str(Path(__file__).resolve().parents[2]))
+from cli4.modules.provenance import ensure_provenance_columns
+
 def create_unified_postgres_database():
     """
     Create the complete unified political transparency database in PostgreSQL
@@ -559,6 +564,7 @@ def create_unified_postgres_database():
         "CREATE INDEX IF NOT EXISTS idx_financial_counterpart_cnpj ON unified_financial_records(counterpart_cnpj_cpf)",
         "CREATE INDEX IF NOT EXISTS idx_counterparts_cnpj ON financial_counterparts(cnpj_cpf)",
         "CREATE INDEX IF NOT EXISTS idx_networks_politician ON unified_political_networks(politician_id, network_type)",
+        "CREATE INDEX IF NOT EXISTS idx_networks_type_id ON unified_political_networks(network_type, network_id)",
         "CREATE INDEX IF NOT EXISTS idx_sanctions_cnpj ON vendor_sanctions(cnpj_cpf)",
         "CREATE INDEX IF NOT EXISTS idx_sanctions_active ON vendor_sanctions(is_active)",
         "CREATE INDEX IF NOT EXISTS idx_wealth_politician_year ON unified_wealth_tracking(politician_id, year)",
@@ -575,7 +581,12 @@ def create_unified_postgres_database():
         "CREATE INDEX IF NOT EXISTS idx_parties_legislatura ON political_parties(legislatura_id)",
         "CREATE INDEX IF NOT EXISTS idx_party_memberships_party ON party_memberships(party_id)",
         "CREATE INDEX IF NOT EXISTS idx_party_memberships_deputy ON party_memberships(deputy_id)",
-        "CREATE INDEX IF NOT EXISTS idx_party_memberships_legislatura ON party_memberships(legislatura_id)"
+        "CREATE INDEX IF NOT EXISTS idx_party_memberships_legislatura ON party_memberships(legislatura_id)",
+        # Trigram indexes on normalized names for prefix lookups (/api/search/suggest without a search index)
+        "CREATE INDEX IF NOT EXISTS idx_politicians_nome_eleitoral_trgm ON unified_politicians USING gin (normalize_text(nome_eleitoral) gin_trgm_ops)",
+        "CREATE INDEX IF NOT EXISTS idx_politicians_nome_civil_trgm ON unified_politicians USING gin (normalize_text(nome_civil) gin_trgm_ops)",
+        "CREATE INDEX IF NOT EXISTS idx_counterparts_name_trgm ON financial_counterparts USING gin (normalize_text(name) gin_trgm_ops)",
+        "CREATE INDEX IF NOT EXISTS idx_counterparts_trade_name_trgm ON financial_counterparts USING gin (normalize_text(trade_name) gin_trgm_ops)"
     ]
 
     # Add unique constraints to prevent duplicates
@@ -594,6 +605,17 @@ def create_unified_postgres_database():
         "CREATE UNIQUE INDEX IF NOT EXISTS idx_party_memberships_unique ON party_memberships(party_id, deputy_id, legislatura_id)"
     ]
 
+    # normalize_text(): accent-free, uppercase, single-spaced, matching normalize_text in
+    # src/validation/normalization.py and utils.NormalizeText in the API. unaccent is wrapped
+    # with its dictionary spelled out so the function is immutable and can be indexed.
+    cursor.execute("CREATE EXTENSION IF NOT EXISTS pg_trgm")
+    cursor.execute("CREATE EXTENSION IF NOT EXISTS unaccent")
+    cursor.execute(r"""
+        CREATE OR REPLACE FUNCTION normalize_text(text) RETURNS text
+        LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT AS $$
+            SELECT btrim(regexp_replace(upper(public.unaccent('public.unaccent'::regdictionary, $1)), '\s+', ' ', 'g'))
+        $$
+    """)
     for index_sql in indexes:
         cursor.execute(index_sql)
 
@@ -621,6 +643,8 @@ def create_unified_postgres_database():
     ADD COLUMN IF NOT EXISTS sanctioned_vendors_count INTEGER DEFAULT 0,
     ADD COLUMN IF NOT EXISTS sanctioned_vendors_total_sanctions INTEGER DEFAULT 0,
     ADD COLUMN IF NOT EXISTS sanctioned_vendors_amount DECIMAL(15,2) DEFAULT 0.0,
+    ADD COLUMN IF NOT EXISTS geo_mismatch_vendors INTEGER DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS geo_mismatch_amount DECIMAL(15,2) DEFAULT 0.0,
     ADD COLUMN IF NOT EXISTS corruption_risk_score DECIMAL(5,2) DEFAULT 0.0,
 
     -- FAMILY NETWORK ANALYSIS
@@ -646,6 +670,17 @@ def create_unified_postgres_database():
     ADD COLUMN IF NOT EXISTS parliamentary_event_types INTEGER DEFAULT 0,
     ADD COLUMN IF NOT EXISTS parliamentary_attendance_rate DECIMAL(5,2) DEFAULT 0.0,
 
+    -- PLENARY ATTENDANCE (deliberative sessions)
+    ADD COLUMN IF NOT EXISTS plenary_sessions_total INTEGER DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS plenary_sessions_present INTEGER DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS plenary_absence_rate DECIMAL(5,2),
+
+    -- LATEST ELECTION (populate-electoral-results)
+    ADD COLUMN IF NOT EXISTS last_election_votes INTEGER,
+    ADD COLUMN IF NOT EXISTS last_election_elected BOOLEAN,
+    ADD COLUMN IF NOT EXISTS last_election_coalition VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS last_election_spending DECIMAL(15,2),
+
     -- WEALTH PROGRESSION METRICS
     ADD COLUMN IF NOT EXISTS wealth_declarations_count INTEGER DEFAULT 0,
     ADD COLUMN IF NOT EXISTS wealth_first_year INTEGER,
@@ -654,6 +689,8 @@ def create_unified_postgres_database():
     ADD COLUMN IF NOT EXISTS wealth_highest_declared DECIMAL(15,2),
     ADD COLUMN IF NOT EXISTS wealth_average_declared DECIMAL(15,2),
     ADD COLUMN IF NOT EXISTS wealth_total_growth DECIMAL(15,2) DEFAULT 0.0,
+    ADD COLUMN IF NOT EXISTS wealth_max_growth_pct DECIMAL(10,2),
+    ADD COLUMN IF NOT EXISTS wealth_unusual_growth BOOLEAN DEFAULT FALSE,
     ADD COLUMN IF NOT EXISTS asset_types_diversity INTEGER DEFAULT 0,
     ADD COLUMN IF NOT EXISTS total_individual_assets INTEGER DEFAULT 0,
     ADD COLUMN IF NOT EXISTS total_individual_asset_value DECIMAL(15,2) DEFAULT 0.0,
@@ -672,6 +709,7 @@ def create_unified_postgres_database():
         "CREATE INDEX IF NOT EXISTS idx_politicians_career_span ON unified_politicians(career_span_years)",
         "CREATE INDEX IF NOT EXISTS idx_politicians_wealth_growth ON unified_politicians(wealth_total_growth)",
         "CREATE INDEX IF NOT EXISTS idx_politicians_electoral_success ON unified_politicians(electoral_success_rate)",
+        "CREATE INDEX IF NOT EXISTS idx_politicians_last_election ON unified_politicians(last_election_year, last_election_votes)",
         "CREATE INDEX IF NOT EXISTS idx_politicians_corruption_composite ON unified_politicians(corruption_risk_score, tcu_disqualifications_total, sanctioned_vendors_count)",
         "CREATE INDEX IF NOT EXISTS idx_politicians_family_network_composite ON unified_politicians(family_senators_count, family_deputies_count, total_political_networks)"
     ]
@@ -704,6 +742,10 @@ def create_unified_postgres_database():
         print("✓ Enhanced validation constraints already exist")
         conn.rollback()
 
+    # Provenance: source, source_record_id and fetched_at on every ingested table
+    ensure_provenance_columns(cursor)
+    print("✓ Added provenance columns")
+
     # Commit changes and close connection
     conn.commit()
     cursor.close()
@@ -8,6 +8,7 @@ from .senado_client import SenadoClient
 from .portal_transparencia_client import PortalTransparenciaClient
 from .tcu_client import TCUClient
 from .datajud_client import DataJudClient
+from .bndes_client import BNDESClient
 
 __all__ = [
     'DeputadosClient',
@@ -15,5 +16,6 @@ __all__ = [
     'SenadoClient',
     'PortalTransparenciaClient',
     'TCUClient',
-    'DataJudClient'
+    'DataJudClient',
+    'BNDESClient'
 ]
\ No newline at end of file
@@ -0,0 +1,51 @@
+"""
+BNDES (Banco Nacional de Desenvolvimento Econômico e Social) Open Data Client
+Financing operations from https://dadosabertos.bndes.gov.br/ (CKAN).
+
+Direct and indirect non-automatic operations and indirect automatic operations are
+published as semicolon-separated CSVs with one row per contract.
+"""
+
+import csv
+import io
+import requests
+from typing import Dict, Iterator, List
+
+
+class BNDESClient:
+    """Client for the BNDES open data portal"""
+
+    OPERATIONS_PACKAGE = 'operacoes-financiamento'
+
+    def __init__(self):
+        self.api_base = "https://dadosabertos.bndes.gov.br/api/3/action/"
+        self.session = requests.Session()
+        self.session.headers.update({
+            'User-Agent': 'Brazilian-Political-Network-Analyzer/1.0'
+        })
+
+    def list_operation_resources(self) -> List[Dict]:
+        """CSV resources of the financing operations package ({'name', 'url'})"""
+        response = self.session.get(
+            f"{self.api_base}package_show", params={'id': self.OPERATIONS_PACKAGE}, timeout=60
+        )
+        response.raise_for_status()
+
+        data = response.json()
+        if not data.get('success'):
+            raise Exception(f"Failed to get package {self.OPERATIONS_PACKAGE}: {data}")
+
+        return [
+            {'name': r.get('name') or '', 'url': r.get('url')}
+            for r in data['result'].get('resources', [])
+            if (r.get('format') or '').lower() == 'csv' and r.get('url')
+        ]
+
+    def iter_operations(self, url: str) -> Iterator[Dict[str, str]]:
+        """Stream the rows of an operations CSV without loading the whole file"""
+        with self.session.get(url, stream=True, timeout=300) as response:
+            response.raise_for_status()
+            response.raw.decode_content = True
+            text = io.TextIOWrapper(response.raw, encoding='utf-8-sig', errors='replace', newline='')
+            for row in csv.DictReader(text, delimiter=';'):
+                yield {(k or '').strip().lower(): (v or '').strip() for k, v in row.items()}
@@ -11,6 +11,8 @@ from typing import Dict, List, Any, Optional
 from datetime import datetime
 import re
 
+from ..validation.normalization import normalize_name
+
 
 class DataJudClient:
     """
@@ -177,19 +179,8 @@ class DataJudClient:
         if not name:
             return ""
 
-        # Convert to uppercase (common in judicial systems)
-        name = name.upper()
-
-        # Remove titles
-        name = re.sub(r'\b(DEPUTADO|DEPUTADA|SENADOR|SENADORA|DR\.?|DRA\.?)\b', '', name)
-
-        # Remove special characters but keep spaces
-        name = re.sub(r'[^\w\s]', '', name)
-
-        # Normalize whitespace
-        name = re.sub(r'\s+', ' ', name).strip()
-
-        return name
+        # Uppercase (common in judicial systems), accent-free, without titles or punctuation
+        return normalize_name(name, drop_honorifics=True)
 
     def analyze_politician_judicial_exposure(self, politician_data: Dict) -> Dict[str, Any]:
         """
@@ -12,6 +12,8 @@ from datetime import datetime, date
 import time
 import re
 
+from ..validation import normalization
+
 
 class DeputadosClient:
     """
@@ -144,10 +146,61 @@ class DeputadosClient:
             deputy_id: Deputy ID
 
         Returns:
-            List of parliamentary front membership records
+            List of parliamentary front membership records (all pages), each with id,
+            titulo and idLegislatura
         """
-        response = self._make_request(f"deputados/{deputy_id}/frentes")
-        return response.get('dados', [])
+        fronts = []
+        page = 1
+        while True:
+            response = self._make_request(f"deputados/{deputy_id}/frentes", {'itens': 100, 'pagina': page})
+            fronts.extend(response.get('dados', []))
+            if not any(link.get('rel') == 'next' for link in response.get('links', [])):
+                return fronts
+            page += 1
+
+    def get_deputy_staff(self, deputy_id: int, year: Optional[int] = None) -> List[Dict[str, Any]]:
+        """
+        Get the deputy's cabinet staff (secretários parlamentares). The open data API has no
+        staff endpoint, so this reads the roster table of the deputy's page on camara.leg.br,
+        which publishes names and positions but no CPF.
+
+        Args:
+            deputy_id: Deputy ID
+            year: Roster year (default: current)
+
+        Returns:
+            List of staff records with nome, grupo, cargo and periodo (as published, e.g.
+            "01/02/2023 a 31/12/2023" or "Desde 01/02/2023")
+        """
+        from bs4 import BeautifulSoup
+
+        url = f"https://www.camara.leg.br/deputados/{deputy_id}/pessoal-gabinete"
+        try:
+            time.sleep(self.rate_limit_delay)
+            response = self.session.get(url, params={'ano': year} if year else {},
+                                        headers={'Accept': 'text/html'})
+            response.raise_for_status()
+        except requests.exceptions.RequestException as e:
+            raise Exception(f"Staff page request failed for deputy {deputy_id}: {str(e)}")
+
+        soup = BeautifulSoup(response.text, 'html.parser')
+        staff = []
+        for table in soup.find_all('table'):
+            headers = [self.normalize_name(th.get_text(' ', strip=True)) for th in table.find_all('th')]
+            if 'NOME' not in headers:
+                continue
+            for row in table.find_all('tr'):
+                cells = [td.get_text(' ', strip=True) for td in row.find_all('td')]
+                if len(cells) != len(headers):
+                    continue
+                record = dict(zip(headers, cells))
+                staff.append({
+                    'nome': record.get('NOME', ''),
+                    'grupo': record.get('GRUPO FUNCIONAL', ''),
+                    'cargo': record.get('CARGO', ''),
+                    'periodo': record.get('PERÍODO DE EXERCÍCIO', record.get('PERIODO DE EXERCICIO', '')),
+                })
+        return staff
 
     def get_deputy_external_mandates(self, deputy_id: int) -> List[Dict[str, Any]]:
         """
@@ -162,6 +215,60 @@ class DeputadosClient:
         response = self._make_request(f"deputados/{deputy_id}/mandatosExternos")
         return response.get('dados', [])
 
+    def get_deputy_history(self, deputy_id: int) -> List[Dict[str, Any]]:
+        """
+        Get deputy status history (party, state and situation changes)
+
+        Args:
+            deputy_id: Deputy ID
+
+        Returns:
+            List of status records, each with dataHora, siglaPartido and descricaoStatus
+        """
+        response = self._make_request(f"deputados/{deputy_id}/historico")
+        return response.get('dados', [])
+
+    def get_plenary_sessions(self, start_date: str, end_date: str) -> List[Dict[str, Any]]:
+        """
+        Get every plenary (órgão 180) event between two dates, following pagination
+
+        Args:
+            start_date: Start date (YYYY-MM-DD)
+            end_date: End date (YYYY-MM-DD)
+
+        Returns:
+            List of events, each with id, dataHoraInicio, descricaoTipo and situacao
+        """
+        events = []
+        page = 1
+        while True:
+            response = self._make_request("eventos", {
+                'idOrgao': 180,
+                'dataInicio': start_date,
+                'dataFim': end_date,
+                'ordem': 'ASC',
+                'ordenarPor': 'dataHoraInicio',
+                'itens': 100,
+                'pagina': page
+            })
+            events.extend(response.get('dados', []))
+            if not any(link.get('rel') == 'next' for link in response.get('links', [])):
+                return events
+            page += 1
+
+    def get_event_attendance(self, event_id: int) -> List[Dict[str, Any]]:
+        """
+        Get the deputies registered as present at an event
+
+        Args:
+            event_id: Event ID
+
+        Returns:
+            List of deputy basic information
+        """
+        response = self._make_request(f"eventos/{event_id}/deputados")
+        return response.get('dados', [])
+
     def get_deputy_events(self, deputy_id: int, start_date: Optional[str] = None,
                          end_date: Optional[str] = None) -> List[Dict[str, Any]]:
         """
@@ -221,16 +328,24 @@ class DeputadosClient:
             end_date: End date filter (YYYY-MM-DD format)
 
         Returns:
-            List of discourse records
+            List of discourse records (all pages), each with dataHoraInicio, faseEvento,
+            tipoDiscurso, keywords, sumario, transcricao and urlTexto
         """
-        params = {}
+        params = {'itens': 100, 'ordem': 'ASC', 'ordenarPor': 'dataHoraInicio'}
         if start_date:
             params['dataInicio'] = start_date
         if end_date:
             params['dataFim'] = end_date
 
-        response = self._make_request(f"deputados/{deputy_id}/discursos", params)
-        return response.get('dados', [])
+        discourses = []
+        page = 1
+        while True:
+            params['pagina'] = page
+            response = self._make_request(f"deputados/{deputy_id}/discursos", params)
+            discourses.extend(response.get('dados', []))
+            if not any(link.get('rel') == 'next' for link in response.get('links', [])):
+                return discourses
+            page += 1
 
     def get_deputy_complete_profile(self, deputy_id: int,
                                   include_financial: bool = True,
@@ -428,10 +543,4 @@ class DeputadosClient:
         if not name:
             return ""
 
-        # Remove common titles and normalize
-        name = name.upper()
-        name = re.sub(r'\b(DR\.?|DRA\.?|PROF\.?|PROFA\.?|SR\.?|SRA\.?)\b', '', name)
-        name = re.sub(r'[^\w\s]', '', name)
-        name = re.sub(r'\s+', ' ', name).strip()
-
-        return name
\ No newline at end of file
+        return normalization.normalize_name(name, drop_honorifics=True)
\ No newline at end of file
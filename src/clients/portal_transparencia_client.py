@@ -146,6 +146,25 @@ class PortalTransparenciaClient:
             print(f"Error fetching nepotism register: {e}")
             return {}
 
+    def get_amendments(self, year: int, author: Optional[str] = None, page: int = 1) -> Dict[str, Any]:
+        """
+        Get parliamentary amendments (emendas) of a budget year, with where the money goes
+        (localidadeDoGasto) and the committed, liquidated and paid values
+        """
+        url = f"{self.base_url}emendas"
+        params = {'ano': year, 'pagina': page}
+
+        if author:
+            params['nomeAutor'] = author
+
+        try:
+            response = self.session.get(url, params=params)
+            response.raise_for_status()
+            return response.json()
+        except Exception as e:
+            print(f"Error fetching amendments: {e}")
+            return {}
+
     def analyze_politician_vendor_network(self, vendor_cnpjs: List[str], politician_name: str = "Unknown") -> Dict[str, Any]:
         """
         Analyze a politician's vendor network against government transparency data
@@ -12,6 +12,8 @@ import json
 from datetime import datetime
 import re
 
+from ..validation.normalization import normalize_name
+
 
 class SenadoClient:
     """
@@ -126,12 +128,7 @@ class SenadoClient:
         if not name:
             return ""
 
-        name = name.upper()
-        name = re.sub(r'\b(SENADOR|SENADORA|DR\.?|DRA\.?|PROF\.?)\b', '', name)
-        name = re.sub(r'[^\w\s]', '', name)
-        name = re.sub(r'\s+', ' ', name).strip()
-
-        return name
+        return normalize_name(name, drop_honorifics=True)
 
     def get_senator_votacoes(self, codigo_parlamentar: str, year: Optional[int] = None) -> List[Dict]:
         """Get voting records for a senator"""
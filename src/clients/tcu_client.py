@@ -72,6 +72,28 @@ class TCUClient:
             print(f"Error fetching acordãos: {e}")
             return {}
 
+    def list_acordaos(self, inicio: int = 0, quantidade: int = 100) -> List[Dict[str, Any]]:
+        """
+        Page through TCU rulings (acórdãos), most recent first
+        Each item carries key, numeroAcordao, anoAcordao, colegiado, relator, dataSessao, titulo,
+        sumario and the urlAcordao/urlArquivo/urlArquivoPdf links to the original documents
+        """
+        url = f"{self.dados_abertos_url}acordao/recupera-acordaos"
+
+        response = self.session.get(url, params={'inicio': inicio, 'quantidade': quantidade}, timeout=60)
+        response.raise_for_status()
+        data = response.json()
+        return data if isinstance(data, list) else data.get('items', [])
+
+    def get_acordao_text(self, document_url: str) -> str:
+        """
+        Download the full text of a ruling (RTF/HTML document) for CNPJ/CPF extraction
+        Markup is left in place; callers only look for document numbers
+        """
+        response = self.session.get(document_url, timeout=60, headers={'Accept': '*/*'})
+        response.raise_for_status()
+        return response.content.decode(response.encoding or 'latin-1', errors='ignore')
+
     def get_congressional_requests(self, year: Optional[int] = None) -> Dict[str, Any]:
         """
         Get congressional requests to TCU
@@ -17,6 +17,8 @@ import re
 from urllib.parse import urljoin
 import time
 
+from ..validation.normalization import normalize_name
+
 
 class TSEClient:
     """
@@ -505,15 +507,8 @@ class TSEClient:
         if not name:
             return ""
 
-        name = name.upper()
-        # Remove common titles
-        name = re.sub(r'\b(DR\.?|DRA\.?|PROF\.?|DEPUTADO|DEPUTADA)\b', '', name)
-        # Remove special characters
-        name = re.sub(r'[^\w\s]', '', name)
-        # Normalize whitespace
-        name = re.sub(r'\s+', ' ', name).strip()
-
-        return name
+        # Accent-free, uppercase, without titles or punctuation
+        return normalize_name(name, drop_honorifics=True)
 
     def _determine_election_success(self, electoral_outcome: str) -> bool:
         """
@@ -1280,6 +1275,94 @@ class TSEClient:
 
         return finance_record
 
+    def get_vote_totals(self, year: int, candidate_ids: Optional[set] = None) -> Dict[tuple, Dict[str, Any]]:
+        """
+        Get nominal vote totals per candidate and round from the election results
+        (votacao_candidato_munzona, one row per municipality and zone).
+        Returns {(SQ_CANDIDATO, NR_TURNO): {'votes': int, 'outcome': str}}, only for
+        candidate_ids when given.
+        """
+        print(f"=== TSE VOTE TOTALS ===")
+        print(f"Year: {year}")
+
+        package = f'resultados-{year}'
+        try:
+            resources = self.get_package_info(package).get('resources', [])
+        except Exception as e:
+            print(f"No results package {package}: {e}")
+            return {}
+
+        vote_resources = [
+            r for r in resources
+            if 'votacao_candidato_munzona' in (r.get('url') or '').lower()
+            or 'munzona' in (r.get('name') or '').lower()
+        ]
+        if not vote_resources:
+            print("No votacao_candidato_munzona resource found")
+            return {}
+
+        totals = {}
+        for resource in vote_resources:
+            download_url = resource.get('url') or ''
+            if download_url.startswith('URL: '):
+                download_url = download_url[5:]
+            if not download_url.startswith('http'):
+                download_url = urljoin(self.base_url, download_url)
+            if not download_url.endswith('.zip'):
+                continue
+
+            print(f"Downloading: {resource.get('name', 'Unknown')}")
+            try:
+                content = self._download_with_retry(download_url, timeout=300)
+                self._sum_zip_vote_totals(content, candidate_ids, totals)
+            except Exception as e:
+                print(f"  ✗ Error processing resource: {e}")
+                print(f"     Resource URL: {download_url}")
+                continue
+
+        print(f"Vote totals for {len(totals)} candidacies")
+        return totals
+
+    def _download_with_retry(self, download_url: str, timeout: int = 60, max_retries: int = 3) -> bytes:
+        """Download a resource, retrying connection errors with growing delays"""
+        for retry in range(max_retries):
+            try:
+                response = self.session.get(download_url, timeout=timeout)
+                response.raise_for_status()
+                return response.content
+            except (requests.ConnectionError, requests.Timeout, requests.exceptions.ChunkedEncodingError,
+                    requests.exceptions.HTTPError) as conn_err:
+                if retry < max_retries - 1:
+                    wait_time = (retry + 1) * 10
+                    print(f"  ⚠️ Connection error (attempt {retry + 1}/{max_retries}), retrying in {wait_time}s...")
+                    time.sleep(wait_time)
+                else:
+                    print(f"  ❌ Failed after {max_retries} attempts: {conn_err}")
+                    raise
+
+    def _sum_zip_vote_totals(self, zip_content: bytes, candidate_ids: Optional[set], totals: Dict[tuple, Dict[str, Any]]):
+        """Add up QT_VOTOS_NOMINAIS of a votacao_candidato_munzona ZIP into totals"""
+        with zipfile.ZipFile(io.BytesIO(zip_content), 'r') as zip_file:
+            csv_names = [n for n in zip_file.namelist() if n.lower().endswith('.csv')]
+            # Since 2014 the ZIP has a _BRASIL file repeating every state's rows
+            national = [n for n in csv_names if 'brasil' in n.lower()]
+            for file_name in national or csv_names:
+                with zip_file.open(file_name) as raw:
+                    reader = csv.DictReader(io.TextIOWrapper(raw, encoding='latin-1'), delimiter=';')
+                    for row in reader:
+                        candidate_id = (row.get('SQ_CANDIDATO') or '').strip()
+                        if not candidate_id or (candidate_ids is not None and candidate_id not in candidate_ids):
+                            continue
+                        try:
+                            round_number = int(row.get('NR_TURNO') or 1)
+                            votes = int(row.get('QT_VOTOS_NOMINAIS') or 0)
+                        except ValueError:
+                            continue
+
+                        entry = totals.setdefault((candidate_id, round_number), {'votes': 0, 'outcome': None})
+                        entry['votes'] += votes
+                        entry['outcome'] = (row.get('DS_SIT_TOT_TURNO') or '').strip() or entry['outcome']
+
     def get_deputy_electoral_history(self, deputy_name: str, deputy_state: str) -> Dict[str, Any]:
         """
         Get complete electoral history for a deputy - implements correlation strategy
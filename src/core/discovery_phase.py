@@ -11,6 +11,8 @@ from datetime import datetime, timedelta
 from typing import Dict, List, Any, Optional
 import json
 
+from ..validation import normalization
+
 
 # Brazilian Document Validation (minimal implementation for discovery)
 def validate_cpf(cpf: str) -> bool:
@@ -46,13 +48,7 @@ def normalize_name(name: str) -> str:
     if not name:
         return ""
 
-    # Remove common titles and normalize
-    name = name.upper()
-    name = re.sub(r'\b(DEPUTADO|DEPUTADA|DR\.?|DRA\.?|PROF\.?)\b', '', name)
-    name = re.sub(r'[^\w\s]', '', name)
-    name = re.sub(r'\s+', ' ', name).strip()
-
-    return name
+    return normalization.normalize_name(name, drop_honorifics=True)
 
 
 def extract_cnpjs(expenses: List[Dict]) -> set:
@@ -0,0 +1,3 @@
+"""
+Document validation and name normalization
+"""
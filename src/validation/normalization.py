@@ -0,0 +1,39 @@
+"""
+Name normalization shared by search, ETL entity matching and duplicate detection, so "José",
+"JOSE" and "jose " are the same entity everywhere.
+
+normalize_text is the canonical form: accents removed, uppercase, whitespace squashed. The API
+(utils.NormalizeText) and PostgreSQL (normalize_text(), created by the setup scripts) produce
+the same string, so values normalized here can be compared in SQL and in Go.
+"""
+
+import re
+import unicodedata
+from typing import Optional
+
+# Honorifics that prefix names in registries and ballot names
+HONORIFICS = frozenset("""
+DR DRA PROF PROFA SR SRA DEPUTADO DEPUTADA SENADOR SENADORA
+""".split())
+
+_PUNCTUATION = re.compile(r'[^\w\s]+')
+
+
+def strip_accents(text: Optional[str]) -> str:
+    """Remove diacritics: "São José" -> "Sao Jose" """
+    decomposed = unicodedata.normalize('NFKD', text or '')
+    return ''.join(c for c in decomposed if not unicodedata.combining(c))
+
+
+def normalize_text(text: Optional[str]) -> str:
+    """Canonical form: accent-free, uppercase, single-spaced, trimmed"""
+    return ' '.join(strip_accents(text).upper().split())
+
+
+def normalize_name(name: Optional[str], drop_honorifics: bool = False) -> str:
+    """normalize_text with punctuation turned into spaces, for comparing names word by word
+    ("D'ÁVILA" -> "D AVILA"); drop_honorifics also removes titles such as DR. or DEPUTADO"""
+    words = _PUNCTUATION.sub(' ', normalize_text(name)).replace('_', ' ').split()
+    if drop_honorifics:
+        words = [w for w in words if w not in HONORIFICS]
+    return ' '.join(words)